@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"voxelcraft.ai/internal/transport/wsauth"
+)
+
+// accountCmd manages the local WS account store (VC_WS_AUTH_ACCOUNTS_FILE). The server
+// reads the store at startup, so changes take effect on its next restart.
+func accountCmd(args []string) {
+	if len(args) == 0 || args[0] != "set" {
+		fmt.Fprintln(os.Stderr, "usage: admin account set -file <accounts.json> -user <account> [-name <display name>] -password-stdin")
+		os.Exit(2)
+	}
+	fs := flag.NewFlagSet("account set", flag.ExitOnError)
+	path := fs.String("file", os.Getenv("VC_WS_AUTH_ACCOUNTS_FILE"), "account store path (default: $VC_WS_AUTH_ACCOUNTS_FILE)")
+	user := fs.String("user", "", "account name")
+	name := fs.String("name", "", "display name (optional; empty keeps the current one)")
+	fromStdin := fs.Bool("password-stdin", false, "read the password from the first line of stdin")
+	_ = fs.Parse(args[1:])
+
+	if strings.TrimSpace(*path) == "" || strings.TrimSpace(*user) == "" {
+		fmt.Fprintln(os.Stderr, "missing -file or -user")
+		os.Exit(2)
+	}
+	// Passwords are never taken from argv, where they would show up in ps and shell history.
+	if !*fromStdin {
+		fmt.Fprintln(os.Stderr, "pass the password on stdin with -password-stdin")
+		os.Exit(2)
+	}
+	if err := setAccount(*path, *user, *name, os.Stdin); err != nil {
+		fmt.Fprintln(os.Stderr, "account:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("account %s set in %s\n", strings.TrimSpace(*user), *path)
+}
+
+// setAccount creates or rotates one account, reading its password from the first line of r.
+func setAccount(path, user, name string, r io.Reader) error {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("read password: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return fmt.Errorf("empty password")
+	}
+	s, err := wsauth.OpenAccounts(path)
+	if err != nil {
+		return err
+	}
+	return s.SetPassword(user, name, password)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/transport/wsauth"
+)
+
+func TestSetAccountCreatesAndRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	if err := setAccount(path, "alice", "Alice", strings.NewReader("first\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := setAccount(path, "alice", "", strings.NewReader("second\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := setAccount(path, "alice", "", strings.NewReader("\n")); err == nil {
+		t.Fatalf("empty password accepted")
+	}
+
+	s, err := wsauth.OpenAccounts(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Authenticate(&protocol.HelloAuth{Account: "alice", Password: "first"}, time.Now()); err == nil {
+		t.Fatalf("rotated password still accepted")
+	}
+	id, err := s.Authenticate(&protocol.HelloAuth{Account: "alice", Password: "second"}, time.Now())
+	if err != nil || id.Name != "Alice" {
+		t.Fatalf("id=%+v err=%v", id, err)
+	}
+}
@@ -32,6 +32,12 @@ func main() {
 		case "snapshot":
 			snapshotCmd(os.Args[2:])
 			return
+		case "flatten":
+			flattenCmd(os.Args[2:])
+			return
+		case "account":
+			accountCmd(os.Args[2:])
+			return
 		}
 	}
 	listCmd(os.Args[1:])
@@ -63,10 +69,16 @@ func rollbackCmd(args []string) {
 	dataDir := fs.String("data", "./data", "runtime data directory")
 	worldID := fs.String("world", "", "world id")
 	snapPath := fs.String("snapshot", "", "snapshot path to rollback from (optional; defaults to latest)")
-	aabb := fs.String("aabb", "", "AABB filter: x1,y1,z1:x2,y2,z2 (required)")
+	mode := fs.String("mode", "blocks", "rollback mode: blocks (audit SET_BLOCK in AABB) | replay (full state: re-simulate tick log without skipped actions)")
+	aabb := fs.String("aabb", "", "AABB filter: x1,y1,z1:x2,y2,z2 (required for -mode blocks)")
 	sinceTick := fs.Uint64("since_tick", 0, "rollback changes since tick (inclusive)")
 	toTick := fs.Uint64("to_tick", 0, "rollback changes up to tick (inclusive, optional; defaults to snapshot tick)")
 	outPath := fs.String("out", "", "output snapshot path (optional)")
+	configDir := fs.String("configs", "./configs", "config directory (-mode replay)")
+	tuningPath := fs.String("tuning", "", "path to tuning.yaml (-mode replay; default: <configs>/tuning.yaml)")
+	skipAgents := fs.String("skip_agents", "", "comma-separated agent ids whose acts are dropped from since_tick on (-mode replay)")
+	skipActions := fs.String("skip_actions", "", "comma-separated instant/task types dropped from since_tick on, e.g. TRANSFER,CLAIM_LAND (-mode replay)")
+	reportPath := fs.String("report", "", "diff report output path (-mode replay, optional)")
 	onlyIllegal := fs.Bool("only_illegal", false, "rollback only illegal operations (unsupported in v0.9; illegal edits are rejected before audit)")
 	_ = fs.Parse(args)
 
@@ -74,6 +86,14 @@ func rollbackCmd(args []string) {
 		fmt.Fprintln(os.Stderr, "missing -world")
 		os.Exit(2)
 	}
+	if *mode == "replay" {
+		replayRollbackCmd(*dataDir, *worldID, *snapPath, *configDir, *tuningPath, *sinceTick, *toTick, *skipAgents, *skipActions, *outPath, *reportPath)
+		return
+	}
+	if *mode != "blocks" {
+		fmt.Fprintln(os.Stderr, "bad -mode (want blocks|replay):", *mode)
+		os.Exit(2)
+	}
 	if strings.TrimSpace(*aabb) == "" {
 		fmt.Fprintln(os.Stderr, "missing -aabb")
 		os.Exit(2)
@@ -134,6 +154,76 @@ func rollbackCmd(args []string) {
 		filepath.Base(snapshotToLoad), snap.Header.Tick, *aabb, *sinceTick, endTick, len(recs), applied, skipped, *outPath)
 }
 
+func replayRollbackCmd(dataDir, worldID, snapPath, configDir, tuningPath string, sinceTick, toTick uint64, skipAgents, skipActions, outPath, reportPath string) {
+	o := replayRollbackOpts{
+		WorldDir:    filepath.Join(dataDir, "worlds", worldID),
+		ConfigDir:   configDir,
+		TuningPath:  tuningPath,
+		Snapshot:    strings.TrimSpace(snapPath),
+		SinceTick:   sinceTick,
+		ToTick:      toTick,
+		SkipAgents:  parseCSVSet(skipAgents),
+		SkipActions: parseCSVSet(skipActions),
+	}
+	if len(o.SkipAgents) == 0 && len(o.SkipActions) == 0 {
+		fmt.Fprintln(os.Stderr, "-mode replay needs -skip_agents and/or -skip_actions")
+		os.Exit(2)
+	}
+	if o.Snapshot == "" {
+		// Base must predate the incident: newest snapshot strictly before since_tick.
+		o.Snapshot = latestSnapshotBefore(o.WorldDir, sinceTick)
+	}
+	if o.Snapshot == "" {
+		fmt.Fprintf(os.Stderr, "no snapshot before tick %d; provide -snapshot\n", sinceTick)
+		os.Exit(2)
+	}
+	snap, rep, st, err := replayRollback(o)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rollback:", err)
+		os.Exit(1)
+	}
+	if strings.TrimSpace(outPath) == "" {
+		outPath = filepath.Join(o.WorldDir, "snapshots", fmt.Sprintf("%d.rollback.snap.zst", rep.ToTick))
+	}
+	if err := snapshot.WriteSnapshot(outPath, snap); err != nil {
+		fmt.Fprintln(os.Stderr, "write snapshot:", err)
+		os.Exit(1)
+	}
+	if strings.TrimSpace(reportPath) == "" {
+		reportPath = strings.TrimSuffix(outPath, ".snap.zst") + ".report.json"
+	}
+	b, _ := json.MarshalIndent(rep, "", "  ")
+	if err := os.WriteFile(reportPath, b, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "write report:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("rollback ok: mode=replay snapshot=%s from=%d since=%d to=%d ticks=%d skipped_acts=%d skipped_instants=%d skipped_tasks=%d agents_changed=%d containers_changed=%d out=%s report=%s\n",
+		rep.Snapshot, rep.FromTick, sinceTick, rep.ToTick, st.Ticks, st.SkippedActs, st.SkippedInstant, st.SkippedTasks, len(rep.Agents), len(rep.Containers), outPath, reportPath)
+}
+
+// flattenCmd materializes a V2 delta (plus its base) into a standalone full snapshot.
+func flattenCmd(args []string) {
+	fs := flag.NewFlagSet("flatten", flag.ExitOnError)
+	inPath := fs.String("snapshot", "", "snapshot path (full or .delta.snap.zst)")
+	outPath := fs.String("out", "", "output full snapshot path")
+	_ = fs.Parse(args)
+
+	if strings.TrimSpace(*inPath) == "" || strings.TrimSpace(*outPath) == "" {
+		fmt.Fprintln(os.Stderr, "missing -snapshot/-out")
+		os.Exit(2)
+	}
+	snap, err := snapshot.ReadSnapshot(*inPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "read snapshot:", err)
+		os.Exit(1)
+	}
+	if err := snapshot.WriteSnapshot(*outPath, snap); err != nil {
+		fmt.Fprintln(os.Stderr, "write snapshot:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("flatten ok: tick=%d chunks=%d agents=%d out=%s\n", snap.Header.Tick, len(snap.Chunks), len(snap.Agents), *outPath)
+}
+
 type auditRec struct {
 	Seq   uint64
 	Entry world.AuditEntry
@@ -290,32 +380,12 @@ func parseVec3(s string) ([3]int, error) {
 }
 
 func latestSnapshot(worldDir string) string {
-	dir := filepath.Join(worldDir, "snapshots")
-	ents, err := os.ReadDir(dir)
-	if err != nil {
-		return ""
-	}
-	var best string
-	var bestTick uint64
-	for _, e := range ents {
-		if e.IsDir() {
-			continue
-		}
-		name := e.Name()
-		if !strings.HasSuffix(name, ".snap.zst") {
-			continue
-		}
-		base := strings.TrimSuffix(name, ".snap.zst")
-		tick, err := strconv.ParseUint(base, 10, 64)
-		if err != nil {
-			continue
-		}
-		if best == "" || tick > bestTick {
-			bestTick = tick
-			best = filepath.Join(dir, name)
-		}
-	}
-	return best
+	return latestSnapshotBefore(worldDir, 0)
+}
+
+// latestSnapshotBefore returns the newest full or delta snapshot with tick < beforeTick (0 = no bound).
+func latestSnapshotBefore(worldDir string, beforeTick uint64) string {
+	return snapshot.LatestInDir(filepath.Join(worldDir, "snapshots"), beforeTick)
 }
 
 func floorDiv(a, b int) int {
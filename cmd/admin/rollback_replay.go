@@ -0,0 +1,479 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"voxelcraft.ai/internal/persistence/snapshot"
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	"voxelcraft.ai/internal/sim/tuning"
+	"voxelcraft.ai/internal/sim/world"
+)
+
+// replayRollbackOpts configures a full-state rollback: load a snapshot taken before the incident,
+// replay the tick log with the selected actions removed, and diff against the unfiltered replay.
+type replayRollbackOpts struct {
+	WorldDir    string
+	ConfigDir   string
+	TuningPath  string // default: <ConfigDir>/tuning.yaml
+	Snapshot    string
+	SinceTick   uint64
+	ToTick      uint64
+	SkipAgents  map[string]bool
+	SkipActions map[string]bool
+}
+
+type replayRollbackStats struct {
+	Ticks          uint64
+	SkippedActs    int
+	SkippedInstant int
+	SkippedTasks   int
+}
+
+type rollbackReport struct {
+	WorldID     string            `json:"world_id"`
+	Snapshot    string            `json:"snapshot"`
+	FromTick    uint64            `json:"from_tick"`
+	SinceTick   uint64            `json:"since_tick"`
+	ToTick      uint64            `json:"to_tick"`
+	SkipAgents  []string          `json:"skip_agents,omitempty"`
+	SkipActions []string          `json:"skip_actions,omitempty"`
+	Skipped     map[string]int    `json:"skipped"`
+	Agents      []entityDiff      `json:"agents"`
+	Containers  []entityDiff      `json:"containers"`
+	Counts      map[string][2]int `json:"counts,omitempty"` // section -> [original, rolled back]
+}
+
+// entityDiff describes one agent/container whose state differs between the original replay and the rollback.
+// Inventory is the per-item delta (rolled back minus original).
+type entityDiff struct {
+	ID        string         `json:"id"`
+	Status    string         `json:"status"` // CHANGED | ONLY_ORIGINAL | ONLY_ROLLBACK
+	Fields    []string       `json:"fields,omitempty"`
+	Inventory map[string]int `json:"inventory,omitempty"`
+}
+
+func replayRollback(o replayRollbackOpts) (snapshot.SnapshotV1, rollbackReport, replayRollbackStats, error) {
+	var st replayRollbackStats
+	snap, err := snapshot.ReadSnapshot(o.Snapshot)
+	if err != nil {
+		return snapshot.SnapshotV1{}, rollbackReport{}, st, fmt.Errorf("read snapshot: %w", err)
+	}
+	cats, err := catalogs.Load(o.ConfigDir)
+	if err != nil {
+		return snapshot.SnapshotV1{}, rollbackReport{}, st, fmt.Errorf("load catalogs: %w", err)
+	}
+	tune, err := loadRollbackTuning(o)
+	if err != nil {
+		return snapshot.SnapshotV1{}, rollbackReport{}, st, fmt.Errorf("load tuning: %w", err)
+	}
+	orig, err := worldFromSnapshot(snap, tune, cats)
+	if err != nil {
+		return snapshot.SnapshotV1{}, rollbackReport{}, st, err
+	}
+	fixed, err := worldFromSnapshot(snap, tune, cats)
+	if err != nil {
+		return snapshot.SnapshotV1{}, rollbackReport{}, st, err
+	}
+
+	startTick := orig.CurrentTick()
+	files, err := listTickLogFiles(filepath.Join(o.WorldDir, "events"))
+	if err != nil {
+		return snapshot.SnapshotV1{}, rollbackReport{}, st, fmt.Errorf("list events: %w", err)
+	}
+	err = scanTickLog(files, func(entry world.TickLogEntry) (bool, error) {
+		if entry.Tick < startTick {
+			return true, nil
+		}
+		if o.ToTick != 0 && entry.Tick > o.ToTick {
+			return false, nil
+		}
+		if entry.Tick != orig.CurrentTick() {
+			return false, fmt.Errorf("tick mismatch: want=%d got=%d", orig.CurrentTick(), entry.Tick)
+		}
+		joins := make([]world.JoinRequest, 0, len(entry.Joins))
+		for _, j := range entry.Joins {
+			joins = append(joins, world.JoinRequest{Name: j.Name, AgentID: j.AgentID})
+		}
+		acts := make([]world.ActionEnvelope, 0, len(entry.Actions))
+		for _, ra := range entry.Actions {
+			acts = append(acts, world.ActionEnvelope{AgentID: ra.AgentID, Act: ra.Act})
+		}
+		tick, digest := orig.StepOnce(joins, entry.Leaves, acts)
+		if digest != entry.Digest {
+			return false, fmt.Errorf("digest mismatch at tick %d (log does not replay from this snapshot)", tick)
+		}
+		if entry.Tick >= o.SinceTick {
+			acts = filterRollbackActions(acts, o.SkipAgents, o.SkipActions, &st)
+		}
+		fixed.StepOnce(joins, entry.Leaves, acts)
+		st.Ticks++
+		return true, nil
+	})
+	if err != nil {
+		return snapshot.SnapshotV1{}, rollbackReport{}, st, err
+	}
+	if st.Ticks == 0 {
+		return snapshot.SnapshotV1{}, rollbackReport{}, st, fmt.Errorf("no tick log entries after snapshot tick %d", snap.Header.Tick)
+	}
+
+	endTick := orig.CurrentTick() - 1
+	origSnap := orig.ExportSnapshot(endTick)
+	fixedSnap := fixed.ExportSnapshot(endTick)
+	rep := rollbackReport{
+		WorldID:     snap.Header.WorldID,
+		Snapshot:    filepath.Base(o.Snapshot),
+		FromTick:    snap.Header.Tick,
+		SinceTick:   o.SinceTick,
+		ToTick:      endTick,
+		SkipAgents:  sortedSet(o.SkipAgents),
+		SkipActions: sortedSet(o.SkipActions),
+		Skipped: map[string]int{
+			"acts":     st.SkippedActs,
+			"instants": st.SkippedInstant,
+			"tasks":    st.SkippedTasks,
+		},
+	}
+	rep.Agents, rep.Containers = diffSnapshots(origSnap, fixedSnap)
+	rep.Counts = sectionCounts(origSnap, fixedSnap)
+	return fixedSnap, rep, st, nil
+}
+
+// loadRollbackTuning mirrors the server's snapshot resume: a missing tuning file falls back to defaults.
+func loadRollbackTuning(o replayRollbackOpts) (tuning.Tuning, error) {
+	tp := strings.TrimSpace(o.TuningPath)
+	if tp == "" {
+		tp = filepath.Join(o.ConfigDir, "tuning.yaml")
+	}
+	tune, err := tuning.Load(tp)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return tuning.Defaults(), nil
+		}
+		return tuning.Tuning{}, err
+	}
+	return tune, nil
+}
+
+// worldFromSnapshot builds the world exactly as cmd/server does when resuming from a snapshot,
+// so rate limits, budgets and other tuning-driven rules replay the same way.
+func worldFromSnapshot(snap snapshot.SnapshotV1, tune tuning.Tuning, cats *catalogs.Catalogs) (*world.World, error) {
+	w, err := world.New(world.WorldConfig{
+		ID:                              snap.Header.WorldID,
+		TickRateHz:                      snap.TickRate,
+		DayTicks:                        snap.DayTicks,
+		SeasonLengthTicks:               tune.SeasonLengthTicks,
+		ObsRadius:                       snap.ObsRadius,
+		ObsRadiusMin:                    tune.ObsRadiusMin,
+		ObsRadiusMax:                    tune.ObsRadiusMax,
+		Height:                          snap.Height,
+		Seed:                            snap.Seed,
+		BoundaryR:                       snap.BoundaryR,
+		BiomeRegionSize:                 tune.WorldGen.BiomeRegionSize,
+		SpawnClearRadius:                tune.WorldGen.SpawnClearRadius,
+		OreClusterProbScalePermille:     tune.WorldGen.OreClusterProbScalePermille,
+		TerrainClusterProbScalePermille: tune.WorldGen.TerrainClusterProbScalePermille,
+		SprinkleStonePermille:           tune.WorldGen.SprinkleStonePermille,
+		SprinkleDirtPermille:            tune.WorldGen.SprinkleDirtPermille,
+		SprinkleLogPermille:             tune.WorldGen.SprinkleLogPermille,
+		StarterItems:                    tune.StarterItems,
+		SnapshotEveryTicks:              tune.SnapshotEveryTicks,
+		DirectorEveryTicks:              tune.DirectorEveryTicks,
+		ChunkIdleTicks:                  tune.ChunkIdleTicks,
+		TaskQueueDepth:                  tune.TaskQueueDepth,
+		TriggerBudgetPerTick:            tune.TriggerBudgetPerTick,
+		WaterUpdatesPerTick:             tune.WaterUpdatesPerTick,
+		SignalUpdatesPerTick:            tune.SignalUpdatesPerTick,
+		RateLimits: world.RateLimitConfig{
+			SayWindowTicks:        tune.RateLimits.SayWindowTicks,
+			SayMax:                tune.RateLimits.SayMax,
+			MarketSayWindowTicks:  tune.RateLimits.MarketSayWindowTicks,
+			MarketSayMax:          tune.RateLimits.MarketSayMax,
+			WhisperWindowTicks:    tune.RateLimits.WhisperWindowTicks,
+			WhisperMax:            tune.RateLimits.WhisperMax,
+			OfferTradeWindowTicks: tune.RateLimits.OfferTradeWindowTicks,
+			OfferTradeMax:         tune.RateLimits.OfferTradeMax,
+			PostBoardWindowTicks:  tune.RateLimits.PostBoardWindowTicks,
+			PostBoardMax:          tune.RateLimits.PostBoardMax,
+		},
+		LawNoticeTicks:         tune.LawNoticeTicks,
+		LawVoteTicks:           tune.LawVoteTicks,
+		BlueprintAutoPullRange: tune.BlueprintAutoPullRange,
+		BlueprintBlocksPerTick: tune.BlueprintBlocksPerTick,
+		AccessPassCoreRadius:   tune.AccessPassCoreRadius,
+		MaintenanceCost:        tune.ClaimMaintenanceCost,
+		FunDecayWindowTicks:    tune.FunDecayWindowTicks,
+		FunDecayBase:           tune.FunDecayBase,
+		StructureSurvivalTicks: tune.StructureSurvivalTicks,
+	}, cats)
+	if err != nil {
+		return nil, fmt.Errorf("world: %w", err)
+	}
+	if err := w.ImportSnapshot(snap); err != nil {
+		return nil, fmt.Errorf("import snapshot: %w", err)
+	}
+	return w, nil
+}
+
+// filterRollbackActions drops whole acts from skipped agents and individual instants/tasks of skipped types.
+// Acts left with nothing to do are dropped entirely.
+func filterRollbackActions(acts []world.ActionEnvelope, skipAgents, skipActions map[string]bool, st *replayRollbackStats) []world.ActionEnvelope {
+	out := make([]world.ActionEnvelope, 0, len(acts))
+	for _, a := range acts {
+		if skipAgents[a.AgentID] {
+			st.SkippedActs++
+			continue
+		}
+		if len(skipActions) > 0 {
+			a.Act = filterActTypes(a.Act, skipActions, st)
+			if len(a.Act.Instants) == 0 && len(a.Act.Tasks) == 0 && len(a.Act.Cancel) == 0 {
+				st.SkippedActs++
+				continue
+			}
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+func filterActTypes(act protocol.ActMsg, skip map[string]bool, st *replayRollbackStats) protocol.ActMsg {
+	var inst []protocol.InstantReq
+	for _, r := range act.Instants {
+		if skip[r.Type] {
+			st.SkippedInstant++
+			continue
+		}
+		inst = append(inst, r)
+	}
+	var tasks []protocol.TaskReq
+	for _, r := range act.Tasks {
+		if skip[r.Type] {
+			st.SkippedTasks++
+			continue
+		}
+		tasks = append(tasks, r)
+	}
+	act.Instants = inst
+	act.Tasks = tasks
+	return act
+}
+
+func diffSnapshots(a, b snapshot.SnapshotV1) (agents, containers []entityDiff) {
+	am := map[string]snapshot.AgentV1{}
+	for _, ag := range a.Agents {
+		am[ag.ID] = ag
+	}
+	bm := map[string]snapshot.AgentV1{}
+	for _, ag := range b.Agents {
+		bm[ag.ID] = ag
+	}
+	for _, id := range unionKeys(am, bm) {
+		x, okA := am[id]
+		y, okB := bm[id]
+		if d, changed := diffEntity(id, x, y, okA, okB, x.Inventory, y.Inventory); changed {
+			agents = append(agents, d)
+		}
+	}
+
+	ac := map[string]snapshot.ContainerV1{}
+	for _, c := range a.Containers {
+		ac[containerKey(c)] = c
+	}
+	bc := map[string]snapshot.ContainerV1{}
+	for _, c := range b.Containers {
+		bc[containerKey(c)] = c
+	}
+	for _, id := range unionKeys(ac, bc) {
+		x, okA := ac[id]
+		y, okB := bc[id]
+		if d, changed := diffEntity(id, x, y, okA, okB, x.Inventory, y.Inventory); changed {
+			containers = append(containers, d)
+		}
+	}
+	return agents, containers
+}
+
+func diffEntity(id string, x, y any, okA, okB bool, invA, invB map[string]int) (entityDiff, bool) {
+	d := entityDiff{ID: id, Inventory: inventoryDelta(invA, invB)}
+	switch {
+	case okA && !okB:
+		d.Status = "ONLY_ORIGINAL"
+		return d, true
+	case !okA && okB:
+		d.Status = "ONLY_ROLLBACK"
+		return d, true
+	}
+	d.Fields = changedFields(x, y)
+	if len(d.Fields) == 0 {
+		return entityDiff{}, false
+	}
+	d.Status = "CHANGED"
+	return d, true
+}
+
+// changedFields compares two snapshot records by their JSON field names.
+func changedFields(x, y any) []string {
+	var mx, my map[string]any
+	bx, _ := json.Marshal(x)
+	by, _ := json.Marshal(y)
+	_ = json.Unmarshal(bx, &mx)
+	_ = json.Unmarshal(by, &my)
+	var out []string
+	for _, k := range unionKeys(mx, my) {
+		if !reflect.DeepEqual(mx[k], my[k]) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+func inventoryDelta(a, b map[string]int) map[string]int {
+	out := map[string]int{}
+	for k, v := range b {
+		out[k] += v
+	}
+	for k, v := range a {
+		out[k] -= v
+	}
+	for k, v := range out {
+		if v == 0 {
+			delete(out, k)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func sectionCounts(a, b snapshot.SnapshotV1) map[string][2]int {
+	out := map[string][2]int{}
+	add := func(name string, x, y int) {
+		if x != y {
+			out[name] = [2]int{x, y}
+		}
+	}
+	add("claims", len(a.Claims), len(b.Claims))
+	add("contracts", len(a.Contracts), len(b.Contracts))
+	add("orders", len(a.Orders), len(b.Orders))
+	add("prices", len(a.Prices), len(b.Prices))
+	add("trades", len(a.Trades), len(b.Trades))
+	add("items", len(a.Items), len(b.Items))
+	add("orgs", len(a.Orgs), len(b.Orgs))
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func containerKey(c snapshot.ContainerV1) string {
+	return fmt.Sprintf("%s@%d,%d,%d", c.Type, c.Pos[0], c.Pos[1], c.Pos[2])
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		seen[k] = true
+		out = append(out, k)
+	}
+	for k := range b {
+		if !seen[k] {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortedSet(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func parseCSVSet(s string) map[string]bool {
+	out := map[string]bool{}
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out[p] = true
+		}
+	}
+	return out
+}
+
+func listTickLogFiles(dir string) ([]string, error) {
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(ents))
+	for _, e := range ents {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if strings.HasPrefix(name, "events-") && strings.HasSuffix(name, ".jsonl.zst") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		out = append(out, filepath.Join(dir, name))
+	}
+	return out, nil
+}
+
+// scanTickLog feeds entries to fn in file order until fn returns false or an error.
+func scanTickLog(files []string, fn func(world.TickLogEntry) (bool, error)) error {
+	for _, path := range files {
+		more, err := scanTickLogFile(path, fn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		if !more {
+			return nil
+		}
+	}
+	return nil
+}
+
+func scanTickLogFile(path string, fn func(world.TickLogEntry) (bool, error)) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	dec, err := zstd.NewReader(f)
+	if err != nil {
+		return false, err
+	}
+	defer dec.Close()
+
+	sc := bufio.NewScanner(dec)
+	sc.Buffer(make([]byte, 64*1024), 8*1024*1024)
+	for sc.Scan() {
+		var entry world.TickLogEntry
+		if err := json.Unmarshal(sc.Bytes(), &entry); err != nil {
+			return false, fmt.Errorf("unmarshal: %w", err)
+		}
+		more, err := fn(entry)
+		if err != nil || !more {
+			return false, err
+		}
+	}
+	return true, sc.Err()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"voxelcraft.ai/internal/persistence/snapshot"
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/tuning"
+	"voxelcraft.ai/internal/sim/world"
+)
+
+func TestFilterRollbackActions(t *testing.T) {
+	acts := []world.ActionEnvelope{
+		{AgentID: "A1", Act: protocol.ActMsg{Instants: []protocol.InstantReq{{Type: "SAY"}}}},
+		{AgentID: "A2", Act: protocol.ActMsg{
+			Instants: []protocol.InstantReq{{Type: "OFFER_TRADE"}, {Type: "SAY"}},
+			Tasks:    []protocol.TaskReq{{Type: "MINE"}},
+		}},
+		{AgentID: "A3", Act: protocol.ActMsg{Instants: []protocol.InstantReq{{Type: "OFFER_TRADE"}}}},
+	}
+	var st replayRollbackStats
+	out := filterRollbackActions(acts, map[string]bool{"A1": true}, map[string]bool{"OFFER_TRADE": true, "MINE": true}, &st)
+	if len(out) != 1 || out[0].AgentID != "A2" {
+		t.Fatalf("out=%+v", out)
+	}
+	if got := out[0].Act.Instants; len(got) != 1 || got[0].Type != "SAY" || len(out[0].Act.Tasks) != 0 {
+		t.Fatalf("A2 act not filtered: %+v", out[0].Act)
+	}
+	if st.SkippedActs != 2 || st.SkippedInstant != 2 || st.SkippedTasks != 1 {
+		t.Fatalf("stats=%+v", st)
+	}
+	if len(acts[1].Act.Instants) != 2 {
+		t.Fatalf("input act mutated: %+v", acts[1].Act)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	a := snapshot.SnapshotV1{
+		Agents: []snapshot.AgentV1{
+			{ID: "A1", HP: 20, Inventory: map[string]int{"PLANK": 5}},
+			{ID: "A2", HP: 20, Inventory: map[string]int{"COAL": 1}},
+		},
+		Containers: []snapshot.ContainerV1{{Type: "CHEST", Pos: [3]int{1, 0, 2}, Inventory: map[string]int{"IRON_INGOT": 3}}},
+	}
+	b := snapshot.SnapshotV1{
+		Agents: []snapshot.AgentV1{
+			{ID: "A1", HP: 20, Inventory: map[string]int{"PLANK": 8}},
+			{ID: "A2", HP: 20, Inventory: map[string]int{"COAL": 1}},
+		},
+		Containers: []snapshot.ContainerV1{{Type: "CHEST", Pos: [3]int{1, 0, 2}, Inventory: map[string]int{}}},
+	}
+	agents, containers := diffSnapshots(a, b)
+	if len(agents) != 1 || agents[0].ID != "A1" || agents[0].Status != "CHANGED" {
+		t.Fatalf("agents=%+v", agents)
+	}
+	if !reflect.DeepEqual(agents[0].Fields, []string{"inventory"}) || agents[0].Inventory["PLANK"] != 3 {
+		t.Fatalf("A1 diff=%+v", agents[0])
+	}
+	if len(containers) != 1 || containers[0].ID != "CHEST@1,0,2" || containers[0].Inventory["IRON_INGOT"] != -3 {
+		t.Fatalf("containers=%+v", containers)
+	}
+}
+
+func TestLoadRollbackTuningMatchesServer(t *testing.T) {
+	want, err := tuning.Load("../../configs/tuning.yaml")
+	if err != nil {
+		t.Fatalf("load tuning: %v", err)
+	}
+	got, err := loadRollbackTuning(replayRollbackOpts{ConfigDir: "../../configs"})
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("tuning from configs: err=%v got=%+v", err, got)
+	}
+	got, err = loadRollbackTuning(replayRollbackOpts{ConfigDir: t.TempDir()})
+	if err != nil || !reflect.DeepEqual(got, tuning.Defaults()) {
+		t.Fatalf("missing tuning should fall back to defaults: err=%v", err)
+	}
+}
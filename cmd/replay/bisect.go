@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"voxelcraft.ai/internal/persistence/snapshot"
+	"voxelcraft.ai/internal/sim/world"
+)
+
+// digestMismatchError is returned by replayFile when a replayed tick does not reproduce the logged digest.
+type digestMismatchError struct {
+	Tick uint64
+	Got  string
+	Want string
+	// Sections are the sections whose digest differs from the tick log at Tick
+	// (empty for logs written before per-section digests were recorded).
+	Sections []sectionReport
+}
+
+func (e *digestMismatchError) Error() string {
+	msg := fmt.Sprintf("digest mismatch at tick %d: got=%s want=%s", e.Tick, e.Got, e.Want)
+	if len(e.Sections) > 0 {
+		names := make([]string, 0, len(e.Sections))
+		for _, s := range e.Sections {
+			names = append(names, s.Name)
+		}
+		msg += " sections=" + strings.Join(names, ",")
+	}
+	return msg
+}
+
+// bisectReport is printed by -bisect. MismatchSections come from the tick log at the first
+// mismatching tick; Sections and Diffs compare against the reference snapshot, when there is one.
+type bisectReport struct {
+	MismatchTick     uint64          `json:"mismatch_tick"`
+	MismatchSections []sectionReport `json:"mismatch_sections"`
+	RefSnapshot      string          `json:"ref_snapshot,omitempty"`
+	RefTick          uint64          `json:"ref_tick,omitempty"`
+	Sections         []sectionReport `json:"sections,omitempty"`
+	Diffs            []sectionDiff   `json:"diffs,omitempty"`
+}
+
+type sectionReport struct {
+	Name string `json:"name"`
+	Got  string `json:"got"`
+	Want string `json:"want"`
+}
+
+// sectionDiff lists, for one diverged section, how many entities differ and the first one's fields.
+type sectionDiff struct {
+	Section  string      `json:"section"`
+	Entities int         `json:"entities"`
+	First    *entityDiff `json:"first,omitempty"`
+}
+
+type entityDiff struct {
+	ID     string      `json:"id"`
+	Status string      `json:"status"` // CHANGED | ONLY_REPLAY | ONLY_REF
+	Fields []fieldDiff `json:"fields,omitempty"`
+}
+
+type fieldDiff struct {
+	Field string `json:"field"`
+	Index *int   `json:"index,omitempty"` // first differing element for equal-length arrays
+	Got   any    `json:"got"`
+	Want  any    `json:"want"`
+}
+
+// snapshotSections maps digest section names to snapshot JSON arrays and the fields that key their entities.
+var snapshotSections = map[string]struct {
+	field string
+	keys  []string
+}{
+	"chunks":     {"chunks", []string{"cx", "cz"}},
+	"claims":     {"claims", []string{"land_id"}},
+	"laws":       {"laws", []string{"law_id"}},
+	"orgs":       {"orgs", []string{"org_id"}},
+	"containers": {"containers", []string{"type", "pos"}},
+	"items":      {"items", []string{"entity_id"}},
+	"mobs":       {"mobs", []string{"entity_id"}},
+	"signs":      {"signs", []string{"pos"}},
+	"conveyors":  {"conveyors", []string{"pos"}},
+	"switches":   {"switches", []string{"pos"}},
+	"crops":      {"crops", []string{"pos"}},
+	"water":      {"water", []string{"pos"}},
+	"signals":    {"signals", []string{"pos"}},
+	"machines":   {"machines", []string{"pos"}},
+	"shops":      {"shops", []string{"pos"}},
+	"contracts":  {"contracts", []string{"contract_id"}},
+	"orders":     {"orders", []string{"order_id"}},
+	"prices":     {"prices", []string{"item"}},
+	"trades":     {"trades", []string{"trade_id"}},
+	"boards":     {"boards", []string{"board_id"}},
+	"structures": {"structures", []string{"structure_id"}},
+	"agents":     {"agents", []string{"id"}},
+}
+
+var headerFields = []string{
+	"weather", "weather_until_tick",
+	"active_event_id", "active_event_start_tick", "active_event_ends_tick", "active_event_center", "active_event_radius",
+}
+
+// refSnapshotAtOrAfter returns the full or delta snapshot in dir with the smallest tick >= tick.
+func refSnapshotAtOrAfter(dir string, tick uint64) (string, uint64) {
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		return "", 0
+	}
+	var best string
+	var bestTick uint64
+	for _, e := range ents {
+		if e.IsDir() {
+			continue
+		}
+		t, _, ok := snapshot.ParseFileName(e.Name())
+		if !ok || t < tick {
+			continue
+		}
+		if best == "" || t < bestTick {
+			best, bestTick = filepath.Join(dir, e.Name()), t
+		}
+	}
+	return best, bestTick
+}
+
+// divergedSections compares the replayed section digests with the ones recorded in the tick log.
+// Sections the log has not recorded yet are skipped.
+func divergedSections(got []world.DigestSection, want map[string]string) []sectionReport {
+	if len(want) == 0 {
+		return nil
+	}
+	var out []sectionReport
+	for _, s := range got {
+		if h, ok := want[s.Name]; ok && h != s.Hash {
+			out = append(out, sectionReport{Name: s.Name, Got: s.Hash, Want: want[s.Name]})
+		}
+	}
+	return out
+}
+
+// bisect fills rep with the section digests and entity diffs of the replayed world against ref.
+func bisect(rep *bisectReport, w *world.World, ref snapshot.SnapshotV1, refPath string, cfgWorld func(snapshot.SnapshotV1) (*world.World, error)) error {
+	refTick := ref.Header.Tick
+	refWorld, err := cfgWorld(ref)
+	if err != nil {
+		return err
+	}
+	got := w.StateDigestSections(refTick)
+	want := refWorld.StateDigestSections(refTick)
+
+	rep.RefSnapshot, rep.RefTick = refPath, refTick
+	gotSnap := w.ExportSnapshot(refTick)
+	gm, wm := snapshotJSON(gotSnap), snapshotJSON(ref)
+	for i := range got {
+		if got[i].Hash == want[i].Hash {
+			continue
+		}
+		name := got[i].Name
+		rep.Sections = append(rep.Sections, sectionReport{Name: name, Got: got[i].Hash, Want: want[i].Hash})
+		rep.Diffs = append(rep.Diffs, diffSection(name, gm, wm))
+	}
+	return nil
+}
+
+func snapshotJSON(s snapshot.SnapshotV1) map[string]any {
+	b, _ := json.Marshal(s)
+	var m map[string]any
+	_ = json.Unmarshal(b, &m)
+	return m
+}
+
+func diffSection(name string, got, want map[string]any) sectionDiff {
+	d := sectionDiff{Section: name}
+	if name == "header" {
+		var fields []fieldDiff
+		for _, f := range headerFields {
+			fields = append(fields, diffValue(f, got[f], want[f])...)
+		}
+		if len(fields) > 0 {
+			d.Entities = 1
+			d.First = &entityDiff{ID: "header", Status: "CHANGED", Fields: fields}
+		}
+		return d
+	}
+	spec, ok := snapshotSections[name]
+	if !ok {
+		return d
+	}
+	gm := keyedRecords(got[spec.field], spec.keys)
+	wm := keyedRecords(want[spec.field], spec.keys)
+	ids := make([]string, 0, len(gm)+len(wm))
+	for id := range gm {
+		ids = append(ids, id)
+	}
+	for id := range wm {
+		if _, ok := gm[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		g, okG := gm[id]
+		x, okW := wm[id]
+		var e entityDiff
+		switch {
+		case okG && !okW:
+			e = entityDiff{ID: id, Status: "ONLY_REPLAY"}
+		case !okG && okW:
+			e = entityDiff{ID: id, Status: "ONLY_REF"}
+		default:
+			e = entityDiff{ID: id, Status: "CHANGED", Fields: diffRecord(g, x)}
+			if len(e.Fields) == 0 {
+				continue
+			}
+		}
+		d.Entities++
+		if d.First == nil {
+			d.First = &e
+		}
+	}
+	return d
+}
+
+func keyedRecords(v any, keys []string) map[string]map[string]any {
+	out := map[string]map[string]any{}
+	arr, _ := v.([]any)
+	for _, r := range arr {
+		rec, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprint(rec[k]))
+		}
+		out[strings.Join(parts, "|")] = rec
+	}
+	return out
+}
+
+func diffRecord(got, want map[string]any) []fieldDiff {
+	keys := make([]string, 0, len(got)+len(want))
+	for k := range got {
+		keys = append(keys, k)
+	}
+	for k := range want {
+		if _, ok := got[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var out []fieldDiff
+	for _, k := range keys {
+		out = append(out, diffValue(k, got[k], want[k])...)
+	}
+	return out
+}
+
+func diffValue(field string, got, want any) []fieldDiff {
+	if reflect.DeepEqual(got, want) {
+		return nil
+	}
+	ga, okG := got.([]any)
+	wa, okW := want.([]any)
+	if okG && okW && len(ga) == len(wa) {
+		for i := range ga {
+			if !reflect.DeepEqual(ga[i], wa[i]) {
+				return []fieldDiff{{Field: field, Index: &i, Got: ga[i], Want: wa[i]}}
+			}
+		}
+	}
+	return []fieldDiff{{Field: field, Got: got, Want: want}}
+}
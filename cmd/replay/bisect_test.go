@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/persistence/snapshot"
+	"voxelcraft.ai/internal/sim/world"
+	digestfeaturepkg "voxelcraft.ai/internal/sim/world/feature/persistence/digest"
+)
+
+func TestEveryDigestSectionMapsToSnapshot(t *testing.T) {
+	for _, s := range digestfeaturepkg.SectionDigests(digestfeaturepkg.StateInput{}) {
+		if _, ok := snapshotSections[s.Name]; !ok && s.Name != "header" {
+			t.Errorf("digest section %q has no snapshotSections entry", s.Name)
+		}
+	}
+}
+
+func TestDivergedSectionsAgainstTickLog(t *testing.T) {
+	got := []world.DigestSection{{Name: "header", Hash: "h"}, {Name: "agents", Hash: "a2"}, {Name: "shops", Hash: "s"}}
+	if d := divergedSections(got, nil); d != nil {
+		t.Fatalf("log without sections: %+v", d)
+	}
+	d := divergedSections(got, map[string]string{"header": "h", "agents": "a1"})
+	if len(d) != 1 || d[0].Name != "agents" || d[0].Want != "a1" {
+		t.Fatalf("diverged=%+v", d)
+	}
+}
+
+func TestDiffSectionFirstEntity(t *testing.T) {
+	got := snapshotJSON(snapshot.SnapshotV1{
+		Agents: []snapshot.AgentV1{
+			{ID: "A1", HP: 20},
+			{ID: "A2", HP: 20, Inventory: map[string]int{"COAL": 2}},
+			{ID: "A3", HP: 19},
+		},
+		Chunks: []snapshot.ChunkV1{{CX: 0, CZ: 0, Height: 1, Blocks: []uint16{1, 2, 3}}},
+	})
+	want := snapshotJSON(snapshot.SnapshotV1{
+		Agents: []snapshot.AgentV1{
+			{ID: "A1", HP: 20},
+			{ID: "A2", HP: 20, Inventory: map[string]int{"COAL": 1}},
+			{ID: "A3", HP: 20},
+		},
+		Chunks: []snapshot.ChunkV1{{CX: 0, CZ: 0, Height: 1, Blocks: []uint16{1, 5, 3}}},
+	})
+
+	d := diffSection("agents", got, want)
+	if d.Entities != 2 || d.First == nil || d.First.ID != "A2" || d.First.Status != "CHANGED" {
+		t.Fatalf("agents diff=%+v", d)
+	}
+	if len(d.First.Fields) != 1 || d.First.Fields[0].Field != "inventory" {
+		t.Fatalf("A2 fields=%+v", d.First.Fields)
+	}
+
+	c := diffSection("chunks", got, want)
+	if c.Entities != 1 || c.First == nil || len(c.First.Fields) != 1 {
+		t.Fatalf("chunks diff=%+v", c)
+	}
+	f := c.First.Fields[0]
+	if f.Field != "blocks" || f.Index == nil || *f.Index != 1 || f.Got != float64(2) || f.Want != float64(5) {
+		t.Fatalf("chunk field=%+v", f)
+	}
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -24,6 +26,8 @@ func main() {
 		configDir = flag.String("configs", "./configs", "config directory")
 		fromTick  = flag.Uint64("from_tick", 0, "start verifying from tick (inclusive, optional)")
 		toTick    = flag.Uint64("to_tick", 0, "stop at tick (inclusive, optional)")
+		bisectOn  = flag.Bool("bisect", false, "on digest mismatch, dump as JSON the sections that diverged at that tick and, given a reference snapshot, the differing entities")
+		refPath   = flag.String("ref_snapshot", "", "reference snapshot for -bisect (optional; defaults to first snapshot at/after the mismatch tick next to -snapshot)")
 	)
 	flag.Parse()
 
@@ -52,21 +56,27 @@ func main() {
 		os.Exit(1)
 	}
 
-	w, err := world.New(world.WorldConfig{
-		ID:         snap.Header.WorldID,
-		TickRateHz: snap.TickRate,
-		DayTicks:   snap.DayTicks,
-		ObsRadius:  snap.ObsRadius,
-		Height:     snap.Height,
-		Seed:       snap.Seed,
-		BoundaryR:  snap.BoundaryR,
-	}, cats)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "world:", err)
-		os.Exit(1)
+	loadWorld := func(s snapshot.SnapshotV1) (*world.World, error) {
+		w, err := world.New(world.WorldConfig{
+			ID:         s.Header.WorldID,
+			TickRateHz: s.TickRate,
+			DayTicks:   s.DayTicks,
+			ObsRadius:  s.ObsRadius,
+			Height:     s.Height,
+			Seed:       s.Seed,
+			BoundaryR:  s.BoundaryR,
+		}, cats)
+		if err != nil {
+			return nil, fmt.Errorf("world: %w", err)
+		}
+		if err := w.ImportSnapshot(s); err != nil {
+			return nil, fmt.Errorf("import snapshot: %w", err)
+		}
+		return w, nil
 	}
-	if err := w.ImportSnapshot(snap); err != nil {
-		fmt.Fprintln(os.Stderr, "import snapshot:", err)
+	w, err := loadWorld(snap)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
@@ -87,8 +97,13 @@ func main() {
 	}
 
 	var checked uint64
+	logged := map[string]string{}
 	for _, path := range files {
-		if err := replayFile(w, path, startTick, verifyFrom, *toTick, &checked); err != nil {
+		if err := replayFile(w, path, startTick, verifyFrom, *toTick, &checked, logged); err != nil {
+			var mm *digestMismatchError
+			if *bisectOn && errors.As(err, &mm) {
+				runBisect(w, files, mm, *snapPath, *refPath, loadWorld, logged)
+			}
 			fmt.Fprintln(os.Stderr, "replay:", err)
 			os.Exit(1)
 		}
@@ -99,6 +114,55 @@ func main() {
 	fmt.Printf("replay ok: checked=%d ticks (from snapshot tick=%d)\n", checked, snap.Header.Tick)
 }
 
+// runBisect prints as JSON the sections that diverged at the mismatch tick (from the tick log's
+// per-section digests). Entity-level diffs need state to compare against, so it then replays past
+// the mismatch (unverified) up to the reference snapshot tick and adds the per-section comparison
+// and first differing entity fields there.
+func runBisect(w *world.World, files []string, mm *digestMismatchError, snapPath, refPath string, loadWorld func(snapshot.SnapshotV1) (*world.World, error), logged map[string]string) {
+	rep := bisectReport{MismatchTick: mm.Tick, MismatchSections: mm.Sections}
+	if rep.MismatchSections == nil {
+		fmt.Fprintf(os.Stderr, "bisect: tick log has no section digests at tick %d\n", mm.Tick)
+	}
+	defer func() {
+		b, _ := json.MarshalIndent(rep, "", "  ")
+		fmt.Println(string(b))
+	}()
+
+	if strings.TrimSpace(refPath) == "" {
+		refPath, _ = refSnapshotAtOrAfter(filepath.Dir(snapPath), mm.Tick)
+	}
+	if refPath == "" {
+		fmt.Fprintf(os.Stderr, "bisect: no reference snapshot at/after tick %d; provide -ref_snapshot for entity diffs\n", mm.Tick)
+		return
+	}
+	ref, err := snapshot.ReadSnapshot(refPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "bisect: read reference snapshot:", err)
+		return
+	}
+	if ref.Header.Tick < mm.Tick {
+		fmt.Fprintf(os.Stderr, "bisect: reference snapshot tick %d is before mismatch tick %d\n", ref.Header.Tick, mm.Tick)
+		return
+	}
+	var unchecked uint64
+	for _, path := range files {
+		if w.CurrentTick() > ref.Header.Tick {
+			break
+		}
+		if err := replayFile(w, path, w.CurrentTick(), math.MaxUint64, ref.Header.Tick, &unchecked, logged); err != nil {
+			fmt.Fprintln(os.Stderr, "bisect: replay to reference tick:", err)
+			return
+		}
+	}
+	if w.CurrentTick() != ref.Header.Tick+1 {
+		fmt.Fprintf(os.Stderr, "bisect: tick log ends at %d before reference tick %d\n", w.CurrentTick()-1, ref.Header.Tick)
+		return
+	}
+	if err := bisect(&rep, w, ref, refPath, loadWorld); err != nil {
+		fmt.Fprintln(os.Stderr, "bisect:", err)
+	}
+}
+
 func listEventFiles(dir string) ([]string, error) {
 	ents, err := os.ReadDir(dir)
 	if err != nil {
@@ -122,7 +186,10 @@ func listEventFiles(dir string) ([]string, error) {
 	return out, nil
 }
 
-func replayFile(w *world.World, path string, startTick, verifyFrom, toTick uint64, checked *uint64) error {
+// replayFile steps w through the tick log entries in path. logged carries the section digests
+// recorded so far across files: entries only hold the sections that changed, so it is updated
+// even from entries before startTick.
+func replayFile(w *world.World, path string, startTick, verifyFrom, toTick uint64, checked *uint64, logged map[string]string) error {
 	f, err := os.Open(path)
 	if err != nil {
 		return err
@@ -144,19 +211,22 @@ func replayFile(w *world.World, path string, startTick, verifyFrom, toTick uint6
 		if err := json.Unmarshal(line, &entry); err != nil {
 			return fmt.Errorf("%s: unmarshal: %w", filepath.Base(path), err)
 		}
-		if entry.Tick < startTick {
-			continue
-		}
 		if toTick != 0 && entry.Tick > toTick {
 			return nil
 		}
+		for name, hash := range entry.Sections {
+			logged[name] = hash
+		}
+		if entry.Tick < startTick {
+			continue
+		}
 		if entry.Tick != w.CurrentTick() {
 			return fmt.Errorf("tick mismatch: want=%d got=%d (file=%s)", w.CurrentTick(), entry.Tick, filepath.Base(path))
 		}
 
 		joins := make([]world.JoinRequest, 0, len(entry.Joins))
 		for _, j := range entry.Joins {
-			joins = append(joins, world.JoinRequest{Name: j.Name})
+			joins = append(joins, world.JoinRequest{Name: j.Name, AgentID: j.AgentID})
 		}
 		leaves := entry.Leaves
 
@@ -175,7 +245,7 @@ func replayFile(w *world.World, path string, startTick, verifyFrom, toTick uint6
 		if tick >= verifyFrom {
 			*checked++
 			if gotDigest != entry.Digest {
-				return fmt.Errorf("digest mismatch at tick %d: got=%s want=%s", tick, gotDigest, entry.Digest)
+				return &digestMismatchError{Tick: tick, Got: gotDigest, Want: entry.Digest, Sections: divergedSections(w.StateDigestSections(tick), logged)}
 			}
 		}
 	}
@@ -12,7 +12,6 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -97,6 +96,12 @@ func main() {
 			if err != nil {
 				logger.Fatalf("load worlds config: %v", err)
 			}
+			if err := mcfg.ValidatePermitItems(func(id string) bool {
+				_, ok := cats.Items.Defs[id]
+				return ok
+			}); err != nil {
+				logger.Fatalf("load worlds config: %v", err)
+			}
 			runMultiWorld(serverRuntimeConfig{
 				Addr:      *addr,
 				DataDir:   *dataDir,
@@ -134,6 +139,8 @@ func main() {
 			DayTicks:                        snap.DayTicks,
 			SeasonLengthTicks:               tune.SeasonLengthTicks,
 			ObsRadius:                       snap.ObsRadius,
+			ObsRadiusMin:                    tune.ObsRadiusMin,
+			ObsRadiusMax:                    tune.ObsRadiusMax,
 			Height:                          snap.Height,
 			Seed:                            snap.Seed,
 			BoundaryR:                       snap.BoundaryR,
@@ -147,6 +154,11 @@ func main() {
 			StarterItems:                    tune.StarterItems,
 			SnapshotEveryTicks:              tune.SnapshotEveryTicks,
 			DirectorEveryTicks:              tune.DirectorEveryTicks,
+			ChunkIdleTicks:                  tune.ChunkIdleTicks,
+			TaskQueueDepth:                  tune.TaskQueueDepth,
+			TriggerBudgetPerTick:            tune.TriggerBudgetPerTick,
+			WaterUpdatesPerTick:             tune.WaterUpdatesPerTick,
+			SignalUpdatesPerTick:            tune.SignalUpdatesPerTick,
 			RateLimits: world.RateLimitConfig{
 				SayWindowTicks:        tune.RateLimits.SayWindowTicks,
 				SayMax:                tune.RateLimits.SayMax,
@@ -183,6 +195,8 @@ func main() {
 			DayTicks:                        tune.DayTicks,
 			SeasonLengthTicks:               tune.SeasonLengthTicks,
 			ObsRadius:                       tune.ObsRadius,
+			ObsRadiusMin:                    tune.ObsRadiusMin,
+			ObsRadiusMax:                    tune.ObsRadiusMax,
 			Height:                          tune.ChunkSize[2],
 			Seed:                            *seed,
 			BoundaryR:                       tune.WorldBoundaryR,
@@ -196,6 +210,11 @@ func main() {
 			StarterItems:                    tune.StarterItems,
 			SnapshotEveryTicks:              tune.SnapshotEveryTicks,
 			DirectorEveryTicks:              tune.DirectorEveryTicks,
+			ChunkIdleTicks:                  tune.ChunkIdleTicks,
+			TaskQueueDepth:                  tune.TaskQueueDepth,
+			TriggerBudgetPerTick:            tune.TriggerBudgetPerTick,
+			WaterUpdatesPerTick:             tune.WaterUpdatesPerTick,
+			SignalUpdatesPerTick:            tune.SignalUpdatesPerTick,
 			RateLimits: world.RateLimitConfig{
 				SayWindowTicks:        tune.RateLimits.SayWindowTicks,
 				SayMax:                tune.RateLimits.SayMax,
@@ -223,6 +242,10 @@ func main() {
 		}
 	}
 
+	if err := w.SetChunkRegionDir(filepath.Join(worldDir, "regions")); err != nil {
+		logger.Fatalf("chunk regions: %v", err)
+	}
+
 	ctx, cancel := signalContext()
 	defer cancel()
 
@@ -241,20 +264,25 @@ func main() {
 	// Snapshot writer.
 	snapCh := make(chan snapshot.SnapshotV1, 2)
 	w.SetSnapshotSink(snapCh)
+	snapWriter := snapshot.NewChainWriter(filepath.Join(worldDir, "snapshots"), tune.SnapshotFullEvery)
 	go func() {
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case snap := <-snapCh:
-				path := filepath.Join(worldDir, "snapshots", fmt.Sprintf("%d.snap.zst", snap.Header.Tick))
-				if err := snapshot.WriteSnapshot(path, snap); err != nil {
+				path, delta, err := snapWriter.Write(snap, archive.IsSeasonEnd(snap))
+				if err != nil {
 					logger.Printf("snapshot write: %v", err)
 					continue
 				}
 
 				if r2Mirror != nil && r2Mirror.enabled {
-					r2Mirror.Enqueue(path)
+					base := ""
+					if delta {
+						base = snapWriter.BasePath()
+					}
+					r2Mirror.EnqueueSnapshot(path, base)
 				}
 
 				if idx != nil {
@@ -265,7 +293,7 @@ func main() {
 					} else if ok {
 						idx.RecordSeason(season, snap.Header.Tick, archivedPath, snap.Seed)
 						if r2Mirror != nil && r2Mirror.enabled {
-							r2Mirror.Enqueue(archivedPath)
+							r2Mirror.EnqueueSnapshot(archivedPath, "")
 							enqueueIfExists(r2Mirror, filepath.Join(filepath.Dir(archivedPath), "meta.json"))
 						}
 					}
@@ -276,7 +304,7 @@ func main() {
 				if _, archivedPath, ok, err := archive.ArchiveSeasonSnapshot(worldDir, path, snap); err != nil {
 					logger.Printf("archive season snapshot: %v", err)
 				} else if ok && r2Mirror != nil && r2Mirror.enabled {
-					r2Mirror.Enqueue(archivedPath)
+					r2Mirror.EnqueueSnapshot(archivedPath, "")
 					enqueueIfExists(r2Mirror, filepath.Join(filepath.Dir(archivedPath), "meta.json"))
 				}
 			}
@@ -319,6 +347,9 @@ func main() {
 		fmt.Fprintf(rw, "# HELP voxelcraft_world_loaded_chunks Loaded chunk count.\n")
 		fmt.Fprintf(rw, "# TYPE voxelcraft_world_loaded_chunks gauge\n")
 		fmt.Fprintf(rw, "voxelcraft_world_loaded_chunks{world=%q} %d\n", *worldID, m.LoadedChunks)
+		fmt.Fprintf(rw, "# HELP voxelcraft_world_evicted_chunks Modified chunks spilled to region files.\n")
+		fmt.Fprintf(rw, "# TYPE voxelcraft_world_evicted_chunks gauge\n")
+		fmt.Fprintf(rw, "voxelcraft_world_evicted_chunks{world=%q} %d\n", *worldID, m.EvictedChunks)
 
 		fmt.Fprintf(rw, "# HELP voxelcraft_world_queue_depth Channel backlog depth.\n")
 		fmt.Fprintf(rw, "# TYPE voxelcraft_world_queue_depth gauge\n")
@@ -410,7 +441,11 @@ func main() {
 	} else {
 		logger.Printf("pprof endpoints disabled (VC_ENABLE_PPROF_HTTP=false)")
 	}
-	mux.HandleFunc("/v1/ws", ws.NewServer(w, logger).Handler())
+	wsSrv := ws.NewServer(w, logger)
+	if err := configureWSAuth(wsSrv, *dataDir, logger); err != nil {
+		logger.Fatalf("%v", err)
+	}
+	mux.HandleFunc("/v1/ws", wsSrv.Handler())
 
 	srv := &http.Server{
 		Addr:              *addr,
@@ -443,32 +478,7 @@ func signalContext() (context.Context, context.CancelFunc) {
 }
 
 func latestSnapshot(worldDir string) string {
-	dir := filepath.Join(worldDir, "snapshots")
-	ents, err := os.ReadDir(dir)
-	if err != nil {
-		return ""
-	}
-	var best string
-	var bestTick uint64
-	for _, e := range ents {
-		if e.IsDir() {
-			continue
-		}
-		name := e.Name()
-		if !strings.HasSuffix(name, ".snap.zst") {
-			continue
-		}
-		base := strings.TrimSuffix(name, ".snap.zst")
-		tick, err := strconv.ParseUint(base, 10, 64)
-		if err != nil {
-			continue
-		}
-		if best == "" || tick > bestTick {
-			bestTick = tick
-			best = filepath.Join(dir, name)
-		}
-	}
-	return best
+	return snapshot.LatestInDir(filepath.Join(worldDir, "snapshots"), 0)
 }
 
 func isLoopbackRemote(remoteAddr string) bool {
@@ -67,10 +67,13 @@ func runMultiWorld(rtCfg serverRuntimeConfig, cfg multiworld.Config, tune tuning
 			ResetEveryTicks:                 spec.ResetEveryTicks,
 			ResetNoticeTicks:                spec.ResetNoticeTicks,
 			ObsRadius:                       tune.ObsRadius,
-			Height:                          1,
+			ObsRadiusMin:                    tune.ObsRadiusMin,
+			ObsRadiusMax:                    tune.ObsRadiusMax,
+			Height:                          spec.Height,
 			Seed:                            rtCfg.Seed + spec.SeedOffset,
 			BoundaryR:                       spec.BoundaryR,
 			SwitchCooldownTicks:             spec.SwitchCooldownTicks,
+			PermitIDs:                       cfg.PermitIDs(spec.ID),
 			AllowClaims:                     spec.AllowClaims,
 			AllowMine:                       spec.AllowMine,
 			AllowPlace:                      spec.AllowPlace,
@@ -87,6 +90,11 @@ func runMultiWorld(rtCfg serverRuntimeConfig, cfg multiworld.Config, tune tuning
 			StarterItems:                    tune.StarterItems,
 			SnapshotEveryTicks:              tune.SnapshotEveryTicks,
 			DirectorEveryTicks:              tune.DirectorEveryTicks,
+			ChunkIdleTicks:                  tune.ChunkIdleTicks,
+			TaskQueueDepth:                  tune.TaskQueueDepth,
+			TriggerBudgetPerTick:            tune.TriggerBudgetPerTick,
+			WaterUpdatesPerTick:             tune.WaterUpdatesPerTick,
+			SignalUpdatesPerTick:            tune.SignalUpdatesPerTick,
 			RateLimits: world.RateLimitConfig{
 				SayWindowTicks:        tune.RateLimits.SayWindowTicks,
 				SayMax:                tune.RateLimits.SayMax,
@@ -112,6 +120,9 @@ func runMultiWorld(rtCfg serverRuntimeConfig, cfg multiworld.Config, tune tuning
 		if err != nil {
 			logger.Fatalf("create world (%s): %v", spec.ID, err)
 		}
+		if err := w.SetChunkRegionDir(filepath.Join(worldDir, "regions")); err != nil {
+			logger.Fatalf("chunk regions (%s): %v", spec.ID, err)
+		}
 
 		logOpts := persistlog.LoggerOptions{}
 		if r2Mirror != nil && r2Mirror.enabled {
@@ -127,19 +138,24 @@ func runMultiWorld(rtCfg serverRuntimeConfig, cfg multiworld.Config, tune tuning
 
 		snapCh := make(chan snapshot.SnapshotV1, 2)
 		w.SetSnapshotSink(snapCh)
+		snapWriter := snapshot.NewChainWriter(filepath.Join(worldDir, "snapshots"), tune.SnapshotFullEvery)
 		go func(worldID, dir string, db runtimeIndex) {
 			for {
 				select {
 				case <-ctx.Done():
 					return
 				case snap := <-snapCh:
-					path := filepath.Join(dir, "snapshots", fmt.Sprintf("%d.snap.zst", snap.Header.Tick))
-					if err := snapshot.WriteSnapshot(path, snap); err != nil {
+					path, delta, err := snapWriter.Write(snap, archive.IsSeasonEnd(snap))
+					if err != nil {
 						logger.Printf("snapshot write (%s): %v", worldID, err)
 						continue
 					}
 					if r2Mirror != nil && r2Mirror.enabled {
-						r2Mirror.Enqueue(path)
+						base := ""
+						if delta {
+							base = snapWriter.BasePath()
+						}
+						r2Mirror.EnqueueSnapshot(path, base)
 					}
 					if db != nil {
 						db.RecordSnapshot(path, snap)
@@ -149,7 +165,7 @@ func runMultiWorld(rtCfg serverRuntimeConfig, cfg multiworld.Config, tune tuning
 						} else if ok {
 							db.RecordSeason(season, snap.Header.Tick, archivedPath, snap.Seed)
 							if r2Mirror != nil && r2Mirror.enabled {
-								r2Mirror.Enqueue(archivedPath)
+								r2Mirror.EnqueueSnapshot(archivedPath, "")
 								enqueueIfExists(r2Mirror, filepath.Join(filepath.Dir(archivedPath), "meta.json"))
 							}
 						}
@@ -157,7 +173,7 @@ func runMultiWorld(rtCfg serverRuntimeConfig, cfg multiworld.Config, tune tuning
 						if _, archivedPath, ok, err := archive.ArchiveSeasonSnapshot(dir, path, snap); err != nil {
 							logger.Printf("archive season snapshot (%s): %v", worldID, err)
 						} else if ok && r2Mirror != nil && r2Mirror.enabled {
-							r2Mirror.Enqueue(archivedPath)
+							r2Mirror.EnqueueSnapshot(archivedPath, "")
 							enqueueIfExists(r2Mirror, filepath.Join(filepath.Dir(archivedPath), "meta.json"))
 						}
 					}
@@ -197,7 +213,11 @@ func runMultiWorld(rtCfg serverRuntimeConfig, cfg multiworld.Config, tune tuning
 
 	enableAdminHTTP := envBool("VC_ENABLE_ADMIN_HTTP", defaultEnableAdminHTTP())
 	enablePprofHTTP := envBool("VC_ENABLE_PPROF_HTTP", false)
-	mux := buildMultiWorldMux(mgr, logger, r2Mirror, enableAdminHTTP, enablePprofHTTP)
+	wsSrv := ws.NewManagedServer(mgr, logger)
+	if err := configureWSAuth(wsSrv, rtCfg.DataDir, logger); err != nil {
+		logger.Fatalf("%v", err)
+	}
+	mux := buildMultiWorldMux(mgr, logger, r2Mirror, wsSrv, enableAdminHTTP, enablePprofHTTP)
 	srv := &http.Server{
 		Addr:              rtCfg.Addr,
 		Handler:           mux,
@@ -217,7 +237,7 @@ func runMultiWorld(rtCfg serverRuntimeConfig, cfg multiworld.Config, tune tuning
 	}
 }
 
-func buildMultiWorldMux(mgr *multiworld.Manager, logger *log.Logger, r2Mirror *r2MirrorRuntime, enableAdminHTTP bool, enablePprofHTTP bool) *http.ServeMux {
+func buildMultiWorldMux(mgr *multiworld.Manager, logger *log.Logger, r2Mirror *r2MirrorRuntime, wsSrv *ws.Server, enableAdminHTTP bool, enablePprofHTTP bool) *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", func(rw http.ResponseWriter, r *http.Request) {
 		rw.WriteHeader(200)
@@ -236,6 +256,7 @@ func buildMultiWorldMux(mgr *multiworld.Manager, logger *log.Logger, r2Mirror *r
 			fmt.Fprintf(rw, "voxelcraft_world_online_agents{world=%q} %d\n", worldID, m.Agents)
 			fmt.Fprintf(rw, "voxelcraft_world_clients{world=%q} %d\n", worldID, m.Clients)
 			fmt.Fprintf(rw, "voxelcraft_world_loaded_chunks{world=%q} %d\n", worldID, m.LoadedChunks)
+			fmt.Fprintf(rw, "voxelcraft_world_evicted_chunks{world=%q} %d\n", worldID, m.EvictedChunks)
 			fmt.Fprintf(rw, "voxelcraft_world_reset_total{world=%q} %d\n", worldID, m.ResetTotal)
 			resourceKeys := make([]string, 0, len(m.ResourceDensity))
 			for resource := range m.ResourceDensity {
@@ -348,7 +369,10 @@ func buildMultiWorldMux(mgr *multiworld.Manager, logger *log.Logger, r2Mirror *r
 	} else {
 		logger.Printf("pprof endpoints disabled (VC_ENABLE_PPROF_HTTP=false)")
 	}
-	mux.HandleFunc("/v1/ws", ws.NewManagedServer(mgr, logger).Handler())
+	if wsSrv == nil {
+		wsSrv = ws.NewManagedServer(mgr, logger)
+	}
+	mux.HandleFunc("/v1/ws", wsSrv.Handler())
 	return mux
 }
 
@@ -74,6 +74,13 @@ func (r *r2MirrorRuntime) Enqueue(localPath string) {
 	r.mirror.Enqueue(localPath)
 }
 
+func (r *r2MirrorRuntime) EnqueueSnapshot(localPath, basePath string) {
+	if r == nil || !r.enabled || r.mirror == nil {
+		return
+	}
+	r.mirror.EnqueueSnapshot(localPath, basePath)
+}
+
 func (r *r2MirrorRuntime) Stats() r2MirrorStats {
 	if r == nil || !r.enabled || r.mirror == nil {
 		return r2MirrorStats{Enabled: false}
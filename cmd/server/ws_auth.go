@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"voxelcraft.ai/internal/transport/ws"
+	"voxelcraft.ai/internal/transport/wsauth"
+)
+
+// configureWSAuth enables agent HELLO authentication when any backend is configured:
+//   - VC_WS_AUTH_API_KEYS_FILE: static API key file (sha256 digests)
+//   - VC_WS_AUTH_HMAC_SECRET (fallback VC_MCP_HMAC_SECRET): HMAC-signed bearer tokens
+//   - VC_WS_AUTH_ACCOUNTS_FILE: local account store
+//
+// Identity -> agent id bindings persist in <data>/ws_identities.json.
+func configureWSAuth(s *ws.Server, dataDir string, logger *log.Logger) error {
+	var chain wsauth.Chain
+	var backends []string
+
+	if p := strings.TrimSpace(os.Getenv("VC_WS_AUTH_API_KEYS_FILE")); p != "" {
+		keys, err := wsauth.LoadAPIKeys(p)
+		if err != nil {
+			return fmt.Errorf("ws auth api keys: %w", err)
+		}
+		chain = append(chain, keys)
+		backends = append(backends, "api_key")
+	}
+	secret := strings.TrimSpace(os.Getenv("VC_WS_AUTH_HMAC_SECRET"))
+	if secret == "" {
+		secret = strings.TrimSpace(os.Getenv("VC_MCP_HMAC_SECRET"))
+	}
+	if secret != "" {
+		chain = append(chain, wsauth.HMACTokens{Secret: []byte(secret)})
+		backends = append(backends, "hmac_token")
+	}
+	if p := strings.TrimSpace(os.Getenv("VC_WS_AUTH_ACCOUNTS_FILE")); p != "" {
+		accounts, err := wsauth.OpenAccounts(p)
+		if err != nil {
+			return fmt.Errorf("ws auth accounts: %w", err)
+		}
+		chain = append(chain, accounts)
+		backends = append(backends, "account")
+	}
+
+	if len(chain) == 0 {
+		logger.Printf("ws auth disabled (no VC_WS_AUTH_* backend configured)")
+		return nil
+	}
+	bindings, err := wsauth.OpenBindings(filepath.Join(dataDir, "ws_identities.json"))
+	if err != nil {
+		return fmt.Errorf("ws auth bindings: %w", err)
+	}
+	s.SetAuth(chain, bindings)
+	logger.Printf("ws auth enabled backends=%v", backends)
+	return nil
+}
@@ -0,0 +1,16 @@
+// Package hmacsig is the hex HMAC-SHA256 signature shared by the MCP sidecar's request
+// signing and the WebSocket session tokens.
+package hmacsig
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Sign returns hex(HMAC-SHA256(secret, canonical)).
+func Sign(secret []byte, canonical string) string {
+	h := hmac.New(sha256.New, secret)
+	_, _ = h.Write([]byte(canonical))
+	return hex.EncodeToString(h.Sum(nil))
+}
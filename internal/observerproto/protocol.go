@@ -52,6 +52,9 @@ type TickMsg struct {
 	Leaves  []string         `json:"leaves,omitempty"`
 	Actions []RecordedAction `json:"actions,omitempty"`
 	Audits  []AuditEntry     `json:"audits,omitempty"`
+
+	// Order books of every MARKET_TERMINAL with resting orders.
+	Markets []protocol.MarketObs `json:"markets,omitempty"`
 }
 
 type JoinInfo struct {
@@ -285,6 +285,8 @@ func (s *Session) GetObs(ctx context.Context, opts GetObsOpts) (ObsResult, error
 			Tasks:           o.Tasks,
 			FunScore:        o.FunScore,
 			PublicBoards:    o.PublicBoards,
+			Markets:         o.Markets,
+			Prices:          o.Prices,
 		}
 		b, _ := json.Marshal(sum)
 		return ObsResult{Tick: tick, AgentID: agentID, ObsID: o.ObsID, EventsCursor: o.EventsCursor, Obs: b}, nil
@@ -312,7 +314,9 @@ type obsSummary struct {
 
 	FunScore *protocol.FunScoreObs `json:"fun_score,omitempty"`
 
-	PublicBoards []protocol.BoardObs `json:"public_boards,omitempty"`
+	PublicBoards []protocol.BoardObs  `json:"public_boards,omitempty"`
+	Markets      []protocol.MarketObs `json:"markets,omitempty"`
+	Prices       []protocol.PriceObs  `json:"prices,omitempty"`
 }
 
 func (s *Session) GetCatalog(ctx context.Context, name string) (CatalogResult, error) {
@@ -414,7 +418,13 @@ func (s *Session) Act(ctx context.Context, args ActArgs) (ActResult, error) {
 			act.ExpectedWorldID = worldID
 		}
 	}
-	b, _ := json.Marshal(act)
+	s.mu.RLock()
+	encoding := s.welcome.ServerCapabilities.Encoding
+	s.mu.RUnlock()
+	frame, b, err := encodeWire(encoding, act)
+	if err != nil {
+		return ActResult{}, err
+	}
 
 	s.writeMu.Lock()
 	defer s.writeMu.Unlock()
@@ -425,7 +435,7 @@ func (s *Session) Act(ctx context.Context, args ActArgs) (ActResult, error) {
 		return ActResult{}, fmt.Errorf("not connected")
 	}
 	_ = conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
-	if err := conn.WriteMessage(websocket.TextMessage, b); err != nil {
+	if err := conn.WriteMessage(frame, b); err != nil {
 		return ActResult{}, err
 	}
 	res := ActResult{Sent: true, TickUsed: tickUsed, AgentID: agentID, ActID: act.ActID}
@@ -607,6 +617,7 @@ func (s *Session) connectAndReadLoop() error {
 			DeltaVoxels: true,
 			AckRequired: true,
 			EventCursor: true,
+			Encodings:   []string{protocol.EncodingCBOR, protocol.EncodingJSON},
 		},
 	}
 	s.mu.RLock()
@@ -636,11 +647,16 @@ func (s *Session) connectAndReadLoop() error {
 		}
 
 		_ = conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-		_, msg, err := conn.ReadMessage()
+		mt, msg, err := conn.ReadMessage()
 		if err != nil {
 			_ = conn.Close()
 			return err
 		}
+		if mt == websocket.BinaryMessage {
+			if msg, err = protocol.ToJSON(protocol.EncodingCBOR, msg); err != nil {
+				continue
+			}
+		}
 		base, err := protocol.DecodeBase(msg)
 		if err != nil {
 			continue
@@ -841,10 +857,16 @@ func (s *Session) getEventsRemote(ctx context.Context, sinceCursor uint64, limit
 		return GetEventsResult{}, false
 	}
 
-	s.writeMu.Lock()
-	_ = conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
-	err := conn.WriteJSON(req)
-	s.writeMu.Unlock()
+	s.mu.RLock()
+	encoding := s.welcome.ServerCapabilities.Encoding
+	s.mu.RUnlock()
+	frame, b, err := encodeWire(encoding, req)
+	if err == nil {
+		s.writeMu.Lock()
+		_ = conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+		err = conn.WriteMessage(frame, b)
+		s.writeMu.Unlock()
+	}
 	if err != nil {
 		cleanup()
 		return GetEventsResult{}, false
@@ -868,3 +890,15 @@ func (s *Session) getEventsRemote(ctx context.Context, sinceCursor uint64, limit
 		return GetEventsResult{Events: out, NextCursor: batch.NextCursor}, true
 	}
 }
+
+// encodeWire encodes an outbound message in the encoding negotiated in WELCOME.
+func encodeWire(encoding string, v any) (int, []byte, error) {
+	b, err := protocol.Marshal(encoding, v)
+	if err != nil {
+		return 0, nil, err
+	}
+	if protocol.IsBinaryEncoding(encoding) {
+		return websocket.BinaryMessage, b, nil
+	}
+	return websocket.TextMessage, b, nil
+}
@@ -1,15 +1,14 @@
 package mcp
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	"voxelcraft.ai/internal/hmacsig"
 )
 
 const (
@@ -27,12 +26,6 @@ func canonicalStringV2(ts string, method string, pathname string, agentID string
 	return ts + "\n" + strings.ToUpper(method) + "\n" + pathname + "\n" + strings.TrimSpace(agentID) + "\n" + strings.TrimSpace(nonce) + "\n" + string(rawBody)
 }
 
-func signHMAC(secret []byte, canonical string) string {
-	h := hmac.New(sha256.New, secret)
-	_, _ = h.Write([]byte(canonical))
-	return hex.EncodeToString(h.Sum(nil))
-}
-
 type hmacVerifyResult struct {
 	SessionKey string
 	Signature  string
@@ -71,7 +64,7 @@ func verifyHMAC(r *http.Request, rawBody []byte, secret []byte, now time.Time) h
 
 	if nonce != "" {
 		canonV2 := canonicalStringV2(tsStr, r.Method, r.URL.Path, agentID, nonce, rawBody)
-		expV2 := signHMAC(secret, canonV2)
+		expV2 := hmacsig.Sign(secret, canonV2)
 		if sig == expV2 {
 			return hmacVerifyResult{SessionKey: agentID, Signature: sig, HTTPStatus: 0}
 		}
@@ -79,7 +72,7 @@ func verifyHMAC(r *http.Request, rawBody []byte, secret []byte, now time.Time) h
 
 	if allowLegacy {
 		canon := canonicalString(tsStr, r.Method, r.URL.Path, rawBody)
-		exp := signHMAC(secret, canon)
+		exp := hmacsig.Sign(secret, canon)
 		if sig == exp {
 			return hmacVerifyResult{SessionKey: agentID, Signature: sig, HTTPStatus: 0}
 		}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"voxelcraft.ai/internal/hmacsig"
 )
 
 func TestHMAC_SignAndVerify_Vector(t *testing.T) {
@@ -17,7 +19,7 @@ func TestHMAC_SignAndVerify_Vector(t *testing.T) {
 
 	nonce := "nonce_1"
 	canon := canonicalStringV2(ts, method, path, "agent_1", nonce, body)
-	got := signHMAC(secret, canon)
+	got := hmacsig.Sign(secret, canon)
 	want := "7695d5b4887f724294f4b74ad71d191bbf7b6ed01c87a9fb5964459543a9af47"
 	if got != want {
 		t.Fatalf("signature mismatch: got=%s want=%s", got, want)
@@ -43,7 +45,7 @@ func TestHMAC_Verify_Expired(t *testing.T) {
 	ts := "1700000000000"
 	body := []byte("{\"jsonrpc\":\"2.0\"}")
 	nonce := "nonce_2"
-	sig := signHMAC(secret, canonicalStringV2(ts, "POST", "/mcp", "agent_1", nonce, body))
+	sig := hmacsig.Sign(secret, canonicalStringV2(ts, "POST", "/mcp", "agent_1", nonce, body))
 
 	req, _ := http.NewRequest("POST", "http://example.invalid/mcp", bytes.NewReader(body))
 	req.Header.Set(headerAgentID, "agent_1")
@@ -78,7 +80,7 @@ func TestHMAC_Verify_MissingNonce_WhenLegacyDisabled(t *testing.T) {
 	secret := []byte("topsecret")
 	ts := "1700000000000"
 	body := []byte("{\"jsonrpc\":\"2.0\"}")
-	sig := signHMAC(secret, canonicalString(ts, "POST", "/mcp", body))
+	sig := hmacsig.Sign(secret, canonicalString(ts, "POST", "/mcp", body))
 
 	req, _ := http.NewRequest("POST", "http://example.invalid/mcp", bytes.NewReader(body))
 	req.Header.Set(headerAgentID, "agent_1")
@@ -108,7 +110,7 @@ func TestHMAC_Verify_LegacyAllowed(t *testing.T) {
 	secret := []byte("topsecret")
 	ts := "1700000000000"
 	body := []byte("{\"jsonrpc\":\"2.0\"}")
-	sig := signHMAC(secret, canonicalString(ts, "POST", "/mcp", body))
+	sig := hmacsig.Sign(secret, canonicalString(ts, "POST", "/mcp", body))
 
 	req, _ := http.NewRequest("POST", "http://example.invalid/mcp", bytes.NewReader(body))
 	req.Header.Set(headerAgentID, "agent_1")
@@ -235,7 +235,7 @@ func (s *Server) toolsList() []map[string]any {
 		},
 		{
 			"name":        "voxelcraft.get_catalog",
-			"description": "Get a catalog (block_palette/item_palette/tuning/recipes/blueprints/law_templates/events).",
+			"description": "Get a catalog (block_palette/item_palette/tuning/recipes/blueprints/law_templates/events/price_index).",
 			"inputSchema": map[string]any{
 				"type": "object",
 				"properties": map[string]any{
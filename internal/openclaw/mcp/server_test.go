@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"voxelcraft.ai/internal/hmacsig"
 	"voxelcraft.ai/internal/openclaw/bridge"
 )
 
@@ -300,7 +301,7 @@ func TestMCP_HMACReplayRejected(t *testing.T) {
 	payload := []byte(`{"jsonrpc":"2.0","id":1,"method":"list_tools"}`)
 	tsStr := "1700000000000"
 	nonce := "nonce_replay"
-	sig := signHMAC([]byte("topsecret"), canonicalStringV2(tsStr, "POST", "/mcp", "agent_1", nonce, payload))
+	sig := hmacsig.Sign([]byte("topsecret"), canonicalStringV2(tsStr, "POST", "/mcp", "agent_1", nonce, payload))
 	headers := map[string]string{
 		headerAgentID:   "agent_1",
 		headerTS:        tsStr,
@@ -21,6 +21,16 @@ type SeasonArchiveMeta struct {
 	SeasonTicks int    `json:"season_length_ticks"`
 }
 
+// IsSeasonEnd reports whether snap is the last tick of a season (tick = seasonLen*k - 1).
+// Season-end snapshots must be written as full snapshots so the archive copy stands alone.
+func IsSeasonEnd(snap snapshot.SnapshotV1) bool {
+	if snap.SeasonLengthTicks <= 0 {
+		return false
+	}
+	seasonLen := uint64(snap.SeasonLengthTicks)
+	return (snap.Header.Tick+1)%seasonLen == 0 && (snap.Header.Tick+1)/seasonLen > 0
+}
+
 // ArchiveSeasonSnapshot copies a season-end snapshot into `worldDir/archives/season_<NNN>/`.
 // It returns (season, archivedPath, archived=true) when the snapshot represents a season end.
 func ArchiveSeasonSnapshot(worldDir, snapshotPath string, snap snapshot.SnapshotV1) (season int, archivedPath string, archived bool, err error) {
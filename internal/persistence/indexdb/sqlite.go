@@ -262,6 +262,7 @@ func initSchema(db *sql.DB) error {
 			allow_break INTEGER NOT NULL,
 			allow_damage INTEGER NOT NULL,
 			allow_trade INTEGER NOT NULL,
+			-- Pre law-DSL columns, kept for old index files; always zero now (see policies_json).
 			market_tax REAL NOT NULL,
 			curfew_enabled INTEGER NOT NULL,
 			curfew_start REAL NOT NULL,
@@ -275,6 +276,7 @@ func initSchema(db *sql.DB) error {
 			maintenance_due_tick INTEGER NOT NULL,
 			maintenance_stage INTEGER NOT NULL,
 			members_json TEXT NOT NULL,
+			policies_json TEXT NOT NULL DEFAULT '[]',
 			PRIMARY KEY (tick, land_id)
 		);`,
 		`CREATE INDEX IF NOT EXISTS idx_snapshot_claims_owner_tick ON snapshot_claims(owner, tick);`,
@@ -387,7 +389,38 @@ func initSchema(db *sql.DB) error {
 			return err
 		}
 	}
-	return nil
+	// Added with the law DSL; indexes created before it lack the column.
+	return addColumnIfMissing(db, "snapshot_claims", "policies_json", `TEXT NOT NULL DEFAULT '[]'`)
+}
+
+func addColumnIfMissing(db *sql.DB, table, column, decl string) error {
+	rows, err := db.Query(`PRAGMA table_info(` + table + `)`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			cid     int
+			name    string
+			typ     string
+			notNull int
+			dflt    sql.NullString
+			pk      int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	_, err = db.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + decl)
+	return err
 }
 
 func (s *SQLiteIndex) Close() error {
@@ -584,7 +617,7 @@ func (s *SQLiteIndex) UpsertCatalogs(configDir string, cats *catalogs.Catalogs,
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	if _, err := tx.Exec(`INSERT OR REPLACE INTO meta(key,value) VALUES('schema_version','3')`); err != nil {
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO meta(key,value) VALUES('schema_version','4')`); err != nil {
 		return err
 	}
 	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO catalogs(name,digest,json,updated_at) VALUES(?,?,?,?)`)
@@ -647,8 +680,8 @@ func (s *SQLiteIndex) loop() {
 		market_tax,curfew_enabled,curfew_start,curfew_end,
 		fine_break_enabled,fine_break_item,fine_break_per_block,
 		access_pass_enabled,access_ticket_item,access_ticket_cost,
-		maintenance_due_tick,maintenance_stage,members_json
-	) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
+		maintenance_due_tick,maintenance_stage,members_json,policies_json
+	) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
 	insertSnapOrg, _ := s.db.Prepare(`INSERT OR REPLACE INTO snapshot_orgs(
 		tick,org_id,kind,name,created_tick,members_json,treasury_json
 	) VALUES(?,?,?,?,?,?,?)`)
@@ -942,6 +975,11 @@ nextReq:
 					break
 				}
 				membersJSON, _ := json.Marshal(c.Members)
+				policies := c.Policies
+				if policies == nil {
+					policies = []snapshot.PolicyV1{}
+				}
+				policiesJSON, _ := json.Marshal(policies)
 				if _, err := tx.Stmt(insertSnapClaim).Exec(
 					tick,
 					c.LandID,
@@ -949,12 +987,13 @@ nextReq:
 					c.Anchor[0], c.Anchor[1], c.Anchor[2],
 					c.Radius,
 					btoi(c.Flags.AllowBuild), btoi(c.Flags.AllowBreak), btoi(c.Flags.AllowDamage), btoi(c.Flags.AllowTrade),
-					c.MarketTax,
-					btoi(c.CurfewEnabled), c.CurfewStart, c.CurfewEnd,
-					btoi(c.FineBreakEnabled), c.FineBreakItem, c.FineBreakPerBlock,
-					btoi(c.AccessPassEnabled), c.AccessTicketItem, c.AccessTicketCost,
+					0.0,
+					0, 0.0, 0.0,
+					0, "", 0,
+					0, "", 0,
 					int64(c.MaintenanceDueTick), c.MaintenanceStage,
 					string(membersJSON),
+					string(policiesJSON),
 				); err != nil {
 					rollback()
 					continue nextReq
@@ -3,6 +3,7 @@ package indexdb
 import (
 	"database/sql"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"voxelcraft.ai/internal/persistence/snapshot"
@@ -74,13 +75,17 @@ func TestSQLiteIndex_RecordSnapshotState_WritesStateTables(t *testing.T) {
 		},
 		Claims: []snapshot.ClaimV1{
 			{
-				LandID:             "LAND1",
-				Owner:              "A1",
-				Anchor:             [3]int{1, 22, 3},
-				Radius:             32,
-				Flags:              snapshot.ClaimFlagsV1{AllowBuild: true, AllowBreak: false, AllowDamage: false, AllowTrade: true},
-				Members:            []string{"A2"},
-				MarketTax:          0.05,
+				LandID:  "LAND1",
+				Owner:   "A1",
+				Anchor:  [3]int{1, 22, 3},
+				Radius:  32,
+				Flags:   snapshot.ClaimFlagsV1{AllowBuild: true, AllowBreak: false, AllowDamage: false, AllowTrade: true},
+				Members: []string{"A2"},
+				Policies: []snapshot.PolicyV1{{
+					LawID:      "LAW1",
+					TemplateID: "MARKET_TAX",
+					Rules:      []snapshot.PolicyRuleV1{{Actions: []string{"TRADE"}, Effect: "TAX", Rate: 0.05}},
+				}},
 				MaintenanceDueTick: 999,
 				MaintenanceStage:   0,
 			},
@@ -184,6 +189,15 @@ func TestSQLiteIndex_RecordSnapshotState_WritesStateTables(t *testing.T) {
 			t.Fatalf("snapshot_world mismatch: weather=%q until=%d event=%q start=%d ends=%d center=%d,%d,%d radius=%d", weather, until, activeID, startTick, endsTick, cx, cy, cz, radius)
 		}
 	}
+	{
+		var policiesJSON string
+		if err := db.QueryRow(`SELECT policies_json FROM snapshot_claims WHERE tick = ? AND land_id = ?`, 123, "LAND1").Scan(&policiesJSON); err != nil {
+			t.Fatalf("scan snapshot_claims: %v", err)
+		}
+		if !strings.Contains(policiesJSON, `"template_id":"MARKET_TAX"`) {
+			t.Fatalf("policies_json mismatch: %s", policiesJSON)
+		}
+	}
 	{
 		var (
 			kind string
@@ -25,15 +25,26 @@ type Stats struct {
 	LastErrorUnix       int64
 }
 
+// mirrorJob uploads path; for snapshots, base is the full snapshot the file depends on
+// (base == path for a full snapshot itself).
+type mirrorJob struct {
+	path string
+	base string
+}
+
 type Mirror struct {
 	client  *Client
 	dataDir string
 	prefix  string
 	logger  *log.Logger
 
-	jobs chan string
+	jobs chan mirrorJob
 	wg   sync.WaitGroup
 
+	// baseMu serializes snapshot base uploads; bases maps a snapshot dir to its last uploaded base.
+	baseMu sync.Mutex
+	bases  map[string]string
+
 	enqueuedTotal       atomic.Uint64
 	queueSaturatedTotal atomic.Uint64
 	droppedTotal        atomic.Uint64
@@ -52,14 +63,15 @@ func NewMirror(client *Client, dataDir, prefix string, workers int, logger *log.
 		dataDir: dataDir,
 		prefix:  strings.Trim(strings.ReplaceAll(prefix, "\\", "/"), "/"),
 		logger:  logger,
-		jobs:    make(chan string, 2048),
+		jobs:    make(chan mirrorJob, 2048),
+		bases:   map[string]string{},
 	}
 	for i := 0; i < workers; i++ {
 		m.wg.Add(1)
 		go func() {
 			defer m.wg.Done()
-			for localPath := range m.jobs {
-				m.uploadOne(localPath)
+			for job := range m.jobs {
+				m.run(job)
 			}
 		}()
 	}
@@ -67,13 +79,38 @@ func NewMirror(client *Client, dataDir, prefix string, workers int, logger *log.
 }
 
 func (m *Mirror) Enqueue(localPath string) {
+	m.enqueue(mirrorJob{path: localPath})
+}
+
+// EnqueueSnapshot queues a snapshot file. A full snapshot (basePath == "") is never dropped:
+// it blocks until the queue has room. A delta (basePath = its full base) may be dropped under
+// pressure, since the next cumulative delta supersedes it, and is only uploaded once its base is.
+func (m *Mirror) EnqueueSnapshot(localPath, basePath string) {
+	if m == nil || m.client == nil {
+		return
+	}
+	if basePath == "" {
+		job := mirrorJob{path: localPath, base: localPath}
+		m.enqueuedTotal.Add(1)
+		select {
+		case m.jobs <- job:
+		default:
+			m.queueSaturatedTotal.Add(1)
+			m.jobs <- job
+		}
+		return
+	}
+	m.enqueue(mirrorJob{path: localPath, base: basePath})
+}
+
+func (m *Mirror) enqueue(job mirrorJob) {
 	if m == nil || m.client == nil {
 		return
 	}
 	m.enqueuedTotal.Add(1)
 
 	select {
-	case m.jobs <- localPath:
+	case m.jobs <- job:
 		return
 	default:
 	}
@@ -84,11 +121,11 @@ func (m *Mirror) Enqueue(localPath string) {
 	timer := time.NewTimer(25 * time.Millisecond)
 	defer timer.Stop()
 	select {
-	case m.jobs <- localPath:
+	case m.jobs <- job:
 		return
 	case <-timer.C:
 		m.droppedTotal.Add(1)
-		m.printf("r2 mirror drop local=%s reason=queue_saturated", localPath)
+		m.printf("r2 mirror drop local=%s reason=queue_saturated", job.path)
 	}
 }
 
@@ -117,22 +154,49 @@ func (m *Mirror) Stats() Stats {
 	}
 }
 
-func (m *Mirror) uploadOne(localPath string) {
+func (m *Mirror) run(job mirrorJob) {
+	if job.base != "" && !m.uploadBase(job.base) {
+		m.printf("r2 mirror skip local=%s reason=base_not_uploaded base=%s", job.path, job.base)
+		return
+	}
+	if job.path != job.base {
+		m.uploadOne(job.path)
+	}
+}
+
+// uploadBase uploads a delta's full base unless it already is. A base whose own job was dropped
+// or failed is retried here, so a mirrored delta always has its base next to it.
+func (m *Mirror) uploadBase(basePath string) bool {
+	m.baseMu.Lock()
+	defer m.baseMu.Unlock()
+	dir := filepath.Dir(basePath)
+	if m.bases[dir] == basePath {
+		return true
+	}
+	if !m.uploadOne(basePath) {
+		return false
+	}
+	m.bases[dir] = basePath
+	return true
+}
+
+func (m *Mirror) uploadOne(localPath string) bool {
 	key, err := m.objectKey(localPath)
 	if err != nil {
 		m.printf("r2 mirror skip local=%s err=%v", localPath, err)
-		return
+		return false
 	}
 
 	if err := m.uploadWithRetry(key, localPath); err != nil {
 		m.uploadFailTotal.Add(1)
 		m.lastErrorUnix.Store(time.Now().UTC().Unix())
 		m.printf("r2 mirror upload failed key=%s local=%s err=%v", key, localPath, err)
-		return
+		return false
 	}
 	m.uploadSuccessTotal.Add(1)
 	m.lastSuccessUnix.Store(time.Now().UTC().Unix())
 	m.printf("r2 mirror uploaded key=%s local=%s", key, localPath)
+	return true
 }
 
 func (m *Mirror) uploadWithRetry(key, localPath string) error {
@@ -0,0 +1,102 @@
+package r2s3
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"voxelcraft.ai/internal/persistence/snapshot"
+)
+
+// fakeBucket stores PUT bodies by path-style object key (/bucket/key).
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	order   []string
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "method", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	b.mu.Lock()
+	b.objects[key] = body
+	b.order = append(b.order, key)
+	b.mu.Unlock()
+}
+
+func testSnap(tick uint64, planks int) snapshot.SnapshotV1 {
+	return snapshot.SnapshotV1{
+		Header:   snapshot.Header{Version: snapshot.CurrentVersion, WorldID: "w1", Tick: tick},
+		Seed:     7,
+		Chunks:   []snapshot.ChunkV1{{CX: 0, CZ: 0, Height: 1, Blocks: []uint16{1, 1, 1, 1}}},
+		Agents:   []snapshot.AgentV1{{ID: "A1", Name: "a", Inventory: map[string]int{"PLANK": planks}}},
+		Counters: snapshot.CountersV1{NextAgent: 2},
+	}
+}
+
+func TestMirror_DeltaChainRestoresFromBucket(t *testing.T) {
+	bucket := &fakeBucket{objects: map[string][]byte{}}
+	srv := httptest.NewServer(bucket)
+	defer srv.Close()
+	client, err := New(srv.URL, "bucket", "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataDir := t.TempDir()
+	cw := snapshot.NewChainWriter(filepath.Join(dataDir, "worlds", "w1", "snapshots"), 4)
+	if _, delta, err := cw.Write(testSnap(100, 1), false); err != nil || delta {
+		t.Fatalf("base write: delta=%v err=%v", delta, err)
+	}
+	deltaPath, delta, err := cw.Write(testSnap(200, 5), false)
+	if err != nil || !delta {
+		t.Fatalf("delta write: delta=%v err=%v", delta, err)
+	}
+
+	// Only the delta is queued, e.g. because the base's own job was lost; the mirror must
+	// still upload the base, and before the delta.
+	m := NewMirror(client, dataDir, "prefix", 2, nil)
+	m.EnqueueSnapshot(deltaPath, cw.BasePath())
+	m.Close()
+
+	wantOrder := []string{"prefix/worlds/w1/snapshots/100.snap.zst", "prefix/worlds/w1/snapshots/200.delta.snap.zst"}
+	if !reflect.DeepEqual(bucket.order, wantOrder) {
+		t.Fatalf("upload order=%v want %v", bucket.order, wantOrder)
+	}
+
+	restoreDir := t.TempDir()
+	for key, body := range bucket.objects {
+		p := filepath.Join(restoreDir, filepath.FromSlash(strings.TrimPrefix(key, "prefix/")))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, body, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := snapshot.ReadSnapshot(filepath.Join(restoreDir, "worlds", "w1", "snapshots", "200.delta.snap.zst"))
+	if err != nil {
+		t.Fatalf("restore delta: %v", err)
+	}
+	want, err := snapshot.ReadSnapshot(deltaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) || got.Agents[0].Inventory["PLANK"] != 5 {
+		t.Fatalf("restored snapshot mismatch:\ngot=%+v\nwant=%+v", got, want)
+	}
+}
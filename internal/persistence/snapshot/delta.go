@@ -0,0 +1,345 @@
+package snapshot
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// VersionV1 files hold one full SnapshotV1.
+	VersionV1 = 1
+	// VersionV2 files are either full (same payload as V1) or deltas against a full base.
+	VersionV2 = 2
+
+	CurrentVersion = VersionV2
+
+	KindFull  = ""
+	KindDelta = "delta"
+)
+
+// SupportedVersion reports whether an importer can load a full snapshot with this header version.
+// V1 payloads migrate unchanged: V2 only adds the delta container around the same full state.
+func SupportedVersion(v int) bool { return v == VersionV1 || v == VersionV2 }
+
+// DeltaV2 holds the state changed since a full base snapshot. Deltas are cumulative against
+// their base (not the previous delta), so loading needs only the base plus one delta.
+//
+// State carries every scalar section in full (config, counters, weather, stats, ...); its keyed
+// sections (chunks, agents, claims, ...) hold only entries that are new or changed since the base.
+type DeltaV2 struct {
+	Header Header
+	State  SnapshotV1
+	// Removed lists, per section, keys present in the base but gone at Header.Tick.
+	Removed map[string][]string
+}
+
+// FileName returns the on-disk name for a snapshot at tick.
+func FileName(tick uint64, delta bool) string {
+	if delta {
+		return fmt.Sprintf("%d.delta.snap.zst", tick)
+	}
+	return fmt.Sprintf("%d.snap.zst", tick)
+}
+
+// ParseFileName parses names produced by FileName. Other *.snap.zst files (e.g. rollback outputs) are rejected.
+func ParseFileName(name string) (tick uint64, delta bool, ok bool) {
+	base, found := strings.CutSuffix(name, ".snap.zst")
+	if !found {
+		return 0, false, false
+	}
+	base, delta = strings.CutSuffix(base, ".delta")
+	t, err := strconv.ParseUint(base, 10, 64)
+	if err != nil {
+		return 0, false, false
+	}
+	return t, delta, true
+}
+
+// LatestInDir returns the newest full or delta snapshot in dir with tick < beforeTick (0 = no bound).
+func LatestInDir(dir string, beforeTick uint64) string {
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	var best string
+	var bestTick uint64
+	var bestDelta bool
+	for _, e := range ents {
+		if e.IsDir() {
+			continue
+		}
+		tick, delta, ok := ParseFileName(e.Name())
+		if !ok || (beforeTick != 0 && tick >= beforeTick) {
+			continue
+		}
+		// A full snapshot wins over a delta at the same tick.
+		if best == "" || tick > bestTick || (tick == bestTick && bestDelta && !delta) {
+			best, bestTick, bestDelta = filepath.Join(dir, e.Name()), tick, delta
+		}
+	}
+	return best
+}
+
+type keyedSection struct {
+	name   string
+	prints func(s *SnapshotV1) map[string][32]byte
+	// keep drops entries whose key is not in keep.
+	keep func(s *SnapshotV1, keep map[string]bool)
+	// merge overlays delta entries on base entries minus removed keys.
+	merge func(dst, base, delta *SnapshotV1, removed map[string]bool)
+}
+
+func keyed[T any](name string, get func(*SnapshotV1) *[]T, key func(T) string, hash func(T) [32]byte) keyedSection {
+	if hash == nil {
+		hash = jsonHash[T]
+	}
+	return keyedSection{
+		name: name,
+		prints: func(s *SnapshotV1) map[string][32]byte {
+			items := *get(s)
+			out := make(map[string][32]byte, len(items))
+			for _, v := range items {
+				out[key(v)] = hash(v)
+			}
+			return out
+		},
+		keep: func(s *SnapshotV1, keep map[string]bool) {
+			items := *get(s)
+			out := make([]T, 0, len(keep))
+			for _, v := range items {
+				if keep[key(v)] {
+					out = append(out, v)
+				}
+			}
+			*get(s) = out
+		},
+		merge: func(dst, base, delta *SnapshotV1, removed map[string]bool) {
+			over := map[string]bool{}
+			for _, v := range *get(delta) {
+				over[key(v)] = true
+			}
+			out := make([]T, 0, len(*get(base))+len(over))
+			for _, v := range *get(base) {
+				k := key(v)
+				if !removed[k] && !over[k] {
+					out = append(out, v)
+				}
+			}
+			out = append(out, *get(delta)...)
+			sort.SliceStable(out, func(i, j int) bool { return key(out[i]) < key(out[j]) })
+			*get(dst) = out
+		},
+	}
+}
+
+func jsonHash[T any](v T) [32]byte {
+	b, _ := json.Marshal(v)
+	return sha256.Sum256(b)
+}
+
+func chunkHash(c ChunkV1) [32]byte {
+	h := sha256.New()
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], uint64(c.Height))
+	h.Write(tmp[:])
+	buf := make([]byte, 2*len(c.Blocks))
+	for i, b := range c.Blocks {
+		binary.LittleEndian.PutUint16(buf[2*i:], b)
+	}
+	h.Write(buf)
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+func posKey(p [3]int) string { return fmt.Sprintf("%d,%d,%d", p[0], p[1], p[2]) }
+
+var keyedSections = []keyedSection{
+	keyed("chunks", func(s *SnapshotV1) *[]ChunkV1 { return &s.Chunks }, func(c ChunkV1) string { return fmt.Sprintf("%d,%d", c.CX, c.CZ) }, chunkHash),
+	keyed("agents", func(s *SnapshotV1) *[]AgentV1 { return &s.Agents }, func(a AgentV1) string { return a.ID }, nil),
+	keyed("claims", func(s *SnapshotV1) *[]ClaimV1 { return &s.Claims }, func(c ClaimV1) string { return c.LandID }, nil),
+	keyed("containers", func(s *SnapshotV1) *[]ContainerV1 { return &s.Containers }, func(c ContainerV1) string { return c.Type + "@" + posKey(c.Pos) }, nil),
+	keyed("items", func(s *SnapshotV1) *[]ItemEntityV1 { return &s.Items }, func(i ItemEntityV1) string { return i.EntityID }, nil),
+	keyed("mobs", func(s *SnapshotV1) *[]MobV1 { return &s.Mobs }, func(m MobV1) string { return m.EntityID }, nil),
+	keyed("signs", func(s *SnapshotV1) *[]SignV1 { return &s.Signs }, func(v SignV1) string { return posKey(v.Pos) }, nil),
+	keyed("conveyors", func(s *SnapshotV1) *[]ConveyorV1 { return &s.Conveyors }, func(v ConveyorV1) string { return posKey(v.Pos) }, nil),
+	keyed("switches", func(s *SnapshotV1) *[]SwitchV1 { return &s.Switches }, func(v SwitchV1) string { return posKey(v.Pos) }, nil),
+	keyed("crops", func(s *SnapshotV1) *[]CropV1 { return &s.Crops }, func(v CropV1) string { return posKey(v.Pos) }, nil),
+	keyed("water", func(s *SnapshotV1) *[]WaterV1 { return &s.Water }, func(v WaterV1) string { return posKey(v.Pos) }, nil),
+	keyed("signals", func(s *SnapshotV1) *[]SignalV1 { return &s.Signals }, func(v SignalV1) string { return posKey(v.Pos) }, nil),
+	keyed("machines", func(s *SnapshotV1) *[]MachineV1 { return &s.Machines }, func(v MachineV1) string { return posKey(v.Pos) }, nil),
+	keyed("shops", func(s *SnapshotV1) *[]ShopV1 { return &s.Shops }, func(v ShopV1) string { return posKey(v.Pos) }, nil),
+	keyed("trades", func(s *SnapshotV1) *[]TradeV1 { return &s.Trades }, func(t TradeV1) string { return t.TradeID }, nil),
+	keyed("boards", func(s *SnapshotV1) *[]BoardV1 { return &s.Boards }, func(b BoardV1) string { return b.BoardID }, nil),
+	keyed("contracts", func(s *SnapshotV1) *[]ContractV1 { return &s.Contracts }, func(c ContractV1) string { return c.ContractID }, nil),
+	keyed("orders", func(s *SnapshotV1) *[]OrderV1 { return &s.Orders }, func(o OrderV1) string { return o.OrderID }, nil),
+	keyed("prices", func(s *SnapshotV1) *[]PriceSeriesV1 { return &s.Prices }, func(p PriceSeriesV1) string { return p.Item }, nil),
+	keyed("laws", func(s *SnapshotV1) *[]LawV1 { return &s.Laws }, func(l LawV1) string { return l.LawID }, nil),
+	keyed("orgs", func(s *SnapshotV1) *[]OrgV1 { return &s.Orgs }, func(o OrgV1) string { return o.OrgID }, nil),
+	keyed("structures", func(s *SnapshotV1) *[]StructureV1 { return &s.Structures }, func(v StructureV1) string { return v.StructureID }, nil),
+}
+
+// Fingerprint is the per-entity hash set of a full base snapshot, kept in memory by the writer
+// instead of the base itself.
+type Fingerprint struct {
+	Tick     uint64
+	WorldID  string
+	Seed     int64
+	sections map[string]map[string][32]byte
+}
+
+func NewFingerprint(base SnapshotV1) *Fingerprint {
+	fp := &Fingerprint{Tick: base.Header.Tick, WorldID: base.Header.WorldID, Seed: base.Seed, sections: map[string]map[string][32]byte{}}
+	for _, ks := range keyedSections {
+		fp.sections[ks.name] = ks.prints(&base)
+	}
+	return fp
+}
+
+// Diff builds the delta from the fingerprinted base to cur.
+func (fp *Fingerprint) Diff(cur SnapshotV1) DeltaV2 {
+	d := DeltaV2{
+		Header: Header{Version: VersionV2, WorldID: cur.Header.WorldID, Tick: cur.Header.Tick, Kind: KindDelta, BaseTick: fp.Tick},
+		State:  cur,
+	}
+	d.State.Header = d.Header
+	for _, ks := range keyedSections {
+		basePrints := fp.sections[ks.name]
+		curPrints := ks.prints(&cur)
+		changed := map[string]bool{}
+		for k, h := range curPrints {
+			if old, ok := basePrints[k]; !ok || old != h {
+				changed[k] = true
+			}
+		}
+		ks.keep(&d.State, changed)
+		var removed []string
+		for k := range basePrints {
+			if _, ok := curPrints[k]; !ok {
+				removed = append(removed, k)
+			}
+		}
+		if len(removed) > 0 {
+			sort.Strings(removed)
+			if d.Removed == nil {
+				d.Removed = map[string][]string{}
+			}
+			d.Removed[ks.name] = removed
+		}
+	}
+	return d
+}
+
+// ApplyDelta materializes the full snapshot at d.Header.Tick from its base.
+func ApplyDelta(base SnapshotV1, d DeltaV2) (SnapshotV1, error) {
+	if base.Header.Tick != d.Header.BaseTick {
+		return SnapshotV1{}, fmt.Errorf("delta base mismatch: delta wants tick %d, base is %d", d.Header.BaseTick, base.Header.Tick)
+	}
+	if base.Header.WorldID != d.Header.WorldID {
+		return SnapshotV1{}, fmt.Errorf("delta world mismatch: base=%q delta=%q", base.Header.WorldID, d.Header.WorldID)
+	}
+	out := d.State
+	out.Header = Header{Version: VersionV2, WorldID: d.Header.WorldID, Tick: d.Header.Tick}
+	for _, ks := range keyedSections {
+		removed := map[string]bool{}
+		for _, k := range d.Removed[ks.name] {
+			removed[k] = true
+		}
+		ks.merge(&out, &base, &d.State, removed)
+	}
+	return out, nil
+}
+
+func WriteDelta(path string, d DeltaV2) error {
+	return writeFile(path, d.Header, &d)
+}
+
+func writeFile(path string, h Header, payload any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc, err := zstd.NewWriter(f, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	if err != nil {
+		return err
+	}
+	defer enc.Close()
+
+	bw := bufio.NewWriterSize(enc, 256*1024)
+	defer bw.Flush()
+
+	hb, _ := json.Marshal(h)
+	if _, err := bw.Write(hb); err != nil {
+		return err
+	}
+	if err := bw.WriteByte('\n'); err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(bw).Encode(payload); err != nil {
+		return fmt.Errorf("gob encode: %w", err)
+	}
+	return nil
+}
+
+// ChainWriter writes a full base every FullEvery snapshots and cumulative deltas in between.
+// It is not safe for concurrent use; each world's snapshot goroutine owns one.
+type ChainWriter struct {
+	Dir       string
+	FullEvery int
+
+	base      *Fingerprint
+	sinceBase int
+}
+
+func NewChainWriter(dir string, fullEvery int) *ChainWriter {
+	return &ChainWriter{Dir: dir, FullEvery: fullEvery}
+}
+
+// BasePath returns the full base the next delta would be written against ("" before the first write).
+func (c *ChainWriter) BasePath() string {
+	if c.base == nil {
+		return ""
+	}
+	return filepath.Join(c.Dir, FileName(c.base.Tick, false))
+}
+
+// Write persists snap as a full base or a delta and returns the written path.
+// forceFull is for snapshots that must stand alone (e.g. season archives).
+func (c *ChainWriter) Write(snap SnapshotV1, forceFull bool) (path string, delta bool, err error) {
+	full := forceFull || c.FullEvery <= 1 || c.base == nil ||
+		c.sinceBase+1 >= c.FullEvery ||
+		snap.Header.Tick <= c.base.Tick ||
+		snap.Header.WorldID != c.base.WorldID ||
+		snap.Seed != c.base.Seed
+	if full {
+		path = filepath.Join(c.Dir, FileName(snap.Header.Tick, false))
+		if err := WriteSnapshot(path, snap); err != nil {
+			return "", false, err
+		}
+		c.base = NewFingerprint(snap)
+		c.sinceBase = 0
+		return path, false, nil
+	}
+	path = filepath.Join(c.Dir, FileName(snap.Header.Tick, true))
+	if err := WriteDelta(path, c.base.Diff(snap)); err != nil {
+		return "", true, err
+	}
+	c.sinceBase++
+	return path, true, nil
+}
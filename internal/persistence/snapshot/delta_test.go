@@ -0,0 +1,129 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func deltaTestSnap(tick uint64) SnapshotV1 {
+	return SnapshotV1{
+		Header: Header{Version: VersionV2, WorldID: "w1", Tick: tick},
+		Seed:   7,
+		Chunks: []ChunkV1{
+			{CX: 0, CZ: 0, Height: 1, Blocks: []uint16{1, 1, 1, 1}},
+			{CX: 1, CZ: 0, Height: 1, Blocks: []uint16{2, 2, 2, 2}},
+		},
+		Agents: []AgentV1{
+			{ID: "A1", Name: "a", Inventory: map[string]int{"PLANK": 1}},
+			{ID: "A2", Name: "b", Inventory: map[string]int{}},
+		},
+		Containers: []ContainerV1{{Type: "CHEST", Pos: [3]int{1, 0, 1}, Inventory: map[string]int{"COAL": 3}}},
+		Counters:   CountersV1{NextAgent: 3},
+	}
+}
+
+func sortForCompare(s *SnapshotV1) {
+	sort.Slice(s.Chunks, func(i, j int) bool {
+		if s.Chunks[i].CX != s.Chunks[j].CX {
+			return s.Chunks[i].CX < s.Chunks[j].CX
+		}
+		return s.Chunks[i].CZ < s.Chunks[j].CZ
+	})
+	sort.Slice(s.Agents, func(i, j int) bool { return s.Agents[i].ID < s.Agents[j].ID })
+}
+
+func TestDeltaRoundTrip(t *testing.T) {
+	base := deltaTestSnap(100)
+
+	cur := deltaTestSnap(200)
+	cur.Chunks[1].Blocks = []uint16{2, 5, 2, 2}
+	cur.Chunks = append(cur.Chunks, ChunkV1{CX: 2, CZ: 0, Height: 1, Blocks: []uint16{3, 3, 3, 3}})
+	cur.Agents = []AgentV1{{ID: "A1", Name: "a", Inventory: map[string]int{"PLANK": 4}}, {ID: "A3", Name: "c", Inventory: map[string]int{}}}
+	cur.Containers = nil
+	cur.Weather = "RAIN"
+	cur.Counters.NextAgent = 4
+
+	d := NewFingerprint(base).Diff(cur)
+	if len(d.State.Chunks) != 2 || len(d.State.Agents) != 2 {
+		t.Fatalf("delta should hold only changed entries: chunks=%d agents=%d", len(d.State.Chunks), len(d.State.Agents))
+	}
+	if !reflect.DeepEqual(d.Removed["agents"], []string{"A2"}) || !reflect.DeepEqual(d.Removed["containers"], []string{"CHEST@1,0,1"}) {
+		t.Fatalf("removed=%v", d.Removed)
+	}
+
+	dir := t.TempDir()
+	if err := WriteSnapshot(filepath.Join(dir, FileName(100, false)), base); err != nil {
+		t.Fatal(err)
+	}
+	deltaPath := filepath.Join(dir, FileName(200, true))
+	if err := WriteDelta(deltaPath, d); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadSnapshot(deltaPath)
+	if err != nil {
+		t.Fatalf("read delta: %v", err)
+	}
+	if got.Header.Kind != KindFull || got.Header.Tick != 200 {
+		t.Fatalf("materialized header=%+v", got.Header)
+	}
+	want := cur
+	want.Header = got.Header
+	sortForCompare(&got)
+	sortForCompare(&want)
+	if len(got.Containers) != 0 || !reflect.DeepEqual(got.Chunks, want.Chunks) || !reflect.DeepEqual(got.Agents, want.Agents) ||
+		got.Weather != "RAIN" || got.Counters.NextAgent != 4 {
+		t.Fatalf("materialized snapshot mismatch:\ngot=%+v\nwant=%+v", got, want)
+	}
+}
+
+func TestChainWriterBaseAndDeltas(t *testing.T) {
+	dir := t.TempDir()
+	cw := NewChainWriter(dir, 3)
+	var kinds []bool
+	for i, tick := range []uint64{10, 20, 30, 40} {
+		s := deltaTestSnap(tick)
+		s.Agents[0].Inventory["PLANK"] = i
+		_, delta, err := cw.Write(s, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kinds = append(kinds, delta)
+	}
+	if !reflect.DeepEqual(kinds, []bool{false, true, true, false}) {
+		t.Fatalf("delta pattern=%v want [full delta delta full]", kinds)
+	}
+	if _, delta, _ := cw.Write(deltaTestSnap(50), true); delta {
+		t.Fatalf("forceFull must write a full snapshot")
+	}
+
+	if got := LatestInDir(dir, 35); filepath.Base(got) != "30.delta.snap.zst" {
+		t.Fatalf("LatestInDir(35)=%q", got)
+	}
+	s, err := ReadSnapshot(filepath.Join(dir, "30.delta.snap.zst"))
+	if err != nil || s.Agents[0].Inventory["PLANK"] != 2 {
+		t.Fatalf("read 30: inv=%v err=%v", s.Agents, err)
+	}
+
+}
+
+func TestParseFileName(t *testing.T) {
+	cases := []struct {
+		name  string
+		tick  uint64
+		delta bool
+		ok    bool
+	}{
+		{"123.snap.zst", 123, false, true},
+		{"123.delta.snap.zst", 123, true, true},
+		{"123.rollback.snap.zst", 0, false, false},
+		{"events-1.jsonl.zst", 0, false, false},
+	}
+	for _, c := range cases {
+		tick, delta, ok := ParseFileName(c.name)
+		if tick != c.tick || delta != c.delta || ok != c.ok {
+			t.Fatalf("%s: got (%d,%v,%v)", c.name, tick, delta, ok)
+		}
+	}
+}
@@ -15,6 +15,10 @@ type Header struct {
 	Version int    `json:"version"`
 	WorldID string `json:"world_id"`
 	Tick    uint64 `json:"tick"`
+
+	// V2 only: Kind is KindDelta for delta files (empty = full); BaseTick is the delta's full base.
+	Kind     string `json:"kind,omitempty"`
+	BaseTick uint64 `json:"base_tick,omitempty"`
 }
 
 type SnapshotV1 struct {
@@ -64,19 +68,27 @@ type SnapshotV1 struct {
 	ActiveEventCenter [3]int `json:"active_event_center,omitempty"`
 	ActiveEventRadius int    `json:"active_event_radius,omitempty"`
 
-	Chunks     []ChunkV1      `json:"chunks"`
-	Agents     []AgentV1      `json:"agents"`
-	Claims     []ClaimV1      `json:"claims"`
-	Containers []ContainerV1  `json:"containers"`
-	Items      []ItemEntityV1 `json:"items,omitempty"`
-	Signs      []SignV1       `json:"signs,omitempty"`
-	Conveyors  []ConveyorV1   `json:"conveyors,omitempty"`
-	Switches   []SwitchV1     `json:"switches,omitempty"`
-	Trades     []TradeV1      `json:"trades"`
-	Boards     []BoardV1      `json:"boards"`
-	Contracts  []ContractV1   `json:"contracts"`
-	Laws       []LawV1        `json:"laws"`
-	Orgs       []OrgV1        `json:"orgs"`
+	Chunks     []ChunkV1       `json:"chunks"`
+	Agents     []AgentV1       `json:"agents"`
+	Claims     []ClaimV1       `json:"claims"`
+	Containers []ContainerV1   `json:"containers"`
+	Items      []ItemEntityV1  `json:"items,omitempty"`
+	Mobs       []MobV1         `json:"mobs,omitempty"`
+	Signs      []SignV1        `json:"signs,omitempty"`
+	Conveyors  []ConveyorV1    `json:"conveyors,omitempty"`
+	Switches   []SwitchV1      `json:"switches,omitempty"`
+	Crops      []CropV1        `json:"crops,omitempty"`
+	Water      []WaterV1       `json:"water,omitempty"`
+	Signals    []SignalV1      `json:"signals,omitempty"`
+	Machines   []MachineV1     `json:"machines,omitempty"`
+	Shops      []ShopV1        `json:"shops,omitempty"`
+	Trades     []TradeV1       `json:"trades"`
+	Boards     []BoardV1       `json:"boards"`
+	Contracts  []ContractV1    `json:"contracts"`
+	Orders     []OrderV1       `json:"orders,omitempty"`
+	Prices     []PriceSeriesV1 `json:"prices,omitempty"`
+	Laws       []LawV1         `json:"laws"`
+	Orgs       []OrgV1         `json:"orgs"`
 
 	Structures []StructureV1 `json:"structures,omitempty"`
 
@@ -108,6 +120,8 @@ type CountersV1 struct {
 	NextLaw      uint64 `json:"next_law"`
 	NextOrg      uint64 `json:"next_org"`
 	NextItem     uint64 `json:"next_item"`
+	NextMob      uint64 `json:"next_mob,omitempty"`
+	NextOrder    uint64 `json:"next_order,omitempty"`
 }
 
 type ChunkV1 struct {
@@ -118,13 +132,14 @@ type ChunkV1 struct {
 }
 
 type AgentV1 struct {
-	ID                           string `json:"id"`
-	Name                         string `json:"name"`
-	OrgID                        string `json:"org_id,omitempty"`
-	CurrentWorldID               string `json:"current_world_id,omitempty"`
-	WorldSwitchCooldownUntilTick uint64 `json:"world_switch_cooldown_until_tick,omitempty"`
-	Pos                          [3]int `json:"pos"`
-	Yaw                          int    `json:"yaw"`
+	ID                           string            `json:"id"`
+	Name                         string            `json:"name"`
+	OrgID                        string            `json:"org_id,omitempty"`
+	CurrentWorldID               string            `json:"current_world_id,omitempty"`
+	WorldSwitchCooldownUntilTick uint64            `json:"world_switch_cooldown_until_tick,omitempty"`
+	Permits                      map[string]uint64 `json:"permits,omitempty"` // permit id -> ticks left (0 = never)
+	Pos                          [3]int            `json:"pos"`
+	Yaw                          int               `json:"yaw"`
 
 	HP            int            `json:"hp"`
 	Hunger        int            `json:"hunger"`
@@ -141,16 +156,23 @@ type AgentV1 struct {
 	FunRiskRescue int            `json:"fun_risk_rescue"`
 	Inventory     map[string]int `json:"inventory"`
 
+	MainHand string    `json:"main_hand,omitempty"`
+	Armor    [4]string `json:"armor"`
+
 	Memory      map[string]MemoryEntryV1 `json:"memory,omitempty"`
 	RateWindows map[string]RateWindowV1  `json:"rate_windows,omitempty"`
+	View        *ViewV1                  `json:"view,omitempty"`
 
 	SeenBiomes  []string              `json:"seen_biomes,omitempty"`
 	SeenRecipes []string              `json:"seen_recipes,omitempty"`
 	SeenEvents  []string              `json:"seen_events,omitempty"`
 	FunDecay    map[string]FunDecayV1 `json:"fun_decay,omitempty"`
 
-	MoveTask *MovementTaskV1 `json:"move_task,omitempty"`
-	WorkTask *WorkTaskV1     `json:"work_task,omitempty"`
+	MoveTask  *MovementTaskV1 `json:"move_task,omitempty"`
+	WorkTask  *WorkTaskV1     `json:"work_task,omitempty"`
+	TaskQueue []QueuedTaskV1  `json:"task_queue,omitempty"`
+
+	Triggers []TriggerV1 `json:"triggers,omitempty"`
 }
 
 type MemoryEntryV1 struct {
@@ -158,6 +180,35 @@ type MemoryEntryV1 struct {
 	ExpiryTick uint64 `json:"expiry_tick,omitempty"`
 }
 
+// ViewV1 is the agent's SET_VIEW configuration.
+type ViewV1 struct {
+	Hide        []string `json:"hide,omitempty"`
+	ObsRadius   int      `json:"obs_radius,omitempty"`
+	EntityTypes []string `json:"entity_types,omitempty"`
+	MaxEvents   int      `json:"max_events,omitempty"`
+}
+
+// TriggerV1 keeps the action as JSON (InstantReq/TaskReq carry interface{} fields).
+type TriggerV1 struct {
+	ID            string          `json:"id"`
+	On            string          `json:"on"`
+	Where         []TriggerCondV1 `json:"where,omitempty"`
+	Instant       json.RawMessage `json:"instant,omitempty"`
+	Task          json.RawMessage `json:"task,omitempty"`
+	CooldownTicks int             `json:"cooldown_ticks,omitempty"`
+	MaxFires      int             `json:"max_fires,omitempty"`
+	Fires         int             `json:"fires,omitempty"`
+	LastFireTick  uint64          `json:"last_fire_tick,omitempty"`
+}
+
+type TriggerCondV1 struct {
+	Field string  `json:"field"`
+	Op    string  `json:"op"`
+	Str   string  `json:"str,omitempty"`
+	Num   float64 `json:"num,omitempty"`
+	IsStr bool    `json:"is_str,omitempty"`
+}
+
 type RateWindowV1 struct {
 	StartTick uint64 `json:"start_tick"`
 	Count     int    `json:"count"`
@@ -177,6 +228,9 @@ type MovementTaskV1 struct {
 	Distance    float64 `json:"distance,omitempty"`
 	StartPos    [3]int  `json:"start_pos"`
 	StartedTick uint64  `json:"started_tick"`
+
+	Path     [][3]int `json:"path,omitempty"`
+	PathGoal [3]int   `json:"path_goal,omitempty"`
 }
 
 type WorkTaskV1 struct {
@@ -202,6 +256,23 @@ type WorkTaskV1 struct {
 	WorkTicks   int    `json:"work_ticks"`
 }
 
+// QueuedTaskV1 is a task queue entry; request parameters reuse the movement/work
+// task shapes (task_id/started_tick unused there).
+type QueuedTaskV1 struct {
+	Ref       string          `json:"ref"`
+	Kind      string          `json:"kind"`
+	Move      *MovementTaskV1 `json:"move,omitempty"`
+	Work      *WorkTaskV1     `json:"work,omitempty"`
+	Radius    int             `json:"radius,omitempty"` // CLAIM_LAND
+	OnFail    string          `json:"on_fail,omitempty"`
+	Retries   int             `json:"retries,omitempty"`
+	Attempts  int             `json:"attempts,omitempty"`
+	TaskID    string          `json:"task_id,omitempty"` // running head
+	NotBefore uint64          `json:"not_before,omitempty"`
+	EndType   string          `json:"end_type,omitempty"` // how the running head ended (TASK_DONE/TASK_FAIL)
+	EndCode   string          `json:"end_code,omitempty"`
+}
+
 type ClaimV1 struct {
 	LandID    string       `json:"land_id"`
 	Owner     string       `json:"owner"`
@@ -212,6 +283,9 @@ type ClaimV1 struct {
 
 	Members []string `json:"members,omitempty"`
 
+	Policies []PolicyV1 `json:"policies,omitempty"`
+
+	// Pre law-DSL claim parameters: only read to migrate old snapshots, never written.
 	MarketTax     float64 `json:"market_tax,omitempty"`
 	CurfewEnabled bool    `json:"curfew_enabled,omitempty"`
 	CurfewStart   float64 `json:"curfew_start,omitempty"`
@@ -229,6 +303,28 @@ type ClaimV1 struct {
 	MaintenanceStage   int    `json:"maintenance_stage,omitempty"`
 }
 
+type PolicyV1 struct {
+	LawID      string         `json:"law_id"`
+	TemplateID string         `json:"template_id"`
+	Rules      []PolicyRuleV1 `json:"rules"`
+}
+
+type PolicyRuleV1 struct {
+	Actions    []string `json:"actions,omitempty"`
+	Actor      string   `json:"actor,omitempty"`
+	Region     string   `json:"region,omitempty"`
+	Items      []string `json:"items,omitempty"`
+	Outcome    string   `json:"outcome,omitempty"`
+	TimeWindow bool     `json:"time_window,omitempty"`
+	TimeFrom   float64  `json:"time_from,omitempty"`
+	TimeTo     float64  `json:"time_to,omitempty"`
+
+	Effect string  `json:"effect"`
+	Item   string  `json:"item,omitempty"`
+	Amount int     `json:"amount,omitempty"`
+	Rate   float64 `json:"rate,omitempty"`
+}
+
 type ClaimFlagsV1 struct {
 	AllowBuild  bool `json:"allow_build"`
 	AllowBreak  bool `json:"allow_break"`
@@ -253,6 +349,20 @@ type ItemEntityV1 struct {
 	ExpiresTick uint64 `json:"expires_tick"`
 }
 
+type MobV1 struct {
+	EntityID    string `json:"entity_id"`
+	Kind        string `json:"kind"`
+	Pos         [3]int `json:"pos"`
+	Home        [3]int `json:"home"`
+	HP          int    `json:"hp"`
+	State       string `json:"state"`
+	TargetID    string `json:"target_id,omitempty"`
+	StateTick   uint64 `json:"state_tick"`
+	AttackTick  uint64 `json:"attack_tick"`
+	CreatedTick uint64 `json:"created_tick"`
+	DespawnTick uint64 `json:"despawn_tick"`
+}
+
 type SignV1 struct {
 	Pos         [3]int `json:"pos"`
 	Text        string `json:"text"`
@@ -271,6 +381,60 @@ type SwitchV1 struct {
 	On  bool   `json:"on"`
 }
 
+type CropV1 struct {
+	Pos         [3]int `json:"pos"`
+	Type        string `json:"type"`
+	Stage       int    `json:"stage"`
+	Growth      int    `json:"growth,omitempty"`
+	PlantedTick uint64 `json:"planted_tick"`
+}
+
+// WaterV1 is a FLOWING_WATER cell (Level > 0) and/or a cell queued for the next
+// water update (Active).
+type WaterV1 struct {
+	Pos    [3]int `json:"pos"`
+	Level  int    `json:"level,omitempty"`
+	Active bool   `json:"active,omitempty"`
+}
+
+// SignalV1 is a signal network node (wire, sensor, battery or gate) and/or a cell
+// queued for the next signal update (Active).
+type SignalV1 struct {
+	Pos    [3]int `json:"pos"`
+	Level  int    `json:"level,omitempty"`
+	DX     int    `json:"dx,omitempty"`
+	DZ     int    `json:"dz,omitempty"`
+	Charge int    `json:"charge,omitempty"`
+	Mode   string `json:"mode,omitempty"`
+	Arg    int    `json:"arg,omitempty"`
+	Node   bool   `json:"node,omitempty"`
+	Active bool   `json:"active,omitempty"`
+}
+
+// MachineV1 is the runtime state of a FURNACE, AUTO_CRAFTER or HOPPER.
+type MachineV1 struct {
+	Pos      [3]int `json:"pos"`
+	DX       int    `json:"dx,omitempty"`
+	DZ       int    `json:"dz,omitempty"`
+	Owner    string `json:"owner,omitempty"`
+	RecipeID string `json:"recipe_id,omitempty"`
+	Progress int    `json:"progress,omitempty"`
+	Fuel     int    `json:"fuel,omitempty"`
+}
+
+// ShopV1 is the owner and price list of a SHOP block (its stock is in the container).
+type ShopV1 struct {
+	Pos    [3]int        `json:"pos"`
+	Owner  string        `json:"owner,omitempty"`
+	Prices []ShopPriceV1 `json:"prices,omitempty"`
+}
+
+type ShopPriceV1 struct {
+	Item      string `json:"item"`
+	PriceItem string `json:"price_item"`
+	Price     int    `json:"price"`
+}
+
 type TradeV1 struct {
 	TradeID     string         `json:"trade_id"`
 	From        string         `json:"from"`
@@ -308,6 +472,57 @@ type ContractV1 struct {
 	Rotation     int            `json:"rotation"`
 	CreatedTick  uint64         `json:"created_tick"`
 	DeadlineTick uint64         `json:"deadline_tick"`
+
+	// LOAN contracts.
+	InterestPct    int                 `json:"interest_pct,omitempty"`
+	CollateralLand string              `json:"collateral_land,omitempty"`
+	Installments   []LoanInstallmentV1 `json:"installments,omitempty"`
+
+	// AUCTION contracts.
+	AuctionType  string         `json:"auction_type,omitempty"`
+	PriceItem    string         `json:"price_item,omitempty"`
+	ReservePrice int            `json:"reserve_price,omitempty"`
+	Bids         []AuctionBidV1 `json:"bids,omitempty"`
+}
+
+// AuctionBidV1 is one escrowed AUCTION bid.
+type AuctionBidV1 struct {
+	Bidder string `json:"bidder"`
+	Amount int    `json:"amount"`
+	Tick   uint64 `json:"tick"`
+}
+
+// LoanInstallmentV1 is one scheduled LOAN repayment.
+type LoanInstallmentV1 struct {
+	DueTick uint64         `json:"due_tick"`
+	Items   map[string]int `json:"items"`
+	Paid    bool           `json:"paid,omitempty"`
+}
+
+// OrderV1 is a resting MARKET_TERMINAL order; its escrow is in the terminal container.
+type OrderV1 struct {
+	OrderID     string `json:"order_id"`
+	TerminalPos [3]int `json:"terminal_pos"`
+	Owner       string `json:"owner"`
+	Side        string `json:"side"`
+	Item        string `json:"item"`
+	PriceItem   string `json:"price_item"`
+	Price       int    `json:"price"`
+	Count       int    `json:"count"`
+	CreatedTick uint64 `json:"created_tick"`
+}
+
+// PriceSeriesV1 is one item's price index window, oldest sample first. Prices are milli
+// reference units.
+type PriceSeriesV1 struct {
+	Item    string          `json:"item"`
+	Samples []PriceSampleV1 `json:"samples"`
+}
+
+type PriceSampleV1 struct {
+	Tick   uint64 `json:"tick"`
+	Price  int64  `json:"price"`
+	Volume int    `json:"volume"`
 }
 
 type LawV1 struct {
@@ -374,39 +589,14 @@ type ChunkKeyV1 struct {
 	CZ int `json:"cz"`
 }
 
+// WriteSnapshot writes a full snapshot.
 func WriteSnapshot(path string, snap SnapshotV1) error {
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		return err
-	}
-	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	enc, err := zstd.NewWriter(f, zstd.WithEncoderLevel(zstd.SpeedDefault))
-	if err != nil {
-		return err
-	}
-	defer enc.Close()
-
-	bw := bufio.NewWriterSize(enc, 256*1024)
-	defer bw.Flush()
-
-	hb, _ := json.Marshal(snap.Header)
-	if _, err := bw.Write(hb); err != nil {
-		return err
-	}
-	if err := bw.WriteByte('\n'); err != nil {
-		return err
-	}
-
-	if err := gob.NewEncoder(bw).Encode(&snap); err != nil {
-		return fmt.Errorf("gob encode: %w", err)
-	}
-	return nil
+	snap.Header.Kind, snap.Header.BaseTick = KindFull, 0
+	return writeFile(path, snap.Header, &snap)
 }
 
+// ReadSnapshot reads a full snapshot. A V2 delta is resolved against its base
+// (<base_tick>.snap.zst in the same directory) and returned fully materialized.
 func ReadSnapshot(path string) (SnapshotV1, error) {
 	var snap SnapshotV1
 	f, err := os.Open(path)
@@ -423,8 +613,21 @@ func ReadSnapshot(path string) (SnapshotV1, error) {
 
 	br := bufio.NewReaderSize(dec, 256*1024)
 
-	// Read header line (ignore it for now, gob also contains header).
-	_, _ = br.ReadBytes('\n')
+	var h Header
+	if line, err := br.ReadBytes('\n'); err == nil {
+		_ = json.Unmarshal(line, &h)
+	}
+	if h.Kind == KindDelta {
+		var d DeltaV2
+		if err := gob.NewDecoder(br).Decode(&d); err != nil {
+			return snap, fmt.Errorf("gob decode delta: %w", err)
+		}
+		base, err := ReadSnapshot(filepath.Join(filepath.Dir(path), FileName(d.Header.BaseTick, false)))
+		if err != nil {
+			return snap, fmt.Errorf("read delta base: %w", err)
+		}
+		return ApplyDelta(base, d)
+	}
 
 	if err := gob.NewDecoder(br).Decode(&snap); err != nil {
 		return snap, fmt.Errorf("gob decode: %w", err)
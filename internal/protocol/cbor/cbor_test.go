@@ -0,0 +1,133 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type inner struct {
+	X int `json:"x"`
+}
+
+type sample struct {
+	inner
+	Name    string            `json:"name"`
+	Skip    string            `json:"-"`
+	Empty   string            `json:"empty,omitempty"`
+	Tick    uint64            `json:"tick"`
+	Neg     int               `json:"neg"`
+	Ratio   float64           `json:"ratio"`
+	Half    float32           `json:"half"`
+	OK      bool              `json:"ok"`
+	Ptr     *inner            `json:"ptr"`
+	List    []uint16          `json:"list"`
+	Counts  map[string]int    `json:"counts"`
+	ByID    map[int]string    `json:"by_id"`
+	Raw     json.RawMessage   `json:"raw"`
+	Any     map[string]any    `json:"any"`
+	Quoted  int64             `json:"quoted,string"`
+	NilMap  map[string]string `json:"nil_map"`
+	Payload []byte            `json:"payload"`
+}
+
+func TestMarshalMatchesJSONShape(t *testing.T) {
+	v := sample{
+		inner:   inner{X: 7},
+		Name:    "agent",
+		Skip:    "hidden",
+		Tick:    1 << 40,
+		Neg:     -300,
+		Ratio:   0.1,
+		Half:    1.5,
+		OK:      true,
+		Ptr:     &inner{X: -1},
+		List:    []uint16{0, 23, 24, 255, 256, 65535},
+		Counts:  map[string]int{"b": 2, "a": 1},
+		ByID:    map[int]string{2: "two", 10: "ten"},
+		Raw:     json.RawMessage(`{"k":[1,2.5,"s",null,true]}`),
+		Any:     map[string]any{"n": 3, "s": "x"},
+		Quoted:  42,
+		Payload: []byte{1, 2, 3},
+	}
+	b, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	got, err := ToJSON(b)
+	if err != nil {
+		t.Fatalf("to json: %v", err)
+	}
+	want, _ := json.Marshal(v)
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("decode got: %v", err)
+	}
+	_ = json.Unmarshal(want, &w)
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("shape mismatch:\n got %s\nwant %s", got, want)
+	}
+	if len(b) >= len(want) {
+		t.Fatalf("expected cbor smaller than json: %d >= %d", len(b), len(want))
+	}
+}
+
+func TestMarshalDeterministic(t *testing.T) {
+	m := map[string]int{}
+	for i := 0; i < 50; i++ {
+		m[string(rune('a'+i%26))+string(rune('A'+i/26))] = i
+	}
+	a, _ := Marshal(m)
+	for i := 0; i < 5; i++ {
+		b, _ := Marshal(m)
+		if !bytes.Equal(a, b) {
+			t.Fatalf("map encoding not deterministic")
+		}
+	}
+}
+
+func TestKnownEncodings(t *testing.T) {
+	cases := []struct {
+		v    any
+		want []byte
+	}{
+		{0, []byte{0x00}},
+		{23, []byte{0x17}},
+		{24, []byte{0x18, 0x18}},
+		{-1, []byte{0x20}},
+		{1000, []byte{0x19, 0x03, 0xe8}},
+		{"a", []byte{0x61, 'a'}},
+		{[]int{1, 2}, []byte{0x82, 0x01, 0x02}},
+		{nil, []byte{0xf6}},
+		{1.5, []byte{0xfa, 0x3f, 0xc0, 0x00, 0x00}},
+	}
+	for _, c := range cases {
+		got, err := Marshal(c.v)
+		if err != nil || !bytes.Equal(got, c.want) {
+			t.Fatalf("Marshal(%v) = %x, %v; want %x", c.v, got, err, c.want)
+		}
+	}
+	if _, err := Marshal(math.Inf(1)); err == nil {
+		t.Fatalf("expected error for Inf")
+	}
+}
+
+func TestUnmarshalForeignForms(t *testing.T) {
+	// Indefinite map {"a": half(1.0), "b": indefinite text "xy"} wrapped in tag 1.
+	in := []byte{0xc1, 0xbf, 0x61, 'a', 0xf9, 0x3c, 0x00, 0x61, 'b', 0x7f, 0x61, 'x', 0x61, 'y', 0xff, 0xff}
+	v, err := Unmarshal(in)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := map[string]any{"a": 1.0, "b": "xy"}
+	if !reflect.DeepEqual(v, want) {
+		t.Fatalf("got %#v want %#v", v, want)
+	}
+	for _, bad := range [][]byte{{}, {0x62, 'a'}, {0x01, 0x02}, {0xa1, 0x80, 0x01}} {
+		if _, err := Unmarshal(bad); err == nil {
+			t.Fatalf("expected error for %x", bad)
+		}
+	}
+}
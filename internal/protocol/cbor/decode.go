@@ -0,0 +1,260 @@
+package cbor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// maxDepth bounds nesting so hostile input cannot exhaust the stack.
+const maxDepth = 64
+
+var errTruncated = errors.New("cbor: unexpected end of data")
+
+// Unmarshal decodes one CBOR data item into generic values: map[string]any, []any,
+// string, []byte, uint64/int64, float64, bool or nil. Tags are skipped, and map keys
+// must be text (or integers, which are formatted as strings like JSON object keys).
+func Unmarshal(data []byte) (any, error) {
+	d := decoder{data: data}
+	v, err := d.value(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(d.data) {
+		return nil, fmt.Errorf("cbor: %d trailing bytes", len(d.data)-d.off)
+	}
+	return v, nil
+}
+
+// ToJSON transcodes one CBOR data item to JSON text (byte strings become base64,
+// like []byte in encoding/json).
+func ToJSON(data []byte) ([]byte, error) {
+	v, err := Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+type decoder struct {
+	data []byte
+	off  int
+}
+
+func (d *decoder) byte() (byte, error) {
+	if d.off >= len(d.data) {
+		return 0, errTruncated
+	}
+	b := d.data[d.off]
+	d.off++
+	return b, nil
+}
+
+func (d *decoder) take(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return nil, errTruncated
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// arg reads the argument that follows an initial byte with additional info ai.
+// indefinite reports the indefinite-length marker (ai 31).
+func (d *decoder) arg(ai byte) (n uint64, indefinite bool, err error) {
+	switch {
+	case ai < 24:
+		return uint64(ai), false, nil
+	case ai == 24, ai == 25, ai == 26, ai == 27:
+		b, err := d.take(1 << (ai - 24))
+		if err != nil {
+			return 0, false, err
+		}
+		for _, c := range b {
+			n = n<<8 | uint64(c)
+		}
+		return n, false, nil
+	case ai == 31:
+		return 0, true, nil
+	}
+	return 0, false, fmt.Errorf("cbor: reserved additional info %d", ai)
+}
+
+func (d *decoder) isBreak() bool {
+	if d.off < len(d.data) && d.data[d.off] == 0xff {
+		d.off++
+		return true
+	}
+	return false
+}
+
+func (d *decoder) value(depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, errors.New("cbor: nesting too deep")
+	}
+	ib, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	major, ai := ib>>5, ib&0x1f
+	if major == majorSimple {
+		return d.simple(ai)
+	}
+	n, indefinite, err := d.arg(ai)
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case majorUint:
+		return n, nil
+	case majorNegInt:
+		if n > math.MaxInt64 {
+			return nil, errors.New("cbor: negative integer overflows int64")
+		}
+		return -1 - int64(n), nil
+	case majorBytes, majorText:
+		b, err := d.chunks(major, n, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		if major == majorText {
+			return string(b), nil
+		}
+		return b, nil
+	case majorArray:
+		out := make([]any, 0, min(n, 1024))
+		for i := uint64(0); indefinite || i < n; i++ {
+			if indefinite && d.isBreak() {
+				break
+			}
+			v, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case majorMap:
+		out := make(map[string]any, min(n, 1024))
+		for i := uint64(0); indefinite || i < n; i++ {
+			if indefinite && d.isBreak() {
+				break
+			}
+			k, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			var key string
+			switch x := k.(type) {
+			case string:
+				key = x
+			case uint64:
+				key = strconv.FormatUint(x, 10)
+			case int64:
+				key = strconv.FormatInt(x, 10)
+			default:
+				return nil, fmt.Errorf("cbor: unsupported map key %T", k)
+			}
+			v, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = v
+		}
+		return out, nil
+	case majorTag:
+		return d.value(depth + 1)
+	}
+	return nil, fmt.Errorf("cbor: bad major type %d", major)
+}
+
+func (d *decoder) chunks(major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		b, err := d.take(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	}
+	var out []byte
+	for !d.isBreak() {
+		ib, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		if ib>>5 != major {
+			return nil, errors.New("cbor: bad chunk in indefinite string")
+		}
+		cn, ind, err := d.arg(ib & 0x1f)
+		if err != nil {
+			return nil, err
+		}
+		if ind {
+			return nil, errors.New("cbor: nested indefinite string")
+		}
+		b, err := d.take(cn)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b...)
+	}
+	return out, nil
+}
+
+func (d *decoder) simple(ai byte) (any, error) {
+	switch ai {
+	case simpleFalse & 0x1f:
+		return false, nil
+	case simpleTrue & 0x1f:
+		return true, nil
+	case simpleNull & 0x1f, 23: // null, undefined
+		return nil, nil
+	case floatHalf & 0x1f:
+		b, err := d.take(2)
+		if err != nil {
+			return nil, err
+		}
+		return halfToFloat(uint16(b[0])<<8 | uint16(b[1])), nil
+	case floatSingle & 0x1f:
+		b, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]))), nil
+	case floatDouble & 0x1f:
+		b, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+		var u uint64
+		for _, c := range b {
+			u = u<<8 | uint64(c)
+		}
+		return math.Float64frombits(u), nil
+	}
+	return nil, fmt.Errorf("cbor: unsupported simple value %d", ai)
+}
+
+func halfToFloat(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
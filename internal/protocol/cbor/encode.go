@@ -0,0 +1,417 @@
+// Package cbor is a minimal CBOR (RFC 8949) codec for the agent wire protocol.
+//
+// Go values are encoded with the same logical shape encoding/json would produce:
+// struct fields use their json tags (including omitempty and "-"), maps are keyed by
+// strings in sorted order, and json.Marshaler values (e.g. json.RawMessage) are
+// transcoded from their JSON form. Decoding yields generic values or JSON text, so
+// inbound messages reuse the existing JSON decoding paths.
+package cbor
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Major types.
+const (
+	majorUint   = 0
+	majorNegInt = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorTag    = 6
+	majorSimple = 7
+)
+
+// Simple values / float headers (major type 7).
+const (
+	simpleFalse = 0xf4
+	simpleTrue  = 0xf5
+	simpleNull  = 0xf6
+	floatHalf   = 0xf9
+	floatSingle = 0xfa
+	floatDouble = 0xfb
+)
+
+// Marshal encodes v.
+func Marshal(v any) ([]byte, error) {
+	e := encoder{buf: make([]byte, 0, 1024)}
+	if err := e.value(reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+type encoder struct {
+	buf []byte
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func (e *encoder) head(major byte, n uint64) {
+	m := major << 5
+	switch {
+	case n < 24:
+		e.buf = append(e.buf, m|byte(n))
+	case n <= math.MaxUint8:
+		e.buf = append(e.buf, m|24, byte(n))
+	case n <= math.MaxUint16:
+		e.buf = append(e.buf, m|25, byte(n>>8), byte(n))
+	case n <= math.MaxUint32:
+		e.buf = append(e.buf, m|26, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	default:
+		e.buf = append(e.buf, m|27, byte(n>>56), byte(n>>48), byte(n>>40), byte(n>>32), byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+func (e *encoder) int(n int64) {
+	if n < 0 {
+		e.head(majorNegInt, uint64(-1-n))
+		return
+	}
+	e.head(majorUint, uint64(n))
+}
+
+func (e *encoder) float(f float64) {
+	if f32 := float32(f); float64(f32) == f {
+		b := math.Float32bits(f32)
+		e.buf = append(e.buf, floatSingle, byte(b>>24), byte(b>>16), byte(b>>8), byte(b))
+		return
+	}
+	b := math.Float64bits(f)
+	e.buf = append(e.buf, floatDouble, byte(b>>56), byte(b>>48), byte(b>>40), byte(b>>32), byte(b>>24), byte(b>>16), byte(b>>8), byte(b))
+}
+
+func (e *encoder) text(s string) {
+	e.head(majorText, uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *encoder) value(v reflect.Value) error {
+	if !v.IsValid() {
+		e.buf = append(e.buf, simpleNull)
+		return nil
+	}
+	t := v.Type()
+	if t.Implements(jsonMarshalerType) && !(v.Kind() == reflect.Pointer && v.IsNil()) {
+		return e.marshaler(v.Interface().(json.Marshaler))
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.buf = append(e.buf, simpleTrue)
+		} else {
+			e.buf = append(e.buf, simpleFalse)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.head(majorUint, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return fmt.Errorf("cbor: unsupported float value %v", f)
+		}
+		e.float(f)
+	case reflect.String:
+		e.text(v.String())
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			e.buf = append(e.buf, simpleNull)
+			return nil
+		}
+		return e.value(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			e.buf = append(e.buf, simpleNull)
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			e.head(majorBytes, uint64(v.Len()))
+			e.buf = append(e.buf, v.Bytes()...)
+			return nil
+		}
+		return e.array(v)
+	case reflect.Array:
+		return e.array(v)
+	case reflect.Map:
+		if v.IsNil() {
+			e.buf = append(e.buf, simpleNull)
+			return nil
+		}
+		return e.mapValue(v)
+	case reflect.Struct:
+		return e.structValue(v)
+	default:
+		return fmt.Errorf("cbor: unsupported type %s", t)
+	}
+	return nil
+}
+
+func (e *encoder) array(v reflect.Value) error {
+	n := v.Len()
+	e.head(majorArray, uint64(n))
+	for i := 0; i < n; i++ {
+		if err := e.value(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *encoder) mapValue(v reflect.Value) error {
+	type kv struct {
+		key string
+		val reflect.Value
+	}
+	kvs := make([]kv, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k, err := mapKey(iter.Key())
+		if err != nil {
+			return err
+		}
+		kvs = append(kvs, kv{key: k, val: iter.Value()})
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].key < kvs[j].key })
+	e.head(majorMap, uint64(len(kvs)))
+	for _, p := range kvs {
+		e.text(p.key)
+		if err := e.value(p.val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapKey mirrors encoding/json: string kinds, TextMarshalers, then integers.
+func mapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if k.Type().Implements(textMarshalerType) {
+		b, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("cbor: unsupported map key type %s", k.Type())
+}
+
+func (e *encoder) structValue(v reflect.Value) error {
+	fields := cachedFields(v.Type())
+	// Count first: definite-length maps keep the output canonical.
+	n := 0
+	for i := range fields {
+		if fv, ok := fields[i].get(v); ok && !(fields[i].omitEmpty && isEmpty(fv)) {
+			n++
+		}
+	}
+	e.head(majorMap, uint64(n))
+	for i := range fields {
+		f := &fields[i]
+		fv, ok := f.get(v)
+		if !ok || (f.omitEmpty && isEmpty(fv)) {
+			continue
+		}
+		e.text(f.name)
+		if f.quoted {
+			if err := e.quoted(fv); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := e.value(fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quoted implements the json ",string" option for scalars.
+func (e *encoder) quoted(v reflect.Value) error {
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	e.text(string(b))
+	return nil
+}
+
+func (e *encoder) marshaler(m json.Marshaler) error {
+	b, err := m.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return err
+	}
+	return e.generic(generic)
+}
+
+// generic encodes a value produced by encoding/json with UseNumber.
+func (e *encoder) generic(v any) error {
+	switch x := v.(type) {
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			e.int(i)
+			return nil
+		}
+		if u, err := strconv.ParseUint(string(x), 10, 64); err == nil {
+			e.head(majorUint, u)
+			return nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return err
+		}
+		e.float(f)
+		return nil
+	case []any:
+		e.head(majorArray, uint64(len(x)))
+		for _, it := range x {
+			if err := e.generic(it); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		e.head(majorMap, uint64(len(keys)))
+		for _, k := range keys {
+			e.text(k)
+			if err := e.generic(x[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return e.value(reflect.ValueOf(v))
+}
+
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	quoted    bool
+}
+
+// get walks the field index, reporting false when it crosses a nil embedded pointer.
+func (f *field) get(v reflect.Value) (reflect.Value, bool) {
+	for i, x := range f.index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+var fieldCache sync.Map // reflect.Type -> []field
+
+func cachedFields(t reflect.Type) []field {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.([]field)
+	}
+	f, _ := fieldCache.LoadOrStore(t, typeFields(t, nil))
+	return f.([]field)
+}
+
+// typeFields lists encodable fields in declaration order, flattening untagged embedded
+// structs the way encoding/json does (outer fields win on name conflicts).
+func typeFields(t reflect.Type, prefix []int) []field {
+	var out []field
+	seen := map[string]bool{}
+	var embedded []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		index := append(append([]int(nil), prefix...), i)
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, typeFields(ft, index)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		f := field{name: name, index: index}
+		for _, o := range strings.Split(opts, ",") {
+			switch o {
+			case "omitempty":
+				f.omitEmpty = true
+			case "string":
+				switch sf.Type.Kind() {
+				case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+					f.quoted = true
+				}
+			}
+		}
+		seen[name] = true
+		out = append(out, f)
+	}
+	for _, f := range embedded {
+		if !seen[f.name] {
+			seen[f.name] = true
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// isEmpty matches encoding/json's omitempty rules.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
@@ -10,6 +10,9 @@ const (
 	ErrWorldDenied   = "E_WORLD_DENIED"
 	ErrWorldCooldown = "E_WORLD_COOLDOWN"
 
+	ErrWorldPermitRequired = "E_WORLD_PERMIT_REQUIRED"
+	ErrWorldPermitExpired  = "E_WORLD_PERMIT_EXPIRED"
+
 	// Rule/action layer.
 	ErrBadRequest    = "E_BAD_REQUEST"
 	ErrNoPermission  = "E_NO_PERMISSION"
@@ -18,25 +21,29 @@ const (
 	ErrRateLimit     = "E_RATE_LIMIT"
 	ErrConflict      = "E_CONFLICT"
 	ErrBlocked       = "E_BLOCKED"
+	ErrNoPath        = "E_NO_PATH"
 	ErrStale         = "E_STALE"
 	ErrInternal      = "E_INTERNAL"
 )
 
 var knownCodes = map[string]struct{}{
-	ErrProtoBadRequest: {},
-	ErrWorldBusy:       {},
-	ErrWorldNotFound:   {},
-	ErrWorldDenied:     {},
-	ErrWorldCooldown:   {},
-	ErrBadRequest:      {},
-	ErrNoPermission:    {},
-	ErrNoResource:      {},
-	ErrInvalidTarget:   {},
-	ErrRateLimit:       {},
-	ErrConflict:        {},
-	ErrBlocked:         {},
-	ErrStale:           {},
-	ErrInternal:        {},
+	ErrProtoBadRequest:     {},
+	ErrWorldBusy:           {},
+	ErrWorldNotFound:       {},
+	ErrWorldDenied:         {},
+	ErrWorldCooldown:       {},
+	ErrWorldPermitRequired: {},
+	ErrWorldPermitExpired:  {},
+	ErrBadRequest:          {},
+	ErrNoPermission:        {},
+	ErrNoResource:          {},
+	ErrInvalidTarget:       {},
+	ErrRateLimit:           {},
+	ErrConflict:            {},
+	ErrBlocked:             {},
+	ErrNoPath:              {},
+	ErrStale:               {},
+	ErrInternal:            {},
 }
 
 func IsKnownCode(code string) bool {
@@ -10,6 +10,8 @@ func TestIsKnownCode(t *testing.T) {
 		ErrWorldNotFound,
 		ErrWorldDenied,
 		ErrWorldCooldown,
+		ErrWorldPermitRequired,
+		ErrWorldPermitExpired,
 		ErrBadRequest,
 		ErrNoPermission,
 		ErrNoResource,
@@ -17,6 +19,7 @@ func TestIsKnownCode(t *testing.T) {
 		ErrRateLimit,
 		ErrConflict,
 		ErrBlocked,
+		ErrNoPath,
 		ErrStale,
 		ErrInternal,
 	}
@@ -21,10 +21,21 @@ type HelloClientCapabilities struct {
 	DeltaVoxels bool `json:"delta_voxels,omitempty"`
 	AckRequired bool `json:"ack_required,omitempty"`
 	EventCursor bool `json:"event_cursor,omitempty"`
+	// Encodings lists accepted wire encodings in preference order (e.g. ["cbor","json"]).
+	Encodings []string `json:"encodings,omitempty"`
+	// ObsDelta enables OBS_ACK/OBS_RESYNC and field-level OBS deltas.
+	ObsDelta bool `json:"obs_delta,omitempty"`
 }
 
 type HelloAuth struct {
+	// Token is a resume token from a previous WELCOME.
 	Token string `json:"token,omitempty"`
+
+	// Credentials checked by the server authenticator (when enabled).
+	APIKey   string `json:"api_key,omitempty"`
+	Bearer   string `json:"bearer,omitempty"` // HMAC-signed token
+	Account  string `json:"account,omitempty"`
+	Password string `json:"password,omitempty"`
 }
 
 // WELCOME (server -> client)
@@ -46,6 +57,11 @@ type ServerCapabilities struct {
 	Ack         bool `json:"ack,omitempty"`
 	EventBatch  bool `json:"event_batch,omitempty"`
 	Idempotency bool `json:"idempotency,omitempty"`
+	// Encodings advertises what the server can speak; Encoding is the one selected
+	// for this session (messages after WELCOME use it).
+	Encodings []string `json:"encodings,omitempty"`
+	Encoding  string   `json:"encoding,omitempty"`
+	ObsDelta  bool     `json:"obs_delta,omitempty"`
 }
 
 type WorldRef struct {
@@ -9,6 +9,7 @@ type ObsMsg struct {
 	EventsCursor    uint64 `json:"events_cursor,omitempty"`
 	WorldID         string `json:"world_id,omitempty"`
 	WorldClock      uint64 `json:"world_clock,omitempty"`
+	ObsMode         string `json:"obs_mode,omitempty"` // "FULL" keyframe in delta mode
 
 	World      WorldObs      `json:"world"`
 	Self       SelfObs       `json:"self"`
@@ -23,8 +24,10 @@ type ObsMsg struct {
 
 	FunScore *FunScoreObs `json:"fun_score,omitempty"`
 
-	PublicBoards []BoardObs `json:"public_boards,omitempty"`
-	Memory       []MemoryKV `json:"memory,omitempty"`
+	PublicBoards []BoardObs  `json:"public_boards,omitempty"`
+	Markets      []MarketObs `json:"markets,omitempty"`
+	Prices       []PriceObs  `json:"prices,omitempty"`
+	Memory       []MemoryKV  `json:"memory,omitempty"`
 }
 
 type WorldObs struct {
@@ -108,6 +111,8 @@ type TaskObs struct {
 	Progress float64 `json:"progress"`
 	Target   [3]int  `json:"target,omitempty"`
 	EtaTicks int     `json:"eta_ticks,omitempty"`
+	Status   string  `json:"status,omitempty"` // QUEUED for task queue entries not yet started
+	Ref      string  `json:"ref,omitempty"`    // queued request id
 }
 
 type BoardObs struct {
@@ -122,6 +127,39 @@ type BoardPost struct {
 	Summary string `json:"summary"`
 }
 
+// MarketObs is the order book of one MARKET_TERMINAL, one MarketBook per item pair.
+type MarketObs struct {
+	TerminalID string       `json:"terminal_id"`
+	Pos        [3]int       `json:"pos"`
+	Books      []MarketBook `json:"books"`
+}
+
+// MarketBook aggregates resting orders for Item priced in PriceItem by price level:
+// bids best (highest) first, asks best (lowest) first.
+type MarketBook struct {
+	Item      string        `json:"item"`
+	PriceItem string        `json:"price_item"`
+	Bids      []MarketLevel `json:"bids,omitempty"`
+	Asks      []MarketLevel `json:"asks,omitempty"`
+}
+
+type MarketLevel struct {
+	Price  int `json:"price"`
+	Count  int `json:"count"`
+	Orders int `json:"orders"`
+}
+
+// PriceObs is one item of the world price index, in reference units: Price is the
+// rolling VWAP of recent trades and Base the reference value it started from.
+type PriceObs struct {
+	Item     string  `json:"item"`
+	Price    float64 `json:"price"`
+	Base     float64 `json:"base"`
+	Samples  int     `json:"samples"`
+	Volume   int     `json:"volume"`
+	LastTick uint64  `json:"last_tick,omitempty"`
+}
+
 type MemoryKV struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
@@ -206,6 +244,52 @@ type InstantReq struct {
 
 	TargetWorldID string `json:"target_world_id,omitempty"`
 	EntryPointID  string `json:"entry_point_id,omitempty"`
+
+	View *ViewSpec `json:"view,omitempty"` // SET_VIEW
+
+	Trigger   *TriggerSpec `json:"trigger,omitempty"`    // SET_TRIGGER
+	TriggerID string       `json:"trigger_id,omitempty"` // REMOVE_TRIGGER
+
+	Mode     string `json:"mode,omitempty"`      // SET_SENSOR (with radius / count); POST_CONTRACT (AUCTION): ENGLISH or SEALED
+	RecipeID string `json:"recipe_id,omitempty"` // SET_RECIPE
+
+	Side      string `json:"side,omitempty"`       // POST_ORDER: BUY or SELL (with item_id / count)
+	PriceItem string `json:"price_item,omitempty"` // POST_ORDER; POST_CONTRACT (AUCTION); SET_SHOP_PRICE
+	Price     int    `json:"price,omitempty"`      // POST_ORDER/SET_SHOP_PRICE: price_item per unit; AUCTION: reserve; BID_AUCTION: bid
+	OrderID   string `json:"order_id,omitempty"`   // CANCEL_ORDER
+
+	InterestPct  int `json:"interest_pct,omitempty"` // POST_CONTRACT (LOAN)
+	Installments int `json:"installments,omitempty"` // POST_CONTRACT (LOAN)
+}
+
+// ViewSpec replaces the agent's OBS view (SET_VIEW); omit it to restore defaults.
+type ViewSpec struct {
+	Hide        []string `json:"hide,omitempty"`         // sections: voxels, entities, public_boards, memory, fun_score
+	ObsRadius   int      `json:"obs_radius,omitempty"`   // 0 = world default
+	EntityTypes []string `json:"entity_types,omitempty"` // e.g. ["AGENT","ITEM"]; empty = all
+	MaxEvents   int      `json:"max_events,omitempty"`   // per OBS; 0 = unlimited, the rest wait for later OBS
+}
+
+// TriggerSpec is a server-side rule (SET_TRIGGER): when an event of type On (or, for
+// On=STATS, the agent's own stats each tick) satisfies every Where condition, the
+// server applies Instant or Task on the agent's behalf. Action string fields of the
+// form "$field" take that field of the matching event (e.g. "$trade_id").
+type TriggerSpec struct {
+	ID            string        `json:"id"`
+	On            string        `json:"on"`
+	Where         []TriggerCond `json:"where,omitempty"`
+	Instant       *InstantReq   `json:"instant,omitempty"`
+	Task          *TaskReq      `json:"task,omitempty"`
+	CooldownTicks int           `json:"cooldown_ticks,omitempty"`
+	MaxFires      int           `json:"max_fires,omitempty"` // 0 = unlimited; removed after the last fire
+}
+
+// TriggerCond compares an event field (or self.hunger, self.hp, self.stamina,
+// self.inv.<ITEM>) with Value; strings support EQ/NE, numbers EQ/NE/LT/LE/GT/GE.
+type TriggerCond struct {
+	Field string `json:"field"`
+	Op    string `json:"op"`
+	Value any    `json:"value"`
 }
 
 type TaskReq struct {
@@ -228,4 +312,8 @@ type TaskReq struct {
 	Anchor      [3]int `json:"anchor,omitempty"`
 	Rotation    int    `json:"rotation,omitempty"`
 	Radius      int    `json:"radius,omitempty"`
+
+	Queue   string `json:"queue,omitempty"`   // ENQUEUE = append to the agent's task queue
+	OnFail  string `json:"on_fail,omitempty"` // queued tasks: SKIP (default) | ABORT | RETRY
+	Retries int    `json:"retries,omitempty"` // on_fail=RETRY: extra attempts before the queue aborts
 }
@@ -0,0 +1,85 @@
+package protocol
+
+// OBS modes. Legacy OBS omit obs_mode and are always full.
+const (
+	ObsModeFull  = "FULL"
+	ObsModeDelta = "DELTA"
+)
+
+// ObsDeltaMsg is an OBS with obs_mode=DELTA: only sections that differ from the
+// acknowledged base_obs_id are present. Events/memory are per-tick as in a full OBS.
+type ObsDeltaMsg struct {
+	Type            string `json:"type"`
+	ProtocolVersion string `json:"protocol_version"`
+	Tick            uint64 `json:"tick"`
+	AgentID         string `json:"agent_id"`
+	ObsID           string `json:"obs_id"`
+	EventsCursor    uint64 `json:"events_cursor,omitempty"`
+	WorldID         string `json:"world_id,omitempty"`
+	WorldClock      uint64 `json:"world_clock,omitempty"`
+	ObsMode         string `json:"obs_mode"`
+	BaseObsID       string `json:"base_obs_id"`
+
+	World      *WorldObs      `json:"world,omitempty"`
+	Self       *SelfObs       `json:"self,omitempty"`
+	Inventory  []ItemStack    `json:"inventory,omitempty"` // changed entries; count 0 = removed
+	Equipment  *EquipmentObs  `json:"equipment,omitempty"`
+	LocalRules *LocalRulesObs `json:"local_rules,omitempty"`
+
+	Voxels   *VoxelsObs     `json:"voxels,omitempty"` // DELTA ops are relative to the base voxels
+	Entities *EntitiesDelta `json:"entities,omitempty"`
+	Events   []Event        `json:"events,omitempty"`
+	Tasks    *TasksDelta    `json:"tasks,omitempty"`
+
+	FunScore *FunScoreObs `json:"fun_score,omitempty"`
+
+	PublicBoards *BoardsDelta  `json:"public_boards,omitempty"`
+	Markets      *MarketsDelta `json:"markets,omitempty"`
+	Prices       *PricesDelta  `json:"prices,omitempty"`
+	Memory       []MemoryKV    `json:"memory,omitempty"`
+}
+
+// EntitiesDelta upserts entries by id and removes the listed ids.
+type EntitiesDelta struct {
+	Upsert []EntityObs `json:"upsert,omitempty"`
+	Remove []string    `json:"remove,omitempty"`
+}
+
+// TasksDelta upserts entries by task_id and removes the listed ids.
+type TasksDelta struct {
+	Upsert []TaskObs `json:"upsert,omitempty"`
+	Remove []string  `json:"remove,omitempty"`
+}
+
+// BoardsDelta upserts entries by board_id and removes the listed ids.
+type BoardsDelta struct {
+	Upsert []BoardObs `json:"upsert,omitempty"`
+	Remove []string   `json:"remove,omitempty"`
+}
+
+// MarketsDelta upserts entries by terminal_id and removes the listed ids.
+type MarketsDelta struct {
+	Upsert []MarketObs `json:"upsert,omitempty"`
+	Remove []string    `json:"remove,omitempty"`
+}
+
+// PricesDelta upserts entries by item and removes the listed items.
+type PricesDelta struct {
+	Upsert []PriceObs `json:"upsert,omitempty"`
+	Remove []string   `json:"remove,omitempty"`
+}
+
+// OBS_ACK (client -> server): obs_id is the newest OBS the client has applied and
+// kept as a delta base.
+type ObsAckMsg struct {
+	Type            string `json:"type"`
+	ProtocolVersion string `json:"protocol_version"`
+	ObsID           string `json:"obs_id"`
+}
+
+// OBS_RESYNC (client -> server): drop the delta base and send a full OBS next tick.
+type ObsResyncMsg struct {
+	Type            string `json:"type"`
+	ProtocolVersion string `json:"protocol_version"`
+	Reason          string `json:"reason,omitempty"`
+}
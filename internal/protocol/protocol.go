@@ -25,6 +25,8 @@ const (
 	TypeAck           = "ACK"
 	TypeEventBatchReq = "EVENT_BATCH_REQ"
 	TypeEventBatch    = "EVENT_BATCH"
+	TypeObsAck        = "OBS_ACK"
+	TypeObsResync     = "OBS_RESYNC"
 )
 
 var preferredVersions = []string{"1.1", "1.0", "0.9"}
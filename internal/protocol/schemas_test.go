@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"voxelcraft.ai/internal/protocol"
 )
 
 func TestSchemas_ValidateSamples(t *testing.T) {
@@ -112,7 +114,7 @@ func TestSchemas_ValidateSamples(t *testing.T) {
 	  "supported_versions":["1.1","1.0"],
 	  "agent_name":"bot11",
 	  "capabilities":{"delta_voxels":true,"max_queue":8},
-	  "client_capabilities":{"ack_required":true,"event_cursor":true}
+	  "client_capabilities":{"ack_required":true,"event_cursor":true,"encodings":["cbor","json"],"obs_delta":true}
 	}`), &hello11)
 	validate(hello11Schema, hello11)
 
@@ -122,7 +124,7 @@ func TestSchemas_ValidateSamples(t *testing.T) {
 	  "protocol_version":"1.1",
 	  "selected_version":"1.1",
 	  "session_id":"sess_1",
-	  "server_capabilities":{"ack":true,"event_batch":true,"idempotency":true},
+	  "server_capabilities":{"ack":true,"event_batch":true,"idempotency":true,"encodings":["cbor","json"],"encoding":"cbor","obs_delta":true},
 	  "agent_id":"A1",
 	  "resume_token":"resume_world_1_123",
 	  "world_params":{"tick_rate_hz":5,"chunk_size":[16,16,1],"height":1,"obs_radius":7,"day_ticks":6000,"seed":1337},
@@ -199,4 +201,63 @@ func TestSchemas_ValidateSamples(t *testing.T) {
 	  "world_id":"OVERWORLD"
 	}`), &eventBatchMsg)
 	validate(eventBatchMsgSchema, eventBatchMsg)
+
+	var obsDelta any
+	_ = json.Unmarshal([]byte(`{
+	  "type":"OBS",
+	  "protocol_version":"1.1",
+	  "tick":12,
+	  "agent_id":"A1",
+	  "obs_id":"A1:12:3",
+	  "obs_mode":"DELTA",
+	  "base_obs_id":"A1:10:3",
+	  "inventory":[{"item":"PLANK","count":0}],
+	  "entities":{"upsert":[{"id":"A2","type":"AGENT","pos":[1,0,1]}],"remove":["C1"]}
+	}`), &obsDelta)
+	validate(compile("obs_delta_v1_1.schema.json"), obsDelta)
+
+	var obsAck any
+	_ = json.Unmarshal([]byte(`{"type":"OBS_ACK","protocol_version":"1.1","obs_id":"A1:12:3"}`), &obsAck)
+	validate(compile("obs_ack.schema.json"), obsAck)
+
+	var obsResync any
+	_ = json.Unmarshal([]byte(`{"type":"OBS_RESYNC","protocol_version":"1.1","reason":"missing base"}`), &obsResync)
+	validate(compile("obs_resync.schema.json"), obsResync)
+}
+
+// CBOR payloads must keep the logical shape the JSON schemas describe.
+func TestSchemas_ValidateCBOR(t *testing.T) {
+	validate := func(name string, msg any) {
+		t.Helper()
+		s, err := jsonschema.Compile(filepath.Join("..", "..", "schemas", name))
+		if err != nil {
+			t.Fatalf("compile %s: %v", name, err)
+		}
+		b, err := protocol.Marshal(protocol.EncodingCBOR, msg)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		j, err := protocol.ToJSON(protocol.EncodingCBOR, b)
+		if err != nil {
+			t.Fatalf("to json: %v", err)
+		}
+		var v any
+		if err := json.Unmarshal(j, &v); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if err := s.Validate(v); err != nil {
+			t.Fatalf("validate %s: %v", name, err)
+		}
+	}
+	validate("ack.schema.json", protocol.AckMsg{
+		Type: protocol.TypeAck, ProtocolVersion: "1.1", AckFor: "ACT_1", Accepted: true, ServerTick: 1, WorldID: "OVERWORLD",
+	})
+	validate("event_batch_msg.schema.json", protocol.EventBatchMsg{
+		Type: protocol.TypeEventBatch, ProtocolVersion: "1.1", ReqID: "req_1", NextCursor: 1, WorldID: "OVERWORLD",
+		Events: []protocol.EventBatchItem{{Cursor: 1, Event: protocol.Event{"type": "ACTION_RESULT", "ok": true}}},
+	})
+	validate("act_v1_1.schema.json", protocol.ActMsg{
+		Type: protocol.TypeAct, ProtocolVersion: "1.1", ActID: "ACT_1", BasedOnObsID: "O1", Tick: 1, AgentID: "A1",
+		ExpectedWorldID: "OVERWORLD", Instants: []protocol.InstantReq{{ID: "I1", Type: "SAY", Channel: "LOCAL", Text: "hi"}},
+	})
 }
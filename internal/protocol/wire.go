@@ -0,0 +1,48 @@
+package protocol
+
+import (
+	"encoding/json"
+
+	"voxelcraft.ai/internal/protocol/cbor"
+)
+
+// Wire encodings. JSON travels in text frames, CBOR in binary frames; both carry the
+// same logical shape described by /schemas.
+const (
+	EncodingJSON = "json"
+	EncodingCBOR = "cbor"
+)
+
+// ServerEncodings is what the server advertises in WELCOME.
+var ServerEncodings = []string{EncodingCBOR, EncodingJSON}
+
+// SelectEncoding picks the first client-preferred encoding the server supports,
+// defaulting to JSON.
+func SelectEncoding(clientPrefs []string) string {
+	for _, e := range clientPrefs {
+		switch e {
+		case EncodingJSON, EncodingCBOR:
+			return e
+		}
+	}
+	return EncodingJSON
+}
+
+// IsBinaryEncoding reports whether enc must be sent as binary frames.
+func IsBinaryEncoding(enc string) bool { return enc == EncodingCBOR }
+
+// Marshal encodes v with the given encoding ("" means JSON).
+func Marshal(enc string, v any) ([]byte, error) {
+	if enc == EncodingCBOR {
+		return cbor.Marshal(v)
+	}
+	return json.Marshal(v)
+}
+
+// ToJSON normalizes an encoded message to JSON so the JSON decoding paths can be reused.
+func ToJSON(enc string, b []byte) ([]byte, error) {
+	if enc == EncodingCBOR {
+		return cbor.ToJSON(b)
+	}
+	return b, nil
+}
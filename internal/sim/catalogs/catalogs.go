@@ -11,6 +11,8 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
 type Catalogs struct {
@@ -36,6 +38,7 @@ type BlockDef struct {
 	Solid     bool   `json:"solid"`
 	Breakable bool   `json:"breakable"`
 	DropsItem string `json:"drops_item,omitempty"`
+	Gravity   bool   `json:"gravity,omitempty"` // falls when unsupported (3D worlds only)
 }
 
 type ItemCatalog struct {
@@ -48,7 +51,7 @@ type ItemCatalog struct {
 
 type ItemDef struct {
 	ID       string `json:"id"`
-	Kind     string `json:"kind"` // "BLOCK","TOOL","MATERIAL","FOOD","MECH"
+	Kind     string `json:"kind"` // "BLOCK","TOOL","ARMOR","MATERIAL","FOOD","MECH"
 	PlaceAs  string `json:"place_as,omitempty"`
 	EdibleHP int    `json:"edible_hp,omitempty"`
 }
@@ -102,6 +105,10 @@ type LawTemplate struct {
 	Title       string            `json:"title"`
 	Description string            `json:"description"`
 	Params      map[string]string `json:"params"`
+	// Limits clamps numeric params to [min, max] on proposal.
+	Limits map[string][2]float64 `json:"limits,omitempty"`
+	// Rules is the law DSL body; see policy/rules.
+	Rules []rules.RuleSpec `json:"rules"`
 }
 
 type EventCatalog struct {
@@ -285,8 +292,8 @@ func loadBlueprints(dir string, out *BlueprintCatalog) error {
 			return fmt.Errorf("blueprint %s: missing id", filepath.Base(p))
 		}
 		for i, blk := range bp.Blocks {
-			if blk.Pos[1] != 0 {
-				return fmt.Errorf("blueprint %s: blocks[%d] y must be 0 for 2D world (got %d)", filepath.Base(p), i, blk.Pos[1])
+			if blk.Pos[1] < 0 || blk.Pos[1] < bp.AABB[0][1] || (bp.AABB[1][1] > bp.AABB[0][1] && blk.Pos[1] >= bp.AABB[1][1]) {
+				return fmt.Errorf("blueprint %s: blocks[%d] y=%d outside aabb", filepath.Base(p), i, blk.Pos[1])
 			}
 		}
 		out.ByID[bp.ID] = bp
@@ -312,6 +319,16 @@ func loadLaws(path string, out *LawCatalog) error {
 	}
 	out.ByID = map[string]LawTemplate{}
 	for _, t := range out.Templates {
+		for name, typ := range t.Params {
+			switch typ {
+			case rules.TypeItemID, rules.TypeInt, rules.TypeFloat, rules.TypeFloat01:
+			default:
+				return fmt.Errorf("law_templates.json: %s: bad param type %s for %s", t.ID, typ, name)
+			}
+		}
+		if err := rules.ValidateSpecs(t.Params, t.Rules); err != nil {
+			return fmt.Errorf("law_templates.json: %s: %w", t.ID, err)
+		}
 		out.ByID[t.ID] = t
 	}
 	return nil
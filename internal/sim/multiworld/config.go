@@ -8,12 +8,14 @@ import (
 
 	"gopkg.in/yaml.v3"
 	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/world"
 )
 
 type Config struct {
 	DefaultWorldID string            `yaml:"default_world_id"`
 	Worlds         []WorldSpec       `yaml:"worlds"`
 	SwitchRoutes   []SwitchRouteSpec `yaml:"switch_routes,omitempty"`
+	Permits        []PermitSpec      `yaml:"permits,omitempty"`
 }
 
 type WorldSpec struct {
@@ -21,6 +23,7 @@ type WorldSpec struct {
 	Type                string           `yaml:"type"`
 	SeedOffset          int64            `yaml:"seed_offset"`
 	BoundaryR           int              `yaml:"boundary_r"`
+	Height              int              `yaml:"height,omitempty"` // layers; 1 (default) is a flat world
 	ResetEveryTicks     int              `yaml:"reset_every_ticks"`
 	ResetNoticeTicks    int              `yaml:"reset_notice_ticks"`
 	SwitchCooldownTicks int              `yaml:"switch_cooldown_ticks"`
@@ -53,6 +56,22 @@ type SwitchRouteSpec struct {
 	RequiresPermit bool   `yaml:"requires_permit"`
 }
 
+// PermitSpec is a world permit. A switch into a world that requires a permit (world or
+// route requires_permit) succeeds if any permit listing that world admits the agent:
+// a held unexpired permit, org membership or reputation (free), else one permit item
+// from the inventory, else the price.
+type PermitSpec struct {
+	ID            string         `yaml:"id"`
+	Worlds        []string       `yaml:"worlds"`
+	DurationTicks int            `yaml:"duration_ticks"` // 0 = never expires
+	OrgIDs        []string       `yaml:"org_ids,omitempty"`
+	OrgKinds      []string       `yaml:"org_kinds,omitempty"`
+	MinRepLaw     int            `yaml:"min_rep_law,omitempty"`
+	MinRepTrade   int            `yaml:"min_rep_trade,omitempty"`
+	Item          string         `yaml:"item,omitempty"`
+	Price         map[string]int `yaml:"price,omitempty"`
+}
+
 func Load(path string) (Config, error) {
 	cfg := defaults()
 	if strings.TrimSpace(path) == "" {
@@ -99,6 +118,7 @@ func defaults() Config {
 			{
 				ID:                  "MINE_L1",
 				Type:                "MINE_L1",
+				Height:              16,
 				BoundaryR:           1200,
 				ResetEveryTicks:     3000,
 				ResetNoticeTicks:    300,
@@ -118,6 +138,7 @@ func defaults() Config {
 			{
 				ID:                  "MINE_L2",
 				Type:                "MINE_L2",
+				Height:              24,
 				BoundaryR:           1000,
 				ResetEveryTicks:     6000,
 				ResetNoticeTicks:    300,
@@ -137,6 +158,7 @@ func defaults() Config {
 			{
 				ID:                  "MINE_L3",
 				Type:                "MINE_L3",
+				Height:              32,
 				BoundaryR:           800,
 				ResetEveryTicks:     12000,
 				ResetNoticeTicks:    300,
@@ -191,6 +213,9 @@ func (c *Config) Normalize() {
 		return
 	}
 	for i := range c.Worlds {
+		if c.Worlds[i].Height <= 0 {
+			c.Worlds[i].Height = 1
+		}
 		if len(c.Worlds[i].EntryPoints) == 0 {
 			id := strings.TrimSpace(c.Worlds[i].EntryPointID)
 			if id == "" {
@@ -249,6 +274,9 @@ func (c Config) Validate() error {
 		if w.BoundaryR <= 0 {
 			return fmt.Errorf("world %s boundary_r must be > 0", w.ID)
 		}
+		if w.Height < 1 || w.Height > 256 {
+			return fmt.Errorf("world %s height must be in [1,256]", w.ID)
+		}
 		if w.ResetEveryTicks <= 0 {
 			return fmt.Errorf("world %s reset_every_ticks must be > 0", w.ID)
 		}
@@ -309,9 +337,88 @@ func (c Config) Validate() error {
 			return fmt.Errorf("switch_routes[%d] to_entry_id %q not found in %s", i, r.ToEntryID, r.ToWorld)
 		}
 	}
+	permitIDs := map[string]bool{}
+	for i, p := range c.Permits {
+		if strings.TrimSpace(p.ID) == "" {
+			return fmt.Errorf("permits[%d] id must not be empty", i)
+		}
+		if permitIDs[p.ID] {
+			return fmt.Errorf("duplicate permit id: %s", p.ID)
+		}
+		permitIDs[p.ID] = true
+		if len(p.Worlds) == 0 {
+			return fmt.Errorf("permit %s must list worlds", p.ID)
+		}
+		for _, wid := range p.Worlds {
+			if !seen[wid] {
+				return fmt.Errorf("permit %s world %q not found", p.ID, wid)
+			}
+		}
+		if p.DurationTicks < 0 {
+			return fmt.Errorf("permit %s duration_ticks must be >= 0", p.ID)
+		}
+		for _, k := range p.OrgKinds {
+			if k != "GUILD" && k != "CITY" {
+				return fmt.Errorf("permit %s bad org kind %q", p.ID, k)
+			}
+		}
+		for item, n := range p.Price {
+			if strings.TrimSpace(item) == "" || n <= 0 {
+				return fmt.Errorf("permit %s price entries must be item: count > 0", p.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidatePermitItems checks that permit items and prices name catalog items.
+func (c Config) ValidatePermitItems(itemExists func(string) bool) error {
+	for _, p := range c.Permits {
+		if p.Item != "" && !itemExists(p.Item) {
+			return fmt.Errorf("permit %s unknown item %s", p.ID, p.Item)
+		}
+		for item := range p.Price {
+			if !itemExists(item) {
+				return fmt.Errorf("permit %s unknown price item %s", p.ID, item)
+			}
+		}
+	}
 	return nil
 }
 
+// PermitRules returns the permits that admit agents into worldID, in config order.
+func (c Config) PermitRules(worldID string) []world.PermitRule {
+	var out []world.PermitRule
+	for _, p := range c.Permits {
+		for _, wid := range p.Worlds {
+			if wid != worldID {
+				continue
+			}
+			out = append(out, world.PermitRule{
+				ID:            p.ID,
+				DurationTicks: uint64(max(0, p.DurationTicks)),
+				OrgIDs:        append([]string(nil), p.OrgIDs...),
+				OrgKinds:      append([]string(nil), p.OrgKinds...),
+				MinRepLaw:     p.MinRepLaw,
+				MinRepTrade:   p.MinRepTrade,
+				Item:          p.Item,
+				Price:         p.Price,
+			})
+			break
+		}
+	}
+	return out
+}
+
+// PermitIDs returns the ids of the permits that admit agents into worldID.
+func (c Config) PermitIDs(worldID string) []string {
+	var out []string
+	for _, r := range c.PermitRules(worldID) {
+		out = append(out, r.ID)
+	}
+	return out
+}
+
 func (c Config) Manifest() []protocol.WorldRef {
 	out := make([]protocol.WorldRef, 0, len(c.Worlds))
 	for _, w := range c.Worlds {
@@ -19,6 +19,7 @@ type Session struct {
 	AgentID      string
 	CurrentWorld string
 	DeltaVoxels  bool
+	Encoding     string
 	Out          chan []byte
 }
 
@@ -81,6 +82,7 @@ type Manager struct {
 	worldsCfg map[string]WorldSpec
 	entries   map[string]map[string]EntryPointSpec
 	routes    map[string][]SwitchRouteSpec
+	permits   map[string][]world.PermitRule
 	manifest  []protocol.WorldRef
 	defaultID string
 	stateFile string
@@ -131,8 +133,10 @@ func NewManager(cfg Config, runtimes map[string]*Runtime, stateFile string) (*Ma
 	}
 	worldsCfg := map[string]WorldSpec{}
 	entries := map[string]map[string]EntryPointSpec{}
+	permits := map[string][]world.PermitRule{}
 	for _, spec := range cfg.Worlds {
 		worldsCfg[spec.ID] = spec
+		permits[spec.ID] = cfg.PermitRules(spec.ID)
 		byID := map[string]EntryPointSpec{}
 		for _, ep := range spec.EntryPoints {
 			byID[ep.ID] = ep
@@ -158,6 +162,7 @@ func NewManager(cfg Config, runtimes map[string]*Runtime, stateFile string) (*Ma
 		worldsCfg:          worldsCfg,
 		entries:            entries,
 		routes:             routes,
+		permits:            permits,
 		manifest:           cfg.Manifest(),
 		defaultID:          cfg.DefaultWorldID,
 		stateFile:          stateFile,
@@ -316,6 +321,13 @@ func (m *Manager) SwitchMetrics() []SwitchMetric {
 }
 
 func (m *Manager) Join(name string, delta bool, out chan []byte, worldPreference string) (Session, world.JoinResponse, error) {
+	return m.JoinAs("", nil, name, delta, "", out, worldPreference)
+}
+
+// JoinAs joins with a requested agent id (identity binding); an empty id behaves like Join.
+// reserved, when set, marks ids bound to other identities that a fresh id must not reuse.
+// encoding selects the OBS wire encoding ("" = JSON).
+func (m *Manager) JoinAs(agentID string, reserved func(agentID string) bool, name string, delta bool, encoding string, out chan []byte, worldPreference string) (Session, world.JoinResponse, error) {
 	target := m.pickWorld(worldPreference)
 	rt := m.runtime(target)
 	if rt == nil {
@@ -325,7 +337,10 @@ func (m *Manager) Join(name string, delta bool, out chan []byte, worldPreference
 	respCh := make(chan world.JoinResponse, 1)
 	req := world.JoinRequest{
 		Name:        name,
+		AgentID:     agentID,
+		Reserved:    reserved,
 		DeltaVoxels: delta,
+		Encoding:    encoding,
 		Out:         out,
 		Resp:        respCh,
 	}
@@ -345,14 +360,33 @@ func (m *Manager) Join(name string, delta bool, out chan []byte, worldPreference
 		AgentID:      resp.Welcome.AgentID,
 		CurrentWorld: target,
 		DeltaVoxels:  delta,
+		Encoding:     encoding,
 		Out:          out,
 	}
 	m.updateResidency(resp.Welcome.AgentID, target, resp.Welcome.ResumeToken)
 	return s, resp, nil
 }
 
-func (m *Manager) Attach(resumeToken string, delta bool, out chan []byte) (Session, world.JoinResponse, error) {
-	worldID := m.worldByResumeToken(resumeToken)
+func (m *Manager) Attach(resumeToken string, delta bool, encoding string, out chan []byte) (Session, world.JoinResponse, error) {
+	s, resp, ok := m.attach(m.worldByResumeToken(resumeToken), world.AttachRequest{ResumeToken: resumeToken, DeltaVoxels: delta, Encoding: encoding, Out: out})
+	if !ok {
+		return Session{}, world.JoinResponse{}, errors.New("resume token not found")
+	}
+	return s, resp, nil
+}
+
+// AttachAgent re-attaches an authenticated identity to its bound agent, starting at the
+// agent's resident world.
+func (m *Manager) AttachAgent(agentID string, delta bool, encoding string, out chan []byte) (Session, world.JoinResponse, error) {
+	s, resp, ok := m.attach(m.AgentWorld(agentID), world.AttachRequest{AgentID: agentID, DeltaVoxels: delta, Encoding: encoding, Out: out})
+	if !ok {
+		return Session{}, world.JoinResponse{}, errors.New("agent not found")
+	}
+	return s, resp, nil
+}
+
+func (m *Manager) attach(worldID string, base world.AttachRequest) (Session, world.JoinResponse, bool) {
+	delta, encoding, out := base.DeltaVoxels, base.Encoding, base.Out
 	try := []string{}
 	if worldID != "" {
 		try = append(try, worldID)
@@ -370,12 +404,8 @@ func (m *Manager) Attach(resumeToken string, delta bool, out chan []byte) (Sessi
 			continue
 		}
 		respCh := make(chan world.JoinResponse, 1)
-		req := world.AttachRequest{
-			ResumeToken: resumeToken,
-			DeltaVoxels: delta,
-			Out:         out,
-			Resp:        respCh,
-		}
+		req := base
+		req.Resp = respCh
 		ctx, cancel := m.requestCtx(context.Background())
 		resp, err := m.sendAttachRequest(ctx, rt, req)
 		cancel()
@@ -391,12 +421,13 @@ func (m *Manager) Attach(resumeToken string, delta bool, out chan []byte) (Sessi
 			AgentID:      resp.Welcome.AgentID,
 			CurrentWorld: id,
 			DeltaVoxels:  delta,
+			Encoding:     encoding,
 			Out:          out,
 		}
 		m.updateResidency(resp.Welcome.AgentID, id, resp.Welcome.ResumeToken)
-		return s, resp, nil
+		return s, resp, true
 	}
-	return Session{}, world.JoinResponse{}, errors.New("resume token not found")
+	return Session{}, world.JoinResponse{}, false
 }
 
 func (m *Manager) Leave(s Session) {
@@ -483,10 +514,6 @@ func (m *Manager) switchWorld(ctx context.Context, s *Session, target, entryPoin
 		m.recordSwitch(srcID, target, "denied")
 		return m.injectActionResult(ctx, s.CurrentWorld, s.AgentID, actionResult(0, ref, false, protocol.ErrWorldDenied, "entry point required"))
 	}
-	if route.RequiresPermit || dst.Spec.RequiresPermit {
-		m.recordSwitch(srcID, target, "denied")
-		return m.injectActionResult(ctx, s.CurrentWorld, s.AgentID, actionResult(0, ref, false, protocol.ErrWorldDenied, "permit required"))
-	}
 	if !withinEntry(pos, srcEntry) {
 		m.recordSwitch(srcID, target, "denied")
 		return m.injectActionResult(ctx, s.CurrentWorld, s.AgentID, actionResult(0, ref, false, protocol.ErrWorldDenied, "entry point required"))
@@ -501,11 +528,13 @@ func (m *Manager) switchWorld(ctx context.Context, s *Session, target, entryPoin
 	nowDst := dst.World.CurrentTick()
 	if transfer.WorldSwitchCooldownUntilTick != 0 && nowDst < transfer.WorldSwitchCooldownUntilTick {
 		// Roll back to source world on cooldown rejection.
-		_ = src.World.RequestTransferIn(timeoutCtx, transfer, s.Out, s.DeltaVoxels)
+		_ = src.World.RequestTransferIn(timeoutCtx, transfer, s.Out, s.DeltaVoxels, s.Encoding)
 		m.recordSwitch(srcID, target, "cooldown")
 		return m.injectActionResult(ctx, s.CurrentWorld, s.AgentID, actionResult(0, ref, false, protocol.ErrWorldCooldown, "switch cooldown active"))
 	}
 
+	// The agent as it left the source: where it stands and without a new cooldown.
+	rollback := transfer
 	m.mergeOrgMetaFromTransfer(transfer.Org)
 	m.attachOrgMetaToTransfer(&transfer)
 	// 1.0 entry placement: attach directly to configured destination entry point.
@@ -516,9 +545,22 @@ func (m *Manager) switchWorld(ctx context.Context, s *Session, target, entryPoin
 	transfer.ToEntryPointID = dstEntry.ID
 	transfer.WorldSwitchCooldownUntilTick = nowDst + uint64(max(0, dst.Spec.SwitchCooldownTicks))
 
-	if err := dst.World.RequestTransferIn(timeoutCtx, transfer, s.Out, s.DeltaVoxels); err != nil {
+	var permit world.PermitGrant
+	if route.RequiresPermit || dst.Spec.RequiresPermit {
+		// Checked (and items/price charged) by the destination world loop on admission, so
+		// every rollback below hands back the transfer as it left the source, uncharged.
+		permit, err = dst.World.RequestPermitTransferIn(timeoutCtx, transfer, m.permitRules(target), s.Out, s.DeltaVoxels, s.Encoding)
+	} else {
+		err = dst.World.RequestTransferIn(timeoutCtx, transfer, s.Out, s.DeltaVoxels, s.Encoding)
+	}
+	if err != nil {
 		// Attempt rollback to source to avoid orphaning the agent.
-		_ = src.World.RequestTransferIn(timeoutCtx, transfer, s.Out, s.DeltaVoxels)
+		_ = src.World.RequestTransferIn(timeoutCtx, rollback, s.Out, s.DeltaVoxels, s.Encoding)
+		var denied *world.TransferDeniedError
+		if errors.As(err, &denied) {
+			m.recordSwitch(srcID, target, "permit_denied")
+			return m.injectActionResult(ctx, srcID, s.AgentID, actionResult(0, ref, false, denied.Code, denied.Message))
+		}
 		m.recordSwitch(srcID, target, "target_busy")
 		return m.injectActionResult(ctx, srcID, s.AgentID, actionResult(0, ref, false, protocol.ErrWorldBusy, "switch failed: "+err.Error()))
 	}
@@ -526,7 +568,7 @@ func (m *Manager) switchWorld(ctx context.Context, s *Session, target, entryPoin
 	s.CurrentWorld = target
 	m.updateResidency(s.AgentID, target, "")
 	m.recordSwitch(srcID, target, "ok")
-	_ = m.injectActionResult(ctx, target, s.AgentID, protocol.Event{
+	res := protocol.Event{
 		"type":          "ACTION_RESULT",
 		"ref":           ref,
 		"ok":            true,
@@ -534,10 +576,22 @@ func (m *Manager) switchWorld(ctx context.Context, s *Session, target, entryPoin
 		"from":          srcID,
 		"from_entry_id": srcEntry.ID,
 		"to_entry_id":   dstEntry.ID,
-	})
+	}
+	if permit.PermitID != "" {
+		res["permit_id"] = permit.PermitID
+		res["permit_via"] = permit.Via
+		res["permit_remaining_ticks"] = permit.RemainingTicks
+	}
+	_ = m.injectActionResult(ctx, target, s.AgentID, res)
 	return nil
 }
 
+func (m *Manager) permitRules(worldID string) []world.PermitRule {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.permits[worldID]
+}
+
 func (m *Manager) selectRoute(fromWorld, toWorld, requestedFromEntry string, pos world.Vec3i) (SwitchRouteSpec, EntryPointSpec, EntryPointSpec, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -723,8 +777,8 @@ func (m *Manager) MoveAgentWorld(ctx context.Context, agentID, targetWorldID str
 	transfer.FromWorldID = srcID
 	transfer.CurrentWorldID = targetWorldID
 	transfer.WorldSwitchCooldownUntilTick = dst.World.CurrentTick() + uint64(max(0, dst.Spec.SwitchCooldownTicks))
-	if err := dst.World.RequestTransferIn(tctx, transfer, nil, false); err != nil {
-		_ = src.World.RequestTransferIn(tctx, transfer, nil, false)
+	if err := dst.World.RequestTransferIn(tctx, transfer, nil, false, ""); err != nil {
+		_ = src.World.RequestTransferIn(tctx, transfer, nil, false, "")
 		m.recordSwitch(srcID, targetWorldID, "admin_target_busy")
 		return err
 	}
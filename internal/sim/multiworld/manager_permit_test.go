@@ -0,0 +1,122 @@
+package multiworld
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"voxelcraft.ai/internal/protocol"
+)
+
+func TestManagerSwitchWorld_PermitRequired(t *testing.T) {
+	wOver := newTestWorldForManager(t, "OVERWORLD", 12)
+	wMine := newTestWorldForManager(t, "MINE_L1", 22)
+	wDeep := newTestWorldForManager(t, "MINE_L2", 32)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = wOver.Run(ctx) }()
+	go func() { _ = wMine.Run(ctx) }()
+	go func() { _ = wDeep.Run(ctx) }()
+
+	spec := func(id, entry string) WorldSpec {
+		return WorldSpec{
+			ID: id, Type: id, BoundaryR: 128, ResetEveryTicks: 12000, SwitchCooldownTicks: 1,
+			EntryPointID: entry,
+			EntryPoints:  []EntryPointSpec{{ID: entry, X: 0, Z: 0, Radius: 16, Enabled: true}},
+		}
+	}
+	cfg := Config{
+		DefaultWorldID: "OVERWORLD",
+		Worlds:         []WorldSpec{spec("OVERWORLD", "over_spawn"), spec("MINE_L1", "mine_gate"), spec("MINE_L2", "deep_gate")},
+		SwitchRoutes: []SwitchRouteSpec{
+			{FromWorld: "OVERWORLD", ToWorld: "MINE_L1", FromEntryID: "over_spawn", ToEntryID: "mine_gate", RequiresPermit: true},
+			{FromWorld: "OVERWORLD", ToWorld: "MINE_L2", FromEntryID: "over_spawn", ToEntryID: "deep_gate"},
+		},
+		Permits: []PermitSpec{
+			{ID: "MINE_PASS", Worlds: []string{"MINE_L1"}, DurationTicks: 100, MinRepLaw: 400},
+			{ID: "DEEP_PASS", Worlds: []string{"MINE_L2"}, MinRepLaw: 900, Price: map[string]int{"IRON_INGOT": 5}},
+		},
+	}
+	cfg.Worlds[2].RequiresPermit = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate cfg: %v", err)
+	}
+	mgr, err := NewManager(cfg, map[string]*Runtime{
+		"OVERWORLD": {Spec: cfg.Worlds[0], World: wOver},
+		"MINE_L1":   {Spec: cfg.Worlds[1], World: wMine},
+		"MINE_L2":   {Spec: cfg.Worlds[2], World: wDeep},
+	}, filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer mgr.Close()
+	out := make(chan []byte, 256)
+	sess, _, err := mgr.Join("agent", true, out, "OVERWORLD")
+	if err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	obs := waitObsMsg(t, out, 3*time.Second)
+	switchTo := func(ref, target string) protocol.Event {
+		t.Helper()
+		if _, err := mgr.RouteAct(context.Background(), &sess, protocol.ActMsg{
+			Type:            protocol.TypeAct,
+			ProtocolVersion: protocol.Version,
+			Tick:            obs.Tick,
+			AgentID:         sess.AgentID,
+			Instants:        []protocol.InstantReq{{ID: ref, Type: "SWITCH_WORLD", TargetWorldID: target}},
+		}); err != nil {
+			t.Fatalf("route act: %v", err)
+		}
+		ev, _ := waitActionResult(t, out, ref, 3*time.Second)
+		return ev
+	}
+
+	// Default law reputation (500) is below DEEP_PASS and the agent cannot pay.
+	ev := switchTo("I_SWITCH_DEEP", "MINE_L2")
+	if ok, _ := ev["ok"].(bool); ok {
+		t.Fatalf("expected denied switch, got %+v", ev)
+	}
+	if code, _ := ev["code"].(string); code != protocol.ErrWorldPermitRequired {
+		t.Fatalf("expected %s, got %+v", protocol.ErrWorldPermitRequired, ev)
+	}
+	if sess.CurrentWorld != "OVERWORLD" {
+		t.Fatalf("agent should stay in OVERWORLD, got %s", sess.CurrentWorld)
+	}
+
+	ev = switchTo("I_SWITCH_MINE", "MINE_L1")
+	if ok, _ := ev["ok"].(bool); !ok {
+		t.Fatalf("expected permitted switch, got %+v", ev)
+	}
+	if ev["permit_id"] != "MINE_PASS" || ev["permit_via"] != "REP" {
+		t.Fatalf("unexpected permit grant: %+v", ev)
+	}
+	if rem, _ := ev["permit_remaining_ticks"].(float64); rem != 100 {
+		t.Fatalf("expected 100 permit ticks, got %+v", ev)
+	}
+	if sess.CurrentWorld != "MINE_L1" {
+		t.Fatalf("agent should be in MINE_L1, got %s", sess.CurrentWorld)
+	}
+}
+
+func TestConfigValidate_Permits(t *testing.T) {
+	cfg := testManagerConfig()
+	cfg.Permits = []PermitSpec{{ID: "P", Worlds: []string{"NOPE"}}}
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected unknown permit world rejected")
+	}
+	cfg.Permits = []PermitSpec{{ID: "P", Worlds: []string{"MINE_L1"}, Price: map[string]int{"IRON_INGOT": 0}}}
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected zero price rejected")
+	}
+	cfg.Permits = []PermitSpec{{ID: "P", Worlds: []string{"MINE_L1"}, OrgKinds: []string{"GUILD"}, Item: "MINE_PERMIT"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if err := cfg.ValidatePermitItems(func(id string) bool { return id == "IRON_INGOT" }); err == nil {
+		t.Fatalf("expected unknown permit item rejected")
+	}
+	if rules := cfg.PermitRules("MINE_L1"); len(rules) != 1 || rules[0].ID != "P" {
+		t.Fatalf("unexpected permit rules: %+v", rules)
+	}
+}
@@ -13,6 +13,9 @@ const (
 	KindCraft          Kind = "CRAFT"
 	KindSmelt          Kind = "SMELT"
 	KindBuildBlueprint Kind = "BUILD_BLUEPRINT"
+	KindAttack         Kind = "ATTACK"
+	KindTill           Kind = "TILL"
+	KindBuy            Kind = "BUY"
 )
 
 type MovementTask struct {
@@ -24,6 +27,11 @@ type MovementTask struct {
 	Distance    float64 // FOLLOW: desired distance
 	StartPos    Vec3i
 	StartedTick uint64
+
+	// Cached A* route (remaining steps, excluding the current position) and the
+	// goal it was planned for. Cleared when an audited block change crosses it.
+	Path     []Vec3i
+	PathGoal Vec3i
 }
 
 type WorkTask struct {
@@ -43,7 +51,7 @@ type WorkTask struct {
 	Rotation    int
 	BuildIndex  int // next block index to place
 
-	// OPEN/TRANSFER
+	// OPEN/TRANSFER/ATTACK/BUY
 	TargetID     string
 	SrcContainer string
 	DstContainer string
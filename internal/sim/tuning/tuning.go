@@ -19,6 +19,8 @@ type Tuning struct {
 	SeasonLengthTicks int   `yaml:"season_length_ticks"`
 	ChunkSize         []int `yaml:"chunk_size"`
 	ObsRadius         int   `yaml:"obs_radius"`
+	ObsRadiusMin      int   `yaml:"obs_radius_min"`
+	ObsRadiusMax      int   `yaml:"obs_radius_max"`
 	WorldBoundaryR    int   `yaml:"world_boundary_r"`
 
 	WorldGen WorldGen `yaml:"worldgen"`
@@ -27,7 +29,19 @@ type Tuning struct {
 	StarterItems map[string]int `yaml:"starter_items"`
 
 	SnapshotEveryTicks int `yaml:"snapshot_every_ticks"`
+	// Every Nth snapshot is a full base; the rest are deltas against it (1 = always full).
+	SnapshotFullEvery  int `yaml:"snapshot_full_every"`
 	DirectorEveryTicks int `yaml:"director_every_ticks"`
+	// Idle chunks are evicted after this many ticks; modified ones spill to <world>/regions.
+	ChunkIdleTicks int `yaml:"chunk_idle_ticks"`
+	// Max ENQUEUE'd tasks per agent.
+	TaskQueueDepth int `yaml:"task_queue_depth"`
+	// Max SET_TRIGGER fires per tick across all agents.
+	TriggerBudgetPerTick int `yaml:"trigger_budget_per_tick"`
+	// Max water cell updates per tick (flow spreading/drying).
+	WaterUpdatesPerTick int `yaml:"water_updates_per_tick"`
+	// Max signal network cell updates per tick.
+	SignalUpdatesPerTick int `yaml:"signal_updates_per_tick"`
 
 	RateLimits RateLimits `yaml:"rate_limits"`
 
@@ -93,6 +107,8 @@ func Defaults() Tuning {
 		SeasonLengthTicks: 6000 * 7, // 7 in-game days
 		ChunkSize:         []int{16, 16, 1},
 		ObsRadius:         7,
+		ObsRadiusMin:      2,
+		ObsRadiusMax:      12,
 		WorldBoundaryR:    4000,
 
 		WorldGen: WorldGen{
@@ -112,8 +128,14 @@ func Defaults() Tuning {
 			"BERRIES": 10,
 		},
 
-		SnapshotEveryTicks: 3000,
-		DirectorEveryTicks: 3000,
+		SnapshotEveryTicks:   3000,
+		SnapshotFullEvery:    10,
+		DirectorEveryTicks:   3000,
+		ChunkIdleTicks:       600,
+		TaskQueueDepth:       8,
+		TriggerBudgetPerTick: 64,
+		WaterUpdatesPerTick:  256,
+		SignalUpdatesPerTick: 512,
 
 		RateLimits: RateLimits{
 			SayWindowTicks:        50,
@@ -183,13 +205,16 @@ func (t Tuning) Validate() error {
 	if t.ChunkSize[0] != 16 || t.ChunkSize[1] != 16 {
 		return fmt.Errorf("chunk_size x/z must be 16 (got %v)", t.ChunkSize)
 	}
-	if t.ChunkSize[2] != 1 {
-		return fmt.Errorf("chunk_size height must be 1 for 2D world (got %d)", t.ChunkSize[2])
+	if t.ChunkSize[2] < 1 || t.ChunkSize[2] > 256 {
+		return fmt.Errorf("chunk_size height must be in [1,256] (got %d)", t.ChunkSize[2])
 	}
 
 	if t.ObsRadius <= 0 || t.ObsRadius > 32 {
 		return fmt.Errorf("obs_radius must be in 1..32 (got %d)", t.ObsRadius)
 	}
+	if t.ObsRadiusMin <= 0 || t.ObsRadiusMin > t.ObsRadius || t.ObsRadiusMax < t.ObsRadius || t.ObsRadiusMax > 32 {
+		return fmt.Errorf("obs_radius_min/max must satisfy 1 <= min <= obs_radius <= max <= 32 (got %d/%d)", t.ObsRadiusMin, t.ObsRadiusMax)
+	}
 	if t.WorldBoundaryR <= 0 {
 		return fmt.Errorf("world_boundary_r must be > 0 (got %d)", t.WorldBoundaryR)
 	}
@@ -210,9 +235,27 @@ func (t Tuning) Validate() error {
 	if t.SnapshotEveryTicks <= 0 {
 		return fmt.Errorf("snapshot_every_ticks must be > 0 (got %d)", t.SnapshotEveryTicks)
 	}
+	if t.SnapshotFullEvery <= 0 {
+		return fmt.Errorf("snapshot_full_every must be > 0 (got %d)", t.SnapshotFullEvery)
+	}
 	if t.DirectorEveryTicks <= 0 {
 		return fmt.Errorf("director_every_ticks must be > 0 (got %d)", t.DirectorEveryTicks)
 	}
+	if t.ChunkIdleTicks <= 0 {
+		return fmt.Errorf("chunk_idle_ticks must be > 0 (got %d)", t.ChunkIdleTicks)
+	}
+	if t.TaskQueueDepth <= 0 || t.TaskQueueDepth > 64 {
+		return fmt.Errorf("task_queue_depth must be in 1..64 (got %d)", t.TaskQueueDepth)
+	}
+	if t.TriggerBudgetPerTick <= 0 || t.TriggerBudgetPerTick > 4096 {
+		return fmt.Errorf("trigger_budget_per_tick must be in 1..4096 (got %d)", t.TriggerBudgetPerTick)
+	}
+	if t.WaterUpdatesPerTick <= 0 || t.WaterUpdatesPerTick > 65536 {
+		return fmt.Errorf("water_updates_per_tick must be in 1..65536 (got %d)", t.WaterUpdatesPerTick)
+	}
+	if t.SignalUpdatesPerTick <= 0 || t.SignalUpdatesPerTick > 65536 {
+		return fmt.Errorf("signal_updates_per_tick must be in 1..65536 (got %d)", t.SignalUpdatesPerTick)
+	}
 
 	// Worldgen tuning.
 	if t.WorldGen.BiomeRegionSize <= 0 || t.WorldGen.BiomeRegionSize > 512 {
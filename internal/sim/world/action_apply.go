@@ -2,6 +2,7 @@ package world
 
 import (
 	"voxelcraft.ai/internal/protocol"
+	taskqueuepkg "voxelcraft.ai/internal/sim/world/feature/work/queue"
 )
 
 func (w *World) applyAct(a *Agent, act protocol.ActMsg, nowTick uint64) {
@@ -23,9 +24,23 @@ func (w *World) applyAct(a *Agent, act protocol.ActMsg, nowTick uint64) {
 			a.AddEvent(actionResult(nowTick, cid, true, "", "canceled"))
 			continue
 		}
+		if taskqueuepkg.Cancel(a, cid) {
+			a.AddEvent(actionResult(nowTick, cid, true, "", "dequeued"))
+			continue
+		}
 		a.AddEvent(actionResult(nowTick, cid, false, protocol.ErrInvalidTarget, "task not found"))
 	}
 
+	if w.permitBarred(a) {
+		for _, inst := range act.Instants {
+			a.AddEvent(actionResult(nowTick, inst.ID, false, protocol.ErrWorldPermitExpired, "no permit for this world"))
+		}
+		for _, tr := range act.Tasks {
+			a.AddEvent(actionResult(nowTick, tr.ID, false, protocol.ErrWorldPermitExpired, "no permit for this world"))
+		}
+		return
+	}
+
 	// Instants.
 	for _, inst := range act.Instants {
 		w.applyInstant(a, inst, nowTick)
@@ -46,6 +61,10 @@ func (w *World) applyInstant(a *Agent, inst protocol.InstantReq, nowTick uint64)
 }
 
 func (w *World) applyTaskReq(a *Agent, tr protocol.TaskReq, nowTick uint64) {
+	if tr.Queue != "" {
+		taskqueuepkg.HandleEnqueue(w.taskQueueEnv(), actionResult, a, tr, nowTick, w.cfg.TaskQueueDepth)
+		return
+	}
 	if h := taskReqDispatch[tr.Type]; h != nil {
 		h(w, a, tr, nowTick)
 		return
@@ -12,17 +12,26 @@ const (
 	InstantTypeEat            = "EAT"
 	InstantTypeSaveMemory     = "SAVE_MEMORY"
 	InstantTypeLoadMemory     = "LOAD_MEMORY"
+	InstantTypeSetView        = "SET_VIEW"
+	InstantTypeSetTrigger     = "SET_TRIGGER"
+	InstantTypeRemoveTrigger  = "REMOVE_TRIGGER"
 	InstantTypeOfferTrade     = "OFFER_TRADE"
 	InstantTypeAcceptTrade    = "ACCEPT_TRADE"
 	InstantTypeDeclineTrade   = "DECLINE_TRADE"
+	InstantTypePostOrder      = "POST_ORDER"
+	InstantTypeCancelOrder    = "CANCEL_ORDER"
+	InstantTypeSetShopPrice   = "SET_SHOP_PRICE"
 	InstantTypePostBoard      = "POST_BOARD"
 	InstantTypeSearchBoard    = "SEARCH_BOARD"
 	InstantTypeSetSign        = "SET_SIGN"
 	InstantTypeToggleSwitch   = "TOGGLE_SWITCH"
+	InstantTypeSetSensor      = "SET_SENSOR"
+	InstantTypeSetRecipe      = "SET_RECIPE"
 	InstantTypeClaimOwed      = "CLAIM_OWED"
 	InstantTypePostContract   = "POST_CONTRACT"
 	InstantTypeAcceptContract = "ACCEPT_CONTRACT"
 	InstantTypeSubmitContract = "SUBMIT_CONTRACT"
+	InstantTypeBidAuction     = "BID_AUCTION"
 	InstantTypeSetPermissions = "SET_PERMISSIONS"
 	InstantTypeUpgradeClaim   = "UPGRADE_CLAIM"
 	InstantTypeAddMember      = "ADD_MEMBER"
@@ -35,9 +44,11 @@ const (
 	InstantTypeDeedLand       = "DEED_LAND"
 	InstantTypeProposeLaw     = "PROPOSE_LAW"
 	InstantTypeVote           = "VOTE"
+	InstantTypeEquip          = "EQUIP"
 
-	TaskTypeStop      = "STOP"
-	TaskTypeClaimLand = "CLAIM_LAND"
+	TaskTypeStop       = "STOP"
+	TaskTypeClaimLand  = "CLAIM_LAND"
+	TaskTypeClearQueue = "CLEAR_QUEUE"
 )
 
 var supportedInstantTypes = []string{
@@ -46,17 +57,26 @@ var supportedInstantTypes = []string{
 	InstantTypeEat,
 	InstantTypeSaveMemory,
 	InstantTypeLoadMemory,
+	InstantTypeSetView,
+	InstantTypeSetTrigger,
+	InstantTypeRemoveTrigger,
 	InstantTypeOfferTrade,
 	InstantTypeAcceptTrade,
 	InstantTypeDeclineTrade,
+	InstantTypePostOrder,
+	InstantTypeCancelOrder,
+	InstantTypeSetShopPrice,
 	InstantTypePostBoard,
 	InstantTypeSearchBoard,
 	InstantTypeSetSign,
 	InstantTypeToggleSwitch,
+	InstantTypeSetSensor,
+	InstantTypeSetRecipe,
 	InstantTypeClaimOwed,
 	InstantTypePostContract,
 	InstantTypeAcceptContract,
 	InstantTypeSubmitContract,
+	InstantTypeBidAuction,
 	InstantTypeSetPermissions,
 	InstantTypeUpgradeClaim,
 	InstantTypeAddMember,
@@ -69,6 +89,7 @@ var supportedInstantTypes = []string{
 	InstantTypeDeedLand,
 	InstantTypeProposeLaw,
 	InstantTypeVote,
+	InstantTypeEquip,
 }
 
 var supportedTaskReqTypes = []string{
@@ -84,6 +105,10 @@ var supportedTaskReqTypes = []string{
 	string(tasks.KindSmelt),
 	TaskTypeClaimLand,
 	string(tasks.KindBuildBlueprint),
+	string(tasks.KindAttack),
+	string(tasks.KindTill),
+	string(tasks.KindBuy),
+	TaskTypeClearQueue,
 }
 
 func validateActionDispatchMaps() error {
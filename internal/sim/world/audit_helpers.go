@@ -13,6 +13,12 @@ func (w *World) auditSetBlock(tick uint64, actor string, pos Vec3i, from, to uin
 	if w.auditLogger != nil {
 		_ = w.auditLogger.WriteAudit(entry)
 	}
+	w.invalidateMovePaths(pos)
+	w.wakeWater(pos)
+	w.wakeSignal(pos)
+	if w.cfg.Height > 1 {
+		w.gravityQueue = append(w.gravityQueue, pos)
+	}
 	if len(w.observers) > 0 {
 		w.obsAuditsThisTick = append(w.obsAuditsThisTick, entry)
 	}
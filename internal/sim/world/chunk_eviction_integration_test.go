@@ -0,0 +1,107 @@
+package world
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestChunkEviction_DigestIndependentOfResidency(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "evict", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000, ChunkIdleTicks: 40}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	if err := w.SetChunkRegionDir(t.TempDir()); err != nil {
+		t.Fatalf("regions: %v", err)
+	}
+	w.StepOnce([]JoinRequest{{Name: "alpha"}}, nil, nil)
+
+	far := Vec3i{X: 800, Y: 0, Z: -800}
+	setSolid(w, far, w.catalogs.Blocks.Index["STONE"])
+	tick := w.CurrentTick()
+	before := chunkSectionDigest(w, tick)
+
+	for i := 0; i < 2*chunkMaintainEveryTicks+cfg.ChunkIdleTicks; i++ {
+		w.step(nil, nil, nil)
+	}
+	if w.chunks.evictedCount() == 0 {
+		t.Fatalf("expected far modified chunk to be evicted")
+	}
+	if got := chunkSectionDigest(w, tick); got != before {
+		t.Fatalf("digest changed by eviction: %s vs %s", got, before)
+	}
+	snap := w.ExportSnapshot(tick)
+	if w.chunks.GetBlock(far) != w.catalogs.Blocks.Index["STONE"] {
+		t.Fatalf("evicted block lost on reload")
+	}
+
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	if err := w2.ImportSnapshot(snap); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+}
+
+func TestChunkEviction_UnreadableRegionFailsTick(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "evict", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000, ChunkIdleTicks: 40}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	if err := w.SetChunkRegionDir(t.TempDir()); err != nil {
+		t.Fatalf("regions: %v", err)
+	}
+	w.StepOnce([]JoinRequest{{Name: "alpha"}}, nil, nil)
+	far := Vec3i{X: 800, Y: 0, Z: -800}
+	setSolid(w, far, w.catalogs.Blocks.Index["STONE"])
+	for i := 0; i < 2*chunkMaintainEveryTicks+cfg.ChunkIdleTicks; i++ {
+		w.step(nil, nil, nil)
+	}
+	if w.chunks.evictedCount() == 0 {
+		t.Fatalf("expected far modified chunk to be evicted")
+	}
+	if err := w.chunkRegions.Reset(); err != nil {
+		t.Fatalf("reset regions: %v", err)
+	}
+
+	// Bring an agent next to the evicted chunk so the tick-start prefetch has to read it.
+	for _, a := range w.agents {
+		a.Pos = far
+	}
+	tick := w.CurrentTick()
+	for i := 0; i < chunkMaintainEveryTicks; i++ {
+		w.step(nil, nil, nil)
+	}
+	if w.chunks.err() == nil {
+		t.Fatalf("unreadable region not surfaced")
+	}
+	if w.CurrentTick() == tick+chunkMaintainEveryTicks {
+		t.Fatalf("failed tick was committed")
+	}
+	if w.chunks.evictedCount() == 0 {
+		t.Fatalf("unreadable chunk was regenerated")
+	}
+}
+
+func chunkSectionDigest(w *World, tick uint64) string {
+	for _, s := range w.StateDigestSections(tick) {
+		if s.Name == "chunks" {
+			return s.Hash
+		}
+	}
+	return ""
+}
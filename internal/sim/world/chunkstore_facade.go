@@ -1,6 +1,10 @@
 package world
 
-import storepkg "voxelcraft.ai/internal/sim/world/terrain/store"
+import (
+	"voxelcraft.ai/internal/persistence/snapshot"
+	genpkg "voxelcraft.ai/internal/sim/world/terrain/gen"
+	storepkg "voxelcraft.ai/internal/sim/world/terrain/store"
+)
 
 type ChunkKey = storepkg.ChunkKey
 type Chunk = storepkg.Chunk
@@ -12,8 +16,18 @@ type ChunkStore struct {
 	chunks map[ChunkKey]*Chunk
 }
 
+const (
+	// Chunk residency is maintained every N ticks at the tick boundary.
+	chunkMaintainEveryTicks = 20
+	// Chunks within this radius (in chunks) of an agent are prefetched and never evicted.
+	chunkHotRadius = 2
+)
+
 func NewChunkStore(gen WorldGen) *ChunkStore {
-	inner := storepkg.NewChunkStore(gen)
+	return wrapChunkStore(storepkg.NewChunkStore(gen))
+}
+
+func wrapChunkStore(inner *storepkg.ChunkStore) *ChunkStore {
 	return &ChunkStore{
 		inner:  inner,
 		gen:    inner.Gen,
@@ -62,3 +76,100 @@ func (s *ChunkStore) generateChunk(ch *Chunk) {
 	}
 	s.inner.GenerateChunk(ch)
 }
+
+func (s *ChunkStore) modifiedChunkKeys() []ChunkKey {
+	if s == nil || s.inner == nil {
+		return nil
+	}
+	return s.inner.ModifiedChunkKeys()
+}
+
+func (s *ChunkStore) chunkDigest(k ChunkKey) [32]byte {
+	if s == nil || s.inner == nil {
+		return [32]byte{}
+	}
+	return s.inner.ChunkDigest(k)
+}
+
+func (s *ChunkStore) exportModifiedChunks() []snapshot.ChunkV1 {
+	if s == nil || s.inner == nil {
+		return nil
+	}
+	return s.inner.ExportModifiedChunks()
+}
+
+func (s *ChunkStore) peekChunk(cx, cz int) *Chunk {
+	if s == nil || s.inner == nil {
+		return nil
+	}
+	return s.inner.PeekChunk(cx, cz)
+}
+
+// err reports terrain that could not be read back from the region store (see storepkg.ChunkStore.Err).
+func (s *ChunkStore) err() error {
+	if s == nil || s.inner == nil {
+		return nil
+	}
+	return s.inner.Err()
+}
+
+func (s *ChunkStore) evictedCount() int {
+	if s == nil || s.inner == nil {
+		return 0
+	}
+	return len(s.inner.Evicted)
+}
+
+// SetChunkRegionDir attaches an on-disk region store for evicted modified chunks. Existing region
+// files are discarded (the snapshot is authoritative). Call before Run, after ImportSnapshot.
+func (w *World) SetChunkRegionDir(dir string) error {
+	r, err := storepkg.OpenRegionStore(dir)
+	if err != nil {
+		return err
+	}
+	if w.chunks != nil && w.chunks.inner != nil {
+		w.chunks.inner.AttachRegions(r)
+	}
+	w.chunkRegions = r
+	return nil
+}
+
+// resetChunks installs a fresh chunk store, keeping the region store (emptied) if one is attached.
+func (w *World) resetChunks(inner *storepkg.ChunkStore) error {
+	if w.chunkRegions != nil {
+		if err := w.chunkRegions.Reset(); err != nil {
+			return err
+		}
+		inner.Regions = w.chunkRegions
+	}
+	w.chunks = wrapChunkStore(inner)
+	return nil
+}
+
+// maintainChunks prefetches evicted chunks near agents and evicts idle ones. It runs at the tick
+// boundary and only changes residency, never block content, so digests do not depend on it.
+func (w *World) maintainChunks(nowTick uint64) {
+	if w.chunks == nil || w.chunks.inner == nil {
+		return
+	}
+	inner := w.chunks.inner
+	if nowTick%chunkMaintainEveryTicks != 0 {
+		inner.Maintain(nowTick, 0, nil)
+		return
+	}
+	seen := map[ChunkKey]bool{}
+	var hot []ChunkKey
+	for _, a := range w.agents {
+		cx, cz := genpkg.FloorDiv(a.Pos.X, 16), genpkg.FloorDiv(a.Pos.Z, 16)
+		for dz := -chunkHotRadius; dz <= chunkHotRadius; dz++ {
+			for dx := -chunkHotRadius; dx <= chunkHotRadius; dx++ {
+				k := ChunkKey{CX: cx + dx, CZ: cz + dz}
+				if !seen[k] {
+					seen[k] = true
+					hot = append(hot, k)
+				}
+			}
+		}
+	}
+	inner.Maintain(nowTick, uint64(w.cfg.ChunkIdleTicks), hot)
+}
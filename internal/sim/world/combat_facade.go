@@ -0,0 +1,19 @@
+package world
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/tasks"
+	combatruntimepkg "voxelcraft.ai/internal/sim/world/feature/combat/runtime"
+)
+
+func handleTaskAttack(w *World, a *Agent, tr protocol.TaskReq, nowTick uint64) {
+	combatruntimepkg.HandleTaskAttack(newCombatTaskEnv(w), actionResult, a, tr, nowTick)
+}
+
+func handleInstantEquip(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	combatruntimepkg.HandleEquip(actionResult, a, inst, nowTick, w.catalogs.Items.Defs)
+}
+
+func (w *World) tickAttack(a *Agent, wt *tasks.WorkTask, nowTick uint64) {
+	combatruntimepkg.TickAttack(newCombatTaskEnv(w), a, wt, nowTick)
+}
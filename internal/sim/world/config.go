@@ -1,5 +1,8 @@
 package world
 
+// MaxWorldHeight bounds Height (blocks per column); 1 is the classic 2D tilemap.
+const MaxWorldHeight = 256
+
 type WorldConfig struct {
 	ID                  string
 	WorldType           string
@@ -9,10 +12,15 @@ type WorldConfig struct {
 	ResetEveryTicks     int
 	ResetNoticeTicks    int
 	ObsRadius           int
+	ObsRadiusMin        int // SET_VIEW obs_radius bounds
+	ObsRadiusMax        int
 	Height              int
 	Seed                int64
 	BoundaryR           int
 	SwitchCooldownTicks int
+	// PermitIDs are the permits that admit agents into this world (multiworld permits[]).
+	// They run down while the holder is here; an agent holding none of them may not act.
+	PermitIDs []string
 
 	AllowClaims bool
 	AllowMine   bool
@@ -21,7 +29,7 @@ type WorldConfig struct {
 	AllowTrade  bool
 	AllowBuild  bool
 
-	// Worldgen tuning (pure 2D tilemap).
+	// Worldgen tuning (2D tile clusters; also decides surface features in 3D worlds).
 	BiomeRegionSize                 int
 	SpawnClearRadius                int
 	OreClusterProbScalePermille     int
@@ -39,6 +47,18 @@ type WorldConfig struct {
 	DirectorEveryTicks int
 	RateLimits         RateLimitConfig
 
+	// Chunks not touched for this many ticks are evicted from memory (residency only; not part of state).
+	ChunkIdleTicks int
+
+	// Max ENQUEUE'd tasks per agent.
+	TaskQueueDepth int
+	// Max SET_TRIGGER fires per tick across all agents.
+	TriggerBudgetPerTick int
+	// Max water cell updates per tick.
+	WaterUpdatesPerTick int
+	// Max signal network cell updates per tick.
+	SignalUpdatesPerTick int
+
 	// Governance.
 	LawNoticeTicks int
 	LawVoteTicks   int
@@ -86,9 +106,27 @@ func (c *WorldConfig) applyDefaults() {
 	if c.SwitchCooldownTicks <= 0 {
 		c.SwitchCooldownTicks = 150
 	}
+	if c.TaskQueueDepth <= 0 {
+		c.TaskQueueDepth = 8
+	}
+	if c.TriggerBudgetPerTick <= 0 {
+		c.TriggerBudgetPerTick = 64
+	}
+	if c.WaterUpdatesPerTick <= 0 {
+		c.WaterUpdatesPerTick = 256
+	}
+	if c.SignalUpdatesPerTick <= 0 {
+		c.SignalUpdatesPerTick = 512
+	}
 	if c.ObsRadius <= 0 {
 		c.ObsRadius = 7
 	}
+	if c.ObsRadiusMin <= 0 || c.ObsRadiusMin > c.ObsRadius {
+		c.ObsRadiusMin = c.ObsRadius
+	}
+	if c.ObsRadiusMax < c.ObsRadius {
+		c.ObsRadiusMax = c.ObsRadius
+	}
 	if c.Height <= 0 {
 		c.Height = 1
 	}
@@ -130,6 +168,9 @@ func (c *WorldConfig) applyDefaults() {
 	if c.DirectorEveryTicks <= 0 {
 		c.DirectorEveryTicks = 3000
 	}
+	if c.ChunkIdleTicks <= 0 {
+		c.ChunkIdleTicks = 600
+	}
 	c.RateLimits.applyDefaults()
 
 	if c.LawNoticeTicks <= 0 {
@@ -2,9 +2,13 @@ package world
 
 import (
 	"fmt"
+	"sort"
 
+	auctionpkg "voxelcraft.ai/internal/sim/world/feature/contracts/auction"
 	auditpkg "voxelcraft.ai/internal/sim/world/feature/contracts/audit"
 	corepkg "voxelcraft.ai/internal/sim/world/feature/contracts/core"
+	contractinstantspkg "voxelcraft.ai/internal/sim/world/feature/contracts/instants"
+	loanpkg "voxelcraft.ai/internal/sim/world/feature/contracts/loan"
 	reppkg "voxelcraft.ai/internal/sim/world/feature/contracts/reputation"
 	runtimepkg "voxelcraft.ai/internal/sim/world/feature/contracts/runtime"
 	inventorypkg "voxelcraft.ai/internal/sim/world/feature/economy/inventory"
@@ -23,7 +27,7 @@ func normalizeContractKind(k string) string {
 func (w *World) contractSummariesForTerminal(pos Vec3i) []map[string]interface{} {
 	contracts := make([]runtimepkg.SummaryContract, 0, len(w.contracts))
 	for _, c := range w.contracts {
-		contracts = append(contracts, runtimepkg.SummaryContract{
+		sc := runtimepkg.SummaryContract{
 			ContractID:   c.ContractID,
 			TerminalPos:  c.TerminalPos.ToArray(),
 			State:        string(c.State),
@@ -31,13 +35,37 @@ func (w *World) contractSummariesForTerminal(pos Vec3i) []map[string]interface{}
 			Poster:       c.Poster,
 			Acceptor:     c.Acceptor,
 			DeadlineTick: c.DeadlineTick,
-		})
+		}
+		if c.Kind == "LOAN" {
+			sc.InterestPct = c.InterestPct
+			sc.Outstanding = loanpkg.Outstanding(c)
+			sc.CollateralLand = c.CollateralLand
+			if i := loanpkg.Next(c); i >= 0 {
+				sc.NextDueTick = c.Installments[i].DueTick
+			}
+		}
+		if c.Kind == "AUCTION" {
+			sc.AuctionType = c.AuctionType
+			sc.PriceItem = c.PriceItem
+			sc.ReservePrice = c.ReservePrice
+			sc.HighBid = auctionpkg.Leading(c)
+			sc.BidCount = len(c.Bids)
+		}
+		contracts = append(contracts, sc)
 	}
 	return runtimepkg.BuildTerminalSummaries(pos.ToArray(), contracts)
 }
 
 func (w *World) tickContracts(nowTick uint64) {
-	for _, c := range w.contracts {
+	// Contracts at one terminal draw on the same stock (loan installments in particular),
+	// so settle them in id order.
+	ids := make([]string, 0, len(w.contracts))
+	for id := range w.contracts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		c := w.contracts[id]
 		if c.State != ContractOpen && c.State != ContractAccepted {
 			continue
 		}
@@ -45,18 +73,27 @@ func (w *World) tickContracts(nowTick uint64) {
 		reqOK := false
 		buildPlaced := false
 		buildStable := false
-			if terminal != nil && c.State == ContractAccepted {
-				switch c.Kind {
-				case "GATHER", "DELIVER":
-					reqOK = reppkg.HasAvailable(c.Requirements, terminal.AvailableCount)
-				case "BUILD":
-					buildPlaced = w.checkBlueprintPlaced(c.BlueprintID, c.Anchor, c.Rotation)
-					if buildPlaced {
-						bp, okBP := w.catalogs.Blueprints.ByID[c.BlueprintID]
+		installment := -1
+		loanDue, loanCanPay, loanFinal := false, false, false
+		auctionHasBid := c.Kind == "AUCTION" && len(c.Bids) > 0
+		if terminal != nil && c.State == ContractAccepted {
+			switch c.Kind {
+			case "GATHER", "DELIVER":
+				reqOK = reppkg.HasAvailable(c.Requirements, terminal.AvailableCount)
+			case "BUILD":
+				buildPlaced = w.checkBlueprintPlaced(c.BlueprintID, c.Anchor, c.Rotation)
+				if buildPlaced {
+					bp, okBP := w.catalogs.Blueprints.ByID[c.BlueprintID]
 					if okBP && w.structureStable(&bp, c.Anchor, c.Rotation) {
 						buildStable = true
 					}
 				}
+			case "LOAN":
+				if installment = loanpkg.Next(c); installment >= 0 && nowTick >= c.Installments[installment].DueTick {
+					loanDue = true
+					loanCanPay = reppkg.HasAvailable(c.Installments[installment].Items, terminal.AvailableCount)
+					loanFinal = loanpkg.IsFinal(c, installment)
+				}
 			}
 		}
 		decision := corepkg.DecideTick(corepkg.TickInput{
@@ -68,6 +105,10 @@ func (w *World) tickContracts(nowTick uint64) {
 			RequirementsOK: reqOK,
 			BuildPlaced:    buildPlaced,
 			BuildStable:    buildStable,
+			LoanDue:        loanDue,
+			LoanCanPay:     loanCanPay,
+			LoanFinal:      loanFinal,
+			AuctionHasBid:  auctionHasBid,
 		})
 		if decision == corepkg.DecisionNoop {
 			continue
@@ -80,12 +121,34 @@ func (w *World) tickContracts(nowTick uint64) {
 		if plan.ConsumeRequirements && plan.RequirementsToPoster {
 			w.contractTerminalTransfer(terminal, c.Requirements, c.Poster, false)
 		}
+		var due map[string]int
+		if loanDue {
+			due = c.Installments[installment].Items
+		}
+		if plan.PayInstallment {
+			w.contractTerminalTransfer(terminal, due, c.Poster, false)
+			c.Installments[installment].Paid = true
+		}
+		var winning AuctionBid
+		if plan.PayWinningBid {
+			winning, _ = auctionpkg.Winner(c)
+			c.Acceptor = winning.Bidder
+			w.contractTerminalTransfer(terminal, map[string]int{c.PriceItem: winning.Amount}, c.Poster, true)
+		}
+		if plan.RefundLosingBids {
+			for _, b := range auctionpkg.Losers(c) {
+				contractinstantspkg.ReleaseBid(terminal, c.PriceItem, b)
+			}
+		}
 		if plan.RewardTo != corepkg.PayoutNone {
 			w.contractTerminalTransfer(terminal, c.Reward, w.contractPayoutAgent(c, plan.RewardTo), true)
 		}
 		if plan.DepositTo != corepkg.PayoutNone {
 			w.contractTerminalTransfer(terminal, c.Deposit, w.contractPayoutAgent(c, plan.DepositTo), true)
 		}
+		if plan.ForfeitLand {
+			w.forfeitLoanCollateralLand(c)
+		}
 		if plan.MarkFailed {
 			c.State = ContractFailed
 		}
@@ -93,7 +156,7 @@ func (w *World) tickContracts(nowTick uint64) {
 			c.State = ContractCompleted
 		}
 		if plan.PenalizeAcceptorLaw {
-			w.addLawPenalty(c.Acceptor, "CONTRACT_TIMEOUT")
+			w.addLawPenalty(c.Acceptor, plan.AuditReason)
 		}
 		audit := auditpkg.BuildTickAudit(auditpkg.TickAuditInput{
 			Decision:     decision,
@@ -109,9 +172,14 @@ func (w *World) tickContracts(nowTick uint64) {
 			Requirements: inventorypkg.EncodeItemPairs(c.Requirements),
 			Reward:       inventorypkg.EncodeItemPairs(c.Reward),
 			Deposit:      inventorypkg.EncodeItemPairs(c.Deposit),
+			Installment:  inventorypkg.EncodeItemPairs(due),
+			Collateral:   c.CollateralLand,
+			PriceItem:    c.PriceItem,
+			WinningBid:   winning.Amount,
 		})
 		if audit.GrantTradeCredit {
 			w.addTradeCredit(nowTick, c.Acceptor, c.Poster, c.Kind)
+			w.observeContractPrices(c, nowTick)
 		}
 		if audit.GrantBuildCredit {
 			w.addBuildCredit(nowTick, c.Acceptor, c.Poster, c.Kind)
@@ -122,6 +190,49 @@ func (w *World) tickContracts(nowTick uint64) {
 	}
 }
 
+// landPledged reports whether landID secures an outstanding loan.
+func (w *World) landPledged(landID string) bool {
+	if landID == "" {
+		return false
+	}
+	for _, c := range w.contracts {
+		if c.Kind == "LOAN" && c.State == ContractAccepted && c.CollateralLand == landID {
+			return true
+		}
+	}
+	return false
+}
+
+// claimPledgedAt reports whether a claim anchored at anchor secures an outstanding loan.
+func (w *World) claimPledgedAt(anchor Vec3i) bool {
+	for id, c := range w.claims {
+		if c != nil && c.Anchor == anchor && w.landPledged(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// terminalHasActiveLoan reports whether an accepted loan settles at pos. Land-secured
+// loans escrow nothing, so this is what keeps their terminal from being broken.
+func (w *World) terminalHasActiveLoan(pos Vec3i) bool {
+	for _, c := range w.contracts {
+		if c.Kind == "LOAN" && c.State == ContractAccepted && c.TerminalPos == pos {
+			return true
+		}
+	}
+	return false
+}
+
+// forfeitLoanCollateralLand deeds a defaulted borrower's pledged land to the lender.
+func (w *World) forfeitLoanCollateralLand(c *Contract) {
+	land := w.claims[c.CollateralLand]
+	if land == nil || land.Owner != c.Acceptor {
+		return
+	}
+	land.Owner = c.Poster
+}
+
 func (w *World) contractPayoutAgent(c *Contract, target corepkg.PayoutTarget) string {
 	return runtimepkg.PayoutAgent(c.Poster, c.Acceptor, target)
 }
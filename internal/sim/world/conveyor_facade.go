@@ -2,7 +2,6 @@ package world
 
 import (
 	conveyruntimepkg "voxelcraft.ai/internal/sim/world/feature/conveyor/runtime"
-	"voxelcraft.ai/internal/sim/world/logic/conveyorpower"
 )
 
 // systemConveyors moves dropped item entities along conveyor blocks.
@@ -36,36 +35,10 @@ func (w *World) systemConveyors(nowTick uint64) {
 	)
 }
 
-type conveyorEnvAdapter struct {
-	w *World
-}
-
-func (a conveyorEnvAdapter) BlockName(p conveyorpower.Pos) string {
-	return a.w.blockName(a.w.chunks.GetBlock(Vec3i{X: p.X, Y: p.Y, Z: p.Z}))
-}
-
-func (a conveyorEnvAdapter) SwitchOn(p conveyorpower.Pos) bool {
-	return a.w.switches[Vec3i{X: p.X, Y: p.Y, Z: p.Z}]
-}
-
-func (a conveyorEnvAdapter) SensorOn(p conveyorpower.Pos) bool {
-	return a.w.sensorOn(Vec3i{X: p.X, Y: p.Y, Z: p.Z})
-}
-
-func (w *World) conveyorEnabled(pos Vec3i) bool {
-	return conveyorpower.Enabled(
-		conveyorEnvAdapter{w: w},
-		conveyorpower.Pos{X: pos.X, Y: pos.Y, Z: pos.Z},
-		1024,
-	)
-}
-
-// sensorOn reports whether the sensor block at pos currently outputs an "ON" signal.
-//
-// MVP behavior (no configuration UI yet):
+// sensorItemsActive is the ITEMS sensor condition:
 // - ON if there is any non-empty dropped item entity on the sensor block or adjacent to it.
 // - ON if there is any adjacent container with at least 1 available item (inventory minus reserved).
-func (w *World) sensorOn(pos Vec3i) bool {
+func (w *World) sensorItemsActive(pos Vec3i) bool {
 	if w == nil {
 		return false
 	}
@@ -19,7 +19,7 @@ func (w *World) DebugSetAgentPos(agentID string, pos Vec3i) bool {
 	if w == nil || agentID == "" {
 		return false
 	}
-	return admindebugpkg.SetAgentPos(w.agents[agentID], pos)
+	return admindebugpkg.SetAgentPos(w.agents[agentID], pos, w.spawnY)
 }
 
 func (w *World) DebugClearAgentEvents(agentID string) bool {
@@ -56,15 +56,12 @@ func (w *World) DebugAddInventory(agentID string, item string, delta int) bool {
 	})
 }
 
-// DebugSetBlock sets a single world tile directly (2D: y must be 0).
+// DebugSetBlock sets a single world block directly.
 // It does not write audit entries and does not update derived runtime meta (containers/signs/etc).
 func (w *World) DebugSetBlock(pos Vec3i, blockName string) error {
 	if w == nil {
 		return errors.New("nil world")
 	}
-	if pos.Y != 0 {
-		return fmt.Errorf("2D world requires y==0: %+v", pos)
-	}
 	bid, ok := w.catalogs.Blocks.Index[blockName]
 	if !ok {
 		return fmt.Errorf("unknown block: %q", blockName)
@@ -80,9 +77,6 @@ func (w *World) DebugGetBlock(pos Vec3i) (uint16, error) {
 	if w == nil {
 		return 0, errors.New("nil world")
 	}
-	if pos.Y != 0 {
-		return 0, fmt.Errorf("2D world requires y==0: %+v", pos)
-	}
 	if !w.chunks.inBounds(pos) {
 		return 0, fmt.Errorf("out of bounds: %+v", pos)
 	}
@@ -3,8 +3,53 @@ package world
 import digestfeaturepkg "voxelcraft.ai/internal/sim/world/feature/persistence/digest"
 
 func (w *World) stateDigest(nowTick uint64) string {
-	keys := w.chunks.LoadedChunkKeys()
-	return digestfeaturepkg.StateDigest(digestfeaturepkg.StateInput{
+	return digestfeaturepkg.StateDigest(w.digestInput(nowTick))
+}
+
+// stateDigestWithSections computes the state digest and the per-section digests (by name)
+// in one pass, for the tick log.
+func (w *World) stateDigestWithSections(nowTick uint64) (string, map[string]string) {
+	digest, sections := digestfeaturepkg.StateDigestWithSections(w.digestInput(nowTick))
+	bySection := make(map[string]string, len(sections))
+	for _, s := range sections {
+		bySection[s.Name] = s.Hash
+	}
+	return digest, bySection
+}
+
+// sectionsToLog returns the section digests to record in the tick log for nowTick: all of them on
+// the first logged tick and on snapshot ticks, otherwise only the ones that changed since the
+// previous logged tick.
+func (w *World) sectionsToLog(nowTick uint64, sections map[string]string) map[string]string {
+	prev := w.loggedSections
+	w.loggedSections = sections
+	if every := uint64(w.cfg.SnapshotEveryTicks); prev == nil || (every > 0 && nowTick%every == 0) {
+		return sections
+	}
+	var changed map[string]string
+	for name, hash := range sections {
+		if prev[name] == hash {
+			continue
+		}
+		if changed == nil {
+			changed = map[string]string{}
+		}
+		changed[name] = hash
+	}
+	return changed
+}
+
+// StateDigestSections returns per-section digests for nowTick (replay bisection).
+//
+// This must be called only when the world is stopped or from the world loop goroutine.
+func (w *World) StateDigestSections(nowTick uint64) []DigestSection {
+	return digestfeaturepkg.SectionDigests(w.digestInput(nowTick))
+}
+
+func (w *World) digestInput(nowTick uint64) digestfeaturepkg.StateInput {
+	// Only modified chunks carry terrain state; unmodified ones are a pure function of the seed.
+	keys := w.chunks.modifiedChunkKeys()
+	return digestfeaturepkg.StateInput{
 		NowTick: nowTick,
 		Seed:    w.cfg.Seed,
 
@@ -17,27 +62,31 @@ func (w *World) stateDigest(nowTick uint64) string {
 		ActiveEventCenter: w.activeEventCenter,
 		ActiveEventRadius: w.activeEventRadius,
 
-		ChunkKeys: keys,
-		ChunkDigest: func(k ChunkKey) [32]byte {
-			ch := w.chunks.chunks[k]
-			if ch == nil {
-				return [32]byte{}
-			}
-			return ch.Digest()
-		},
+		ChunkKeys:   keys,
+		ChunkDigest: w.chunks.chunkDigest,
 
 		Claims:     w.claims,
 		Laws:       w.laws,
 		Orgs:       w.orgs,
 		Containers: w.containers,
 		Items:      w.items,
+		Mobs:       w.mobs,
 		Signs:      w.signs,
 		Conveyors:  w.conveyors,
 		Switches:   w.switches,
+		Machines:   w.machines,
+		Shops:      w.shops,
+		Crops:      w.crops,
+		Water:      w.waterLevels,
+		WaterWake:  w.waterActive,
+		Signals:    w.signalNodes,
+		SignalWake: w.signalActive,
 		Contracts:  w.contracts,
+		Orders:     w.orders,
+		Prices:     w.prices,
 		Trades:     w.trades,
 		Boards:     w.boards,
 		Structures: w.structures,
 		Agents:     w.agents,
-	})
+	}
 }
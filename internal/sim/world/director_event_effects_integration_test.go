@@ -5,6 +5,7 @@ import (
 
 	"voxelcraft.ai/internal/protocol"
 	"voxelcraft.ai/internal/sim/catalogs"
+	rulespkg "voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
 func TestMarketWeek_ReducesMarketTaxOnTrades(t *testing.T) {
@@ -47,12 +48,15 @@ func TestMarketWeek_ReducesMarketTaxOnTrades(t *testing.T) {
 	seller.Pos = owner.Pos
 	buyer.Pos = owner.Pos
 	land := &LandClaim{
-		LandID:    "LAND_MARKET",
-		Owner:     owner.ID,
-		Anchor:    owner.Pos,
-		Radius:    32,
-		Flags:     ClaimFlags{AllowBuild: true, AllowBreak: true, AllowDamage: false, AllowTrade: true},
-		MarketTax: 0.10,
+		LandID: "LAND_MARKET",
+		Owner:  owner.ID,
+		Anchor: owner.Pos,
+		Radius: 32,
+		Flags:  ClaimFlags{AllowBuild: true, AllowBreak: true, AllowDamage: false, AllowTrade: true},
+		Policies: []rulespkg.Policy{{
+			TemplateID: "MARKET_TAX",
+			Rules:      []rulespkg.Rule{{Actions: []string{rulespkg.ActionTrade}, Effect: rulespkg.EffectTax, Rate: 0.10}},
+		}},
 	}
 	w.claims[land.LandID] = land
 
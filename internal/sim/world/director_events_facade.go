@@ -58,21 +58,22 @@ func (w *World) onContainerOpenedDuringEvent(a *Agent, c *Container, nowTick uin
 }
 
 func (w *World) applySpawnPlan(nowTick uint64, reason string, plan spawnspkg.Plan) {
+	at := w.spawnPlanGround()
 	if plan.Center != nil {
-		w.activeEventCenter = Vec3i{X: plan.Center.X, Y: plan.Center.Y, Z: plan.Center.Z}
+		w.activeEventCenter = at(*plan.Center)
 	}
 	for _, p := range plan.Placements {
 		to, ok := w.catalogs.Blocks.Index[p.Block]
 		if !ok {
 			continue
 		}
-		pos := Vec3i{X: p.Pos.X, Y: p.Pos.Y, Z: p.Pos.Z}
+		pos := at(p.Pos)
 		from := w.chunks.GetBlock(pos)
 		w.chunks.SetBlock(pos, to)
 		w.auditSetBlock(nowTick, "WORLD", pos, from, to, reason)
 	}
 	for _, c := range plan.Containers {
-		pos := Vec3i{X: c.Pos.X, Y: c.Pos.Y, Z: c.Pos.Z}
+		pos := at(c.Pos)
 		container := w.ensureContainer(pos, c.Type)
 		if container == nil {
 			continue
@@ -85,14 +86,14 @@ func (w *World) applySpawnPlan(nowTick uint64, reason string, plan spawnspkg.Pla
 		}
 	}
 	for _, s := range plan.Signs {
-		pos := Vec3i{X: s.Pos.X, Y: s.Pos.Y, Z: s.Pos.Z}
+		pos := at(s.Pos)
 		sign := w.ensureSign(pos)
 		sign.Text = s.Text
 		sign.UpdatedTick = nowTick
 		sign.UpdatedBy = "WORLD"
 	}
 	for _, post := range plan.BoardPosts {
-		pos := Vec3i{X: post.Pos.X, Y: post.Pos.Y, Z: post.Pos.Z}
+		pos := at(post.Pos)
 		w.ensureBoard(pos)
 		if b := w.boards[boardIDAt(pos)]; b != nil {
 			b.Posts = append(b.Posts, BoardPost{
@@ -104,6 +105,11 @@ func (w *World) applySpawnPlan(nowTick uint64, reason string, plan spawnspkg.Pla
 			})
 		}
 	}
+	for _, m := range plan.Mobs {
+		pos := at(m.Pos)
+		// Event mobs leave with the event.
+		w.spawnMob(nowTick, m.Kind, pos, w.activeEventEnds, reason)
+	}
 }
 
 func (w *World) spawnCrystalRift(nowTick uint64, center Vec3i) {
@@ -133,3 +139,19 @@ func (w *World) spawnBlightZone(nowTick uint64, center Vec3i) {
 func (w *World) spawnBanditCamp(nowTick uint64, center Vec3i) {
 	w.applySpawnPlan(nowTick, "EVENT:BANDIT_CAMP", spawnspkg.BanditCampPlan(spawnspkg.Pos{X: center.X, Y: 0, Z: center.Z}))
 }
+
+// spawnPlanGround maps plan positions onto the ground of their column. Plans are laid out
+// flat around the event center, so in 3D worlds each cell follows the local surface as it
+// was before the plan placed anything (a chest and its container share the same y).
+func (w *World) spawnPlanGround() func(spawnspkg.Pos) Vec3i {
+	ground := map[[2]int]int{}
+	return func(p spawnspkg.Pos) Vec3i {
+		k := [2]int{p.X, p.Z}
+		y, ok := ground[k]
+		if !ok {
+			y = w.spawnY(p.X, p.Z)
+			ground[k] = y
+		}
+		return Vec3i{X: p.X, Y: y, Z: p.Z}
+	}
+}
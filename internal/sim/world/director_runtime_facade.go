@@ -109,7 +109,7 @@ func (w *World) computeDirectorMetrics(nowTick uint64) metricspkg.EvalMetrics {
 		if a == nil {
 			continue
 		}
-		wealth = append(wealth, metricspkg.InventoryValue(a.Inventory))
+		wealth = append(wealth, metricspkg.InventoryValue(a.Inventory, w.itemUnitValue))
 	}
 	m := metricspkg.ComputeMetrics(metricspkg.EvalInput{
 		Agents:      agents,
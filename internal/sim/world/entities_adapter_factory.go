@@ -0,0 +1,22 @@
+package world
+
+import mobsctxpkg "voxelcraft.ai/internal/sim/world/featurectx/mobs"
+
+// newMobTickEnv checks steps against occupied, the tick's index of mob positions.
+func newMobTickEnv(w *World, occupied map[Vec3i]string) mobsctxpkg.Env {
+	if w == nil {
+		return mobsctxpkg.Env{}
+	}
+	return mobsctxpkg.Env{
+		SortedAgentsFn: w.sortedAgents,
+		GetAgentFn: func(agentID string) *Agent {
+			return w.agents[agentID]
+		},
+		WalkableFn: func(pos Vec3i) (Vec3i, bool) {
+			return w.mobWalkable(pos, occupied)
+		},
+		CanDamageAtFn: w.mobCanDamageAt,
+		RespawnFn:     w.respawnAgent,
+		AuditEventFn:  w.auditEvent,
+	}
+}
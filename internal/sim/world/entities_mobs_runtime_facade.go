@@ -0,0 +1,141 @@
+package world
+
+import (
+	"fmt"
+	"sort"
+
+	mobspkg "voxelcraft.ai/internal/sim/world/feature/entities/mobs"
+	movementruntimepkg "voxelcraft.ai/internal/sim/world/feature/movement/runtime"
+)
+
+func (w *World) newMobID() string {
+	n := w.nextMobNum.Add(1)
+	return fmt.Sprintf("MB%06d", n)
+}
+
+func (w *World) spawnMob(nowTick uint64, kind string, pos Vec3i, despawnTick uint64, reason string) string {
+	def, ok := mobspkg.DefFor(kind)
+	if !ok {
+		return ""
+	}
+	pos, ok = w.mobWalkable(pos, w.mobCells())
+	if !ok {
+		return ""
+	}
+	m := &Mob{
+		EntityID:    w.newMobID(),
+		Kind:        kind,
+		Pos:         pos,
+		Home:        pos,
+		HP:          def.MaxHP,
+		State:       mobspkg.IdleState(def),
+		StateTick:   nowTick,
+		CreatedTick: nowTick,
+		DespawnTick: despawnTick,
+	}
+	w.mobs[m.EntityID] = m
+	w.auditEvent(nowTick, "WORLD", "MOB_SPAWN", pos, reason, map[string]any{
+		"mob_id": m.EntityID,
+		"kind":   kind,
+	})
+	return m.EntityID
+}
+
+func (w *World) removeMob(nowTick uint64, actor string, id string, reason string) {
+	m := w.mobs[id]
+	if m == nil {
+		return
+	}
+	delete(w.mobs, id)
+	w.auditEvent(nowTick, actor, "MOB_REMOVE", m.Pos, reason, map[string]any{
+		"mob_id": id,
+		"kind":   m.Kind,
+	})
+}
+
+// mobWalkable returns the cell a mob at height pos.Y stands on in column (pos.X,pos.Z), using
+// the agent step rules (see movementruntimepkg.StandAt); ok is false when the step is not
+// possible or another mob stands there (occupied indexes mob positions, see mobCells).
+func (w *World) mobWalkable(pos Vec3i, occupied map[Vec3i]string) (Vec3i, bool) {
+	stand, ok := movementruntimepkg.StandAt(pos, pos.X, pos.Z, func(p Vec3i) bool { return w.blockSolid(w.chunks.GetBlock(p)) }, w.chunks.inBounds)
+	if !ok {
+		return Vec3i{}, false
+	}
+	if _, taken := occupied[stand]; taken {
+		return Vec3i{}, false
+	}
+	return stand, true
+}
+
+// mobCells indexes mob positions to their ids.
+func (w *World) mobCells() map[Vec3i]string {
+	out := make(map[Vec3i]string, len(w.mobs))
+	for id, m := range w.mobs {
+		if m != nil {
+			out[m.Pos] = id
+		}
+	}
+	return out
+}
+
+// mobCanDamageAt applies the damage rules of the land at pos to mob attacks, as for a
+// visitor; the wild stays open to mobs.
+func (w *World) mobCanDamageAt(pos Vec3i, nowTick uint64) bool {
+	if w.landAt(pos) == nil {
+		return true
+	}
+	return w.canDamageAt("", pos, nowTick)
+}
+
+func (w *World) mobHurt(nowTick uint64, m *Mob, attackerID string) {
+	mobspkg.OnHurt(m, attackerID, nowTick)
+}
+
+func (w *World) killMob(nowTick uint64, m *Mob, killerID string) {
+	if def, ok := mobspkg.DefFor(m.Kind); ok {
+		for _, d := range def.Drops {
+			w.spawnItemEntity(nowTick, "WORLD", m.Pos, d.Item, d.Count, "MOB_DROP")
+		}
+	}
+	w.removeMob(nowTick, killerID, m.EntityID, "KILLED")
+}
+
+func (w *World) sortedMobIDs() []string {
+	ids := make([]string, 0, len(w.mobs))
+	for id := range w.mobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (w *World) systemMobsImpl(nowTick uint64) {
+	if len(w.mobs) == 0 {
+		return
+	}
+	// Index positions once per tick; only the mob being ticked moves, so updating its
+	// entry afterwards keeps the index exact for the mobs after it.
+	occupied := w.mobCells()
+	env := newMobTickEnv(w, occupied)
+	for _, id := range w.sortedMobIDs() {
+		m := w.mobs[id]
+		if m == nil {
+			continue
+		}
+		if m.DespawnTick != 0 && nowTick >= m.DespawnTick {
+			if occupied[m.Pos] == id {
+				delete(occupied, m.Pos)
+			}
+			w.removeMob(nowTick, "WORLD", id, "DESPAWN")
+			continue
+		}
+		from := m.Pos
+		mobspkg.Tick(env, m, nowTick)
+		if m.Pos != from {
+			if occupied[from] == id {
+				delete(occupied, from)
+			}
+			occupied[m.Pos] = id
+		}
+	}
+}
@@ -30,6 +30,9 @@ func (w *World) ensureContainerForPlacedBlock(pos Vec3i, blockName string) {
 	if e.EnsureSwitch {
 		w.ensureSwitch(pos, e.SwitchOn)
 	}
+	if e.ResetMachine {
+		w.resetMachine(pos, blockName)
+	}
 }
 
 func (w *World) ensureContainer(pos Vec3i, typ string) *Container {
@@ -37,6 +40,7 @@ func (w *World) ensureContainer(pos Vec3i, typ string) *Container {
 }
 
 func (w *World) removeContainer(pos Vec3i) *Container {
+	delete(w.shops, pos)
 	return entitiesruntimepkg.RemoveContainer(w.containers, pos)
 }
 
@@ -45,6 +49,9 @@ func (w *World) getContainerByID(id string) *Container {
 }
 
 func (w *World) canWithdrawFromContainer(agentID string, pos Vec3i) bool {
+	if s := w.shops[pos]; s != nil {
+		return s.Owner == agentID
+	}
 	land := w.landAt(pos)
 	if land == nil {
 		return permissionspkg.CanWithdrawContainer(false, false, 0)
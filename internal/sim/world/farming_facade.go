@@ -0,0 +1,46 @@
+package world
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	runtimepkg "voxelcraft.ai/internal/sim/world/feature/director/runtime"
+	farmingpkg "voxelcraft.ai/internal/sim/world/feature/survival/farming"
+	workruntimepkg "voxelcraft.ai/internal/sim/world/feature/work/runtime"
+)
+
+func handleTaskTill(w *World, a *Agent, tr protocol.TaskReq, nowTick uint64) {
+	workruntimepkg.HandleTaskTill(newWorkTaskReqEnv(w), actionResult, a, tr, nowTick, w.cfg.AllowPlace)
+}
+
+func (w *World) plantCrop(pos Vec3i, cropType string, nowTick uint64) {
+	w.crops[pos] = &Crop{Type: cropType, PlantedTick: nowTick}
+}
+
+func (w *World) takeCrop(pos Vec3i) (Crop, bool) {
+	c := w.crops[pos]
+	if c == nil {
+		return Crop{}, false
+	}
+	delete(w.crops, pos)
+	return *c, true
+}
+
+func (w *World) tickCrops(nowTick uint64) {
+	farmingpkg.Tick(
+		farmingpkg.TickInput{
+			NowTick:           nowTick,
+			SeasonLengthTicks: runtimepkg.SeasonLengthTicks(w.cfg.ResetEveryTicks, w.cfg.SeasonLengthTicks),
+			Weather:           w.weather,
+			Crops:             w.crops,
+		},
+		farmingpkg.TickHooks{
+			BlockNameAt: func(pos Vec3i) string { return w.blockName(w.chunks.GetBlock(pos)) },
+			Watered: func(pos Vec3i) bool {
+				return w.nearBlock(pos, "WATER", farmingpkg.WaterRadius)
+			},
+			InBlight: func(pos Vec3i) bool {
+				return w.activeEventID == "BLIGHT_ZONE" && nowTick < w.activeEventEnds &&
+					w.activeEventRadius > 0 && distXZ(pos, w.activeEventCenter) <= w.activeEventRadius
+			},
+		},
+	)
+}
@@ -0,0 +1,88 @@
+package world
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	farmingpkg "voxelcraft.ai/internal/sim/world/feature/survival/farming"
+)
+
+func TestFarming_TillPlantGrowHarvestAcrossSnapshot(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "farming", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "farmer", Resp: resp})
+	a := w.agents[(<-resp).Welcome.AgentID]
+	a.Inventory["WOOD_HOE"] = 1
+	a.Inventory["WHEAT_SEEDS"] = 1
+
+	pos := Vec3i{X: a.Pos.X + 1, Y: 0, Z: a.Pos.Z}
+	setSolid(w, pos, w.catalogs.Blocks.Index["GRASS"])
+	run := func(tr protocol.TaskReq) {
+		t.Helper()
+		w.step(nil, nil, []ActionEnvelope{{AgentID: a.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: a.ID, Tasks: []protocol.TaskReq{tr}}}})
+		for i := 0; i < 50 && a.WorkTask != nil; i++ {
+			w.step(nil, nil, nil)
+		}
+		if a.WorkTask != nil {
+			t.Fatalf("%s did not finish", tr.Type)
+		}
+	}
+
+	run(protocol.TaskReq{ID: "till", Type: "TILL", BlockPos: pos.ToArray()})
+	if got := w.blockName(w.chunks.GetBlock(pos)); got != "FARMLAND" {
+		t.Fatalf("expected FARMLAND after TILL, got %s", got)
+	}
+	run(protocol.TaskReq{ID: "plant", Type: "PLACE", ItemID: "WHEAT_SEEDS", BlockPos: pos.ToArray()})
+	if c := w.crops[pos]; c == nil || c.Type != "WHEAT_CROP" || a.Inventory["WHEAT_SEEDS"] != 0 {
+		t.Fatalf("expected wheat planted, got %+v", w.crops[pos])
+	}
+
+	// Drive growth steps directly (SUMMER quarter, clear weather).
+	w.weather = "CLEAR"
+	for tick := uint64(farmingpkg.GrowEveryTicks); !farmingpkg.Ripe(*w.crops[pos]); tick += farmingpkg.GrowEveryTicks {
+		if tick > 100*farmingpkg.GrowEveryTicks {
+			t.Fatalf("crop never ripened: %+v", w.crops[pos])
+		}
+		w.tickCrops(tick)
+	}
+
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	tick := w.CurrentTick() - 1
+	if err := w2.ImportSnapshot(w.ExportSnapshot(tick)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+	if c := w2.crops[pos]; c == nil || !farmingpkg.Ripe(*c) {
+		t.Fatalf("crop not restored: %+v", c)
+	}
+
+	w, a = w2, w2.agents[a.ID]
+	run(protocol.TaskReq{ID: "harvest", Type: "MINE", BlockPos: pos.ToArray()})
+	if got := w.blockName(w.chunks.GetBlock(pos)); got != "FARMLAND" {
+		t.Fatalf("expected FARMLAND after harvest, got %s", got)
+	}
+	drops := map[string]int{}
+	for _, e := range w.items {
+		if e.Pos == pos {
+			drops[e.Item] += e.Count
+		}
+	}
+	// 2 seeds from the harvest plus 1 from tilling grass.
+	if drops["WHEAT"] != 2 || drops["WHEAT_SEEDS"] != 3 {
+		t.Fatalf("unexpected harvest drops: %v", drops)
+	}
+}
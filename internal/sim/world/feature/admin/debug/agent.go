@@ -6,11 +6,16 @@ import (
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 )
 
-func SetAgentPos(a *modelpkg.Agent, pos modelpkg.Vec3i) bool {
+// SetAgentPos moves a to column (pos.X,pos.Z); the agent always stands at surfaceY
+// (y=0 when surfaceY is nil).
+func SetAgentPos(a *modelpkg.Agent, pos modelpkg.Vec3i, surfaceY func(x, z int) int) bool {
 	if a == nil {
 		return false
 	}
 	pos.Y = 0
+	if surfaceY != nil {
+		pos.Y = surfaceY(pos.X, pos.Z)
+	}
 	a.Pos = pos
 	return true
 }
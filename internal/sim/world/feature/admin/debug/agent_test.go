@@ -6,14 +6,18 @@ import (
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 )
 
-func TestSetAgentPosForces2D(t *testing.T) {
+func TestSetAgentPosSnapsToSurface(t *testing.T) {
 	a := &modelpkg.Agent{}
-	if !SetAgentPos(a, modelpkg.Vec3i{X: 3, Y: 9, Z: -4}) {
+	if !SetAgentPos(a, modelpkg.Vec3i{X: 3, Y: 9, Z: -4}, nil) {
 		t.Fatalf("expected success")
 	}
 	if a.Pos.Y != 0 || a.Pos.X != 3 || a.Pos.Z != -4 {
 		t.Fatalf("unexpected pos: %+v", a.Pos)
 	}
+	SetAgentPos(a, modelpkg.Vec3i{X: 3, Y: 9, Z: -4}, func(x, z int) int { return 5 })
+	if a.Pos.Y != 5 {
+		t.Fatalf("expected surface y=5, got %+v", a.Pos)
+	}
 }
 
 func TestAddInventoryWithValidation(t *testing.T) {
@@ -0,0 +1,63 @@
+package machine
+
+import (
+	"strings"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type ActionResultFn func(tick uint64, ref string, ok bool, code string, message string) protocol.Event
+
+type RecipeInstantEnv interface {
+	ParseContainerID(id string) (typ string, pos modelpkg.Vec3i, ok bool)
+	BlockNameAt(pos modelpkg.Vec3i) string
+	Distance(a modelpkg.Vec3i, b modelpkg.Vec3i) int
+	CanBuildAt(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool
+	GetRecipe(recipeID string) (catalogs.RecipeDef, bool)
+	SetMachineRecipe(pos modelpkg.Vec3i, recipeID string)
+	AuditMachineSet(nowTick uint64, actorID string, pos modelpkg.Vec3i, recipeID string)
+	BumpLawRep(agentID string, delta int)
+	RecordDenied(nowTick uint64)
+}
+
+// HandleSetRecipe binds an AUTO_CRAFTER to a HAND or CRAFTING_BENCH recipe
+// (SET_RECIPE: target_id, recipe_id; an empty recipe_id unbinds it). Same reach and
+// permission as TOGGLE_SWITCH.
+func HandleSetRecipe(env RecipeInstantEnv, ar ActionResultFn, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64) {
+	typ, pos, ok := env.ParseContainerID(strings.TrimSpace(inst.TargetID))
+	if !ok || typ != AutoCrafter {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BAD_REQUEST", "invalid auto-crafter target"))
+		return
+	}
+	recipeID := strings.TrimSpace(inst.RecipeID)
+	if recipeID != "" {
+		rec, ok := env.GetRecipe(recipeID)
+		if !ok {
+			a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "unknown recipe"))
+			return
+		}
+		if !Craftable(rec) {
+			a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "recipe needs another station"))
+			return
+		}
+	}
+	if env.BlockNameAt(pos) != AutoCrafter {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "auto-crafter not found"))
+		return
+	}
+	if env.Distance(a.Pos, pos) > 3 {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BLOCKED", "too far"))
+		return
+	}
+	if !env.CanBuildAt(a.ID, pos, nowTick) {
+		env.BumpLawRep(a.ID, -1)
+		env.RecordDenied(nowTick)
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_PERMISSION", "machine config denied"))
+		return
+	}
+	env.SetMachineRecipe(pos, recipeID)
+	env.AuditMachineSet(nowTick, a.ID, pos, recipeID)
+	a.AddEvent(ar(nowTick, inst.ID, true, "", "ok"))
+}
@@ -0,0 +1,320 @@
+// Package machine runs the placeable machines. FURNACE and AUTO_CRAFTER work through
+// the inventory of their own container: a furnace smelts whatever FURNACE recipe its
+// contents allow, an auto-crafter repeats the recipe it is bound to. Both advance only
+// while powered, by the signal network or by burning FuelItem from their inventory.
+// A HOPPER moves one item per tick from the container (or dropped items) behind it
+// into the container or onto the conveyor in front of it; it pulls from a container
+// only where its owner could TAKE by hand.
+package machine
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"voxelcraft.ai/internal/sim/catalogs"
+	shoppkg "voxelcraft.ai/internal/sim/world/feature/economy/shop"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	Furnace     = "FURNACE"
+	AutoCrafter = "AUTO_CRAFTER"
+	Hopper      = "HOPPER"
+
+	// FuelItem is burnt by an unpowered machine with work to do; one unit keeps it
+	// running for FuelTicks ticks.
+	FuelItem  = "COAL"
+	FuelTicks = 80
+)
+
+func IsMachine(blockName string) bool {
+	return blockName == Hopper || IsProcessor(blockName)
+}
+
+// IsProcessor reports machines that craft inside their own container.
+func IsProcessor(blockName string) bool {
+	return blockName == Furnace || blockName == AutoCrafter
+}
+
+// Faced reports machines with an output side (taken from the placer's yaw).
+func Faced(blockName string) bool { return blockName == Hopper }
+
+// Craftable reports recipes an AUTO_CRAFTER can be bound to.
+func Craftable(rec catalogs.RecipeDef) bool {
+	switch strings.TrimSpace(rec.Station) {
+	case "HAND", "CRAFTING_BENCH":
+		return true
+	}
+	return false
+}
+
+type StepInput struct {
+	Machines   map[modelpkg.Vec3i]modelpkg.MachineMeta
+	Containers map[modelpkg.Vec3i]*modelpkg.Container
+	Recipes    map[string]catalogs.RecipeDef
+}
+
+type StepHooks struct {
+	BlockNameAt func(pos modelpkg.Vec3i) string
+	// Powered reports whether the signal network powers the machine at pos.
+	Powered func(pos modelpkg.Vec3i) bool
+	// HopperEnabled applies the conveyor rule: a hopper wired to a control runs only
+	// while powered.
+	HopperEnabled func(pos modelpkg.Vec3i) bool
+	// CanWithdraw applies the manual TAKE permission of agentID to the container at pos.
+	CanWithdraw func(agentID string, pos modelpkg.Vec3i) bool
+	// TakeDropped removes one unit from the dropped items at pos.
+	TakeDropped func(pos modelpkg.Vec3i) string
+	// BeltFree reports a CONVEYOR at pos with no item on it.
+	BeltFree func(pos modelpkg.Vec3i) bool
+	Drop     func(pos modelpkg.Vec3i, item string)
+	Audit    func(action string, pos modelpkg.Vec3i, details map[string]any)
+}
+
+// Step runs every machine once, in position order.
+func Step(in StepInput, hooks StepHooks) {
+	cells := map[modelpkg.Vec3i]bool{}
+	for p := range in.Machines {
+		cells[p] = true
+	}
+	for p, c := range in.Containers {
+		if c != nil && IsProcessor(c.Type) {
+			cells[p] = true
+		}
+	}
+	if len(cells) == 0 {
+		return
+	}
+	smelts := furnaceRecipes(in.Recipes)
+	for _, p := range modelpkg.SortedPositions(cells) {
+		name := hooks.BlockNameAt(p)
+		switch {
+		case name == Hopper:
+			stepHopper(in, hooks, p)
+		case IsProcessor(name) && in.Containers[p] != nil && in.Containers[p].Type == name:
+			stepProcessor(in, hooks, smelts, p, name, in.Containers[p])
+		default:
+			delete(in.Machines, p)
+		}
+	}
+}
+
+func furnaceRecipes(recipes map[string]catalogs.RecipeDef) []catalogs.RecipeDef {
+	out := make([]catalogs.RecipeDef, 0, 4)
+	for _, r := range recipes {
+		if strings.TrimSpace(r.Station) == Furnace && len(r.Inputs) > 0 {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RecipeID < out[j].RecipeID })
+	return out
+}
+
+func stepProcessor(in StepInput, hooks StepHooks, smelts []catalogs.RecipeDef, p modelpkg.Vec3i, name string, c *modelpkg.Container) {
+	m := in.Machines[p]
+	defer func() { save(in, p, m) }()
+
+	rec, ok := current(in, smelts, name, m, c)
+	if !ok {
+		m.Progress = 0
+		if name == Furnace {
+			m.RecipeID = ""
+		}
+		return
+	}
+	if name == Furnace {
+		m.RecipeID = rec.RecipeID
+	}
+	if !(hooks.Powered != nil && hooks.Powered(p)) && !burn(&m, c, rec) {
+		return
+	}
+	m.Progress++
+	if m.Progress < rec.TimeTicks {
+		return
+	}
+	m.Progress = 0
+	if name == Furnace {
+		m.RecipeID = ""
+	}
+	for _, it := range rec.Inputs {
+		take(c, it.Item, it.Count)
+	}
+	if c.Inventory == nil {
+		c.Inventory = map[string]int{}
+	}
+	for _, out := range rec.Outputs {
+		c.Inventory[out.Item] += out.Count
+	}
+	if hooks.Audit != nil {
+		hooks.Audit("MACHINE_CRAFT", p, map[string]any{
+			"machine_id": c.ID(),
+			"recipe_id":  rec.RecipeID,
+		})
+	}
+}
+
+// current picks the recipe the machine works on this tick: the crafter's binding, or
+// the furnace's recipe in progress, else the first FURNACE recipe its contents allow.
+func current(in StepInput, smelts []catalogs.RecipeDef, name string, m modelpkg.MachineMeta, c *modelpkg.Container) (catalogs.RecipeDef, bool) {
+	if name == AutoCrafter {
+		rec, ok := in.Recipes[m.RecipeID]
+		return rec, ok && Craftable(rec) && hasInputs(c, rec, 0)
+	}
+	if rec, ok := in.Recipes[m.RecipeID]; ok && hasInputs(c, rec, 0) {
+		return rec, true
+	}
+	for _, rec := range smelts {
+		if hasInputs(c, rec, 0) {
+			return rec, true
+		}
+	}
+	return catalogs.RecipeDef{}, false
+}
+
+// burn spends a tick of fuel, burning a new FuelItem when the last one is used up.
+// Fuel the recipe itself needs is never burnt.
+func burn(m *modelpkg.MachineMeta, c *modelpkg.Container, rec catalogs.RecipeDef) bool {
+	if m.Fuel > 0 {
+		m.Fuel--
+		return true
+	}
+	if !hasInputs(c, rec, 1) {
+		return false
+	}
+	take(c, FuelItem, 1)
+	m.Fuel = FuelTicks - 1
+	return true
+}
+
+// hasInputs reports whether c holds the recipe inputs plus extraFuel more FuelItem.
+func hasInputs(c *modelpkg.Container, rec catalogs.RecipeDef, extraFuel int) bool {
+	fuel := extraFuel
+	for _, it := range rec.Inputs {
+		if it.Item == FuelItem {
+			fuel += it.Count
+			continue
+		}
+		if c.AvailableCount(it.Item) < it.Count {
+			return false
+		}
+	}
+	return fuel == 0 || c.AvailableCount(FuelItem) >= fuel
+}
+
+func take(c *modelpkg.Container, item string, n int) {
+	c.Inventory[item] -= n
+	if c.Inventory[item] <= 0 {
+		delete(c.Inventory, item)
+	}
+}
+
+func stepHopper(in StepInput, hooks StepHooks, p modelpkg.Vec3i) {
+	m := in.Machines[p]
+	if m.DX == 0 && m.DZ == 0 {
+		return
+	}
+	if hooks.HopperEnabled != nil && !hooks.HopperEnabled(p) {
+		return
+	}
+	back := modelpkg.Vec3i{X: p.X - int(m.DX), Y: p.Y, Z: p.Z - int(m.DZ)}
+	front := modelpkg.Vec3i{X: p.X + int(m.DX), Y: p.Y, Z: p.Z + int(m.DZ)}
+	dst := in.Containers[front]
+	if dst == nil && (hooks.BeltFree == nil || !hooks.BeltFree(front)) {
+		return
+	}
+
+	item := ""
+	if src := in.Containers[back]; src != nil {
+		if hooks.CanWithdraw != nil && !hooks.CanWithdraw(m.Owner, back) {
+			return
+		}
+		// A shop's stock is only for sale.
+		if src.Type == shoppkg.Block {
+			return
+		}
+		if item = pickOutput(in, src); item == "" {
+			return
+		}
+		take(src, item, 1)
+	} else if hooks.TakeDropped != nil {
+		if item = hooks.TakeDropped(back); item == "" {
+			return
+		}
+	} else {
+		return
+	}
+
+	if dst != nil {
+		if dst.Inventory == nil {
+			dst.Inventory = map[string]int{}
+		}
+		dst.Inventory[item]++
+	} else if hooks.Drop != nil {
+		hooks.Drop(front, item)
+	}
+	if hooks.Audit != nil {
+		hooks.Audit("HOPPER_MOVE", p, map[string]any{
+			"from":  back.ToArray(),
+			"to":    front.ToArray(),
+			"item":  item,
+			"count": 1,
+		})
+	}
+}
+
+// pickOutput is the first available item a hopper may pull from src. From a machine
+// it only takes products (outputs of the bound recipe or of any FURNACE recipe), so
+// it never steals ingredients or fuel.
+func pickOutput(in StepInput, src *modelpkg.Container) string {
+	var allowed map[string]bool
+	if IsProcessor(src.Type) {
+		allowed = map[string]bool{}
+		for _, rec := range in.Recipes {
+			if src.Type == Furnace && strings.TrimSpace(rec.Station) != Furnace {
+				continue
+			}
+			if src.Type == AutoCrafter && rec.RecipeID != in.Machines[src.Pos].RecipeID {
+				continue
+			}
+			for _, out := range rec.Outputs {
+				allowed[out.Item] = true
+			}
+		}
+	}
+	keys := make([]string, 0, len(src.Inventory))
+	for item := range src.Inventory {
+		if item == "" || src.AvailableCount(item) <= 0 || (allowed != nil && !allowed[item]) {
+			continue
+		}
+		keys = append(keys, item)
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
+
+func save(in StepInput, p modelpkg.Vec3i, m modelpkg.MachineMeta) {
+	if m == (modelpkg.MachineMeta{}) {
+		delete(in.Machines, p)
+		return
+	}
+	in.Machines[p] = m
+}
+
+// Tags describes a machine for OBS entities.
+func Tags(m modelpkg.MachineMeta) []string {
+	var tags []string
+	if m.RecipeID != "" {
+		tags = append(tags, "recipe:"+m.RecipeID)
+	}
+	if m.Progress > 0 {
+		tags = append(tags, "progress:"+strconv.Itoa(m.Progress))
+	}
+	if m.Fuel > 0 {
+		tags = append(tags, "fuel:"+strconv.Itoa(m.Fuel))
+	}
+	return tags
+}
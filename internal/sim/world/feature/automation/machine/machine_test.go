@@ -0,0 +1,155 @@
+package machine
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/sim/catalogs"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type plant struct {
+	blocks     map[modelpkg.Vec3i]string
+	powered    map[modelpkg.Vec3i]bool
+	dropped    map[modelpkg.Vec3i][]string
+	machines   map[modelpkg.Vec3i]modelpkg.MachineMeta
+	containers map[modelpkg.Vec3i]*modelpkg.Container
+	recipes    map[string]catalogs.RecipeDef
+}
+
+func newPlant() *plant {
+	return &plant{
+		blocks:     map[modelpkg.Vec3i]string{},
+		powered:    map[modelpkg.Vec3i]bool{},
+		dropped:    map[modelpkg.Vec3i][]string{},
+		machines:   map[modelpkg.Vec3i]modelpkg.MachineMeta{},
+		containers: map[modelpkg.Vec3i]*modelpkg.Container{},
+		recipes: map[string]catalogs.RecipeDef{
+			"iron_ingot": {
+				RecipeID: "iron_ingot", Station: "FURNACE", TimeTicks: 3,
+				Inputs:  []catalogs.ItemCount{{Item: "IRON_ORE", Count: 1}, {Item: "COAL", Count: 1}},
+				Outputs: []catalogs.ItemCount{{Item: "IRON_INGOT", Count: 1}},
+			},
+			"plank": {
+				RecipeID: "plank", Station: "HAND", TimeTicks: 2,
+				Inputs:  []catalogs.ItemCount{{Item: "LOG", Count: 1}},
+				Outputs: []catalogs.ItemCount{{Item: "PLANK", Count: 4}},
+			},
+		},
+	}
+}
+
+func (pl *plant) container(p modelpkg.Vec3i, typ string, inv map[string]int) *modelpkg.Container {
+	pl.blocks[p] = typ
+	c := &modelpkg.Container{Type: typ, Pos: p, Inventory: inv}
+	pl.containers[p] = c
+	return c
+}
+
+func (pl *plant) step() {
+	Step(StepInput{Machines: pl.machines, Containers: pl.containers, Recipes: pl.recipes}, StepHooks{
+		BlockNameAt: func(p modelpkg.Vec3i) string { return pl.blocks[p] },
+		Powered:     func(p modelpkg.Vec3i) bool { return pl.powered[p] },
+		TakeDropped: func(p modelpkg.Vec3i) string {
+			items := pl.dropped[p]
+			if len(items) == 0 {
+				return ""
+			}
+			pl.dropped[p] = items[1:]
+			return items[0]
+		},
+		BeltFree: func(p modelpkg.Vec3i) bool { return pl.blocks[p] == "CONVEYOR" && len(pl.dropped[p]) == 0 },
+		Drop:     func(p modelpkg.Vec3i, item string) { pl.dropped[p] = append(pl.dropped[p], item) },
+	})
+}
+
+func at(x, z int) modelpkg.Vec3i { return modelpkg.Vec3i{X: x, Z: z} }
+
+func TestFurnaceBurnsSpareFuel(t *testing.T) {
+	pl := newPlant()
+	c := pl.container(at(0, 0), Furnace, map[string]int{"IRON_ORE": 2, "COAL": 1})
+
+	// The only COAL is the recipe's own: nothing left to burn, so no progress.
+	pl.step()
+	if m := pl.machines[at(0, 0)]; m.Progress != 0 || m.RecipeID != "iron_ingot" {
+		t.Fatalf("unpowered furnace without spare fuel: %+v", m)
+	}
+
+	c.Inventory["COAL"] = 3
+	for i := 0; i < 3; i++ {
+		pl.step()
+	}
+	if c.Inventory["IRON_INGOT"] != 1 || c.Inventory["IRON_ORE"] != 1 || c.Inventory["COAL"] != 1 {
+		t.Fatalf("inventory after one smelt = %v", c.Inventory)
+	}
+	if m := pl.machines[at(0, 0)]; m.Fuel != FuelTicks-3 || m.Progress != 0 {
+		t.Fatalf("machine after one smelt = %+v", m)
+	}
+
+	// The last COAL goes into the recipe; the burning fuel finishes the job.
+	for i := 0; i < 3; i++ {
+		pl.step()
+	}
+	if c.Inventory["IRON_INGOT"] != 2 || c.Inventory["COAL"] != 0 {
+		t.Fatalf("inventory after two smelts = %v", c.Inventory)
+	}
+	pl.step()
+	if _, ok := pl.machines[at(0, 0)]; ok && pl.machines[at(0, 0)].RecipeID != "" {
+		t.Fatalf("idle furnace still has a recipe: %+v", pl.machines[at(0, 0)])
+	}
+}
+
+func TestAutoCrafterNeedsBindingAndPower(t *testing.T) {
+	pl := newPlant()
+	c := pl.container(at(0, 0), AutoCrafter, map[string]int{"LOG": 1})
+	pl.step()
+	pl.step()
+	if c.Inventory["PLANK"] != 0 {
+		t.Fatalf("unbound crafter crafted")
+	}
+
+	pl.machines[at(0, 0)] = modelpkg.MachineMeta{RecipeID: "plank"}
+	pl.step()
+	if pl.machines[at(0, 0)].Progress != 0 {
+		t.Fatalf("unpowered crafter without fuel progressed")
+	}
+	pl.powered[at(0, 0)] = true
+	pl.step()
+	pl.step()
+	if c.Inventory["PLANK"] != 4 || c.Inventory["LOG"] != 0 {
+		t.Fatalf("inventory = %v", c.Inventory)
+	}
+	if m := pl.machines[at(0, 0)]; m.RecipeID != "plank" || m.Fuel != 0 {
+		t.Fatalf("binding lost or fuel burnt while powered: %+v", m)
+	}
+}
+
+func TestHopperMovesProducts(t *testing.T) {
+	pl := newPlant()
+	// Crafter -> hopper -> chest along +X; a second hopper feeds dropped items onto a belt.
+	crafter := pl.container(at(0, 0), AutoCrafter, map[string]int{"LOG": 3, "PLANK": 2})
+	pl.machines[at(0, 0)] = modelpkg.MachineMeta{RecipeID: "plank"}
+	pl.blocks[at(1, 0)] = Hopper
+	pl.machines[at(1, 0)] = modelpkg.MachineMeta{DX: 1}
+	chest := pl.container(at(2, 0), "CHEST", map[string]int{})
+
+	pl.blocks[at(1, 5)] = Hopper
+	pl.machines[at(1, 5)] = modelpkg.MachineMeta{DX: 1}
+	pl.dropped[at(0, 5)] = []string{"STONE"}
+	pl.blocks[at(2, 5)] = "CONVEYOR"
+
+	pl.step()
+	pl.step()
+	pl.step()
+	if chest.Inventory["PLANK"] != 2 || chest.Inventory["LOG"] != 0 || crafter.Inventory["LOG"] != 3 {
+		t.Fatalf("chest=%v crafter=%v", chest.Inventory, crafter.Inventory)
+	}
+	if got := pl.dropped[at(2, 5)]; len(got) != 1 || got[0] != "STONE" {
+		t.Fatalf("belt = %v", got)
+	}
+
+	delete(pl.blocks, at(1, 0))
+	pl.step()
+	if _, ok := pl.machines[at(1, 0)]; ok {
+		t.Fatalf("state of a removed hopper kept")
+	}
+}
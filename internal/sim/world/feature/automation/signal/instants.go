@@ -0,0 +1,53 @@
+package signal
+
+import (
+	"strings"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type ActionResultFn func(tick uint64, ref string, ok bool, code string, message string) protocol.Event
+
+type SensorInstantEnv interface {
+	ParseContainerID(id string) (typ string, pos modelpkg.Vec3i, ok bool)
+	BlockNameAt(pos modelpkg.Vec3i) string
+	Distance(a modelpkg.Vec3i, b modelpkg.Vec3i) int
+	CanBuildAt(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool
+	SetSensor(pos modelpkg.Vec3i, mode string, arg int)
+	AuditSensorSet(nowTick uint64, actorID string, pos modelpkg.Vec3i, mode string, arg int)
+	BumpLawRep(agentID string, delta int)
+	RecordDenied(nowTick uint64)
+}
+
+// HandleSetSensor configures what a SENSOR detects (SET_SENSOR: target_id, mode,
+// radius for AGENT, count for FILL). Same reach and permission as TOGGLE_SWITCH.
+func HandleSetSensor(env SensorInstantEnv, ar ActionResultFn, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64) {
+	typ, pos, ok := env.ParseContainerID(strings.TrimSpace(inst.TargetID))
+	if !ok || typ != Sensor {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BAD_REQUEST", "invalid sensor target"))
+		return
+	}
+	mode, arg, ok := NormalizeSensor(inst.Mode, inst.Radius, inst.Count)
+	if !ok {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BAD_REQUEST", "bad sensor mode"))
+		return
+	}
+	if env.BlockNameAt(pos) != Sensor {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "sensor not found"))
+		return
+	}
+	if env.Distance(a.Pos, pos) > 3 {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BLOCKED", "too far"))
+		return
+	}
+	if !env.CanBuildAt(a.ID, pos, nowTick) {
+		env.BumpLawRep(a.ID, -1)
+		env.RecordDenied(nowTick)
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_PERMISSION", "sensor config denied"))
+		return
+	}
+	env.SetSensor(pos, mode, arg)
+	env.AuditSensorSet(nowTick, a.ID, pos, mode, arg)
+	a.AddEvent(ar(nowTick, inst.ID, true, "", "ok"))
+}
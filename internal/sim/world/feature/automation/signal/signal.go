@@ -0,0 +1,336 @@
+// Package signal runs the block logic network. SWITCH, SENSOR and BATTERY blocks emit
+// MaxStrength; WIRE carries the strongest neighbouring signal minus one per block;
+// AND/OR/NOT/REPEATER gates read their back and side inputs and drive the cell in
+// front of them one tick later. Conveyors, doors and machines are consumers: powered
+// while any neighbour delivers a signal. Only the four horizontal neighbours connect.
+package signal
+
+import (
+	"strings"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	MaxStrength = 15
+	// BatteryCapacity is how many ticks a full battery keeps its output on.
+	BatteryCapacity = 600
+
+	Wire     = "WIRE"
+	Switch   = "SWITCH"
+	Sensor   = "SENSOR"
+	Battery  = "BATTERY"
+	AndGate  = "AND_GATE"
+	OrGate   = "OR_GATE"
+	NotGate  = "NOT_GATE"
+	Repeater = "REPEATER"
+	Door     = "DOOR"
+	DoorOpen = "DOOR_OPEN"
+)
+
+// Sensor modes.
+const (
+	SensorItems = "ITEMS" // items on or next to the sensor, or in an adjacent container
+	SensorAgent = "AGENT" // an agent within Arg blocks
+	SensorDay   = "DAY"
+	SensorNight = "NIGHT"
+	SensorFill  = "FILL" // adjacent containers hold at least Arg items
+
+	DefaultAgentRadius = 3
+	MaxAgentRadius     = 8
+)
+
+// HasNode reports blocks whose state lives in a SignalNode.
+func HasNode(blockName string) bool {
+	return blockName == Wire || blockName == Sensor || Faced(blockName)
+}
+
+// Faced reports blocks with an output side (taken from the placer's yaw).
+func Faced(blockName string) bool {
+	return blockName == Battery || IsGate(blockName)
+}
+
+func IsGate(blockName string) bool {
+	switch blockName {
+	case AndGate, OrGate, NotGate, Repeater:
+		return true
+	}
+	return false
+}
+
+func IsDoor(blockName string) bool { return blockName == Door || blockName == DoorOpen }
+
+// IsPolled reports blocks whose output is re-evaluated every tick (sensors and batteries).
+func IsPolled(blockName string) bool { return blockName == Sensor || blockName == Battery }
+
+// PolledPositions rebuilds the Polled set from the nodes (after loading a snapshot).
+func PolledPositions(nodes map[modelpkg.Vec3i]*modelpkg.SignalNode, blockNameAt func(modelpkg.Vec3i) string) map[modelpkg.Vec3i]bool {
+	out := map[modelpkg.Vec3i]bool{}
+	for p := range nodes {
+		if IsPolled(blockNameAt(p)) {
+			out[p] = true
+		}
+	}
+	return out
+}
+
+// NormalizeSensor validates a SET_SENSOR mode. ITEMS is stored as "" (the default).
+func NormalizeSensor(mode string, radius, count int) (string, int, bool) {
+	switch m := strings.ToUpper(strings.TrimSpace(mode)); m {
+	case "", SensorItems:
+		return "", 0, true
+	case SensorDay, SensorNight:
+		return m, 0, true
+	case SensorAgent:
+		if radius == 0 {
+			radius = DefaultAgentRadius
+		}
+		if radius < 1 || radius > MaxAgentRadius {
+			return "", 0, false
+		}
+		return m, radius, true
+	case SensorFill:
+		if count < 1 {
+			return "", 0, false
+		}
+		return m, count, true
+	}
+	return "", 0, false
+}
+
+// SensorMode is the display name of a sensor node's mode.
+func SensorMode(n modelpkg.SignalNode) string {
+	if n.Mode == "" {
+		return SensorItems
+	}
+	return n.Mode
+}
+
+type StepInput struct {
+	Nodes map[modelpkg.Vec3i]*modelpkg.SignalNode
+	// Active cells are re-evaluated in position order, up to Budget per tick.
+	Active map[modelpkg.Vec3i]bool
+	Budget int
+	// Polled holds at least every node position that is a sensor or battery, so the
+	// per-tick poll does not walk the whole network. Stale entries are dropped here.
+	Polled map[modelpkg.Vec3i]bool
+}
+
+type StepHooks struct {
+	BlockNameAt func(pos modelpkg.Vec3i) string
+	SwitchOn    func(pos modelpkg.Vec3i) bool
+	// SensorActive evaluates a sensor's condition for its mode and arg.
+	SensorActive func(pos modelpkg.Vec3i, n modelpkg.SignalNode) bool
+	// SetDoor opens or closes the door at pos; false means it has to wait (an agent
+	// stands in the doorway).
+	SetDoor func(pos modelpkg.Vec3i, open bool) bool
+}
+
+// Step polls sensors and batteries, then propagates changes through up to in.Budget
+// active cells and returns how many it handled. Wires settle within the step; gates
+// and doors woken by it wait for the next one, so gate loops oscillate instead of
+// spinning.
+func Step(in StepInput, hooks StepHooks) int {
+	next := map[modelpkg.Vec3i]bool{}
+	for _, p := range modelpkg.SortedPositions(in.Polled) {
+		n := in.Nodes[p]
+		name := hooks.BlockNameAt(p)
+		if n == nil || !IsPolled(name) {
+			delete(in.Polled, p)
+			continue
+		}
+		level := n.Level
+		switch name {
+		case Sensor:
+			level = 0
+			if hooks.SensorActive != nil && hooks.SensorActive(p, *n) {
+				level = MaxStrength
+			}
+		case Battery:
+			level = stepBattery(in, hooks, p, n)
+		}
+		if level != n.Level {
+			n.Level = level
+			wake(in, hooks, next, p)
+		}
+	}
+
+	done := 0
+	for done < in.Budget && len(in.Active) > 0 {
+		for _, p := range modelpkg.SortedPositions(in.Active) {
+			if done >= in.Budget {
+				break
+			}
+			delete(in.Active, p)
+			done++
+			update(in, hooks, next, p)
+		}
+	}
+	for p := range next {
+		in.Active[p] = true
+	}
+	return done
+}
+
+// stepBattery charges while its back input is powered and drains while it is not;
+// either way it outputs MaxStrength until the charge runs out.
+func stepBattery(in StepInput, hooks StepHooks, p modelpkg.Vec3i, n *modelpkg.SignalNode) int {
+	back := modelpkg.Vec3i{X: p.X - int(n.DX), Y: p.Y, Z: p.Z - int(n.DZ)}
+	if Delivered(in.Nodes, hooks, back, p) > 0 {
+		if n.Charge < BatteryCapacity {
+			n.Charge++
+		}
+		return MaxStrength
+	}
+	if n.Charge > 0 {
+		n.Charge--
+		return MaxStrength
+	}
+	return 0
+}
+
+func update(in StepInput, hooks StepHooks, next map[modelpkg.Vec3i]bool, p modelpkg.Vec3i) {
+	name := hooks.BlockNameAt(p)
+	n := in.Nodes[p]
+	if !HasNode(name) {
+		if n != nil {
+			delete(in.Nodes, p)
+			wake(in, hooks, next, p)
+		}
+		if IsDoor(name) {
+			open := Powered(in.Nodes, hooks, p)
+			if open != (name == DoorOpen) && hooks.SetDoor != nil && !hooks.SetDoor(p, open) {
+				next[p] = true
+			}
+		}
+		return
+	}
+	if n == nil {
+		n = &modelpkg.SignalNode{DX: 1}
+		in.Nodes[p] = n
+	}
+	if IsPolled(name) {
+		in.Polled[p] = true
+	}
+	level := n.Level
+	switch {
+	case name == Wire:
+		level = wireLevel(in.Nodes, hooks, p)
+	case IsGate(name):
+		level = 0
+		if gateOn(in.Nodes, hooks, p, name, n) {
+			level = MaxStrength
+		}
+	}
+	if level != n.Level {
+		n.Level = level
+		wake(in, hooks, next, p)
+	}
+}
+
+func wireLevel(nodes map[modelpkg.Vec3i]*modelpkg.SignalNode, hooks StepHooks, p modelpkg.Vec3i) int {
+	best := 0
+	for _, s := range sideways(p) {
+		d := Delivered(nodes, hooks, s, p)
+		if d > 0 && hooks.BlockNameAt(s) == Wire {
+			d--
+		}
+		if d > best {
+			best = d
+		}
+	}
+	return best
+}
+
+func gateOn(nodes map[modelpkg.Vec3i]*modelpkg.SignalNode, hooks StepHooks, p modelpkg.Vec3i, name string, n *modelpkg.SignalNode) bool {
+	dx, dz := int(n.DX), int(n.DZ)
+	powered := func(q modelpkg.Vec3i) bool { return Delivered(nodes, hooks, q, p) > 0 }
+	back := modelpkg.Vec3i{X: p.X - dx, Y: p.Y, Z: p.Z - dz}
+	left := modelpkg.Vec3i{X: p.X + dz, Y: p.Y, Z: p.Z - dx}
+	right := modelpkg.Vec3i{X: p.X - dz, Y: p.Y, Z: p.Z + dx}
+	switch name {
+	case NotGate:
+		return !powered(back)
+	case Repeater:
+		return powered(back)
+	case AndGate:
+		return powered(left) && powered(right)
+	case OrGate:
+		return powered(back) || powered(left) || powered(right)
+	}
+	return false
+}
+
+// wake queues the neighbours that read p: wires settle this step, gates and doors
+// next step.
+func wake(in StepInput, hooks StepHooks, next map[modelpkg.Vec3i]bool, p modelpkg.Vec3i) {
+	for _, s := range sideways(p) {
+		switch name := hooks.BlockNameAt(s); {
+		case name == Wire:
+			in.Active[s] = true
+		case IsGate(name) || IsDoor(name):
+			next[s] = true
+		}
+	}
+}
+
+// Delivered is the strength the block at from feeds into the adjacent cell to. Gates
+// and batteries only feed the cell in front of them.
+func Delivered(nodes map[modelpkg.Vec3i]*modelpkg.SignalNode, hooks StepHooks, from, to modelpkg.Vec3i) int {
+	switch name := hooks.BlockNameAt(from); {
+	case name == Switch:
+		if hooks.SwitchOn != nil && hooks.SwitchOn(from) {
+			return MaxStrength
+		}
+	case name == Wire || name == Sensor:
+		if n := nodes[from]; n != nil {
+			return n.Level
+		}
+	case Faced(name):
+		if n := nodes[from]; n != nil && from.X+int(n.DX) == to.X && from.Z+int(n.DZ) == to.Z {
+			return n.Level
+		}
+	}
+	return 0
+}
+
+// Powered reports whether any neighbour delivers a signal into p.
+func Powered(nodes map[modelpkg.Vec3i]*modelpkg.SignalNode, hooks StepHooks, p modelpkg.Vec3i) bool {
+	for _, s := range sideways(p) {
+		if Delivered(nodes, hooks, s, p) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Controlled reports whether p has a neighbour that can feed it a signal (wire,
+// switch, sensor, or a gate/battery facing it). Uncontrolled conveyors run freely.
+func Controlled(nodes map[modelpkg.Vec3i]*modelpkg.SignalNode, hooks StepHooks, p modelpkg.Vec3i) bool {
+	for _, s := range sideways(p) {
+		switch name := hooks.BlockNameAt(s); {
+		case name == Wire || name == Switch || name == Sensor:
+			return true
+		case Faced(name):
+			if n := nodes[s]; n != nil && s.X+int(n.DX) == p.X && s.Z+int(n.DZ) == p.Z {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Neighbours lists the cells that read p.
+func Neighbours(p modelpkg.Vec3i) []modelpkg.Vec3i {
+	s := sideways(p)
+	return s[:]
+}
+
+func sideways(p modelpkg.Vec3i) [4]modelpkg.Vec3i {
+	return [4]modelpkg.Vec3i{
+		{X: p.X - 1, Y: p.Y, Z: p.Z},
+		{X: p.X + 1, Y: p.Y, Z: p.Z},
+		{X: p.X, Y: p.Y, Z: p.Z - 1},
+		{X: p.X, Y: p.Y, Z: p.Z + 1},
+	}
+}
@@ -0,0 +1,254 @@
+package signal
+
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type board struct {
+	blocks   map[modelpkg.Vec3i]string
+	switches map[modelpkg.Vec3i]bool
+	nodes    map[modelpkg.Vec3i]*modelpkg.SignalNode
+	active   map[modelpkg.Vec3i]bool
+	polled   map[modelpkg.Vec3i]bool
+	budget   int
+}
+
+func newBoard() *board {
+	return &board{
+		blocks:   map[modelpkg.Vec3i]string{},
+		switches: map[modelpkg.Vec3i]bool{},
+		nodes:    map[modelpkg.Vec3i]*modelpkg.SignalNode{},
+		active:   map[modelpkg.Vec3i]bool{},
+		polled:   map[modelpkg.Vec3i]bool{},
+		budget:   1000,
+	}
+}
+
+// set places a block and wakes it and its neighbours, as the world does on SET_BLOCK.
+func (b *board) set(p modelpkg.Vec3i, name string) {
+	if name == "" {
+		delete(b.blocks, p)
+	} else {
+		b.blocks[p] = name
+	}
+	if IsPolled(name) {
+		b.polled[p] = true
+	}
+	b.active[p] = true
+	for _, n := range Neighbours(p) {
+		b.active[n] = true
+	}
+}
+
+func (b *board) hooks() StepHooks {
+	return StepHooks{
+		BlockNameAt: func(p modelpkg.Vec3i) string { return b.blocks[p] },
+		SwitchOn:    func(p modelpkg.Vec3i) bool { return b.switches[p] },
+		SetDoor: func(p modelpkg.Vec3i, open bool) bool {
+			if open {
+				b.set(p, DoorOpen)
+			} else {
+				b.set(p, Door)
+			}
+			return true
+		},
+	}
+}
+
+func (b *board) step() int {
+	return Step(StepInput{Nodes: b.nodes, Active: b.active, Budget: b.budget, Polled: b.polled}, b.hooks())
+}
+
+func (b *board) level(p modelpkg.Vec3i) int {
+	if n := b.nodes[p]; n != nil {
+		return n.Level
+	}
+	return 0
+}
+
+func at(x, z int) modelpkg.Vec3i { return modelpkg.Vec3i{X: x, Z: z} }
+
+func TestWireStrengthDecays(t *testing.T) {
+	b := newBoard()
+	b.set(at(0, 0), Switch)
+	for x := 1; x <= MaxStrength+2; x++ {
+		b.set(at(x, 0), Wire)
+	}
+	b.switches[at(0, 0)] = true
+	b.set(at(0, 0), Switch)
+	b.step()
+
+	if got := b.level(at(1, 0)); got != MaxStrength {
+		t.Fatalf("wire next to switch = %d, want %d", got, MaxStrength)
+	}
+	if got := b.level(at(MaxStrength, 0)); got != 1 {
+		t.Fatalf("last powered wire = %d, want 1", got)
+	}
+	if got := b.level(at(MaxStrength+1, 0)); got != 0 {
+		t.Fatalf("wire past the range = %d, want 0", got)
+	}
+	if !Powered(b.nodes, b.hooks(), at(1, 1)) || Powered(b.nodes, b.hooks(), at(MaxStrength+1, 1)) {
+		t.Fatalf("consumers next to the wire see the wrong power")
+	}
+
+	b.switches[at(0, 0)] = false
+	b.set(at(0, 0), Switch)
+	b.step()
+	for x := 1; x <= MaxStrength; x++ {
+		if got := b.level(at(x, 0)); got != 0 {
+			t.Fatalf("wire %d still at %d after switch off", x, got)
+		}
+	}
+}
+
+func TestGatesAndDoor(t *testing.T) {
+	b := newBoard()
+	// AND gate at origin facing +X: inputs on its sides (z=-1 and z=+1), door in front.
+	b.set(at(0, 0), AndGate)
+	b.set(at(0, -1), Switch)
+	b.set(at(0, 1), Switch)
+	b.set(at(1, 0), Door)
+	b.step()
+	if _, ok := b.nodes[at(0, 0)]; !ok {
+		t.Fatalf("gate node not created")
+	}
+
+	b.switches[at(0, -1)] = true
+	b.set(at(0, -1), Switch)
+	b.step()
+	b.step()
+	if b.blocks[at(1, 0)] != Door {
+		t.Fatalf("door opened with one AND input")
+	}
+
+	b.switches[at(0, 1)] = true
+	b.set(at(0, 1), Switch)
+	b.step() // gate turns on
+	if b.blocks[at(1, 0)] != Door {
+		t.Fatalf("gate output must take a tick to reach the door")
+	}
+	b.step()
+	if b.blocks[at(1, 0)] != DoorOpen {
+		t.Fatalf("door not opened by the AND gate")
+	}
+
+	// A NOT gate fed by its own output through wire oscillates, one flip per step.
+	c := newBoard()
+	c.set(at(0, 0), NotGate)
+	c.set(at(1, 0), Wire)
+	c.set(at(1, 1), Wire)
+	c.set(at(0, 1), Wire)
+	c.set(at(-1, 1), Wire)
+	c.set(at(-1, 0), Wire)
+	levels := []int{}
+	for i := 0; i < 6; i++ {
+		c.step()
+		levels = append(levels, c.level(at(0, 0)))
+	}
+	flips := 0
+	for i := 1; i < len(levels); i++ {
+		if levels[i] != levels[i-1] {
+			flips++
+		}
+	}
+	if flips < 2 {
+		t.Fatalf("NOT loop did not oscillate: %v", levels)
+	}
+}
+
+func TestBatteryStoresCharge(t *testing.T) {
+	b := newBoard()
+	b.set(at(0, 0), Switch)
+	b.set(at(1, 0), Battery) // faces +X by default: input from the switch
+	b.set(at(2, 0), Wire)
+	b.step()
+	b.switches[at(0, 0)] = true
+	for i := 0; i < 5; i++ {
+		b.step()
+	}
+	if got := b.nodes[at(1, 0)].Charge; got != 5 {
+		t.Fatalf("charge = %d, want 5", got)
+	}
+	if b.level(at(2, 0)) != MaxStrength {
+		t.Fatalf("battery output not on the wire")
+	}
+
+	b.switches[at(0, 0)] = false
+	for i := 0; i < 5; i++ {
+		b.step()
+	}
+	if b.level(at(2, 0)) != MaxStrength || b.nodes[at(1, 0)].Charge != 0 {
+		t.Fatalf("battery should drain while powering: charge=%d", b.nodes[at(1, 0)].Charge)
+	}
+	b.step()
+	if b.level(at(2, 0)) != 0 {
+		t.Fatalf("empty battery still powers the wire")
+	}
+}
+
+func TestPollOnlyVisitsSensorsAndBatteries(t *testing.T) {
+	b := newBoard()
+	b.set(at(0, 0), Battery)
+	for x := 1; x <= 4; x++ {
+		b.set(at(x, 0), Wire)
+	}
+	b.step()
+	if len(b.polled) != 1 || !b.polled[at(0, 0)] {
+		t.Fatalf("polled = %v, want only the battery", b.polled)
+	}
+	b.set(at(0, 0), "")
+	b.step()
+	if len(b.polled) != 0 {
+		t.Fatalf("removed battery still polled: %v", b.polled)
+	}
+
+	// Rebuilt from the nodes after a snapshot load.
+	b.set(at(0, 0), Sensor)
+	b.step()
+	got := PolledPositions(b.nodes, b.hooks().BlockNameAt)
+	if len(got) != 1 || !got[at(0, 0)] {
+		t.Fatalf("rebuilt polled = %v", got)
+	}
+}
+
+func TestStepBudget(t *testing.T) {
+	b := newBoard()
+	b.budget = 3
+	b.set(at(0, 0), Switch)
+	for x := 1; x <= 6; x++ {
+		b.set(at(x, 0), Wire)
+	}
+	b.switches[at(0, 0)] = true
+	if n := b.step(); n != 3 {
+		t.Fatalf("handled %d cells with budget 3", n)
+	}
+	if len(b.active) == 0 {
+		t.Fatalf("the rest of the work must wait for later steps")
+	}
+	for i := 0; i < 20 && len(b.active) > 0; i++ {
+		b.step()
+	}
+	if got := b.level(at(6, 0)); got != MaxStrength-5 {
+		t.Fatalf("wire 6 = %d, want %d", got, MaxStrength-5)
+	}
+}
+
+func TestNormalizeSensor(t *testing.T) {
+	if m, arg, ok := NormalizeSensor("agent", 0, 0); !ok || m != SensorAgent || arg != DefaultAgentRadius {
+		t.Fatalf("agent default: %q %d %v", m, arg, ok)
+	}
+	if _, _, ok := NormalizeSensor("AGENT", MaxAgentRadius+1, 0); ok {
+		t.Fatalf("radius above max accepted")
+	}
+	if _, _, ok := NormalizeSensor("FILL", 0, 0); ok {
+		t.Fatalf("FILL without threshold accepted")
+	}
+	if m, _, ok := NormalizeSensor("ITEMS", 0, 0); !ok || m != "" {
+		t.Fatalf("ITEMS must normalize to the default mode")
+	}
+	if _, _, ok := NormalizeSensor("LASER", 0, 0); ok {
+		t.Fatalf("unknown mode accepted")
+	}
+}
@@ -0,0 +1,106 @@
+package damage
+
+import "strings"
+
+const (
+	// AttackReach is the max Manhattan distance for a melee swing.
+	AttackReach = 2
+	// AttackIntervalTicks is the number of work ticks per swing.
+	AttackIntervalTicks = 4
+
+	// Law reputation penalties.
+	RepLawDenied = 1 // attack attempt where damage is not allowed
+	RepLawKill   = 5 // killing an agent that is not wanted
+)
+
+// BestWeaponTier returns the best sword tier carried in inv (0 = bare hands). Like
+// mining.BestToolTier, weapons are used implicitly from the inventory.
+func BestWeaponTier(inv map[string]int) int {
+	if len(inv) == 0 {
+		return 0
+	}
+	if inv["IRON_SWORD"] > 0 {
+		return 3
+	}
+	if inv["STONE_SWORD"] > 0 {
+		return 2
+	}
+	if inv["WOOD_SWORD"] > 0 {
+		return 1
+	}
+	return 0
+}
+
+func AttackParamsForTier(tier int) (dmg int, staminaCost int) {
+	switch tier {
+	case 3:
+		return 5, 40
+	case 2:
+		return 4, 45
+	case 1:
+		return 3, 50
+	default:
+		return 1, 60
+	}
+}
+
+// ArmorSlot maps an armor item to its Equipment.Armor slot
+// (0=head, 1=chest, 2=legs, 3=feet).
+func ArmorSlot(item string) (int, bool) {
+	switch {
+	case strings.HasSuffix(item, "_HELMET"):
+		return 0, true
+	case strings.HasSuffix(item, "_CHESTPLATE"):
+		return 1, true
+	case strings.HasSuffix(item, "_LEGGINGS"):
+		return 2, true
+	case strings.HasSuffix(item, "_BOOTS"):
+		return 3, true
+	default:
+		return 0, false
+	}
+}
+
+func armorPoints(item string) int {
+	if _, ok := ArmorSlot(item); !ok {
+		return 0
+	}
+	switch {
+	case strings.HasPrefix(item, "IRON_"):
+		return 2
+	case strings.HasPrefix(item, "COPPER_"):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ArmorPoints sums the armor points of the equipped pieces (max 8).
+func ArmorPoints(armor [4]string) int {
+	total := 0
+	for i, item := range armor {
+		if slot, ok := ArmorSlot(item); ok && slot == i {
+			total += armorPoints(item)
+		}
+	}
+	return total
+}
+
+// Reduce applies armor to a raw hit. Each armor point absorbs 10%; a non-zero
+// hit always deals at least 1 damage.
+func Reduce(dmg int, armorPoints int) int {
+	if dmg <= 0 {
+		return 0
+	}
+	if armorPoints < 0 {
+		armorPoints = 0
+	}
+	if armorPoints > 8 {
+		armorPoints = 8
+	}
+	out := dmg * (10 - armorPoints) / 10
+	if out < 1 {
+		out = 1
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package damage
+
+import "testing"
+
+func TestBestWeaponTier(t *testing.T) {
+	inv := map[string]int{"WOOD_SWORD": 1, "STONE_SWORD": 1}
+	if got := BestWeaponTier(inv); got != 2 {
+		t.Fatalf("expected stone tier (2), got %d", got)
+	}
+	if got := BestWeaponTier(map[string]int{"IRON_PICKAXE": 1, "IRON_SWORD": 0}); got != 0 {
+		t.Fatalf("expected bare hands (0), got %d", got)
+	}
+}
+
+func TestArmorSlotAndPoints(t *testing.T) {
+	if slot, ok := ArmorSlot("IRON_CHESTPLATE"); !ok || slot != 1 {
+		t.Fatalf("unexpected slot=%d ok=%v", slot, ok)
+	}
+	if _, ok := ArmorSlot("IRON_INGOT"); ok {
+		t.Fatalf("IRON_INGOT is not armor")
+	}
+	armor := [4]string{"IRON_HELMET", "COPPER_CHESTPLATE", "NONE", "IRON_HELMET"}
+	// The misplaced helmet in the feet slot does not count.
+	if got := ArmorPoints(armor); got != 3 {
+		t.Fatalf("expected 3 armor points, got %d", got)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	if got := Reduce(5, 0); got != 5 {
+		t.Fatalf("expected 5, got %d", got)
+	}
+	if got := Reduce(5, 8); got != 1 {
+		t.Fatalf("expected 1, got %d", got)
+	}
+	if got := Reduce(1, 4); got != 1 {
+		t.Fatalf("expected min 1, got %d", got)
+	}
+	if got := Reduce(0, 0); got != 0 {
+		t.Fatalf("expected 0, got %d", got)
+	}
+}
@@ -0,0 +1,124 @@
+package runtime
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/tasks"
+	damagepkg "voxelcraft.ai/internal/sim/world/feature/combat/damage"
+	respawnpkg "voxelcraft.ai/internal/sim/world/feature/survival/respawn"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
+)
+
+type AttackExecEnv interface {
+	GetAgent(agentID string) *modelpkg.Agent
+	CanDamageAt(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool
+	EnforceLaw(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool
+	BumpRepLaw(agentID string, delta int)
+	RecordDenied(nowTick uint64)
+	Respawn(nowTick uint64, a *modelpkg.Agent, reason string)
+	AuditEvent(nowTick uint64, actor string, action string, pos modelpkg.Vec3i, reason string, details map[string]any)
+
+	GetMob(mobID string) *modelpkg.Mob
+	MobHurt(nowTick uint64, m *modelpkg.Mob, attackerID string)
+	KillMob(nowTick uint64, m *modelpkg.Mob, killerID string)
+}
+
+func TickAttack(env AttackExecEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTick uint64) {
+	if m := env.GetMob(wt.TargetID); m != nil {
+		tickAttackMob(env, a, wt, m, nowTick)
+		return
+	}
+	target := env.GetAgent(wt.TargetID)
+	if target == nil || target == a {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_INVALID_TARGET", "message": "target not found"})
+		return
+	}
+	if modelpkg.Manhattan(a.Pos, target.Pos) > damagepkg.AttackReach {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_INVALID_TARGET", "message": "too far"})
+		return
+	}
+	if !env.CanDamageAt(a.ID, target.Pos, nowTick) {
+		env.EnforceLaw(a, target.Pos, rules.ActionDamage, nil, nowTick)
+		a.WorkTask = nil
+		env.BumpRepLaw(a.ID, -damagepkg.RepLawDenied)
+		env.RecordDenied(nowTick)
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_NO_PERMISSION", "message": "damage denied"})
+		return
+	}
+
+	tier, dmg, ok := swing(a, wt)
+	if !ok {
+		return
+	}
+
+	hit := damagepkg.Reduce(dmg, damagepkg.ArmorPoints(target.Equipment.Armor))
+	target.HP -= hit
+	if target.HP < 0 {
+		target.HP = 0
+	}
+	target.AddEvent(protocol.Event{"t": nowTick, "type": "DAMAGE", "kind": "ATTACK", "attacker_id": a.ID, "hp": target.HP})
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "ATTACK_HIT", "task_id": wt.TaskID, "target_id": target.ID, "damage": hit, "target_hp": target.HP})
+	if target.HP > 0 {
+		return
+	}
+
+	// Killing an agent that is not wanted is a law offence even where damage is allowed.
+	wanted := rules.Wanted(target.RepLaw)
+	if !wanted {
+		env.BumpRepLaw(a.ID, -damagepkg.RepLawKill)
+	}
+	env.AuditEvent(nowTick, a.ID, "KILL", target.Pos, "ATTACK", map[string]any{
+		"target_id":   target.ID,
+		"weapon_tier": tier,
+		"wanted":      wanted,
+	})
+	env.Respawn(nowTick, target, respawnpkg.ReasonKilled)
+	a.WorkTask = nil
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_DONE", "task_id": wt.TaskID, "kind": string(wt.Kind)})
+}
+
+// swing advances the attack cooldown and spends stamina once a swing is ready.
+func swing(a *modelpkg.Agent, wt *tasks.WorkTask) (tier int, dmg int, ok bool) {
+	if wt.WorkTicks < damagepkg.AttackIntervalTicks {
+		wt.WorkTicks++
+	}
+	if wt.WorkTicks < damagepkg.AttackIntervalTicks {
+		return 0, 0, false
+	}
+	tier = damagepkg.BestWeaponTier(a.Inventory)
+	dmg, cost := damagepkg.AttackParamsForTier(tier)
+	if a.StaminaMilli < cost {
+		// Swing is ready; wait for stamina.
+		return 0, 0, false
+	}
+	a.StaminaMilli -= cost
+	wt.WorkTicks = 0
+	return tier, dmg, true
+}
+
+// tickAttackMob hits an NPC mob. Mobs are not protected by claims and carry no law reputation.
+func tickAttackMob(env AttackExecEnv, a *modelpkg.Agent, wt *tasks.WorkTask, m *modelpkg.Mob, nowTick uint64) {
+	if modelpkg.Manhattan(a.Pos, m.Pos) > damagepkg.AttackReach {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_INVALID_TARGET", "message": "too far"})
+		return
+	}
+	_, dmg, ok := swing(a, wt)
+	if !ok {
+		return
+	}
+	m.HP -= dmg
+	if m.HP < 0 {
+		m.HP = 0
+	}
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "ATTACK_HIT", "task_id": wt.TaskID, "target_id": m.EntityID, "damage": dmg, "target_hp": m.HP})
+	if m.HP > 0 {
+		env.MobHurt(nowTick, m, a.ID)
+		return
+	}
+	env.KillMob(nowTick, m, a.ID)
+	a.WorkTask = nil
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_DONE", "task_id": wt.TaskID, "kind": string(wt.Kind)})
+}
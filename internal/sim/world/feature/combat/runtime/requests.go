@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	"voxelcraft.ai/internal/sim/tasks"
+	damagepkg "voxelcraft.ai/internal/sim/world/feature/combat/damage"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type ActionResultFn func(tick uint64, ref string, ok bool, code string, message string) protocol.Event
+
+type AttackRequestEnv interface {
+	NewTaskID() string
+	TargetExists(targetID string) bool
+}
+
+func HandleTaskAttack(env AttackRequestEnv, ar ActionResultFn, a *modelpkg.Agent, tr protocol.TaskReq, nowTick uint64) {
+	if a.WorkTask != nil {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_CONFLICT", "work task slot occupied"))
+		return
+	}
+	if tr.TargetID == "" {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_BAD_REQUEST", "missing target_id"))
+		return
+	}
+	if tr.TargetID == a.ID {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "cannot attack self"))
+		return
+	}
+	if env == nil || !env.TargetExists(tr.TargetID) {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "target not found"))
+		return
+	}
+	taskID := env.NewTaskID()
+	a.WorkTask = &tasks.WorkTask{
+		TaskID:      taskID,
+		Kind:        tasks.KindAttack,
+		TargetID:    tr.TargetID,
+		StartedTick: nowTick,
+	}
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": tr.ID, "ok": true, "task_id": taskID})
+}
+
+// HandleEquip moves one armor item from the inventory into its armor slot,
+// returning any previously equipped piece to the inventory. Weapons are not
+// equipped: the attack tier comes from the inventory (damage.BestWeaponTier).
+func HandleEquip(ar ActionResultFn, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64, itemDefs map[string]catalogs.ItemDef) {
+	if inst.ItemID == "" {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BAD_REQUEST", "missing item_id"))
+		return
+	}
+	def, ok := itemDefs[inst.ItemID]
+	if !ok {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "unknown item"))
+		return
+	}
+	slot, ok := damagepkg.ArmorSlot(inst.ItemID)
+	if def.Kind != "ARMOR" || !ok {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BAD_REQUEST", "item not equippable"))
+		return
+	}
+	if a.Inventory[inst.ItemID] < 1 {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_RESOURCE", "missing item"))
+		return
+	}
+	a.Inventory[inst.ItemID]--
+	if a.Inventory[inst.ItemID] <= 0 {
+		delete(a.Inventory, inst.ItemID)
+	}
+	if prev := a.Equipment.Armor[slot]; prev != "" && prev != "NONE" {
+		a.Inventory[prev]++
+	}
+	a.Equipment.Armor[slot] = inst.ItemID
+	a.AddEvent(ar(nowTick, inst.ID, true, "", "ok"))
+}
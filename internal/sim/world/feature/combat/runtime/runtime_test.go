@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func testActionResult(tick uint64, ref string, ok bool, code string, message string) protocol.Event {
+	return protocol.Event{"t": tick, "type": "ACTION_RESULT", "ref": ref, "ok": ok, "code": code, "message": message}
+}
+
+func TestHandleEquipSwapsArmorPiece(t *testing.T) {
+	defs := map[string]catalogs.ItemDef{
+		"COPPER_HELMET": {ID: "COPPER_HELMET", Kind: "ARMOR"},
+		"IRON_HELMET":   {ID: "IRON_HELMET", Kind: "ARMOR"},
+		"IRON_INGOT":    {ID: "IRON_INGOT", Kind: "MATERIAL"},
+	}
+	a := &modelpkg.Agent{ID: "A1", Inventory: map[string]int{"IRON_HELMET": 1, "IRON_INGOT": 1}}
+	a.Equipment.Armor = [4]string{"COPPER_HELMET", "NONE", "NONE", "NONE"}
+
+	HandleEquip(testActionResult, a, protocol.InstantReq{ID: "I1", Type: "EQUIP", ItemID: "IRON_HELMET"}, 1, defs)
+	if a.Equipment.Armor[0] != "IRON_HELMET" {
+		t.Fatalf("armor=%v", a.Equipment.Armor)
+	}
+	if a.Inventory["IRON_HELMET"] != 0 || a.Inventory["COPPER_HELMET"] != 1 {
+		t.Fatalf("inventory=%v", a.Inventory)
+	}
+
+	a.Events = nil
+	HandleEquip(testActionResult, a, protocol.InstantReq{ID: "I2", Type: "EQUIP", ItemID: "IRON_INGOT"}, 2, defs)
+	if len(a.Events) != 1 || a.Events[0]["code"] != "E_BAD_REQUEST" {
+		t.Fatalf("expected E_BAD_REQUEST, got %v", a.Events)
+	}
+}
+
+func TestHandleEquipRejectsWeapons(t *testing.T) {
+	defs := map[string]catalogs.ItemDef{"IRON_SWORD": {ID: "IRON_SWORD", Kind: "TOOL"}}
+	a := &modelpkg.Agent{ID: "A1", Inventory: map[string]int{"IRON_SWORD": 1}}
+
+	HandleEquip(testActionResult, a, protocol.InstantReq{ID: "I1", Type: "EQUIP", ItemID: "IRON_SWORD"}, 1, defs)
+	if len(a.Events) != 1 || a.Events[0]["code"] != "E_BAD_REQUEST" {
+		t.Fatalf("expected E_BAD_REQUEST, got %v", a.Events)
+	}
+	if a.Inventory["IRON_SWORD"] != 1 || a.Equipment.MainHand != "" {
+		t.Fatalf("sword moved: inv=%v hand=%q", a.Inventory, a.Equipment.MainHand)
+	}
+}
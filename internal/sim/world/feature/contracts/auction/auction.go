@@ -0,0 +1,134 @@
+// Package auction holds the pure rules of AUCTION contracts: which bids are accepted,
+// which escrowed bids a new bid releases, and who wins at the deadline.
+package auction
+
+import (
+	"strings"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	// English auctions show the leading bid; each bid must beat it.
+	English = "ENGLISH"
+	// Sealed auctions hide bids until the deadline; each bidder holds one bid.
+	Sealed = "SEALED"
+)
+
+func NormalizeType(t string) string {
+	switch strings.TrimSpace(strings.ToUpper(t)) {
+	case "", English:
+		return English
+	case Sealed:
+		return Sealed
+	default:
+		return ""
+	}
+}
+
+func ValidatePost(requirements map[string]int, auctionType string, priceItem string, reserve int) (ok bool, code string, msg string) {
+	if len(requirements) > 0 {
+		return false, "E_BAD_REQUEST", "auctions take bids, not requirements"
+	}
+	if NormalizeType(auctionType) == "" {
+		return false, "E_BAD_REQUEST", "bad mode"
+	}
+	if strings.TrimSpace(priceItem) == "" {
+		return false, "E_BAD_REQUEST", "missing price_item"
+	}
+	if reserve <= 0 {
+		return false, "E_BAD_REQUEST", "bad reserve price"
+	}
+	return true, "", ""
+}
+
+type BidInput struct {
+	HasContract bool
+	Kind        string
+	State       string
+	Poster      string
+	Bidder      string
+	NowTick     uint64
+	Deadline    uint64
+	Amount      int
+	Reserve     int
+	// MinAmount is the leading bid an ENGLISH bid has to beat (0 for SEALED).
+	MinAmount int
+	HasFunds  bool
+}
+
+func ValidateBid(in BidInput) (ok bool, code string, msg string) {
+	if !in.HasContract || in.Kind != "AUCTION" {
+		return false, "E_INVALID_TARGET", "auction not found"
+	}
+	if in.State != "OPEN" || in.NowTick > in.Deadline {
+		return false, "E_CONFLICT", "auction closed"
+	}
+	if in.Bidder == in.Poster {
+		return false, "E_NO_PERMISSION", "cannot bid on own auction"
+	}
+	if in.Amount < in.Reserve {
+		return false, "E_BAD_REQUEST", "bid below reserve"
+	}
+	if in.Amount <= in.MinAmount {
+		return false, "E_CONFLICT", "bid does not beat the leading bid"
+	}
+	if !in.HasFunds {
+		return false, "E_NO_RESOURCE", "insufficient price_item"
+	}
+	return true, "", ""
+}
+
+// Leading is the bid an ENGLISH bid has to beat, or 0.
+func Leading(c *modelpkg.Contract) int {
+	if c.AuctionType == Sealed {
+		return 0
+	}
+	if w, ok := Winner(c); ok {
+		return w.Amount
+	}
+	return 0
+}
+
+// Place records bid and returns the escrowed bids it releases: every earlier bid in an
+// ENGLISH auction, the bidder's own earlier bid in a SEALED one.
+func Place(c *modelpkg.Contract, bid modelpkg.AuctionBid) (released []modelpkg.AuctionBid) {
+	kept := c.Bids[:0:0]
+	for _, b := range c.Bids {
+		if c.AuctionType == Sealed && b.Bidder != bid.Bidder {
+			kept = append(kept, b)
+			continue
+		}
+		released = append(released, b)
+	}
+	c.Bids = append(kept, bid)
+	return released
+}
+
+// Winner is the highest bid, the earliest on ties.
+func Winner(c *modelpkg.Contract) (modelpkg.AuctionBid, bool) {
+	var best modelpkg.AuctionBid
+	found := false
+	for _, b := range c.Bids {
+		if !found || b.Amount > best.Amount || (b.Amount == best.Amount && b.Tick < best.Tick) {
+			best = b
+			found = true
+		}
+	}
+	return best, found
+}
+
+// Losers are every bid but the winner's, in bid order.
+func Losers(c *modelpkg.Contract) []modelpkg.AuctionBid {
+	w, ok := Winner(c)
+	if !ok {
+		return nil
+	}
+	out := make([]modelpkg.AuctionBid, 0, len(c.Bids))
+	for _, b := range c.Bids {
+		if b != w {
+			out = append(out, b)
+		}
+	}
+	return out
+}
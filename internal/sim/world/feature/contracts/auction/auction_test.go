@@ -0,0 +1,66 @@
+package auction
+
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func TestEnglishBidReleasesPreviousLeader(t *testing.T) {
+	c := &modelpkg.Contract{AuctionType: English}
+	if rel := Place(c, modelpkg.AuctionBid{Bidder: "A1", Amount: 5, Tick: 1}); len(rel) != 0 {
+		t.Fatalf("first bid released %v", rel)
+	}
+	if Leading(c) != 5 {
+		t.Fatalf("leading: %d", Leading(c))
+	}
+	rel := Place(c, modelpkg.AuctionBid{Bidder: "A2", Amount: 7, Tick: 2})
+	if len(rel) != 1 || rel[0].Bidder != "A1" || len(c.Bids) != 1 {
+		t.Fatalf("outbid: released=%v bids=%v", rel, c.Bids)
+	}
+	if w, ok := Winner(c); !ok || w.Bidder != "A2" || len(Losers(c)) != 0 {
+		t.Fatalf("winner: %+v", w)
+	}
+}
+
+func TestSealedKeepsOneBidPerBidderAndEarliestWinsTies(t *testing.T) {
+	c := &modelpkg.Contract{AuctionType: Sealed}
+	Place(c, modelpkg.AuctionBid{Bidder: "A1", Amount: 9, Tick: 3})
+	Place(c, modelpkg.AuctionBid{Bidder: "A2", Amount: 4, Tick: 4})
+	if Leading(c) != 0 {
+		t.Fatalf("sealed auctions should not expose a leading bid")
+	}
+	rel := Place(c, modelpkg.AuctionBid{Bidder: "A2", Amount: 9, Tick: 5})
+	if len(rel) != 1 || rel[0].Amount != 4 || len(c.Bids) != 2 {
+		t.Fatalf("rebid: released=%v bids=%v", rel, c.Bids)
+	}
+	w, _ := Winner(c)
+	if w.Bidder != "A1" {
+		t.Fatalf("tie should go to the earlier bid, got %+v", w)
+	}
+	if l := Losers(c); len(l) != 1 || l[0].Bidder != "A2" {
+		t.Fatalf("losers: %v", l)
+	}
+}
+
+func TestValidateBid(t *testing.T) {
+	in := BidInput{HasContract: true, Kind: "AUCTION", State: "OPEN", Poster: "A1", Bidder: "A2", NowTick: 5, Deadline: 10, Amount: 6, Reserve: 5, MinAmount: 5, HasFunds: true}
+	if ok, code, msg := ValidateBid(in); !ok {
+		t.Fatalf("valid bid rejected: %s %s", code, msg)
+	}
+	low := in
+	low.Amount = 5
+	if ok, code, _ := ValidateBid(low); ok || code != "E_CONFLICT" {
+		t.Fatalf("bid matching the leader should be rejected, got %q", code)
+	}
+	own := in
+	own.Bidder = "A1"
+	if ok, code, _ := ValidateBid(own); ok || code != "E_NO_PERMISSION" {
+		t.Fatalf("poster bid should be rejected, got %q", code)
+	}
+	late := in
+	late.NowTick = 11
+	if ok, _, _ := ValidateBid(late); ok {
+		t.Fatalf("late bid should be rejected")
+	}
+}
@@ -16,6 +16,10 @@ type TickAuditInput struct {
 	Requirements [][]interface{}
 	Reward       [][]interface{}
 	Deposit      [][]interface{}
+	Installment  [][]interface{}
+	Collateral   string
+	PriceItem    string
+	WinningBid   int
 }
 
 type TickAuditOutput struct {
@@ -64,6 +68,16 @@ func BuildSubmitAuditFields(contractID, terminalID, kind, poster, acceptor strin
 	}
 }
 
+func BuildBidAuditFields(contractID, terminalID, auctionType, priceItem string, amount int) map[string]any {
+	return map[string]any{
+		"contract_id":  contractID,
+		"terminal_id":  terminalID,
+		"auction_type": auctionType,
+		"price_item":   priceItem,
+		"amount":       amount,
+	}
+}
+
 func BuildTickAudit(input TickAuditInput) TickAuditOutput {
 	switch input.Decision {
 	case core.DecisionExpireOpen:
@@ -135,6 +149,75 @@ func BuildTickAudit(input TickAuditInput) TickAuditOutput {
 				"deposit":      input.Deposit,
 			},
 		}
+	case core.DecisionLoanInstallment:
+		return TickAuditOutput{
+			ShouldAudit: true,
+			EventType:   "LOAN_PAYMENT",
+			Actor:       input.Acceptor,
+			Reason:      input.AuditReason,
+			Fields: map[string]any{
+				"contract_id": input.ContractID,
+				"kind":        input.Kind,
+				"poster":      input.Poster,
+				"acceptor":    input.Acceptor,
+				"state":       input.State,
+				"installment": input.Installment,
+			},
+		}
+	case core.DecisionLoanRepaid:
+		return TickAuditOutput{
+			ShouldAudit:      true,
+			EventType:        "CONTRACT_COMPLETE",
+			Actor:            input.Acceptor,
+			Reason:           input.AuditReason,
+			GrantTradeCredit: true,
+			Fields: map[string]any{
+				"contract_id":     input.ContractID,
+				"kind":            input.Kind,
+				"poster":          input.Poster,
+				"acceptor":        input.Acceptor,
+				"state":           input.State,
+				"requirements":    input.Requirements,
+				"installment":     input.Installment,
+				"deposit":         input.Deposit,
+				"collateral_land": input.Collateral,
+			},
+		}
+	case core.DecisionLoanDefault:
+		return TickAuditOutput{
+			ShouldAudit: true,
+			EventType:   "CONTRACT_FAIL",
+			Actor:       "WORLD",
+			Reason:      input.AuditReason,
+			Fields: map[string]any{
+				"contract_id":     input.ContractID,
+				"kind":            input.Kind,
+				"poster":          input.Poster,
+				"acceptor":        input.Acceptor,
+				"state":           input.State,
+				"installment":     input.Installment,
+				"deposit":         input.Deposit,
+				"collateral_land": input.Collateral,
+			},
+		}
+	case core.DecisionAuctionSettle:
+		return TickAuditOutput{
+			ShouldAudit:      true,
+			EventType:        "CONTRACT_COMPLETE",
+			Actor:            input.Acceptor,
+			Reason:           input.AuditReason,
+			GrantTradeCredit: true,
+			Fields: map[string]any{
+				"contract_id": input.ContractID,
+				"kind":        input.Kind,
+				"poster":      input.Poster,
+				"acceptor":    input.Acceptor,
+				"state":       input.State,
+				"reward":      input.Reward,
+				"price_item":  input.PriceItem,
+				"winning_bid": input.WinningBid,
+			},
+		}
 	default:
 		return TickAuditOutput{}
 	}
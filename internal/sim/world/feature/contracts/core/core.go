@@ -12,12 +12,23 @@ type SummaryInput struct {
 	Poster       string
 	Acceptor     string
 	DeadlineTick uint64
+	// LOAN only.
+	InterestPct    int
+	NextDueTick    uint64
+	Outstanding    map[string]int
+	CollateralLand string
+	// AUCTION only. HighBid is left 0 for SEALED auctions.
+	AuctionType  string
+	PriceItem    string
+	ReservePrice int
+	HighBid      int
+	BidCount     int
 }
 
 func NormalizeKind(k string) string {
 	k = strings.TrimSpace(strings.ToUpper(k))
 	switch k {
-	case "GATHER", "DELIVER", "BUILD":
+	case "GATHER", "DELIVER", "BUILD", "LOAN", "AUCTION":
 		return k
 	default:
 		return ""
@@ -33,14 +44,32 @@ func BuildSummaries(in []SummaryInput) []map[string]interface{} {
 	})
 	out := make([]map[string]interface{}, 0, len(in))
 	for _, c := range in {
-		out = append(out, map[string]interface{}{
+		m := map[string]interface{}{
 			"contract_id":   c.ContractID,
 			"state":         c.State,
 			"kind":          c.Kind,
 			"poster":        c.Poster,
 			"acceptor":      c.Acceptor,
 			"deadline_tick": c.DeadlineTick,
-		})
+		}
+		if c.Kind == "LOAN" {
+			m["interest_pct"] = c.InterestPct
+			m["next_due_tick"] = c.NextDueTick
+			m["outstanding"] = c.Outstanding
+			if c.CollateralLand != "" {
+				m["collateral_land"] = c.CollateralLand
+			}
+		}
+		if c.Kind == "AUCTION" {
+			m["auction_type"] = c.AuctionType
+			m["price_item"] = c.PriceItem
+			m["reserve_price"] = c.ReservePrice
+			m["bids"] = c.BidCount
+			if c.HighBid > 0 {
+				m["high_bid"] = c.HighBid
+			}
+		}
+		out = append(out, m)
 	}
 	return out
 }
@@ -54,6 +83,10 @@ const (
 	DecisionTimeoutAccepted   TickDecision = "TIMEOUT_ACCEPTED"
 	DecisionCompleteDeliver   TickDecision = "COMPLETE_DELIVER"
 	DecisionCompleteBuild     TickDecision = "COMPLETE_BUILD"
+	DecisionLoanInstallment   TickDecision = "LOAN_INSTALLMENT"
+	DecisionLoanRepaid        TickDecision = "LOAN_REPAID"
+	DecisionLoanDefault       TickDecision = "LOAN_DEFAULT"
+	DecisionAuctionSettle     TickDecision = "AUCTION_SETTLE"
 )
 
 type TickInput struct {
@@ -65,6 +98,12 @@ type TickInput struct {
 	RequirementsOK bool
 	BuildPlaced    bool
 	BuildStable    bool
+	// LOAN: the next unpaid installment is due, payable from the terminal, and the last.
+	LoanDue    bool
+	LoanCanPay bool
+	LoanFinal  bool
+	// AUCTION: at least one bid is escrowed.
+	AuctionHasBid bool
 }
 
 func DecideTick(in TickInput) TickDecision {
@@ -76,10 +115,25 @@ func DecideTick(in TickInput) TickDecision {
 	}
 	if in.State == "OPEN" {
 		if in.NowTick > in.DeadlineTick {
+			if in.Kind == "AUCTION" && in.AuctionHasBid {
+				return DecisionAuctionSettle
+			}
 			return DecisionExpireOpen
 		}
 		return DecisionNoop
 	}
+	if in.Kind == "LOAN" {
+		switch {
+		case !in.LoanDue:
+			return DecisionNoop
+		case !in.LoanCanPay:
+			return DecisionLoanDefault
+		case in.LoanFinal:
+			return DecisionLoanRepaid
+		default:
+			return DecisionLoanInstallment
+		}
+	}
 	if in.NowTick > in.DeadlineTick {
 		return DecisionTimeoutAccepted
 	}
@@ -112,6 +166,10 @@ type SettlementPlan struct {
 	RewardTo             PayoutTarget
 	DepositTo            PayoutTarget
 	PenalizeAcceptorLaw  bool
+	PayInstallment       bool
+	ForfeitLand          bool
+	PayWinningBid        bool
+	RefundLosingBids     bool
 	AuditReason          string
 }
 
@@ -149,6 +207,34 @@ func PlanSettlement(decision TickDecision) SettlementPlan {
 			DepositTo:     PayoutAcceptor,
 			AuditReason:   "AUTO_COMPLETE",
 		}
+	case DecisionLoanInstallment:
+		return SettlementPlan{
+			PayInstallment: true,
+			AuditReason:    "LOAN_INSTALLMENT",
+		}
+	case DecisionLoanRepaid:
+		return SettlementPlan{
+			MarkCompleted:  true,
+			PayInstallment: true,
+			DepositTo:      PayoutAcceptor,
+			AuditReason:    "LOAN_REPAID",
+		}
+	case DecisionLoanDefault:
+		return SettlementPlan{
+			MarkFailed:          true,
+			DepositTo:           PayoutPoster,
+			ForfeitLand:         true,
+			PenalizeAcceptorLaw: true,
+			AuditReason:         "LOAN_DEFAULT",
+		}
+	case DecisionAuctionSettle:
+		return SettlementPlan{
+			MarkCompleted:    true,
+			RewardTo:         PayoutAcceptor,
+			PayWinningBid:    true,
+			RefundLosingBids: true,
+			AuditReason:      "AUCTION_SETTLED",
+		}
 	default:
 		return SettlementPlan{}
 	}
@@ -43,6 +43,24 @@ func TestDecideTick(t *testing.T) {
 	if got := DecideTick(TickInput{State: "ACCEPTED", Kind: "BUILD", HasTerminal: true, BuildPlaced: true, BuildStable: false}); got != DecisionNoop {
 		t.Fatalf("expected noop, got %s", got)
 	}
+	if got := DecideTick(TickInput{State: "ACCEPTED", Kind: "LOAN", HasTerminal: true, LoanDue: true, LoanCanPay: true}); got != DecisionLoanInstallment {
+		t.Fatalf("expected loan installment, got %s", got)
+	}
+	if got := DecideTick(TickInput{State: "ACCEPTED", Kind: "LOAN", HasTerminal: true, LoanDue: true, LoanCanPay: true, LoanFinal: true}); got != DecisionLoanRepaid {
+		t.Fatalf("expected loan repaid, got %s", got)
+	}
+	if got := DecideTick(TickInput{State: "ACCEPTED", Kind: "LOAN", HasTerminal: true, LoanDue: true}); got != DecisionLoanDefault {
+		t.Fatalf("expected loan default, got %s", got)
+	}
+	if got := DecideTick(TickInput{State: "ACCEPTED", Kind: "LOAN", HasTerminal: true, NowTick: 11, DeadlineTick: 10}); got != DecisionNoop {
+		t.Fatalf("expected loan noop before due, got %s", got)
+	}
+	if got := DecideTick(TickInput{State: "OPEN", Kind: "AUCTION", HasTerminal: true, NowTick: 11, DeadlineTick: 10, AuctionHasBid: true}); got != DecisionAuctionSettle {
+		t.Fatalf("expected auction settle, got %s", got)
+	}
+	if got := DecideTick(TickInput{State: "OPEN", Kind: "AUCTION", HasTerminal: true, NowTick: 11, DeadlineTick: 10}); got != DecisionExpireOpen {
+		t.Fatalf("expected unsold auction to expire, got %s", got)
+	}
 }
 
 func TestPlanSettlement(t *testing.T) {
@@ -61,4 +79,7 @@ func TestPlanSettlement(t *testing.T) {
 	if p := PlanSettlement(DecisionCompleteBuild); !p.MarkCompleted || p.DepositTo != PayoutAcceptor {
 		t.Fatalf("unexpected complete build plan: %+v", p)
 	}
+	if p := PlanSettlement(DecisionLoanDefault); !p.MarkFailed || p.RewardTo != PayoutNone || p.DepositTo != PayoutPoster || !p.ForfeitLand {
+		t.Fatalf("unexpected loan default plan: %+v", p)
+	}
 }
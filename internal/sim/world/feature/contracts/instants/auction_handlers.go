@@ -0,0 +1,101 @@
+package instants
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	auctionpkg "voxelcraft.ai/internal/sim/world/feature/contracts/auction"
+	validationpkg "voxelcraft.ai/internal/sim/world/feature/contracts/validation"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type AuctionBidHooks struct {
+	OnBid func(AuctionBidOutcome)
+}
+
+type AuctionBidOutcome struct {
+	Contract *modelpkg.Contract
+	Terminal *modelpkg.Container
+	Bid      modelpkg.AuctionBid
+	// Released are the escrowed bids the new bid replaced, now owed back to their
+	// bidders at the terminal.
+	Released []modelpkg.AuctionBid
+}
+
+// HandleBidAuction escrows a bid of inst.Price price_item on an AUCTION contract
+// (BID_AUCTION: contract_id, terminal_id, price).
+func HandleBidAuction(env ContractLifecycleEnv, ar ActionResultFn, hooks AuctionBidHooks, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64) {
+	if env == nil {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INTERNAL", "contract env unavailable"))
+		return
+	}
+	if ok, code, msg := validationpkg.ValidateLifecycleIDs(inst.ContractID, inst.TerminalID); !ok {
+		a.AddEvent(ar(nowTick, inst.ID, false, code, msg))
+		return
+	}
+	c := env.GetContract(inst.ContractID)
+	term := env.GetContainerByID(inst.TerminalID)
+	if c != nil {
+		ctx := TerminalContext{}
+		if term != nil {
+			ctx = BuildTerminalContext(
+				true,
+				term.Type,
+				Vec3{X: term.Pos.X, Y: term.Pos.Y, Z: term.Pos.Z},
+				Vec3{X: c.TerminalPos.X, Y: c.TerminalPos.Y, Z: c.TerminalPos.Z},
+				Vec3{X: a.Pos.X, Y: a.Pos.Y, Z: a.Pos.Z},
+			)
+		}
+		if ctx.Type != "CONTRACT_TERMINAL" || !ctx.Matches {
+			a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "terminal mismatch"))
+			return
+		}
+		if ctx.Distance > 3 {
+			a.AddEvent(ar(nowTick, inst.ID, false, "E_BLOCKED", "too far"))
+			return
+		}
+	}
+	in := auctionpkg.BidInput{HasContract: c != nil, Bidder: a.ID, NowTick: nowTick, Amount: inst.Price}
+	if c != nil {
+		in.Kind = c.Kind
+		in.State = string(c.State)
+		in.Poster = c.Poster
+		in.Deadline = c.DeadlineTick
+		in.Reserve = c.ReservePrice
+		in.MinAmount = auctionpkg.Leading(c)
+		in.HasFunds = inst.Price > 0 && a.Inventory[c.PriceItem] >= inst.Price
+	}
+	if ok, code, msg := auctionpkg.ValidateBid(in); !ok {
+		a.AddEvent(ar(nowTick, inst.ID, false, code, msg))
+		return
+	}
+
+	a.Inventory[c.PriceItem] -= inst.Price
+	if a.Inventory[c.PriceItem] <= 0 {
+		delete(a.Inventory, c.PriceItem)
+	}
+	term.Inventory[c.PriceItem] += inst.Price
+	term.Reserve(c.PriceItem, inst.Price)
+
+	bid := modelpkg.AuctionBid{Bidder: a.ID, Amount: inst.Price, Tick: nowTick}
+	released := auctionpkg.Place(c, bid)
+	for _, b := range released {
+		ReleaseBid(term, c.PriceItem, b)
+	}
+	if hooks.OnBid != nil {
+		hooks.OnBid(AuctionBidOutcome{Contract: c, Terminal: term, Bid: bid, Released: released})
+	}
+	a.AddEvent(ar(nowTick, inst.ID, true, "", "bid placed"))
+}
+
+// ReleaseBid takes an escrowed bid out of the terminal and records it as owed to the
+// bidder, who collects it with CLAIM_OWED.
+func ReleaseBid(term *modelpkg.Container, priceItem string, b modelpkg.AuctionBid) {
+	if term == nil || b.Amount <= 0 {
+		return
+	}
+	term.Unreserve(priceItem, b.Amount)
+	term.Inventory[priceItem] -= b.Amount
+	if term.Inventory[priceItem] <= 0 {
+		delete(term.Inventory, priceItem)
+	}
+	term.AddOwed(b.Bidder, priceItem, b.Amount)
+}
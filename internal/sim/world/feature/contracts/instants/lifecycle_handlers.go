@@ -2,7 +2,9 @@ package instants
 
 import (
 	"voxelcraft.ai/internal/protocol"
+	auctionpkg "voxelcraft.ai/internal/sim/world/feature/contracts/auction"
 	lifecyclepkg "voxelcraft.ai/internal/sim/world/feature/contracts/lifecycle"
+	loanpkg "voxelcraft.ai/internal/sim/world/feature/contracts/loan"
 	reppkg "voxelcraft.ai/internal/sim/world/feature/contracts/reputation"
 	runtimepkg "voxelcraft.ai/internal/sim/world/feature/contracts/runtime"
 	validationpkg "voxelcraft.ai/internal/sim/world/feature/contracts/validation"
@@ -18,6 +20,8 @@ type ContractLifecycleEnv interface {
 	GetContract(contractID string) *modelpkg.Contract
 	RepDepositMultiplier(a *modelpkg.Agent) int
 	CheckBuildContract(c *modelpkg.Contract) bool
+	GetLand(landID string) *modelpkg.LandClaim
+	LandPledged(landID string) bool
 }
 
 type ContractLifecycleHooks struct {
@@ -70,6 +74,11 @@ func HandlePostContract(env ContractLifecycleEnv, ar ActionResultFn, hooks Contr
 		DeadlineTick:    inst.DeadlineTick,
 		DurationTicks:   inst.DurationTicks,
 		DayTicks:        dayTicks,
+		InterestPct:     inst.InterestPct,
+		Installments:    inst.Installments,
+		AuctionType:     inst.Mode,
+		PriceItem:       inst.PriceItem,
+		ReservePrice:    inst.Price,
 	})
 	if ok, code, msg := validationpkg.ValidatePost(prep.Validation); !ok {
 		a.AddEvent(ar(nowTick, inst.ID, false, code, msg))
@@ -77,6 +86,7 @@ func HandlePostContract(env ContractLifecycleEnv, ar ActionResultFn, hooks Contr
 	}
 	kind := prep.ResolvedKind
 	deadline := prep.Deadline
+	req = prep.Requirements
 
 	for item, n := range reward {
 		a.Inventory[item] -= n
@@ -102,6 +112,16 @@ func HandlePostContract(env ContractLifecycleEnv, ar ActionResultFn, hooks Contr
 		c.Anchor = modelpkg.Vec3i{X: inst.Anchor[0], Y: inst.Anchor[1], Z: inst.Anchor[2]}
 		c.Rotation = blueprint.NormalizeRotation(inst.Rotation)
 	}
+	if kind == "LOAN" {
+		c.InterestPct = inst.InterestPct
+		c.Installments = loanpkg.Schedule(req, inst.Installments, 0, deadline-nowTick)
+	}
+	if kind == "AUCTION" {
+		c.AuctionType = auctionpkg.NormalizeType(inst.Mode)
+		c.PriceItem = inst.PriceItem
+		c.ReservePrice = inst.Price
+		c.Deposit = nil
+	}
 	env.PutContract(c)
 	if hooks.OnPosted != nil {
 		hooks.OnPosted(ContractPostOutcome{Contract: c, Terminal: term})
@@ -121,12 +141,14 @@ func HandleAcceptContract(env ContractLifecycleEnv, ar ActionResultFn, hooks Con
 	c := env.GetContract(inst.ContractID)
 	term := env.GetContainerByID(inst.TerminalID)
 	state := ""
+	kind := ""
 	deadline := uint64(0)
 	distance := 0
 	terminalType := ""
 	terminalMatch := false
 	if c != nil {
 		state = string(c.State)
+		kind = c.Kind
 		deadline = c.DeadlineTick
 		if term != nil {
 			ctx := BuildTerminalContext(
@@ -143,6 +165,7 @@ func HandleAcceptContract(env ContractLifecycleEnv, ar ActionResultFn, hooks Con
 	}
 	prep := validationpkg.PrepareAccept(validationpkg.AcceptPrepInput{
 		HasContract:     c != nil,
+		Kind:            kind,
 		State:           state,
 		TerminalType:    terminalType,
 		TerminalMatches: terminalMatch,
@@ -162,6 +185,19 @@ func HandleAcceptContract(env ContractLifecycleEnv, ar ActionResultFn, hooks Con
 		a.AddEvent(ar(nowTick, inst.ID, false, code, msg))
 		return
 	}
+	// A loan without collateral items is secured by land the borrower owns.
+	pledgeLand := c.Kind == "LOAN" && len(prep.RequiredDeposit) == 0
+	if pledgeLand {
+		land := env.GetLand(inst.LandID)
+		owner := ""
+		if land != nil {
+			owner = land.Owner
+		}
+		if ok, code, msg := loanpkg.ValidateLandCollateral(inst.LandID, land != nil, owner, a.ID, env.LandPledged(inst.LandID)); !ok {
+			a.AddEvent(ar(nowTick, inst.ID, false, code, msg))
+			return
+		}
+	}
 
 	for item, n := range prep.RequiredDeposit {
 		a.Inventory[item] -= n
@@ -171,6 +207,16 @@ func HandleAcceptContract(env ContractLifecycleEnv, ar ActionResultFn, hooks Con
 	c.Deposit = prep.RequiredDeposit
 	c.Acceptor = a.ID
 	c.State = modelpkg.ContractAccepted
+	if c.Kind == "LOAN" {
+		if pledgeLand {
+			c.CollateralLand = inst.LandID
+		}
+		// The principal goes to the borrower now; the schedule starts running.
+		runtimepkg.PayoutItems(term.Inventory, c.Reward, term.Unreserve, func(item string, n int) {
+			a.Inventory[item] += n
+		})
+		c.DeadlineTick = loanpkg.Activate(c, nowTick)
+	}
 	if hooks.OnAccepted != nil {
 		hooks.OnAccepted(ContractAcceptOutcome{Contract: c, Terminal: term})
 	}
@@ -220,6 +266,8 @@ func HandleSubmitContract(env ContractLifecycleEnv, ar ActionResultFn, hooks Con
 				requirementsOK = reppkg.HasAvailable(c.Requirements, term.AvailableCount)
 			case "BUILD":
 				buildOK = env.CheckBuildContract(c)
+			case "LOAN":
+				requirementsOK = reppkg.HasAvailable(loanpkg.Outstanding(c), term.AvailableCount)
 			}
 		}
 	}
@@ -241,14 +289,23 @@ func HandleSubmitContract(env ContractLifecycleEnv, ar ActionResultFn, hooks Con
 		return
 	}
 
-	if lifecyclepkg.NeedsRequirementsConsumption(c.Kind) {
-		runtimepkg.ConsumeRequirementsToPoster(term.Inventory, c.Requirements, func(item string, n int) {
+	if c.Kind == "LOAN" {
+		// Early repayment: everything still outstanding goes to the lender at once and
+		// the collateral comes back; the principal was paid out on acceptance.
+		runtimepkg.ConsumeRequirementsToPoster(term.Inventory, loanpkg.Outstanding(c), func(item string, n int) {
 			term.AddOwed(c.Poster, item, n)
 		})
+		loanpkg.Settle(c)
+	} else {
+		if lifecyclepkg.NeedsRequirementsConsumption(c.Kind) {
+			runtimepkg.ConsumeRequirementsToPoster(term.Inventory, c.Requirements, func(item string, n int) {
+				term.AddOwed(c.Poster, item, n)
+			})
+		}
+		runtimepkg.PayoutItems(term.Inventory, c.Reward, term.Unreserve, func(item string, n int) {
+			a.Inventory[item] += n
+		})
 	}
-	runtimepkg.PayoutItems(term.Inventory, c.Reward, term.Unreserve, func(item string, n int) {
-		a.Inventory[item] += n
-	})
 	runtimepkg.PayoutItems(term.Inventory, c.Deposit, term.Unreserve, func(item string, n int) {
 		a.Inventory[item] += n
 	})
@@ -18,7 +18,7 @@ func ValidatePostInput(kind string, requirements map[string]int, reward map[stri
 	if len(reward) == 0 {
 		return false, "E_BAD_REQUEST", "missing reward"
 	}
-	if kind != "BUILD" && len(requirements) == 0 {
+	if (kind == "GATHER" || kind == "DELIVER") && len(requirements) == 0 {
 		return false, "E_BAD_REQUEST", "missing requirements"
 	}
 	return true, "", ""
@@ -50,7 +50,7 @@ func ScaleDeposit(base map[string]int, multiplier int) map[string]int {
 
 func CanSubmit(kind string, requirementsOK bool, buildOK bool) bool {
 	switch kind {
-	case "GATHER", "DELIVER":
+	case "GATHER", "DELIVER", "LOAN":
 		return requirementsOK
 	case "BUILD":
 		return buildOK
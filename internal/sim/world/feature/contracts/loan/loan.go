@@ -0,0 +1,150 @@
+// Package loan holds the pure rules of LOAN contracts: the repayment owed for a
+// principal, its installment schedule, and which installment falls due next.
+package loan
+
+import (
+	"sort"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	// MaxInterestPct caps the interest a lender may charge over the whole term.
+	MaxInterestPct = 100
+	// MaxInstallments caps how many repayments a loan is split into.
+	MaxInstallments = 12
+)
+
+func ValidatePost(requirements map[string]int, interestPct int, installments int, term uint64) (ok bool, code string, msg string) {
+	if len(requirements) > 0 {
+		return false, "E_BAD_REQUEST", "loan requirements are derived from interest_pct"
+	}
+	if interestPct < 0 || interestPct > MaxInterestPct {
+		return false, "E_BAD_REQUEST", "bad interest_pct"
+	}
+	if installments < 0 || installments > MaxInstallments {
+		return false, "E_BAD_REQUEST", "bad installments"
+	}
+	if term < uint64(NormalizeInstallments(installments)) {
+		return false, "E_BAD_REQUEST", "loan term too short"
+	}
+	return true, "", ""
+}
+
+func NormalizeInstallments(n int) int {
+	if n <= 0 {
+		return 1
+	}
+	return n
+}
+
+// Repayment is the principal plus interestPct percent of every item, rounded up.
+func Repayment(principal map[string]int, interestPct int) map[string]int {
+	if len(principal) == 0 {
+		return nil
+	}
+	out := make(map[string]int, len(principal))
+	for item, n := range principal {
+		if item == "" || n <= 0 {
+			continue
+		}
+		out[item] = (n*(100+interestPct) + 99) / 100
+	}
+	return out
+}
+
+// Schedule splits repayment into n installments due evenly over term ticks after
+// start. Each item is split evenly and the remainder falls on the last installment.
+func Schedule(repayment map[string]int, n int, start uint64, term uint64) []modelpkg.LoanInstallment {
+	n = NormalizeInstallments(n)
+	items := make([]string, 0, len(repayment))
+	for item := range repayment {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	out := make([]modelpkg.LoanInstallment, n)
+	for i := range out {
+		out[i].DueTick = start + term*uint64(i+1)/uint64(n)
+		out[i].Items = map[string]int{}
+		for _, item := range items {
+			per := repayment[item] / n
+			if i == n-1 {
+				per += repayment[item] % n
+			}
+			if per > 0 {
+				out[i].Items[item] = per
+			}
+		}
+	}
+	return out
+}
+
+// Activate fixes an OPEN loan's schedule, whose due ticks are offsets, to absolute ticks
+// from acceptance at nowTick, and returns the final due tick.
+func Activate(c *modelpkg.Contract, nowTick uint64) uint64 {
+	last := nowTick
+	for i := range c.Installments {
+		c.Installments[i].DueTick += nowTick
+		last = c.Installments[i].DueTick
+	}
+	return last
+}
+
+// Next is the index of the first unpaid installment, or -1 when the loan is repaid.
+func Next(c *modelpkg.Contract) int {
+	for i, inst := range c.Installments {
+		if !inst.Paid {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsFinal reports whether installment i is the last one unpaid.
+func IsFinal(c *modelpkg.Contract, i int) bool {
+	for j := i + 1; j < len(c.Installments); j++ {
+		if !c.Installments[j].Paid {
+			return false
+		}
+	}
+	return true
+}
+
+// Outstanding sums the items of every unpaid installment.
+func Outstanding(c *modelpkg.Contract) map[string]int {
+	out := map[string]int{}
+	for _, inst := range c.Installments {
+		if inst.Paid {
+			continue
+		}
+		for item, n := range inst.Items {
+			out[item] += n
+		}
+	}
+	return out
+}
+
+// Settle marks every installment paid (early repayment in full).
+func Settle(c *modelpkg.Contract) {
+	for i := range c.Installments {
+		c.Installments[i].Paid = true
+	}
+}
+
+// ValidateLandCollateral checks the land a borrower pledges when the lender asked for
+// no collateral items.
+func ValidateLandCollateral(landID string, hasLand bool, owner string, borrower string, pledged bool) (ok bool, code string, msg string) {
+	if landID == "" {
+		return false, "E_BAD_REQUEST", "missing land_id collateral"
+	}
+	if !hasLand {
+		return false, "E_INVALID_TARGET", "land not found"
+	}
+	if owner != borrower {
+		return false, "E_NO_PERMISSION", "not land owner"
+	}
+	if pledged {
+		return false, "E_CONFLICT", "land already pledged"
+	}
+	return true, "", ""
+}
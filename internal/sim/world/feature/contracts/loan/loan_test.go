@@ -0,0 +1,63 @@
+package loan
+
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func TestRepaymentRoundsInterestUp(t *testing.T) {
+	got := Repayment(map[string]int{"IRON_INGOT": 10, "COAL": 3}, 15)
+	if got["IRON_INGOT"] != 12 || got["COAL"] != 4 {
+		t.Fatalf("repayment: %v", got)
+	}
+}
+
+func TestScheduleSplitsEvenlyWithRemainderLast(t *testing.T) {
+	s := Schedule(map[string]int{"IRON_INGOT": 10}, 3, 100, 90)
+	if len(s) != 3 {
+		t.Fatalf("installments: %d", len(s))
+	}
+	if s[0].DueTick != 130 || s[1].DueTick != 160 || s[2].DueTick != 190 {
+		t.Fatalf("due ticks: %+v", s)
+	}
+	if s[0].Items["IRON_INGOT"] != 3 || s[1].Items["IRON_INGOT"] != 3 || s[2].Items["IRON_INGOT"] != 4 {
+		t.Fatalf("split: %+v", s)
+	}
+}
+
+func TestNextOutstandingAndActivate(t *testing.T) {
+	c := &modelpkg.Contract{Installments: Schedule(map[string]int{"COAL": 4}, 2, 0, 50)}
+	if last := Activate(c, 1000); last != 1050 || c.Installments[0].DueTick != 1025 {
+		t.Fatalf("activate: last=%d %+v", last, c.Installments)
+	}
+	if i := Next(c); i != 0 || IsFinal(c, i) {
+		t.Fatalf("next: %d", i)
+	}
+	c.Installments[0].Paid = true
+	if i := Next(c); i != 1 || !IsFinal(c, i) {
+		t.Fatalf("next after payment: %d", i)
+	}
+	if got := Outstanding(c); got["COAL"] != 2 {
+		t.Fatalf("outstanding: %v", got)
+	}
+	Settle(c)
+	if Next(c) != -1 || len(Outstanding(c)) != 0 {
+		t.Fatalf("settle: %+v", c.Installments)
+	}
+}
+
+func TestValidatePost(t *testing.T) {
+	if ok, _, _ := ValidatePost(map[string]int{"COAL": 1}, 10, 1, 100); ok {
+		t.Fatalf("explicit requirements should be rejected")
+	}
+	if ok, _, _ := ValidatePost(nil, MaxInterestPct+1, 1, 100); ok {
+		t.Fatalf("interest above cap should be rejected")
+	}
+	if ok, _, _ := ValidatePost(nil, 10, 5, 3); ok {
+		t.Fatalf("term shorter than installments should be rejected")
+	}
+	if ok, code, msg := ValidatePost(nil, 10, 0, 100); !ok {
+		t.Fatalf("valid loan rejected: %s %s", code, msg)
+	}
+}
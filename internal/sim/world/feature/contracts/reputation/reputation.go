@@ -33,6 +33,8 @@ func LawPenaltyDelta(reason string) (trade int, law int) {
 	switch reason {
 	case "CONTRACT_TIMEOUT":
 		return -12, -8
+	case "LOAN_DEFAULT":
+		return -20, -8
 	default:
 		return 0, -4
 	}
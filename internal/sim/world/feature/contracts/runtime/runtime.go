@@ -10,6 +10,17 @@ type SummaryContract struct {
 	Poster       string
 	Acceptor     string
 	DeadlineTick uint64
+
+	InterestPct    int
+	NextDueTick    uint64
+	Outstanding    map[string]int
+	CollateralLand string
+
+	AuctionType  string
+	PriceItem    string
+	ReservePrice int
+	HighBid      int
+	BidCount     int
 }
 
 func BuildTerminalSummaries(terminalPos [3]int, contracts []SummaryContract) []map[string]interface{} {
@@ -25,6 +36,17 @@ func BuildTerminalSummaries(terminalPos [3]int, contracts []SummaryContract) []m
 			Poster:       c.Poster,
 			Acceptor:     c.Acceptor,
 			DeadlineTick: c.DeadlineTick,
+
+			InterestPct:    c.InterestPct,
+			NextDueTick:    c.NextDueTick,
+			Outstanding:    c.Outstanding,
+			CollateralLand: c.CollateralLand,
+
+			AuctionType:  c.AuctionType,
+			PriceItem:    c.PriceItem,
+			ReservePrice: c.ReservePrice,
+			HighBid:      c.HighBid,
+			BidCount:     c.BidCount,
 		})
 	}
 	return corepkg.BuildSummaries(entries)
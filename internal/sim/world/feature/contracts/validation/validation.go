@@ -3,8 +3,10 @@ package validation
 import (
 	"strings"
 
+	auctionpkg "voxelcraft.ai/internal/sim/world/feature/contracts/auction"
 	"voxelcraft.ai/internal/sim/world/feature/contracts/core"
 	lc "voxelcraft.ai/internal/sim/world/feature/contracts/lifecycle"
+	loanpkg "voxelcraft.ai/internal/sim/world/feature/contracts/loan"
 )
 
 type PostValidationInput struct {
@@ -17,6 +19,14 @@ type PostValidationInput struct {
 	IsBuild         bool
 	BlueprintID     string
 	HasEnoughReward bool
+	IsLoan          bool
+	InterestPct     int
+	Installments    int
+	LoanTerm        uint64
+	IsAuction       bool
+	AuctionType     string
+	PriceItem       string
+	ReservePrice    int
 }
 
 func ValidatePost(in PostValidationInput) (ok bool, code string, msg string) {
@@ -35,6 +45,16 @@ func ValidatePost(in PostValidationInput) (ok bool, code string, msg string) {
 	if ok, code, msg := lc.ValidatePostInput(in.Kind, in.Requirements, in.Reward); !ok {
 		return false, code, msg
 	}
+	if in.IsLoan {
+		if ok, code, msg := loanpkg.ValidatePost(in.Requirements, in.InterestPct, in.Installments, in.LoanTerm); !ok {
+			return false, code, msg
+		}
+	}
+	if in.IsAuction {
+		if ok, code, msg := auctionpkg.ValidatePost(in.Requirements, in.AuctionType, in.PriceItem, in.ReservePrice); !ok {
+			return false, code, msg
+		}
+	}
 	if in.IsBuild && in.BlueprintID == "" {
 		return false, "E_BAD_REQUEST", "missing blueprint_id"
 	}
@@ -46,6 +66,7 @@ func ValidatePost(in PostValidationInput) (ok bool, code string, msg string) {
 
 type AcceptValidationInput struct {
 	HasContract      bool
+	IsAuction        bool
 	State            string
 	TerminalMatch    bool
 	Distance         int
@@ -58,6 +79,9 @@ func ValidateAccept(in AcceptValidationInput) (ok bool, code string, msg string)
 	if !in.HasContract {
 		return false, "E_INVALID_TARGET", "contract not found"
 	}
+	if in.IsAuction {
+		return false, "E_CONFLICT", "auctions take bids (BID_AUCTION)"
+	}
 	if in.State != "OPEN" {
 		return false, "E_CONFLICT", "contract not open"
 	}
@@ -122,16 +146,32 @@ type PostPrepInput struct {
 	DeadlineTick    uint64
 	DurationTicks   int
 	DayTicks        int
+	InterestPct     int
+	Installments    int
+	AuctionType     string
+	PriceItem       string
+	ReservePrice    int
 }
 
 type PostPrepResult struct {
 	Validation   PostValidationInput
 	ResolvedKind string
 	Deadline     uint64
+	// Requirements are the posted requirements, or a loan's total repayment.
+	Requirements map[string]int
 }
 
 func PreparePost(in PostPrepInput) PostPrepResult {
 	kind := core.NormalizeKind(in.Kind)
+	deadline := lc.BuildDeadline(in.NowTick, in.DeadlineTick, in.DurationTicks, in.DayTicks)
+	requirements := in.Requirements
+	term := uint64(0)
+	if kind == "LOAN" {
+		requirements = loanpkg.Repayment(in.Reward, in.InterestPct)
+		if deadline > in.NowTick {
+			term = deadline - in.NowTick
+		}
+	}
 	return PostPrepResult{
 		Validation: PostValidationInput{
 			TerminalID:      in.TerminalID,
@@ -143,9 +183,18 @@ func PreparePost(in PostPrepInput) PostPrepResult {
 			IsBuild:         kind == "BUILD",
 			BlueprintID:     in.BlueprintID,
 			HasEnoughReward: in.HasEnoughReward,
+			IsLoan:          kind == "LOAN",
+			InterestPct:     in.InterestPct,
+			Installments:    in.Installments,
+			LoanTerm:        term,
+			IsAuction:       kind == "AUCTION",
+			AuctionType:     in.AuctionType,
+			PriceItem:       in.PriceItem,
+			ReservePrice:    in.ReservePrice,
 		},
 		ResolvedKind: kind,
-		Deadline:     lc.BuildDeadline(in.NowTick, in.DeadlineTick, in.DurationTicks, in.DayTicks),
+		Deadline:     deadline,
+		Requirements: requirements,
 	}
 }
 
@@ -158,6 +207,7 @@ func ValidateLifecycleIDs(contractID, terminalID string) (ok bool, code string,
 
 type AcceptPrepInput struct {
 	HasContract     bool
+	Kind            string
 	State           string
 	TerminalType    string
 	TerminalMatches bool
@@ -189,6 +239,7 @@ func PrepareAccept(in AcceptPrepInput) AcceptPrepResult {
 	return AcceptPrepResult{
 		Validation: AcceptValidationInput{
 			HasContract:      in.HasContract,
+			IsAuction:        in.Kind == "AUCTION",
 			State:            in.State,
 			TerminalMatch:    in.TerminalType == "CONTRACT_TERMINAL" && in.TerminalMatches,
 			Distance:         in.Distance,
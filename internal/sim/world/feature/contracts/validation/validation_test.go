@@ -113,6 +113,31 @@ func TestPreparePost(t *testing.T) {
 	}
 }
 
+func TestPreparePostLoanDerivesRepayment(t *testing.T) {
+	out := PreparePost(PostPrepInput{
+		TerminalID:      "CONTRACT_TERMINAL@1,0,1",
+		TerminalType:    "CONTRACT_TERMINAL",
+		Distance:        1,
+		Kind:            "loan",
+		Reward:          map[string]int{"IRON_INGOT": 10},
+		HasEnoughReward: true,
+		NowTick:         10,
+		DurationTicks:   300,
+		InterestPct:     20,
+		Installments:    3,
+	})
+	if out.Requirements["IRON_INGOT"] != 12 || out.Validation.LoanTerm != 300 {
+		t.Fatalf("unexpected loan prep: %+v", out)
+	}
+	if ok, code, msg := ValidatePost(out.Validation); !ok {
+		t.Fatalf("expected valid loan post: %s %s", code, msg)
+	}
+	out.Validation.Requirements = map[string]int{"COAL": 1}
+	if ok, _, _ := ValidatePost(out.Validation); ok {
+		t.Fatalf("expected explicit loan requirements to be rejected")
+	}
+}
+
 func TestPrepareAccept(t *testing.T) {
 	out := PrepareAccept(AcceptPrepInput{
 		HasContract:     true,
@@ -32,20 +32,3 @@ func ApplyFeedback(weights map[string]float64, m Metrics) {
 		weights["FLOOD_WARNING"] += 0.10
 	}
 }
-
-func ItemUnitValue(item string) float64 {
-	switch item {
-	case "CRYSTAL_SHARD":
-		return 50
-	case "IRON_INGOT":
-		return 10
-	case "COPPER_INGOT":
-		return 6
-	case "COAL":
-		return 1
-	case "PLANK":
-		return 1
-	default:
-		return 0.5
-	}
-}
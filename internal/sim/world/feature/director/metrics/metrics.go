@@ -1,9 +1,6 @@
 package metrics
 
-import (
-	"voxelcraft.ai/internal/sim/world/feature/director/feedback"
-	"voxelcraft.ai/internal/sim/world/logic/directorcenter"
-)
+import "voxelcraft.ai/internal/sim/world/logic/directorcenter"
 
 type EvalInput struct {
 	Agents      int
@@ -55,6 +52,7 @@ func ComputeMetrics(in EvalInput) EvalMetrics {
 	}
 }
 
-func InventoryValue(inv map[string]int) float64 {
-	return directorcenter.MapValue(inv, feedback.ItemUnitValue)
+// InventoryValue prices an inventory with valueOf (the world price index).
+func InventoryValue(inv map[string]int, valueOf func(string) float64) float64 {
+	return directorcenter.MapValue(inv, valueOf)
 }
@@ -124,11 +124,17 @@ type BoardPost struct {
 	Body   string
 }
 
+type MobSpawn struct {
+	Pos  Pos
+	Kind string
+}
+
 type Plan struct {
 	Placements []BlockPlacement
 	Containers []LootContainer
 	Signs      []SignPlacement
 	BoardPosts []BoardPost
+	Mobs       []MobSpawn
 	Center     *Pos
 }
 
@@ -137,6 +143,11 @@ func CrystalRiftPlan(center Pos) Plan {
 	for _, p := range Square(center, 2) {
 		plan.Placements = append(plan.Placements, BlockPlacement{Pos: p, Block: "CRYSTAL_ORE"})
 	}
+	for _, dx := range []int{-3, 3} {
+		p := Pos{X: center.X + dx, Y: center.Y, Z: center.Z}
+		plan.Placements = append(plan.Placements, BlockPlacement{Pos: p, Block: "AIR"})
+		plan.Mobs = append(plan.Mobs, MobSpawn{Pos: p, Kind: "CRYSTAL_BEETLE"})
+	}
 	return plan
 }
 
@@ -170,6 +181,9 @@ func RuinsGatePlan(center Pos) Plan {
 			"COPPER_INGOT":  4,
 		},
 	})
+	guardPos := Pos{X: center.X + 2, Y: center.Y, Z: center.Z}
+	plan.Placements = append(plan.Placements, BlockPlacement{Pos: guardPos, Block: "AIR"})
+	plan.Mobs = append(plan.Mobs, MobSpawn{Pos: guardPos, Kind: "RUIN_GUARDIAN"})
 	return plan
 }
 
@@ -236,6 +250,11 @@ func BanditCampPlan(center Pos) Plan {
 	signPos := Pos{X: center.X + 3, Y: center.Y, Z: center.Z}
 	plan.Placements = append(plan.Placements, BlockPlacement{Pos: signPos, Block: "SIGN"})
 	plan.Signs = append(plan.Signs, SignPlacement{Pos: signPos, Text: "BANDIT CAMP"})
+	// Bandits hold the camp interior (already cleared to AIR above).
+	plan.Mobs = append(plan.Mobs,
+		MobSpawn{Pos: Pos{X: center.X - 1, Y: center.Y, Z: center.Z - 1}, Kind: "BANDIT"},
+		MobSpawn{Pos: Pos{X: center.X + 1, Y: center.Y, Z: center.Z + 1}, Kind: "BANDIT"},
+	)
 	return plan
 }
 
@@ -30,21 +30,32 @@ func TestCrystalRiftPlan(t *testing.T) {
 	if plan.Center == nil || *plan.Center != center {
 		t.Fatalf("center mismatch: %+v", plan.Center)
 	}
-	if len(plan.Placements) != 25 {
-		t.Fatalf("placements=%d want 25", len(plan.Placements))
-	}
+	ore := 0
 	for _, p := range plan.Placements {
-		if p.Block != "CRYSTAL_ORE" {
+		switch p.Block {
+		case "CRYSTAL_ORE":
+			ore++
+		case "AIR":
+		default:
 			t.Fatalf("unexpected block %q", p.Block)
 		}
 	}
+	if ore != 25 {
+		t.Fatalf("ore placements=%d want 25", ore)
+	}
+	if len(plan.Mobs) != 2 || plan.Mobs[0].Kind != "CRYSTAL_BEETLE" {
+		t.Fatalf("unexpected mobs: %+v", plan.Mobs)
+	}
 }
 
 func TestRuinsGatePlanLoot(t *testing.T) {
 	center := Pos{X: 0, Y: 0, Z: 0}
 	plan := RuinsGatePlan(center)
-	if len(plan.Placements) != 9 {
-		t.Fatalf("placements=%d want 9", len(plan.Placements))
+	if len(plan.Placements) != 10 {
+		t.Fatalf("placements=%d want 10", len(plan.Placements))
+	}
+	if len(plan.Mobs) != 1 || plan.Mobs[0].Kind != "RUIN_GUARDIAN" {
+		t.Fatalf("unexpected mobs: %+v", plan.Mobs)
 	}
 	if len(plan.Containers) != 1 {
 		t.Fatalf("containers=%d want 1", len(plan.Containers))
@@ -87,3 +98,19 @@ func TestBanditCampPlan(t *testing.T) {
 		t.Fatalf("expected AIR/CHEST/BRICK placements, got %+v", plan.Placements)
 	}
 }
+
+func TestBanditCampPlanMobsOnAir(t *testing.T) {
+	plan := BanditCampPlan(Pos{X: 0, Y: 0, Z: 0})
+	if len(plan.Mobs) != 2 {
+		t.Fatalf("mobs=%d want 2", len(plan.Mobs))
+	}
+	blocks := map[Pos]string{}
+	for _, p := range plan.Placements {
+		blocks[p.Pos] = p.Block
+	}
+	for _, m := range plan.Mobs {
+		if m.Kind != "BANDIT" || blocks[m.Pos] != "AIR" {
+			t.Fatalf("bandit %+v on %q", m, blocks[m.Pos])
+		}
+	}
+}
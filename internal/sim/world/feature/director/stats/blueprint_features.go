@@ -20,13 +20,13 @@ func ExtractBlueprintFeatures(blocks []catalogs.BPBlock) BlueprintFeatures {
 	for _, b := range blocks {
 		unique[b.Block] = true
 		switch b.Block {
-		case "CHEST":
+		case "CHEST", "SHOP":
 			hasStorage = true
 		case "TORCH":
 			hasLight = true
 		case "CRAFTING_BENCH", "FURNACE":
 			hasWorkshop = true
-		case "BULLETIN_BOARD", "CONTRACT_TERMINAL", "CLAIM_TOTEM", "SIGN":
+		case "BULLETIN_BOARD", "CONTRACT_TERMINAL", "MARKET_TERMINAL", "CLAIM_TOTEM", "SIGN":
 			hasGov = true
 		}
 	}
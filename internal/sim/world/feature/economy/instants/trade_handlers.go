@@ -18,9 +18,12 @@ type TradeEnv interface {
 	PutTrade(tr *modelpkg.Trade)
 	GetTrade(tradeID string) *modelpkg.Trade
 	DeleteTrade(tradeID string)
+	// ItemValue is the price index value of one unit of item.
+	ItemValue(item string) int64
 }
 
 type TradeTaxResolution struct {
+	Denied bool // a land law forbids this trade
 	Rate   float64
 	Sink   map[string]int
 	LandID string
@@ -46,6 +49,7 @@ type TradeAcceptEnv interface {
 	AgentByID(agentID string) *modelpkg.Agent
 	PermissionsFor(agentID string, pos modelpkg.Vec3i) map[string]bool
 	ResolveTradeTax(tr *modelpkg.Trade, from *modelpkg.Agent, to *modelpkg.Agent, nowTick uint64) TradeTaxResolution
+	ItemValue(item string) int64
 }
 
 type TradeAcceptHooks struct {
@@ -105,6 +109,9 @@ func HandleOfferTrade(env TradeEnv, ar ActionResultFn, a *modelpkg.Agent, inst p
 		"from":     a.ID,
 		"offer":    inventorypkg.EncodeItemPairs(offer),
 		"request":  inventorypkg.EncodeItemPairs(req),
+		// Reference values let receivers filter offers (e.g. SET_TRIGGER conditions).
+		"offer_value":   valuepkg.TradeValue(offer, env.ItemValue),
+		"request_value": valuepkg.TradeValue(req, env.ItemValue),
 	})
 	a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": inst.ID, "ok": true, "trade_id": tradeID})
 }
@@ -171,12 +178,16 @@ func HandleAcceptTrade(env TradeAcceptEnv, ar ActionResultFn, hooks TradeAcceptH
 	}
 
 	tax := env.ResolveTradeTax(tr, from, a, nowTick)
+	if tax.Denied {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_PERMISSION", "trade denied by law"))
+		return
+	}
 	inventorypkg.ApplyTransferWithTax(from.Inventory, a.Inventory, tr.Offer, tax.Sink, tax.Rate)
 	inventorypkg.ApplyTransferWithTax(a.Inventory, from.Inventory, tr.Request, tax.Sink, tax.Rate)
 	env.DeleteTrade(inst.TradeID)
 
-	vOffer := valuepkg.TradeValue(tr.Offer, valuepkg.ItemTradeValue)
-	vReq := valuepkg.TradeValue(tr.Request, valuepkg.ItemTradeValue)
+	vOffer := valuepkg.TradeValue(tr.Offer, env.ItemValue)
+	vReq := valuepkg.TradeValue(tr.Request, env.ItemValue)
 	mutualOK := valuepkg.TradeMutualBenefit(vOffer, vReq)
 	if hooks.OnCompleted != nil {
 		hooks.OnCompleted(TradeAcceptOutcome{
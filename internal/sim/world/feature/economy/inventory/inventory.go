@@ -146,3 +146,19 @@ func DeductItems(inv map[string]int, cost map[string]int) {
 		}
 	}
 }
+
+// SortedItemKeys returns the distinct item ids of the given maps in sorted order.
+func SortedItemKeys(ms ...map[string]int) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, m := range ms {
+		for item := range m {
+			if !seen[item] {
+				seen[item] = true
+				out = append(out, item)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,146 @@
+package market
+
+import (
+	"strings"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type ActionResultFn func(tick uint64, ref string, ok bool, code string, message string) protocol.Event
+
+type OrderEnv interface {
+	GetContainerByID(id string) *modelpkg.Container
+	Distance(a modelpkg.Vec3i, b modelpkg.Vec3i) int
+	// CanTradeAt checks the land's can_trade permission and trade laws at the terminal.
+	CanTradeAt(agentID string, pos modelpkg.Vec3i, items []string, nowTick uint64) bool
+	ItemExists(itemID string) bool
+	OpenOrders(agentID string) int
+	NewOrderID() string
+	PutOrder(o *modelpkg.MarketOrder)
+	GetOrder(orderID string) *modelpkg.MarketOrder
+	DeleteOrder(orderID string)
+}
+
+type OrderHooks struct {
+	OnPosted   func(o *modelpkg.MarketOrder)
+	OnCanceled func(o *modelpkg.MarketOrder, refund map[string]int)
+}
+
+// HandlePostOrder places a limit order at a MARKET_TERMINAL (POST_ORDER: terminal_id,
+// side, item_id, price_item, price, count). The escrow moves from the agent into the
+// terminal and stays reserved until the order fills or is canceled.
+func HandlePostOrder(env OrderEnv, ar ActionResultFn, hooks OrderHooks, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64, allowTrade bool) {
+	if !allowTrade {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_PERMISSION", "trade disabled in this world"))
+		return
+	}
+	in := PostInput{
+		Side:      inst.Side,
+		Item:      strings.TrimSpace(inst.ItemID),
+		PriceItem: strings.TrimSpace(inst.PriceItem),
+		Price:     inst.Price,
+		Count:     inst.Count,
+	}
+	if ok, code, msg := ValidatePost(in); !ok {
+		a.AddEvent(ar(nowTick, inst.ID, false, code, msg))
+		return
+	}
+	if env == nil {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INTERNAL", "market env unavailable"))
+		return
+	}
+	if !env.ItemExists(in.Item) || !env.ItemExists(in.PriceItem) {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BAD_REQUEST", "unknown item"))
+		return
+	}
+	term := env.GetContainerByID(strings.TrimSpace(inst.TerminalID))
+	if term == nil || term.Type != TerminalBlock {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "market terminal not found"))
+		return
+	}
+	if env.Distance(a.Pos, term.Pos) > ReachDistance {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BLOCKED", "too far"))
+		return
+	}
+	if !env.CanTradeAt(a.ID, term.Pos, []string{in.Item, in.PriceItem}, nowTick) {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_PERMISSION", "trade not allowed here"))
+		return
+	}
+	if env.OpenOrders(a.ID) >= MaxOpenOrders {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_CONFLICT", "too many open orders"))
+		return
+	}
+	side, _ := NormalizeSide(in.Side)
+	o := &modelpkg.MarketOrder{
+		TerminalPos: term.Pos,
+		Owner:       a.ID,
+		Side:        side,
+		Item:        in.Item,
+		PriceItem:   in.PriceItem,
+		Price:       in.Price,
+		Count:       in.Count,
+		CreatedTick: nowTick,
+	}
+	item, n := o.Escrow()
+	if a.Inventory[item] < n {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_RESOURCE", "missing escrow"))
+		return
+	}
+	o.OrderID = env.NewOrderID()
+	if o.OrderID == "" {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INTERNAL", "order id allocation failed"))
+		return
+	}
+	a.Inventory[item] -= n
+	if a.Inventory[item] <= 0 {
+		delete(a.Inventory, item)
+	}
+	if term.Inventory == nil {
+		term.Inventory = map[string]int{}
+	}
+	term.Inventory[item] += n
+	term.Reserve(item, n)
+	env.PutOrder(o)
+	if hooks.OnPosted != nil {
+		hooks.OnPosted(o)
+	}
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": inst.ID, "ok": true, "order_id": o.OrderID})
+}
+
+// HandleCancelOrder withdraws the agent's own order (CANCEL_ORDER: order_id) and
+// returns the remaining escrow to its inventory.
+func HandleCancelOrder(env OrderEnv, ar ActionResultFn, hooks OrderHooks, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64) {
+	orderID := strings.TrimSpace(inst.OrderID)
+	if orderID == "" {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BAD_REQUEST", "missing order_id"))
+		return
+	}
+	if env == nil {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INTERNAL", "market env unavailable"))
+		return
+	}
+	o := env.GetOrder(orderID)
+	if o == nil {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "order not found"))
+		return
+	}
+	if o.Owner != a.ID {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_PERMISSION", "not your order"))
+		return
+	}
+	if env.Distance(a.Pos, o.TerminalPos) > ReachDistance {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BLOCKED", "too far"))
+		return
+	}
+	term := env.GetContainerByID(modelpkg.ContainerID(TerminalBlock, o.TerminalPos))
+	refund := ReleaseEscrow(term, o)
+	for item, n := range refund {
+		a.Inventory[item] += n
+	}
+	env.DeleteOrder(o.OrderID)
+	if hooks.OnCanceled != nil {
+		hooks.OnCanceled(o, refund)
+	}
+	a.AddEvent(ar(nowTick, inst.ID, true, "", "canceled"))
+}
@@ -0,0 +1,333 @@
+// Package market runs the order-book exchange at MARKET_TERMINAL blocks. Agents post
+// limit orders for an item priced in another item; the terminal container escrows
+// what each order can pay, and Match crosses the books every tick by price-time
+// priority.
+package market
+
+import (
+	"sort"
+	"strings"
+
+	"voxelcraft.ai/internal/protocol"
+	inventorypkg "voxelcraft.ai/internal/sim/world/feature/economy/inventory"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	TerminalBlock = "MARKET_TERMINAL"
+
+	// MaxOpenOrders caps one agent's resting orders across all terminals.
+	MaxOpenOrders = 16
+	// MaxUnits bounds an order's count and price.
+	MaxUnits = 10000
+	// BookDepth is the number of price levels per side shown in OBS.
+	BookDepth = 5
+	// ReachDistance is how close an agent must stand to post or cancel.
+	ReachDistance = 3
+)
+
+type PostInput struct {
+	Side      string
+	Item      string
+	PriceItem string
+	Price     int
+	Count     int
+}
+
+func NormalizeSide(s string) (modelpkg.OrderSide, bool) {
+	switch modelpkg.OrderSide(strings.ToUpper(strings.TrimSpace(s))) {
+	case modelpkg.OrderBuy:
+		return modelpkg.OrderBuy, true
+	case modelpkg.OrderSell:
+		return modelpkg.OrderSell, true
+	}
+	return "", false
+}
+
+func ValidatePost(in PostInput) (ok bool, code string, msg string) {
+	if _, ok := NormalizeSide(in.Side); !ok {
+		return false, "E_BAD_REQUEST", "side must be BUY or SELL"
+	}
+	if in.Item == "" || in.PriceItem == "" {
+		return false, "E_BAD_REQUEST", "missing item_id or price_item"
+	}
+	if in.Item == in.PriceItem {
+		return false, "E_BAD_REQUEST", "item_id and price_item must differ"
+	}
+	if in.Price <= 0 || in.Price > MaxUnits {
+		return false, "E_BAD_REQUEST", "bad price"
+	}
+	if in.Count <= 0 || in.Count > MaxUnits {
+		return false, "E_BAD_REQUEST", "bad count"
+	}
+	return true, "", ""
+}
+
+// Fill is one match between a bid and an ask. Price is the resting (earlier) order's
+// price; the buyer is refunded the difference to its own limit. BuyLeft and SellLeft
+// are what each order had left right after this fill.
+type Fill struct {
+	Buy      *modelpkg.MarketOrder
+	Sell     *modelpkg.MarketOrder
+	Count    int
+	Price    int
+	BuyLeft  int
+	SellLeft int
+}
+
+type bookKey struct {
+	Pos       modelpkg.Vec3i
+	Item      string
+	PriceItem string
+}
+
+// Match crosses every book while its best bid is at or above its best ask, lowering
+// Count on the matched orders. A bid passes over asks of its own owner, which stay
+// open for other bidders. Books are visited in terminal/item order, so the result
+// only depends on the orders.
+func Match(orders map[string]*modelpkg.MarketOrder) []Fill {
+	bids := map[bookKey][]*modelpkg.MarketOrder{}
+	asks := map[bookKey][]*modelpkg.MarketOrder{}
+	for _, o := range orders {
+		if o == nil || o.Count <= 0 {
+			continue
+		}
+		k := bookKey{Pos: o.TerminalPos, Item: o.Item, PriceItem: o.PriceItem}
+		if o.Side == modelpkg.OrderBuy {
+			bids[k] = append(bids[k], o)
+		} else {
+			asks[k] = append(asks[k], o)
+		}
+	}
+	keys := make([]bookKey, 0, len(bids))
+	for k := range bids {
+		if len(asks[k]) > 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keyLess(keys[i], keys[j]) })
+
+	var fills []Fill
+	for _, k := range keys {
+		b, s := bids[k], asks[k]
+		SortBids(b)
+		SortAsks(s)
+		for _, buy := range b {
+			for _, sell := range s {
+				if buy.Count == 0 || buy.Price < sell.Price {
+					break
+				}
+				// An agent's own orders never fill each other.
+				if sell.Count == 0 || sell.Owner == buy.Owner {
+					continue
+				}
+				n := buy.Count
+				if sell.Count < n {
+					n = sell.Count
+				}
+				price := sell.Price
+				if Earlier(buy, sell) {
+					price = buy.Price
+				}
+				buy.Count -= n
+				sell.Count -= n
+				fills = append(fills, Fill{Buy: buy, Sell: sell, Count: n, Price: price, BuyLeft: buy.Count, SellLeft: sell.Count})
+			}
+		}
+	}
+	return fills
+}
+
+// SortBids orders bids best first: highest price, then oldest.
+func SortBids(os []*modelpkg.MarketOrder) {
+	sort.Slice(os, func(i, j int) bool {
+		if os[i].Price != os[j].Price {
+			return os[i].Price > os[j].Price
+		}
+		return Earlier(os[i], os[j])
+	})
+}
+
+// SortAsks orders asks best first: lowest price, then oldest.
+func SortAsks(os []*modelpkg.MarketOrder) {
+	sort.Slice(os, func(i, j int) bool {
+		if os[i].Price != os[j].Price {
+			return os[i].Price < os[j].Price
+		}
+		return Earlier(os[i], os[j])
+	})
+}
+
+// Earlier reports time priority: creation tick, then order id.
+func Earlier(a, b *modelpkg.MarketOrder) bool {
+	if a.CreatedTick != b.CreatedTick {
+		return a.CreatedTick < b.CreatedTick
+	}
+	return a.OrderID < b.OrderID
+}
+
+func keyLess(a, b bookKey) bool {
+	if a.Pos != b.Pos {
+		return modelpkg.PosLess(a.Pos, b.Pos)
+	}
+	if a.Item != b.Item {
+		return a.Item < b.Item
+	}
+	return a.PriceItem < b.PriceItem
+}
+
+// Settlement is what each side of a fill receives (after tax) and what the land
+// keeps.
+type Settlement struct {
+	ToBuyer   map[string]int
+	ToSeller  map[string]int
+	TaxBuyer  map[string]int
+	TaxSeller map[string]int
+}
+
+// Settle releases a fill's escrow from the terminal: the buyer gets the items plus the
+// refund between its limit and the fill price, the seller gets the payment. Each side
+// pays its own tax rate on what it receives (the refund is untaxed).
+func Settle(term *modelpkg.Container, f Fill, buyerTax, sellerTax float64) Settlement {
+	paid := f.Count * f.Price
+	held := f.Count * f.Buy.Price
+	term.Unreserve(f.Sell.Item, f.Count)
+	term.Unreserve(f.Buy.PriceItem, held)
+	inventorypkg.DeductItems(term.Inventory, map[string]int{f.Sell.Item: f.Count})
+	inventorypkg.DeductItems(term.Inventory, map[string]int{f.Buy.PriceItem: held})
+
+	goods := map[string]int{f.Sell.Item: f.Count}
+	payment := map[string]int{f.Buy.PriceItem: paid}
+	out := Settlement{
+		TaxBuyer:  inventorypkg.CalcTax(goods, buyerTax),
+		TaxSeller: inventorypkg.CalcTax(payment, sellerTax),
+	}
+	out.ToBuyer = map[string]int{f.Sell.Item: f.Count - out.TaxBuyer[f.Sell.Item]}
+	if refund := held - paid; refund > 0 {
+		out.ToBuyer[f.Buy.PriceItem] += refund
+	}
+	out.ToSeller = map[string]int{f.Buy.PriceItem: paid - out.TaxSeller[f.Buy.PriceItem]}
+	return out
+}
+
+// ReleaseEscrow takes a canceled order's remaining escrow out of the terminal.
+func ReleaseEscrow(term *modelpkg.Container, o *modelpkg.MarketOrder) map[string]int {
+	item, n := o.Escrow()
+	if term == nil || n <= 0 {
+		return nil
+	}
+	term.Unreserve(item, n)
+	if have := term.Inventory[item]; have < n {
+		n = have
+	}
+	if n <= 0 {
+		return nil
+	}
+	inventorypkg.DeductItems(term.Inventory, map[string]int{item: n})
+	return map[string]int{item: n}
+}
+
+// OpenOrders counts an agent's resting orders.
+func OpenOrders(orders map[string]*modelpkg.MarketOrder, owner string) int {
+	n := 0
+	for _, o := range orders {
+		if o != nil && o.Owner == owner && o.Count > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Books aggregates the resting orders of every terminal include accepts, sorted by
+// terminal id, each book truncated to BookDepth levels per side.
+func Books(orders map[string]*modelpkg.MarketOrder, include func(pos modelpkg.Vec3i) bool) []protocol.MarketObs {
+	byTerm := map[modelpkg.Vec3i]map[bookKey][]*modelpkg.MarketOrder{}
+	for _, o := range orders {
+		if o == nil || o.Count <= 0 || (include != nil && !include(o.TerminalPos)) {
+			continue
+		}
+		m := byTerm[o.TerminalPos]
+		if m == nil {
+			m = map[bookKey][]*modelpkg.MarketOrder{}
+			byTerm[o.TerminalPos] = m
+		}
+		k := bookKey{Pos: o.TerminalPos, Item: o.Item, PriceItem: o.PriceItem}
+		m[k] = append(m[k], o)
+	}
+	out := make([]protocol.MarketObs, 0, len(byTerm))
+	for pos, m := range byTerm {
+		keys := make([]bookKey, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keyLess(keys[i], keys[j]) })
+		mo := protocol.MarketObs{
+			TerminalID: modelpkg.ContainerID(TerminalBlock, pos),
+			Pos:        pos.ToArray(),
+			Books:      make([]protocol.MarketBook, 0, len(keys)),
+		}
+		for _, k := range keys {
+			var bids, asks []*modelpkg.MarketOrder
+			for _, o := range m[k] {
+				if o.Side == modelpkg.OrderBuy {
+					bids = append(bids, o)
+				} else {
+					asks = append(asks, o)
+				}
+			}
+			SortBids(bids)
+			SortAsks(asks)
+			mo.Books = append(mo.Books, protocol.MarketBook{
+				Item:      k.Item,
+				PriceItem: k.PriceItem,
+				Bids:      levels(bids),
+				Asks:      levels(asks),
+			})
+		}
+		out = append(out, mo)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].TerminalID < out[j].TerminalID })
+	return out
+}
+
+// levels folds sorted orders into price levels, keeping the best BookDepth.
+func levels(os []*modelpkg.MarketOrder) []protocol.MarketLevel {
+	var out []protocol.MarketLevel
+	for _, o := range os {
+		if n := len(out); n > 0 && out[n-1].Price == o.Price {
+			out[n-1].Count += o.Count
+			out[n-1].Orders++
+			continue
+		}
+		if len(out) == BookDepth {
+			break
+		}
+		out = append(out, protocol.MarketLevel{Price: o.Price, Count: o.Count, Orders: 1})
+	}
+	return out
+}
+
+// OwnOrders lists owner's resting orders at the terminal at pos, oldest first (the
+// OPEN event of a MARKET_TERMINAL).
+func OwnOrders(orders map[string]*modelpkg.MarketOrder, pos modelpkg.Vec3i, owner string) []map[string]interface{} {
+	var mine []*modelpkg.MarketOrder
+	for _, o := range orders {
+		if o != nil && o.Owner == owner && o.TerminalPos == pos && o.Count > 0 {
+			mine = append(mine, o)
+		}
+	}
+	sort.Slice(mine, func(i, j int) bool { return Earlier(mine[i], mine[j]) })
+	out := make([]map[string]interface{}, 0, len(mine))
+	for _, o := range mine {
+		out = append(out, map[string]interface{}{
+			"order_id":     o.OrderID,
+			"side":         string(o.Side),
+			"item":         o.Item,
+			"price_item":   o.PriceItem,
+			"price":        o.Price,
+			"count":        o.Count,
+			"created_tick": o.CreatedTick,
+		})
+	}
+	return out
+}
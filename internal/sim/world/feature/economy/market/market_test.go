@@ -0,0 +1,150 @@
+package market
+
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+var term = modelpkg.Vec3i{X: 1, Y: 0, Z: 1}
+
+func order(id string, side modelpkg.OrderSide, price, count int, tick uint64) *modelpkg.MarketOrder {
+	return &modelpkg.MarketOrder{
+		OrderID: id, TerminalPos: term, Owner: "A_" + id, Side: side,
+		Item: "IRON_INGOT", PriceItem: "COAL", Price: price, Count: count, CreatedTick: tick,
+	}
+}
+
+func TestValidatePost(t *testing.T) {
+	cases := []struct {
+		in PostInput
+		ok bool
+	}{
+		{PostInput{Side: "buy", Item: "IRON_INGOT", PriceItem: "COAL", Price: 2, Count: 3}, true},
+		{PostInput{Side: "HOLD", Item: "IRON_INGOT", PriceItem: "COAL", Price: 2, Count: 3}, false},
+		{PostInput{Side: "SELL", Item: "COAL", PriceItem: "COAL", Price: 2, Count: 3}, false},
+		{PostInput{Side: "SELL", Item: "IRON_INGOT", PriceItem: "COAL", Price: 0, Count: 3}, false},
+		{PostInput{Side: "SELL", Item: "IRON_INGOT", PriceItem: "COAL", Price: 2, Count: MaxUnits + 1}, false},
+	}
+	for i, c := range cases {
+		if ok, _, _ := ValidatePost(c.in); ok != c.ok {
+			t.Fatalf("case %d: ok=%v want %v", i, ok, c.ok)
+		}
+	}
+}
+
+func TestMatchPriceTimePriority(t *testing.T) {
+	orders := map[string]*modelpkg.MarketOrder{
+		"S1": order("S1", modelpkg.OrderSell, 5, 2, 1),
+		"S2": order("S2", modelpkg.OrderSell, 4, 1, 3),
+		"S3": order("S3", modelpkg.OrderSell, 5, 4, 2),
+		"B1": order("B1", modelpkg.OrderBuy, 5, 5, 4),
+		"B2": order("B2", modelpkg.OrderBuy, 3, 9, 0),
+	}
+	fills := Match(orders)
+	if len(fills) != 3 {
+		t.Fatalf("fills=%d want 3: %+v", len(fills), fills)
+	}
+	// Cheapest ask first, then the older of the two asks at 5.
+	want := []struct {
+		sell  string
+		count int
+		price int
+	}{{"S2", 1, 4}, {"S1", 2, 5}, {"S3", 2, 5}}
+	for i, w := range want {
+		f := fills[i]
+		if f.Buy.OrderID != "B1" || f.Sell.OrderID != w.sell || f.Count != w.count || f.Price != w.price {
+			t.Fatalf("fill %d = %s/%s x%d @%d, want B1/%s x%d @%d", i, f.Buy.OrderID, f.Sell.OrderID, f.Count, f.Price, w.sell, w.count, w.price)
+		}
+	}
+	if orders["B1"].Count != 0 || orders["S3"].Count != 2 || orders["B2"].Count != 9 {
+		t.Fatalf("remaining counts wrong: B1=%d S3=%d B2=%d", orders["B1"].Count, orders["S3"].Count, orders["B2"].Count)
+	}
+}
+
+func TestMatchFillsAtRestingPrice(t *testing.T) {
+	orders := map[string]*modelpkg.MarketOrder{
+		"B": order("B", modelpkg.OrderBuy, 6, 1, 1),
+		"S": order("S", modelpkg.OrderSell, 4, 1, 2),
+	}
+	fills := Match(orders)
+	if len(fills) != 1 || fills[0].Price != 6 {
+		t.Fatalf("expected fill at the resting bid price: %+v", fills)
+	}
+	// Different pairs or terminals never cross.
+	other := order("S2", modelpkg.OrderSell, 1, 1, 3)
+	other.TerminalPos = modelpkg.Vec3i{X: 9}
+	if fills := Match(map[string]*modelpkg.MarketOrder{"B": order("B", modelpkg.OrderBuy, 6, 1, 1), "S2": other}); len(fills) != 0 {
+		t.Fatalf("orders at different terminals matched: %+v", fills)
+	}
+}
+
+func TestMatchSkipsOwnOrders(t *testing.T) {
+	own := order("S1", modelpkg.OrderSell, 1, 3, 2)
+	own.Owner = "A_B1"
+	orders := map[string]*modelpkg.MarketOrder{
+		"B1": order("B1", modelpkg.OrderBuy, 5, 2, 1),
+		"S1": own,
+		"S2": order("S2", modelpkg.OrderSell, 4, 1, 3),
+		"B2": order("B2", modelpkg.OrderBuy, 2, 1, 4),
+	}
+	fills := Match(orders)
+	if len(fills) != 2 {
+		t.Fatalf("fills=%d want 2: %+v", len(fills), fills)
+	}
+	// B1 passes over its owner's cheaper ask; that ask still fills the other bidder.
+	if f := fills[0]; f.Buy.OrderID != "B1" || f.Sell.OrderID != "S2" || f.Count != 1 {
+		t.Fatalf("fill 0 = %s/%s x%d, want B1/S2 x1", f.Buy.OrderID, f.Sell.OrderID, f.Count)
+	}
+	if f := fills[1]; f.Buy.OrderID != "B2" || f.Sell.OrderID != "S1" || f.Count != 1 || f.Price != 1 {
+		t.Fatalf("fill 1 = %s/%s x%d @%d, want B2/S1 x1 @1", f.Buy.OrderID, f.Sell.OrderID, f.Count, f.Price)
+	}
+	if orders["B1"].Count != 1 || orders["S1"].Count != 2 {
+		t.Fatalf("remaining counts wrong: B1=%d S1=%d", orders["B1"].Count, orders["S1"].Count)
+	}
+}
+
+func TestSettleRefundsAndTaxes(t *testing.T) {
+	buy := order("B", modelpkg.OrderBuy, 6, 0, 2)
+	sell := order("S", modelpkg.OrderSell, 4, 0, 1)
+	c := &modelpkg.Container{Type: TerminalBlock, Pos: term, Inventory: map[string]int{"IRON_INGOT": 10, "COAL": 60}}
+	c.Reserve("IRON_INGOT", 10)
+	c.Reserve("COAL", 60)
+
+	s := Settle(c, Fill{Buy: buy, Sell: sell, Count: 10, Price: 4}, 0.1, 0.5)
+	if s.ToBuyer["IRON_INGOT"] != 9 || s.TaxBuyer["IRON_INGOT"] != 1 {
+		t.Fatalf("buyer goods: %+v tax %+v", s.ToBuyer, s.TaxBuyer)
+	}
+	if s.ToBuyer["COAL"] != 20 {
+		t.Fatalf("buyer refund=%d want 20", s.ToBuyer["COAL"])
+	}
+	if s.ToSeller["COAL"] != 20 || s.TaxSeller["COAL"] != 20 {
+		t.Fatalf("seller payment: %+v tax %+v", s.ToSeller, s.TaxSeller)
+	}
+	if len(c.Inventory) != 0 || len(c.Reserved) != 0 {
+		t.Fatalf("escrow not released: inv=%v reserved=%v", c.Inventory, c.Reserved)
+	}
+}
+
+func TestBooksAggregatesLevels(t *testing.T) {
+	orders := map[string]*modelpkg.MarketOrder{
+		"B1": order("B1", modelpkg.OrderBuy, 3, 2, 1),
+		"B2": order("B2", modelpkg.OrderBuy, 3, 5, 2),
+		"B3": order("B3", modelpkg.OrderBuy, 4, 1, 3),
+		"S1": order("S1", modelpkg.OrderSell, 7, 1, 4),
+	}
+	books := Books(orders, nil)
+	if len(books) != 1 || len(books[0].Books) != 1 {
+		t.Fatalf("books=%+v", books)
+	}
+	b := books[0].Books[0]
+	if len(b.Bids) != 2 || b.Bids[0].Price != 4 || b.Bids[1].Count != 7 || b.Bids[1].Orders != 2 {
+		t.Fatalf("bids=%+v", b.Bids)
+	}
+	if len(b.Asks) != 1 || b.Asks[0].Price != 7 {
+		t.Fatalf("asks=%+v", b.Asks)
+	}
+	if got := Books(orders, func(modelpkg.Vec3i) bool { return false }); len(got) != 0 {
+		t.Fatalf("filtered books=%+v", got)
+	}
+}
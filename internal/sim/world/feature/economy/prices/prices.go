@@ -0,0 +1,202 @@
+// Package prices maintains the per-world price index. Every completed exchange of items
+// (agent trades, contract settlements, exchange fills) is split into implied unit prices
+// for the items on each side, and an item's index price is the volume-weighted average
+// of its recent samples after dropping outliers. Prices are fixed-point milli reference
+// units so the index stays exact across snapshots and replays.
+package prices
+
+import (
+	"sort"
+
+	"voxelcraft.ai/internal/protocol"
+	valuepkg "voxelcraft.ai/internal/sim/world/feature/economy/value"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	// Scale is the number of index units per reference unit.
+	Scale = 1000
+	// Window is the number of most recent samples kept per item.
+	Window = 32
+	// MinSamples is how many samples an item needs before the index leaves its
+	// reference value.
+	MinSamples = 3
+	// OutlierFactor rejects samples more than this factor away from the window median.
+	OutlierFactor = 4
+)
+
+// Base is the reference value of item in index units.
+func Base(item string) int64 {
+	return valuepkg.ReferenceValue(item) * Scale
+}
+
+// Price is the index price of item in index units.
+func Price(series map[string]*modelpkg.PriceSeries, item string) int64 {
+	s := series[item]
+	if s == nil {
+		return Base(item)
+	}
+	return VWAP(s.Samples, Base(item))
+}
+
+// Value is the index price of item rounded to whole reference units (at least 1), the
+// scale TradeValue and the agent-facing value fields use.
+func Value(series map[string]*modelpkg.PriceSeries, item string) int64 {
+	v := (Price(series, item) + Scale/2) / Scale
+	if v < 1 {
+		return 1
+	}
+	return v
+}
+
+// UnitValue is the index price of item in reference units.
+func UnitValue(series map[string]*modelpkg.PriceSeries, item string) float64 {
+	return float64(Price(series, item)) / Scale
+}
+
+// VWAP is the volume-weighted average of samples whose price is within OutlierFactor of
+// the window median, or base while there are fewer than MinSamples samples.
+func VWAP(samples []modelpkg.PriceSample, base int64) int64 {
+	if len(samples) < MinSamples {
+		return base
+	}
+	ps := make([]int64, 0, len(samples))
+	for _, s := range samples {
+		ps = append(ps, s.Price)
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i] < ps[j] })
+	median := ps[(len(ps)-1)/2]
+
+	var num, vol int64
+	for _, s := range samples {
+		if s.Price*OutlierFactor < median || s.Price > median*OutlierFactor {
+			continue
+		}
+		num += s.Price * int64(s.Volume)
+		vol += int64(s.Volume)
+	}
+	if vol <= 0 {
+		return base
+	}
+	if p := num / vol; p > 0 {
+		return p
+	}
+	return 1
+}
+
+// Observe records a completed exchange where one side handed over a and the other b.
+// Each item's implied unit price is its current price scaled by value(b)/value(a) (and
+// the other way round), so a one-for-one swap of fairly priced items leaves the index
+// where it is. All implied prices are computed before any sample is recorded.
+func Observe(series map[string]*modelpkg.PriceSeries, a, b map[string]int, nowTick uint64) {
+	if series == nil {
+		return
+	}
+	va, vb := sideValue(series, a), sideValue(series, b)
+	if va <= 0 || vb <= 0 {
+		return
+	}
+	type sample struct {
+		item  string
+		price int64
+		n     int
+	}
+	var out []sample
+	for _, side := range []struct {
+		items    map[string]int
+		own, got int64
+	}{{a, va, vb}, {b, vb, va}} {
+		for _, item := range sortedItems(side.items) {
+			p := Price(series, item) * side.got / side.own
+			if p < 1 {
+				p = 1
+			}
+			out = append(out, sample{item: item, price: p, n: side.items[item]})
+		}
+	}
+	for _, s := range out {
+		Record(series, s.item, s.price, s.n, nowTick)
+	}
+}
+
+// Record appends one sample to item's window, dropping the oldest beyond Window.
+func Record(series map[string]*modelpkg.PriceSeries, item string, price int64, volume int, nowTick uint64) {
+	if series == nil || item == "" || price <= 0 || volume <= 0 {
+		return
+	}
+	s := series[item]
+	if s == nil {
+		s = &modelpkg.PriceSeries{Item: item}
+		series[item] = s
+	}
+	s.Samples = append(s.Samples, modelpkg.PriceSample{Tick: nowTick, Price: price, Volume: volume})
+	if over := len(s.Samples) - Window; over > 0 {
+		s.Samples = append(s.Samples[:0], s.Samples[over:]...)
+	}
+}
+
+func sideValue(series map[string]*modelpkg.PriceSeries, items map[string]int) int64 {
+	var v int64
+	for _, item := range sortedItems(items) {
+		v += Price(series, item) * int64(items[item])
+	}
+	return v
+}
+
+func sortedItems(items map[string]int) []string {
+	keys := make([]string, 0, len(items))
+	for k, n := range items {
+		if k != "" && n > 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Entry is the OBS/catalog view of item's index price.
+func Entry(series map[string]*modelpkg.PriceSeries, item string) protocol.PriceObs {
+	e := protocol.PriceObs{
+		Item:  item,
+		Price: UnitValue(series, item),
+		Base:  float64(Base(item)) / Scale,
+	}
+	if s := series[item]; s != nil {
+		e.Samples = len(s.Samples)
+		for _, smp := range s.Samples {
+			e.Volume += smp.Volume
+		}
+		if n := len(s.Samples); n > 0 {
+			e.LastTick = s.Samples[n-1].Tick
+		}
+	}
+	return e
+}
+
+// Traded lists the index entries of every item with at least one sample, by item.
+func Traded(series map[string]*modelpkg.PriceSeries) []protocol.PriceObs {
+	items := make([]string, 0, len(series))
+	for item, s := range series {
+		if s != nil && len(s.Samples) > 0 {
+			items = append(items, item)
+		}
+	}
+	sort.Strings(items)
+	out := make([]protocol.PriceObs, 0, len(items))
+	for _, item := range items {
+		out = append(out, Entry(series, item))
+	}
+	return out
+}
+
+// Catalog lists the index entry of every item in palette, in palette order.
+func Catalog(series map[string]*modelpkg.PriceSeries, palette []string) []protocol.PriceObs {
+	out := make([]protocol.PriceObs, 0, len(palette))
+	for _, item := range palette {
+		if item == "" {
+			continue
+		}
+		out = append(out, Entry(series, item))
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package prices
+
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func TestPriceStartsAtReferenceUntilEnoughSamples(t *testing.T) {
+	series := map[string]*modelpkg.PriceSeries{}
+	if got, want := Price(series, "IRON_INGOT"), Base("IRON_INGOT"); got != want {
+		t.Fatalf("empty index: got %d want %d", got, want)
+	}
+	for i := 0; i < MinSamples-1; i++ {
+		Record(series, "IRON_INGOT", 20*Scale, 1, uint64(i))
+	}
+	if got, want := Price(series, "IRON_INGOT"), Base("IRON_INGOT"); got != want {
+		t.Fatalf("below MinSamples: got %d want %d", got, want)
+	}
+	Record(series, "IRON_INGOT", 20*Scale, 1, 9)
+	if got := Price(series, "IRON_INGOT"); got != 20*Scale {
+		t.Fatalf("after MinSamples: got %d want %d", got, 20*Scale)
+	}
+}
+
+func TestVWAPWeightsVolumeAndRejectsOutliers(t *testing.T) {
+	samples := []modelpkg.PriceSample{
+		{Price: 4000, Volume: 1},
+		{Price: 6000, Volume: 3},
+		{Price: 5000, Volume: 1},
+		{Price: 500000, Volume: 50}, // dumped far above the median
+	}
+	// (4000 + 18000 + 5000) / 5
+	if got := VWAP(samples, 1000); got != 5400 {
+		t.Fatalf("vwap: got %d want 5400", got)
+	}
+}
+
+func TestRecordKeepsRollingWindow(t *testing.T) {
+	series := map[string]*modelpkg.PriceSeries{}
+	for i := 0; i < Window+5; i++ {
+		Record(series, "COAL", int64(1000+i), 1, uint64(i))
+	}
+	s := series["COAL"]
+	if len(s.Samples) != Window || s.Samples[0].Tick != 5 {
+		t.Fatalf("window: len=%d first=%d", len(s.Samples), s.Samples[0].Tick)
+	}
+}
+
+func TestObserveImpliesPricesFromCounterValue(t *testing.T) {
+	series := map[string]*modelpkg.PriceSeries{}
+	// 1 IRON_INGOT (ref 5) repeatedly swapped for 4 COAL (ref 2): iron is implied at 8,
+	// coal at 1.25.
+	for i := 0; i < MinSamples; i++ {
+		Observe(series, map[string]int{"IRON_INGOT": 1}, map[string]int{"COAL": 4}, uint64(i))
+	}
+	if got := series["IRON_INGOT"].Samples[0].Price; got != 8000 {
+		t.Fatalf("implied iron: got %d want 8000", got)
+	}
+	if got := series["COAL"].Samples[0].Price; got != 1250 {
+		t.Fatalf("implied coal: got %d want 1250", got)
+	}
+	if Price(series, "IRON_INGOT") <= Base("IRON_INGOT") || Price(series, "COAL") >= Base("COAL") {
+		t.Fatalf("index did not move: iron=%d coal=%d", Price(series, "IRON_INGOT"), Price(series, "COAL"))
+	}
+	if got := Traded(series); len(got) != 2 || got[0].Item != "COAL" || got[0].Samples != MinSamples {
+		t.Fatalf("traded entries: %+v", got)
+	}
+}
@@ -0,0 +1,68 @@
+package shop
+
+import (
+	"strings"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type ActionResultFn func(tick uint64, ref string, ok bool, code string, message string) protocol.Event
+
+type PriceEnv interface {
+	GetContainerByID(id string) *modelpkg.Container
+	Distance(a modelpkg.Vec3i, b modelpkg.Vec3i) int
+	ItemExists(itemID string) bool
+	GetShop(pos modelpkg.Vec3i) *modelpkg.Shop
+}
+
+type PriceHooks struct {
+	OnPriced func(pos modelpkg.Vec3i, in PriceInput)
+}
+
+// HandleSetShopPrice lists an item in the agent's own SHOP (SET_SHOP_PRICE: target_id,
+// item_id, price_item, price per unit; price 0 delists the item).
+func HandleSetShopPrice(env PriceEnv, ar ActionResultFn, hooks PriceHooks, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64) {
+	in := PriceInput{
+		Item:      strings.TrimSpace(inst.ItemID),
+		PriceItem: strings.TrimSpace(inst.PriceItem),
+		Price:     inst.Price,
+	}
+	if ok, code, msg := ValidatePrice(in); !ok {
+		a.AddEvent(ar(nowTick, inst.ID, false, code, msg))
+		return
+	}
+	if env == nil {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INTERNAL", "shop env unavailable"))
+		return
+	}
+	if in.Price > 0 && (!env.ItemExists(in.Item) || !env.ItemExists(in.PriceItem)) {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BAD_REQUEST", "unknown item"))
+		return
+	}
+	term := env.GetContainerByID(strings.TrimSpace(inst.TargetID))
+	var s *modelpkg.Shop
+	if term != nil && term.Type == Block {
+		s = env.GetShop(term.Pos)
+	}
+	if s == nil {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "shop not found"))
+		return
+	}
+	if s.Owner != a.ID {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_PERMISSION", "not your shop"))
+		return
+	}
+	if env.Distance(a.Pos, term.Pos) > ReachDistance {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_BLOCKED", "too far"))
+		return
+	}
+	if !SetPrice(s, in) {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_CONFLICT", "too many listings"))
+		return
+	}
+	if hooks.OnPriced != nil {
+		hooks.OnPriced(term.Pos, in)
+	}
+	a.AddEvent(ar(nowTick, inst.ID, true, "", "ok"))
+}
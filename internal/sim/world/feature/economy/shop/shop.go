@@ -0,0 +1,173 @@
+// Package shop runs SHOP blocks: vending containers whose owner lists fixed prices and
+// whose stock any agent can BUY while the owner is away. Payment lands in the shop's
+// own inventory, less the land's trade tax.
+package shop
+
+import (
+	"sort"
+
+	inventorypkg "voxelcraft.ai/internal/sim/world/feature/economy/inventory"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	Block = "SHOP"
+
+	// MaxListings caps the price list of one shop.
+	MaxListings = 16
+	// MaxUnits bounds a listed price and a single purchase.
+	MaxUnits = 10000
+	// ReachDistance is how close an agent must stand to price or buy.
+	ReachDistance = 3
+)
+
+type PriceInput struct {
+	Item      string
+	PriceItem string
+	Price     int
+}
+
+// ValidatePrice checks a SET_SHOP_PRICE entry; a price of 0 delists the item.
+func ValidatePrice(in PriceInput) (ok bool, code string, msg string) {
+	if in.Item == "" {
+		return false, "E_BAD_REQUEST", "missing item_id"
+	}
+	if in.Price == 0 {
+		return true, "", ""
+	}
+	if in.PriceItem == "" {
+		return false, "E_BAD_REQUEST", "missing price_item"
+	}
+	if in.Item == in.PriceItem {
+		return false, "E_BAD_REQUEST", "item_id and price_item must differ"
+	}
+	if in.Price < 0 || in.Price > MaxUnits {
+		return false, "E_BAD_REQUEST", "bad price"
+	}
+	return true, "", ""
+}
+
+// SetPrice lists or (price 0) delists in.Item. It fails only when a new listing would
+// exceed MaxListings.
+func SetPrice(s *modelpkg.Shop, in PriceInput) bool {
+	if in.Price == 0 {
+		delete(s.Prices, in.Item)
+		return true
+	}
+	if _, listed := s.Prices[in.Item]; !listed && len(s.Prices) >= MaxListings {
+		return false
+	}
+	if s.Prices == nil {
+		s.Prices = map[string]modelpkg.ShopPrice{}
+	}
+	s.Prices[in.Item] = modelpkg.ShopPrice{PriceItem: in.PriceItem, Price: in.Price}
+	return true
+}
+
+type BuyInput struct {
+	HasShop  bool
+	Owner    string
+	Buyer    string
+	Distance int
+	Listed   bool
+	Count    int
+	Stock    int
+	Cost     int
+	Funds    int
+}
+
+func ValidateBuy(in BuyInput) (ok bool, code string, msg string) {
+	if !in.HasShop {
+		return false, "E_INVALID_TARGET", "shop not found"
+	}
+	if in.Distance > ReachDistance {
+		return false, "E_BLOCKED", "too far"
+	}
+	if in.Owner == "" {
+		return false, "E_INVALID_TARGET", "shop has no owner"
+	}
+	if in.Buyer == in.Owner {
+		return false, "E_CONFLICT", "cannot buy from own shop"
+	}
+	if !in.Listed {
+		return false, "E_INVALID_TARGET", "item not for sale"
+	}
+	if in.Count <= 0 || in.Count > MaxUnits {
+		return false, "E_BAD_REQUEST", "bad count"
+	}
+	if in.Stock < in.Count {
+		return false, "E_NO_RESOURCE", "insufficient stock"
+	}
+	if in.Funds < in.Cost {
+		return false, "E_NO_RESOURCE", "insufficient price_item"
+	}
+	return true, "", ""
+}
+
+// Sale is the outcome of one purchase: Paid is what the buyer handed over, Tax the
+// land's share of it and Paid-Tax what the shop keeps.
+type Sale struct {
+	Item      string
+	Count     int
+	PriceItem string
+	Paid      int
+	Tax       map[string]int
+}
+
+// Sell moves count item from the shop's stock to the buyer and the payment, less
+// taxRate, into the shop. The caller has validated stock and funds.
+func Sell(term *modelpkg.Container, buyer *modelpkg.Agent, item string, count int, p modelpkg.ShopPrice, taxRate float64) Sale {
+	paid := count * p.Price
+	s := Sale{
+		Item:      item,
+		Count:     count,
+		PriceItem: p.PriceItem,
+		Paid:      paid,
+		Tax:       inventorypkg.CalcTax(map[string]int{p.PriceItem: paid}, taxRate),
+	}
+	term.Inventory[item] -= count
+	if term.Inventory[item] <= 0 {
+		delete(term.Inventory, item)
+	}
+	buyer.Inventory[item] += count
+
+	buyer.Inventory[p.PriceItem] -= paid
+	if buyer.Inventory[p.PriceItem] <= 0 {
+		delete(buyer.Inventory, p.PriceItem)
+	}
+	if kept := paid - s.Tax[p.PriceItem]; kept > 0 {
+		if term.Inventory == nil {
+			term.Inventory = map[string]int{}
+		}
+		term.Inventory[p.PriceItem] += kept
+	}
+	return s
+}
+
+// Listings is the price list shown when a shop is OPENed, with the stock on hand for
+// each item.
+func Listings(s *modelpkg.Shop, term *modelpkg.Container) []map[string]interface{} {
+	if s == nil || len(s.Prices) == 0 {
+		return []map[string]interface{}{}
+	}
+	items := make([]string, 0, len(s.Prices))
+	for item := range s.Prices {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	out := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		p := s.Prices[item]
+		stock := 0
+		if term != nil {
+			stock = term.AvailableCount(item)
+		}
+		out = append(out, map[string]interface{}{
+			"item":       item,
+			"price_item": p.PriceItem,
+			"price":      p.Price,
+			"stock":      stock,
+		})
+	}
+	return out
+}
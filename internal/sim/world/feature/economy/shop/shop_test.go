@@ -0,0 +1,74 @@
+package shop
+
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func TestSetPriceListsAndDelists(t *testing.T) {
+	s := &modelpkg.Shop{Owner: "A1"}
+	if !SetPrice(s, PriceInput{Item: "BREAD", PriceItem: "COAL", Price: 2}) {
+		t.Fatalf("listing rejected")
+	}
+	if p := s.Prices["BREAD"]; p.PriceItem != "COAL" || p.Price != 2 {
+		t.Fatalf("listing: %+v", p)
+	}
+	SetPrice(s, PriceInput{Item: "BREAD", Price: 0})
+	if len(s.Prices) != 0 {
+		t.Fatalf("price 0 should delist: %v", s.Prices)
+	}
+	if ok, _, _ := ValidatePrice(PriceInput{Item: "COAL", PriceItem: "COAL", Price: 1}); ok {
+		t.Fatalf("item priced in itself should be rejected")
+	}
+}
+
+func TestSetPriceCapsListings(t *testing.T) {
+	s := &modelpkg.Shop{Owner: "A1", Prices: map[string]modelpkg.ShopPrice{}}
+	for i := 0; i < MaxListings; i++ {
+		s.Prices[string(rune('A'+i))] = modelpkg.ShopPrice{PriceItem: "COAL", Price: 1}
+	}
+	if SetPrice(s, PriceInput{Item: "BREAD", PriceItem: "COAL", Price: 1}) {
+		t.Fatalf("listing past the cap should fail")
+	}
+	if !SetPrice(s, PriceInput{Item: "A", PriceItem: "COAL", Price: 3}) {
+		t.Fatalf("repricing a listed item should not count against the cap")
+	}
+}
+
+func TestSellTaxesPayment(t *testing.T) {
+	term := &modelpkg.Container{Type: Block, Inventory: map[string]int{"BREAD": 5}}
+	buyer := &modelpkg.Agent{ID: "A2", Inventory: map[string]int{"COAL": 10}}
+	sale := Sell(term, buyer, "BREAD", 3, modelpkg.ShopPrice{PriceItem: "COAL", Price: 2}, 0.5)
+	if sale.Paid != 6 || sale.Tax["COAL"] != 3 {
+		t.Fatalf("sale: %+v", sale)
+	}
+	if buyer.Inventory["BREAD"] != 3 || buyer.Inventory["COAL"] != 4 {
+		t.Fatalf("buyer: %v", buyer.Inventory)
+	}
+	if term.Inventory["BREAD"] != 2 || term.Inventory["COAL"] != 3 {
+		t.Fatalf("shop: %v", term.Inventory)
+	}
+}
+
+func TestValidateBuy(t *testing.T) {
+	in := BuyInput{HasShop: true, Owner: "A1", Buyer: "A2", Listed: true, Count: 2, Stock: 2, Cost: 4, Funds: 4}
+	if ok, code, msg := ValidateBuy(in); !ok {
+		t.Fatalf("valid buy rejected: %s %s", code, msg)
+	}
+	own := in
+	own.Buyer = "A1"
+	if ok, code, _ := ValidateBuy(own); ok || code != "E_CONFLICT" {
+		t.Fatalf("owner buy: %q", code)
+	}
+	short := in
+	short.Stock = 1
+	if ok, code, _ := ValidateBuy(short); ok || code != "E_NO_RESOURCE" {
+		t.Fatalf("short stock: %q", code)
+	}
+	poor := in
+	poor.Funds = 3
+	if ok, code, _ := ValidateBuy(poor); ok || code != "E_NO_RESOURCE" {
+		t.Fatalf("short funds: %q", code)
+	}
+}
@@ -4,7 +4,9 @@ import "sort"
 
 const TradeFairMinPct = 50
 
-func ItemTradeValue(item string) int64 {
+// ReferenceValue is the prior unit value of an item in reference units. The price index
+// starts every item here and falls back to it until the item has traded enough.
+func ReferenceValue(item string) int64 {
 	switch item {
 	case "PLANK":
 		return 1
@@ -34,7 +36,9 @@ func ItemTradeValue(item string) int64 {
 		return 52
 	case "CLAIM_TOTEM":
 		return 26
-	case "CONTRACT_TERMINAL":
+	case "SHOP":
+		return 11
+	case "CONTRACT_TERMINAL", "MARKET_TERMINAL":
 		return 80
 	case "BERRIES":
 		return 1
@@ -5,8 +5,8 @@ import "testing"
 func TestTradeValueDeterministicAndPositive(t *testing.T) {
 	itemsA := map[string]int{"PLANK": 5, "IRON_INGOT": 1}
 	itemsB := map[string]int{"IRON_INGOT": 1, "PLANK": 5}
-	va := TradeValue(itemsA, ItemTradeValue)
-	vb := TradeValue(itemsB, ItemTradeValue)
+	va := TradeValue(itemsA, ReferenceValue)
+	vb := TradeValue(itemsB, ReferenceValue)
 	if va <= 0 || vb <= 0 {
 		t.Fatalf("expected positive values: va=%d vb=%d", va, vb)
 	}
@@ -16,14 +16,14 @@ func TestTradeValueDeterministicAndPositive(t *testing.T) {
 }
 
 func TestTradeMutualBenefit(t *testing.T) {
-	fairOffer := TradeValue(map[string]int{"PLANK": 5}, ItemTradeValue)
-	fairRequest := TradeValue(map[string]int{"IRON_INGOT": 1}, ItemTradeValue)
+	fairOffer := TradeValue(map[string]int{"PLANK": 5}, ReferenceValue)
+	fairRequest := TradeValue(map[string]int{"IRON_INGOT": 1}, ReferenceValue)
 	if !TradeMutualBenefit(fairOffer, fairRequest) {
 		t.Fatalf("expected fair trade to be mutual benefit")
 	}
 
-	unfairOffer := TradeValue(map[string]int{"PLANK": 1}, ItemTradeValue)
-	unfairRequest := TradeValue(map[string]int{"CRYSTAL_SHARD": 1}, ItemTradeValue)
+	unfairOffer := TradeValue(map[string]int{"PLANK": 1}, ReferenceValue)
+	unfairRequest := TradeValue(map[string]int{"CRYSTAL_SHARD": 1}, ReferenceValue)
 	if TradeMutualBenefit(unfairOffer, unfairRequest) {
 		t.Fatalf("expected unfair trade to be rejected")
 	}
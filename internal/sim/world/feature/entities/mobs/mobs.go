@@ -0,0 +1,156 @@
+package mobs
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+const (
+	StateWander = "WANDER"
+	StateGuard  = "GUARD"
+	StateChase  = "CHASE"
+	StateFlee   = "FLEE"
+)
+
+const (
+	MoveIntervalTicks   = 2
+	AttackIntervalTicks = 5
+	FleeTicks           = 20
+)
+
+type Drop struct {
+	Item  string
+	Count int
+}
+
+type Def struct {
+	Kind         string
+	Hostile      bool
+	MaxHP        int
+	Damage       int
+	AggroRadius  int // hostile: start chasing agents within this distance
+	LeashRadius  int // hostile: give up when the target leaves this distance from home
+	WanderRadius int // neutral: stay within this distance from home
+	FleeHP       int // flee once HP drops to this value or below (0 = never for hostiles)
+	Drops        []Drop
+}
+
+var defs = map[string]Def{
+	"BANDIT": {
+		Kind: "BANDIT", Hostile: true, MaxHP: 12, Damage: 2,
+		AggroRadius: 5, LeashRadius: 8, FleeHP: 3,
+		Drops: []Drop{{Item: "IRON_INGOT", Count: 1}, {Item: "BREAD", Count: 1}},
+	},
+	"RUIN_GUARDIAN": {
+		Kind: "RUIN_GUARDIAN", Hostile: true, MaxHP: 20, Damage: 3,
+		AggroRadius: 3, LeashRadius: 5,
+		Drops: []Drop{{Item: "CRYSTAL_SHARD", Count: 1}, {Item: "COPPER_INGOT", Count: 2}},
+	},
+	"CRYSTAL_BEETLE": {
+		Kind: "CRYSTAL_BEETLE", MaxHP: 6,
+		WanderRadius: 3,
+		Drops:        []Drop{{Item: "CRYSTAL_SHARD", Count: 1}},
+	},
+}
+
+func DefFor(kind string) (Def, bool) {
+	d, ok := defs[kind]
+	return d, ok
+}
+
+func Kinds() []string {
+	out := make([]string, 0, len(defs))
+	for k := range defs {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// IdleState is the state a mob returns to when it has nothing to chase or flee from.
+func IdleState(d Def) string {
+	if d.Hostile {
+		return StateGuard
+	}
+	return StateWander
+}
+
+// NextStateOnHurt decides how a mob reacts to being hit.
+func NextStateOnHurt(d Def, hp int) string {
+	if d.Hostile && hp > d.FleeHP {
+		return StateChase
+	}
+	return StateFlee
+}
+
+// WanderDir picks a deterministic step (dx, dz) for a wandering mob; (0, 0) means stay.
+func WanderDir(id string, nowTick uint64) (int, int) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	_, _ = h.Write([]byte{'|'})
+	_, _ = h.Write([]byte(strconv.FormatUint(nowTick, 10)))
+	switch h.Sum32() % 5 {
+	case 1:
+		return 1, 0
+	case 2:
+		return -1, 0
+	case 3:
+		return 0, 1
+	case 4:
+		return 0, -1
+	default:
+		return 0, 0
+	}
+}
+
+// StepOptions lists greedy single-axis steps toward (sign=+1) or away from (sign=-1) a target,
+// larger axis first. Callers take the first walkable option.
+func StepOptions(dx, dz int, sign int) [][2]int {
+	sx := sgn(dx) * sign
+	sz := sgn(dz) * sign
+	out := make([][2]int, 0, 4)
+	if abs(dx) >= abs(dz) {
+		if sx != 0 {
+			out = append(out, [2]int{sx, 0})
+		}
+		if sz != 0 {
+			out = append(out, [2]int{0, sz})
+		}
+	} else {
+		if sz != 0 {
+			out = append(out, [2]int{0, sz})
+		}
+		if sx != 0 {
+			out = append(out, [2]int{sx, 0})
+		}
+	}
+	if sign < 0 {
+		// Fleeing along a row/column can also sidestep.
+		if sx == 0 {
+			out = append(out, [2]int{1, 0}, [2]int{-1, 0})
+		}
+		if sz == 0 {
+			out = append(out, [2]int{0, 1}, [2]int{0, -1})
+		}
+	}
+	return out
+}
+
+func sgn(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,165 @@
+package mobs
+
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type fakeEnv struct {
+	agents    []*modelpkg.Agent
+	blocked   map[modelpkg.Vec3i]bool
+	sheltered map[modelpkg.Vec3i]bool
+	floor     map[modelpkg.Vec3i]int // column (y=0) -> stand height; default keeps the mob's height
+	respawned []string
+}
+
+func (f *fakeEnv) SortedAgents() []*modelpkg.Agent { return f.agents }
+
+func (f *fakeEnv) GetAgent(id string) *modelpkg.Agent {
+	for _, a := range f.agents {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func (f *fakeEnv) Walkable(pos modelpkg.Vec3i) (modelpkg.Vec3i, bool) {
+	if y, ok := f.floor[modelpkg.Vec3i{X: pos.X, Z: pos.Z}]; ok {
+		pos.Y = y
+	}
+	return pos, !f.blocked[pos]
+}
+
+func (f *fakeEnv) CanDamageAt(pos modelpkg.Vec3i, _ uint64) bool { return !f.sheltered[pos] }
+
+func (f *fakeEnv) Respawn(_ uint64, a *modelpkg.Agent, reason string) {
+	f.respawned = append(f.respawned, a.ID+":"+reason)
+	a.HP = 20
+}
+
+func (f *fakeEnv) AuditEvent(uint64, string, string, modelpkg.Vec3i, string, map[string]any) {}
+
+func TestWanderDirDeterministic(t *testing.T) {
+	for tick := uint64(0); tick < 20; tick++ {
+		dx1, dz1 := WanderDir("MB000001", tick)
+		dx2, dz2 := WanderDir("MB000001", tick)
+		if dx1 != dx2 || dz1 != dz2 {
+			t.Fatalf("tick %d: non-deterministic wander", tick)
+		}
+		if abs(dx1)+abs(dz1) > 1 {
+			t.Fatalf("tick %d: step too large (%d,%d)", tick, dx1, dz1)
+		}
+	}
+}
+
+func TestStepOptions(t *testing.T) {
+	got := StepOptions(3, -1, 1)
+	if len(got) != 2 || got[0] != [2]int{1, 0} || got[1] != [2]int{0, -1} {
+		t.Fatalf("toward: %v", got)
+	}
+	got = StepOptions(0, 2, -1)
+	if len(got) != 3 || got[0] != [2]int{0, -1} || got[1] != [2]int{1, 0} || got[2] != [2]int{-1, 0} {
+		t.Fatalf("away: %v", got)
+	}
+}
+
+func TestHostileGuardChasesAndStrikes(t *testing.T) {
+	a := &modelpkg.Agent{ID: "A1", HP: 20, Pos: modelpkg.Vec3i{X: 3, Z: 0}}
+	env := &fakeEnv{agents: []*modelpkg.Agent{a}}
+	m := &modelpkg.Mob{EntityID: "MB000001", Kind: "BANDIT", HP: 12, State: StateGuard}
+
+	Tick(env, m, 1)
+	if m.State != StateChase || m.TargetID != "A1" {
+		t.Fatalf("expected chase A1, got %s %q", m.State, m.TargetID)
+	}
+	for tick := uint64(2); tick <= 6; tick++ {
+		Tick(env, m, tick)
+	}
+	if modelpkg.Manhattan(m.Pos, a.Pos) != 1 {
+		t.Fatalf("expected mob adjacent, mob=%v agent=%v", m.Pos, a.Pos)
+	}
+	if a.HP != 18 {
+		t.Fatalf("agent hp=%d want 18", a.HP)
+	}
+}
+
+func TestHostileKillRespawnsAgent(t *testing.T) {
+	a := &modelpkg.Agent{ID: "A1", HP: 2, Pos: modelpkg.Vec3i{X: 1}}
+	env := &fakeEnv{agents: []*modelpkg.Agent{a}}
+	m := &modelpkg.Mob{EntityID: "MB000001", Kind: "BANDIT", HP: 12, State: StateChase, TargetID: "A1"}
+
+	Tick(env, m, 10)
+	if len(env.respawned) != 1 || env.respawned[0] != "A1:KILLED" {
+		t.Fatalf("respawned=%v", env.respawned)
+	}
+	if m.State != StateGuard || m.TargetID != "" {
+		t.Fatalf("expected guard after kill, got %s %q", m.State, m.TargetID)
+	}
+}
+
+func TestChaseGivesUpOutsideLeash(t *testing.T) {
+	a := &modelpkg.Agent{ID: "A1", HP: 20, Pos: modelpkg.Vec3i{X: 20}}
+	env := &fakeEnv{agents: []*modelpkg.Agent{a}}
+	m := &modelpkg.Mob{EntityID: "MB000001", Kind: "RUIN_GUARDIAN", HP: 20, State: StateChase, TargetID: "A1"}
+
+	Tick(env, m, 1)
+	if m.State != StateGuard {
+		t.Fatalf("state=%s want GUARD", m.State)
+	}
+}
+
+func TestHostileLeavesShelteredAgentsAlone(t *testing.T) {
+	a := &modelpkg.Agent{ID: "A1", HP: 20, Pos: modelpkg.Vec3i{X: 1}}
+	env := &fakeEnv{agents: []*modelpkg.Agent{a}, sheltered: map[modelpkg.Vec3i]bool{{X: 1}: true}}
+	guard := &modelpkg.Mob{EntityID: "MB000001", Kind: "BANDIT", HP: 12, State: StateGuard}
+	Tick(env, guard, 1)
+	if guard.State != StateGuard {
+		t.Fatalf("guard picked a sheltered target: %s %q", guard.State, guard.TargetID)
+	}
+
+	chaser := &modelpkg.Mob{EntityID: "MB000002", Kind: "BANDIT", HP: 12, State: StateChase, TargetID: "A1"}
+	Tick(env, chaser, 10)
+	if chaser.State != StateGuard || a.HP != 20 {
+		t.Fatalf("chase kept on sheltered target: state=%s hp=%d", chaser.State, a.HP)
+	}
+}
+
+func TestOnHurtFleesAtLowHP(t *testing.T) {
+	m := &modelpkg.Mob{EntityID: "MB000001", Kind: "BANDIT", HP: 3, State: StateGuard}
+	OnHurt(m, "A1", 5)
+	if m.State != StateFlee || m.TargetID != "A1" || m.StateTick != 5 {
+		t.Fatalf("unexpected state %+v", m)
+	}
+
+	beetle := &modelpkg.Mob{EntityID: "MB000002", Kind: "CRYSTAL_BEETLE", HP: 5, State: StateWander}
+	OnHurt(beetle, "A1", 5)
+	if beetle.State != StateFlee {
+		t.Fatalf("neutral mob should flee, got %s", beetle.State)
+	}
+}
+
+func TestWanderStaysNearHome(t *testing.T) {
+	env := &fakeEnv{}
+	m := &modelpkg.Mob{EntityID: "MB000003", Kind: "CRYSTAL_BEETLE", HP: 6, State: StateWander}
+	for tick := uint64(0); tick < 400; tick++ {
+		Tick(env, m, tick)
+		if modelpkg.Manhattan(m.Pos, m.Home) > 3 {
+			t.Fatalf("tick %d: beetle left wander radius: %v", tick, m.Pos)
+		}
+	}
+}
+
+func TestChaseStepsOntoStandHeight(t *testing.T) {
+	a := &modelpkg.Agent{ID: "A1", HP: 20, Pos: modelpkg.Vec3i{X: 3, Y: 1}}
+	env := &fakeEnv{agents: []*modelpkg.Agent{a}, floor: map[modelpkg.Vec3i]int{{X: 1}: 1, {X: 2}: 1}}
+	m := &modelpkg.Mob{EntityID: "MB000001", Kind: "BANDIT", HP: 12, State: StateChase, TargetID: "A1"}
+
+	for tick := uint64(0); tick < 10 && m.Pos.X == 0; tick++ {
+		Tick(env, m, tick)
+	}
+	if m.Pos != (modelpkg.Vec3i{X: 1, Y: 1}) {
+		t.Fatalf("mob pos=%v, want standing on the raised floor", m.Pos)
+	}
+}
@@ -0,0 +1,148 @@
+package mobs
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	damagepkg "voxelcraft.ai/internal/sim/world/feature/combat/damage"
+	respawnpkg "voxelcraft.ai/internal/sim/world/feature/survival/respawn"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+type TickEnv interface {
+	SortedAgents() []*modelpkg.Agent
+	GetAgent(agentID string) *modelpkg.Agent
+	// Walkable reports whether a mob at height pos.Y may step into column (pos.X,pos.Z)
+	// (in bounds, not solid, no other mob) and returns the cell it stands on there.
+	Walkable(pos modelpkg.Vec3i) (modelpkg.Vec3i, bool)
+	// CanDamageAt reports whether a mob may hurt an agent standing at pos; land that
+	// forbids damage shelters agents from mobs as well.
+	CanDamageAt(pos modelpkg.Vec3i, nowTick uint64) bool
+	Respawn(nowTick uint64, a *modelpkg.Agent, reason string)
+	AuditEvent(nowTick uint64, actor string, action string, pos modelpkg.Vec3i, reason string, details map[string]any)
+}
+
+// Tick advances one mob by one tick. Callers iterate mobs in sorted id order.
+func Tick(env TickEnv, m *modelpkg.Mob, nowTick uint64) {
+	d, ok := DefFor(m.Kind)
+	if !ok || m.HP <= 0 {
+		return
+	}
+	moveTick := nowTick%MoveIntervalTicks == 0
+
+	switch m.State {
+	case StateFlee:
+		threat := env.GetAgent(m.TargetID)
+		if threat == nil || nowTick-m.StateTick >= FleeTicks {
+			setState(m, IdleState(d), "", nowTick)
+			return
+		}
+		if moveTick {
+			step(env, m, threat.Pos.X-m.Pos.X, threat.Pos.Z-m.Pos.Z, -1)
+		}
+
+	case StateChase:
+		target := env.GetAgent(m.TargetID)
+		if target == nil || modelpkg.Manhattan(target.Pos, m.Home) > d.LeashRadius || !env.CanDamageAt(target.Pos, nowTick) {
+			setState(m, IdleState(d), "", nowTick)
+			return
+		}
+		if modelpkg.Manhattan(m.Pos, target.Pos) <= 1 {
+			if m.AttackTick == 0 || nowTick-m.AttackTick >= AttackIntervalTicks {
+				strike(env, m, d, target, nowTick)
+			}
+			return
+		}
+		if moveTick {
+			step(env, m, target.Pos.X-m.Pos.X, target.Pos.Z-m.Pos.Z, 1)
+		}
+
+	case StateGuard:
+		if target := pickTarget(env, m, d, nowTick); target != nil {
+			setState(m, StateChase, target.ID, nowTick)
+			return
+		}
+		if moveTick && m.Pos != m.Home {
+			step(env, m, m.Home.X-m.Pos.X, m.Home.Z-m.Pos.Z, 1)
+		}
+
+	default: // StateWander
+		if !moveTick {
+			return
+		}
+		dx, dz := WanderDir(m.EntityID, nowTick)
+		if dx == 0 && dz == 0 {
+			return
+		}
+		to, ok := env.Walkable(modelpkg.Vec3i{X: m.Pos.X + dx, Y: m.Pos.Y, Z: m.Pos.Z + dz})
+		if !ok || modelpkg.Manhattan(to, m.Home) > d.WanderRadius {
+			return
+		}
+		m.Pos = to
+	}
+}
+
+// OnHurt switches a mob into CHASE (retaliate) or FLEE after being hit by attackerID.
+func OnHurt(m *modelpkg.Mob, attackerID string, nowTick uint64) {
+	d, ok := DefFor(m.Kind)
+	if !ok {
+		return
+	}
+	setState(m, NextStateOnHurt(d, m.HP), attackerID, nowTick)
+}
+
+func setState(m *modelpkg.Mob, state string, targetID string, nowTick uint64) {
+	m.State = state
+	m.TargetID = targetID
+	m.StateTick = nowTick
+}
+
+// pickTarget returns the closest agent within aggro range that is also inside the leash and
+// not sheltered by land that forbids damage (ties by id).
+func pickTarget(env TickEnv, m *modelpkg.Mob, d Def, nowTick uint64) *modelpkg.Agent {
+	if !d.Hostile || d.AggroRadius <= 0 {
+		return nil
+	}
+	var best *modelpkg.Agent
+	bestDist := 0
+	for _, a := range env.SortedAgents() {
+		if a == nil || a.HP <= 0 {
+			continue
+		}
+		dist := modelpkg.Manhattan(m.Pos, a.Pos)
+		if dist > d.AggroRadius || modelpkg.Manhattan(a.Pos, m.Home) > d.LeashRadius || !env.CanDamageAt(a.Pos, nowTick) {
+			continue
+		}
+		if best == nil || dist < bestDist {
+			best = a
+			bestDist = dist
+		}
+	}
+	return best
+}
+
+func step(env TickEnv, m *modelpkg.Mob, dx, dz int, sign int) {
+	for _, s := range StepOptions(dx, dz, sign) {
+		if to, ok := env.Walkable(modelpkg.Vec3i{X: m.Pos.X + s[0], Y: m.Pos.Y, Z: m.Pos.Z + s[1]}); ok {
+			m.Pos = to
+			return
+		}
+	}
+}
+
+func strike(env TickEnv, m *modelpkg.Mob, d Def, target *modelpkg.Agent, nowTick uint64) {
+	m.AttackTick = nowTick
+	hit := damagepkg.Reduce(d.Damage, damagepkg.ArmorPoints(target.Equipment.Armor))
+	target.HP -= hit
+	if target.HP < 0 {
+		target.HP = 0
+	}
+	target.AddEvent(protocol.Event{"t": nowTick, "type": "DAMAGE", "kind": "MOB", "mob_id": m.EntityID, "mob_kind": m.Kind, "hp": target.HP})
+	if target.HP > 0 {
+		return
+	}
+	env.AuditEvent(nowTick, m.EntityID, "KILL", target.Pos, "MOB", map[string]any{
+		"target_id": target.ID,
+		"mob_kind":  m.Kind,
+	})
+	env.Respawn(nowTick, target, respawnpkg.ReasonKilled)
+	setState(m, IdleState(d), "", nowTick)
+}
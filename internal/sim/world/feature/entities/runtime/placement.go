@@ -9,12 +9,18 @@ type PlacementEffects struct {
 	ConveyorDZ     int
 	EnsureSwitch   bool
 	SwitchOn       bool
+	ResetMachine   bool
 }
 
 func EffectsForPlacedBlock(blockName string) PlacementEffects {
 	switch blockName {
-	case "CHEST", "FURNACE", "CONTRACT_TERMINAL":
+	case "CHEST", "CONTRACT_TERMINAL", "MARKET_TERMINAL", "SHOP":
 		return PlacementEffects{ContainerType: blockName}
+	case "FURNACE", "AUTO_CRAFTER":
+		return PlacementEffects{ContainerType: blockName, ResetMachine: true}
+	case "HOPPER":
+		// Like conveyors, blueprint-placed hoppers face +X.
+		return PlacementEffects{ResetMachine: true}
 	case "BULLETIN_BOARD":
 		return PlacementEffects{EnsureBoard: true}
 	case "SIGN":
@@ -12,6 +12,8 @@ func TestEffectsForPlacedBlock(t *testing.T) {
 		{"SIGN", func(e PlacementEffects) bool { return e.EnsureSign }},
 		{"CONVEYOR", func(e PlacementEffects) bool { return e.EnsureConveyor && e.ConveyorDX == 1 && e.ConveyorDZ == 0 }},
 		{"SWITCH", func(e PlacementEffects) bool { return e.EnsureSwitch && !e.SwitchOn }},
+		{"AUTO_CRAFTER", func(e PlacementEffects) bool { return e.ContainerType == "AUTO_CRAFTER" && e.ResetMachine }},
+		{"HOPPER", func(e PlacementEffects) bool { return e.ContainerType == "" && e.ResetMachine }},
 		{"PLANK", func(e PlacementEffects) bool { return e == (PlacementEffects{}) }},
 	}
 	for _, tc := range cases {
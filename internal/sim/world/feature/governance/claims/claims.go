@@ -27,10 +27,6 @@ func DefaultFlags(claimType string) Flags {
 	}
 }
 
-func CanActionWithCurfew(baseAllowed bool, curfewEnabled bool, now, start, end float64) bool {
-	return rules.CanActionWithCurfew(baseAllowed, curfewEnabled, now, start, end)
-}
-
 func InWindow(t, start, end float64) bool {
 	return rules.InWindow(t, start, end)
 }
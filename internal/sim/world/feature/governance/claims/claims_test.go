@@ -9,15 +9,6 @@ func TestDefaultFlagsHomestead(t *testing.T) {
 	}
 }
 
-func TestCanActionWithCurfew(t *testing.T) {
-	if CanActionWithCurfew(true, true, 0.3, 0.2, 0.4) {
-		t.Fatalf("expected action denied during curfew window")
-	}
-	if !CanActionWithCurfew(true, true, 0.5, 0.2, 0.4) {
-		t.Fatalf("expected action allowed outside curfew window")
-	}
-}
-
 func TestCoreRadiusAndContains(t *testing.T) {
 	if got := CoreRadius(12, 16); got != 12 {
 		t.Fatalf("core radius cap mismatch: got %d want 12", got)
@@ -39,10 +39,6 @@ func HandleTaskClaimLand(env ClaimTaskEnv, ar ClaimTaskActionResultFn, a *modelp
 	if r > 128 {
 		r = 128
 	}
-	if tr.Anchor[1] != 0 {
-		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "2D world requires y==0"))
-		return
-	}
 	anchor := modelpkg.Vec3i{X: tr.Anchor[0], Y: tr.Anchor[1], Z: tr.Anchor[2]}
 	if !env.InBounds(anchor) {
 		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "out of bounds"))
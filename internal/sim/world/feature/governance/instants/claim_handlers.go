@@ -13,6 +13,7 @@ type ClaimInstantEnv interface {
 	BlockNameAt(pos modelpkg.Vec3i) string
 	ClaimRecords() []ClaimRecord
 	OwnerExists(ownerID string) bool
+	LandPledged(landID string) bool
 	AuditClaimEvent(nowTick uint64, actorID string, action string, pos modelpkg.Vec3i, reason string, details map[string]any)
 }
 
@@ -167,6 +168,10 @@ func HandleDeedLand(env ClaimInstantEnv, ar OrgActionResultFn, a *modelpkg.Agent
 		a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "new owner not found"))
 		return
 	}
+	if env.LandPledged(land.LandID) {
+		a.AddEvent(ar(nowTick, inst.ID, false, "E_CONFLICT", "land pledged as loan collateral"))
+		return
+	}
 	land.Owner = newOwner
 	a.AddEvent(ar(nowTick, inst.ID, true, "", "ok"))
 }
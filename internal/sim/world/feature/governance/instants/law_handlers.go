@@ -11,7 +11,7 @@ import (
 type LawInstantEnv interface {
 	GetLand(landID string) *modelpkg.LandClaim
 	IsLandMember(agentID string, land *modelpkg.LandClaim) bool
-	GetLawTemplateTitle(templateID string) (string, bool)
+	GetLawTemplate(templateID string) (lawspkg.Template, bool)
 	ItemExists(itemID string) bool
 	NewLawID() string
 	PutLaw(law *lawspkg.Law)
@@ -43,12 +43,12 @@ func HandleProposeLaw(env LawInstantEnv, ar OrgActionResultFn, hooks LawInstantH
 		a.AddEvent(ar(nowTick, inst.ID, false, "E_NO_PERMISSION", "not eligible"))
 		return
 	}
-	templateTitle, ok := env.GetLawTemplateTitle(inst.TemplateID)
+	tmpl, ok := env.GetLawTemplate(inst.TemplateID)
 	if !ok {
 		a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "unknown law template"))
 		return
 	}
-	params, err := lawspkg.NormalizeLawParams(inst.TemplateID, inst.Params, env.ItemExists)
+	params, err := lawspkg.NormalizeLawParams(tmpl, inst.Params, env.ItemExists)
 	if err != nil {
 		if errors.Is(err, lawspkg.ErrUnsupportedLawTemplate) {
 			a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "unsupported template"))
@@ -58,7 +58,7 @@ func HandleProposeLaw(env LawInstantEnv, ar OrgActionResultFn, hooks LawInstantH
 		return
 	}
 
-	title := lawspkg.ResolveLawTitle(inst.Title, templateTitle)
+	title := lawspkg.ResolveLawTitle(inst.Title, tmpl.Title)
 	lawID := env.NewLawID()
 	timeline := lawspkg.BuildLawTimeline(nowTick, lawNoticeTicks, lawVoteTicks)
 	law := &lawspkg.Law{
@@ -3,10 +3,9 @@ package laws
 import (
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 
-	"voxelcraft.ai/internal/sim/world/feature/governance/claims"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
 var ErrUnsupportedLawTemplate = errors.New("unsupported template")
@@ -107,224 +106,42 @@ func ValidateVoteInput(allowLaws bool, lawID, choice string) (ok bool, code stri
 	return true, "", ""
 }
 
-type LandState struct {
-	MarketTax         float64
-	CurfewEnabled     bool
-	CurfewStart       float64
-	CurfewEnd         float64
-	FineBreakEnabled  bool
-	FineBreakItem     string
-	FineBreakPerBlock int
-	AccessPassEnabled bool
-	AccessTicketItem  string
-	AccessTicketCost  int
+// Template is a law template from configs/law_templates.json.
+type Template struct {
+	ID     string
+	Title  string
+	Params map[string]string
+	Limits map[string][2]float64
+	Rules  []rules.RuleSpec
 }
 
-func NormalizeLawParams(templateID string, params map[string]interface{}, itemExists func(string) bool) (map[string]string, error) {
-	switch templateID {
-	case "MARKET_TAX":
-		f, err := claims.ParamFloat(params, "market_tax")
-		if err != nil {
-			return nil, err
-		}
-		if f < 0 {
-			f = 0
-		}
-		if f > 0.25 {
-			f = 0.25
-		}
-		return map[string]string{"market_tax": claims.FloatToCanonString(f)}, nil
-	case "CURFEW_NO_BUILD":
-		s, err := claims.ParamFloat(params, "start_time")
-		if err != nil {
-			return nil, err
-		}
-		en, err := claims.ParamFloat(params, "end_time")
-		if err != nil {
-			return nil, err
-		}
-		if s < 0 {
-			s = 0
-		}
-		if s > 1 {
-			s = 1
-		}
-		if en < 0 {
-			en = 0
-		}
-		if en > 1 {
-			en = 1
-		}
-		return map[string]string{
-			"start_time": claims.FloatToCanonString(s),
-			"end_time":   claims.FloatToCanonString(en),
-		}, nil
-	case "FINE_BREAK_PER_BLOCK":
-		item, err := claims.ParamString(params, "fine_item")
-		if err != nil {
-			return nil, err
-		}
-		if itemExists != nil && !itemExists(item) {
-			return nil, fmt.Errorf("unknown fine_item")
-		}
-		n, err := claims.ParamInt(params, "fine_per_block")
-		if err != nil {
-			return nil, err
-		}
-		if n < 0 {
-			n = 0
-		}
-		if n > 100 {
-			n = 100
-		}
-		return map[string]string{
-			"fine_item":      item,
-			"fine_per_block": fmt.Sprintf("%d", n),
-		}, nil
-	case "ACCESS_PASS_CORE":
-		item, err := claims.ParamString(params, "ticket_item")
-		if err != nil {
-			return nil, err
-		}
-		if itemExists != nil && !itemExists(item) {
-			return nil, fmt.Errorf("unknown ticket_item")
-		}
-		n, err := claims.ParamInt(params, "ticket_cost")
-		if err != nil {
-			return nil, err
-		}
-		if n < 0 {
-			n = 0
-		}
-		if n > 64 {
-			n = 64
-		}
-		return map[string]string{
-			"ticket_item": item,
-			"ticket_cost": fmt.Sprintf("%d", n),
-		}, nil
-	default:
+func NormalizeLawParams(tmpl Template, params map[string]interface{}, itemExists func(string) bool) (map[string]string, error) {
+	if len(tmpl.Rules) == 0 {
 		return nil, ErrUnsupportedLawTemplate
 	}
+	out, err := rules.NormalizeParams(tmpl.Params, tmpl.Limits, params, itemExists)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rules.Compile(tmpl.Rules, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func ApplyLawTemplate(templateID string, params map[string]string, in LandState) (LandState, error) {
-	out := in
-	switch templateID {
-	case "MARKET_TAX":
-		raw := params["market_tax"]
-		if raw == "" {
-			return in, fmt.Errorf("missing market_tax")
-		}
-		f, err := strconv.ParseFloat(raw, 64)
-		if err != nil {
-			return in, fmt.Errorf("bad market_tax")
-		}
-		if f < 0 {
-			f = 0
-		}
-		if f > 0.25 {
-			f = 0.25
-		}
-		out.MarketTax = f
-		return out, nil
-	case "CURFEW_NO_BUILD":
-		sRaw := params["start_time"]
-		eRaw := params["end_time"]
-		if sRaw == "" || eRaw == "" {
-			return in, fmt.Errorf("missing start_time/end_time")
-		}
-		s, err := strconv.ParseFloat(sRaw, 64)
-		if err != nil {
-			return in, fmt.Errorf("bad start_time")
-		}
-		en, err := strconv.ParseFloat(eRaw, 64)
-		if err != nil {
-			return in, fmt.Errorf("bad end_time")
-		}
-		if s < 0 {
-			s = 0
-		}
-		if s > 1 {
-			s = 1
-		}
-		if en < 0 {
-			en = 0
-		}
-		if en > 1 {
-			en = 1
-		}
-		if s == en {
-			out.CurfewEnabled = false
-			out.CurfewStart = 0
-			out.CurfewEnd = 0
-			return out, nil
-		}
-		out.CurfewEnabled = true
-		out.CurfewStart = s
-		out.CurfewEnd = en
-		return out, nil
-	case "FINE_BREAK_PER_BLOCK":
-		item := strings.TrimSpace(params["fine_item"])
-		raw := strings.TrimSpace(params["fine_per_block"])
-		if item == "" || raw == "" {
-			out.FineBreakEnabled = false
-			out.FineBreakItem = ""
-			out.FineBreakPerBlock = 0
-			return in, fmt.Errorf("missing fine_item/fine_per_block")
-		}
-		n, err := strconv.Atoi(raw)
-		if err != nil {
-			return in, fmt.Errorf("bad fine_per_block")
-		}
-		if n < 0 {
-			n = 0
-		}
-		if n > 100 {
-			n = 100
-		}
-		if n == 0 {
-			out.FineBreakEnabled = false
-			out.FineBreakItem = ""
-			out.FineBreakPerBlock = 0
-			return out, nil
-		}
-		out.FineBreakEnabled = true
-		out.FineBreakItem = item
-		out.FineBreakPerBlock = n
-		return out, nil
-	case "ACCESS_PASS_CORE":
-		item := strings.TrimSpace(params["ticket_item"])
-		raw := strings.TrimSpace(params["ticket_cost"])
-		if item == "" || raw == "" {
-			out.AccessPassEnabled = false
-			out.AccessTicketItem = ""
-			out.AccessTicketCost = 0
-			return in, fmt.Errorf("missing ticket_item/ticket_cost")
-		}
-		n, err := strconv.Atoi(raw)
-		if err != nil {
-			return in, fmt.Errorf("bad ticket_cost")
-		}
-		if n < 0 {
-			n = 0
-		}
-		if n > 64 {
-			n = 64
-		}
-		if n == 0 {
-			out.AccessPassEnabled = false
-			out.AccessTicketItem = ""
-			out.AccessTicketCost = 0
-			return out, nil
-		}
-		out.AccessPassEnabled = true
-		out.AccessTicketItem = item
-		out.AccessTicketCost = n
-		return out, nil
-	default:
-		return in, ErrUnsupportedLawTemplate
+// CompilePolicy turns an activated law into the policy it contributes to its land.
+func CompilePolicy(tmpl Template, law *Law) (rules.Policy, error) {
+	if law == nil {
+		return rules.Policy{}, fmt.Errorf("nil law")
+	}
+	if len(tmpl.Rules) == 0 {
+		return rules.Policy{}, ErrUnsupportedLawTemplate
+	}
+	compiled, err := rules.Compile(tmpl.Rules, law.Params)
+	if err != nil {
+		return rules.Policy{}, err
 	}
+	return rules.Policy{LawID: law.LawID, TemplateID: law.TemplateID, Rules: compiled}, nil
 }
 
 func maxInt(a, b int) int {
@@ -5,27 +5,48 @@ import (
 
 	lawspkg "voxelcraft.ai/internal/sim/world/feature/governance/laws"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
+var marketTaxTemplate = lawspkg.Template{
+	ID:     "MARKET_TAX",
+	Params: map[string]string{"market_tax": rules.TypeFloat},
+	Rules: []rules.RuleSpec{{
+		When: rules.When{Actions: []string{rules.ActionTrade}},
+		Then: rules.Then{Effect: rules.EffectTax, Rate: "$market_tax"},
+	}},
+}
+
 func TestApplyTemplateToLandMarketTax(t *testing.T) {
 	law := &lawspkg.Law{
+		LawID:      "LAW000001",
 		TemplateID: "MARKET_TAX",
 		Params:     map[string]string{"market_tax": "0.12"},
 	}
 	land := &modelpkg.LandClaim{}
-	if err := ApplyTemplateToLand(law, land); err != nil {
+	if err := ApplyTemplateToLand(law, marketTaxTemplate, land); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d := rules.Evaluate(land.Policies, rules.Context{Action: rules.ActionTrade, BaseAllowed: true})
+	if d.TaxRate != 0.12 {
+		t.Fatalf("expected market tax 0.12, got %v", d.TaxRate)
+	}
+
+	// A later law of the same template replaces the earlier one; a zero rate removes it.
+	law2 := &lawspkg.Law{LawID: "LAW000002", TemplateID: "MARKET_TAX", Params: map[string]string{"market_tax": "0"}}
+	if err := ApplyTemplateToLand(law2, marketTaxTemplate, land); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if land.MarketTax != 0.12 {
-		t.Fatalf("expected market tax 0.12, got %v", land.MarketTax)
+	if len(land.Policies) != 0 {
+		t.Fatalf("expected policy removed, got %+v", land.Policies)
 	}
 }
 
 func TestApplyTemplateToLandNilGuards(t *testing.T) {
-	if err := ApplyTemplateToLand(nil, &modelpkg.LandClaim{}); err == nil {
+	if err := ApplyTemplateToLand(nil, marketTaxTemplate, &modelpkg.LandClaim{}); err == nil {
 		t.Fatalf("expected nil law error")
 	}
-	if err := ApplyTemplateToLand(&lawspkg.Law{}, nil); err == nil {
+	if err := ApplyTemplateToLand(&lawspkg.Law{}, marketTaxTemplate, nil); err == nil {
 		t.Fatalf("expected nil land error")
 	}
 }
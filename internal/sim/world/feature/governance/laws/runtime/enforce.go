@@ -0,0 +1,38 @@
+package runtime
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
+)
+
+type EnforceHooks struct {
+	TransferToLandOwner func(ownerID string, item string, count int)
+	BumpRepLaw          func(agentID string, delta int)
+}
+
+// SettleConsequences charges the fines of d (each capped at what the actor holds) to the
+// land owner and applies the reputation change. Tickets are charged by the caller, since
+// a missing ticket blocks the action instead.
+func SettleConsequences(a *modelpkg.Agent, land *modelpkg.LandClaim, d rules.Decision, reason string, nowTick uint64, hooks EnforceHooks) {
+	if a == nil || land == nil {
+		return
+	}
+	for _, f := range d.Fines {
+		pay := f.Amount
+		if have := a.Inventory[f.Item]; have < pay {
+			pay = have
+		}
+		if pay <= 0 {
+			continue
+		}
+		a.Inventory[f.Item] -= pay
+		if hooks.TransferToLandOwner != nil {
+			hooks.TransferToLandOwner(land.Owner, f.Item, pay)
+		}
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "FINE", "land_id": land.LandID, "law_id": f.LawID, "item": f.Item, "count": pay, "reason": reason})
+	}
+	if d.RepDelta != 0 && hooks.BumpRepLaw != nil {
+		hooks.BumpRepLaw(a.ID, d.RepDelta)
+	}
+}
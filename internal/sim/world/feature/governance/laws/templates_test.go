@@ -3,8 +3,39 @@ package laws
 import (
 	"errors"
 	"testing"
+
+	"voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
+var testTemplates = map[string]Template{
+	"MARKET_TAX": {
+		ID:     "MARKET_TAX",
+		Params: map[string]string{"market_tax": rules.TypeFloat},
+		Limits: map[string][2]float64{"market_tax": {0, 0.25}},
+		Rules: []rules.RuleSpec{{
+			When: rules.When{Actions: []string{rules.ActionTrade}},
+			Then: rules.Then{Effect: rules.EffectTax, Rate: "$market_tax"},
+		}},
+	},
+	"CURFEW_NO_BUILD": {
+		ID:     "CURFEW_NO_BUILD",
+		Params: map[string]string{"start_time": rules.TypeFloat01, "end_time": rules.TypeFloat01},
+		Rules: []rules.RuleSpec{{
+			When: rules.When{Actions: []string{rules.ActionBuild}, Time: []rules.Arg{"$start_time", "$end_time"}},
+			Then: rules.Then{Effect: rules.EffectDeny},
+		}},
+	},
+	"FINE_BREAK_PER_BLOCK": {
+		ID:     "FINE_BREAK_PER_BLOCK",
+		Params: map[string]string{"fine_item": rules.TypeItemID, "fine_per_block": rules.TypeInt},
+		Limits: map[string][2]float64{"fine_per_block": {0, 100}},
+		Rules: []rules.RuleSpec{{
+			When: rules.When{Actions: []string{rules.ActionBreak}, Actor: rules.RoleVisitor, Outcome: rules.OutcomeDenied},
+			Then: rules.Then{Effect: rules.EffectFine, Item: "$fine_item", Amount: "$fine_per_block"},
+		}},
+	},
+}
+
 func TestNormalizeLawParams(t *testing.T) {
 	itemExists := func(item string) bool { return item == "IRON_INGOT" }
 
@@ -12,17 +43,20 @@ func TestNormalizeLawParams(t *testing.T) {
 		name       string
 		templateID string
 		params     map[string]interface{}
+		want       map[string]string
 		wantErr    bool
 	}{
 		{
-			name:       "market tax",
+			name:       "market tax clamps",
 			templateID: "MARKET_TAX",
 			params:     map[string]interface{}{"market_tax": 0.31},
+			want:       map[string]string{"market_tax": "0.25"},
 		},
 		{
 			name:       "curfew",
 			templateID: "CURFEW_NO_BUILD",
-			params:     map[string]interface{}{"start_time": 0.2, "end_time": 0.4},
+			params:     map[string]interface{}{"start_time": 0.2, "end_time": 1.4},
+			want:       map[string]string{"start_time": "0.2", "end_time": "1"},
 		},
 		{
 			name:       "fine unknown item",
@@ -31,13 +65,14 @@ func TestNormalizeLawParams(t *testing.T) {
 			wantErr:    true,
 		},
 		{
-			name:       "access pass",
-			templateID: "ACCESS_PASS_CORE",
-			params:     map[string]interface{}{"ticket_item": "IRON_INGOT", "ticket_cost": 12},
+			name:       "fine int",
+			templateID: "FINE_BREAK_PER_BLOCK",
+			params:     map[string]interface{}{"fine_item": "IRON_INGOT", "fine_per_block": 250.7},
+			want:       map[string]string{"fine_item": "IRON_INGOT", "fine_per_block": "100"},
 		},
 		{
-			name:       "unsupported template",
-			templateID: "NO_SUCH",
+			name:       "missing param",
+			templateID: "MARKET_TAX",
 			params:     map[string]interface{}{},
 			wantErr:    true,
 		},
@@ -45,58 +80,52 @@ func TestNormalizeLawParams(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NormalizeLawParams(tt.templateID, tt.params, itemExists)
-			if tt.wantErr && err == nil {
-				t.Fatalf("expected error")
+			got, err := NormalizeLawParams(testTemplates[tt.templateID], tt.params, itemExists)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
 			}
-			if !tt.wantErr && err != nil {
+			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Fatalf("%s=%q want %q", k, got[k], v)
+				}
+			}
 		})
 	}
 
-	_, err := NormalizeLawParams("NO_SUCH", map[string]interface{}{}, itemExists)
+	_, err := NormalizeLawParams(Template{ID: "NO_SUCH"}, map[string]interface{}{}, itemExists)
 	if !errors.Is(err, ErrUnsupportedLawTemplate) {
 		t.Fatalf("want ErrUnsupportedLawTemplate, got %v", err)
 	}
 }
 
-func TestApplyLawTemplate(t *testing.T) {
-	base := LandState{}
-	out, err := ApplyLawTemplate("MARKET_TAX", map[string]string{"market_tax": "0.3"}, base)
-	if err != nil {
-		t.Fatalf("apply market tax: %v", err)
-	}
-	if out.MarketTax != 0.25 {
-		t.Fatalf("market tax clamp failed: got %v", out.MarketTax)
-	}
-
-	out, err = ApplyLawTemplate("CURFEW_NO_BUILD", map[string]string{"start_time": "0.2", "end_time": "0.8"}, base)
+func TestCompilePolicy(t *testing.T) {
+	law := &Law{LawID: "LAW000001", TemplateID: "CURFEW_NO_BUILD", Params: map[string]string{"start_time": "0.2", "end_time": "0.8"}}
+	p, err := CompilePolicy(testTemplates["CURFEW_NO_BUILD"], law)
 	if err != nil {
-		t.Fatalf("apply curfew: %v", err)
+		t.Fatalf("compile curfew: %v", err)
 	}
-	if !out.CurfewEnabled || out.CurfewStart != 0.2 || out.CurfewEnd != 0.8 {
-		t.Fatalf("curfew fields mismatch: %+v", out)
+	if len(p.Rules) != 1 || !p.Rules[0].TimeWindow || p.Rules[0].TimeFrom != 0.2 || p.Rules[0].TimeTo != 0.8 {
+		t.Fatalf("curfew rules mismatch: %+v", p.Rules)
 	}
 
-	out, err = ApplyLawTemplate("FINE_BREAK_PER_BLOCK", map[string]string{"fine_item": "IRON_INGOT", "fine_per_block": "9"}, base)
-	if err != nil {
-		t.Fatalf("apply fine break: %v", err)
-	}
-	if !out.FineBreakEnabled || out.FineBreakItem != "IRON_INGOT" || out.FineBreakPerBlock != 9 {
-		t.Fatalf("fine break mismatch: %+v", out)
+	// start == end disables the curfew.
+	law.Params = map[string]string{"start_time": "0.5", "end_time": "0.5"}
+	if p, err = CompilePolicy(testTemplates["CURFEW_NO_BUILD"], law); err != nil || len(p.Rules) != 0 {
+		t.Fatalf("expected empty policy, got %+v err=%v", p, err)
 	}
 
-	out, err = ApplyLawTemplate("ACCESS_PASS_CORE", map[string]string{"ticket_item": "IRON_INGOT", "ticket_cost": "11"}, base)
+	law = &Law{LawID: "LAW000002", TemplateID: "FINE_BREAK_PER_BLOCK", Params: map[string]string{"fine_item": "IRON_INGOT", "fine_per_block": "9"}}
+	p, err = CompilePolicy(testTemplates["FINE_BREAK_PER_BLOCK"], law)
 	if err != nil {
-		t.Fatalf("apply access pass: %v", err)
+		t.Fatalf("compile fine: %v", err)
 	}
-	if !out.AccessPassEnabled || out.AccessTicketItem != "IRON_INGOT" || out.AccessTicketCost != 11 {
-		t.Fatalf("access pass mismatch: %+v", out)
-	}
-
-	_, err = ApplyLawTemplate("NO_SUCH", map[string]string{}, base)
-	if !errors.Is(err, ErrUnsupportedLawTemplate) {
-		t.Fatalf("want ErrUnsupportedLawTemplate, got %v", err)
+	if len(p.Rules) != 1 || p.Rules[0].Item != "IRON_INGOT" || p.Rules[0].Amount != 9 || p.LawID != "LAW000002" {
+		t.Fatalf("fine rules mismatch: %+v", p)
 	}
 }
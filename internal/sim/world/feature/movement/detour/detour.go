@@ -8,26 +8,47 @@ type Pos struct {
 	Z int
 }
 
-func DetourStep2D(start, target Pos, maxDepth int, inBounds func(Pos) bool, isSolid func(Pos) bool) (Pos, bool) {
-	next, ok := logicmovement.DetourStep2D(
+func FindPath2D(start, target Pos, tolerance int, maxNodes int, inBounds func(Pos) bool, isSolid func(Pos) bool, stepCost func(Pos) int) ([]Pos, bool) {
+	var step func(from, to Pos) (Pos, bool)
+	if isSolid != nil {
+		step = func(_, to Pos) (Pos, bool) { return to, !isSolid(to) }
+	}
+	start.Y = 0
+	return FindPathSurface(start, target, tolerance, maxNodes, inBounds, step, stepCost)
+}
+
+func FindPathSurface(start, target Pos, tolerance int, maxNodes int, inBounds func(Pos) bool, step func(from, to Pos) (Pos, bool), stepCost func(Pos) int) ([]Pos, bool) {
+	path, reached := logicmovement.FindPathSurface(
 		logicmovement.Pos{X: start.X, Y: start.Y, Z: start.Z},
 		logicmovement.Pos{X: target.X, Y: target.Y, Z: target.Z},
-		maxDepth,
+		tolerance,
+		maxNodes,
 		func(p logicmovement.Pos) bool {
 			if inBounds == nil {
 				return true
 			}
 			return inBounds(Pos{X: p.X, Y: p.Y, Z: p.Z})
 		},
-		func(p logicmovement.Pos) bool {
-			if isSolid == nil {
-				return false
+		func(from, to logicmovement.Pos) (logicmovement.Pos, bool) {
+			if step == nil {
+				return to, true
 			}
-			return isSolid(Pos{X: p.X, Y: p.Y, Z: p.Z})
+			p, ok := step(Pos{X: from.X, Y: from.Y, Z: from.Z}, Pos{X: to.X, Y: to.Y, Z: to.Z})
+			return logicmovement.Pos{X: p.X, Y: p.Y, Z: p.Z}, ok
+		},
+		func(p logicmovement.Pos) int {
+			if stepCost == nil {
+				return 0
+			}
+			return stepCost(Pos{X: p.X, Y: p.Y, Z: p.Z})
 		},
 	)
-	if !ok {
-		return Pos{}, false
+	if len(path) == 0 {
+		return nil, reached
+	}
+	out := make([]Pos, 0, len(path))
+	for _, p := range path {
+		out = append(out, Pos{X: p.X, Y: p.Y, Z: p.Z})
 	}
-	return Pos{X: next.X, Y: next.Y, Z: next.Z}, true
+	return out, reached
 }
@@ -0,0 +1,183 @@
+package runtime
+
+import (
+	"voxelcraft.ai/internal/sim/tasks"
+	detourpkg "voxelcraft.ai/internal/sim/world/feature/movement/detour"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
+)
+
+const (
+	// PathMaxNodes bounds the number of cells expanded by a single A* search.
+	PathMaxNodes = 4096
+	// PathMaxLen bounds the number of cached steps kept on a task.
+	PathMaxLen = 256
+
+	// Extra step costs for land cores the agent is not a member of. Cores that
+	// would deny entry are heavily penalized but stay traversable, so a route
+	// that cannot avoid them still fails with the enforcement code at the border.
+	pathCostDenied     = 64
+	pathCostTicket     = 16
+	pathCostRestricted = 8 // e.g. curfew: a law currently forbids building there
+
+	// MaxClimb and MaxDrop bound the height change of a single step.
+	// Both are irrelevant in flat (height 1) worlds where every surface is y=0.
+	MaxClimb = 1
+	MaxDrop  = 3
+)
+
+// CanStep reports whether an agent standing at from may step onto to, both
+// given as standing positions.
+func CanStep(from, to modelpkg.Vec3i, solidAt func(modelpkg.Vec3i) bool) bool {
+	if solidAt != nil && solidAt(to) {
+		return false
+	}
+	dy := to.Y - from.Y
+	return dy <= MaxClimb && -dy <= MaxDrop
+}
+
+// StandAt returns the cell an agent standing at from lands on when it steps into
+// column (x,z): the first air cell resting on solid ground (or the world floor)
+// near from.Y. A solid cell at from.Y is climbed onto (MaxClimb); otherwise the
+// agent drops until supported (MaxDrop). Steps are taken from the agent's own
+// height, so tunnels and caves are walked at their floor rather than the
+// column top. inBounds rejects cells outside the world.
+func StandAt(from modelpkg.Vec3i, x, z int, solidAt func(modelpkg.Vec3i) bool, inBounds func(modelpkg.Vec3i) bool) (modelpkg.Vec3i, bool) {
+	solid := func(y int) bool { return solidAt != nil && solidAt(modelpkg.Vec3i{X: x, Y: y, Z: z}) }
+	in := func(y int) bool { return inBounds == nil || inBounds(modelpkg.Vec3i{X: x, Y: y, Z: z}) }
+	if !in(from.Y) {
+		return modelpkg.Vec3i{}, false
+	}
+	if solid(from.Y) {
+		for y := from.Y + 1; y <= from.Y+MaxClimb && in(y); y++ {
+			if !solid(y) {
+				return modelpkg.Vec3i{X: x, Y: y, Z: z}, true
+			}
+		}
+		return modelpkg.Vec3i{}, false
+	}
+	for y := from.Y; y >= from.Y-MaxDrop; y-- {
+		if y == 0 || solid(y-1) {
+			return modelpkg.Vec3i{X: x, Y: y, Z: z}, true
+		}
+	}
+	return modelpkg.Vec3i{}, false
+}
+
+// NeedsReplan reports whether the cached path on mt can no longer be followed
+// from pos towards goal.
+func NeedsReplan(mt *tasks.MovementTask, pos modelpkg.Vec3i, goal tasks.Vec3i, want int, blockedAt func(modelpkg.Vec3i) bool) bool {
+	if mt == nil || len(mt.Path) == 0 {
+		return true
+	}
+	if DistXZ(Pos{X: mt.PathGoal.X, Z: mt.PathGoal.Z}, Pos{X: goal.X, Z: goal.Z}) > want {
+		// FOLLOW target moved away from the planned goal.
+		return true
+	}
+	next := mt.Path[0]
+	if DistXZ(Pos{X: pos.X, Z: pos.Z}, Pos{X: next.X, Z: next.Z}) != 1 {
+		return true
+	}
+	if blockedAt != nil && blockedAt(modelpkg.Vec3i{X: next.X, Y: next.Y, Z: next.Z}) {
+		return true
+	}
+	return false
+}
+
+// PlanPath computes a bounded A* route for a from its current position towards
+// target. ok=false means no reachable cell gets closer to target.
+func PlanPath(env MovementSystemEnv, a *modelpkg.Agent, target modelpkg.Vec3i, want int, nowTick uint64) ([]tasks.Vec3i, bool) {
+	if env == nil || a == nil {
+		return nil, false
+	}
+	path, _ := detourpkg.FindPathSurface(
+		detourpkg.Pos{X: a.Pos.X, Y: a.Pos.Y, Z: a.Pos.Z},
+		detourpkg.Pos{X: target.X, Y: target.Y, Z: target.Z},
+		want,
+		PathMaxNodes,
+		func(p detourpkg.Pos) bool {
+			return env.InBounds(modelpkg.Vec3i{X: p.X, Y: p.Y, Z: p.Z})
+		},
+		func(from, to detourpkg.Pos) (detourpkg.Pos, bool) {
+			s, ok := StandAt(modelpkg.Vec3i{X: from.X, Y: from.Y, Z: from.Z}, to.X, to.Z, env.BlockSolidAt, env.InBounds)
+			return detourpkg.Pos{X: s.X, Y: s.Y, Z: s.Z}, ok
+		},
+		func(p detourpkg.Pos) int {
+			return LandStepCost(env, a, modelpkg.Vec3i{X: p.X, Y: p.Y, Z: p.Z}, nowTick)
+		},
+	)
+	if len(path) == 0 {
+		return nil, false
+	}
+	if len(path) > PathMaxLen {
+		path = path[:PathMaxLen]
+	}
+	out := make([]tasks.Vec3i, 0, len(path))
+	for _, p := range path {
+		out = append(out, tasks.Vec3i{X: p.X, Y: p.Y, Z: p.Z})
+	}
+	return out, true
+}
+
+// LandStepCost is the extra path cost of stepping into pos for agent a, based on
+// the land core rules enforced by the movement system (wanted city cores, ENTER
+// laws and their tickets) plus laws that currently forbid building there.
+func LandStepCost(env MovementSystemEnv, a *modelpkg.Agent, pos modelpkg.Vec3i, nowTick uint64) int {
+	land := env.LandAt(pos)
+	if land == nil || !env.LandCoreContains(land, pos) {
+		return 0
+	}
+	if env.LandCoreContains(land, a.Pos) {
+		// Already inside this core: moving within it is never re-checked.
+		return 0
+	}
+	member := env.IsLandMember(a.ID, land)
+	if org := env.OrgByID(land.Owner); !member && org != nil && org.Kind == modelpkg.OrgCity && rules.Wanted(a.RepLaw) {
+		return pathCostDenied
+	}
+	if len(land.Policies) == 0 {
+		return 0
+	}
+	cost := 0
+	enter := env.LawDecision(a.ID, pos, rules.ActionEnter, nil, nowTick)
+	if !enter.Allowed {
+		return pathCostDenied
+	}
+	if len(enter.Tickets) > 0 {
+		if !CanPayTickets(a, enter.Tickets) {
+			return pathCostDenied
+		}
+		cost += pathCostTicket
+	}
+	if env.LawDecision(a.ID, pos, rules.ActionBuild, nil, nowTick).DeniedBy != "" {
+		cost += pathCostRestricted
+	}
+	return cost
+}
+
+// CanPayTickets reports whether a holds every ticket (tickets of the same item add up).
+func CanPayTickets(a *modelpkg.Agent, tickets []rules.Charge) bool {
+	need := map[string]int{}
+	for _, t := range tickets {
+		need[t.Item] += t.Amount
+	}
+	for item, n := range need {
+		if a.Inventory[item] < n {
+			return false
+		}
+	}
+	return true
+}
+
+// PathCrosses reports whether the cached path on mt passes through pos.
+func PathCrosses(mt *tasks.MovementTask, pos modelpkg.Vec3i) bool {
+	if mt == nil {
+		return false
+	}
+	for _, p := range mt.Path {
+		if p.X == pos.X && p.Z == pos.Z {
+			return true
+		}
+	}
+	return false
+}
@@ -39,50 +39,3 @@ func DistXZ(a, b Pos) int {
 	}
 	return dx + dz
 }
-
-func PrimaryAxis(dx, dz int) bool {
-	return abs(dx) >= abs(dz)
-}
-
-func PrimaryStep(cur Pos, dx, dz int, primaryX bool) Pos {
-	next := cur
-	if primaryX {
-		if dx > 0 {
-			next.X++
-		} else if dx < 0 {
-			next.X--
-		}
-		return next
-	}
-	if dz > 0 {
-		next.Z++
-	} else if dz < 0 {
-		next.Z--
-	}
-	return next
-}
-
-func SecondaryStep(cur Pos, dx, dz int, primaryX bool) Pos {
-	next := cur
-	if primaryX {
-		if dz > 0 {
-			next.Z++
-		} else if dz < 0 {
-			next.Z--
-		}
-		return next
-	}
-	if dx > 0 {
-		next.X++
-	} else if dx < 0 {
-		next.X--
-	}
-	return next
-}
-
-func abs(v int) int {
-	if v < 0 {
-		return -v
-	}
-	return v
-}
@@ -1,6 +1,10 @@
 package runtime
 
-import "testing"
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
 
 func TestMoveTolerance(t *testing.T) {
 	if got := MoveTolerance(0); got != 1 {
@@ -29,14 +33,58 @@ func TestSkipRules(t *testing.T) {
 	}
 }
 
-func TestPrimarySecondarySteps(t *testing.T) {
-	cur := Pos{X: 0, Z: 0}
-	next := PrimaryStep(cur, 3, 1, true)
-	if next.X != 1 || next.Z != 0 {
-		t.Fatalf("primary step mismatch: %+v", next)
-	}
-	alt := SecondaryStep(cur, 3, 1, true)
-	if alt.X != 0 || alt.Z != 1 {
-		t.Fatalf("secondary step mismatch: %+v", alt)
+func TestCanStepClimbLimit(t *testing.T) {
+	solid := func(p modelpkg.Vec3i) bool { return p.X == 9 }
+	from := modelpkg.Vec3i{X: 0, Y: 5, Z: 0}
+	cases := []struct {
+		to   modelpkg.Vec3i
+		want bool
+	}{
+		{modelpkg.Vec3i{X: 1, Y: 5}, true},
+		{modelpkg.Vec3i{X: 1, Y: 6}, true},
+		{modelpkg.Vec3i{X: 1, Y: 7}, false},
+		{modelpkg.Vec3i{X: 1, Y: 2}, true},
+		{modelpkg.Vec3i{X: 1, Y: 1}, false},
+		{modelpkg.Vec3i{X: 9, Y: 5}, false},
+	}
+	for _, c := range cases {
+		if got := CanStep(from, c.to, solid); got != c.want {
+			t.Fatalf("CanStep(%+v -> %+v) = %v, want %v", from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestStandAtFromAgentHeight(t *testing.T) {
+	// Column x=1: ground at y=0..2, a tunnel at y=3 under a roof at y=4..7 (world height 8).
+	// Column x=2: solid only at y=0.
+	solid := func(p modelpkg.Vec3i) bool {
+		switch p.X {
+		case 1:
+			return p.Y != 3
+		case 2:
+			return p.Y == 0
+		}
+		return false
+	}
+	inBounds := func(p modelpkg.Vec3i) bool { return p.Y >= 0 && p.Y < 8 }
+	cases := []struct {
+		from   modelpkg.Vec3i
+		x      int
+		want   modelpkg.Vec3i
+		wantOK bool
+	}{
+		{modelpkg.Vec3i{Y: 3}, 1, modelpkg.Vec3i{X: 1, Y: 3}, true}, // tunnel at the same height
+		{modelpkg.Vec3i{Y: 2}, 1, modelpkg.Vec3i{X: 1, Y: 3}, true}, // climb one into the tunnel
+		{modelpkg.Vec3i{Y: 5}, 1, modelpkg.Vec3i{}, false},          // inside the roof, more than one up
+		{modelpkg.Vec3i{Y: 7}, 1, modelpkg.Vec3i{}, false},          // on top: the column top is out of the world
+		{modelpkg.Vec3i{Y: 3}, 2, modelpkg.Vec3i{X: 2, Y: 1}, true}, // drop two
+		{modelpkg.Vec3i{Y: 5}, 2, modelpkg.Vec3i{}, false},          // drop four: too deep
+		{modelpkg.Vec3i{Y: 2}, 3, modelpkg.Vec3i{X: 3, Y: 0}, true}, // drop to the world floor
+	}
+	for _, c := range cases {
+		got, ok := StandAt(c.from, c.x, 0, solid, inBounds)
+		if ok != c.wantOK || (ok && got != c.want) {
+			t.Fatalf("StandAt(%+v, x=%d) = %+v,%v want %+v,%v", c.from, c.x, got, ok, c.want, c.wantOK)
+		}
 	}
 }
@@ -1,12 +1,10 @@
 package runtime
 
 import (
-	"strings"
-
 	"voxelcraft.ai/internal/protocol"
 	"voxelcraft.ai/internal/sim/tasks"
-	detourpkg "voxelcraft.ai/internal/sim/world/feature/movement/detour"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
 type SystemInput struct {
@@ -21,7 +19,6 @@ type SystemInput struct {
 type MovementSystemEnv interface {
 	SortedAgents() []*modelpkg.Agent
 	FollowTargetPos(targetID string) (modelpkg.Vec3i, bool)
-	SurfaceY(x int, z int) int
 	BlockSolidAt(pos modelpkg.Vec3i) bool
 	InBounds(pos modelpkg.Vec3i) bool
 
@@ -30,6 +27,8 @@ type MovementSystemEnv interface {
 	IsLandMember(agentID string, land *modelpkg.LandClaim) bool
 	OrgByID(id string) *modelpkg.Organization
 	TransferAccessTicket(ownerID string, item string, count int)
+	LawDecision(agentID string, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) rules.Decision
+	EnforceLaw(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool
 	RecordDenied(nowTick uint64)
 
 	RecordStructureUsage(agentID string, pos modelpkg.Vec3i, nowTick uint64)
@@ -95,52 +94,45 @@ func RunMovementSystem(env MovementSystemEnv, in SystemInput) {
 			continue
 		}
 
+		// An exhausted agent waits without re-planning; A* is only paid for a step it can take.
 		const moveCost = 8
 		if a.StaminaMilli < moveCost {
 			continue
 		}
-		a.StaminaMilli -= moveCost
-
-		dx := target.X - a.Pos.X
-		dz := target.Z - a.Pos.Z
-		primaryX := PrimaryAxis(dx, dz)
-
-		next := PrimaryStep(Pos{X: a.Pos.X, Y: a.Pos.Y, Z: a.Pos.Z}, dx, dz, primaryX)
-		next.Y = env.SurfaceY(next.X, next.Z)
 
-		if env.BlockSolidAt(modelpkg.Vec3i{X: next.X, Y: next.Y, Z: next.Z}) {
-			alt := SecondaryStep(Pos{X: a.Pos.X, Y: a.Pos.Y, Z: a.Pos.Z}, dx, dz, primaryX)
-			if alt != (Pos{X: a.Pos.X, Y: a.Pos.Y, Z: a.Pos.Z}) {
-				alt.Y = env.SurfaceY(alt.X, alt.Z)
-				if !env.BlockSolidAt(modelpkg.Vec3i{X: alt.X, Y: alt.Y, Z: alt.Z}) {
-					next = alt
+		want := MoveTolerance(mt.Tolerance)
+		if mt.Kind == tasks.KindFollow {
+			want = MoveTolerance(mt.Distance)
+		}
+		goal := tasks.Vec3i{X: target.X, Y: target.Y, Z: target.Z}
+		blockedAt := func(p modelpkg.Vec3i) bool {
+			_, ok := StandAt(a.Pos, p.X, p.Z, env.BlockSolidAt, env.InBounds)
+			return !ok
+		}
+		if NeedsReplan(mt, a.Pos, goal, want, blockedAt) {
+			path, ok := PlanPath(env, a, target, want, in.NowTick)
+			if !ok {
+				mt.Path = nil
+				if mt.Kind == tasks.KindMoveTo {
+					a.MoveTask = nil
+					a.AddEvent(protocol.Event{"t": in.NowTick, "type": "TASK_FAIL", "task_id": mt.TaskID, "code": "E_NO_PATH", "message": "no path to target"})
 				}
+				continue
 			}
+			mt.Path = path
+			mt.PathGoal = goal
 		}
 
-		if env.BlockSolidAt(modelpkg.Vec3i{X: next.X, Y: next.Y, Z: next.Z}) {
-			if alt, ok := detourpkg.DetourStep2D(
-				detourpkg.Pos{X: a.Pos.X, Y: a.Pos.Y, Z: a.Pos.Z},
-				detourpkg.Pos{X: target.X, Y: target.Y, Z: target.Z},
-				16,
-				func(p detourpkg.Pos) bool {
-					return env.InBounds(modelpkg.Vec3i{X: p.X, Y: p.Y, Z: p.Z})
-				},
-				func(p detourpkg.Pos) bool {
-					return env.BlockSolidAt(modelpkg.Vec3i{X: p.X, Y: p.Y, Z: p.Z})
-				},
-			); ok {
-				next = Pos{X: alt.X, Y: alt.Y, Z: alt.Z}
-			}
-		}
+		a.StaminaMilli -= moveCost
 
-		nextPos := modelpkg.Vec3i{X: next.X, Y: next.Y, Z: next.Z}
+		step := mt.Path[0]
+		nextPos, _ := StandAt(a.Pos, step.X, step.Z, env.BlockSolidAt, env.InBounds) // checked by NeedsReplan above
 
 		if toLand := env.LandAt(nextPos); toLand != nil && env.LandCoreContains(toLand, nextPos) && !env.IsLandMember(a.ID, toLand) {
 			if org := env.OrgByID(toLand.Owner); org != nil && org.Kind == modelpkg.OrgCity {
 				fromLand := env.LandAt(a.Pos)
 				entering := fromLand == nil || fromLand.LandID != toLand.LandID || !env.LandCoreContains(toLand, a.Pos)
-				if entering && a.RepLaw > 0 && a.RepLaw < 200 {
+				if entering && rules.Wanted(a.RepLaw) {
 					a.MoveTask = nil
 					env.RecordDenied(in.NowTick)
 					a.AddEvent(protocol.Event{"t": in.NowTick, "type": "TASK_FAIL", "task_id": mt.TaskID, "code": "E_NO_PERMISSION", "message": "wanted: law reputation too low"})
@@ -149,37 +141,41 @@ func RunMovementSystem(env MovementSystemEnv, in SystemInput) {
 			}
 		}
 
-		if toLand := env.LandAt(nextPos); toLand != nil && toLand.AccessPassEnabled && env.LandCoreContains(toLand, nextPos) && !env.IsLandMember(a.ID, toLand) {
+		if toLand := env.LandAt(nextPos); toLand != nil && len(toLand.Policies) > 0 && env.LandCoreContains(toLand, nextPos) {
 			fromLand := env.LandAt(a.Pos)
 			entering := fromLand == nil || fromLand.LandID != toLand.LandID || !env.LandCoreContains(toLand, a.Pos)
 			if entering {
-				item := strings.TrimSpace(toLand.AccessTicketItem)
-				cost := toLand.AccessTicketCost
-				if item == "" || cost <= 0 {
+				d := env.LawDecision(a.ID, nextPos, rules.ActionEnter, nil, in.NowTick)
+				if !d.Allowed {
+					env.EnforceLaw(a, nextPos, rules.ActionEnter, nil, in.NowTick)
 					a.MoveTask = nil
 					env.RecordDenied(in.NowTick)
-					a.AddEvent(protocol.Event{"t": in.NowTick, "type": "TASK_FAIL", "task_id": mt.TaskID, "code": "E_NO_PERMISSION", "message": "access pass required"})
+					a.AddEvent(protocol.Event{"t": in.NowTick, "type": "TASK_FAIL", "task_id": mt.TaskID, "code": "E_NO_PERMISSION", "message": "entry denied by law"})
 					continue
 				}
-				if a.Inventory[item] < cost {
+				if !CanPayTickets(a, d.Tickets) {
 					a.MoveTask = nil
 					env.RecordDenied(in.NowTick)
 					a.AddEvent(protocol.Event{"t": in.NowTick, "type": "TASK_FAIL", "task_id": mt.TaskID, "code": "E_NO_RESOURCE", "message": "need access ticket"})
 					continue
 				}
-				a.Inventory[item] -= cost
-				env.TransferAccessTicket(toLand.Owner, item, cost)
-				a.AddEvent(protocol.Event{"t": in.NowTick, "type": "ACCESS_PASS", "land_id": toLand.LandID, "item": item, "count": cost})
+				for _, t := range d.Tickets {
+					a.Inventory[t.Item] -= t.Amount
+					env.TransferAccessTicket(toLand.Owner, t.Item, t.Amount)
+					a.AddEvent(protocol.Event{"t": in.NowTick, "type": "ACCESS_PASS", "land_id": toLand.LandID, "law_id": t.LawID, "item": t.Item, "count": t.Amount})
+				}
+				env.EnforceLaw(a, nextPos, rules.ActionEnter, nil, in.NowTick)
 			}
 		}
 
-		if env.BlockSolidAt(nextPos) {
+		if !CanStep(a.Pos, nextPos, env.BlockSolidAt) {
 			a.MoveTask = nil
 			a.AddEvent(protocol.Event{"t": in.NowTick, "type": "TASK_FAIL", "task_id": mt.TaskID, "code": "E_BLOCKED", "message": "blocked"})
 			continue
 		}
 
 		a.Pos = nextPos
+		mt.Path = mt.Path[1:]
 		env.RecordStructureUsage(a.ID, a.Pos, in.NowTick)
 		env.OnBiome(a, in.NowTick)
 	}
@@ -96,14 +96,7 @@ func ComputeChunkSurface(blocks []uint16, cx, cz int, air uint16, boundaryR int)
 				out[z*16+x] = SurfaceCell{B: air, Y: 0}
 				continue
 			}
-			b := air
-			if idx := x + z*16; idx >= 0 && idx < len(blocks) {
-				v := blocks[idx]
-				if v != air {
-					b = v
-				}
-			}
-			out[z*16+x] = SurfaceCell{B: b, Y: 0}
+			out[z*16+x] = topCell(blocks, x+z*16, air)
 		}
 	}
 	return out
@@ -123,7 +116,9 @@ func ComputeChunkVoxels(blocks []uint16, cx, cz int, air uint16, boundaryR int)
 			wx := cx*16 + x
 			wz := cz*16 + z
 			if wx < -boundaryR || wx > boundaryR || wz < -boundaryR || wz > boundaryR {
-				out[x+z*16] = air
+				for idx := x + z*16; idx < len(out); idx += 256 {
+					out[idx] = air
+				}
 			}
 		}
 	}
@@ -138,12 +133,15 @@ func ComputeSurfaceCellAt(wx, wz int, air uint16, boundaryR int, chunkBlocks fun
 	cz := floorDiv(wz, 16)
 	lx := mod(wx, 16)
 	lz := mod(wz, 16)
-	blocks := chunkBlocks(cx, cz)
-	idx := lx + lz*16
-	if idx >= 0 && idx < len(blocks) {
-		v := blocks[idx]
-		if v != air {
-			return SurfaceCell{B: v, Y: 0}
+	return topCell(chunkBlocks(cx, cz), lx+lz*16, air)
+}
+
+// topCell returns the highest non-air block of the column starting at blocks[col]
+// (YZX layout, 256 cells per layer), or air at y=0 for an empty column.
+func topCell(blocks []uint16, col int, air uint16) SurfaceCell {
+	for y := len(blocks)/256 - 1; y >= 0; y-- {
+		if v := blocks[col+y*256]; v != air {
+			return SurfaceCell{B: v, Y: uint8(y)}
 		}
 	}
 	return SurfaceCell{B: air, Y: 0}
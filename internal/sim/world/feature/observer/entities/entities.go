@@ -2,9 +2,11 @@ package entities
 
 import (
 	"sort"
+	"strconv"
 	"strings"
 
 	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
 type Pos struct {
@@ -49,7 +51,7 @@ func BuildAgentEntities(selfID string, selfPos Pos, agents []AgentInput, dist in
 		if other.OrgID != "" {
 			tags = append(tags, "org:"+other.OrgID)
 		}
-		if other.RepLaw > 0 && other.RepLaw < 200 {
+		if rules.Wanted(other.RepLaw) {
 			tags = append(tags, "wanted")
 		}
 		out = append(out, protocol.EntityObs{
@@ -67,12 +69,13 @@ type SimpleInput struct {
 	ID   string
 	Type string
 	Pos  Pos
+	Tags []string
 }
 
 func BuildSimpleEntities(in []SimpleInput) []protocol.EntityObs {
 	out := make([]protocol.EntityObs, 0, len(in))
 	for _, e := range in {
-		out = append(out, protocol.EntityObs{ID: e.ID, Type: e.Type, Pos: e.Pos.ToArray()})
+		out = append(out, protocol.EntityObs{ID: e.ID, Type: e.Type, Pos: e.Pos.ToArray(), Tags: e.Tags})
 	}
 	return out
 }
@@ -127,10 +130,31 @@ func BuildSwitchEntities(in []SwitchInput) []protocol.EntityObs {
 	return out
 }
 
+type CropInput struct {
+	ID    string
+	Pos   Pos
+	Type  string
+	Stage int
+	Ripe  bool
+}
+
+func BuildCropEntities(in []CropInput) []protocol.EntityObs {
+	out := make([]protocol.EntityObs, 0, len(in))
+	for _, c := range in {
+		tags := []string{"crop:" + c.Type, "stage:" + strconv.Itoa(c.Stage)}
+		if c.Ripe {
+			tags = append(tags, "ripe")
+		}
+		out = append(out, protocol.EntityObs{ID: c.ID, Type: "CROP", Pos: c.Pos.ToArray(), Tags: tags})
+	}
+	return out
+}
+
 type SensorInput struct {
-	ID  string
-	Pos Pos
-	On  bool
+	ID   string
+	Pos  Pos
+	On   bool
+	Mode string
 }
 
 func BuildSensorEntities(in []SensorInput) []protocol.EntityObs {
@@ -140,7 +164,38 @@ func BuildSensorEntities(in []SensorInput) []protocol.EntityObs {
 		if s.On {
 			state = "on"
 		}
-		out = append(out, protocol.EntityObs{ID: s.ID, Type: "SENSOR", Pos: s.Pos.ToArray(), Tags: []string{"state:" + state}})
+		tags := []string{"state:" + state}
+		if s.Mode != "" {
+			tags = append(tags, "mode:"+s.Mode)
+		}
+		out = append(out, protocol.EntityObs{ID: s.ID, Type: "SENSOR", Pos: s.Pos.ToArray(), Tags: tags})
+	}
+	return out
+}
+
+// SignalInput is a gate or battery (Type is its block name).
+type SignalInput struct {
+	ID      string
+	Pos     Pos
+	Type    string
+	DirTag  string
+	On      bool
+	Charge  int
+	Battery bool
+}
+
+func BuildSignalEntities(in []SignalInput) []protocol.EntityObs {
+	out := make([]protocol.EntityObs, 0, len(in))
+	for _, s := range in {
+		state := "off"
+		if s.On {
+			state = "on"
+		}
+		tags := []string{"dir:" + s.DirTag, "state:" + state}
+		if s.Battery {
+			tags = append(tags, "charge:"+strconv.Itoa(s.Charge))
+		}
+		out = append(out, protocol.EntityObs{ID: s.ID, Type: s.Type, Pos: s.Pos.ToArray(), Tags: tags})
 	}
 	return out
 }
@@ -166,3 +221,25 @@ func BuildItemEntities(in []ItemInput) []protocol.EntityObs {
 	}
 	return out
 }
+
+type MobInput struct {
+	ID      string
+	Pos     Pos
+	Kind    string
+	State   string
+	HP      int
+	Hostile bool
+}
+
+func BuildMobEntities(in []MobInput) []protocol.EntityObs {
+	sort.Slice(in, func(i, j int) bool { return in[i].ID < in[j].ID })
+	out := make([]protocol.EntityObs, 0, len(in))
+	for _, m := range in {
+		tags := []string{"kind:" + m.Kind, "state:" + m.State, "hp:" + strconv.Itoa(m.HP)}
+		if m.Hostile {
+			tags = append(tags, "hostile")
+		}
+		out = append(out, protocol.EntityObs{ID: m.ID, Type: "MOB", Pos: m.Pos.ToArray(), Tags: tags})
+	}
+	return out
+}
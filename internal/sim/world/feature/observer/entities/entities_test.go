@@ -25,3 +25,25 @@ func TestBuildItemEntitiesSorted(t *testing.T) {
 		t.Fatalf("expected sorted item ids, got %#v", got)
 	}
 }
+
+func TestBuildMobEntitiesTags(t *testing.T) {
+	got := BuildMobEntities([]MobInput{
+		{ID: "MB000002", Kind: "CRYSTAL_BEETLE", State: "WANDER", HP: 6},
+		{ID: "MB000001", Kind: "BANDIT", State: "CHASE", HP: 9, Hostile: true},
+	})
+	if len(got) != 2 || got[0].ID != "MB000001" || got[0].Type != "MOB" {
+		t.Fatalf("unexpected mobs: %#v", got)
+	}
+	want := []string{"kind:BANDIT", "state:CHASE", "hp:9", "hostile"}
+	if len(got[0].Tags) != len(want) {
+		t.Fatalf("tags=%v want %v", got[0].Tags, want)
+	}
+	for i := range want {
+		if got[0].Tags[i] != want[i] {
+			t.Fatalf("tags=%v want %v", got[0].Tags, want)
+		}
+	}
+	if len(got[1].Tags) != 3 {
+		t.Fatalf("neutral mob tags=%v", got[1].Tags)
+	}
+}
@@ -26,24 +26,37 @@ func FunScorePtr(novelty, creation, social, influence, narrative, riskRescue int
 }
 
 func AttachObsEventsAndMeta(a *modelpkg.Agent, obs *protocol.ObsMsg, nowTick uint64) {
+	AttachObsEventsAndMetaWith(a, obs, nowTick, modelpkg.View{})
+}
+
+// AttachObsEventsAndMetaWith applies the agent's SET_VIEW: max_events leaves the rest
+// queued for later OBS (events_cursor points at the last delivered event), hidden
+// memory stays pending and hidden fun_score is omitted.
+func AttachObsEventsAndMetaWith(a *modelpkg.Agent, obs *protocol.ObsMsg, nowTick uint64, view modelpkg.View) {
 	if a == nil || obs == nil {
 		return
 	}
 
-	ev := a.TakeEvents()
+	ev := a.TakeEventsN(view.MaxEvents)
+	cursor := a.EventCursor
+	if n := uint64(len(a.Events)); n <= cursor {
+		cursor -= n
+	}
 	obs.Events = ev
-	obs.EventsCursor = a.EventCursor
-	obs.ObsID = ObsID(a.ID, nowTick, a.EventCursor)
-	obs.FunScore = FunScorePtr(
-		a.Fun.Novelty,
-		a.Fun.Creation,
-		a.Fun.Social,
-		a.Fun.Influence,
-		a.Fun.Narrative,
-		a.Fun.RiskRescue,
-	)
-
-	if len(a.PendingMemory) > 0 {
+	obs.EventsCursor = cursor
+	obs.ObsID = ObsID(a.ID, nowTick, cursor)
+	if view.Shows(modelpkg.ViewSectionFunScore) {
+		obs.FunScore = FunScorePtr(
+			a.Fun.Novelty,
+			a.Fun.Creation,
+			a.Fun.Social,
+			a.Fun.Influence,
+			a.Fun.Narrative,
+			a.Fun.RiskRescue,
+		)
+	}
+
+	if len(a.PendingMemory) > 0 && view.Shows(modelpkg.ViewSectionMemory) {
 		obs.Memory = a.PendingMemory
 		a.PendingMemory = nil
 	}
@@ -36,3 +36,26 @@ func TestAttachObsEventsAndMeta(t *testing.T) {
 		t.Fatalf("expected pending memory cleared")
 	}
 }
+
+func TestAttachObsEventsAndMetaWithView(t *testing.T) {
+	a := &modelpkg.Agent{ID: "A1", Fun: modelpkg.FunScore{Novelty: 2}, PendingMemory: []protocol.MemoryKV{{Key: "k", Value: "v"}}}
+	for i := 0; i < 3; i++ {
+		a.AddEvent(protocol.Event{"type": "TEST"})
+	}
+	view := modelpkg.View{Hidden: []string{modelpkg.ViewSectionFunScore, modelpkg.ViewSectionMemory}, MaxEvents: 2}
+
+	obs := &protocol.ObsMsg{}
+	AttachObsEventsAndMetaWith(a, obs, 100, view)
+	if len(obs.Events) != 2 || obs.EventsCursor != 2 || len(a.Events) != 1 {
+		t.Fatalf("expected 2 events at cursor 2 with 1 queued, got %d cursor=%d queued=%d", len(obs.Events), obs.EventsCursor, len(a.Events))
+	}
+	if obs.FunScore != nil || obs.Memory != nil || len(a.PendingMemory) != 1 {
+		t.Fatalf("expected fun score and memory hidden")
+	}
+
+	obs = &protocol.ObsMsg{}
+	AttachObsEventsAndMetaWith(a, obs, 101, view)
+	if len(obs.Events) != 1 || obs.EventsCursor != 3 {
+		t.Fatalf("expected remaining event at cursor 3, got %d cursor=%d", len(obs.Events), obs.EventsCursor)
+	}
+}
@@ -0,0 +1,334 @@
+// Package obsdelta implements field-level OBS deltas against the last OBS a client
+// acknowledged (OBS_ACK), with periodic FULL keyframes and client-requested resyncs.
+package obsdelta
+
+import (
+	"reflect"
+
+	"voxelcraft.ai/internal/protocol"
+	simenc "voxelcraft.ai/internal/sim/encoding"
+	"voxelcraft.ai/internal/sim/world/io/obscodec"
+)
+
+const (
+	// DefaultKeyframeTicks forces a FULL OBS at least this often.
+	DefaultKeyframeTicks = 100
+	// maxPending bounds unacknowledged frames kept as candidate bases.
+	maxPending = 32
+)
+
+// Frame is a sent OBS kept as a potential delta base (per-tick events/memory stripped).
+type Frame struct {
+	Obs    protocol.ObsMsg
+	Voxels []uint16
+}
+
+// Tracker is per-client delta state, owned by the world loop.
+type Tracker struct {
+	KeyframeTicks uint64
+
+	base     *Frame
+	pending  []Frame
+	keyTick  uint64
+	needFull bool
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{KeyframeTicks: DefaultKeyframeTicks, needFull: true}
+}
+
+// BaseObsID is the acknowledged base ("" when none).
+func (t *Tracker) BaseObsID() string {
+	if t == nil || t.base == nil {
+		return ""
+	}
+	return t.base.Obs.ObsID
+}
+
+// Ack promotes a sent frame to the delta base. Unknown ids (e.g. evicted frames)
+// schedule a FULL so the client can ack a fresh base.
+func (t *Tracker) Ack(obsID string) bool {
+	if t == nil || obsID == "" {
+		return false
+	}
+	if t.base != nil && t.base.Obs.ObsID == obsID {
+		return true
+	}
+	for i := range t.pending {
+		if t.pending[i].Obs.ObsID != obsID {
+			continue
+		}
+		f := t.pending[i]
+		t.base = &f
+		t.pending = append(t.pending[:0], t.pending[i+1:]...)
+		return true
+	}
+	t.needFull = true
+	return false
+}
+
+// Resync drops the base; the next OBS is FULL.
+func (t *Tracker) Resync() {
+	if t == nil {
+		return
+	}
+	t.base = nil
+	t.pending = t.pending[:0]
+	t.needFull = true
+}
+
+// Next returns the message to send for obs: a FULL ObsMsg or an ObsDeltaMsg.
+// voxels is the uncompressed voxel cube behind obs.Voxels (RLE).
+func (t *Tracker) Next(obs protocol.ObsMsg, voxels []uint16, nowTick uint64) any {
+	f := Frame{Obs: obs, Voxels: voxels}
+	f.Obs.Events = nil
+	f.Obs.Memory = nil
+	if len(t.pending) >= maxPending {
+		t.pending = append(t.pending[:0], t.pending[1:]...)
+	}
+	t.pending = append(t.pending, f)
+
+	if t.base == nil || t.needFull || nowTick-t.keyTick >= t.KeyframeTicks {
+		t.needFull = false
+		t.keyTick = nowTick
+		obs.ObsMode = protocol.ObsModeFull
+		return obs
+	}
+	return Diff(t.base.Obs, obs, t.base.Voxels, voxels)
+}
+
+// Diff builds the delta that turns base into cur. world.time_of_day is derivable from
+// tick and only refreshed when another world field changes.
+func Diff(base, cur protocol.ObsMsg, baseVox, curVox []uint16) protocol.ObsDeltaMsg {
+	d := protocol.ObsDeltaMsg{
+		Type:            cur.Type,
+		ProtocolVersion: cur.ProtocolVersion,
+		Tick:            cur.Tick,
+		AgentID:         cur.AgentID,
+		ObsID:           cur.ObsID,
+		EventsCursor:    cur.EventsCursor,
+		WorldID:         cur.WorldID,
+		WorldClock:      cur.WorldClock,
+		ObsMode:         protocol.ObsModeDelta,
+		BaseObsID:       base.ObsID,
+		Events:          cur.Events,
+		Memory:          cur.Memory,
+	}
+	bw, cw := base.World, cur.World
+	bw.TimeOfDay, cw.TimeOfDay = 0, 0
+	if bw != cw {
+		d.World = &cur.World
+	}
+	if !reflect.DeepEqual(base.Self, cur.Self) {
+		d.Self = &cur.Self
+	}
+	d.Inventory = diffInventory(base.Inventory, cur.Inventory)
+	if !reflect.DeepEqual(base.Equipment, cur.Equipment) {
+		d.Equipment = &cur.Equipment
+	}
+	if !reflect.DeepEqual(base.LocalRules, cur.LocalRules) {
+		d.LocalRules = &cur.LocalRules
+	}
+	d.Voxels = diffVoxels(base.Voxels, cur.Voxels, baseVox, curVox)
+
+	if up, rm := diffByID(base.Entities, cur.Entities, func(e protocol.EntityObs) string { return e.ID }); len(up)+len(rm) > 0 {
+		d.Entities = &protocol.EntitiesDelta{Upsert: up, Remove: rm}
+	}
+	if up, rm := diffByID(base.Tasks, cur.Tasks, func(t protocol.TaskObs) string { return t.TaskID }); len(up)+len(rm) > 0 {
+		d.Tasks = &protocol.TasksDelta{Upsert: up, Remove: rm}
+	}
+	if up, rm := diffByID(base.PublicBoards, cur.PublicBoards, func(b protocol.BoardObs) string { return b.BoardID }); len(up)+len(rm) > 0 {
+		d.PublicBoards = &protocol.BoardsDelta{Upsert: up, Remove: rm}
+	}
+	if up, rm := diffByID(base.Markets, cur.Markets, func(m protocol.MarketObs) string { return m.TerminalID }); len(up)+len(rm) > 0 {
+		d.Markets = &protocol.MarketsDelta{Upsert: up, Remove: rm}
+	}
+	if up, rm := diffByID(base.Prices, cur.Prices, func(p protocol.PriceObs) string { return p.Item }); len(up)+len(rm) > 0 {
+		d.Prices = &protocol.PricesDelta{Upsert: up, Remove: rm}
+	}
+	if cur.FunScore != nil && !reflect.DeepEqual(base.FunScore, cur.FunScore) {
+		d.FunScore = cur.FunScore
+	}
+	return d
+}
+
+func diffInventory(base, cur []protocol.ItemStack) []protocol.ItemStack {
+	prev := make(map[string]int, len(base))
+	for _, s := range base {
+		prev[s.Item] = s.Count
+	}
+	var out []protocol.ItemStack
+	seen := make(map[string]bool, len(cur))
+	for _, s := range cur {
+		seen[s.Item] = true
+		if c, ok := prev[s.Item]; !ok || c != s.Count {
+			out = append(out, s)
+		}
+	}
+	for _, s := range base {
+		if !seen[s.Item] {
+			out = append(out, protocol.ItemStack{Item: s.Item, Count: 0})
+		}
+	}
+	return out
+}
+
+func diffVoxels(base, cur protocol.VoxelsObs, baseVox, curVox []uint16) *protocol.VoxelsObs {
+	if len(curVox) == 0 && reflect.DeepEqual(base, cur) {
+		return nil // hidden by SET_VIEW and unchanged
+	}
+	if base.Center != cur.Center || base.Radius != cur.Radius || len(baseVox) != len(curVox) || len(curVox) == 0 {
+		return &cur
+	}
+	ops := obscodec.BuildDeltaOps(baseVox, curVox, cur.Radius)
+	if len(ops) == 0 {
+		return nil
+	}
+	if len(ops) >= len(curVox)/2 {
+		return &cur
+	}
+	return &protocol.VoxelsObs{Center: cur.Center, Radius: cur.Radius, Encoding: "DELTA", Ops: ops}
+}
+
+func diffByID[T any](base, cur []T, id func(T) string) (upsert []T, remove []string) {
+	prev := make(map[string]int, len(base))
+	for i := range base {
+		prev[id(base[i])] = i
+	}
+	seen := make(map[string]bool, len(cur))
+	for _, v := range cur {
+		k := id(v)
+		seen[k] = true
+		if i, ok := prev[k]; !ok || !reflect.DeepEqual(base[i], v) {
+			upsert = append(upsert, v)
+		}
+	}
+	for _, v := range base {
+		if k := id(v); !seen[k] {
+			remove = append(remove, k)
+		}
+	}
+	return upsert, remove
+}
+
+// Apply reconstructs the full OBS from base and a delta produced by Diff (reference
+// client behaviour; ordering of upserted entries follows the delta).
+func Apply(base protocol.ObsMsg, d protocol.ObsDeltaMsg) (protocol.ObsMsg, error) {
+	out := base
+	out.Type, out.ProtocolVersion, out.Tick, out.AgentID = d.Type, d.ProtocolVersion, d.Tick, d.AgentID
+	out.ObsID, out.EventsCursor, out.WorldID, out.WorldClock = d.ObsID, d.EventsCursor, d.WorldID, d.WorldClock
+	out.ObsMode = ""
+	out.Events, out.Memory = d.Events, d.Memory
+	if d.World != nil {
+		out.World = *d.World
+	}
+	if d.Self != nil {
+		out.Self = *d.Self
+	}
+	if len(d.Inventory) > 0 {
+		out.Inventory = applyInventory(base.Inventory, d.Inventory)
+	}
+	if d.Equipment != nil {
+		out.Equipment = *d.Equipment
+	}
+	if d.LocalRules != nil {
+		out.LocalRules = *d.LocalRules
+	}
+	if d.Voxels != nil {
+		v, err := applyVoxels(base.Voxels, *d.Voxels)
+		if err != nil {
+			return protocol.ObsMsg{}, err
+		}
+		out.Voxels = v
+	}
+	if d.Entities != nil {
+		out.Entities = applyByID(base.Entities, d.Entities.Upsert, d.Entities.Remove, func(e protocol.EntityObs) string { return e.ID })
+	}
+	if d.Tasks != nil {
+		out.Tasks = applyByID(base.Tasks, d.Tasks.Upsert, d.Tasks.Remove, func(t protocol.TaskObs) string { return t.TaskID })
+	}
+	if d.PublicBoards != nil {
+		out.PublicBoards = applyByID(base.PublicBoards, d.PublicBoards.Upsert, d.PublicBoards.Remove, func(b protocol.BoardObs) string { return b.BoardID })
+	}
+	if d.Markets != nil {
+		out.Markets = applyByID(base.Markets, d.Markets.Upsert, d.Markets.Remove, func(m protocol.MarketObs) string { return m.TerminalID })
+	}
+	if d.Prices != nil {
+		out.Prices = applyByID(base.Prices, d.Prices.Upsert, d.Prices.Remove, func(p protocol.PriceObs) string { return p.Item })
+	}
+	if d.FunScore != nil {
+		out.FunScore = d.FunScore
+	}
+	return out, nil
+}
+
+func applyInventory(base, changes []protocol.ItemStack) []protocol.ItemStack {
+	idx := map[string]int{}
+	out := append([]protocol.ItemStack(nil), base...)
+	for i, s := range out {
+		idx[s.Item] = i
+	}
+	for _, c := range changes {
+		if i, ok := idx[c.Item]; ok {
+			out[i].Count = c.Count
+			continue
+		}
+		idx[c.Item] = len(out)
+		out = append(out, c)
+	}
+	kept := out[:0]
+	for _, s := range out {
+		if s.Count != 0 {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+func applyVoxels(base, d protocol.VoxelsObs) (protocol.VoxelsObs, error) {
+	if d.Encoding != "DELTA" {
+		return d, nil
+	}
+	ids, err := simenc.DecodeRLE(base.Data)
+	if err != nil {
+		return protocol.VoxelsObs{}, err
+	}
+	r := d.Radius
+	dim := 2*r + 1
+	for _, op := range d.Ops {
+		i := ((op.D[1]+r)*dim+(op.D[2]+r))*dim + (op.D[0] + r)
+		if i >= 0 && i < len(ids) {
+			ids[i] = op.B
+		}
+	}
+	return protocol.VoxelsObs{Center: d.Center, Radius: r, Encoding: "RLE", Data: simenc.EncodeRLE(ids)}, nil
+}
+
+func applyByID[T any](base, upsert []T, remove []string, id func(T) string) []T {
+	drop := make(map[string]bool, len(remove))
+	for _, k := range remove {
+		drop[k] = true
+	}
+	repl := make(map[string]T, len(upsert))
+	for _, v := range upsert {
+		repl[id(v)] = v
+	}
+	out := make([]T, 0, len(base)+len(upsert))
+	for _, v := range base {
+		k := id(v)
+		if drop[k] {
+			continue
+		}
+		if nv, ok := repl[k]; ok {
+			v = nv
+			delete(repl, k)
+		}
+		out = append(out, v)
+	}
+	for _, v := range upsert {
+		if _, ok := repl[id(v)]; ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package obsdelta
+
+import (
+	"reflect"
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	simenc "voxelcraft.ai/internal/sim/encoding"
+)
+
+func testObs(tick uint64, vox []uint16) protocol.ObsMsg {
+	return protocol.ObsMsg{
+		Type:            protocol.TypeObs,
+		ProtocolVersion: protocol.Version,
+		Tick:            tick,
+		AgentID:         "A1",
+		ObsID:           "A1:" + string(rune('0'+tick%10)),
+		World:           protocol.WorldObs{TimeOfDay: float64(tick) / 100, Weather: "CLEAR"},
+		Self:            protocol.SelfObs{Pos: [3]int{1, 0, 1}, HP: 20, Status: []string{"FED"}},
+		Inventory:       []protocol.ItemStack{{Item: "PLANK", Count: 4}, {Item: "STONE", Count: 2}},
+		Equipment:       protocol.EquipmentObs{MainHand: "NONE", Armor: []string{"NONE", "NONE", "NONE", "NONE"}},
+		LocalRules:      protocol.LocalRulesObs{Role: "WILD", Permissions: map[string]bool{"can_build": true}},
+		Voxels:          protocol.VoxelsObs{Center: [3]int{1, 0, 1}, Radius: 1, Encoding: "RLE", Data: simenc.EncodeRLE(vox)},
+		Entities:        []protocol.EntityObs{{ID: "A2", Type: "AGENT", Pos: [3]int{2, 0, 2}}, {ID: "C1", Type: "CHEST"}},
+		Tasks:           []protocol.TaskObs{},
+	}
+}
+
+func TestDiffIdleIsHeaderOnly(t *testing.T) {
+	vox := make([]uint16, 27)
+	base, cur := testObs(1, vox), testObs(2, vox)
+	d := Diff(base, cur, vox, vox)
+	if d.World != nil || d.Self != nil || d.Inventory != nil || d.Equipment != nil || d.LocalRules != nil ||
+		d.Voxels != nil || d.Entities != nil || d.Tasks != nil || d.PublicBoards != nil {
+		t.Fatalf("expected empty delta for idle agent, got %+v", d)
+	}
+	if d.ObsMode != protocol.ObsModeDelta || d.BaseObsID != base.ObsID {
+		t.Fatalf("bad header: %+v", d)
+	}
+}
+
+func TestDiffApplyRoundTrip(t *testing.T) {
+	baseVox := make([]uint16, 27)
+	curVox := append([]uint16(nil), baseVox...)
+	curVox[13] = 5
+	base, cur := testObs(1, baseVox), testObs(2, curVox)
+	cur.Self.HP = 18
+	cur.Inventory = []protocol.ItemStack{{Item: "PLANK", Count: 3}, {Item: "COAL", Count: 1}}
+	cur.Entities = []protocol.EntityObs{{ID: "A2", Type: "AGENT", Pos: [3]int{3, 0, 2}}, {ID: "I9", Type: "ITEM", Item: "COAL", Count: 1}}
+	cur.Tasks = []protocol.TaskObs{{TaskID: "T1", Kind: "MINE", Progress: 0.5}}
+	cur.Events = []protocol.Event{{"type": "TASK_DONE"}}
+
+	d := Diff(base, cur, baseVox, curVox)
+	if d.Voxels == nil || d.Voxels.Encoding != "DELTA" || len(d.Voxels.Ops) != 1 {
+		t.Fatalf("expected single voxel op, got %+v", d.Voxels)
+	}
+	if d.Entities == nil || len(d.Entities.Upsert) != 2 || !reflect.DeepEqual(d.Entities.Remove, []string{"C1"}) {
+		t.Fatalf("unexpected entities delta: %+v", d.Entities)
+	}
+	got, err := Apply(base, d)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	cur.World.TimeOfDay = base.World.TimeOfDay // not carried by idle deltas
+	if !reflect.DeepEqual(got, cur) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, cur)
+	}
+}
+
+func TestTrackerAckKeyframeResync(t *testing.T) {
+	vox := make([]uint16, 27)
+	tr := NewTracker()
+	tr.KeyframeTicks = 10
+
+	if m, ok := tr.Next(testObs(1, vox), vox, 1).(protocol.ObsMsg); !ok || m.ObsMode != protocol.ObsModeFull {
+		t.Fatalf("expected FULL before any ack, got %T", m)
+	}
+	if !tr.Ack(testObs(1, vox).ObsID) || tr.BaseObsID() != testObs(1, vox).ObsID {
+		t.Fatalf("ack of sent frame should set base")
+	}
+	if _, ok := tr.Next(testObs(2, vox), vox, 2).(protocol.ObsDeltaMsg); !ok {
+		t.Fatalf("expected DELTA after ack")
+	}
+	if _, ok := tr.Next(testObs(11, vox), vox, 11).(protocol.ObsMsg); !ok {
+		t.Fatalf("expected keyframe after KeyframeTicks")
+	}
+	if _, ok := tr.Next(testObs(12, vox), vox, 12).(protocol.ObsDeltaMsg); !ok {
+		t.Fatalf("expected DELTA after keyframe")
+	}
+
+	if tr.Ack("unknown") {
+		t.Fatalf("unknown ack should fail")
+	}
+	if _, ok := tr.Next(testObs(13, vox), vox, 13).(protocol.ObsMsg); !ok {
+		t.Fatalf("expected FULL after unknown ack")
+	}
+
+	tr.Resync()
+	if tr.BaseObsID() != "" {
+		t.Fatalf("resync should drop base")
+	}
+	if _, ok := tr.Next(testObs(14, vox), vox, 14).(protocol.ObsMsg); !ok {
+		t.Fatalf("expected FULL after resync")
+	}
+}
+
+func TestDiffHiddenVoxelsStayQuiet(t *testing.T) {
+	base, cur := testObs(1, nil), testObs(2, nil)
+	base.Voxels = protocol.VoxelsObs{Center: [3]int{1, 0, 1}, Encoding: "NONE"}
+	cur.Voxels = base.Voxels
+	if d := Diff(base, cur, nil, nil); d.Voxels != nil {
+		t.Fatalf("expected no voxel delta while hidden, got %+v", d.Voxels)
+	}
+}
@@ -17,6 +17,8 @@ type ComposeObsInput struct {
 	Tasks      []protocol.TaskObs
 
 	PublicBoards []protocol.BoardObs
+	Markets      []protocol.MarketObs
+	Prices       []protocol.PriceObs
 }
 
 func ComposeObs(in ComposeObsInput) protocol.ObsMsg {
@@ -36,5 +38,7 @@ func ComposeObs(in ComposeObsInput) protocol.ObsMsg {
 		Entities:        in.Entities,
 		Tasks:           in.Tasks,
 		PublicBoards:    in.PublicBoards,
+		Markets:         in.Markets,
+		Prices:          in.Prices,
 	}
 }
@@ -4,8 +4,12 @@ import (
 	"sort"
 
 	"voxelcraft.ai/internal/protocol"
+	machinepkg "voxelcraft.ai/internal/sim/world/feature/automation/machine"
+	signalpkg "voxelcraft.ai/internal/sim/world/feature/automation/signal"
 	conveyruntimepkg "voxelcraft.ai/internal/sim/world/feature/conveyor/runtime"
+	mobspkg "voxelcraft.ai/internal/sim/world/feature/entities/mobs"
 	entitiespkg "voxelcraft.ai/internal/sim/world/feature/observer/entities"
+	farmingpkg "voxelcraft.ai/internal/sim/world/feature/survival/farming"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 )
 
@@ -19,7 +23,11 @@ type BuildEntitiesFromWorldInput struct {
 	Signs      map[modelpkg.Vec3i]*modelpkg.Sign
 	Conveyors  map[modelpkg.Vec3i]modelpkg.ConveyorMeta
 	Switches   map[modelpkg.Vec3i]bool
+	Machines   map[modelpkg.Vec3i]modelpkg.MachineMeta
+	Crops      map[modelpkg.Vec3i]*modelpkg.Crop
+	Signals    map[modelpkg.Vec3i]*modelpkg.SignalNode
 	Items      map[string]*modelpkg.ItemEntity
+	Mobs       map[string]*modelpkg.Mob
 
 	SensorsNear []modelpkg.Vec3i
 
@@ -28,6 +36,7 @@ type BuildEntitiesFromWorldInput struct {
 	SortedConveyorPositionsNear func(pos modelpkg.Vec3i, dist int) []modelpkg.Vec3i
 	SortedSwitchPositionsNear   func(pos modelpkg.Vec3i, dist int) []modelpkg.Vec3i
 	SensorOn                    func(pos modelpkg.Vec3i) bool
+	BlockNameAt                 func(pos modelpkg.Vec3i) string
 
 	ContainerID  func(typ string, pos modelpkg.Vec3i) string
 	SignIDAt     func(pos modelpkg.Vec3i) string
@@ -74,10 +83,28 @@ func BuildEntitiesFromWorld(in BuildEntitiesFromWorldInput) []protocol.EntityObs
 			ID:   c.ID(),
 			Type: c.Type,
 			Pos:  pos,
+			Tags: machinepkg.Tags(in.Machines[c.Pos]),
 		})
 	}
 	ents = append(ents, entitiespkg.BuildSimpleEntities(containers)...)
 
+	if len(in.Machines) > 0 && in.ContainerID != nil && in.BlockNameAt != nil {
+		hoppers := make([]entitiespkg.SimpleInput, 0, 4)
+		for _, p := range modelpkg.SortedPositions(in.Machines) {
+			if modelpkg.Manhattan(p, in.SelfPos) > dist || !machinepkg.Faced(in.BlockNameAt(p)) {
+				continue
+			}
+			m := in.Machines[p]
+			hoppers = append(hoppers, entitiespkg.SimpleInput{
+				ID:   in.ContainerID(machinepkg.Hopper, p),
+				Type: machinepkg.Hopper,
+				Pos:  entitiespkg.Pos{X: p.X, Y: p.Y, Z: p.Z},
+				Tags: []string{"dir:" + conveyruntimepkg.DirectionTag(int(m.DX), int(m.DZ))},
+			})
+		}
+		ents = append(ents, entitiespkg.BuildSimpleEntities(hoppers)...)
+	}
+
 	if len(in.Boards) > 0 && in.ParseContainerID != nil {
 		boardEntries := make([]entitiespkg.SimpleInput, 0, len(in.Boards))
 		for id := range in.Boards {
@@ -141,6 +168,24 @@ func BuildEntitiesFromWorld(in BuildEntitiesFromWorldInput) []protocol.EntityObs
 		ents = append(ents, entitiespkg.BuildSwitchEntities(switches)...)
 	}
 
+	if len(in.Crops) > 0 && in.ContainerID != nil {
+		crops := make([]entitiespkg.CropInput, 0, 8)
+		for _, p := range modelpkg.SortedPositions(in.Crops) {
+			if modelpkg.Manhattan(p, in.SelfPos) > dist {
+				continue
+			}
+			c := in.Crops[p]
+			crops = append(crops, entitiespkg.CropInput{
+				ID:    in.ContainerID("CROP", p),
+				Pos:   entitiespkg.Pos{X: p.X, Y: p.Y, Z: p.Z},
+				Type:  c.Type,
+				Stage: c.Stage,
+				Ripe:  farmingpkg.Ripe(*c),
+			})
+		}
+		ents = append(ents, entitiespkg.BuildCropEntities(crops)...)
+	}
+
 	if len(in.SensorsNear) > 0 && in.ContainerID != nil && in.SensorOn != nil {
 		sensorsNear := append([]modelpkg.Vec3i(nil), in.SensorsNear...)
 		sort.Slice(sensorsNear, func(i, j int) bool {
@@ -154,15 +199,44 @@ func BuildEntitiesFromWorld(in BuildEntitiesFromWorldInput) []protocol.EntityObs
 		})
 		sensors := make([]entitiespkg.SensorInput, 0, len(sensorsNear))
 		for _, p := range sensorsNear {
+			mode := ""
+			if n := in.Signals[p]; n != nil {
+				mode = signalpkg.SensorMode(*n)
+			}
 			sensors = append(sensors, entitiespkg.SensorInput{
-				ID:  in.ContainerID("SENSOR", p),
-				Pos: entitiespkg.Pos{X: p.X, Y: p.Y, Z: p.Z},
-				On:  in.SensorOn(p),
+				ID:   in.ContainerID("SENSOR", p),
+				Pos:  entitiespkg.Pos{X: p.X, Y: p.Y, Z: p.Z},
+				On:   in.SensorOn(p),
+				Mode: mode,
 			})
 		}
 		ents = append(ents, entitiespkg.BuildSensorEntities(sensors)...)
 	}
 
+	if len(in.Signals) > 0 && in.ContainerID != nil && in.BlockNameAt != nil {
+		signals := make([]entitiespkg.SignalInput, 0, 8)
+		for _, p := range modelpkg.SortedPositions(in.Signals) {
+			if modelpkg.Manhattan(p, in.SelfPos) > dist {
+				continue
+			}
+			name := in.BlockNameAt(p)
+			if !signalpkg.Faced(name) {
+				continue
+			}
+			n := in.Signals[p]
+			signals = append(signals, entitiespkg.SignalInput{
+				ID:      in.ContainerID(name, p),
+				Pos:     entitiespkg.Pos{X: p.X, Y: p.Y, Z: p.Z},
+				Type:    name,
+				DirTag:  conveyruntimepkg.DirectionTag(int(n.DX), int(n.DZ)),
+				On:      n.Level > 0,
+				Charge:  n.Charge,
+				Battery: name == signalpkg.Battery,
+			})
+		}
+		ents = append(ents, entitiespkg.BuildSignalEntities(signals)...)
+	}
+
 	if len(in.Items) > 0 {
 		items := make([]entitiespkg.ItemInput, 0, len(in.Items))
 		for _, e := range in.Items {
@@ -183,5 +257,28 @@ func BuildEntitiesFromWorld(in BuildEntitiesFromWorldInput) []protocol.EntityObs
 		ents = append(ents, entitiespkg.BuildItemEntities(items)...)
 	}
 
+	if len(in.Mobs) > 0 {
+		mobs := make([]entitiespkg.MobInput, 0, len(in.Mobs))
+		for _, m := range in.Mobs {
+			if m == nil || m.HP <= 0 {
+				continue
+			}
+			pos := entitiespkg.Pos{X: m.Pos.X, Y: m.Pos.Y, Z: m.Pos.Z}
+			if !entitiespkg.IsNear(selfPos, pos, dist) {
+				continue
+			}
+			def, _ := mobspkg.DefFor(m.Kind)
+			mobs = append(mobs, entitiespkg.MobInput{
+				ID:      m.EntityID,
+				Pos:     pos,
+				Kind:    m.Kind,
+				State:   m.State,
+				HP:      m.HP,
+				Hostile: def.Hostile,
+			})
+		}
+		ents = append(ents, entitiespkg.BuildMobEntities(mobs)...)
+	}
+
 	return ents
 }
@@ -37,7 +37,7 @@ func BuildTasksFromWorld(in BuildTasksFromWorldInput, followTargetPos func(id st
 			Progress: in.Progress,
 		}
 	}
-	return taskspkg.BuildTasks(taskspkg.BuildInput{
+	out := taskspkg.BuildTasks(taskspkg.BuildInput{
 		SelfPos: taskspkg.Vec3{X: a.Pos.X, Y: a.Pos.Y, Z: a.Pos.Z},
 		Move:    moveIn,
 		Work:    workIn,
@@ -50,4 +50,29 @@ func BuildTasksFromWorld(in BuildTasksFromWorldInput, followTargetPos func(id st
 		}
 		return taskspkg.Vec3{}, false
 	})
+	return appendQueuedTasks(out, a.TaskQueue)
+}
+
+// appendQueuedTasks tags the running queue head with its ref and lists the rest as
+// QUEUED entries keyed by their request id.
+func appendQueuedTasks(out []protocol.TaskObs, queue []modelpkg.QueuedTask) []protocol.TaskObs {
+	for _, q := range queue {
+		if q.TaskID != "" {
+			for i := range out {
+				if out[i].TaskID == q.TaskID {
+					out[i].Ref = q.Req.ID
+				}
+			}
+			continue
+		}
+		t := protocol.TaskObs{TaskID: q.Req.ID, Kind: q.Req.Type, Status: "QUEUED", Ref: q.Req.ID}
+		switch q.Req.Type {
+		case "MOVE_TO":
+			t.Target = q.Req.Target
+		case "MINE":
+			t.Target = q.Req.BlockPos
+		}
+		out = append(out, t)
+	}
+	return out
 }
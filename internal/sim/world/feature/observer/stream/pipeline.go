@@ -259,7 +259,7 @@ func StepVoxelRuntime(states map[ChunkKey]*VoxelRuntimeState, in VoxelStepInput,
 			continue
 		}
 		wx, wy, wz := e.Pos[0], e.Pos[1], e.Pos[2]
-		if wy != 0 {
+		if wy < 0 {
 			continue
 		}
 		cx := floorDiv(wx, 16)
@@ -274,7 +274,7 @@ func StepVoxelRuntime(states map[ChunkKey]*VoxelRuntimeState, in VoxelStepInput,
 		}
 		lx := mod(wx, 16)
 		lz := mod(wz, 16)
-		idx := lx + lz*16
+		idx := lx + lz*16 + wy*256
 		if idx < 0 || idx >= len(st.Blocks) {
 			continue
 		}
@@ -287,7 +287,7 @@ func StepVoxelRuntime(states map[ChunkKey]*VoxelRuntimeState, in VoxelStepInput,
 			m = map[int]VoxelPatchCell{}
 			patches[key] = m
 		}
-		m[idx] = VoxelPatchCell{X: lx, Y: 0, Z: lz, Block: e.To}
+		m[idx] = VoxelPatchCell{X: lx, Y: wy, Z: lz, Block: e.To}
 	}
 
 	for key, m := range patches {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"voxelcraft.ai/internal/observerproto"
+	"voxelcraft.ai/internal/protocol"
 )
 
 type TickBuildInput struct {
@@ -17,6 +18,7 @@ type TickBuildInput struct {
 	Leaves              []string
 	Actions             []observerproto.RecordedAction
 	Audits              []observerproto.AuditEntry
+	Markets             []protocol.MarketObs
 }
 
 func BuildTickMsgBytes(in TickBuildInput) ([]byte, error) {
@@ -33,6 +35,7 @@ func BuildTickMsgBytes(in TickBuildInput) ([]byte, error) {
 		Leaves:              in.Leaves,
 		Actions:             in.Actions,
 		Audits:              in.Audits,
+		Markets:             in.Markets,
 	}
 	return json.Marshal(msg)
 }
@@ -15,6 +15,7 @@ type VoxelPos struct {
 type VoxelsBuildInput struct {
 	Center      VoxelPos
 	Radius      int
+	Height      int // world height; layers outside [0,Height) stay air
 	AirBlock    uint16
 	HasSensor   bool
 	SensorBlock uint16
@@ -29,7 +30,7 @@ type VoxelsBuildOutput struct {
 	SensorPos []VoxelPos
 }
 
-func BuildObsVoxels2D(in VoxelsBuildInput, getBlock func(pos VoxelPos) uint16) VoxelsBuildOutput {
+func BuildObsVoxels(in VoxelsBuildInput, getBlock func(pos VoxelPos) uint16) VoxelsBuildOutput {
 	r := in.Radius
 	dim := 2*r + 1
 	plane := dim * dim
@@ -43,13 +44,20 @@ func BuildObsVoxels2D(in VoxelsBuildInput, getBlock func(pos VoxelPos) uint16) V
 		}
 	}
 
-	dy0 := -in.Center.Y
-	if dy0 >= -r && dy0 <= r {
-		layerOff := (dy0 + r) * plane
+	height := in.Height
+	if height < 1 {
+		height = 1
+	}
+	for dy := -r; dy <= r; dy++ {
+		wy := in.Center.Y + dy
+		if wy < 0 || wy >= height {
+			continue
+		}
+		layerOff := (dy + r) * plane
 		for dz := -r; dz <= r; dz++ {
 			rowOff := layerOff + (dz+r)*dim
 			for dx := -r; dx <= r; dx++ {
-				p := VoxelPos{X: in.Center.X + dx, Y: 0, Z: in.Center.Z + dz}
+				p := VoxelPos{X: in.Center.X + dx, Y: wy, Z: in.Center.Z + dz}
 				b := getBlock(p)
 				curr[rowOff+(dx+r)] = b
 				if in.HasSensor && b == in.SensorBlock {
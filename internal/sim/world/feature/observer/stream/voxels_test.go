@@ -6,7 +6,7 @@ import (
 	simenc "voxelcraft.ai/internal/sim/encoding"
 )
 
-func TestBuildObsVoxels2D_RLE(t *testing.T) {
+func TestBuildObsVoxels_RLE(t *testing.T) {
 	in := VoxelsBuildInput{
 		Center:      VoxelPos{X: 10, Y: 0, Z: 20},
 		Radius:      1,
@@ -14,7 +14,7 @@ func TestBuildObsVoxels2D_RLE(t *testing.T) {
 		HasSensor:   true,
 		SensorBlock: 7,
 	}
-	out := BuildObsVoxels2D(in, func(pos VoxelPos) uint16 {
+	out := BuildObsVoxels(in, func(pos VoxelPos) uint16 {
 		if pos.X == 10 && pos.Z == 20 {
 			return 7
 		}
@@ -35,7 +35,7 @@ func TestBuildObsVoxels2D_RLE(t *testing.T) {
 	}
 }
 
-func TestBuildObsVoxels2D_Delta(t *testing.T) {
+func TestBuildObsVoxels_Delta(t *testing.T) {
 	// Start with all AIR in a 3x3x3 volume.
 	last := make([]uint16, 27)
 	in := VoxelsBuildInput{
@@ -45,7 +45,7 @@ func TestBuildObsVoxels2D_Delta(t *testing.T) {
 		DeltaEnabled: true,
 		LastVoxels:   last,
 	}
-	out := BuildObsVoxels2D(in, func(pos VoxelPos) uint16 {
+	out := BuildObsVoxels(in, func(pos VoxelPos) uint16 {
 		if pos.X == 0 && pos.Z == 0 {
 			return 5
 		}
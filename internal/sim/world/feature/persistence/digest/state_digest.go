@@ -4,11 +4,14 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
+	"io"
 	"math"
 	"sort"
 
 	lawspkg "voxelcraft.ai/internal/sim/world/feature/governance/laws"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
 	storepkg "voxelcraft.ai/internal/sim/world/terrain/store"
 )
 
@@ -36,37 +39,99 @@ type StateInput struct {
 	Orgs       map[string]*modelpkg.Organization
 	Containers map[modelpkg.Vec3i]*modelpkg.Container
 	Items      map[string]*modelpkg.ItemEntity
+	Mobs       map[string]*modelpkg.Mob
 	Signs      map[modelpkg.Vec3i]*modelpkg.Sign
 	Conveyors  map[modelpkg.Vec3i]modelpkg.ConveyorMeta
 	Switches   map[modelpkg.Vec3i]bool
+	Machines   map[modelpkg.Vec3i]modelpkg.MachineMeta
+	Shops      map[modelpkg.Vec3i]*modelpkg.Shop
+	Crops      map[modelpkg.Vec3i]*modelpkg.Crop
+	// Water levels / signal nodes, each with the cells queued for their next update.
+	Water      map[modelpkg.Vec3i]int
+	WaterWake  map[modelpkg.Vec3i]bool
+	Signals    map[modelpkg.Vec3i]*modelpkg.SignalNode
+	SignalWake map[modelpkg.Vec3i]bool
 	Contracts  map[string]*modelpkg.Contract
+	Orders     map[string]*modelpkg.MarketOrder
+	Prices     map[string]*modelpkg.PriceSeries
 	Trades     map[string]*modelpkg.Trade
 	Boards     map[string]*modelpkg.Board
 	Structures map[string]*modelpkg.Structure
 	Agents     map[string]*modelpkg.Agent
 }
 
+// Section is the digest of one state section (header, chunks, agents, ...).
+type Section struct {
+	Name string
+	Hash string
+}
+
+type sectionFn func(h hashWriter, tmp *[8]byte, in StateInput)
+
+// stateSections is the fixed digest order; StateDigest hashes them back to back into one stream.
+var stateSections = []struct {
+	name string
+	fn   sectionFn
+}{
+	{"header", digestHeader},
+	{"chunks", digestChunks},
+	{"claims", func(h hashWriter, tmp *[8]byte, in StateInput) { digestClaims(h, tmp, in.Claims) }},
+	{"laws", func(h hashWriter, tmp *[8]byte, in StateInput) { digestLaws(h, tmp, in.Laws) }},
+	{"orgs", func(h hashWriter, tmp *[8]byte, in StateInput) { digestOrgs(h, tmp, in.Orgs) }},
+	{"containers", func(h hashWriter, tmp *[8]byte, in StateInput) { digestContainers(h, tmp, in.Containers) }},
+	{"items", func(h hashWriter, tmp *[8]byte, in StateInput) { digestItems(h, tmp, in.Items) }},
+	{"mobs", func(h hashWriter, tmp *[8]byte, in StateInput) { digestMobs(h, tmp, in.Mobs) }},
+	{"signs", func(h hashWriter, tmp *[8]byte, in StateInput) { digestSigns(h, tmp, in.Signs) }},
+	{"conveyors", func(h hashWriter, tmp *[8]byte, in StateInput) { digestConveyors(h, tmp, in.Conveyors) }},
+	{"switches", func(h hashWriter, tmp *[8]byte, in StateInput) { digestSwitches(h, tmp, in.Switches) }},
+	{"crops", func(h hashWriter, tmp *[8]byte, in StateInput) { digestCrops(h, tmp, in.Crops) }},
+	{"water", func(h hashWriter, tmp *[8]byte, in StateInput) { digestWater(h, tmp, in.Water, in.WaterWake) }},
+	{"signals", func(h hashWriter, tmp *[8]byte, in StateInput) { digestSignals(h, tmp, in.Signals, in.SignalWake) }},
+	{"machines", func(h hashWriter, tmp *[8]byte, in StateInput) { digestMachines(h, tmp, in.Machines) }},
+	{"shops", func(h hashWriter, tmp *[8]byte, in StateInput) { digestShops(h, tmp, in.Shops) }},
+	{"contracts", func(h hashWriter, tmp *[8]byte, in StateInput) { digestContracts(h, tmp, in.Contracts) }},
+	{"orders", func(h hashWriter, tmp *[8]byte, in StateInput) { digestOrders(h, tmp, in.Orders) }},
+	{"prices", func(h hashWriter, tmp *[8]byte, in StateInput) { digestPrices(h, tmp, in.Prices) }},
+	{"trades", func(h hashWriter, tmp *[8]byte, in StateInput) { digestTrades(h, tmp, in.Trades) }},
+	{"boards", func(h hashWriter, tmp *[8]byte, in StateInput) { digestBoards(h, tmp, in.Boards) }},
+	{"structures", func(h hashWriter, tmp *[8]byte, in StateInput) { digestStructures(h, tmp, in.Structures) }},
+	{"agents", func(h hashWriter, tmp *[8]byte, in StateInput) { digestAgents(h, tmp, in.Agents) }},
+}
+
 func StateDigest(in StateInput) string {
 	h := sha256.New()
 	var tmp [8]byte
+	for _, s := range stateSections {
+		s.fn(h, &tmp, in)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
 
-	digestHeader(h, &tmp, in)
-	digestChunks(h, &tmp, in)
-	digestClaims(h, &tmp, in.Claims)
-	digestLaws(h, &tmp, in.Laws)
-	digestOrgs(h, &tmp, in.Orgs)
-	digestContainers(h, &tmp, in.Containers)
-	digestItems(h, &tmp, in.Items)
-	digestSigns(h, &tmp, in.Signs)
-	digestConveyors(h, &tmp, in.Conveyors)
-	digestSwitches(h, &tmp, in.Switches)
-	digestContracts(h, &tmp, in.Contracts)
-	digestTrades(h, &tmp, in.Trades)
-	digestBoards(h, &tmp, in.Boards)
-	digestStructures(h, &tmp, in.Structures)
-	digestAgents(h, &tmp, in.Agents)
+// SectionDigests hashes each section independently (same order as StateDigest) so a
+// mismatching StateDigest can be narrowed to the sections that diverged.
+func SectionDigests(in StateInput) []Section {
+	out := make([]Section, 0, len(stateSections))
+	var tmp [8]byte
+	for _, s := range stateSections {
+		h := sha256.New()
+		s.fn(h, &tmp, in)
+		out = append(out, Section{Name: s.name, Hash: hex.EncodeToString(h.Sum(nil))})
+	}
+	return out
+}
 
-	return hex.EncodeToString(h.Sum(nil))
+// StateDigestWithSections returns StateDigest together with SectionDigests from a single
+// walk of the state, for tick logs that record both.
+func StateDigestWithSections(in StateInput) (string, []Section) {
+	h := sha256.New()
+	out := make([]Section, 0, len(stateSections))
+	var tmp [8]byte
+	for _, s := range stateSections {
+		sh := sha256.New()
+		s.fn(io.MultiWriter(h, sh), &tmp, in)
+		out = append(out, Section{Name: s.name, Hash: hex.EncodeToString(sh.Sum(nil))})
+	}
+	return hex.EncodeToString(h.Sum(nil)), out
 }
 
 type hashWriter interface {
@@ -138,21 +203,41 @@ func digestClaims(h hashWriter, tmp *[8]byte, claims map[string]*modelpkg.LandCl
 		} else {
 			digestWriteU64(h, tmp, 0)
 		}
-		digestWriteU64(h, tmp, math.Float64bits(c.MarketTax))
-		h.Write([]byte{BoolByte(c.CurfewEnabled)})
-		digestWriteU64(h, tmp, math.Float64bits(c.CurfewStart))
-		digestWriteU64(h, tmp, math.Float64bits(c.CurfewEnd))
-		h.Write([]byte{BoolByte(c.FineBreakEnabled)})
-		h.Write([]byte(c.FineBreakItem))
-		digestWriteU64(h, tmp, uint64(c.FineBreakPerBlock))
-		h.Write([]byte{BoolByte(c.AccessPassEnabled)})
-		h.Write([]byte(c.AccessTicketItem))
-		digestWriteU64(h, tmp, uint64(c.AccessTicketCost))
+		digestWriteU64(h, tmp, uint64(len(c.Policies)))
+		for _, p := range c.Policies {
+			digestPolicy(h, tmp, p)
+		}
 		digestWriteU64(h, tmp, c.MaintenanceDueTick)
 		digestWriteU64(h, tmp, uint64(c.MaintenanceStage))
 	}
 }
 
+func digestPolicy(h hashWriter, tmp *[8]byte, p rules.Policy) {
+	h.Write([]byte(p.LawID))
+	h.Write([]byte(p.TemplateID))
+	digestWriteU64(h, tmp, uint64(len(p.Rules)))
+	for _, r := range p.Rules {
+		digestWriteU64(h, tmp, uint64(len(r.Actions)))
+		for _, act := range r.Actions {
+			h.Write([]byte(act))
+		}
+		h.Write([]byte(r.Actor))
+		h.Write([]byte(r.Region))
+		digestWriteU64(h, tmp, uint64(len(r.Items)))
+		for _, it := range r.Items {
+			h.Write([]byte(it))
+		}
+		h.Write([]byte(r.Outcome))
+		h.Write([]byte{BoolByte(r.TimeWindow)})
+		digestWriteU64(h, tmp, math.Float64bits(r.TimeFrom))
+		digestWriteU64(h, tmp, math.Float64bits(r.TimeTo))
+		h.Write([]byte(r.Effect))
+		h.Write([]byte(r.Item))
+		digestWriteI64(h, tmp, int64(r.Amount))
+		digestWriteU64(h, tmp, math.Float64bits(r.Rate))
+	}
+}
+
 func digestLaws(h hashWriter, tmp *[8]byte, laws map[string]*lawspkg.Law) {
 	if len(laws) == 0 {
 		return
@@ -318,6 +403,36 @@ func digestItems(h hashWriter, tmp *[8]byte, items map[string]*modelpkg.ItemEnti
 	digestWriteU64(h, tmp, 0)
 }
 
+func digestMobs(h hashWriter, tmp *[8]byte, mobs map[string]*modelpkg.Mob) {
+	ids := make([]string, 0, len(mobs))
+	for id, m := range mobs {
+		if m == nil || m.HP <= 0 {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	digestWriteU64(h, tmp, uint64(len(ids)))
+	for _, id := range ids {
+		m := mobs[id]
+		h.Write([]byte(id))
+		h.Write([]byte(m.Kind))
+		digestWriteI64(h, tmp, int64(m.Pos.X))
+		digestWriteI64(h, tmp, int64(m.Pos.Y))
+		digestWriteI64(h, tmp, int64(m.Pos.Z))
+		digestWriteI64(h, tmp, int64(m.Home.X))
+		digestWriteI64(h, tmp, int64(m.Home.Y))
+		digestWriteI64(h, tmp, int64(m.Home.Z))
+		digestWriteI64(h, tmp, int64(m.HP))
+		h.Write([]byte(m.State))
+		h.Write([]byte(m.TargetID))
+		digestWriteU64(h, tmp, m.StateTick)
+		digestWriteU64(h, tmp, m.AttackTick)
+		digestWriteU64(h, tmp, m.CreatedTick)
+		digestWriteU64(h, tmp, m.DespawnTick)
+	}
+}
+
 func digestSigns(h hashWriter, tmp *[8]byte, signs map[modelpkg.Vec3i]*modelpkg.Sign) {
 	if len(signs) > 0 {
 		posKeys := make([]modelpkg.Vec3i, 0, len(signs))
@@ -383,6 +498,120 @@ func digestConveyors(h hashWriter, tmp *[8]byte, conveyors map[modelpkg.Vec3i]mo
 	digestWriteU64(h, tmp, 0)
 }
 
+// digestCrops writes nothing for a world without crops so pre-farming digests are unchanged.
+func digestCrops(h hashWriter, tmp *[8]byte, crops map[modelpkg.Vec3i]*modelpkg.Crop) {
+	if len(crops) == 0 {
+		return
+	}
+	posKeys := modelpkg.SortedPositions(crops)
+	digestWriteU64(h, tmp, uint64(len(posKeys)))
+	for _, p := range posKeys {
+		c := crops[p]
+		digestWriteI64(h, tmp, int64(p.X))
+		digestWriteI64(h, tmp, int64(p.Y))
+		digestWriteI64(h, tmp, int64(p.Z))
+		h.Write([]byte(c.Type))
+		digestWriteI64(h, tmp, int64(c.Stage))
+		digestWriteI64(h, tmp, int64(c.Growth))
+		digestWriteU64(h, tmp, c.PlantedTick)
+	}
+}
+
+// digestWater, like digestCrops, writes nothing for a world without moving water.
+func digestWater(h hashWriter, tmp *[8]byte, levels map[modelpkg.Vec3i]int, active map[modelpkg.Vec3i]bool) {
+	if len(levels) == 0 && len(active) == 0 {
+		return
+	}
+	for _, m := range []map[modelpkg.Vec3i]int{levels, activeLevels(active)} {
+		posKeys := modelpkg.SortedPositions(m)
+		digestWriteU64(h, tmp, uint64(len(posKeys)))
+		for _, p := range posKeys {
+			digestWriteI64(h, tmp, int64(p.X))
+			digestWriteI64(h, tmp, int64(p.Y))
+			digestWriteI64(h, tmp, int64(p.Z))
+			digestWriteI64(h, tmp, int64(m[p]))
+		}
+	}
+}
+
+func digestSignals(h hashWriter, tmp *[8]byte, nodes map[modelpkg.Vec3i]*modelpkg.SignalNode, active map[modelpkg.Vec3i]bool) {
+	if len(nodes) == 0 && len(active) == 0 {
+		return
+	}
+	posKeys := modelpkg.SortedPositions(nodes)
+	digestWriteU64(h, tmp, uint64(len(posKeys)))
+	for _, p := range posKeys {
+		n := nodes[p]
+		digestWriteI64(h, tmp, int64(p.X))
+		digestWriteI64(h, tmp, int64(p.Y))
+		digestWriteI64(h, tmp, int64(p.Z))
+		digestWriteI64(h, tmp, int64(n.Level))
+		digestWriteI64(h, tmp, int64(n.DX))
+		digestWriteI64(h, tmp, int64(n.DZ))
+		digestWriteI64(h, tmp, int64(n.Charge))
+		h.Write([]byte(n.Mode))
+		digestWriteI64(h, tmp, int64(n.Arg))
+	}
+	digestWater(h, tmp, nil, active)
+}
+
+func activeLevels(active map[modelpkg.Vec3i]bool) map[modelpkg.Vec3i]int {
+	out := make(map[modelpkg.Vec3i]int, len(active))
+	for p, on := range active {
+		if on {
+			out[p] = 1
+		}
+	}
+	return out
+}
+
+func digestMachines(h hashWriter, tmp *[8]byte, machines map[modelpkg.Vec3i]modelpkg.MachineMeta) {
+	if len(machines) == 0 {
+		return
+	}
+	posKeys := modelpkg.SortedPositions(machines)
+	digestWriteU64(h, tmp, uint64(len(posKeys)))
+	for _, p := range posKeys {
+		m := machines[p]
+		digestWriteI64(h, tmp, int64(p.X))
+		digestWriteI64(h, tmp, int64(p.Y))
+		digestWriteI64(h, tmp, int64(p.Z))
+		digestWriteI64(h, tmp, int64(m.DX))
+		digestWriteI64(h, tmp, int64(m.DZ))
+		h.Write([]byte(m.Owner))
+		h.Write([]byte(m.RecipeID))
+		digestWriteI64(h, tmp, int64(m.Progress))
+		digestWriteI64(h, tmp, int64(m.Fuel))
+	}
+}
+
+func digestShops(h hashWriter, tmp *[8]byte, shops map[modelpkg.Vec3i]*modelpkg.Shop) {
+	if len(shops) == 0 {
+		return
+	}
+	posKeys := modelpkg.SortedPositions(shops)
+	digestWriteU64(h, tmp, uint64(len(posKeys)))
+	for _, p := range posKeys {
+		s := shops[p]
+		digestWriteI64(h, tmp, int64(p.X))
+		digestWriteI64(h, tmp, int64(p.Y))
+		digestWriteI64(h, tmp, int64(p.Z))
+		h.Write([]byte(s.Owner))
+		items := make([]string, 0, len(s.Prices))
+		for item := range s.Prices {
+			items = append(items, item)
+		}
+		sort.Strings(items)
+		digestWriteU64(h, tmp, uint64(len(items)))
+		for _, item := range items {
+			pr := s.Prices[item]
+			h.Write([]byte(item))
+			h.Write([]byte(pr.PriceItem))
+			digestWriteI64(h, tmp, int64(pr.Price))
+		}
+	}
+}
+
 func digestSwitches(h hashWriter, tmp *[8]byte, switches map[modelpkg.Vec3i]bool) {
 	if len(switches) > 0 {
 		posKeys := make([]modelpkg.Vec3i, 0, len(switches))
@@ -437,6 +666,81 @@ func digestContracts(h hashWriter, tmp *[8]byte, contracts map[string]*modelpkg.
 		digestWriteI64(h, tmp, int64(c.Anchor.Y))
 		digestWriteI64(h, tmp, int64(c.Anchor.Z))
 		digestWriteU64(h, tmp, uint64(c.Rotation))
+		if c.Kind == "LOAN" {
+			digestWriteU64(h, tmp, uint64(c.InterestPct))
+			h.Write([]byte(c.CollateralLand))
+			digestWriteU64(h, tmp, uint64(len(c.Installments)))
+			for _, inst := range c.Installments {
+				digestWriteU64(h, tmp, inst.DueTick)
+				WriteSortedNonZeroIntMap(h, tmp, inst.Items)
+				if inst.Paid {
+					h.Write([]byte{1})
+				} else {
+					h.Write([]byte{0})
+				}
+			}
+		}
+		if c.Kind == "AUCTION" {
+			h.Write([]byte(c.AuctionType))
+			h.Write([]byte(c.PriceItem))
+			digestWriteU64(h, tmp, uint64(c.ReservePrice))
+			digestWriteU64(h, tmp, uint64(len(c.Bids)))
+			for _, b := range c.Bids {
+				h.Write([]byte(b.Bidder))
+				digestWriteU64(h, tmp, uint64(b.Amount))
+				digestWriteU64(h, tmp, b.Tick)
+			}
+		}
+	}
+}
+
+func digestOrders(h hashWriter, tmp *[8]byte, orders map[string]*modelpkg.MarketOrder) {
+	if len(orders) == 0 {
+		return
+	}
+	orderIDs := make([]string, 0, len(orders))
+	for id := range orders {
+		orderIDs = append(orderIDs, id)
+	}
+	sort.Strings(orderIDs)
+	digestWriteU64(h, tmp, uint64(len(orderIDs)))
+	for _, id := range orderIDs {
+		o := orders[id]
+		h.Write([]byte(id))
+		h.Write([]byte(o.Owner))
+		h.Write([]byte(string(o.Side)))
+		h.Write([]byte(o.Item))
+		h.Write([]byte(o.PriceItem))
+		digestWriteI64(h, tmp, int64(o.Price))
+		digestWriteI64(h, tmp, int64(o.Count))
+		digestWriteU64(h, tmp, o.CreatedTick)
+		digestWriteI64(h, tmp, int64(o.TerminalPos.X))
+		digestWriteI64(h, tmp, int64(o.TerminalPos.Y))
+		digestWriteI64(h, tmp, int64(o.TerminalPos.Z))
+	}
+}
+
+func digestPrices(h hashWriter, tmp *[8]byte, series map[string]*modelpkg.PriceSeries) {
+	items := make([]string, 0, len(series))
+	for item, s := range series {
+		if s != nil && len(s.Samples) > 0 {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return
+	}
+	sort.Strings(items)
+	digestWriteU64(h, tmp, uint64(len(items)))
+	for _, item := range items {
+		s := series[item]
+		h.Write([]byte(item))
+		digestWriteU64(h, tmp, uint64(len(s.Samples)))
+		for _, smp := range s.Samples {
+			digestWriteU64(h, tmp, smp.Tick)
+			digestWriteI64(h, tmp, smp.Price)
+			digestWriteI64(h, tmp, int64(smp.Volume))
+		}
 	}
 }
 
@@ -607,6 +911,15 @@ func digestAgents(h hashWriter, tmp *[8]byte, agents map[string]*modelpkg.Agent)
 			digestWriteI64(h, tmp, int64(mt.StartPos.Y))
 			digestWriteI64(h, tmp, int64(mt.StartPos.Z))
 			digestWriteU64(h, tmp, mt.StartedTick)
+			digestWriteI64(h, tmp, int64(mt.PathGoal.X))
+			digestWriteI64(h, tmp, int64(mt.PathGoal.Y))
+			digestWriteI64(h, tmp, int64(mt.PathGoal.Z))
+			digestWriteU64(h, tmp, uint64(len(mt.Path)))
+			for _, p := range mt.Path {
+				digestWriteI64(h, tmp, int64(p.X))
+				digestWriteI64(h, tmp, int64(p.Y))
+				digestWriteI64(h, tmp, int64(p.Z))
+			}
 		}
 		h.Write([]byte{BoolByte(a.WorkTask != nil)})
 		if a.WorkTask != nil {
@@ -631,6 +944,78 @@ func digestAgents(h hashWriter, tmp *[8]byte, agents map[string]*modelpkg.Agent)
 			digestWriteU64(h, tmp, wt.StartedTick)
 			digestWriteU64(h, tmp, uint64(wt.WorkTicks))
 		}
+		// Task queue (only when non-empty so queue-less digests are unchanged).
+		if len(a.TaskQueue) > 0 {
+			digestWriteU64(h, tmp, uint64(len(a.TaskQueue)))
+			for _, q := range a.TaskQueue {
+				r := q.Req
+				h.Write([]byte(r.ID))
+				h.Write([]byte(r.Type))
+				for _, v := range [][3]int{r.Target, r.BlockPos, r.Anchor} {
+					digestWriteI64(h, tmp, int64(v[0]))
+					digestWriteI64(h, tmp, int64(v[1]))
+					digestWriteI64(h, tmp, int64(v[2]))
+				}
+				digestWriteU64(h, tmp, math.Float64bits(r.Tolerance))
+				digestWriteU64(h, tmp, math.Float64bits(r.Distance))
+				h.Write([]byte(r.TargetID))
+				h.Write([]byte(r.Src))
+				h.Write([]byte(r.Dst))
+				h.Write([]byte(r.RecipeID))
+				h.Write([]byte(r.ItemID))
+				h.Write([]byte(r.BlueprintID))
+				digestWriteI64(h, tmp, int64(r.Count))
+				digestWriteI64(h, tmp, int64(r.Rotation))
+				digestWriteI64(h, tmp, int64(r.Radius))
+				h.Write([]byte(r.OnFail))
+				digestWriteI64(h, tmp, int64(r.Retries))
+				digestWriteI64(h, tmp, int64(q.Attempts))
+				h.Write([]byte(q.TaskID))
+				digestWriteU64(h, tmp, q.NotBefore)
+				h.Write([]byte(q.EndType))
+				h.Write([]byte(q.EndCode))
+			}
+		}
+		// Triggers (same rule: only when present).
+		if len(a.Triggers) > 0 {
+			digestWriteU64(h, tmp, uint64(len(a.Triggers)))
+			for _, t := range a.Triggers {
+				h.Write([]byte(t.ID))
+				h.Write([]byte(t.On))
+				for _, c := range t.Where {
+					h.Write([]byte(c.Field))
+					h.Write([]byte(c.Op))
+					h.Write([]byte(c.Str))
+					digestWriteU64(h, tmp, math.Float64bits(c.Num))
+				}
+				// Actions hash as JSON: struct fields in order, map keys sorted.
+				if t.Instant != nil {
+					b, _ := json.Marshal(t.Instant)
+					h.Write(b)
+				}
+				if t.Task != nil {
+					b, _ := json.Marshal(t.Task)
+					h.Write(b)
+				}
+				digestWriteI64(h, tmp, int64(t.CooldownTicks))
+				digestWriteI64(h, tmp, int64(t.MaxFires))
+				digestWriteI64(h, tmp, int64(t.Fires))
+				digestWriteU64(h, tmp, t.LastFireTick)
+			}
+		}
+		// World permits (same rule: only when held).
+		if len(a.Permits) > 0 {
+			permitIDs := make([]string, 0, len(a.Permits))
+			for id := range a.Permits {
+				permitIDs = append(permitIDs, id)
+			}
+			sort.Strings(permitIDs)
+			digestWriteU64(h, tmp, uint64(len(permitIDs)))
+			for _, id := range permitIDs {
+				h.Write([]byte(id))
+				digestWriteU64(h, tmp, a.Permits[id])
+			}
+		}
 
 		// Inventory (sorted).
 		inv := a.InventoryList()
@@ -0,0 +1,72 @@
+package digest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func sampleInput() StateInput {
+	return StateInput{
+		NowTick: 9,
+		Seed:    42,
+		Weather: "CLEAR",
+		Agents: map[string]*modelpkg.Agent{
+			"A1": {ID: "A1", Name: "a", Inventory: map[string]int{"PLANK": 2}},
+		},
+		Items: map[string]*modelpkg.ItemEntity{
+			"IT000001": {EntityID: "IT000001", Item: "COAL", Count: 1},
+		},
+	}
+}
+
+func TestSectionDigestsComposeStateDigest(t *testing.T) {
+	in := sampleInput()
+	secs := SectionDigests(in)
+	if len(secs) != len(stateSections) || secs[0].Name != "header" || secs[len(secs)-1].Name != "agents" {
+		t.Fatalf("unexpected sections: %+v", secs)
+	}
+
+	// The combined digest is the single stream of all sections, not a hash of section hashes.
+	h := sha256.New()
+	var tmp [8]byte
+	for _, s := range stateSections {
+		s.fn(h, &tmp, in)
+	}
+	if got := StateDigest(in); got != hex.EncodeToString(h.Sum(nil)) {
+		t.Fatalf("StateDigest changed encoding")
+	}
+}
+
+func TestStateDigestWithSectionsMatchesSeparatePasses(t *testing.T) {
+	in := sampleInput()
+	d, secs := StateDigestWithSections(in)
+	if d != StateDigest(in) {
+		t.Fatalf("digest = %s want %s", d, StateDigest(in))
+	}
+	want := SectionDigests(in)
+	for i := range want {
+		if secs[i] != want[i] {
+			t.Fatalf("section %d = %+v want %+v", i, secs[i], want[i])
+		}
+	}
+}
+
+func TestSectionDigestsIsolateChange(t *testing.T) {
+	a := SectionDigests(sampleInput())
+	in := sampleInput()
+	in.Agents["A1"].Inventory["PLANK"] = 3
+	b := SectionDigests(in)
+
+	var diverged []string
+	for i := range a {
+		if a[i].Hash != b[i].Hash {
+			diverged = append(diverged, a[i].Name)
+		}
+	}
+	if len(diverged) != 1 || diverged[0] != "agents" {
+		t.Fatalf("diverged=%v want [agents]", diverged)
+	}
+}
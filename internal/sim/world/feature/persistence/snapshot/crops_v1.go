@@ -0,0 +1,32 @@
+package snapshot
+
+import (
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	farmingpkg "voxelcraft.ai/internal/sim/world/feature/survival/farming"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func ExportCrops(crops map[modelpkg.Vec3i]*modelpkg.Crop) []snapv1.CropV1 {
+	if len(crops) == 0 {
+		return nil
+	}
+	out := make([]snapv1.CropV1, 0, len(crops))
+	for _, p := range modelpkg.SortedPositions(crops) {
+		c := crops[p]
+		out = append(out, snapv1.CropV1{Pos: p.ToArray(), Type: c.Type, Stage: c.Stage, Growth: c.Growth, PlantedTick: c.PlantedTick})
+	}
+	return out
+}
+
+// ImportCrops drops entries whose cell no longer holds the crop block.
+func ImportCrops(s snapv1.SnapshotV1, blockNameAt BlockNameAt) map[modelpkg.Vec3i]*modelpkg.Crop {
+	out := map[modelpkg.Vec3i]*modelpkg.Crop{}
+	for _, cv := range s.Crops {
+		pos := modelpkg.Vec3i{X: cv.Pos[0], Y: cv.Pos[1], Z: cv.Pos[2]}
+		if !farmingpkg.IsCropBlock(cv.Type) || (blockNameAt != nil && blockNameAt(pos) != cv.Type) {
+			continue
+		}
+		out[pos] = &modelpkg.Crop{Type: cv.Type, Stage: cv.Stage, Growth: cv.Growth, PlantedTick: cv.PlantedTick}
+	}
+	return out
+}
@@ -182,6 +182,13 @@ func ExportAgents(nowTick uint64, agents map[string]*modelpkg.Agent) []snapv1.Ag
 				Distance:    mt.Distance,
 				StartPos:    [3]int{mt.StartPos.X, mt.StartPos.Y, mt.StartPos.Z},
 				StartedTick: mt.StartedTick,
+				PathGoal:    [3]int{mt.PathGoal.X, mt.PathGoal.Y, mt.PathGoal.Z},
+			}
+			if len(mt.Path) > 0 {
+				moveTask.Path = make([][3]int, 0, len(mt.Path))
+				for _, p := range mt.Path {
+					moveTask.Path = append(moveTask.Path, [3]int{p.X, p.Y, p.Z})
+				}
 			}
 		}
 		var workTask *snapv1.WorkTaskV1
@@ -212,6 +219,7 @@ func ExportAgents(nowTick uint64, agents map[string]*modelpkg.Agent) []snapv1.Ag
 			OrgID:                        a.OrgID,
 			CurrentWorldID:               a.CurrentWorldID,
 			WorldSwitchCooldownUntilTick: a.WorldSwitchCooldownUntilTick,
+			Permits:                      clonePermits(a.Permits),
 			Pos:                          a.Pos.ToArray(),
 			Yaw:                          a.Yaw,
 			HP:                           a.HP,
@@ -228,14 +236,19 @@ func ExportAgents(nowTick uint64, agents map[string]*modelpkg.Agent) []snapv1.Ag
 			FunNarrative:                 a.Fun.Narrative,
 			FunRiskRescue:                a.Fun.RiskRescue,
 			Inventory:                    inv,
+			MainHand:                     a.Equipment.MainHand,
+			Armor:                        a.Equipment.Armor,
 			Memory:                       mem,
 			RateWindows:                  rateWindows,
+			View:                         exportView(a.View),
 			SeenBiomes:                   a.SeenBiomesSorted(),
 			SeenRecipes:                  a.SeenRecipesSorted(),
 			SeenEvents:                   a.SeenEventsSorted(),
 			FunDecay:                     funDecay,
 			MoveTask:                     moveTask,
 			WorkTask:                     workTask,
+			TaskQueue:                    ExportTaskQueue(a.TaskQueue),
+			Triggers:                     ExportTriggers(a.Triggers),
 		})
 	}
 	return out
@@ -273,19 +286,10 @@ func ExportClaims(claims map[string]*modelpkg.LandClaim) []snapv1.ClaimV1 {
 				AllowDamage: c.Flags.AllowDamage,
 				AllowTrade:  c.Flags.AllowTrade,
 			},
-			Members:             members,
-			MarketTax:           c.MarketTax,
-			CurfewEnabled:       c.CurfewEnabled,
-			CurfewStart:         c.CurfewStart,
-			CurfewEnd:           c.CurfewEnd,
-			FineBreakEnabled:    c.FineBreakEnabled,
-			FineBreakItem:       c.FineBreakItem,
-			FineBreakPerBlock:   c.FineBreakPerBlock,
-			AccessPassEnabled:   c.AccessPassEnabled,
-			AccessTicketItem:    c.AccessTicketItem,
-			AccessTicketCost:    c.AccessTicketCost,
-			MaintenanceDueTick:  c.MaintenanceDueTick,
-			MaintenanceStage:    c.MaintenanceStage,
+			Members:            members,
+			Policies:           ExportPolicies(c.Policies),
+			MaintenanceDueTick: c.MaintenanceDueTick,
+			MaintenanceStage:   c.MaintenanceStage,
 		})
 	}
 	return out
@@ -353,6 +357,38 @@ func ExportItems(nowTick uint64, items map[string]*modelpkg.ItemEntity) []snapv1
 	return out
 }
 
+func ExportMobs(nowTick uint64, mobs map[string]*modelpkg.Mob) []snapv1.MobV1 {
+	ids := make([]string, 0, len(mobs))
+	for id := range mobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]snapv1.MobV1, 0, len(ids))
+	for _, id := range ids {
+		m := mobs[id]
+		if m == nil || m.HP <= 0 {
+			continue
+		}
+		if m.DespawnTick != 0 && nowTick >= m.DespawnTick {
+			continue
+		}
+		out = append(out, snapv1.MobV1{
+			EntityID:    m.EntityID,
+			Kind:        m.Kind,
+			Pos:         m.Pos.ToArray(),
+			Home:        m.Home.ToArray(),
+			HP:          m.HP,
+			State:       m.State,
+			TargetID:    m.TargetID,
+			StateTick:   m.StateTick,
+			AttackTick:  m.AttackTick,
+			CreatedTick: m.CreatedTick,
+			DespawnTick: m.DespawnTick,
+		})
+	}
+	return out
+}
+
 func ExportContracts(contracts map[string]*modelpkg.Contract) []snapv1.ContractV1 {
 	ids := make([]string, 0, len(contracts))
 	for id := range contracts {
@@ -380,11 +416,42 @@ func ExportContracts(contracts map[string]*modelpkg.Contract) []snapv1.ContractV
 			CreatedTick:  c.CreatedTick,
 			DeadlineTick: c.DeadlineTick,
 			State:        string(c.State),
+
+			InterestPct:    c.InterestPct,
+			CollateralLand: c.CollateralLand,
+			Installments:   exportLoanInstallments(c.Installments),
+
+			AuctionType:  c.AuctionType,
+			PriceItem:    c.PriceItem,
+			ReservePrice: c.ReservePrice,
+			Bids:         exportAuctionBids(c.Bids),
 		})
 	}
 	return out
 }
 
+func exportAuctionBids(in []modelpkg.AuctionBid) []snapv1.AuctionBidV1 {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]snapv1.AuctionBidV1, 0, len(in))
+	for _, b := range in {
+		out = append(out, snapv1.AuctionBidV1{Bidder: b.Bidder, Amount: b.Amount, Tick: b.Tick})
+	}
+	return out
+}
+
+func exportLoanInstallments(in []modelpkg.LoanInstallment) []snapv1.LoanInstallmentV1 {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]snapv1.LoanInstallmentV1, 0, len(in))
+	for _, inst := range in {
+		out = append(out, snapv1.LoanInstallmentV1{DueTick: inst.DueTick, Items: PositiveMap(inst.Items), Paid: inst.Paid})
+	}
+	return out
+}
+
 func ExportLaws(laws map[string]*lawspkg.Law) []snapv1.LawV1 {
 	ids := make([]string, 0, len(laws))
 	for id := range laws {
@@ -581,3 +648,22 @@ func ExportSwitches(switches map[modelpkg.Vec3i]bool) []snapv1.SwitchV1 {
 	}
 	return out
 }
+
+func clonePermits(m map[string]uint64) map[string]uint64 {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]uint64, len(m))
+	for id, exp := range m {
+		out[id] = exp
+	}
+	return out
+}
+
+func exportView(v modelpkg.View) *snapv1.ViewV1 {
+	if v.IsZero() {
+		return nil
+	}
+	v = v.Clone()
+	return &snapv1.ViewV1{Hide: v.Hidden, ObsRadius: v.ObsRadius, EntityTypes: v.EntityTypes, MaxEvents: v.MaxEvents}
+}
@@ -85,6 +85,7 @@ func ImportAgents(s snapv1.SnapshotV1) (agents map[string]*modelpkg.Agent, maxAg
 			OrgID:                        a.OrgID,
 			CurrentWorldID:               a.CurrentWorldID,
 			WorldSwitchCooldownUntilTick: a.WorldSwitchCooldownUntilTick,
+			Permits:                      clonePermits(a.Permits),
 			Pos:                          modelpkg.Vec3i{X: a.Pos[0], Y: a.Pos[1], Z: a.Pos[2]},
 			Yaw:                          a.Yaw,
 			HP:                           a.HP,
@@ -124,6 +125,14 @@ func ImportAgents(s snapv1.SnapshotV1) (agents map[string]*modelpkg.Agent, maxAg
 				aa.Memory = nil
 			}
 		}
+		if a.View != nil {
+			aa.View = modelpkg.View{
+				Hidden:      a.View.Hide,
+				ObsRadius:   a.View.ObsRadius,
+				EntityTypes: a.View.EntityTypes,
+				MaxEvents:   a.View.MaxEvents,
+			}.Clone()
+		}
 		if len(a.RateWindows) > 0 {
 			rws := map[string]modelpkg.RateWindowSnapshot{}
 			for k, rw := range a.RateWindows {
@@ -138,7 +147,8 @@ func ImportAgents(s snapv1.SnapshotV1) (agents map[string]*modelpkg.Agent, maxAg
 			aa.LoadRateWindowsSnapshot(rws)
 		}
 
-			// Equipment is not persisted in snapshot v1. Keep defaults from InitDefaults().
+		// Empty slots (older snapshots) fall back to InitDefaults().
+		aa.Equipment = modelpkg.Equipment{MainHand: a.MainHand, Armor: a.Armor}
 
 		aa.SetSeenBiomes(a.SeenBiomes)
 		aa.SetSeenRecipes(a.SeenRecipes)
@@ -164,6 +174,10 @@ func ImportAgents(s snapv1.SnapshotV1) (agents map[string]*modelpkg.Agent, maxAg
 					Distance:    a.MoveTask.Distance,
 					StartPos:    taskVec3i(a.MoveTask.StartPos),
 					StartedTick: a.MoveTask.StartedTick,
+					PathGoal:    taskVec3i(a.MoveTask.PathGoal),
+				}
+				for _, p := range a.MoveTask.Path {
+					aa.MoveTask.Path = append(aa.MoveTask.Path, taskVec3i(p))
 				}
 				if n, ok := ParseUintAfterPrefix("T", aa.MoveTask.TaskID); ok && n > maxTask {
 					maxTask = n
@@ -191,6 +205,8 @@ func ImportAgents(s snapv1.SnapshotV1) (agents map[string]*modelpkg.Agent, maxAg
 				maxTask = n
 			}
 		}
+		aa.TaskQueue = ImportTaskQueue(a.TaskQueue)
+		aa.Triggers = ImportTriggers(a.Triggers)
 
 			aa.InitDefaults()
 			if aa.CurrentWorldID == "" {
@@ -236,18 +252,7 @@ func ImportClaims(s snapv1.SnapshotV1) (claims map[string]*modelpkg.LandClaim, m
 			Flags:     flags,
 			Members:   members,
 
-			MarketTax:     c.MarketTax,
-			CurfewEnabled: c.CurfewEnabled,
-			CurfewStart:   c.CurfewStart,
-			CurfewEnd:     c.CurfewEnd,
-
-			FineBreakEnabled:  c.FineBreakEnabled,
-			FineBreakItem:     c.FineBreakItem,
-			FineBreakPerBlock: c.FineBreakPerBlock,
-
-			AccessPassEnabled: c.AccessPassEnabled,
-			AccessTicketItem:  c.AccessTicketItem,
-			AccessTicketCost:  c.AccessTicketCost,
+			Policies: ImportPolicies(c),
 
 			MaintenanceDueTick: c.MaintenanceDueTick,
 			MaintenanceStage:   c.MaintenanceStage,
@@ -332,6 +337,36 @@ func ImportItems(s snapv1.SnapshotV1) (items map[string]*modelpkg.ItemEntity, it
 	return items, itemsAt, maxItem
 }
 
+func ImportMobs(s snapv1.SnapshotV1) (mobs map[string]*modelpkg.Mob, maxMob uint64) {
+	mobs = map[string]*modelpkg.Mob{}
+	for _, mm := range s.Mobs {
+		if mm.EntityID == "" || mm.Kind == "" || mm.HP <= 0 {
+			continue
+		}
+		if mm.DespawnTick != 0 && s.Header.Tick >= mm.DespawnTick {
+			continue
+		}
+		m := &modelpkg.Mob{
+			EntityID:    mm.EntityID,
+			Kind:        mm.Kind,
+			Pos:         modelpkg.Vec3i{X: mm.Pos[0], Y: mm.Pos[1], Z: mm.Pos[2]},
+			Home:        modelpkg.Vec3i{X: mm.Home[0], Y: mm.Home[1], Z: mm.Home[2]},
+			HP:          mm.HP,
+			State:       mm.State,
+			TargetID:    mm.TargetID,
+			StateTick:   mm.StateTick,
+			AttackTick:  mm.AttackTick,
+			CreatedTick: mm.CreatedTick,
+			DespawnTick: mm.DespawnTick,
+		}
+		mobs[m.EntityID] = m
+		if n, ok := ParseUintAfterPrefix("MB", m.EntityID); ok && n > maxMob {
+			maxMob = n
+		}
+	}
+	return mobs, maxMob
+}
+
 type BlockNameAt func(pos modelpkg.Vec3i) string
 
 func ImportSigns(s snapv1.SnapshotV1, blockNameAt BlockNameAt) map[modelpkg.Vec3i]*modelpkg.Sign {
@@ -393,6 +428,19 @@ func ImportContracts(s snapv1.SnapshotV1) (contracts map[string]*modelpkg.Contra
 			Rotation:     blueprint.NormalizeRotation(cc.Rotation),
 			CreatedTick:  cc.CreatedTick,
 			DeadlineTick: cc.DeadlineTick,
+
+			InterestPct:    cc.InterestPct,
+			CollateralLand: cc.CollateralLand,
+
+			AuctionType:  cc.AuctionType,
+			PriceItem:    cc.PriceItem,
+			ReservePrice: cc.ReservePrice,
+		}
+		for _, inst := range cc.Installments {
+			c.Installments = append(c.Installments, modelpkg.LoanInstallment{DueTick: inst.DueTick, Items: PositiveMap(inst.Items), Paid: inst.Paid})
+		}
+		for _, b := range cc.Bids {
+			c.Bids = append(c.Bids, modelpkg.AuctionBid{Bidder: b.Bidder, Amount: b.Amount, Tick: b.Tick})
 		}
 		contracts[c.ContractID] = c
 		if n, ok := ParseUintAfterPrefix("C", c.ContractID); ok && n > maxContract {
@@ -539,14 +587,15 @@ func ImportStats(s snapv1.SnapshotV1) *statspkg.WorldStats {
 }
 
 func ValidateSnapshotBasics(s snapv1.SnapshotV1, expectedWorldID string) error {
-	if s.Header.Version != 1 {
+	// V1 payloads migrate as-is; V2 full snapshots (and materialized deltas) share the layout.
+	if !snapv1.SupportedVersion(s.Header.Version) {
 		return fmt.Errorf("unsupported snapshot version: %d", s.Header.Version)
 	}
 	if expectedWorldID != "" && s.Header.WorldID != expectedWorldID {
 		return fmt.Errorf("snapshot world mismatch: got %q want %q", s.Header.WorldID, expectedWorldID)
 	}
-	if s.Height != 1 {
-		return fmt.Errorf("snapshot height mismatch: got %d want 1", s.Height)
+	if s.Height < 1 {
+		return fmt.Errorf("snapshot height invalid: %d", s.Height)
 	}
 	if s.TickRate <= 0 || s.DayTicks <= 0 {
 		return fmt.Errorf("snapshot tick/day ticks invalid")
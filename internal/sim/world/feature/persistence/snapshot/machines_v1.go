@@ -0,0 +1,47 @@
+package snapshot
+
+import (
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	machinepkg "voxelcraft.ai/internal/sim/world/feature/automation/machine"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func ExportMachines(machines map[modelpkg.Vec3i]modelpkg.MachineMeta) []snapv1.MachineV1 {
+	if len(machines) == 0 {
+		return nil
+	}
+	out := make([]snapv1.MachineV1, 0, len(machines))
+	for _, p := range modelpkg.SortedPositions(machines) {
+		m := machines[p]
+		out = append(out, snapv1.MachineV1{
+			Pos:      p.ToArray(),
+			DX:       int(m.DX),
+			DZ:       int(m.DZ),
+			Owner:    m.Owner,
+			RecipeID: m.RecipeID,
+			Progress: m.Progress,
+			Fuel:     m.Fuel,
+		})
+	}
+	return out
+}
+
+// ImportMachines keeps state only for cells that still hold a machine block.
+func ImportMachines(s snapv1.SnapshotV1, blockNameAt BlockNameAt) map[modelpkg.Vec3i]modelpkg.MachineMeta {
+	out := map[modelpkg.Vec3i]modelpkg.MachineMeta{}
+	for _, mv := range s.Machines {
+		pos := modelpkg.Vec3i{X: mv.Pos[0], Y: mv.Pos[1], Z: mv.Pos[2]}
+		if blockNameAt != nil && !machinepkg.IsMachine(blockNameAt(pos)) {
+			continue
+		}
+		out[pos] = modelpkg.MachineMeta{
+			DX:       int8(mv.DX),
+			DZ:       int8(mv.DZ),
+			Owner:    mv.Owner,
+			RecipeID: mv.RecipeID,
+			Progress: mv.Progress,
+			Fuel:     mv.Fuel,
+		}
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+package snapshot
+
+import (
+	"sort"
+
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	marketpkg "voxelcraft.ai/internal/sim/world/feature/economy/market"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func ExportOrders(orders map[string]*modelpkg.MarketOrder) []snapv1.OrderV1 {
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(orders))
+	for id := range orders {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]snapv1.OrderV1, 0, len(ids))
+	for _, id := range ids {
+		o := orders[id]
+		if o == nil || o.Count <= 0 {
+			continue
+		}
+		out = append(out, snapv1.OrderV1{
+			OrderID:     o.OrderID,
+			TerminalPos: o.TerminalPos.ToArray(),
+			Owner:       o.Owner,
+			Side:        string(o.Side),
+			Item:        o.Item,
+			PriceItem:   o.PriceItem,
+			Price:       o.Price,
+			Count:       o.Count,
+			CreatedTick: o.CreatedTick,
+		})
+	}
+	return out
+}
+
+func ImportOrders(s snapv1.SnapshotV1) (orders map[string]*modelpkg.MarketOrder, maxOrder uint64) {
+	orders = map[string]*modelpkg.MarketOrder{}
+	for _, ov := range s.Orders {
+		side, ok := marketpkg.NormalizeSide(ov.Side)
+		if !ok || ov.OrderID == "" || ov.Count <= 0 || ov.Price <= 0 {
+			continue
+		}
+		orders[ov.OrderID] = &modelpkg.MarketOrder{
+			OrderID:     ov.OrderID,
+			TerminalPos: modelpkg.Vec3i{X: ov.TerminalPos[0], Y: ov.TerminalPos[1], Z: ov.TerminalPos[2]},
+			Owner:       ov.Owner,
+			Side:        side,
+			Item:        ov.Item,
+			PriceItem:   ov.PriceItem,
+			Price:       ov.Price,
+			Count:       ov.Count,
+			CreatedTick: ov.CreatedTick,
+		}
+		if n, ok := ParseUintAfterPrefix("ORD", ov.OrderID); ok && n > maxOrder {
+			maxOrder = n
+		}
+	}
+	return orders, maxOrder
+}
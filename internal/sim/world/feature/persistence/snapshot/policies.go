@@ -0,0 +1,94 @@
+package snapshot
+
+import (
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
+)
+
+func ExportPolicies(policies []rules.Policy) []snapv1.PolicyV1 {
+	if len(policies) == 0 {
+		return nil
+	}
+	out := make([]snapv1.PolicyV1, 0, len(policies))
+	for _, p := range policies {
+		rs := make([]snapv1.PolicyRuleV1, 0, len(p.Rules))
+		for _, r := range p.Rules {
+			rs = append(rs, snapv1.PolicyRuleV1{
+				Actions:    append([]string(nil), r.Actions...),
+				Actor:      r.Actor,
+				Region:     r.Region,
+				Items:      append([]string(nil), r.Items...),
+				Outcome:    r.Outcome,
+				TimeWindow: r.TimeWindow,
+				TimeFrom:   r.TimeFrom,
+				TimeTo:     r.TimeTo,
+				Effect:     r.Effect,
+				Item:       r.Item,
+				Amount:     r.Amount,
+				Rate:       r.Rate,
+			})
+		}
+		out = append(out, snapv1.PolicyV1{LawID: p.LawID, TemplateID: p.TemplateID, Rules: rs})
+	}
+	return out
+}
+
+// ImportPolicies restores a claim's law policies. Snapshots written before the law DSL
+// only carry the four built-in law parameters; those are rebuilt as equivalent policies.
+func ImportPolicies(c snapv1.ClaimV1) []rules.Policy {
+	if len(c.Policies) > 0 {
+		out := make([]rules.Policy, 0, len(c.Policies))
+		for _, p := range c.Policies {
+			rs := make([]rules.Rule, 0, len(p.Rules))
+			for _, r := range p.Rules {
+				rs = append(rs, rules.Rule{
+					Actions:    append([]string(nil), r.Actions...),
+					Actor:      r.Actor,
+					Region:     r.Region,
+					Items:      append([]string(nil), r.Items...),
+					Outcome:    r.Outcome,
+					TimeWindow: r.TimeWindow,
+					TimeFrom:   r.TimeFrom,
+					TimeTo:     r.TimeTo,
+					Effect:     r.Effect,
+					Item:       r.Item,
+					Amount:     r.Amount,
+					Rate:       r.Rate,
+				})
+			}
+			out = append(out, rules.Policy{LawID: p.LawID, TemplateID: p.TemplateID, Rules: rs})
+		}
+		return out
+	}
+	return legacyPolicies(c)
+}
+
+func legacyPolicies(c snapv1.ClaimV1) []rules.Policy {
+	var out []rules.Policy
+	add := func(templateID string, r rules.Rule) {
+		out = append(out, rules.Policy{TemplateID: templateID, Rules: []rules.Rule{r}})
+	}
+	if c.FineBreakEnabled && c.FineBreakItem != "" && c.FineBreakPerBlock > 0 {
+		add("FINE_BREAK_PER_BLOCK", rules.Rule{
+			Actions: []string{rules.ActionBreak}, Actor: rules.RoleVisitor, Outcome: rules.OutcomeDenied,
+			Effect: rules.EffectFine, Item: c.FineBreakItem, Amount: c.FineBreakPerBlock,
+		})
+	}
+	if c.MarketTax > 0 {
+		add("MARKET_TAX", rules.Rule{Actions: []string{rules.ActionTrade}, Effect: rules.EffectTax, Rate: c.MarketTax})
+	}
+	if c.CurfewEnabled && c.CurfewStart != c.CurfewEnd {
+		add("CURFEW_NO_BUILD", rules.Rule{
+			Actions:    []string{rules.ActionBuild, rules.ActionBreak, rules.ActionDamage},
+			TimeWindow: true, TimeFrom: c.CurfewStart, TimeTo: c.CurfewEnd,
+			Effect: rules.EffectDeny,
+		})
+	}
+	if c.AccessPassEnabled && c.AccessTicketItem != "" && c.AccessTicketCost > 0 {
+		add("ACCESS_PASS_CORE", rules.Rule{
+			Actions: []string{rules.ActionEnter}, Actor: rules.RoleVisitor, Region: rules.RegionCore,
+			Effect: rules.EffectTicket, Item: c.AccessTicketItem, Amount: c.AccessTicketCost,
+		})
+	}
+	return out
+}
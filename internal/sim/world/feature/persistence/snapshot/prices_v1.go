@@ -0,0 +1,51 @@
+package snapshot
+
+import (
+	"sort"
+
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func ExportPrices(series map[string]*modelpkg.PriceSeries) []snapv1.PriceSeriesV1 {
+	if len(series) == 0 {
+		return nil
+	}
+	items := make([]string, 0, len(series))
+	for item, s := range series {
+		if s != nil && len(s.Samples) > 0 {
+			items = append(items, item)
+		}
+	}
+	sort.Strings(items)
+	out := make([]snapv1.PriceSeriesV1, 0, len(items))
+	for _, item := range items {
+		s := series[item]
+		samples := make([]snapv1.PriceSampleV1, 0, len(s.Samples))
+		for _, smp := range s.Samples {
+			samples = append(samples, snapv1.PriceSampleV1{Tick: smp.Tick, Price: smp.Price, Volume: smp.Volume})
+		}
+		out = append(out, snapv1.PriceSeriesV1{Item: item, Samples: samples})
+	}
+	return out
+}
+
+func ImportPrices(s snapv1.SnapshotV1) map[string]*modelpkg.PriceSeries {
+	series := map[string]*modelpkg.PriceSeries{}
+	for _, pv := range s.Prices {
+		if pv.Item == "" {
+			continue
+		}
+		ps := &modelpkg.PriceSeries{Item: pv.Item}
+		for _, smp := range pv.Samples {
+			if smp.Price <= 0 || smp.Volume <= 0 {
+				continue
+			}
+			ps.Samples = append(ps.Samples, modelpkg.PriceSample{Tick: smp.Tick, Price: smp.Price, Volume: smp.Volume})
+		}
+		if len(ps.Samples) > 0 {
+			series[pv.Item] = ps
+		}
+	}
+	return series
+}
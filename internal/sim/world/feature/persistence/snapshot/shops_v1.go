@@ -0,0 +1,51 @@
+package snapshot
+
+import (
+	"sort"
+
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	shoppkg "voxelcraft.ai/internal/sim/world/feature/economy/shop"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func ExportShops(shops map[modelpkg.Vec3i]*modelpkg.Shop) []snapv1.ShopV1 {
+	if len(shops) == 0 {
+		return nil
+	}
+	out := make([]snapv1.ShopV1, 0, len(shops))
+	for _, p := range modelpkg.SortedPositions(shops) {
+		s := shops[p]
+		sv := snapv1.ShopV1{Pos: p.ToArray(), Owner: s.Owner}
+		items := make([]string, 0, len(s.Prices))
+		for item := range s.Prices {
+			items = append(items, item)
+		}
+		sort.Strings(items)
+		for _, item := range items {
+			pr := s.Prices[item]
+			sv.Prices = append(sv.Prices, snapv1.ShopPriceV1{Item: item, PriceItem: pr.PriceItem, Price: pr.Price})
+		}
+		out = append(out, sv)
+	}
+	return out
+}
+
+// ImportShops keeps state only for cells that still hold a SHOP block.
+func ImportShops(s snapv1.SnapshotV1, blockNameAt BlockNameAt) map[modelpkg.Vec3i]*modelpkg.Shop {
+	out := map[modelpkg.Vec3i]*modelpkg.Shop{}
+	for _, sv := range s.Shops {
+		pos := modelpkg.Vec3i{X: sv.Pos[0], Y: sv.Pos[1], Z: sv.Pos[2]}
+		if blockNameAt != nil && blockNameAt(pos) != shoppkg.Block {
+			continue
+		}
+		shop := &modelpkg.Shop{Owner: sv.Owner}
+		for _, pr := range sv.Prices {
+			if shop.Prices == nil {
+				shop.Prices = map[string]modelpkg.ShopPrice{}
+			}
+			shop.Prices[pr.Item] = modelpkg.ShopPrice{PriceItem: pr.PriceItem, Price: pr.Price}
+		}
+		out[pos] = shop
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package snapshot
+
+import (
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	signalpkg "voxelcraft.ai/internal/sim/world/feature/automation/signal"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func ExportSignals(nodes map[modelpkg.Vec3i]*modelpkg.SignalNode, active map[modelpkg.Vec3i]bool) []snapv1.SignalV1 {
+	if len(nodes) == 0 && len(active) == 0 {
+		return nil
+	}
+	cells := map[modelpkg.Vec3i]bool{}
+	for p := range nodes {
+		cells[p] = true
+	}
+	for p, on := range active {
+		if on {
+			cells[p] = true
+		}
+	}
+	out := make([]snapv1.SignalV1, 0, len(cells))
+	for _, p := range modelpkg.SortedPositions(cells) {
+		sv := snapv1.SignalV1{Pos: p.ToArray(), Active: active[p]}
+		if n := nodes[p]; n != nil {
+			sv.Node = true
+			sv.Level, sv.DX, sv.DZ = n.Level, int(n.DX), int(n.DZ)
+			sv.Charge, sv.Mode, sv.Arg = n.Charge, n.Mode, n.Arg
+		}
+		out = append(out, sv)
+	}
+	return out
+}
+
+// ImportSignals keeps nodes only for cells that still hold a signal block. Snapshots
+// from before the signal network have no section: the neighbours of ON switches are
+// queued so their wires power up again.
+func ImportSignals(s snapv1.SnapshotV1, blockNameAt BlockNameAt) (map[modelpkg.Vec3i]*modelpkg.SignalNode, map[modelpkg.Vec3i]bool) {
+	nodes := map[modelpkg.Vec3i]*modelpkg.SignalNode{}
+	active := map[modelpkg.Vec3i]bool{}
+	for _, sv := range s.Signals {
+		pos := modelpkg.Vec3i{X: sv.Pos[0], Y: sv.Pos[1], Z: sv.Pos[2]}
+		if sv.Node && (blockNameAt == nil || signalpkg.HasNode(blockNameAt(pos))) {
+			nodes[pos] = &modelpkg.SignalNode{
+				Level:  sv.Level,
+				DX:     int8(sv.DX),
+				DZ:     int8(sv.DZ),
+				Charge: sv.Charge,
+				Mode:   sv.Mode,
+				Arg:    sv.Arg,
+			}
+		}
+		if sv.Active {
+			active[pos] = true
+		}
+	}
+	if len(s.Signals) == 0 {
+		for _, sw := range s.Switches {
+			if !sw.On {
+				continue
+			}
+			for _, n := range signalpkg.Neighbours(modelpkg.Vec3i{X: sw.Pos[0], Y: sw.Pos[1], Z: sw.Pos[2]}) {
+				active[n] = true
+			}
+		}
+	}
+	return nodes, active
+}
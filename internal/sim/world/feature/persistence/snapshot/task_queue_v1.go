@@ -0,0 +1,83 @@
+package snapshot
+
+import (
+	"strings"
+
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	"voxelcraft.ai/internal/protocol"
+	taskqueuepkg "voxelcraft.ai/internal/sim/world/feature/work/queue"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func ExportTaskQueue(q []modelpkg.QueuedTask) []snapv1.QueuedTaskV1 {
+	if len(q) == 0 {
+		return nil
+	}
+	out := make([]snapv1.QueuedTaskV1, 0, len(q))
+	for _, e := range q {
+		r := e.Req
+		v := snapv1.QueuedTaskV1{
+			Ref:       r.ID,
+			Kind:      r.Type,
+			OnFail:    r.OnFail,
+			Retries:   r.Retries,
+			Attempts:  e.Attempts,
+			TaskID:    e.TaskID,
+			NotBefore: e.NotBefore,
+			EndType:   e.EndType,
+			EndCode:   e.EndCode,
+		}
+		if taskqueuepkg.SlotFor(r.Type) == taskqueuepkg.SlotMove {
+			v.Move = &snapv1.MovementTaskV1{
+				Kind:      r.Type,
+				Target:    r.Target,
+				Tolerance: r.Tolerance,
+				TargetID:  r.TargetID,
+				Distance:  r.Distance,
+			}
+		} else {
+			v.Work = &snapv1.WorkTaskV1{
+				Kind:         r.Type,
+				BlockPos:     r.BlockPos,
+				RecipeID:     r.RecipeID,
+				ItemID:       r.ItemID,
+				Count:        r.Count,
+				BlueprintID:  r.BlueprintID,
+				Anchor:       r.Anchor,
+				Rotation:     r.Rotation,
+				TargetID:     r.TargetID,
+				SrcContainer: r.Src,
+				DstContainer: r.Dst,
+			}
+			v.Radius = r.Radius
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func ImportTaskQueue(in []snapv1.QueuedTaskV1) []modelpkg.QueuedTask {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]modelpkg.QueuedTask, 0, len(in))
+	for _, v := range in {
+		r := protocol.TaskReq{
+			ID:      v.Ref,
+			Type:    strings.ToUpper(v.Kind),
+			OnFail:  v.OnFail,
+			Retries: v.Retries,
+			Radius:  v.Radius,
+		}
+		if m := v.Move; m != nil {
+			r.Target, r.Tolerance, r.TargetID, r.Distance = m.Target, m.Tolerance, m.TargetID, m.Distance
+		}
+		if w := v.Work; w != nil {
+			r.BlockPos, r.RecipeID, r.ItemID, r.Count = w.BlockPos, w.RecipeID, w.ItemID, w.Count
+			r.BlueprintID, r.Anchor, r.Rotation = w.BlueprintID, w.Anchor, w.Rotation
+			r.TargetID, r.Src, r.Dst = w.TargetID, w.SrcContainer, w.DstContainer
+		}
+		out = append(out, modelpkg.QueuedTask{Req: r, Attempts: v.Attempts, TaskID: v.TaskID, NotBefore: v.NotBefore, EndType: v.EndType, EndCode: v.EndCode})
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+package snapshot
+
+import (
+	"encoding/json"
+
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func ExportTriggers(ts []modelpkg.Trigger) []snapv1.TriggerV1 {
+	if len(ts) == 0 {
+		return nil
+	}
+	out := make([]snapv1.TriggerV1, 0, len(ts))
+	for _, t := range ts {
+		v := snapv1.TriggerV1{
+			ID:            t.ID,
+			On:            t.On,
+			CooldownTicks: t.CooldownTicks,
+			MaxFires:      t.MaxFires,
+			Fires:         t.Fires,
+			LastFireTick:  t.LastFireTick,
+		}
+		for _, c := range t.Where {
+			v.Where = append(v.Where, snapv1.TriggerCondV1{Field: c.Field, Op: c.Op, Str: c.Str, Num: c.Num, IsStr: c.IsStr})
+		}
+		if t.Instant != nil {
+			v.Instant, _ = json.Marshal(t.Instant)
+		}
+		if t.Task != nil {
+			v.Task, _ = json.Marshal(t.Task)
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// ImportTriggers drops rules whose action no longer decodes.
+func ImportTriggers(in []snapv1.TriggerV1) []modelpkg.Trigger {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]modelpkg.Trigger, 0, len(in))
+	for _, v := range in {
+		t := modelpkg.Trigger{
+			ID:            v.ID,
+			On:            v.On,
+			CooldownTicks: v.CooldownTicks,
+			MaxFires:      v.MaxFires,
+			Fires:         v.Fires,
+			LastFireTick:  v.LastFireTick,
+		}
+		for _, c := range v.Where {
+			t.Where = append(t.Where, modelpkg.TriggerCond{Field: c.Field, Op: c.Op, Str: c.Str, Num: c.Num, IsStr: c.IsStr})
+		}
+		switch {
+		case len(v.Instant) > 0:
+			var inst protocol.InstantReq
+			if json.Unmarshal(v.Instant, &inst) != nil {
+				continue
+			}
+			t.Instant = &inst
+		case len(v.Task) > 0:
+			var tr protocol.TaskReq
+			if json.Unmarshal(v.Task, &tr) != nil {
+				continue
+			}
+			t.Task = &tr
+		default:
+			continue
+		}
+		out = append(out, t)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
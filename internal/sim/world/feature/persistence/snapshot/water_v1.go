@@ -0,0 +1,43 @@
+package snapshot
+
+import (
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+	waterpkg "voxelcraft.ai/internal/sim/world/feature/survival/water"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func ExportWater(levels map[modelpkg.Vec3i]int, active map[modelpkg.Vec3i]bool) []snapv1.WaterV1 {
+	if len(levels) == 0 && len(active) == 0 {
+		return nil
+	}
+	cells := map[modelpkg.Vec3i]bool{}
+	for p := range levels {
+		cells[p] = true
+	}
+	for p, on := range active {
+		if on {
+			cells[p] = true
+		}
+	}
+	out := make([]snapv1.WaterV1, 0, len(cells))
+	for _, p := range modelpkg.SortedPositions(cells) {
+		out = append(out, snapv1.WaterV1{Pos: p.ToArray(), Level: levels[p], Active: active[p]})
+	}
+	return out
+}
+
+// ImportWater keeps levels only for cells that still hold FLOWING_WATER.
+func ImportWater(s snapv1.SnapshotV1, blockNameAt BlockNameAt) (map[modelpkg.Vec3i]int, map[modelpkg.Vec3i]bool) {
+	levels := map[modelpkg.Vec3i]int{}
+	active := map[modelpkg.Vec3i]bool{}
+	for _, wv := range s.Water {
+		pos := modelpkg.Vec3i{X: wv.Pos[0], Y: wv.Pos[1], Z: wv.Pos[2]}
+		if wv.Level > 0 && (blockNameAt == nil || blockNameAt(pos) == waterpkg.FlowBlock) {
+			levels[pos] = wv.Level
+		}
+		if wv.Active {
+			active[pos] = true
+		}
+	}
+	return levels, active
+}
@@ -27,6 +27,7 @@ func OrderedCatalogs(
 	blueprints protocol.CatalogMsg,
 	laws protocol.CatalogMsg,
 	events protocol.CatalogMsg,
+	priceIndex protocol.CatalogMsg,
 ) []protocol.CatalogMsg {
 	return []protocol.CatalogMsg{
 		blockPalette,
@@ -36,6 +37,7 @@ func OrderedCatalogs(
 		blueprints,
 		laws,
 		events,
+		priceIndex,
 	}
 }
 
@@ -113,6 +115,9 @@ type BuildHandshakeCatalogsInput struct {
 	EventsDigest     string
 	EventsByID       map[string]catalogs.EventTemplate
 
+	// PriceIndex is the world price index at join time, one entry per item.
+	PriceIndex []protocol.PriceObs
+
 	Tuning TuningInput
 }
 
@@ -237,6 +242,26 @@ func EventsCatalogMsg(digest string, byID map[string]catalogs.EventTemplate) pro
 	}
 }
 
+// PriceIndexCatalogMsg is a point-in-time copy of the price index; OBS.prices carries
+// the live values of traded items. The digest covers the data, so it changes as the
+// index moves.
+func PriceIndexCatalogMsg(entries []protocol.PriceObs) protocol.CatalogMsg {
+	if entries == nil {
+		entries = []protocol.PriceObs{}
+	}
+	b, _ := json.Marshal(entries)
+	sum := sha256.Sum256(b)
+	return protocol.CatalogMsg{
+		Type:            protocol.TypeCatalog,
+		ProtocolVersion: protocol.Version,
+		Name:            "price_index",
+		Digest:          hex.EncodeToString(sum[:]),
+		Part:            1,
+		TotalParts:      1,
+		Data:            entries,
+	}
+}
+
 func BuildHandshakeCatalogs(in BuildHandshakeCatalogsInput) ([]protocol.CatalogMsg, string) {
 	tuningCat := TuningCatalogMsg(in.Tuning)
 	recipesCat := RecipesCatalogMsg(in.RecipesDigest, in.RecipesByID)
@@ -268,5 +293,6 @@ func BuildHandshakeCatalogs(in BuildHandshakeCatalogsInput) ([]protocol.CatalogM
 		blueprintsCat,
 		lawsCat,
 		eventsCat,
+		PriceIndexCatalogMsg(in.PriceIndex),
 	), tuningCat.Digest
 }
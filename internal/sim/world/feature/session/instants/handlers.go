@@ -8,6 +8,7 @@ import (
 	chatpkg "voxelcraft.ai/internal/sim/world/feature/session/chat"
 	eatpkg "voxelcraft.ai/internal/sim/world/feature/session/eat"
 	memorypkg "voxelcraft.ai/internal/sim/world/feature/session/memory"
+	viewpkg "voxelcraft.ai/internal/sim/world/feature/session/view"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 )
 
@@ -173,3 +174,13 @@ func HandleLoadMemory(ar ActionResultFn, a *modelpkg.Agent, inst protocol.Instan
 	a.PendingMemory = kvs
 	a.AddEvent(ar(nowTick, inst.ID, true, "", fmt.Sprintf("loaded %d keys", len(kvs))))
 }
+
+func HandleSetView(ar ActionResultFn, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64, limits viewpkg.Limits) {
+	v, code, msg := viewpkg.Normalize(inst.View, limits)
+	if code != "" {
+		a.AddEvent(ar(nowTick, inst.ID, false, code, msg))
+		return
+	}
+	a.View = v
+	a.AddEvent(ar(nowTick, inst.ID, true, "", "ok"))
+}
@@ -19,7 +19,7 @@ func BuildJoinedAgent(in BuildJoinedAgentInput) *modelpkg.Agent {
 	a := &modelpkg.Agent{
 		ID:             in.AgentID,
 		Name:           strings.TrimSpace(in.Name),
-		Pos:            modelpkg.Vec3i{X: in.Spawn.X, Y: 0, Z: in.Spawn.Z},
+		Pos:            in.Spawn,
 		Yaw:            0,
 		CurrentWorldID: in.WorldID,
 	}
@@ -43,6 +43,22 @@ type AttachResult struct {
 	OK       bool
 }
 
+// AttachByID re-attaches a known agent by id (caller has already authenticated the identity).
+func AttachByID(agentID string, agents map[string]*modelpkg.Agent, worldID string, nowUnixNano int64) AttachResult {
+	a := agents[strings.TrimSpace(agentID)]
+	if a == nil {
+		return AttachResult{}
+	}
+	a.CurrentWorldID = worldID
+	newToken := NewResumeToken(worldID, nowUnixNano)
+	a.ResumeToken = newToken
+	return AttachResult{
+		Agent:    a,
+		NewToken: newToken,
+		OK:       true,
+	}
+}
+
 func AttachByToken(token string, agents map[string]*modelpkg.Agent, worldID string, nowUnixNano int64) AttachResult {
 	if strings.TrimSpace(token) == "" || len(agents) == 0 {
 		return AttachResult{}
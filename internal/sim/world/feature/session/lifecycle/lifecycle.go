@@ -1,11 +1,28 @@
 package lifecycle
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 func NewAgentID(idNum uint64) string {
 	return fmt.Sprintf("A%d", idNum)
 }
 
+// ParseAgentID is the inverse of NewAgentID.
+func ParseAgentID(id string) (uint64, bool) {
+	rest, ok := strings.CutPrefix(id, "A")
+	if !ok || rest == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(rest, 10, 64)
+	if err != nil || n == 0 || NewAgentID(n) != id {
+		return 0, false
+	}
+	return n, true
+}
+
 func SpawnSeed(idNum uint64) (x int, z int) {
 	seed := int(idNum) * 2
 	return seed, -seed
@@ -8,6 +8,17 @@ func TestNewAgentID(t *testing.T) {
 	}
 }
 
+func TestParseAgentID(t *testing.T) {
+	if n, ok := ParseAgentID("A17"); !ok || n != 17 {
+		t.Fatalf("ParseAgentID(A17) = %d,%v", n, ok)
+	}
+	for _, bad := range []string{"", "A", "A0", "A017", "B3", "A-1"} {
+		if _, ok := ParseAgentID(bad); ok {
+			t.Fatalf("ParseAgentID(%q) should fail", bad)
+		}
+	}
+}
+
 func TestSpawnSeed(t *testing.T) {
 	x, z := SpawnSeed(3)
 	if x != 6 || z != -6 {
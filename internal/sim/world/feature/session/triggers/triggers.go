@@ -0,0 +1,373 @@
+// Package triggers evaluates per-agent reactive rules (SET_TRIGGER): event or stat
+// conditions that apply an instant/task on the agent's behalf within the tick.
+package triggers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	// OnStats matches the agent's own stats once per tick instead of an event.
+	OnStats = "STATS"
+
+	MaxPerAgent = 8
+	MaxConds    = 8
+	maxIDLen    = 64
+
+	// Per-agent fire rate limit (on top of cooldowns and the world budget).
+	FireWindowTicks = 50
+	FireWindowMax   = 10
+)
+
+var ops = map[string]bool{"EQ": true, "NE": true, "LT": true, "LE": true, "GT": true, "GE": true}
+
+type ActionResultFn func(tick uint64, ref string, ok bool, code string, message string) protocol.Event
+
+type Env struct {
+	ApplyInstant func(a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64)
+	ApplyTask    func(a *modelpkg.Agent, tr protocol.TaskReq, nowTick uint64)
+	// InstantAllowed/TaskAllowed gate action types a trigger may use.
+	InstantAllowed func(typ string) bool
+	TaskAllowed    func(typ string) bool
+	// Suspended holds an agent's triggers while it may not act (e.g. no permit for a
+	// gated world); events seen meanwhile never fire them.
+	Suspended func(a *modelpkg.Agent) bool
+}
+
+// Normalize validates a SET_TRIGGER spec.
+func Normalize(env Env, spec *protocol.TriggerSpec) (modelpkg.Trigger, string, string) {
+	if spec == nil {
+		return modelpkg.Trigger{}, "E_BAD_REQUEST", "missing trigger"
+	}
+	id := strings.TrimSpace(spec.ID)
+	if id == "" || len(id) > maxIDLen {
+		return modelpkg.Trigger{}, "E_BAD_REQUEST", "bad trigger id"
+	}
+	on := strings.ToUpper(strings.TrimSpace(spec.On))
+	if on == "" {
+		return modelpkg.Trigger{}, "E_BAD_REQUEST", "missing on"
+	}
+	if len(spec.Where) > MaxConds {
+		return modelpkg.Trigger{}, "E_BAD_REQUEST", fmt.Sprintf("too many conditions (max %d)", MaxConds)
+	}
+	t := modelpkg.Trigger{ID: id, On: on, CooldownTicks: spec.CooldownTicks, MaxFires: spec.MaxFires}
+	for _, c := range spec.Where {
+		nc, ok := normalizeCond(c)
+		if !ok {
+			return modelpkg.Trigger{}, "E_BAD_REQUEST", fmt.Sprintf("bad condition on %q", c.Field)
+		}
+		t.Where = append(t.Where, nc)
+	}
+	switch {
+	case (spec.Instant == nil) == (spec.Task == nil):
+		return modelpkg.Trigger{}, "E_BAD_REQUEST", "need exactly one of instant or task"
+	case spec.Instant != nil:
+		if env.InstantAllowed == nil || !env.InstantAllowed(spec.Instant.Type) {
+			return modelpkg.Trigger{}, "E_BAD_REQUEST", "instant type not allowed in trigger"
+		}
+		inst := *spec.Instant
+		t.Instant = &inst
+	default:
+		if env.TaskAllowed == nil || !env.TaskAllowed(spec.Task.Type) {
+			return modelpkg.Trigger{}, "E_BAD_REQUEST", "task type not allowed in trigger"
+		}
+		tr := *spec.Task
+		t.Task = &tr
+	}
+	if t.CooldownTicks < 0 || t.MaxFires < 0 {
+		return modelpkg.Trigger{}, "E_BAD_REQUEST", "cooldown_ticks/max_fires must be >= 0"
+	}
+	return t, "", ""
+}
+
+func normalizeCond(c protocol.TriggerCond) (modelpkg.TriggerCond, bool) {
+	op := strings.ToUpper(c.Op)
+	if c.Field == "" || !ops[op] {
+		return modelpkg.TriggerCond{}, false
+	}
+	out := modelpkg.TriggerCond{Field: c.Field, Op: op}
+	switch v := c.Value.(type) {
+	case string:
+		out.Str, out.IsStr = v, true
+	case bool:
+		out.Str, out.IsStr = strconv.FormatBool(v), true
+	default:
+		n, ok := number(v)
+		if !ok {
+			return modelpkg.TriggerCond{}, false
+		}
+		out.Num = n
+	}
+	if out.IsStr && op != "EQ" && op != "NE" {
+		return modelpkg.TriggerCond{}, false
+	}
+	return out, true
+}
+
+func HandleSet(env Env, ar ActionResultFn, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64) {
+	t, code, msg := Normalize(env, inst.Trigger)
+	if code != "" {
+		a.AddEvent(ar(nowTick, inst.ID, false, code, msg))
+		return
+	}
+	replaced := false
+	for i := range a.Triggers {
+		if a.Triggers[i].ID == t.ID {
+			a.Triggers[i] = t
+			replaced = true
+		}
+	}
+	if !replaced {
+		if len(a.Triggers) >= MaxPerAgent {
+			a.AddEvent(ar(nowTick, inst.ID, false, "E_CONFLICT", fmt.Sprintf("too many triggers (max %d)", MaxPerAgent)))
+			return
+		}
+		a.Triggers = append(a.Triggers, t)
+		sort.Slice(a.Triggers, func(i, j int) bool { return a.Triggers[i].ID < a.Triggers[j].ID })
+	}
+	// Only events after registration can fire the rule.
+	a.TriggerCursor = a.EventCursor
+	a.AddEvent(ar(nowTick, inst.ID, true, "", "ok"))
+}
+
+func HandleRemove(ar ActionResultFn, a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64) {
+	for i := range a.Triggers {
+		if a.Triggers[i].ID == inst.TriggerID {
+			a.Triggers = append(a.Triggers[:i], a.Triggers[i+1:]...)
+			if len(a.Triggers) == 0 {
+				a.Triggers = nil
+			}
+			a.AddEvent(ar(nowTick, inst.ID, true, "", "ok"))
+			return
+		}
+	}
+	a.AddEvent(ar(nowTick, inst.ID, false, "E_INVALID_TARGET", "trigger not found"))
+}
+
+// Evaluate matches the agent's triggers against events logged since the last call
+// (each trigger fires at most once per tick, on its first matching event) and spends
+// one unit of *budget per fire. Events caused by the fired actions are not matched,
+// so triggers cannot chain.
+func Evaluate(env Env, a *modelpkg.Agent, nowTick uint64, budget *int) {
+	if len(a.Triggers) == 0 || (env.Suspended != nil && env.Suspended(a)) {
+		a.TriggerCursor = a.EventCursor
+		return
+	}
+	events := a.EventsSince(a.TriggerCursor)
+	for i := range a.Triggers {
+		t := &a.Triggers[i]
+		if t.Fires > 0 && nowTick < t.LastFireTick+uint64(t.CooldownTicks) {
+			continue
+		}
+		match, ok := firstMatch(a, t, events)
+		if !ok {
+			continue
+		}
+		if *budget <= 0 {
+			break
+		}
+		if ok, _ := a.RateLimitAllow("TRIGGER", nowTick, FireWindowTicks, FireWindowMax); !ok {
+			break
+		}
+		*budget--
+		t.Fires++
+		t.LastFireTick = nowTick
+		fire(env, a, *t, match, nowTick)
+	}
+	kept := a.Triggers[:0]
+	for _, t := range a.Triggers {
+		if t.MaxFires == 0 || t.Fires < t.MaxFires {
+			kept = append(kept, t)
+		}
+	}
+	a.Triggers = kept
+	if len(a.Triggers) == 0 {
+		a.Triggers = nil
+	}
+	a.TriggerCursor = a.EventCursor
+}
+
+// EvaluateAll runs Evaluate for agents (in a stable order) sharing one budget. The
+// starting agent rotates with the tick, so when the budget runs out it is not always
+// the same agents at the end of the order that miss out.
+func EvaluateAll(env Env, agents []*modelpkg.Agent, nowTick uint64, budget int) {
+	n := len(agents)
+	for i := 0; i < n; i++ {
+		Evaluate(env, agents[(int(nowTick%uint64(n))+i)%n], nowTick, &budget)
+	}
+}
+
+func firstMatch(a *modelpkg.Agent, t *modelpkg.Trigger, events []protocol.Event) (protocol.Event, bool) {
+	if t.On == OnStats {
+		return nil, condsHold(a, t.Where, nil)
+	}
+	for _, e := range events {
+		if e["type"] == t.On && condsHold(a, t.Where, e) {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+func fire(env Env, a *modelpkg.Agent, t modelpkg.Trigger, e protocol.Event, nowTick uint64) {
+	ref := "trigger:" + t.ID
+	if t.Instant != nil {
+		inst := bind(*t.Instant, e)
+		if inst.ID == "" {
+			inst.ID = ref
+		}
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TRIGGER_FIRED", "trigger_id": t.ID, "ref": inst.ID})
+		if env.ApplyInstant != nil {
+			env.ApplyInstant(a, inst, nowTick)
+		}
+		return
+	}
+	tr := bind(*t.Task, e)
+	if tr.ID == "" {
+		tr.ID = ref
+	}
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TRIGGER_FIRED", "trigger_id": t.ID, "ref": tr.ID})
+	if env.ApplyTask != nil {
+		env.ApplyTask(a, tr, nowTick)
+	}
+}
+
+// bind replaces "$field" strings in req with the event's field (missing fields
+// become "").
+func bind[T any](req T, e protocol.Event) T {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return req
+	}
+	var m any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return req
+	}
+	b, err = json.Marshal(substitute(m, e))
+	if err != nil {
+		return req
+	}
+	var out T
+	if err := json.Unmarshal(b, &out); err != nil {
+		return req
+	}
+	return out
+}
+
+func substitute(v any, e protocol.Event) any {
+	switch x := v.(type) {
+	case string:
+		if strings.HasPrefix(x, "$") {
+			if val, ok := e[x[1:]]; ok {
+				return val
+			}
+			return ""
+		}
+		return x
+	case []any:
+		for i := range x {
+			x[i] = substitute(x[i], e)
+		}
+		return x
+	case map[string]any:
+		for k := range x {
+			x[k] = substitute(x[k], e)
+		}
+		return x
+	}
+	return v
+}
+
+func condsHold(a *modelpkg.Agent, conds []modelpkg.TriggerCond, e protocol.Event) bool {
+	for _, c := range conds {
+		if !condHolds(a, c, e) {
+			return false
+		}
+	}
+	return true
+}
+
+func condHolds(a *modelpkg.Agent, c modelpkg.TriggerCond, e protocol.Event) bool {
+	v, ok := fieldValue(a, c.Field, e)
+	if !ok {
+		return false
+	}
+	if c.IsStr {
+		s, isStr := v.(string)
+		if b, isBool := v.(bool); isBool {
+			s, isStr = strconv.FormatBool(b), true
+		}
+		if !isStr {
+			return false
+		}
+		return (c.Op == "EQ") == (s == c.Str)
+	}
+	n, ok := number(v)
+	if !ok {
+		return false
+	}
+	switch c.Op {
+	case "EQ":
+		return n == c.Num
+	case "NE":
+		return n != c.Num
+	case "LT":
+		return n < c.Num
+	case "LE":
+		return n <= c.Num
+	case "GT":
+		return n > c.Num
+	case "GE":
+		return n >= c.Num
+	}
+	return false
+}
+
+func fieldValue(a *modelpkg.Agent, field string, e protocol.Event) (any, bool) {
+	if rest, ok := strings.CutPrefix(field, "self."); ok {
+		switch rest {
+		case "hunger":
+			return a.Hunger, true
+		case "hp":
+			return a.HP, true
+		case "stamina":
+			return float64(a.StaminaMilli) / 1000.0, true
+		}
+		if item, ok := strings.CutPrefix(rest, "inv."); ok {
+			return a.Inventory[item], true
+		}
+		return nil, false
+	}
+	v, ok := e[field]
+	return v, ok
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,160 @@
+package triggers
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func testAR(tick uint64, ref string, ok bool, code string, message string) protocol.Event {
+	e := protocol.Event{"t": tick, "type": "ACTION_RESULT", "ref": ref, "ok": ok}
+	if code != "" {
+		e["code"] = code
+	}
+	return e
+}
+
+// testEnv records applied instants.
+func testEnv() (Env, *[]protocol.InstantReq) {
+	var applied []protocol.InstantReq
+	return Env{
+		ApplyInstant: func(a *modelpkg.Agent, inst protocol.InstantReq, nowTick uint64) {
+			applied = append(applied, inst)
+			a.AddEvent(testAR(nowTick, inst.ID, true, "", "ok"))
+		},
+		InstantAllowed: func(typ string) bool { return typ == "EAT" || typ == "ACCEPT_TRADE" },
+		TaskAllowed:    func(typ string) bool { return typ == "MINE" },
+	}, &applied
+}
+
+func set(t *testing.T, env Env, a *modelpkg.Agent, spec protocol.TriggerSpec) {
+	t.Helper()
+	HandleSet(env, testAR, a, protocol.InstantReq{ID: "set", Type: "SET_TRIGGER", Trigger: &spec}, 1)
+	if ev := a.Events[len(a.Events)-1]; ev["ok"] != true {
+		t.Fatalf("SET_TRIGGER rejected: %v", ev)
+	}
+}
+
+func TestNormalizeRejects(t *testing.T) {
+	env, _ := testEnv()
+	eat := &protocol.InstantReq{Type: "EAT", ItemID: "BERRIES"}
+	for _, spec := range []*protocol.TriggerSpec{
+		nil,
+		{On: "STATS", Instant: eat},
+		{ID: "x", Instant: eat},
+		{ID: "x", On: "STATS"},
+		{ID: "x", On: "STATS", Instant: eat, Task: &protocol.TaskReq{Type: "MINE"}},
+		{ID: "x", On: "STATS", Instant: &protocol.InstantReq{Type: "SET_TRIGGER"}},
+		{ID: "x", On: "STATS", Task: &protocol.TaskReq{Type: "CRAFT"}},
+		{ID: "x", On: "STATS", Instant: eat, Where: []protocol.TriggerCond{{Field: "from", Op: "GT", Value: "A1"}}},
+		{ID: "x", On: "STATS", Instant: eat, Where: []protocol.TriggerCond{{Field: "hp", Op: "ABOUT", Value: 1}}},
+		{ID: "x", On: "STATS", Instant: eat, Where: []protocol.TriggerCond{{Field: "hp", Op: "LT", Value: []any{1}}}},
+		{ID: "x", On: "STATS", Instant: eat, CooldownTicks: -1},
+	} {
+		if _, code, _ := Normalize(env, spec); code == "" {
+			t.Fatalf("expected %+v rejected", spec)
+		}
+	}
+	tr, code, _ := Normalize(env, &protocol.TriggerSpec{ID: " eat ", On: "stats", Instant: eat,
+		Where: []protocol.TriggerCond{{Field: "self.hunger", Op: "lt", Value: 8.0}}})
+	if code != "" || tr.ID != "eat" || tr.On != OnStats || tr.Where[0].Op != "LT" || tr.Where[0].Num != 8 {
+		t.Fatalf("unexpected normalized trigger: %+v (%s)", tr, code)
+	}
+}
+
+func TestStatsTriggerCooldownAndMaxFires(t *testing.T) {
+	env, applied := testEnv()
+	a := &modelpkg.Agent{ID: "A1", Hunger: 5}
+	set(t, env, a, protocol.TriggerSpec{
+		ID: "eat", On: OnStats, CooldownTicks: 3, MaxFires: 2,
+		Where:   []protocol.TriggerCond{{Field: "self.hunger", Op: "LT", Value: 8}},
+		Instant: &protocol.InstantReq{Type: "EAT", ItemID: "BERRIES"},
+	})
+	for tick := uint64(1); tick <= 10; tick++ {
+		budget := 10
+		Evaluate(env, a, tick, &budget)
+	}
+	if len(*applied) != 2 || (*applied)[0].ID != "trigger:eat" {
+		t.Fatalf("expected 2 fires, got %+v", *applied)
+	}
+	if a.Triggers != nil {
+		t.Fatalf("expected trigger removed after max_fires, got %+v", a.Triggers)
+	}
+
+	// Conditions that do not hold never fire.
+	a = &modelpkg.Agent{ID: "A2", Hunger: 20}
+	set(t, env, a, protocol.TriggerSpec{
+		ID: "eat", On: OnStats,
+		Where:   []protocol.TriggerCond{{Field: "self.hunger", Op: "LT", Value: 8}},
+		Instant: &protocol.InstantReq{Type: "EAT", ItemID: "BERRIES"},
+	})
+	budget := 10
+	Evaluate(env, a, 1, &budget)
+	if len(*applied) != 2 || budget != 10 {
+		t.Fatalf("unexpected fire")
+	}
+}
+
+func TestEventTriggerBindsFields(t *testing.T) {
+	env, applied := testEnv()
+	a := &modelpkg.Agent{ID: "A1"}
+	set(t, env, a, protocol.TriggerSpec{
+		ID: "auto", On: "TRADE_OFFER",
+		Where: []protocol.TriggerCond{
+			{Field: "from", Op: "EQ", Value: "A2"},
+			{Field: "offer_value", Op: "GE", Value: 10},
+		},
+		Instant: &protocol.InstantReq{Type: "ACCEPT_TRADE", TradeID: "$trade_id"},
+	})
+	a.AddEvent(protocol.Event{"t": uint64(2), "type": "TRADE_OFFER", "trade_id": "TR1", "from": "A3", "offer_value": int64(50)})
+	a.AddEvent(protocol.Event{"t": uint64(2), "type": "TRADE_OFFER", "trade_id": "TR2", "from": "A2", "offer_value": int64(5)})
+	a.AddEvent(protocol.Event{"t": uint64(2), "type": "TRADE_OFFER", "trade_id": "TR3", "from": "A2", "offer_value": int64(12)})
+	budget := 10
+	Evaluate(env, a, 2, &budget)
+	if len(*applied) != 1 || (*applied)[0].TradeID != "TR3" {
+		t.Fatalf("expected TR3 accepted, got %+v", *applied)
+	}
+	// Already-matched events and the trigger's own results do not fire again.
+	Evaluate(env, a, 3, &budget)
+	if len(*applied) != 1 {
+		t.Fatalf("trigger re-fired on old events: %+v", *applied)
+	}
+
+	HandleRemove(testAR, a, protocol.InstantReq{ID: "rm", TriggerID: "auto"}, 4)
+	if a.Triggers != nil || a.Events[len(a.Events)-1]["ok"] != true {
+		t.Fatalf("remove failed: %+v", a.Triggers)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	env, applied := testEnv()
+	spec := protocol.TriggerSpec{ID: "eat", On: OnStats, Instant: &protocol.InstantReq{Type: "EAT"}}
+	a1 := &modelpkg.Agent{ID: "A1"}
+	a2 := &modelpkg.Agent{ID: "A2"}
+	set(t, env, a1, spec)
+	set(t, env, a2, spec)
+	budget := 1
+	Evaluate(env, a1, 1, &budget)
+	Evaluate(env, a2, 1, &budget)
+	if len(*applied) != 1 || budget != 0 || a2.Triggers[0].Fires != 0 {
+		t.Fatalf("expected one fire within budget, got %d", len(*applied))
+	}
+}
+
+func TestBudgetRotatesStartingAgent(t *testing.T) {
+	env, _ := testEnv()
+	spec := protocol.TriggerSpec{ID: "eat", On: OnStats, Instant: &protocol.InstantReq{Type: "EAT"}}
+	agents := []*modelpkg.Agent{{ID: "A1"}, {ID: "A2"}, {ID: "A3"}}
+	for _, a := range agents {
+		set(t, env, a, spec)
+	}
+	for tick := uint64(1); tick <= 3; tick++ {
+		EvaluateAll(env, agents, tick, 1)
+	}
+	for _, a := range agents {
+		if a.Triggers[0].Fires != 1 {
+			t.Fatalf("%s fired %d times in 3 ticks with budget 1", a.ID, a.Triggers[0].Fires)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+// MaxEventsCap bounds max_events so a view cannot request unbounded OBS growth.
+const MaxEventsCap = 256
+
+var sections = map[string]bool{
+	modelpkg.ViewSectionVoxels:       true,
+	modelpkg.ViewSectionEntities:     true,
+	modelpkg.ViewSectionPublicBoards: true,
+	modelpkg.ViewSectionMarkets:      true,
+	modelpkg.ViewSectionPrices:       true,
+	modelpkg.ViewSectionMemory:       true,
+	modelpkg.ViewSectionFunScore:     true,
+}
+
+// Limits are the tuning bounds for a per-agent obs_radius.
+type Limits struct {
+	MinRadius int
+	MaxRadius int
+}
+
+// Normalize validates a SET_VIEW payload (nil = defaults) and returns the canonical view.
+func Normalize(spec *protocol.ViewSpec, limits Limits) (modelpkg.View, string, string) {
+	if spec == nil {
+		return modelpkg.View{}, "", ""
+	}
+	var v modelpkg.View
+	for _, s := range spec.Hide {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if !sections[s] {
+			return modelpkg.View{}, protocol.ErrBadRequest, fmt.Sprintf("unknown view section: %q", s)
+		}
+		v.Hidden = appendUnique(v.Hidden, s)
+	}
+	if spec.ObsRadius != 0 {
+		if spec.ObsRadius < limits.MinRadius || spec.ObsRadius > limits.MaxRadius {
+			return modelpkg.View{}, protocol.ErrBadRequest, fmt.Sprintf("obs_radius must be in %d..%d", limits.MinRadius, limits.MaxRadius)
+		}
+		v.ObsRadius = spec.ObsRadius
+	}
+	for _, t := range spec.EntityTypes {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if t == "" {
+			return modelpkg.View{}, protocol.ErrBadRequest, "empty entity type"
+		}
+		v.EntityTypes = appendUnique(v.EntityTypes, t)
+	}
+	if spec.MaxEvents < 0 || spec.MaxEvents > MaxEventsCap {
+		return modelpkg.View{}, protocol.ErrBadRequest, fmt.Sprintf("max_events must be in 0..%d", MaxEventsCap)
+	}
+	v.MaxEvents = spec.MaxEvents
+	sort.Strings(v.Hidden)
+	sort.Strings(v.EntityTypes)
+	return v, "", ""
+}
+
+// FilterEntities drops entities the view does not show.
+func FilterEntities(v modelpkg.View, ents []protocol.EntityObs) []protocol.EntityObs {
+	if len(v.EntityTypes) == 0 {
+		return ents
+	}
+	out := ents[:0]
+	for _, e := range ents {
+		if v.ShowsEntity(e.Type) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Radius is the effective voxel radius for the view, clamped to limits (a view may
+// arrive by transfer or snapshot from a world with wider bounds).
+func Radius(v modelpkg.View, worldDefault int, limits Limits) int {
+	if v.ObsRadius <= 0 {
+		return worldDefault
+	}
+	return max(limits.MinRadius, min(v.ObsRadius, limits.MaxRadius))
+}
+
+func appendUnique(xs []string, s string) []string {
+	for _, x := range xs {
+		if x == s {
+			return xs
+		}
+	}
+	return append(xs, s)
+}
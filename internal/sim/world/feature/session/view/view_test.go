@@ -0,0 +1,57 @@
+package view
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func TestNormalize(t *testing.T) {
+	limits := Limits{MinRadius: 2, MaxRadius: 12}
+	v, code, _ := Normalize(&protocol.ViewSpec{
+		Hide:        []string{"Memory", "voxels", "memory"},
+		ObsRadius:   4,
+		EntityTypes: []string{"item", "AGENT"},
+		MaxEvents:   10,
+	}, limits)
+	if code != "" {
+		t.Fatalf("unexpected error %s", code)
+	}
+	if len(v.Hidden) != 2 || v.Hidden[0] != "memory" || v.Hidden[1] != "voxels" {
+		t.Fatalf("hidden: %v", v.Hidden)
+	}
+	if len(v.EntityTypes) != 2 || v.EntityTypes[0] != "AGENT" || v.EntityTypes[1] != "ITEM" {
+		t.Fatalf("entity types: %v", v.EntityTypes)
+	}
+	if v.Shows(modelpkg.ViewSectionVoxels) || !v.Shows(modelpkg.ViewSectionEntities) || Radius(v, 7, limits) != 4 {
+		t.Fatalf("unexpected view %+v", v)
+	}
+
+	if r := Radius(modelpkg.View{ObsRadius: 20}, 7, limits); r != 12 {
+		t.Fatalf("expected clamp to 12, got %d", r)
+	}
+
+	for _, bad := range []*protocol.ViewSpec{
+		{Hide: []string{"self"}},
+		{ObsRadius: 1},
+		{ObsRadius: 13},
+		{MaxEvents: -1},
+		{EntityTypes: []string{" "}},
+	} {
+		if _, code, _ := Normalize(bad, limits); code != protocol.ErrBadRequest {
+			t.Fatalf("expected rejection for %+v", bad)
+		}
+	}
+	if v, code, _ := Normalize(nil, limits); code != "" || !v.IsZero() {
+		t.Fatalf("nil spec should reset: %+v", v)
+	}
+}
+
+func TestFilterEntities(t *testing.T) {
+	ents := []protocol.EntityObs{{ID: "A", Type: "AGENT"}, {ID: "C", Type: "CHEST"}, {ID: "I", Type: "ITEM"}}
+	got := FilterEntities(modelpkg.View{EntityTypes: []string{"AGENT", "ITEM"}}, ents)
+	if len(got) != 2 || got[0].ID != "A" || got[1].ID != "I" {
+		t.Fatalf("filtered: %+v", got)
+	}
+}
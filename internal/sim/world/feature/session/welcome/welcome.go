@@ -12,6 +12,7 @@ type Input struct {
 	ResetNoticeTicks   int
 	TickRateHz         int
 	ObsRadius          int
+	Height             int
 	DayTicks           int
 	Seed               int64
 	BlockPaletteDigest string
@@ -41,8 +42,8 @@ func Build(in Input) protocol.WelcomeMsg {
 		}},
 		WorldParams: protocol.WorldParams{
 			TickRateHz: in.TickRateHz,
-			ChunkSize:  [3]int{16, 16, 1},
-			Height:     1,
+			ChunkSize:  [3]int{16, 16, in.Height},
+			Height:     in.Height,
 			ObsRadius:  in.ObsRadius,
 			DayTicks:   in.DayTicks,
 			Seed:       in.Seed,
@@ -13,6 +13,7 @@ func TestBuildWelcome(t *testing.T) {
 		ResetNoticeTicks:   300,
 		TickRateHz:         5,
 		ObsRadius:          7,
+		Height:             1,
 		DayTicks:           6000,
 		Seed:               123,
 		BlockPaletteDigest: "bd",
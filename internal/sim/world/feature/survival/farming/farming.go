@@ -0,0 +1,176 @@
+// Package farming implements tilled soil and crops: crop definitions, deterministic
+// growth (season quarter, weather, water, blight) and harvest yields.
+package farming
+
+import (
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	FarmlandBlock = "FARMLAND"
+
+	// GrowEveryTicks is the growth step interval (~20s at 5Hz).
+	GrowEveryTicks = 100
+
+	// WaterRadius is how close WATER must be to count as irrigated.
+	WaterRadius = 4
+)
+
+type Drop struct {
+	Item  string
+	Count int
+}
+
+// Def describes one crop. Stage 0 is freshly planted; Stages-1 is ripe.
+type Def struct {
+	Block          string
+	Seed           string // item planted as Block
+	Stages         int
+	GrowthPerStage int
+	Ripe           []Drop
+}
+
+var defs = map[string]Def{
+	"WHEAT_CROP": {
+		Block:          "WHEAT_CROP",
+		Seed:           "WHEAT_SEEDS",
+		Stages:         4,
+		GrowthPerStage: 12,
+		Ripe:           []Drop{{Item: "WHEAT", Count: 2}, {Item: "WHEAT_SEEDS", Count: 2}},
+	},
+	"CARROT_CROP": {
+		Block:          "CARROT_CROP",
+		Seed:           "CARROT",
+		Stages:         3,
+		GrowthPerStage: 16,
+		Ripe:           []Drop{{Item: "CARROT", Count: 3}},
+	},
+}
+
+// DefForBlock returns the crop planted as blockName.
+func DefForBlock(blockName string) (Def, bool) {
+	d, ok := defs[blockName]
+	return d, ok
+}
+
+func IsCropBlock(blockName string) bool {
+	_, ok := defs[blockName]
+	return ok
+}
+
+// Tillable reports blocks a hoe turns into FARMLAND.
+func Tillable(blockName string) bool {
+	return blockName == "DIRT" || blockName == "GRASS"
+}
+
+// TillDrops is what tilling blockName yields: grass gives wheat seeds, the starting
+// seed source besides harvests.
+func TillDrops(blockName string) []Drop {
+	if blockName == "GRASS" {
+		return []Drop{{Item: "WHEAT_SEEDS", Count: 1}}
+	}
+	return nil
+}
+
+// Ripe reports whether c reached its last stage.
+func Ripe(c modelpkg.Crop) bool {
+	d, ok := defs[c.Type]
+	return ok && c.Stage >= d.Stages-1
+}
+
+// HarvestDrops is the yield of breaking c: the ripe table, or the seed back if unripe.
+func HarvestDrops(c modelpkg.Crop) []Drop {
+	d, ok := defs[c.Type]
+	if !ok {
+		return nil
+	}
+	if c.Stage >= d.Stages-1 {
+		return append([]Drop(nil), d.Ripe...)
+	}
+	return []Drop{{Item: d.Seed, Count: 1}}
+}
+
+// Quarter splits the season into SPRING/SUMMER/AUTUMN/WINTER.
+func Quarter(nowTick, seasonLengthTicks uint64) string {
+	if seasonLengthTicks == 0 {
+		return "SUMMER"
+	}
+	switch (nowTick % seasonLengthTicks) * 4 / seasonLengthTicks {
+	case 0:
+		return "SPRING"
+	case 1:
+		return "SUMMER"
+	case 2:
+		return "AUTUMN"
+	default:
+		return "WINTER"
+	}
+}
+
+// GrowthPoints is the growth a crop gains per step. Cold weather and blight stop
+// growth; storms (rain) and nearby water speed it up.
+func GrowthPoints(quarter, weather string, watered, inBlight bool) int {
+	if inBlight || weather == "COLD" {
+		return 0
+	}
+	pts := 2
+	switch quarter {
+	case "SPRING":
+		pts = 3
+	case "SUMMER":
+		pts = 4
+	case "WINTER":
+		pts = 1
+	}
+	if weather == "STORM" {
+		pts++
+	}
+	if watered {
+		pts++
+	}
+	return pts
+}
+
+type TickInput struct {
+	NowTick           uint64
+	SeasonLengthTicks uint64
+	Weather           string
+	Crops             map[modelpkg.Vec3i]*modelpkg.Crop
+}
+
+type TickHooks struct {
+	// BlockNameAt verifies the crop block is still there (crops whose block changed are dropped).
+	BlockNameAt func(pos modelpkg.Vec3i) string
+	Watered     func(pos modelpkg.Vec3i) bool
+	InBlight    func(pos modelpkg.Vec3i) bool
+}
+
+// Tick advances every crop one growth step on GrowEveryTicks boundaries, in position
+// order.
+func Tick(in TickInput, hooks TickHooks) {
+	if in.NowTick == 0 || in.NowTick%GrowEveryTicks != 0 || len(in.Crops) == 0 {
+		return
+	}
+	quarter := Quarter(in.NowTick, in.SeasonLengthTicks)
+	for _, pos := range modelpkg.SortedPositions(in.Crops) {
+		c := in.Crops[pos]
+		d, ok := defs[c.Type]
+		if !ok || (hooks.BlockNameAt != nil && hooks.BlockNameAt(pos) != c.Type) {
+			delete(in.Crops, pos)
+			continue
+		}
+		if c.Stage >= d.Stages-1 {
+			continue
+		}
+		watered := hooks.Watered != nil && hooks.Watered(pos)
+		inBlight := hooks.InBlight != nil && hooks.InBlight(pos)
+		c.Growth += GrowthPoints(quarter, in.Weather, watered, inBlight)
+		for c.Growth >= d.GrowthPerStage && c.Stage < d.Stages-1 {
+			c.Growth -= d.GrowthPerStage
+			c.Stage++
+		}
+		if c.Stage >= d.Stages-1 {
+			c.Growth = 0
+		}
+	}
+}
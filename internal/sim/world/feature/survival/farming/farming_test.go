@@ -0,0 +1,70 @@
+package farming
+
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func TestGrowthPoints(t *testing.T) {
+	cases := []struct {
+		quarter, weather string
+		watered, blight  bool
+		want             int
+	}{
+		{"SUMMER", "CLEAR", false, false, 4},
+		{"SPRING", "STORM", true, false, 5},
+		{"AUTUMN", "CLEAR", true, false, 3},
+		{"WINTER", "CLEAR", false, false, 1},
+		{"SUMMER", "COLD", true, false, 0},
+		{"SUMMER", "CLEAR", true, true, 0},
+	}
+	for _, c := range cases {
+		if got := GrowthPoints(c.quarter, c.weather, c.watered, c.blight); got != c.want {
+			t.Fatalf("GrowthPoints(%+v)=%d want %d", c, got, c.want)
+		}
+	}
+	if Quarter(0, 400) != "SPRING" || Quarter(100, 400) != "SUMMER" || Quarter(399, 400) != "WINTER" || Quarter(400, 400) != "SPRING" {
+		t.Fatalf("unexpected quarters")
+	}
+}
+
+func TestTickStagesAndDropsStaleCrops(t *testing.T) {
+	a := modelpkg.Vec3i{X: 1}
+	b := modelpkg.Vec3i{X: 2}
+	crops := map[modelpkg.Vec3i]*modelpkg.Crop{
+		a: {Type: "CARROT_CROP"},
+		b: {Type: "WHEAT_CROP"},
+	}
+	hooks := TickHooks{
+		BlockNameAt: func(p modelpkg.Vec3i) string {
+			if p == a {
+				return "CARROT_CROP"
+			}
+			return "AIR" // b was broken
+		},
+		Watered: func(modelpkg.Vec3i) bool { return true },
+	}
+	// Off-boundary ticks do nothing.
+	Tick(TickInput{NowTick: GrowEveryTicks + 1, Crops: crops}, hooks)
+	if len(crops) != 2 || crops[a].Growth != 0 {
+		t.Fatalf("expected no growth off the step boundary")
+	}
+	for i := uint64(1); i <= 8; i++ {
+		Tick(TickInput{NowTick: i * GrowEveryTicks, Weather: "CLEAR", Crops: crops}, hooks)
+	}
+	if _, ok := crops[b]; ok {
+		t.Fatalf("expected crop with changed block dropped")
+	}
+	// SUMMER (no season length) + watered = 5/step; 32 points for 2 stages.
+	c := crops[a]
+	if c.Stage != 2 || c.Growth != 0 || !Ripe(*c) {
+		t.Fatalf("expected ripe carrot, got %+v", c)
+	}
+	if d := HarvestDrops(*c); len(d) != 1 || d[0].Item != "CARROT" || d[0].Count != 3 {
+		t.Fatalf("unexpected ripe drops %+v", d)
+	}
+	if d := HarvestDrops(modelpkg.Crop{Type: "WHEAT_CROP", Stage: 1}); len(d) != 1 || d[0].Item != "WHEAT_SEEDS" {
+		t.Fatalf("unripe crop should return its seed, got %+v", d)
+	}
+}
@@ -11,6 +11,7 @@ func ResetForSeason(a *modelpkg.Agent, findSpawnAir func(x, z, maxRadius int) (i
 	// Cancel ongoing tasks.
 	a.MoveTask = nil
 	a.WorkTask = nil
+	a.TaskQueue = nil
 
 	// Reset physical attributes.
 	a.HP = 20
@@ -8,12 +8,20 @@ import (
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 )
 
+// Respawn reasons reported in the RESPAWN event.
+const (
+	ReasonDowned = "DOWNED" // survival damage (starvation, cold, hazards)
+	ReasonKilled = "KILLED" // combat
+)
+
 type SpawnItemFn func(nowTick uint64, actor string, pos modelpkg.Vec3i, item string, count int, reason string) string
 type FindSpawnAirFn func(x, z, radius int) (int, int)
+type SurfaceYFn func(x, z int) int
 
 type Hooks struct {
 	SpawnItem    SpawnItemFn
 	FindSpawnAir FindSpawnAirFn
+	SurfaceY     SurfaceYFn // nil = y 0 (2D)
 }
 
 func Apply(nowTick uint64, a *modelpkg.Agent, reason string, hooks Hooks) {
@@ -24,6 +32,7 @@ func Apply(nowTick uint64, a *modelpkg.Agent, reason string, hooks Hooks) {
 	// Cancel ongoing tasks.
 	a.MoveTask = nil
 	a.WorkTask = nil
+	a.TaskQueue = nil
 
 	// Drop ~30% of each stack (deterministic) at the downed position.
 	dropPos := a.Pos
@@ -54,6 +63,9 @@ func Apply(nowTick uint64, a *modelpkg.Agent, reason string, hooks Hooks) {
 		spawnX, spawnZ = hooks.FindSpawnAir(spawnX, spawnZ, 8)
 	}
 	a.Pos = modelpkg.Vec3i{X: spawnX, Y: 0, Z: spawnZ}
+	if hooks.SurfaceY != nil {
+		a.Pos.Y = hooks.SurfaceY(spawnX, spawnZ)
+	}
 	a.Yaw = 0
 
 	a.HP = 20
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"voxelcraft.ai/internal/protocol"
+	respawnpkg "voxelcraft.ai/internal/sim/world/feature/survival/respawn"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 )
 
@@ -105,7 +106,7 @@ func Tick(in TickInput, hooks TickHooks) {
 
 		// Downed -> respawn.
 		if a.HP <= 0 && hooks.Respawn != nil {
-			hooks.Respawn(nowTick, a, "DOWNED")
+			hooks.Respawn(nowTick, a, respawnpkg.ReasonDowned)
 		}
 	}
 
@@ -0,0 +1,193 @@
+// Package water simulates flowing water on the block grid. WATER blocks are sources;
+// FLOWING_WATER cells carry a level that drops by one per sideways step, so a source
+// floods up to MaxLevel-1 cells around it. Updates are pulled: an active cell
+// recomputes its level from its neighbours and wakes them when it changes.
+package water
+
+import (
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	SourceBlock = "WATER"
+	FlowBlock   = "FLOWING_WATER"
+
+	// MaxLevel is a source's level; falling water restarts at MaxLevel-1.
+	MaxLevel = 7
+
+	BucketItem      = "BUCKET"
+	WaterBucketItem = "WATER_BUCKET"
+)
+
+// Cell classifies a position for the flow rules.
+type Cell int
+
+const (
+	// CellSolid holds water back (any block that is not air, water or floodable).
+	CellSolid Cell = iota
+	CellAir
+	CellSource
+	CellFlow
+	// CellFloodable is a block the water may replace (unprotected crops and
+	// structure blocks inside a flood zone).
+	CellFloodable
+)
+
+// Erodable reports blocks a flood may wash out of a structure: blocks with attached
+// state (containers, boards, signs, machines, totems) always hold.
+func Erodable(blockName string) bool {
+	switch blockName {
+	case "", "AIR", SourceBlock, FlowBlock,
+		"CHEST", "FURNACE", "CONTRACT_TERMINAL", "MARKET_TERMINAL", "SHOP", "BULLETIN_BOARD", "SIGN",
+		"CLAIM_TOTEM", "CONVEYOR", "SWITCH", "SENSOR", "WIRE", "BATTERY",
+		"AND_GATE", "OR_GATE", "NOT_GATE", "REPEATER", "AUTO_CRAFTER", "HOPPER":
+		return false
+	}
+	return true
+}
+
+type StepInput struct {
+	// Levels holds FLOWING_WATER levels (1..MaxLevel-1).
+	Levels map[modelpkg.Vec3i]int
+	// Active cells are re-evaluated this tick, in position order, up to Budget.
+	Active map[modelpkg.Vec3i]bool
+	Budget int
+	// Vertical enables falling (3D worlds): water only spreads sideways from a
+	// cell resting on something.
+	Vertical bool
+}
+
+type StepHooks struct {
+	CellAt   func(pos modelpkg.Vec3i) Cell
+	InBounds func(pos modelpkg.Vec3i) bool
+	// SetFlow turns an air or floodable cell into FLOWING_WATER.
+	SetFlow func(pos modelpkg.Vec3i, from Cell)
+	// Dry turns a FLOWING_WATER cell back into air.
+	Dry func(pos modelpkg.Vec3i)
+}
+
+// Step processes up to in.Budget active cells and returns how many it handled.
+// Cells woken during the step wait for the next one.
+func Step(in StepInput, hooks StepHooks) int {
+	if len(in.Active) == 0 || in.Budget <= 0 {
+		return 0
+	}
+	done := 0
+	for _, p := range modelpkg.SortedPositions(in.Active) {
+		if done >= in.Budget {
+			break
+		}
+		delete(in.Active, p)
+		done++
+		switch kind := hooks.CellAt(p); kind {
+		case CellSource:
+			wake(in, hooks, p)
+		case CellAir, CellFlow, CellFloodable:
+			cur := 0
+			if kind == CellFlow {
+				cur = in.Levels[p]
+			}
+			want := wantLevel(in, hooks, p)
+			if want == cur {
+				continue
+			}
+			switch {
+			case want == 0:
+				delete(in.Levels, p)
+				hooks.Dry(p)
+			case kind == CellFlow:
+				in.Levels[p] = want
+			default:
+				hooks.SetFlow(p, kind)
+				in.Levels[p] = want
+			}
+			wake(in, hooks, p)
+		default:
+			delete(in.Levels, p)
+		}
+	}
+	return done
+}
+
+// wantLevel is the level p would hold given its neighbours.
+func wantLevel(in StepInput, hooks StepHooks, p modelpkg.Vec3i) int {
+	best := 0
+	if in.Vertical {
+		if k := cellAt(hooks, up(p)); k == CellSource || k == CellFlow {
+			best = MaxLevel - 1
+		}
+	}
+	for _, n := range sideways(p) {
+		lvl := 0
+		switch cellAt(hooks, n) {
+		case CellSource:
+			lvl = MaxLevel
+		case CellFlow:
+			if in.Vertical && !resting(hooks, n) {
+				continue
+			}
+			lvl = in.Levels[n]
+		}
+		if lvl-1 > best {
+			best = lvl - 1
+		}
+	}
+	return best
+}
+
+// resting reports whether flowing water at p can spread sideways: it sits on the
+// world floor, a solid block or a source. Flow over flow keeps falling, so flowing
+// water is one cell deep.
+func resting(hooks StepHooks, p modelpkg.Vec3i) bool {
+	below := modelpkg.Vec3i{X: p.X, Y: p.Y - 1, Z: p.Z}
+	if p.Y == 0 || !hooks.InBounds(below) {
+		return true
+	}
+	switch hooks.CellAt(below) {
+	case CellSolid, CellSource:
+		return true
+	}
+	return false
+}
+
+func wake(in StepInput, hooks StepHooks, p modelpkg.Vec3i) {
+	for _, n := range Neighbours(p, in.Vertical) {
+		switch cellAt(hooks, n) {
+		case CellAir, CellFlow, CellFloodable:
+			in.Active[n] = true
+		}
+	}
+}
+
+func cellAt(hooks StepHooks, p modelpkg.Vec3i) Cell {
+	if !hooks.InBounds(p) {
+		return CellSolid
+	}
+	return hooks.CellAt(p)
+}
+
+func up(p modelpkg.Vec3i) modelpkg.Vec3i { return modelpkg.Vec3i{X: p.X, Y: p.Y + 1, Z: p.Z} }
+
+func sideways(p modelpkg.Vec3i) [4]modelpkg.Vec3i {
+	return [4]modelpkg.Vec3i{
+		{X: p.X - 1, Y: p.Y, Z: p.Z},
+		{X: p.X + 1, Y: p.Y, Z: p.Z},
+		{X: p.X, Y: p.Y, Z: p.Z - 1},
+		{X: p.X, Y: p.Y, Z: p.Z + 1},
+	}
+}
+
+// Neighbours lists the cells whose level may depend on p: its sideways and vertical
+// neighbours, plus (3D) the cells beside the one above, which spread only while
+// it rests on p.
+func Neighbours(p modelpkg.Vec3i, vertical bool) []modelpkg.Vec3i {
+	s := sideways(p)
+	out := s[:]
+	if vertical {
+		above := up(p)
+		side := sideways(above)
+		out = append(out, modelpkg.Vec3i{X: p.X, Y: p.Y - 1, Z: p.Z}, above)
+		out = append(out, side[:]...)
+	}
+	return out
+}
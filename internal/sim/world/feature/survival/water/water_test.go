@@ -0,0 +1,99 @@
+package water
+
+import (
+	"testing"
+
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+// grid is a tiny world: cells default to air inside [-20,20] on x/z and y in [0,height).
+type grid struct {
+	height int
+	cells  map[modelpkg.Vec3i]Cell
+	sets   int
+}
+
+func (g *grid) hooks() StepHooks {
+	return StepHooks{
+		CellAt: func(p modelpkg.Vec3i) Cell {
+			if c, ok := g.cells[p]; ok {
+				return c
+			}
+			return CellAir
+		},
+		InBounds: func(p modelpkg.Vec3i) bool {
+			return p.Y >= 0 && p.Y < g.height && p.X >= -20 && p.X <= 20 && p.Z >= -20 && p.Z <= 20
+		},
+		SetFlow: func(p modelpkg.Vec3i, _ Cell) { g.cells[p] = CellFlow; g.sets++ },
+		Dry:     func(p modelpkg.Vec3i) { delete(g.cells, p) },
+	}
+}
+
+func settle(t *testing.T, in StepInput, hooks StepHooks) {
+	t.Helper()
+	for i := 0; i < 100 && len(in.Active) > 0; i++ {
+		Step(in, hooks)
+	}
+	if len(in.Active) > 0 {
+		t.Fatalf("water did not settle")
+	}
+}
+
+func TestFlowSpreadsAndDries(t *testing.T) {
+	g := &grid{height: 1, cells: map[modelpkg.Vec3i]Cell{}}
+	src := modelpkg.Vec3i{}
+	wall := modelpkg.Vec3i{X: -1}
+	g.cells[src] = CellSource
+	g.cells[wall] = CellSolid
+	in := StepInput{Levels: map[modelpkg.Vec3i]int{}, Active: map[modelpkg.Vec3i]bool{src: true}, Budget: 1000}
+	settle(t, in, g.hooks())
+
+	if got := in.Levels[modelpkg.Vec3i{X: 1}]; got != MaxLevel-1 {
+		t.Fatalf("level next to source = %d, want %d", got, MaxLevel-1)
+	}
+	if got := in.Levels[modelpkg.Vec3i{X: MaxLevel - 1}]; got != 1 {
+		t.Fatalf("level at the edge = %d, want 1", got)
+	}
+	if _, ok := g.cells[modelpkg.Vec3i{X: MaxLevel}]; ok {
+		t.Fatalf("water spread past MaxLevel-1 cells")
+	}
+	if g.cells[wall] != CellSolid || g.cells[modelpkg.Vec3i{X: -2}] != CellFlow {
+		t.Fatalf("expected the wall kept and water around it")
+	}
+
+	// Removing the source drains every flow cell.
+	delete(g.cells, src)
+	in.Active[src] = true
+	settle(t, in, g.hooks())
+	for p, c := range g.cells {
+		if c == CellFlow {
+			t.Fatalf("flow left at %+v after the source was removed", p)
+		}
+	}
+	if len(in.Levels) != 0 {
+		t.Fatalf("levels left: %v", in.Levels)
+	}
+}
+
+func TestStepBudgetAndFalling(t *testing.T) {
+	g := &grid{height: 4, cells: map[modelpkg.Vec3i]Cell{}}
+	// Source at y=3 above an empty column: water falls to the floor before spreading.
+	src := modelpkg.Vec3i{Y: 3}
+	g.cells[src] = CellSource
+	in := StepInput{Levels: map[modelpkg.Vec3i]int{}, Active: map[modelpkg.Vec3i]bool{src: true}, Budget: 1, Vertical: true}
+	if n := Step(in, g.hooks()); n != 1 {
+		t.Fatalf("Step handled %d cells with budget 1", n)
+	}
+	in.Budget = 1000
+	settle(t, in, g.hooks())
+	if g.cells[modelpkg.Vec3i{Y: 0}] != CellFlow || in.Levels[modelpkg.Vec3i{Y: 1}] != MaxLevel-1 {
+		t.Fatalf("expected a falling column, got %v", in.Levels)
+	}
+	// The source spills one cell sideways; that water falls instead of spreading on.
+	if _, ok := g.cells[modelpkg.Vec3i{X: 2, Y: 3}]; ok {
+		t.Fatalf("falling water must not spread sideways mid-air")
+	}
+	if got := in.Levels[modelpkg.Vec3i{X: 2, Y: 0}]; got != MaxLevel-2 {
+		t.Fatalf("floor spread = %d, want %d", got, MaxLevel-2)
+	}
+}
@@ -0,0 +1,179 @@
+package permit
+
+import (
+	"sort"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+// Grant sources, reported in the switch ACTION_RESULT.
+const (
+	ViaHeld     = "HELD"     // a permit the agent already holds
+	ViaOrg      = "ORG"      // org membership
+	ViaRep      = "REP"      // reputation thresholds
+	ViaItem     = "ITEM"     // one permit item consumed from the inventory
+	ViaPurchase = "PURCHASE" // price paid from the inventory
+)
+
+// Rule is one way into a permit-gated world (worlds.yaml "permits").
+type Rule struct {
+	ID string
+	// DurationTicks is how long an issued permit stays valid, counted in ticks spent inside
+	// the worlds it gates; 0 never expires.
+	DurationTicks uint64
+
+	OrgIDs      []string
+	OrgKinds    []string
+	MinRepLaw   int
+	MinRepTrade int
+
+	Item  string
+	Price map[string]int
+}
+
+// Grant is a successful permit check.
+type Grant struct {
+	PermitID string
+	Via      string
+	// RemainingTicks is the validity left inside the gated worlds (0 = never expires).
+	RemainingTicks uint64
+}
+
+// Denial is a failed permit check.
+type Denial struct {
+	Code    string
+	Message string
+}
+
+// Check finds the first rule that admits the agent and issues (or keeps) its permit. It runs
+// in the gated world on the incoming agent, so nothing is charged unless the agent gets in.
+// Items and prices are only charged when no free grant applies.
+//
+// Agent.Permits holds the remaining ticks of each permit. They only run down while the agent
+// is inside a world the permit gates (see Tick), so no world's clock leaks into another.
+func Check(a *modelpkg.Agent, org *modelpkg.Organization, rules []Rule) (Grant, *Denial) {
+	if a == nil {
+		return Grant{}, &Denial{Code: protocol.ErrWorldPermitRequired, Message: "agent not found"}
+	}
+	if len(rules) == 0 {
+		return Grant{}, &Denial{Code: protocol.ErrWorldPermitRequired, Message: "no permit issued for this world"}
+	}
+	for _, r := range rules {
+		if rem, ok := a.Permits[r.ID]; ok {
+			return Grant{PermitID: r.ID, Via: ViaHeld, RemainingTicks: rem}, nil
+		}
+	}
+	for _, r := range rules {
+		if orgGrants(r, org) {
+			return issue(a, r, ViaOrg), nil
+		}
+		if repGrants(r, a) {
+			return issue(a, r, ViaRep), nil
+		}
+	}
+	for _, r := range rules {
+		if r.Item != "" && a.Inventory[r.Item] > 0 {
+			a.Inventory[r.Item]--
+			if a.Inventory[r.Item] == 0 {
+				delete(a.Inventory, r.Item)
+			}
+			return issue(a, r, ViaItem), nil
+		}
+	}
+	for _, r := range rules {
+		if canPay(a, r.Price) {
+			for _, item := range sortedKeys(r.Price) {
+				a.Inventory[item] -= r.Price[item]
+				if a.Inventory[item] == 0 {
+					delete(a.Inventory, item)
+				}
+			}
+			return issue(a, r, ViaPurchase), nil
+		}
+	}
+	return Grant{}, &Denial{Code: protocol.ErrWorldPermitRequired, Message: "permit required"}
+}
+
+// Tick runs down the agent's permits among gating (the permits of the world it is in) by one
+// tick and returns the ids that expired, sorted. Expired permits are removed.
+func Tick(a *modelpkg.Agent, gating []string) []string {
+	var expired []string
+	for _, id := range gating {
+		rem, ok := a.Permits[id]
+		if !ok || rem == 0 {
+			continue
+		}
+		if rem > 1 {
+			a.Permits[id] = rem - 1
+			continue
+		}
+		delete(a.Permits, id)
+		expired = append(expired, id)
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+// Holds reports whether the agent holds any of the permits in gating.
+func Holds(a *modelpkg.Agent, gating []string) bool {
+	for _, id := range gating {
+		if _, ok := a.Permits[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func issue(a *modelpkg.Agent, r Rule, via string) Grant {
+	if a.Permits == nil {
+		a.Permits = map[string]uint64{}
+	}
+	a.Permits[r.ID] = r.DurationTicks
+	return Grant{PermitID: r.ID, Via: via, RemainingTicks: r.DurationTicks}
+}
+
+func orgGrants(r Rule, org *modelpkg.Organization) bool {
+	if org == nil {
+		return false
+	}
+	for _, id := range r.OrgIDs {
+		if id == org.OrgID {
+			return true
+		}
+	}
+	for _, k := range r.OrgKinds {
+		if k == string(org.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func repGrants(r Rule, a *modelpkg.Agent) bool {
+	if r.MinRepLaw <= 0 && r.MinRepTrade <= 0 {
+		return false
+	}
+	return a.RepLaw >= r.MinRepLaw && a.RepTrade >= r.MinRepTrade
+}
+
+func canPay(a *modelpkg.Agent, price map[string]int) bool {
+	if len(price) == 0 {
+		return false
+	}
+	for item, n := range price {
+		if n <= 0 || a.Inventory[item] < n {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]int) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,75 @@
+package permit
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func TestCheckGrantOrder(t *testing.T) {
+	rules := []Rule{
+		{ID: "GUILD_PASS", OrgKinds: []string{"GUILD"}},
+		{ID: "PASS", DurationTicks: 50, Item: "MINE_PERMIT", Price: map[string]int{"IRON_INGOT": 2}},
+	}
+	a := &modelpkg.Agent{ID: "A1", RepLaw: 500, Inventory: map[string]int{"MINE_PERMIT": 1, "IRON_INGOT": 3}}
+
+	g, d := Check(a, &modelpkg.Organization{OrgID: "O1", Kind: modelpkg.OrgGuild}, rules)
+	if d != nil || g.PermitID != "GUILD_PASS" || g.Via != ViaOrg || g.RemainingTicks != 0 {
+		t.Fatalf("org grant: %+v %+v", g, d)
+	}
+	delete(a.Permits, "GUILD_PASS")
+
+	g, d = Check(a, nil, rules)
+	if d != nil || g.Via != ViaItem || g.RemainingTicks != 50 || a.Inventory["MINE_PERMIT"] != 0 {
+		t.Fatalf("item grant: %+v %+v inv=%v", g, d, a.Inventory)
+	}
+	g, d = Check(a, nil, rules)
+	if d != nil || g.Via != ViaHeld || a.Inventory["IRON_INGOT"] != 3 {
+		t.Fatalf("held grant: %+v %+v inv=%v", g, d, a.Inventory)
+	}
+
+	delete(a.Permits, "PASS")
+	g, d = Check(a, nil, rules)
+	if d != nil || g.Via != ViaPurchase || a.Inventory["IRON_INGOT"] != 1 {
+		t.Fatalf("purchase: %+v %+v inv=%v", g, d, a.Inventory)
+	}
+	delete(a.Permits, "PASS")
+	_, d = Check(a, nil, rules)
+	if d == nil || d.Code != protocol.ErrWorldPermitRequired {
+		t.Fatalf("expected required denial, got %+v", d)
+	}
+}
+
+func TestTickRunsDownGatingPermitsOnly(t *testing.T) {
+	a := &modelpkg.Agent{ID: "A1", Permits: map[string]uint64{"DEEP": 2, "CITY": 2, "FOREVER": 0}}
+	gating := []string{"DEEP", "FOREVER"}
+	if got := Tick(a, gating); len(got) != 0 || a.Permits["DEEP"] != 1 {
+		t.Fatalf("first tick: expired %v permits %v", got, a.Permits)
+	}
+	if got := Tick(a, gating); len(got) != 1 || got[0] != "DEEP" {
+		t.Fatalf("second tick: expired %v", got)
+	}
+	if _, held := a.Permits["DEEP"]; held || a.Permits["CITY"] != 2 {
+		t.Fatalf("permits = %v", a.Permits)
+	}
+	if !Holds(a, gating) {
+		t.Fatalf("never-expiring permit not held")
+	}
+	delete(a.Permits, "FOREVER")
+	if Holds(a, gating) {
+		t.Fatalf("holds without a gating permit")
+	}
+}
+
+func TestCheckReputation(t *testing.T) {
+	rules := []Rule{{ID: "TRUSTED", MinRepLaw: 600, MinRepTrade: 400}}
+	a := &modelpkg.Agent{ID: "A1", RepLaw: 550, RepTrade: 500}
+	if _, d := Check(a, nil, rules); d == nil {
+		t.Fatalf("expected denial below threshold")
+	}
+	a.RepLaw = 600
+	if g, d := Check(a, nil, rules); d != nil || g.Via != ViaRep {
+		t.Fatalf("rep grant: %+v %+v", g, d)
+	}
+}
@@ -14,6 +14,7 @@ func BuildIncomingAgent(t AgentTransfer, worldID string) *modelpkg.Agent {
 		OrgID:                        t.OrgID,
 		CurrentWorldID:               worldID,
 		WorldSwitchCooldownUntilTick: t.WorldSwitchCooldownUntilTick,
+		Permits:                      copyPermits(t.Permits),
 		Pos:                          t.Pos,
 		Yaw:                          t.Yaw,
 		HP:                           t.HP,
@@ -29,10 +30,11 @@ func BuildIncomingAgent(t AgentTransfer, worldID string) *modelpkg.Agent {
 		Memory: transfermapspkg.CopyMap(t.Memory, func(k string, _ modelpkg.MemoryEntry) bool {
 			return k != ""
 		}),
+		View:     t.View.Clone(),
+		Triggers: modelpkg.CloneTriggers(t.Triggers),
 	}
-	if a.Pos.Y != 0 {
-		a.Pos.Y = 0
-	}
+	// The destination world puts the agent on its own surface.
+	a.Pos.Y = 0
 	if a.OrgID == "" && t.Org != nil && t.Org.OrgID != "" {
 		a.OrgID = t.Org.OrgID
 	}
@@ -56,6 +58,7 @@ func BuildOutgoingAgent(a *modelpkg.Agent, worldID string, org *OrgTransfer) Age
 		FromWorldID:                  worldID,
 		CurrentWorldID:               a.CurrentWorldID,
 		WorldSwitchCooldownUntilTick: a.WorldSwitchCooldownUntilTick,
+		Permits:                      copyPermits(a.Permits),
 		Pos:                          a.Pos,
 		Yaw:                          a.Yaw,
 		HP:                           a.HP,
@@ -69,6 +72,8 @@ func BuildOutgoingAgent(a *modelpkg.Agent, worldID string, org *OrgTransfer) Age
 		Inventory:                    inv,
 		Equipment:                    a.Equipment,
 		Memory:                       mem,
+		View:                         a.View.Clone(),
+		Triggers:                     modelpkg.CloneTriggers(a.Triggers),
 	}
 }
 
@@ -98,3 +103,16 @@ func BuildOrgTransferFromOrganization(org *modelpkg.Organization) *OrgTransfer {
 		Members:     members,
 	}
 }
+
+func copyPermits(m map[string]uint64) map[string]uint64 {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]uint64, len(m))
+	for id, exp := range m {
+		if id != "" {
+			out[id] = exp
+		}
+	}
+	return out
+}
@@ -68,3 +68,22 @@ func UpsertIncomingOrg(
 	}
 	return org
 }
+
+// incomingOrg is the org the incoming agent belongs to, for admission checks made before
+// UpsertIncomingOrg records the agent as a member.
+func incomingOrg(orgs map[string]*modelpkg.Organization, incoming *OrgTransfer, fallbackOrgID string) *modelpkg.Organization {
+	orgID := fallbackOrgID
+	if incoming != nil && incoming.OrgID != "" {
+		orgID = incoming.OrgID
+	}
+	if orgID == "" {
+		return nil
+	}
+	if org := orgs[orgID]; org != nil {
+		return org
+	}
+	if incoming != nil {
+		return &modelpkg.Organization{OrgID: incoming.OrgID, Kind: incoming.Kind}
+	}
+	return nil
+}
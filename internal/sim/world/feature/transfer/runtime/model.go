@@ -13,6 +13,7 @@ type AgentTransfer struct {
 	FromEntryPointID             string
 	ToEntryPointID               string
 	WorldSwitchCooldownUntilTick uint64
+	Permits                      map[string]uint64
 
 	Pos modelpkg.Vec3i
 	Yaw int
@@ -30,6 +31,8 @@ type AgentTransfer struct {
 	Inventory map[string]int
 	Equipment modelpkg.Equipment
 	Memory    map[string]modelpkg.MemoryEntry
+	View      modelpkg.View
+	Triggers  []modelpkg.Trigger
 }
 
 type OrgTransfer struct {
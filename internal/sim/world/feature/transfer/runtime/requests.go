@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	orgpkg "voxelcraft.ai/internal/sim/world/feature/transfer/org"
+	permitpkg "voxelcraft.ai/internal/sim/world/feature/transfer/permit"
 )
 
 type TransferOutReq struct {
@@ -17,15 +18,30 @@ type TransferOutResp struct {
 	Err      string
 }
 
+// DeniedError is a transfer refused by a rule (e.g. a missing permit); the agent stays put.
+type DeniedError struct {
+	Code    string
+	Message string
+}
+
+func (e *DeniedError) Error() string { return e.Message }
+
 type TransferInReq struct {
-	Transfer    AgentTransfer
-	Out         chan []byte
-	DeltaVoxels bool
-	Resp        chan TransferInResp
+	Transfer AgentTransfer
+	// RequirePermit gates the transfer on one of Permits (checked and charged in-tick by the
+	// destination world, so a refused agent is handed back unchanged).
+	RequirePermit bool
+	Permits       []permitpkg.Rule
+	Out           chan []byte
+	DeltaVoxels   bool
+	Encoding      string
+	Resp          chan TransferInResp
 }
 
 type TransferInResp struct {
-	Err string
+	Permit permitpkg.Grant
+	Err    string
+	Code   string
 }
 
 type AgentPosReq struct {
@@ -80,29 +96,38 @@ func RequestTransferOut(ctx context.Context, ch chan<- TransferOutReq, agentID s
 	}
 }
 
-func RequestTransferIn(ctx context.Context, ch chan<- TransferInReq, t AgentTransfer, out chan []byte, delta bool) error {
+func RequestTransferIn(ctx context.Context, ch chan<- TransferInReq, t AgentTransfer, out chan []byte, delta bool, encoding string) error {
+	_, err := sendTransferIn(ctx, ch, TransferInReq{Transfer: t, Out: out, DeltaVoxels: delta, Encoding: encoding})
+	return err
+}
+
+// RequestPermitTransferIn admits the agent only if one of permits admits it.
+func RequestPermitTransferIn(ctx context.Context, ch chan<- TransferInReq, t AgentTransfer, permits []permitpkg.Rule, out chan []byte, delta bool, encoding string) (permitpkg.Grant, error) {
+	r, err := sendTransferIn(ctx, ch, TransferInReq{Transfer: t, RequirePermit: true, Permits: permits, Out: out, DeltaVoxels: delta, Encoding: encoding})
+	return r.Permit, err
+}
+
+func sendTransferIn(ctx context.Context, ch chan<- TransferInReq, req TransferInReq) (TransferInResp, error) {
 	if ch == nil {
-		return errors.New("transfer in not available")
-	}
-	req := TransferInReq{
-		Transfer:    t,
-		Out:         out,
-		DeltaVoxels: delta,
-		Resp:        make(chan TransferInResp, 1),
+		return TransferInResp{}, errors.New("transfer in not available")
 	}
+	req.Resp = make(chan TransferInResp, 1)
 	select {
 	case ch <- req:
 	case <-ctx.Done():
-		return ctx.Err()
+		return TransferInResp{}, ctx.Err()
 	}
 	select {
 	case r := <-req.Resp:
+		if r.Code != "" {
+			return TransferInResp{}, &DeniedError{Code: r.Code, Message: r.Err}
+		}
 		if r.Err != "" {
-			return errors.New(r.Err)
+			return TransferInResp{}, errors.New(r.Err)
 		}
-		return nil
+		return r, nil
 	case <-ctx.Done():
-		return ctx.Err()
+		return TransferInResp{}, ctx.Err()
 	}
 }
 
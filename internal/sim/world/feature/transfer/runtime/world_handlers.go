@@ -2,6 +2,7 @@ package runtime
 
 import (
 	transferagentpkg "voxelcraft.ai/internal/sim/world/feature/transfer/agent"
+	permitpkg "voxelcraft.ai/internal/sim/world/feature/transfer/permit"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 	idspkg "voxelcraft.ai/internal/sim/world/logic/ids"
 )
@@ -17,6 +18,8 @@ type TransferInHandleInput struct {
 
 type TransferInHandleOutput struct {
 	Err                string
+	Code               string
+	Permit             permitpkg.Grant
 	JoinedAgentID      string
 	NextOrg            uint64
 	WorldSwitchApplied bool
@@ -35,6 +38,15 @@ func HandleTransferIn(input TransferInHandleInput) TransferInHandleOutput {
 	}
 
 	a := BuildIncomingAgent(t, input.WorldID)
+	if input.Req.RequirePermit {
+		// The incoming agent is a copy: a refusal leaves the transfer (and its inventory) as it was.
+		grant, denied := permitpkg.Check(a, incomingOrg(input.Orgs, t.Org, a.OrgID), input.Req.Permits)
+		if denied != nil {
+			out.Err, out.Code = denied.Message, denied.Code
+			return out
+		}
+		out.Permit = grant
+	}
 	if a.OrgID != "" {
 		orgID := a.OrgID
 		if t.Org != nil && t.Org.OrgID != "" {
@@ -81,9 +93,9 @@ func HandleTransferOut(input TransferOutHandleInput) TransferOutHandleOutput {
 		out.Err = "agent not found"
 		return out
 	}
-
 	a.MoveTask = nil
 	a.WorkTask = nil
+	a.TaskQueue = nil
 
 	var orgTransfer *OrgTransfer
 	if a.OrgID != "" {
@@ -7,12 +7,15 @@ const (
 	ToolFamilyPickaxe
 	ToolFamilyAxe
 	ToolFamilyShovel
+	ToolFamilyHoe
 )
 
 func MineToolFamilyForBlock(blockName string) ToolFamily {
 	switch blockName {
-	case "DIRT", "GRASS", "SAND", "GRAVEL":
+	case "DIRT", "GRASS", "SAND", "GRAVEL", "FARMLAND":
 		return ToolFamilyShovel
+	case "WHEAT_CROP", "CARROT_CROP":
+		return ToolFamilyHoe
 	case "LOG", "PLANK":
 		return ToolFamilyAxe
 	default:
@@ -55,6 +58,16 @@ func BestToolTier(inv map[string]int, family ToolFamily) int {
 		if inv["WOOD_SHOVEL"] > 0 {
 			return 1
 		}
+	case ToolFamilyHoe:
+		if inv["IRON_HOE"] > 0 {
+			return 3
+		}
+		if inv["STONE_HOE"] > 0 {
+			return 2
+		}
+		if inv["WOOD_HOE"] > 0 {
+			return 1
+		}
 	}
 	return 0
 }
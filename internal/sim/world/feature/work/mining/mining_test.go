@@ -45,3 +45,12 @@ func TestMineParamsForTier(t *testing.T) {
 		t.Fatalf("tier3 mismatch: got work=%d cost=%d", work, cost)
 	}
 }
+
+func TestHoeFamily(t *testing.T) {
+	if got := MineToolFamilyForBlock("WHEAT_CROP"); got != ToolFamilyHoe {
+		t.Fatalf("expected hoe family for crops, got %v", got)
+	}
+	if got := BestToolTier(map[string]int{"WOOD_HOE": 1, "STONE_HOE": 1}, ToolFamilyHoe); got != 2 {
+		t.Fatalf("expected stone hoe tier (2), got %d", got)
+	}
+}
@@ -9,6 +9,11 @@ func MineProgress(workTicks int, blockName string, inventory map[string]int) flo
 	return TimedProgress(workTicks, workNeeded)
 }
 
+func TillProgress(workTicks int, inventory map[string]int) float64 {
+	workNeeded, _ := miningpkg.MineParamsForTier(miningpkg.BestToolTier(inventory, miningpkg.ToolFamilyHoe))
+	return TimedProgress(workTicks, workNeeded)
+}
+
 func TimedProgress(workTicks int, totalTicks int) float64 {
 	if totalTicks <= 0 {
 		return 0
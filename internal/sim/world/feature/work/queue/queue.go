@@ -0,0 +1,223 @@
+// Package queue runs per-agent task queues: ENQUEUE'd TaskReqs start in order, one at
+// a time, once the previous queued task ended and the slot it needs is free.
+package queue
+
+import (
+	"fmt"
+
+	"voxelcraft.ai/internal/protocol"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+const (
+	ModeEnqueue = "ENQUEUE"
+
+	OnFailSkip  = "SKIP"
+	OnFailAbort = "ABORT"
+	OnFailRetry = "RETRY"
+
+	// MaxRetries bounds on_fail=RETRY.
+	MaxRetries = 10
+)
+
+type ActionResultFn func(tick uint64, ref string, ok bool, code string, message string) protocol.Event
+
+type Env struct {
+	// Start applies req as if it arrived in an ACT (emits its ACTION_RESULT).
+	Start func(a *modelpkg.Agent, req protocol.TaskReq, nowTick uint64)
+	// Enqueueable reports task types that may be queued.
+	Enqueueable func(typ string) bool
+}
+
+// Slot is the task slot a request type occupies.
+type Slot int
+
+const (
+	SlotNone Slot = iota // completes on start (CLAIM_LAND)
+	SlotMove
+	SlotWork
+)
+
+func SlotFor(typ string) Slot {
+	switch typ {
+	case "MOVE_TO", "FOLLOW":
+		return SlotMove
+	case "CLAIM_LAND":
+		return SlotNone
+	}
+	return SlotWork
+}
+
+// HandleEnqueue validates and appends tr, then starts it if the queue was idle.
+func HandleEnqueue(env Env, ar ActionResultFn, a *modelpkg.Agent, tr protocol.TaskReq, nowTick uint64, depth int) {
+	if tr.Queue != ModeEnqueue {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_BAD_REQUEST", "unknown queue mode"))
+		return
+	}
+	if tr.ID == "" {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_BAD_REQUEST", "queued task needs id"))
+		return
+	}
+	if env.Enqueueable == nil || !env.Enqueueable(tr.Type) {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_BAD_REQUEST", "task type cannot be queued"))
+		return
+	}
+	switch tr.OnFail {
+	case "", OnFailSkip, OnFailAbort, OnFailRetry:
+	default:
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_BAD_REQUEST", "bad on_fail"))
+		return
+	}
+	if tr.Retries < 0 || tr.Retries > MaxRetries {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_BAD_REQUEST", fmt.Sprintf("retries must be in 0..%d", MaxRetries)))
+		return
+	}
+	if len(a.TaskQueue) >= depth {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_CONFLICT", "task queue full"))
+		return
+	}
+	for _, q := range a.TaskQueue {
+		if q.Req.ID == tr.ID {
+			a.AddEvent(ar(nowTick, tr.ID, false, "E_CONFLICT", "duplicate queued id"))
+			return
+		}
+	}
+	tr.Queue = ""
+	a.TaskQueue = append(a.TaskQueue, modelpkg.QueuedTask{Req: tr})
+	a.AddEvent(ar(nowTick, tr.ID, true, "", "queued"))
+	Pump(env, a, nowTick)
+}
+
+// Clear drops queued entries; a running head keeps running as a plain task.
+func Clear(a *modelpkg.Agent) int {
+	n := 0
+	for _, q := range a.TaskQueue {
+		if q.TaskID == "" {
+			n++
+		}
+	}
+	a.TaskQueue = nil
+	return n
+}
+
+// Cancel removes a not-yet-started entry by its request id.
+func Cancel(a *modelpkg.Agent, ref string) bool {
+	for i, q := range a.TaskQueue {
+		if q.Req.ID == ref && q.TaskID == "" {
+			a.TaskQueue = append(a.TaskQueue[:i], a.TaskQueue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Pump advances the queue: it settles an ended head and starts entries until one is
+// running, waiting on a busy slot, or scheduled for retry next tick.
+func Pump(env Env, a *modelpkg.Agent, nowTick uint64) {
+	for len(a.TaskQueue) > 0 {
+		head := &a.TaskQueue[0]
+		if head.TaskID != "" {
+			if running(a, head.TaskID) {
+				return
+			}
+			failed, code := outcome(head)
+			head.TaskID, head.EndType, head.EndCode = "", "", ""
+			if !failed {
+				a.TaskQueue = a.TaskQueue[1:]
+				continue
+			}
+			if !onFail(a, code, nowTick) {
+				return
+			}
+			continue
+		}
+
+		if nowTick < head.NotBefore || !slotFree(a, SlotFor(head.Req.Type)) {
+			return
+		}
+		req := head.Req
+		mark := len(a.Events)
+		env.Start(a, req, nowTick)
+		ok, taskID, code := startResult(a.Events[mark:], req.ID)
+		switch {
+		case ok && taskID != "":
+			a.TaskQueue[0].TaskID = taskID
+			return
+		case ok:
+			a.TaskQueue = a.TaskQueue[1:]
+		default:
+			if !onFail(a, code, nowTick) {
+				return
+			}
+		}
+	}
+	a.TaskQueue = nil
+}
+
+// onFail applies the head's on_fail policy and reports whether pumping may continue
+// this tick (retries wait for the next tick).
+func onFail(a *modelpkg.Agent, code string, nowTick uint64) bool {
+	head := &a.TaskQueue[0]
+	switch head.Req.OnFail {
+	case OnFailRetry:
+		if head.Attempts < head.Req.Retries {
+			head.Attempts++
+			head.NotBefore = nowTick + 1
+			return false
+		}
+		abort(a, head.Req.ID, code, nowTick)
+		return false
+	case OnFailAbort:
+		abort(a, head.Req.ID, code, nowTick)
+		return false
+	default:
+		a.TaskQueue = a.TaskQueue[1:]
+		return true
+	}
+}
+
+func abort(a *modelpkg.Agent, ref, code string, nowTick uint64) {
+	dropped := len(a.TaskQueue)
+	a.TaskQueue = nil
+	e := protocol.Event{"t": nowTick, "type": "TASK_QUEUE_ABORT", "ref": ref, "dropped": dropped}
+	if code != "" {
+		e["code"] = code
+	}
+	a.AddEvent(e)
+}
+
+func slotFree(a *modelpkg.Agent, s Slot) bool {
+	switch s {
+	case SlotMove:
+		return a.MoveTask == nil
+	case SlotWork:
+		return a.WorkTask == nil
+	}
+	return true
+}
+
+func running(a *modelpkg.Agent, taskID string) bool {
+	return (a.MoveTask != nil && a.MoveTask.TaskID == taskID) || (a.WorkTask != nil && a.WorkTask.TaskID == taskID)
+}
+
+// outcome is how a head that left its slot ended, as recorded on the entry when its
+// TASK_DONE/TASK_FAIL was emitted; canceled/stopped tasks count as done.
+func outcome(head *modelpkg.QueuedTask) (failed bool, code string) {
+	if head.EndType != "TASK_FAIL" {
+		return false, ""
+	}
+	return true, head.EndCode
+}
+
+func startResult(events []protocol.Event, ref string) (ok bool, taskID, code string) {
+	for _, e := range events {
+		if e["type"] != "ACTION_RESULT" || e["ref"] != ref {
+			continue
+		}
+		ok, _ = e["ok"].(bool)
+		taskID, _ = e["task_id"].(string)
+		code, _ = e["code"].(string)
+		return ok, taskID, code
+	}
+	return false, "", "E_INTERNAL"
+}
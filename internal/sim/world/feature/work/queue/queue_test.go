@@ -0,0 +1,148 @@
+package queue
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/tasks"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+func testAR(tick uint64, ref string, ok bool, code string, message string) protocol.Event {
+	e := protocol.Event{"t": tick, "type": "ACTION_RESULT", "ref": ref, "ok": ok}
+	if code != "" {
+		e["code"] = code
+	}
+	return e
+}
+
+// testEnv starts work tasks unless the request's recipe is "bad".
+func testEnv() (Env, *int) {
+	n := 0
+	return Env{
+		Start: func(a *modelpkg.Agent, req protocol.TaskReq, nowTick uint64) {
+			if req.RecipeID == "bad" {
+				a.AddEvent(testAR(nowTick, req.ID, false, "E_INVALID_TARGET", "bad"))
+				return
+			}
+			n++
+			id := "T" + string(rune('0'+n))
+			a.WorkTask = &tasks.WorkTask{TaskID: id, Kind: tasks.Kind(req.Type)}
+			a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": req.ID, "ok": true, "task_id": id})
+		},
+		Enqueueable: func(typ string) bool { return typ != "STOP" },
+	}, &n
+}
+
+func finish(a *modelpkg.Agent, typ string, nowTick uint64) {
+	id := a.WorkTask.TaskID
+	a.WorkTask = nil
+	a.AddEvent(protocol.Event{"t": nowTick, "type": typ, "task_id": id})
+}
+
+func TestQueueRunsInOrder(t *testing.T) {
+	env, started := testEnv()
+	a := &modelpkg.Agent{ID: "A1"}
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "q1", Type: "CRAFT", Queue: ModeEnqueue}, 1, 4)
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "q2", Type: "CRAFT", Queue: ModeEnqueue}, 1, 4)
+	if *started != 1 || len(a.TaskQueue) != 2 || a.TaskQueue[0].TaskID != "T1" {
+		t.Fatalf("expected head running and second queued, got %+v", a.TaskQueue)
+	}
+
+	Pump(env, a, 2)
+	if *started != 1 {
+		t.Fatalf("second task must wait for the head")
+	}
+	finish(a, "TASK_DONE", 3)
+	Pump(env, a, 3)
+	if *started != 2 || len(a.TaskQueue) != 1 || a.TaskQueue[0].Req.ID != "q2" {
+		t.Fatalf("expected q2 running, got %+v", a.TaskQueue)
+	}
+	finish(a, "TASK_DONE", 4)
+	Pump(env, a, 4)
+	if a.TaskQueue != nil {
+		t.Fatalf("expected empty queue, got %+v", a.TaskQueue)
+	}
+}
+
+func TestQueueOnFail(t *testing.T) {
+	env, _ := testEnv()
+
+	// SKIP (default): a failed start moves on.
+	a := &modelpkg.Agent{ID: "A1"}
+	a.WorkTask = &tasks.WorkTask{TaskID: "X"}
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "q1", Type: "CRAFT", RecipeID: "bad", Queue: ModeEnqueue}, 1, 4)
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "q2", Type: "CRAFT", Queue: ModeEnqueue}, 1, 4)
+	a.WorkTask = nil
+	Pump(env, a, 2)
+	if len(a.TaskQueue) != 1 || a.TaskQueue[0].Req.ID != "q2" || a.TaskQueue[0].TaskID == "" {
+		t.Fatalf("expected q1 skipped and q2 running, got %+v", a.TaskQueue)
+	}
+
+	// RETRY: the failed task restarts next tick, then the queue aborts.
+	a = &modelpkg.Agent{ID: "A2"}
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "r", Type: "CRAFT", Queue: ModeEnqueue, OnFail: OnFailRetry, Retries: 1}, 1, 4)
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "n", Type: "CRAFT", Queue: ModeEnqueue}, 1, 4)
+	finish(a, "TASK_FAIL", 2)
+	Pump(env, a, 2)
+	if a.WorkTask != nil || a.TaskQueue[0].Attempts != 1 {
+		t.Fatalf("expected retry scheduled, got %+v", a.TaskQueue)
+	}
+	Pump(env, a, 3)
+	if a.WorkTask == nil || a.TaskQueue[0].Req.ID != "r" {
+		t.Fatalf("expected retry started, got %+v", a.TaskQueue)
+	}
+	finish(a, "TASK_FAIL", 4)
+	Pump(env, a, 4)
+	if a.TaskQueue != nil || a.Events[len(a.Events)-1]["type"] != "TASK_QUEUE_ABORT" {
+		t.Fatalf("expected queue aborted after retries, got %+v", a.TaskQueue)
+	}
+}
+
+func TestQueueOutcomeSurvivesEventLogLoss(t *testing.T) {
+	env, _ := testEnv()
+	a := &modelpkg.Agent{ID: "A1"}
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "x", Type: "CRAFT", Queue: ModeEnqueue, OnFail: OnFailAbort}, 1, 4)
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "y", Type: "CRAFT", Queue: ModeEnqueue}, 1, 4)
+	finish(a, "TASK_FAIL", 2)
+	if a.TaskQueue[0].EndType != "TASK_FAIL" {
+		t.Fatalf("end not recorded on the head: %+v", a.TaskQueue[0])
+	}
+	// A snapshot restore (or the 4096-entry cap) loses the event log.
+	a.Events, a.EventLog = nil, nil
+	Pump(env, a, 2)
+	if a.TaskQueue != nil {
+		t.Fatalf("expected abort from the recorded failure, got %+v", a.TaskQueue)
+	}
+}
+
+func TestEnqueueValidation(t *testing.T) {
+	env, _ := testEnv()
+	a := &modelpkg.Agent{ID: "A1"}
+	a.WorkTask = &tasks.WorkTask{TaskID: "X"}
+	for _, tr := range []protocol.TaskReq{
+		{ID: "", Type: "CRAFT", Queue: ModeEnqueue},
+		{ID: "s", Type: "STOP", Queue: ModeEnqueue},
+		{ID: "m", Type: "CRAFT", Queue: "LATER"},
+		{ID: "f", Type: "CRAFT", Queue: ModeEnqueue, OnFail: "PANIC"},
+		{ID: "r", Type: "CRAFT", Queue: ModeEnqueue, Retries: MaxRetries + 1},
+	} {
+		HandleEnqueue(env, testAR, a, tr, 1, 2)
+		if ev := a.Events[len(a.Events)-1]; ev["ok"] != false {
+			t.Fatalf("expected %+v rejected", tr)
+		}
+	}
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "a", Type: "CRAFT", Queue: ModeEnqueue}, 1, 2)
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "a", Type: "CRAFT", Queue: ModeEnqueue}, 1, 2)
+	if ev := a.Events[len(a.Events)-1]; ev["code"] != "E_CONFLICT" {
+		t.Fatalf("expected duplicate id rejected, got %v", ev)
+	}
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "b", Type: "CRAFT", Queue: ModeEnqueue}, 1, 2)
+	HandleEnqueue(env, testAR, a, protocol.TaskReq{ID: "c", Type: "CRAFT", Queue: ModeEnqueue}, 1, 2)
+	if ev := a.Events[len(a.Events)-1]; ev["code"] != "E_CONFLICT" || len(a.TaskQueue) != 2 {
+		t.Fatalf("expected depth enforced, got %v", ev)
+	}
+	if !Cancel(a, "b") || len(a.TaskQueue) != 1 || Clear(a) != 1 || a.TaskQueue != nil {
+		t.Fatalf("cancel/clear failed: %+v", a.TaskQueue)
+	}
+}
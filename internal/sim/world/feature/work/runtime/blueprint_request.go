@@ -10,6 +10,7 @@ import (
 type BlueprintRequestEnv interface {
 	NewTaskID() string
 	BlueprintExists(blueprintID string) bool
+	WorldHeight() int
 }
 
 func HandleTaskBuildBlueprint(env BlueprintRequestEnv, ar ActionResultFn, a *modelpkg.Agent, tr protocol.TaskReq, nowTick uint64, allowBuild bool) {
@@ -25,8 +26,8 @@ func HandleTaskBuildBlueprint(env BlueprintRequestEnv, ar ActionResultFn, a *mod
 		a.AddEvent(ar(nowTick, tr.ID, false, "E_BAD_REQUEST", "missing blueprint_id"))
 		return
 	}
-	if tr.Anchor[1] != 0 {
-		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "2D world requires y==0"))
+	if !validY(tr.Anchor[1], env.WorldHeight()) {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "y out of range"))
 		return
 	}
 	if env == nil || !env.BlueprintExists(tr.BlueprintID) {
@@ -38,7 +39,7 @@ func HandleTaskBuildBlueprint(env BlueprintRequestEnv, ar ActionResultFn, a *mod
 		TaskID:      taskID,
 		Kind:        tasks.KindBuildBlueprint,
 		BlueprintID: tr.BlueprintID,
-		Anchor:      tasks.Vec3i{X: tr.Anchor[0], Y: 0, Z: tr.Anchor[2]},
+		Anchor:      tasks.Vec3i{X: tr.Anchor[0], Y: tr.Anchor[1], Z: tr.Anchor[2]},
 		Rotation:    blueprint.NormalizeRotation(tr.Rotation),
 		BuildIndex:  0,
 		StartedTick: nowTick,
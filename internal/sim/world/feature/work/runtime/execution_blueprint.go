@@ -6,6 +6,8 @@ import (
 	"voxelcraft.ai/internal/protocol"
 	"voxelcraft.ai/internal/sim/catalogs"
 	"voxelcraft.ai/internal/sim/tasks"
+	machinepkg "voxelcraft.ai/internal/sim/world/feature/automation/machine"
+	shoppkg "voxelcraft.ai/internal/sim/world/feature/economy/shop"
 	limitspkg "voxelcraft.ai/internal/sim/world/feature/work/limits"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 	"voxelcraft.ai/internal/sim/world/logic/blueprint"
@@ -24,6 +26,8 @@ type WorkExecBlueprintEnv interface {
 	SetBlock(pos modelpkg.Vec3i, blockID uint16)
 	AuditSetBlock(nowTick uint64, actor string, pos modelpkg.Vec3i, from uint16, to uint16, reason string)
 	EnsureContainerForPlacedBlock(pos modelpkg.Vec3i, blockName string)
+	SetMachinePlacer(pos modelpkg.Vec3i, agentID string, yaw int)
+	SetShopOwner(pos modelpkg.Vec3i, agentID string)
 }
 
 type BlueprintTickResult struct {
@@ -151,6 +155,13 @@ func TickBuildBlueprint(env WorkExecBlueprintEnv, a *modelpkg.Agent, wt *tasks.W
 		env.SetBlock(pos, bid)
 		env.AuditSetBlock(nowTick, a.ID, pos, env.AirBlockID(), bid, "BUILD_BLUEPRINT")
 		env.EnsureContainerForPlacedBlock(pos, p.Block)
+		if machinepkg.Faced(p.Block) {
+			// Blueprint hoppers keep facing +X (yaw 90); the builder owns them.
+			env.SetMachinePlacer(pos, a.ID, 90)
+		}
+		if p.Block == shoppkg.Block {
+			env.SetShopOwner(pos, a.ID)
+		}
 
 		wt.BuildIndex++
 		placed++
@@ -0,0 +1,106 @@
+package runtime
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/tasks"
+	farmingpkg "voxelcraft.ai/internal/sim/world/feature/survival/farming"
+	miningpkg "voxelcraft.ai/internal/sim/world/feature/work/mining"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
+)
+
+type WorkExecTillEnv interface {
+	CanBuildAt(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool
+	EnforceLaw(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool
+	RecordDenied(nowTick uint64)
+	BumpRepLaw(agentID string, delta int)
+
+	BlockAt(pos modelpkg.Vec3i) uint16
+	BlockName(blockID uint16) string
+	BlockIDByName(blockName string) (uint16, bool)
+	SetBlock(pos modelpkg.Vec3i, blockID uint16)
+	AuditSetBlock(nowTick uint64, actor string, pos modelpkg.Vec3i, from uint16, to uint16, reason string)
+	SpawnItemEntity(nowTick uint64, actor string, pos modelpkg.Vec3i, item string, count int, reason string) string
+}
+
+// TickTill works DIRT/GRASS into FARMLAND (grass also drops seeds); the best hoe sets speed and stamina cost
+// like a mining tool.
+func TickTill(env WorkExecTillEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTick uint64) {
+	pos := modelpkg.Vec3i{X: wt.BlockPos.X, Y: wt.BlockPos.Y, Z: wt.BlockPos.Z}
+	if modelpkg.Manhattan(a.Pos, pos) > 2 {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_INVALID_TARGET", "message": "too far"})
+		return
+	}
+	if !env.CanBuildAt(a.ID, pos, nowTick) {
+		env.EnforceLaw(a, pos, rules.ActionBuild, nil, nowTick)
+		failTillDenied(env, a, wt, nowTick)
+		return
+	}
+	b := env.BlockAt(pos)
+	if !farmingpkg.Tillable(env.BlockName(b)) {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_INVALID_TARGET", "message": "block not tillable"})
+		return
+	}
+	tier := miningpkg.BestToolTier(a.Inventory, miningpkg.ToolFamilyHoe)
+	if tier == 0 {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_NO_RESOURCE", "message": "need a hoe"})
+		return
+	}
+	workNeeded, cost := miningpkg.MineParamsForTier(tier)
+	if a.StaminaMilli < cost {
+		return
+	}
+	a.StaminaMilli -= cost
+	wt.WorkTicks++
+	if wt.WorkTicks < workNeeded {
+		return
+	}
+
+	if !env.EnforceLaw(a, pos, rules.ActionBuild, nil, nowTick) {
+		failTillDenied(env, a, wt, nowTick)
+		return
+	}
+	farmland, ok := env.BlockIDByName(farmingpkg.FarmlandBlock)
+	if !ok {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_INTERNAL", "message": "no farmland block"})
+		return
+	}
+	env.SetBlock(pos, farmland)
+	env.AuditSetBlock(nowTick, a.ID, pos, b, farmland, "TILL")
+	for _, d := range farmingpkg.TillDrops(env.BlockName(b)) {
+		_ = env.SpawnItemEntity(nowTick, a.ID, pos, d.Item, d.Count, "TILL_DROP")
+	}
+	a.WorkTask = nil
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_DONE", "task_id": wt.TaskID, "kind": string(wt.Kind)})
+}
+
+func failTillDenied(env WorkExecTillEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTick uint64) {
+	a.WorkTask = nil
+	env.BumpRepLaw(a.ID, -1)
+	env.RecordDenied(nowTick)
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_NO_PERMISSION", "message": "build denied"})
+}
+
+// harvestCrop finishes MINE on a crop block: the cell reverts to FARMLAND and the
+// harvest (or the seed of an unripe crop) drops as item entities.
+func harvestCrop(env WorkExecMineEnv, a *modelpkg.Agent, wt *tasks.WorkTask, pos modelpkg.Vec3i, b uint16, blockName string, nowTick uint64) {
+	c, ok := env.TakeCrop(pos)
+	if !ok {
+		c = modelpkg.Crop{Type: blockName}
+	}
+	to := env.AirBlockID()
+	if farmland, ok := env.BlockIDByName(farmingpkg.FarmlandBlock); ok {
+		to = farmland
+	}
+	env.SetBlock(pos, to)
+	env.AuditSetBlock(nowTick, a.ID, pos, b, to, "HARVEST")
+	for _, d := range farmingpkg.HarvestDrops(c) {
+		_ = env.SpawnItemEntity(nowTick, a.ID, pos, d.Item, d.Count, "HARVEST_DROP")
+	}
+	a.WorkTask = nil
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_DONE", "task_id": wt.TaskID, "kind": string(wt.Kind), "ripe": farmingpkg.Ripe(c)})
+}
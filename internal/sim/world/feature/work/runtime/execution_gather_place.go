@@ -3,7 +3,13 @@ package runtime
 import (
 	"voxelcraft.ai/internal/protocol"
 	"voxelcraft.ai/internal/sim/tasks"
+	machinepkg "voxelcraft.ai/internal/sim/world/feature/automation/machine"
+	signalpkg "voxelcraft.ai/internal/sim/world/feature/automation/signal"
+	shoppkg "voxelcraft.ai/internal/sim/world/feature/economy/shop"
+	farmingpkg "voxelcraft.ai/internal/sim/world/feature/survival/farming"
+	waterpkg "voxelcraft.ai/internal/sim/world/feature/survival/water"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
 type WorkExecGatherPlaceEnv interface {
@@ -13,6 +19,7 @@ type WorkExecGatherPlaceEnv interface {
 
 	InBounds(pos modelpkg.Vec3i) bool
 	CanBuildAt(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool
+	EnforceLaw(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool
 	RecordDenied(nowTick uint64)
 	BumpRepLaw(agentID string, delta int)
 	BlockAt(pos modelpkg.Vec3i) uint16
@@ -23,6 +30,10 @@ type WorkExecGatherPlaceEnv interface {
 	AuditSetBlock(nowTick uint64, actor string, pos modelpkg.Vec3i, from uint16, to uint16, reason string)
 	EnsureContainerForPlacedBlock(pos modelpkg.Vec3i, blockName string)
 	EnsureConveyorFromYaw(pos modelpkg.Vec3i, yaw int)
+	SetSignalFacingFromYaw(pos modelpkg.Vec3i, yaw int)
+	SetMachinePlacer(pos modelpkg.Vec3i, agentID string, yaw int)
+	SetShopOwner(pos modelpkg.Vec3i, agentID string)
+	PlantCrop(pos modelpkg.Vec3i, cropType string, nowTick uint64)
 }
 
 func TickGather(env WorkExecGatherPlaceEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTick uint64) {
@@ -66,13 +77,16 @@ func TickPlace(env WorkExecGatherPlaceEnv, a *modelpkg.Agent, wt *tasks.WorkTask
 		return
 	}
 	if !env.CanBuildAt(a.ID, pos, nowTick) {
-		a.WorkTask = nil
-		env.BumpRepLaw(a.ID, -1)
-		env.RecordDenied(nowTick)
-		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_NO_PERMISSION", "message": "build denied"})
+		env.EnforceLaw(a, pos, rules.ActionBuild, placeItems(wt.ItemID), nowTick)
+		failBuildDenied(env, a, wt, nowTick)
 		return
 	}
-	if env.BlockAt(pos) != env.AirBlockID() {
+	// Seeds go onto FARMLAND (the crop replaces it); everything else needs air or
+	// flowing water.
+	from := env.BlockAt(pos)
+	planting := farmingpkg.IsCropBlock(placeBlockName(env, wt.ItemID))
+	replaceable := from == env.AirBlockID() || isFlowingWater(env, from)
+	if !replaceable && !(planting && isFarmland(env, from)) {
 		a.WorkTask = nil
 		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_BLOCKED", "message": "space occupied"})
 		return
@@ -83,9 +97,11 @@ func TickPlace(env WorkExecGatherPlaceEnv, a *modelpkg.Agent, wt *tasks.WorkTask
 		return
 	}
 
-	blockName := wt.ItemID
-	if placeAs, ok := env.ItemPlaceAs(wt.ItemID); ok && placeAs != "" {
-		blockName = placeAs
+	blockName := placeBlockName(env, wt.ItemID)
+	if planting && !isFarmland(env, from) {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_INVALID_TARGET", "message": "crops need farmland"})
+		return
 	}
 	bid, ok := env.BlockIDByName(blockName)
 	if !ok {
@@ -94,14 +110,67 @@ func TickPlace(env WorkExecGatherPlaceEnv, a *modelpkg.Agent, wt *tasks.WorkTask
 		return
 	}
 
+	if !env.EnforceLaw(a, pos, rules.ActionBuild, placeItems(wt.ItemID), nowTick) {
+		failBuildDenied(env, a, wt, nowTick)
+		return
+	}
+
 	a.Inventory[wt.ItemID]--
+	if wt.ItemID == waterpkg.WaterBucketItem {
+		a.Inventory[waterpkg.BucketItem]++
+	}
 	env.SetBlock(pos, bid)
-	env.AuditSetBlock(nowTick, a.ID, pos, env.AirBlockID(), bid, "PLACE")
+	if planting {
+		env.AuditSetBlock(nowTick, a.ID, pos, from, bid, "PLANT")
+		env.PlantCrop(pos, blockName, nowTick)
+	} else {
+		env.AuditSetBlock(nowTick, a.ID, pos, from, bid, "PLACE")
+	}
 	env.EnsureContainerForPlacedBlock(pos, blockName)
 	if blockName == "CONVEYOR" {
 		env.EnsureConveyorFromYaw(pos, a.Yaw)
 	}
+	if signalpkg.Faced(blockName) {
+		env.SetSignalFacingFromYaw(pos, a.Yaw)
+	}
+	if machinepkg.Faced(blockName) {
+		env.SetMachinePlacer(pos, a.ID, a.Yaw)
+	}
+	if blockName == shoppkg.Block {
+		env.SetShopOwner(pos, a.ID)
+	}
 
 	a.WorkTask = nil
 	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_DONE", "task_id": wt.TaskID, "kind": string(wt.Kind)})
 }
+
+func isFarmland(env WorkExecGatherPlaceEnv, b uint16) bool {
+	fl, ok := env.BlockIDByName(farmingpkg.FarmlandBlock)
+	return ok && b == fl
+}
+
+func isFlowingWater(env WorkExecGatherPlaceEnv, b uint16) bool {
+	flow, ok := env.BlockIDByName(waterpkg.FlowBlock)
+	return ok && b == flow
+}
+
+func placeBlockName(env WorkExecGatherPlaceEnv, itemID string) string {
+	if placeAs, ok := env.ItemPlaceAs(itemID); ok && placeAs != "" {
+		return placeAs
+	}
+	return itemID
+}
+
+func placeItems(itemID string) []string {
+	if itemID == "" {
+		return nil
+	}
+	return []string{itemID}
+}
+
+func failBuildDenied(env WorkExecGatherPlaceEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTick uint64) {
+	a.WorkTask = nil
+	env.BumpRepLaw(a.ID, -1)
+	env.RecordDenied(nowTick)
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_NO_PERMISSION", "message": "build denied"})
+}
@@ -4,6 +4,7 @@ import (
 	"voxelcraft.ai/internal/protocol"
 	"voxelcraft.ai/internal/sim/tasks"
 	inventorypkg "voxelcraft.ai/internal/sim/world/feature/economy/inventory"
+	shoppkg "voxelcraft.ai/internal/sim/world/feature/economy/shop"
 	interactpkg "voxelcraft.ai/internal/sim/world/feature/work/interact"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 )
@@ -17,6 +18,8 @@ type WorkExecInteractEnv interface {
 	GetSign(pos modelpkg.Vec3i) *modelpkg.Sign
 	SignIDAt(pos modelpkg.Vec3i) string
 	ContractSummariesForTerminal(pos modelpkg.Vec3i) []map[string]interface{}
+	MarketOrdersForTerminal(pos modelpkg.Vec3i, agentID string) []map[string]interface{}
+	ShopAt(pos modelpkg.Vec3i) *modelpkg.Shop
 	OnContainerOpenedDuringEvent(a *modelpkg.Agent, c *modelpkg.Container, nowTick uint64)
 	CanWithdrawFromContainer(agentID string, pos modelpkg.Vec3i) bool
 	AuditTransfer(nowTick uint64, actorID string, at modelpkg.Vec3i, srcID string, dstID string, item string, count int)
@@ -108,8 +111,17 @@ func TickOpen(env WorkExecInteractEnv, a *modelpkg.Agent, wt *tasks.WorkTask, no
 			ev["owed"] = inventorypkg.EncodeItemPairs(owed)
 		}
 	}
-	if c.Type == "CONTRACT_TERMINAL" {
+	switch c.Type {
+	case "CONTRACT_TERMINAL":
 		ev["contracts"] = env.ContractSummariesForTerminal(c.Pos)
+	case "MARKET_TERMINAL":
+		ev["orders"] = env.MarketOrdersForTerminal(c.Pos, a.ID)
+	case shoppkg.Block:
+		s := env.ShopAt(c.Pos)
+		if s != nil {
+			ev["owner"] = s.Owner
+		}
+		ev["prices"] = shoppkg.Listings(s, c)
 	}
 	a.AddEvent(ev)
 	env.OnContainerOpenedDuringEvent(a, c, nowTick)
@@ -1,19 +1,19 @@
 package runtime
 
 import (
-	"strings"
-
 	"voxelcraft.ai/internal/protocol"
 	"voxelcraft.ai/internal/sim/tasks"
+	shoppkg "voxelcraft.ai/internal/sim/world/feature/economy/shop"
+	farmingpkg "voxelcraft.ai/internal/sim/world/feature/survival/farming"
+	waterpkg "voxelcraft.ai/internal/sim/world/feature/survival/water"
 	miningpkg "voxelcraft.ai/internal/sim/world/feature/work/mining"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
 type WorkExecMineEnv interface {
 	CanBreakAt(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool
-	PermissionsFor(agentID string, pos modelpkg.Vec3i) (*modelpkg.LandClaim, map[string]bool)
-	IsLandMember(agentID string, land *modelpkg.LandClaim) bool
-	TransferToLandOwner(ownerID string, item string, count int)
+	EnforceLaw(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool
 	BumpRepLaw(agentID string, delta int)
 	RecordDenied(nowTick uint64)
 
@@ -26,14 +26,20 @@ type WorkExecMineEnv interface {
 	SpawnItemEntity(nowTick uint64, actor string, pos modelpkg.Vec3i, item string, count int, reason string) string
 
 	GetContainerAt(pos modelpkg.Vec3i) *modelpkg.Container
+	ContainerInUse(pos modelpkg.Vec3i) bool
+	ShopOwnerAt(pos modelpkg.Vec3i) string
 	RemoveContainer(pos modelpkg.Vec3i)
 	RemoveBoard(pos modelpkg.Vec3i)
 	RemoveSign(nowTick uint64, actor string, pos modelpkg.Vec3i, reason string)
 	RemoveConveyor(nowTick uint64, actor string, pos modelpkg.Vec3i, reason string)
 	RemoveSwitch(nowTick uint64, actor string, pos modelpkg.Vec3i, reason string)
+	ClaimPledgedAt(anchor modelpkg.Vec3i) bool
 	RemoveClaimByAnchor(nowTick uint64, actor string, pos modelpkg.Vec3i, reason string)
 
 	OnMinedBlockDuringEvent(a *modelpkg.Agent, pos modelpkg.Vec3i, blockName string, nowTick uint64)
+
+	BlockIDByName(blockName string) (uint16, bool)
+	TakeCrop(pos modelpkg.Vec3i) (modelpkg.Crop, bool)
 }
 
 func TickMine(env WorkExecMineEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTick uint64) {
@@ -44,24 +50,9 @@ func TickMine(env WorkExecMineEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTic
 		return
 	}
 	if !env.CanBreakAt(a.ID, pos, nowTick) {
-		if land, perms := env.PermissionsFor(a.ID, pos); land != nil && !env.IsLandMember(a.ID, land) && !perms["can_break"] &&
-			land.FineBreakEnabled && land.FineBreakPerBlock > 0 && strings.TrimSpace(land.FineBreakItem) != "" {
-			item := strings.TrimSpace(land.FineBreakItem)
-			fine := land.FineBreakPerBlock
-			pay := fine
-			if have := a.Inventory[item]; have < pay {
-				pay = have
-			}
-			if pay > 0 {
-				a.Inventory[item] -= pay
-				env.TransferToLandOwner(land.Owner, item, pay)
-				a.AddEvent(protocol.Event{"t": nowTick, "type": "FINE", "land_id": land.LandID, "item": item, "count": pay, "reason": "BREAK_DENIED"})
-			}
-		}
-		a.WorkTask = nil
-		env.BumpRepLaw(a.ID, -1)
-		env.RecordDenied(nowTick)
-		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_NO_PERMISSION", "message": "break denied"})
+		// Settles fines/reputation of laws that punish denied breaks.
+		env.EnforceLaw(a, pos, rules.ActionBreak, breakItems(env, env.BlockAt(pos)), nowTick)
+		failBreakDenied(env, a, wt, nowTick)
 		return
 	}
 
@@ -72,6 +63,11 @@ func TickMine(env WorkExecMineEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTic
 		return
 	}
 	blockName := env.BlockName(b)
+	if blockName == waterpkg.FlowBlock {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_INVALID_TARGET", "message": "flowing water cannot be mined"})
+		return
+	}
 
 	family := miningpkg.MineToolFamilyForBlock(blockName)
 	tier := miningpkg.BestToolTier(a.Inventory, family)
@@ -86,15 +82,38 @@ func TickMine(env WorkExecMineEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTic
 		return
 	}
 
+	if !env.EnforceLaw(a, pos, rules.ActionBreak, breakItems(env, b), nowTick) {
+		failBreakDenied(env, a, wt, nowTick)
+		return
+	}
+
+	if farmingpkg.IsCropBlock(blockName) {
+		harvestCrop(env, a, wt, pos, b, blockName, nowTick)
+		return
+	}
+	if blockName == waterpkg.SourceBlock && a.Inventory[waterpkg.BucketItem] > 0 {
+		fillBucket(env, a, wt, pos, b, nowTick)
+		return
+	}
 	if blockName != "" {
 		switch blockName {
-		case "CHEST", "FURNACE", "CONTRACT_TERMINAL":
+		case "CHEST", "FURNACE", "AUTO_CRAFTER", "CONTRACT_TERMINAL", "MARKET_TERMINAL", shoppkg.Block:
 			c := env.GetContainerAt(pos)
+			if owner := env.ShopOwnerAt(pos); c != nil && owner != "" && owner != a.ID {
+				a.WorkTask = nil
+				a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_NO_PERMISSION", "message": "not your shop"})
+				return
+			}
 			if c != nil && len(c.Reserved) > 0 {
 				a.WorkTask = nil
 				a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_BLOCKED", "message": "container has reserved items"})
 				return
 			}
+			if c != nil && env.ContainerInUse(pos) {
+				a.WorkTask = nil
+				a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_BLOCKED", "message": "container in use by a contract"})
+				return
+			}
 			if c != nil {
 				for item, n := range c.Inventory {
 					if n > 0 {
@@ -122,6 +141,12 @@ func TickMine(env WorkExecMineEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTic
 		case "SWITCH":
 			env.RemoveSwitch(nowTick, a.ID, pos, "MINE")
 		case "CLAIM_TOTEM":
+			// The lender takes pledged land on default, so it must outlive the loan.
+			if env.ClaimPledgedAt(pos) {
+				a.WorkTask = nil
+				a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_CONFLICT", "message": "land is pledged as loan collateral"})
+				return
+			}
 			env.RemoveClaimByAnchor(nowTick, a.ID, pos, "MINE")
 		}
 	}
@@ -137,3 +162,17 @@ func TickMine(env WorkExecMineEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTic
 	a.WorkTask = nil
 	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_DONE", "task_id": wt.TaskID, "kind": string(wt.Kind)})
 }
+
+func breakItems(env WorkExecMineEnv, b uint16) []string {
+	if item := env.BlockIDToItem(b); item != "" {
+		return []string{item}
+	}
+	return nil
+}
+
+func failBreakDenied(env WorkExecMineEnv, a *modelpkg.Agent, wt *tasks.WorkTask, nowTick uint64) {
+	a.WorkTask = nil
+	env.BumpRepLaw(a.ID, -1)
+	env.RecordDenied(nowTick)
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": "E_NO_PERMISSION", "message": "break denied"})
+}
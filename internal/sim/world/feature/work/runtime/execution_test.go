@@ -79,6 +79,10 @@ func (s stubInteractExecEnv) SignIDAt(modelpkg.Vec3i) string { return "" }
 func (s stubInteractExecEnv) ContractSummariesForTerminal(modelpkg.Vec3i) []map[string]interface{} {
 	return nil
 }
+func (s stubInteractExecEnv) MarketOrdersForTerminal(modelpkg.Vec3i, string) []map[string]interface{} {
+	return nil
+}
+func (s stubInteractExecEnv) ShopAt(modelpkg.Vec3i) *modelpkg.Shop { return nil }
 func (s stubInteractExecEnv) OnContainerOpenedDuringEvent(*modelpkg.Agent, *modelpkg.Container, uint64) {
 }
 func (s stubInteractExecEnv) CanWithdrawFromContainer(string, modelpkg.Vec3i) bool {
@@ -155,6 +159,9 @@ func (s *stubGatherPlaceEnv) InBounds(modelpkg.Vec3i) bool { return true }
 func (s *stubGatherPlaceEnv) CanBuildAt(string, modelpkg.Vec3i, uint64) bool {
 	return s.canBuild
 }
+func (s *stubGatherPlaceEnv) EnforceLaw(*modelpkg.Agent, modelpkg.Vec3i, string, []string, uint64) bool {
+	return s.canBuild
+}
 func (s *stubGatherPlaceEnv) RecordDenied(uint64)               { s.denied++ }
 func (s *stubGatherPlaceEnv) BumpRepLaw(string, int)            {}
 func (s *stubGatherPlaceEnv) BlockAt(pos modelpkg.Vec3i) uint16 { return s.blocks[pos] }
@@ -172,6 +179,10 @@ func (s *stubGatherPlaceEnv) AuditSetBlock(uint64, string, modelpkg.Vec3i, uint1
 }
 func (s *stubGatherPlaceEnv) EnsureContainerForPlacedBlock(modelpkg.Vec3i, string) {}
 func (s *stubGatherPlaceEnv) EnsureConveyorFromYaw(modelpkg.Vec3i, int)            {}
+func (s *stubGatherPlaceEnv) SetSignalFacingFromYaw(modelpkg.Vec3i, int)           {}
+func (s *stubGatherPlaceEnv) SetMachinePlacer(modelpkg.Vec3i, string, int)         {}
+func (s *stubGatherPlaceEnv) SetShopOwner(modelpkg.Vec3i, string)                  {}
+func (s *stubGatherPlaceEnv) PlantCrop(modelpkg.Vec3i, string, uint64)             {}
 
 func TestTickGatherCollectsItemEntity(t *testing.T) {
 	pos := modelpkg.Vec3i{X: 1, Y: 0, Z: 1}
@@ -244,24 +255,42 @@ type stubMineEnv struct {
 	air        uint16
 	names      map[uint16]string
 	drops      map[uint16]string
+	crops      map[modelpkg.Vec3i]modelpkg.Crop
+	spawned    map[string]int
 }
 
 func (s *stubMineEnv) CanBreakAt(string, modelpkg.Vec3i, uint64) bool { return s.allowBreak }
-func (s *stubMineEnv) PermissionsFor(string, modelpkg.Vec3i) (*modelpkg.LandClaim, map[string]bool) {
-	return nil, nil
-}
-func (s *stubMineEnv) IsLandMember(string, *modelpkg.LandClaim) bool { return false }
-func (s *stubMineEnv) TransferToLandOwner(string, string, int)       {}
-func (s *stubMineEnv) BumpRepLaw(string, int)                        {}
-func (s *stubMineEnv) RecordDenied(uint64)                           {}
-func (s *stubMineEnv) BlockAt(pos modelpkg.Vec3i) uint16             { return s.blocks[pos] }
-func (s *stubMineEnv) AirBlockID() uint16                            { return s.air }
-func (s *stubMineEnv) BlockName(blockID uint16) string               { return s.names[blockID] }
-func (s *stubMineEnv) SetBlock(pos modelpkg.Vec3i, blockID uint16)   { s.blocks[pos] = blockID }
+func (s *stubMineEnv) EnforceLaw(*modelpkg.Agent, modelpkg.Vec3i, string, []string, uint64) bool {
+	return s.allowBreak
+}
+func (s *stubMineEnv) BumpRepLaw(string, int)                      {}
+func (s *stubMineEnv) RecordDenied(uint64)                         {}
+func (s *stubMineEnv) BlockAt(pos modelpkg.Vec3i) uint16           { return s.blocks[pos] }
+func (s *stubMineEnv) AirBlockID() uint16                          { return s.air }
+func (s *stubMineEnv) BlockName(blockID uint16) string             { return s.names[blockID] }
+func (s *stubMineEnv) SetBlock(pos modelpkg.Vec3i, blockID uint16) { s.blocks[pos] = blockID }
 func (s *stubMineEnv) AuditSetBlock(uint64, string, modelpkg.Vec3i, uint16, uint16, string) {
 }
 func (s *stubMineEnv) BlockIDToItem(blockID uint16) string { return s.drops[blockID] }
-func (s *stubMineEnv) SpawnItemEntity(uint64, string, modelpkg.Vec3i, string, int, string) string {
+func (s *stubMineEnv) BlockIDByName(blockName string) (uint16, bool) {
+	for id, name := range s.names {
+		if name == blockName {
+			return id, true
+		}
+	}
+	return 0, false
+}
+func (s *stubMineEnv) ContainerInUse(modelpkg.Vec3i) bool { return false }
+func (s *stubMineEnv) ShopOwnerAt(modelpkg.Vec3i) string  { return "" }
+func (s *stubMineEnv) TakeCrop(pos modelpkg.Vec3i) (modelpkg.Crop, bool) {
+	c, ok := s.crops[pos]
+	delete(s.crops, pos)
+	return c, ok
+}
+func (s *stubMineEnv) SpawnItemEntity(_ uint64, _ string, _ modelpkg.Vec3i, item string, n int, _ string) string {
+	if s.spawned != nil {
+		s.spawned[item] += n
+	}
 	return ""
 }
 func (s *stubMineEnv) GetContainerAt(modelpkg.Vec3i) *modelpkg.Container { return nil }
@@ -271,6 +300,7 @@ func (s *stubMineEnv) RemoveSign(uint64, string, modelpkg.Vec3i, string) {}
 func (s *stubMineEnv) RemoveConveyor(uint64, string, modelpkg.Vec3i, string) {
 }
 func (s *stubMineEnv) RemoveSwitch(uint64, string, modelpkg.Vec3i, string) {}
+func (s *stubMineEnv) ClaimPledgedAt(modelpkg.Vec3i) bool                  { return false }
 func (s *stubMineEnv) RemoveClaimByAnchor(uint64, string, modelpkg.Vec3i, string) {
 }
 func (s *stubMineEnv) OnMinedBlockDuringEvent(*modelpkg.Agent, modelpkg.Vec3i, string, uint64) {}
@@ -311,3 +341,34 @@ func TestTickMineBreaksBlockAfterWorkTicks(t *testing.T) {
 		t.Fatalf("expected mined block to become air, got %d", got)
 	}
 }
+
+func TestTickMineHarvestsCrop(t *testing.T) {
+	pos := modelpkg.Vec3i{X: 1, Y: 0, Z: 0}
+	env := &stubMineEnv{
+		allowBreak: true,
+		blocks:     map[modelpkg.Vec3i]uint16{pos: 6},
+		names:      map[uint16]string{0: "AIR", 5: "FARMLAND", 6: "WHEAT_CROP"},
+		drops:      map[uint16]string{},
+		crops:      map[modelpkg.Vec3i]modelpkg.Crop{pos: {Type: "WHEAT_CROP", Stage: 3}},
+		spawned:    map[string]int{},
+	}
+	a := &modelpkg.Agent{ID: "A1", Inventory: map[string]int{}, StaminaMilli: 1000}
+	wt := &tasks.WorkTask{TaskID: "T4", Kind: tasks.KindMine, BlockPos: tasks.Vec3i{X: pos.X, Y: pos.Y, Z: pos.Z}}
+	a.WorkTask = wt
+
+	for i := 0; i < 20 && a.WorkTask != nil; i++ {
+		TickMine(env, a, wt, uint64(50+i))
+	}
+	if a.WorkTask != nil {
+		t.Fatalf("expected harvest to finish")
+	}
+	if env.blocks[pos] != 5 {
+		t.Fatalf("expected crop cell to revert to farmland, got %d", env.blocks[pos])
+	}
+	if env.spawned["WHEAT"] != 2 || env.spawned["WHEAT_SEEDS"] != 2 {
+		t.Fatalf("unexpected harvest drops: %v", env.spawned)
+	}
+	if _, ok := env.crops[pos]; ok {
+		t.Fatalf("expected crop state removed")
+	}
+}
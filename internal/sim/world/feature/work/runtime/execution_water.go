@@ -0,0 +1,20 @@
+package runtime
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/tasks"
+	waterpkg "voxelcraft.ai/internal/sim/world/feature/survival/water"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
+
+// fillBucket finishes MINE on a water source while carrying a bucket: the source is
+// scooped into a WATER_BUCKET instead of dropping as an item.
+func fillBucket(env WorkExecMineEnv, a *modelpkg.Agent, wt *tasks.WorkTask, pos modelpkg.Vec3i, b uint16, nowTick uint64) {
+	air := env.AirBlockID()
+	env.SetBlock(pos, air)
+	env.AuditSetBlock(nowTick, a.ID, pos, b, air, "BUCKET_FILL")
+	a.Inventory[waterpkg.BucketItem]--
+	a.Inventory[waterpkg.WaterBucketItem]++
+	a.WorkTask = nil
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_DONE", "task_id": wt.TaskID, "kind": string(wt.Kind)})
+}
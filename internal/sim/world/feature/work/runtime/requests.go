@@ -13,6 +13,12 @@ type WorkRequestEnv interface {
 	ItemEntityExists(entityID string) bool
 	RecipeExists(recipeID string) bool
 	SmeltExists(itemID string) bool
+	WorldHeight() int
+}
+
+// validY reports whether y addresses a layer of the world (always 0 in 2D worlds).
+func validY(y, height int) bool {
+	return y >= 0 && y < height
 }
 
 func HandleTaskMine(env WorkRequestEnv, ar ActionResultFn, a *modelpkg.Agent, tr protocol.TaskReq, nowTick uint64, allowMine bool) {
@@ -24,21 +30,45 @@ func HandleTaskMine(env WorkRequestEnv, ar ActionResultFn, a *modelpkg.Agent, tr
 		a.AddEvent(ar(nowTick, tr.ID, false, "E_CONFLICT", "work task slot occupied"))
 		return
 	}
-	if tr.BlockPos[1] != 0 {
-		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "2D world requires y==0"))
+	if !validY(tr.BlockPos[1], env.WorldHeight()) {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "y out of range"))
 		return
 	}
 	taskID := env.NewTaskID()
 	a.WorkTask = &tasks.WorkTask{
 		TaskID:      taskID,
 		Kind:        tasks.KindMine,
-		BlockPos:    tasks.Vec3i{X: tr.BlockPos[0], Y: 0, Z: tr.BlockPos[2]},
+		BlockPos:    tasks.Vec3i{X: tr.BlockPos[0], Y: tr.BlockPos[1], Z: tr.BlockPos[2]},
 		StartedTick: nowTick,
 		WorkTicks:   0,
 	}
 	a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": tr.ID, "ok": true, "task_id": taskID})
 }
 
+// HandleTaskTill starts turning DIRT/GRASS at block_pos into FARMLAND (needs a hoe).
+func HandleTaskTill(env WorkRequestEnv, ar ActionResultFn, a *modelpkg.Agent, tr protocol.TaskReq, nowTick uint64, allowPlace bool) {
+	if !allowPlace {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_NO_PERMISSION", "placing disabled in this world"))
+		return
+	}
+	if a.WorkTask != nil {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_CONFLICT", "work task slot occupied"))
+		return
+	}
+	if !validY(tr.BlockPos[1], env.WorldHeight()) {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "y out of range"))
+		return
+	}
+	taskID := env.NewTaskID()
+	a.WorkTask = &tasks.WorkTask{
+		TaskID:      taskID,
+		Kind:        tasks.KindTill,
+		BlockPos:    tasks.Vec3i{X: tr.BlockPos[0], Y: tr.BlockPos[1], Z: tr.BlockPos[2]},
+		StartedTick: nowTick,
+	}
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": tr.ID, "ok": true, "task_id": taskID})
+}
+
 func HandleTaskGather(env WorkRequestEnv, ar ActionResultFn, a *modelpkg.Agent, tr protocol.TaskReq, nowTick uint64) {
 	if a.WorkTask != nil {
 		a.AddEvent(ar(nowTick, tr.ID, false, "E_CONFLICT", "work task slot occupied"))
@@ -75,8 +105,8 @@ func HandleTaskPlace(env WorkRequestEnv, ar ActionResultFn, a *modelpkg.Agent, t
 		a.AddEvent(ar(nowTick, tr.ID, false, "E_BAD_REQUEST", "missing item_id"))
 		return
 	}
-	if tr.BlockPos[1] != 0 {
-		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "2D world requires y==0"))
+	if !validY(tr.BlockPos[1], env.WorldHeight()) {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_INVALID_TARGET", "y out of range"))
 		return
 	}
 	taskID := env.NewTaskID()
@@ -84,7 +114,7 @@ func HandleTaskPlace(env WorkRequestEnv, ar ActionResultFn, a *modelpkg.Agent, t
 		TaskID:      taskID,
 		Kind:        tasks.KindPlace,
 		ItemID:      tr.ItemID,
-		BlockPos:    tasks.Vec3i{X: tr.BlockPos[0], Y: 0, Z: tr.BlockPos[2]},
+		BlockPos:    tasks.Vec3i{X: tr.BlockPos[0], Y: tr.BlockPos[1], Z: tr.BlockPos[2]},
 		StartedTick: nowTick,
 	}
 	a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": tr.ID, "ok": true, "task_id": taskID})
@@ -132,6 +162,32 @@ func HandleTaskTransfer(env WorkRequestEnv, ar ActionResultFn, a *modelpkg.Agent
 	a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": tr.ID, "ok": true, "task_id": taskID})
 }
 
+// HandleTaskBuy starts a purchase of count item_id from the SHOP target_id.
+func HandleTaskBuy(env WorkRequestEnv, ar ActionResultFn, a *modelpkg.Agent, tr protocol.TaskReq, nowTick uint64, allowTrade bool) {
+	if !allowTrade {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_NO_PERMISSION", "trade disabled in this world"))
+		return
+	}
+	if a.WorkTask != nil {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_CONFLICT", "work task slot occupied"))
+		return
+	}
+	if tr.TargetID == "" || tr.ItemID == "" || tr.Count <= 0 {
+		a.AddEvent(ar(nowTick, tr.ID, false, "E_BAD_REQUEST", "missing target_id/item_id/count"))
+		return
+	}
+	taskID := env.NewTaskID()
+	a.WorkTask = &tasks.WorkTask{
+		TaskID:      taskID,
+		Kind:        tasks.KindBuy,
+		TargetID:    tr.TargetID,
+		ItemID:      tr.ItemID,
+		Count:       tr.Count,
+		StartedTick: nowTick,
+	}
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": tr.ID, "ok": true, "task_id": taskID})
+}
+
 func HandleTaskCraft(env WorkRequestEnv, ar ActionResultFn, a *modelpkg.Agent, tr protocol.TaskReq, nowTick uint64) {
 	if a.WorkTask != nil {
 		a.AddEvent(ar(nowTick, tr.ID, false, "E_CONFLICT", "work task slot occupied"))
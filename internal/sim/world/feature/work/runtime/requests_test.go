@@ -21,6 +21,7 @@ func (s stubWorkReqEnv) ItemEntityExists(id string) bool       { return s.itemEn
 func (s stubWorkReqEnv) RecipeExists(id string) bool           { return s.recipes[id] }
 func (s stubWorkReqEnv) SmeltExists(id string) bool            { return s.smelts[id] }
 func (s stubWorkReqEnv) BlueprintExists(id string) bool        { return s.blueprints[id] }
+func (s stubWorkReqEnv) WorldHeight() int                      { return 1 }
 
 func ar(tick uint64, ref string, ok bool, code string, message string) protocol.Event {
 	e := protocol.Event{"t": tick, "ref": ref, "ok": ok}
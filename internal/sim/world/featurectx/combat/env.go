@@ -0,0 +1,98 @@
+package combat
+
+import modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+
+type Env struct {
+	NewTaskIDFn    func() string
+	TargetExistsFn func(targetID string) bool
+
+	GetAgentFn     func(agentID string) *modelpkg.Agent
+	CanDamageAtFn  func(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool
+	EnforceLawFn   func(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool
+	BumpRepLawFn   func(agentID string, delta int)
+	RecordDeniedFn func(nowTick uint64)
+	RespawnFn      func(nowTick uint64, a *modelpkg.Agent, reason string)
+	AuditEventFn   func(nowTick uint64, actor string, action string, pos modelpkg.Vec3i, reason string, details map[string]any)
+
+	GetMobFn  func(mobID string) *modelpkg.Mob
+	MobHurtFn func(nowTick uint64, m *modelpkg.Mob, attackerID string)
+	KillMobFn func(nowTick uint64, m *modelpkg.Mob, killerID string)
+}
+
+func (e Env) NewTaskID() string {
+	if e.NewTaskIDFn == nil {
+		return ""
+	}
+	return e.NewTaskIDFn()
+}
+
+func (e Env) TargetExists(targetID string) bool {
+	if e.TargetExistsFn == nil {
+		return false
+	}
+	return e.TargetExistsFn(targetID)
+}
+
+func (e Env) GetAgent(agentID string) *modelpkg.Agent {
+	if e.GetAgentFn == nil {
+		return nil
+	}
+	return e.GetAgentFn(agentID)
+}
+
+func (e Env) CanDamageAt(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool {
+	if e.CanDamageAtFn == nil {
+		return false
+	}
+	return e.CanDamageAtFn(agentID, pos, nowTick)
+}
+
+func (e Env) EnforceLaw(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool {
+	if e.EnforceLawFn == nil {
+		return true
+	}
+	return e.EnforceLawFn(a, pos, action, items, nowTick)
+}
+
+func (e Env) BumpRepLaw(agentID string, delta int) {
+	if e.BumpRepLawFn != nil {
+		e.BumpRepLawFn(agentID, delta)
+	}
+}
+
+func (e Env) RecordDenied(nowTick uint64) {
+	if e.RecordDeniedFn != nil {
+		e.RecordDeniedFn(nowTick)
+	}
+}
+
+func (e Env) Respawn(nowTick uint64, a *modelpkg.Agent, reason string) {
+	if e.RespawnFn != nil {
+		e.RespawnFn(nowTick, a, reason)
+	}
+}
+
+func (e Env) AuditEvent(nowTick uint64, actor string, action string, pos modelpkg.Vec3i, reason string, details map[string]any) {
+	if e.AuditEventFn != nil {
+		e.AuditEventFn(nowTick, actor, action, pos, reason, details)
+	}
+}
+
+func (e Env) GetMob(mobID string) *modelpkg.Mob {
+	if e.GetMobFn == nil {
+		return nil
+	}
+	return e.GetMobFn(mobID)
+}
+
+func (e Env) MobHurt(nowTick uint64, m *modelpkg.Mob, attackerID string) {
+	if e.MobHurtFn != nil {
+		e.MobHurtFn(nowTick, m, attackerID)
+	}
+}
+
+func (e Env) KillMob(nowTick uint64, m *modelpkg.Mob, killerID string) {
+	if e.KillMobFn != nil {
+		e.KillMobFn(nowTick, m, killerID)
+	}
+}
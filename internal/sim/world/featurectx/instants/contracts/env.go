@@ -10,6 +10,8 @@ type Env struct {
 	GetContractFn          func(contractID string) *modelpkg.Contract
 	RepDepositMultiplierFn func(a *modelpkg.Agent) int
 	CheckBuildContractFn   func(c *modelpkg.Contract) bool
+	GetLandFn              func(landID string) *modelpkg.LandClaim
+	LandPledgedFn          func(landID string) bool
 }
 
 func (e Env) GetContainerByID(id string) *modelpkg.Container {
@@ -59,3 +61,17 @@ func (e Env) CheckBuildContract(c *modelpkg.Contract) bool {
 	}
 	return e.CheckBuildContractFn(c)
 }
+
+func (e Env) GetLand(landID string) *modelpkg.LandClaim {
+	if e.GetLandFn == nil {
+		return nil
+	}
+	return e.GetLandFn(landID)
+}
+
+func (e Env) LandPledged(landID string) bool {
+	if e.LandPledgedFn == nil {
+		return false
+	}
+	return e.LandPledgedFn(landID)
+}
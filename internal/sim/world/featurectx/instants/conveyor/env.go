@@ -1,6 +1,9 @@
 package conveyor
 
-import modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+import (
+	"voxelcraft.ai/internal/sim/catalogs"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+)
 
 type Env struct {
 	ParseContainerIDFn  func(id string) (typ string, pos modelpkg.Vec3i, ok bool)
@@ -11,6 +14,11 @@ type Env struct {
 	SetSwitchStateFn    func(pos modelpkg.Vec3i, on bool)
 	SwitchIDAtFn        func(pos modelpkg.Vec3i) string
 	AuditSwitchToggleFn func(nowTick uint64, actorID string, pos modelpkg.Vec3i, switchID string, on bool)
+	SetSensorFn         func(pos modelpkg.Vec3i, mode string, arg int)
+	AuditSensorSetFn    func(nowTick uint64, actorID string, pos modelpkg.Vec3i, mode string, arg int)
+	GetRecipeFn         func(recipeID string) (catalogs.RecipeDef, bool)
+	SetMachineRecipeFn  func(pos modelpkg.Vec3i, recipeID string)
+	AuditMachineSetFn   func(nowTick uint64, actorID string, pos modelpkg.Vec3i, recipeID string)
 	BumpLawRepFn        func(agentID string, delta int)
 	RecordDeniedFn      func(nowTick uint64)
 }
@@ -69,6 +77,37 @@ func (e Env) AuditSwitchToggle(nowTick uint64, actorID string, pos modelpkg.Vec3
 	}
 }
 
+func (e Env) SetSensor(pos modelpkg.Vec3i, mode string, arg int) {
+	if e.SetSensorFn != nil {
+		e.SetSensorFn(pos, mode, arg)
+	}
+}
+
+func (e Env) AuditSensorSet(nowTick uint64, actorID string, pos modelpkg.Vec3i, mode string, arg int) {
+	if e.AuditSensorSetFn != nil {
+		e.AuditSensorSetFn(nowTick, actorID, pos, mode, arg)
+	}
+}
+
+func (e Env) GetRecipe(recipeID string) (catalogs.RecipeDef, bool) {
+	if e.GetRecipeFn == nil {
+		return catalogs.RecipeDef{}, false
+	}
+	return e.GetRecipeFn(recipeID)
+}
+
+func (e Env) SetMachineRecipe(pos modelpkg.Vec3i, recipeID string) {
+	if e.SetMachineRecipeFn != nil {
+		e.SetMachineRecipeFn(pos, recipeID)
+	}
+}
+
+func (e Env) AuditMachineSet(nowTick uint64, actorID string, pos modelpkg.Vec3i, recipeID string) {
+	if e.AuditMachineSetFn != nil {
+		e.AuditMachineSetFn(nowTick, actorID, pos, recipeID)
+	}
+}
+
 func (e Env) BumpLawRep(agentID string, delta int) {
 	if e.BumpLawRepFn != nil {
 		e.BumpLawRepFn(agentID, delta)
@@ -1,6 +1,7 @@
 package economy
 
 import economyinstantspkg "voxelcraft.ai/internal/sim/world/feature/economy/instants"
+import valuepkg "voxelcraft.ai/internal/sim/world/feature/economy/value"
 import modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 
 type Env struct {
@@ -11,6 +12,7 @@ type Env struct {
 	GetTradeFn        func(tradeID string) *modelpkg.Trade
 	DeleteTradeFn     func(tradeID string)
 	ResolveTradeTaxFn func(tr *modelpkg.Trade, from *modelpkg.Agent, to *modelpkg.Agent, nowTick uint64) economyinstantspkg.TradeTaxResolution
+	ItemValueFn       func(item string) int64
 }
 
 func (e Env) PermissionsFor(agentID string, pos modelpkg.Vec3i) map[string]bool {
@@ -59,3 +61,10 @@ func (e Env) ResolveTradeTax(tr *modelpkg.Trade, from *modelpkg.Agent, to *model
 	}
 	return e.ResolveTradeTaxFn(tr, from, to, nowTick)
 }
+
+func (e Env) ItemValue(item string) int64 {
+	if e.ItemValueFn == nil {
+		return valuepkg.ReferenceValue(item)
+	}
+	return e.ItemValueFn(item)
+}
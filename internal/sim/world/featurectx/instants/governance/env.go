@@ -12,6 +12,7 @@ type ClaimEnv struct {
 	BlockNameAtFn     func(pos modelpkg.Vec3i) string
 	ClaimRecordsFn    func() []governanceinstantspkg.ClaimRecord
 	OwnerExistsFn     func(ownerID string) bool
+	LandPledgedFn     func(landID string) bool
 	AuditClaimEventFn func(nowTick uint64, actorID string, action string, pos modelpkg.Vec3i, reason string, details map[string]any)
 }
 
@@ -50,6 +51,13 @@ func (e ClaimEnv) OwnerExists(ownerID string) bool {
 	return e.OwnerExistsFn(ownerID)
 }
 
+func (e ClaimEnv) LandPledged(landID string) bool {
+	if e.LandPledgedFn == nil {
+		return false
+	}
+	return e.LandPledgedFn(landID)
+}
+
 func (e ClaimEnv) AuditClaimEvent(nowTick uint64, actorID string, action string, pos modelpkg.Vec3i, reason string, details map[string]any) {
 	if e.AuditClaimEventFn != nil {
 		e.AuditClaimEventFn(nowTick, actorID, action, pos, reason, details)
@@ -57,15 +65,15 @@ func (e ClaimEnv) AuditClaimEvent(nowTick uint64, actorID string, action string,
 }
 
 type LawEnv struct {
-	GetLandFn             func(landID string) *modelpkg.LandClaim
-	IsLandMemberFn        func(agentID string, land *modelpkg.LandClaim) bool
-	GetLawTemplateTitleFn func(templateID string) (string, bool)
-	ItemExistsFn          func(itemID string) bool
-	NewLawIDFn            func() string
-	PutLawFn              func(law *lawspkg.Law)
-	GetLawFn              func(lawID string) *lawspkg.Law
-	BroadcastLawEventFn   func(nowTick uint64, stage string, law *lawspkg.Law, note string)
-	AuditLawEventFn       func(nowTick uint64, actorID string, action string, pos modelpkg.Vec3i, reason string, details map[string]any)
+	GetLandFn           func(landID string) *modelpkg.LandClaim
+	IsLandMemberFn      func(agentID string, land *modelpkg.LandClaim) bool
+	GetLawTemplateFn    func(templateID string) (lawspkg.Template, bool)
+	ItemExistsFn        func(itemID string) bool
+	NewLawIDFn          func() string
+	PutLawFn            func(law *lawspkg.Law)
+	GetLawFn            func(lawID string) *lawspkg.Law
+	BroadcastLawEventFn func(nowTick uint64, stage string, law *lawspkg.Law, note string)
+	AuditLawEventFn     func(nowTick uint64, actorID string, action string, pos modelpkg.Vec3i, reason string, details map[string]any)
 }
 
 func (e LawEnv) GetLand(landID string) *modelpkg.LandClaim {
@@ -82,11 +90,11 @@ func (e LawEnv) IsLandMember(agentID string, land *modelpkg.LandClaim) bool {
 	return e.IsLandMemberFn(agentID, land)
 }
 
-func (e LawEnv) GetLawTemplateTitle(templateID string) (string, bool) {
-	if e.GetLawTemplateTitleFn == nil {
-		return "", false
+func (e LawEnv) GetLawTemplate(templateID string) (lawspkg.Template, bool) {
+	if e.GetLawTemplateFn == nil {
+		return lawspkg.Template{}, false
 	}
-	return e.GetLawTemplateTitleFn(templateID)
+	return e.GetLawTemplateFn(templateID)
 }
 
 func (e LawEnv) ItemExists(itemID string) bool {
@@ -0,0 +1,76 @@
+package market
+
+import modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+
+type Env struct {
+	GetContainerByIDFn func(id string) *modelpkg.Container
+	DistanceFn         func(a modelpkg.Vec3i, b modelpkg.Vec3i) int
+	CanTradeAtFn       func(agentID string, pos modelpkg.Vec3i, items []string, nowTick uint64) bool
+	ItemExistsFn       func(itemID string) bool
+	OpenOrdersFn       func(agentID string) int
+	NewOrderIDFn       func() string
+	PutOrderFn         func(o *modelpkg.MarketOrder)
+	GetOrderFn         func(orderID string) *modelpkg.MarketOrder
+	DeleteOrderFn      func(orderID string)
+}
+
+func (e Env) GetContainerByID(id string) *modelpkg.Container {
+	if e.GetContainerByIDFn == nil {
+		return nil
+	}
+	return e.GetContainerByIDFn(id)
+}
+
+func (e Env) Distance(a modelpkg.Vec3i, b modelpkg.Vec3i) int {
+	if e.DistanceFn == nil {
+		return 0
+	}
+	return e.DistanceFn(a, b)
+}
+
+func (e Env) CanTradeAt(agentID string, pos modelpkg.Vec3i, items []string, nowTick uint64) bool {
+	if e.CanTradeAtFn == nil {
+		return false
+	}
+	return e.CanTradeAtFn(agentID, pos, items, nowTick)
+}
+
+func (e Env) ItemExists(itemID string) bool {
+	if e.ItemExistsFn == nil {
+		return false
+	}
+	return e.ItemExistsFn(itemID)
+}
+
+func (e Env) OpenOrders(agentID string) int {
+	if e.OpenOrdersFn == nil {
+		return 0
+	}
+	return e.OpenOrdersFn(agentID)
+}
+
+func (e Env) NewOrderID() string {
+	if e.NewOrderIDFn == nil {
+		return ""
+	}
+	return e.NewOrderIDFn()
+}
+
+func (e Env) PutOrder(o *modelpkg.MarketOrder) {
+	if e.PutOrderFn != nil {
+		e.PutOrderFn(o)
+	}
+}
+
+func (e Env) GetOrder(orderID string) *modelpkg.MarketOrder {
+	if e.GetOrderFn == nil {
+		return nil
+	}
+	return e.GetOrderFn(orderID)
+}
+
+func (e Env) DeleteOrder(orderID string) {
+	if e.DeleteOrderFn != nil {
+		e.DeleteOrderFn(orderID)
+	}
+}
@@ -0,0 +1,38 @@
+package shop
+
+import modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+
+type Env struct {
+	GetContainerByIDFn func(id string) *modelpkg.Container
+	DistanceFn         func(a modelpkg.Vec3i, b modelpkg.Vec3i) int
+	ItemExistsFn       func(itemID string) bool
+	GetShopFn          func(pos modelpkg.Vec3i) *modelpkg.Shop
+}
+
+func (e Env) GetContainerByID(id string) *modelpkg.Container {
+	if e.GetContainerByIDFn == nil {
+		return nil
+	}
+	return e.GetContainerByIDFn(id)
+}
+
+func (e Env) Distance(a modelpkg.Vec3i, b modelpkg.Vec3i) int {
+	if e.DistanceFn == nil {
+		return 0
+	}
+	return e.DistanceFn(a, b)
+}
+
+func (e Env) ItemExists(itemID string) bool {
+	if e.ItemExistsFn == nil {
+		return false
+	}
+	return e.ItemExistsFn(itemID)
+}
+
+func (e Env) GetShop(pos modelpkg.Vec3i) *modelpkg.Shop {
+	if e.GetShopFn == nil {
+		return nil
+	}
+	return e.GetShopFn(pos)
+}
@@ -0,0 +1,52 @@
+package mobs
+
+import modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+
+type Env struct {
+	SortedAgentsFn func() []*modelpkg.Agent
+	GetAgentFn     func(agentID string) *modelpkg.Agent
+	WalkableFn     func(pos modelpkg.Vec3i) (modelpkg.Vec3i, bool)
+	CanDamageAtFn  func(pos modelpkg.Vec3i, nowTick uint64) bool
+	RespawnFn      func(nowTick uint64, a *modelpkg.Agent, reason string)
+	AuditEventFn   func(nowTick uint64, actor string, action string, pos modelpkg.Vec3i, reason string, details map[string]any)
+}
+
+func (e Env) SortedAgents() []*modelpkg.Agent {
+	if e.SortedAgentsFn == nil {
+		return nil
+	}
+	return e.SortedAgentsFn()
+}
+
+func (e Env) GetAgent(agentID string) *modelpkg.Agent {
+	if e.GetAgentFn == nil {
+		return nil
+	}
+	return e.GetAgentFn(agentID)
+}
+
+func (e Env) Walkable(pos modelpkg.Vec3i) (modelpkg.Vec3i, bool) {
+	if e.WalkableFn == nil {
+		return modelpkg.Vec3i{}, false
+	}
+	return e.WalkableFn(pos)
+}
+
+func (e Env) CanDamageAt(pos modelpkg.Vec3i, nowTick uint64) bool {
+	if e.CanDamageAtFn == nil {
+		return false
+	}
+	return e.CanDamageAtFn(pos, nowTick)
+}
+
+func (e Env) Respawn(nowTick uint64, a *modelpkg.Agent, reason string) {
+	if e.RespawnFn != nil {
+		e.RespawnFn(nowTick, a, reason)
+	}
+}
+
+func (e Env) AuditEvent(nowTick uint64, actor string, action string, pos modelpkg.Vec3i, reason string, details map[string]any) {
+	if e.AuditEventFn != nil {
+		e.AuditEventFn(nowTick, actor, action, pos, reason, details)
+	}
+}
@@ -1,19 +1,23 @@
 package movement
 
-import modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+import (
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	"voxelcraft.ai/internal/sim/world/policy/rules"
+)
 
 type Env struct {
 	NewTaskIDFn            func() string
 	InBoundsFn             func(pos modelpkg.Vec3i) bool
 	FollowTargetPosFn      func(targetID string) (modelpkg.Vec3i, bool)
 	SortedAgentsFn         func() []*modelpkg.Agent
-	SurfaceYFn             func(x int, z int) int
 	BlockSolidAtFn         func(pos modelpkg.Vec3i) bool
 	LandAtFn               func(pos modelpkg.Vec3i) *modelpkg.LandClaim
 	LandCoreContainsFn     func(c *modelpkg.LandClaim, pos modelpkg.Vec3i) bool
 	IsLandMemberFn         func(agentID string, land *modelpkg.LandClaim) bool
 	OrgByIDFn              func(id string) *modelpkg.Organization
 	TransferAccessTicketFn func(ownerID string, item string, count int)
+	LawDecisionFn          func(agentID string, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) rules.Decision
+	EnforceLawFn           func(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool
 	RecordDeniedFn         func(nowTick uint64)
 	RecordStructureUsageFn func(agentID string, pos modelpkg.Vec3i, nowTick uint64)
 	OnBiomeFn              func(a *modelpkg.Agent, nowTick uint64)
@@ -47,13 +51,6 @@ func (e Env) SortedAgents() []*modelpkg.Agent {
 	return e.SortedAgentsFn()
 }
 
-func (e Env) SurfaceY(x int, z int) int {
-	if e.SurfaceYFn == nil {
-		return 0
-	}
-	return e.SurfaceYFn(x, z)
-}
-
 func (e Env) BlockSolidAt(pos modelpkg.Vec3i) bool {
 	if e.BlockSolidAtFn == nil {
 		return false
@@ -95,6 +92,20 @@ func (e Env) TransferAccessTicket(ownerID string, item string, count int) {
 	}
 }
 
+func (e Env) LawDecision(agentID string, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) rules.Decision {
+	if e.LawDecisionFn == nil {
+		return rules.Decision{Allowed: true}
+	}
+	return e.LawDecisionFn(agentID, pos, action, items, nowTick)
+}
+
+func (e Env) EnforceLaw(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool {
+	if e.EnforceLawFn == nil {
+		return true
+	}
+	return e.EnforceLawFn(a, pos, action, items, nowTick)
+}
+
 func (e Env) RecordDenied(nowTick uint64) {
 	if e.RecordDeniedFn != nil {
 		e.RecordDeniedFn(nowTick)
@@ -14,6 +14,8 @@ type Env struct {
 	GetSignFn                      func(pos modelpkg.Vec3i) *modelpkg.Sign
 	SignIDAtFn                     func(pos modelpkg.Vec3i) string
 	ContractSummariesForTerminalFn func(pos modelpkg.Vec3i) []map[string]interface{}
+	MarketOrdersForTerminalFn      func(pos modelpkg.Vec3i, agentID string) []map[string]interface{}
+	ShopAtFn                       func(pos modelpkg.Vec3i) *modelpkg.Shop
 	OnContainerOpenedDuringEventFn func(a *modelpkg.Agent, c *modelpkg.Container, nowTick uint64)
 	CanWithdrawFromContainerFn     func(agentID string, pos modelpkg.Vec3i) bool
 	AuditTransferFn                func(nowTick uint64, actorID string, at modelpkg.Vec3i, srcID string, dstID string, item string, count int)
@@ -41,23 +43,29 @@ type Env struct {
 	EnsureContainerForPlacedBlockFn func(pos modelpkg.Vec3i, blockName string)
 	EnsureBlueprintMaterialsFn      func(a *modelpkg.Agent, anchor modelpkg.Vec3i, needCost []catalogs.ItemCount, nowTick uint64) (bool, string)
 	EnsureConveyorFromYawFn         func(pos modelpkg.Vec3i, yaw int)
+	SetSignalFacingFromYawFn        func(pos modelpkg.Vec3i, yaw int)
+	SetMachinePlacerFn              func(pos modelpkg.Vec3i, agentID string, yaw int)
+	SetShopOwnerFn                  func(pos modelpkg.Vec3i, agentID string)
 
-	CanBreakAtFn          func(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool
-	PermissionsForFn      func(agentID string, pos modelpkg.Vec3i) (*modelpkg.LandClaim, map[string]bool)
-	IsLandMemberFn        func(agentID string, land *modelpkg.LandClaim) bool
-	TransferToLandOwnerFn func(ownerID string, item string, count int)
+	CanBreakAtFn func(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool
+	EnforceLawFn func(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool
 
 	BlockNameFn               func(blockID uint16) string
 	BlockIDToItemFn           func(blockID uint16) string
 	SpawnItemEntityFn         func(nowTick uint64, actor string, pos modelpkg.Vec3i, item string, count int, reason string) string
 	GetContainerAtFn          func(pos modelpkg.Vec3i) *modelpkg.Container
+	ContainerInUseFn          func(pos modelpkg.Vec3i) bool
 	RemoveContainerFn         func(pos modelpkg.Vec3i)
 	RemoveBoardFn             func(pos modelpkg.Vec3i)
 	RemoveSignFn              func(nowTick uint64, actor string, pos modelpkg.Vec3i, reason string)
 	RemoveConveyorFn          func(nowTick uint64, actor string, pos modelpkg.Vec3i, reason string)
 	RemoveSwitchFn            func(nowTick uint64, actor string, pos modelpkg.Vec3i, reason string)
+	ClaimPledgedAtFn          func(anchor modelpkg.Vec3i) bool
 	RemoveClaimByAnchorFn     func(nowTick uint64, actor string, pos modelpkg.Vec3i, reason string)
 	OnMinedBlockDuringEventFn func(a *modelpkg.Agent, pos modelpkg.Vec3i, blockName string, nowTick uint64)
+
+	PlantCropFn func(pos modelpkg.Vec3i, cropType string, nowTick uint64)
+	TakeCropFn  func(pos modelpkg.Vec3i) (modelpkg.Crop, bool)
 }
 
 func (e Env) GetContainerByID(id string) *modelpkg.Container {
@@ -116,12 +124,34 @@ func (e Env) ContractSummariesForTerminal(pos modelpkg.Vec3i) []map[string]inter
 	return e.ContractSummariesForTerminalFn(pos)
 }
 
+func (e Env) MarketOrdersForTerminal(pos modelpkg.Vec3i, agentID string) []map[string]interface{} {
+	if e.MarketOrdersForTerminalFn == nil {
+		return nil
+	}
+	return e.MarketOrdersForTerminalFn(pos, agentID)
+}
+
 func (e Env) OnContainerOpenedDuringEvent(a *modelpkg.Agent, c *modelpkg.Container, nowTick uint64) {
 	if e.OnContainerOpenedDuringEventFn != nil {
 		e.OnContainerOpenedDuringEventFn(a, c, nowTick)
 	}
 }
 
+func (e Env) ShopAt(pos modelpkg.Vec3i) *modelpkg.Shop {
+	if e.ShopAtFn == nil {
+		return nil
+	}
+	return e.ShopAtFn(pos)
+}
+
+// ShopOwnerAt is the owner of the SHOP at pos, or "" when there is none.
+func (e Env) ShopOwnerAt(pos modelpkg.Vec3i) string {
+	if s := e.ShopAt(pos); s != nil {
+		return s.Owner
+	}
+	return ""
+}
+
 func (e Env) CanWithdrawFromContainer(agentID string, pos modelpkg.Vec3i) bool {
 	if e.CanWithdrawFromContainerFn == nil {
 		return false
@@ -274,31 +304,36 @@ func (e Env) EnsureConveyorFromYaw(pos modelpkg.Vec3i, yaw int) {
 	}
 }
 
-func (e Env) CanBreakAt(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool {
-	if e.CanBreakAtFn == nil {
-		return false
+func (e Env) SetSignalFacingFromYaw(pos modelpkg.Vec3i, yaw int) {
+	if e.SetSignalFacingFromYawFn != nil {
+		e.SetSignalFacingFromYawFn(pos, yaw)
+	}
+}
+
+func (e Env) SetMachinePlacer(pos modelpkg.Vec3i, agentID string, yaw int) {
+	if e.SetMachinePlacerFn != nil {
+		e.SetMachinePlacerFn(pos, agentID, yaw)
 	}
-	return e.CanBreakAtFn(agentID, pos, nowTick)
 }
 
-func (e Env) PermissionsFor(agentID string, pos modelpkg.Vec3i) (*modelpkg.LandClaim, map[string]bool) {
-	if e.PermissionsForFn == nil {
-		return nil, nil
+func (e Env) SetShopOwner(pos modelpkg.Vec3i, agentID string) {
+	if e.SetShopOwnerFn != nil {
+		e.SetShopOwnerFn(pos, agentID)
 	}
-	return e.PermissionsForFn(agentID, pos)
 }
 
-func (e Env) IsLandMember(agentID string, land *modelpkg.LandClaim) bool {
-	if e.IsLandMemberFn == nil {
+func (e Env) CanBreakAt(agentID string, pos modelpkg.Vec3i, nowTick uint64) bool {
+	if e.CanBreakAtFn == nil {
 		return false
 	}
-	return e.IsLandMemberFn(agentID, land)
+	return e.CanBreakAtFn(agentID, pos, nowTick)
 }
 
-func (e Env) TransferToLandOwner(ownerID string, item string, count int) {
-	if e.TransferToLandOwnerFn != nil {
-		e.TransferToLandOwnerFn(ownerID, item, count)
+func (e Env) EnforceLaw(a *modelpkg.Agent, pos modelpkg.Vec3i, action string, items []string, nowTick uint64) bool {
+	if e.EnforceLawFn == nil {
+		return true
 	}
+	return e.EnforceLawFn(a, pos, action, items, nowTick)
 }
 
 func (e Env) BlockName(blockID uint16) string {
@@ -359,6 +394,13 @@ func (e Env) RemoveSwitch(nowTick uint64, actor string, pos modelpkg.Vec3i, reas
 	}
 }
 
+func (e Env) ClaimPledgedAt(anchor modelpkg.Vec3i) bool {
+	if e.ClaimPledgedAtFn == nil {
+		return false
+	}
+	return e.ClaimPledgedAtFn(anchor)
+}
+
 func (e Env) RemoveClaimByAnchor(nowTick uint64, actor string, pos modelpkg.Vec3i, reason string) {
 	if e.RemoveClaimByAnchorFn != nil {
 		e.RemoveClaimByAnchorFn(nowTick, actor, pos, reason)
@@ -370,3 +412,23 @@ func (e Env) OnMinedBlockDuringEvent(a *modelpkg.Agent, pos modelpkg.Vec3i, bloc
 		e.OnMinedBlockDuringEventFn(a, pos, blockName, nowTick)
 	}
 }
+
+func (e Env) ContainerInUse(pos modelpkg.Vec3i) bool {
+	if e.ContainerInUseFn == nil {
+		return false
+	}
+	return e.ContainerInUseFn(pos)
+}
+
+func (e Env) PlantCrop(pos modelpkg.Vec3i, cropType string, nowTick uint64) {
+	if e.PlantCropFn != nil {
+		e.PlantCropFn(pos, cropType, nowTick)
+	}
+}
+
+func (e Env) TakeCrop(pos modelpkg.Vec3i) (modelpkg.Crop, bool) {
+	if e.TakeCropFn == nil {
+		return modelpkg.Crop{}, false
+	}
+	return e.TakeCropFn(pos)
+}
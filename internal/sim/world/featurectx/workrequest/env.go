@@ -6,6 +6,7 @@ type Env struct {
 	RecipeExistsFn     func(recipeID string) bool
 	SmeltExistsFn      func(itemID string) bool
 	BlueprintExistsFn  func(blueprintID string) bool
+	WorldHeightFn      func() int
 }
 
 func (e Env) NewTaskID() string {
@@ -42,3 +43,10 @@ func (e Env) BlueprintExists(blueprintID string) bool {
 	}
 	return e.BlueprintExistsFn(blueprintID)
 }
+
+func (e Env) WorldHeight() int {
+	if e.WorldHeightFn == nil {
+		return 1
+	}
+	return e.WorldHeightFn()
+}
@@ -10,6 +10,7 @@ import (
 	lawsruntimepkg "voxelcraft.ai/internal/sim/world/feature/governance/laws/runtime"
 	permissionspkg "voxelcraft.ai/internal/sim/world/feature/governance/permissions"
 	governanceruntimepkg "voxelcraft.ai/internal/sim/world/feature/governance/runtime"
+	rulespkg "voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
 // --- Claims / Permissions ---
@@ -59,31 +60,80 @@ func (w *World) permissionsFor(agentID string, pos Vec3i) (land *LandClaim, perm
 }
 
 func (w *World) canBuildAt(agentID string, pos Vec3i, nowTick uint64) bool {
-	land, perms := w.permissionsFor(agentID, pos)
-	if land == nil {
-		return perms["can_build"]
-	}
-	return claimspkg.CanActionWithCurfew(
-		perms["can_build"],
-		land.CurfewEnabled,
-		w.timeOfDay(nowTick),
-		land.CurfewStart,
-		land.CurfewEnd,
-	)
+	return w.lawDecision(agentID, pos, rulespkg.ActionBuild, nil, nowTick).Allowed
 }
 
 func (w *World) canBreakAt(agentID string, pos Vec3i, nowTick uint64) bool {
+	return w.lawDecision(agentID, pos, rulespkg.ActionBreak, nil, nowTick).Allowed
+}
+
+func (w *World) canDamageAt(agentID string, pos Vec3i, nowTick uint64) bool {
+	return w.lawDecision(agentID, pos, rulespkg.ActionDamage, nil, nowTick).Allowed
+}
+
+var lawActionPerm = map[string]string{
+	rulespkg.ActionBuild:  "can_build",
+	rulespkg.ActionBreak:  "can_break",
+	rulespkg.ActionDamage: "can_damage",
+	rulespkg.ActionTrade:  "can_trade",
+}
+
+// lawDecision evaluates the land's law policies for agentID doing action at pos, on top
+// of the claim permissions. ENTER has no claim permission and starts allowed.
+func (w *World) lawDecision(agentID string, pos Vec3i, action string, items []string, nowTick uint64) rulespkg.Decision {
 	land, perms := w.permissionsFor(agentID, pos)
-	if land == nil {
-		return perms["can_break"]
+	base := true
+	if key, ok := lawActionPerm[action]; ok {
+		base = perms[key]
+	}
+	if land == nil || len(land.Policies) == 0 {
+		return rulespkg.Decision{Allowed: base}
+	}
+	repLaw := 0
+	if a := w.agents[agentID]; a != nil {
+		repLaw = a.RepLaw
+	}
+	return rulespkg.Evaluate(land.Policies, rulespkg.Context{
+		Action:      action,
+		Member:      w.isLandMember(agentID, land),
+		RepLaw:      repLaw,
+		InCore:      w.landCoreContains(land, pos),
+		TimeOfDay:   w.timeOfDay(nowTick),
+		Items:       items,
+		BaseAllowed: base,
+	})
+}
+
+// enforceLaw evaluates action with its concrete items, settles fines and reputation and
+// reports whether the action may proceed.
+func (w *World) enforceLaw(a *Agent, pos Vec3i, action string, items []string, nowTick uint64) bool {
+	if a == nil {
+		return false
+	}
+	d := w.lawDecision(a.ID, pos, action, items, nowTick)
+	outcome := rulespkg.OutcomeAllowed
+	if !d.Allowed {
+		outcome = rulespkg.OutcomeDenied
+	}
+	lawsruntimepkg.SettleConsequences(a, w.landAt(pos), d, action+"_"+outcome, nowTick, lawsruntimepkg.EnforceHooks{
+		TransferToLandOwner: w.transferToLandOwner,
+		BumpRepLaw:          w.bumpRepLaw,
+	})
+	return d.Allowed
+}
+
+// transferToLandOwner credits a land owner: the agent's inventory or the org treasury.
+func (w *World) transferToLandOwner(ownerID string, item string, count int) {
+	if ownerID == "" || item == "" || count <= 0 {
+		return
+	}
+	if owner := w.agents[ownerID]; owner != nil {
+		owner.Inventory[item] += count
+		return
+	}
+	if org := w.orgByID(ownerID); org != nil {
+		w.orgTreasury(org)[item] += count
 	}
-	return claimspkg.CanActionWithCurfew(
-		perms["can_break"],
-		land.CurfewEnabled,
-		w.timeOfDay(nowTick),
-		land.CurfewStart,
-		land.CurfewEnd,
-	)
 }
 
 func (w *World) timeOfDay(nowTick uint64) float64 {
@@ -131,6 +181,21 @@ func (w *World) removeClaimByAnchor(nowTick uint64, actor string, anchor Vec3i,
 	})
 }
 
+// landTaxSink is where a land's taxes go: the owner's inventory, or the treasury of
+// an owning org. It is nil for wild land or an owner not in this world.
+func (w *World) landTaxSink(land *LandClaim) (sink map[string]int, taxTo string) {
+	if land == nil || land.Owner == "" {
+		return nil, ""
+	}
+	if owner := w.agents[land.Owner]; owner != nil {
+		return owner.Inventory, land.Owner
+	}
+	if org := w.orgByID(land.Owner); org != nil {
+		return w.orgTreasury(org), land.Owner
+	}
+	return nil, land.Owner
+}
+
 // --- Organizations ---
 
 func (w *World) orgTreasury(o *Organization) map[string]int {
@@ -181,6 +246,14 @@ const (
 
 type Law = lawspkg.Law
 
+func (w *World) lawTemplate(templateID string) (lawspkg.Template, bool) {
+	t, ok := w.catalogs.Laws.ByID[templateID]
+	if !ok {
+		return lawspkg.Template{}, false
+	}
+	return lawspkg.Template{ID: t.ID, Title: t.Title, Params: t.Params, Limits: t.Limits, Rules: t.Rules}, true
+}
+
 func (w *World) newLawID() string {
 	n := w.nextLawNum.Add(1)
 	return fmt.Sprintf("LAW%06d", n)
@@ -196,7 +269,11 @@ func (w *World) tickLaws(nowTick uint64) {
 			if land == nil {
 				return fmt.Errorf("land not found")
 			}
-			return lawsruntimepkg.ApplyTemplateToLand(law, land)
+			tmpl, ok := w.lawTemplate(law.TemplateID)
+			if !ok {
+				return lawspkg.ErrUnsupportedLawTemplate
+			}
+			return lawsruntimepkg.ApplyTemplateToLand(law, tmpl, land)
 		},
 		OnActivated: func(law *lawspkg.Law, yes int, no int) {
 			if proposer := w.agents[law.ProposedBy]; proposer != nil {
@@ -0,0 +1,77 @@
+package world
+
+import gravitypkg "voxelcraft.ai/internal/sim/world/logic/gravity"
+
+// gravityMaxMoves bounds the blocks moved by gravity in one tick. Anything still
+// unsupported after that stays in place until it is touched again.
+const gravityMaxMoves = 4096
+
+// systemGravity drops unsupported falling blocks (catalog flag "gravity") that were
+// touched this tick, then drops every agent and mob with nothing solid under it onto
+// whatever is below. Anyone standing on a block stays put, so caves and tunnels below
+// the surface hold them. Flat worlds have nothing to fall onto and skip it entirely.
+func (w *World) systemGravity(nowTick uint64) {
+	if w.cfg.Height <= 1 {
+		return
+	}
+	air := w.chunks.gen.Air
+	empty := func(p gravitypkg.Pos) bool {
+		return w.chunks.GetBlock(Vec3i{X: p.X, Y: p.Y, Z: p.Z}) == air
+	}
+	moves := 0
+	for len(w.gravityQueue) > 0 && moves < gravityMaxMoves {
+		queue := w.gravityQueue
+		w.gravityQueue = nil
+		for _, q := range queue {
+			for _, c := range gravitypkg.Candidates(gravitypkg.Pos{X: q.X, Y: q.Y, Z: q.Z}) {
+				from := Vec3i{X: c.X, Y: c.Y, Z: c.Z}
+				if moves >= gravityMaxMoves || !w.chunks.inBounds(from) {
+					continue
+				}
+				b := w.chunks.GetBlock(from)
+				if !w.blockFalls(b) {
+					continue
+				}
+				land, ok := gravitypkg.Landing(c, empty)
+				if !ok {
+					continue
+				}
+				to := Vec3i{X: land.X, Y: land.Y, Z: land.Z}
+				w.chunks.SetBlock(from, air)
+				w.auditSetBlock(nowTick, "WORLD", from, b, air, "GRAVITY")
+				w.chunks.SetBlock(to, b)
+				w.auditSetBlock(nowTick, "WORLD", to, air, b, "GRAVITY")
+				moves++
+			}
+		}
+	}
+	w.gravityQueue = w.gravityQueue[:0]
+
+	for _, a := range w.agents {
+		if a != nil {
+			a.Pos = w.fallTo(a.Pos)
+		}
+	}
+	for _, m := range w.mobs {
+		if m != nil {
+			m.Pos = w.fallTo(m.Pos)
+		}
+	}
+}
+
+// fallTo is where an agent or mob at pos comes to rest: pos itself when it stands on a
+// solid block or the world floor.
+func (w *World) fallTo(pos Vec3i) Vec3i {
+	to, ok := gravitypkg.Landing(gravitypkg.Pos{X: pos.X, Y: pos.Y, Z: pos.Z}, func(p gravitypkg.Pos) bool {
+		return !w.blockSolid(w.chunks.GetBlock(Vec3i{X: p.X, Y: p.Y, Z: p.Z}))
+	})
+	if !ok {
+		return pos
+	}
+	return Vec3i{X: to.X, Y: to.Y, Z: to.Z}
+}
+
+func (w *World) blockFalls(b uint16) bool {
+	def, ok := w.catalogs.Blocks.Defs[w.blockName(b)]
+	return ok && def.Gravity
+}
@@ -1,7 +1,9 @@
 package world
 
 import (
+	"voxelcraft.ai/internal/sim/catalogs"
 	economyinstantspkg "voxelcraft.ai/internal/sim/world/feature/economy/instants"
+	inventorypkg "voxelcraft.ai/internal/sim/world/feature/economy/inventory"
 	taxpkg "voxelcraft.ai/internal/sim/world/feature/economy/tax"
 	governanceinstantspkg "voxelcraft.ai/internal/sim/world/feature/governance/instants"
 	lawspkg "voxelcraft.ai/internal/sim/world/feature/governance/laws"
@@ -12,6 +14,7 @@ import (
 	observerpostinginstctxpkg "voxelcraft.ai/internal/sim/world/featurectx/instants/observerposting"
 	sessioninstctxpkg "voxelcraft.ai/internal/sim/world/featurectx/instants/session"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	rulespkg "voxelcraft.ai/internal/sim/world/policy/rules"
 )
 
 func newSessionInstantsEnv(w *World) sessioninstctxpkg.Env {
@@ -61,24 +64,23 @@ func newEconomyInstantsEnv(w *World) economyinstctxpkg.Env {
 			if tr == nil || from == nil || to == nil {
 				return economyinstantspkg.TradeTaxResolution{}
 			}
-			landFrom, _ := w.permissionsFor(from.ID, from.Pos)
-			landTo, _ := w.permissionsFor(to.ID, to.Pos)
-			res := economyinstantspkg.TradeTaxResolution{}
+			landFrom := w.landAt(from.Pos)
+			landTo := w.landAt(to.Pos)
+			items := inventorypkg.SortedItemKeys(tr.Offer, tr.Request)
+			dFrom := w.lawDecision(from.ID, from.Pos, rulespkg.ActionTrade, items, nowTick)
+			dTo := w.lawDecision(to.ID, to.Pos, rulespkg.ActionTrade, items, nowTick)
+			res := economyinstantspkg.TradeTaxResolution{Denied: !dFrom.Allowed || !dTo.Allowed}
 			if landFrom != nil && landTo != nil {
-				res.Rate = taxpkg.EffectiveMarketTax(landFrom.MarketTax, landFrom.LandID == landTo.LandID, w.activeEventID, nowTick, w.activeEventEnds)
+				res.Rate = taxpkg.EffectiveMarketTax(dFrom.TaxRate, landFrom.LandID == landTo.LandID, w.activeEventID, nowTick, w.activeEventEnds)
 			}
 			if res.Rate <= 0 || landFrom == nil || landFrom.Owner == "" {
 				return res
 			}
-			if owner := w.agents[landFrom.Owner]; owner != nil {
-				res.Sink = owner.Inventory
-			} else if org := w.orgByID(landFrom.Owner); org != nil {
-				res.Sink = w.orgTreasury(org)
-			}
+			res.Sink, res.TaxTo = w.landTaxSink(landFrom)
 			res.LandID = landFrom.LandID
-			res.TaxTo = landFrom.Owner
 			return res
 		},
+		ItemValueFn: w.itemValue,
 	}
 }
 
@@ -113,6 +115,8 @@ func newContractInstantsEnv(w *World) contractsinstctxpkg.Env {
 			}
 			return w.structureStable(&bp, c.Anchor, c.Rotation)
 		},
+		GetLandFn:     func(landID string) *modelpkg.LandClaim { return w.claims[landID] },
+		LandPledgedFn: w.landPledged,
 	}
 }
 
@@ -138,6 +142,7 @@ func newConveyorInstantsEnv(w *World) conveyorinstctxpkg.Env {
 				w.switches = map[Vec3i]bool{}
 			}
 			w.switches[pos] = on
+			w.wakeSignal(pos)
 		},
 		SwitchIDAtFn: switchIDAt,
 		AuditSwitchToggleFn: func(nowTick uint64, actorID string, pos modelpkg.Vec3i, switchID string, on bool) {
@@ -146,6 +151,25 @@ func newConveyorInstantsEnv(w *World) conveyorinstctxpkg.Env {
 				"on":        on,
 			})
 		},
+		SetSensorFn: w.setSensor,
+		AuditSensorSetFn: func(nowTick uint64, actorID string, pos modelpkg.Vec3i, mode string, arg int) {
+			w.auditEvent(nowTick, actorID, "SENSOR_SET", pos, "SET_SENSOR", map[string]any{
+				"sensor_id": containerID("SENSOR", pos),
+				"mode":      mode,
+				"arg":       arg,
+			})
+		},
+		GetRecipeFn: func(recipeID string) (catalogs.RecipeDef, bool) {
+			rec, ok := w.catalogs.Recipes.ByID[recipeID]
+			return rec, ok
+		},
+		SetMachineRecipeFn: w.setMachineRecipe,
+		AuditMachineSetFn: func(nowTick uint64, actorID string, pos modelpkg.Vec3i, recipeID string) {
+			w.auditEvent(nowTick, actorID, "MACHINE_SET", pos, "SET_RECIPE", map[string]any{
+				"machine_id": containerID("AUTO_CRAFTER", pos),
+				"recipe_id":  recipeID,
+			})
+		},
 		BumpLawRepFn: w.bumpRepLaw,
 		RecordDeniedFn: func(nowTick uint64) {
 			if w.stats != nil {
@@ -183,6 +207,7 @@ func newGovernanceClaimInstantsEnv(w *World) governanceinstctxpkg.ClaimEnv {
 		OwnerExistsFn: func(ownerID string) bool {
 			return w.agents[ownerID] != nil || w.orgByID(ownerID) != nil
 		},
+		LandPledgedFn:     w.landPledged,
 		AuditClaimEventFn: w.auditEvent,
 	}
 }
@@ -192,15 +217,9 @@ func newGovernanceLawInstantsEnv(w *World) governanceinstctxpkg.LawEnv {
 		return governanceinstctxpkg.LawEnv{}
 	}
 	return governanceinstctxpkg.LawEnv{
-		GetLandFn:      func(landID string) *modelpkg.LandClaim { return w.claims[landID] },
-		IsLandMemberFn: w.isLandMember,
-		GetLawTemplateTitleFn: func(templateID string) (string, bool) {
-			tmpl, ok := w.catalogs.Laws.ByID[templateID]
-			if !ok {
-				return "", false
-			}
-			return tmpl.Title, true
-		},
+		GetLandFn:        func(landID string) *modelpkg.LandClaim { return w.claims[landID] },
+		IsLandMemberFn:   w.isLandMember,
+		GetLawTemplateFn: w.lawTemplate,
 		ItemExistsFn: func(itemID string) bool {
 			_, ok := w.catalogs.Items.Defs[itemID]
 			return ok
@@ -66,8 +66,11 @@ func handleInstantSubmitContract(w *World, a *Agent, inst protocol.InstantReq, n
 				switch c.Kind {
 				case "GATHER", "DELIVER":
 					w.addTradeCredit(nowTick, a.ID, c.Poster, c.Kind)
+					w.observeContractPrices(c, nowTick)
 				case "BUILD":
 					w.addBuildCredit(nowTick, a.ID, c.Poster, c.Kind)
+				case "LOAN":
+					w.addTradeCredit(nowTick, a.ID, c.Poster, c.Kind)
 				}
 				w.auditEvent(nowTick, a.ID, "CONTRACT_COMPLETE", term.Pos, "SUBMIT_CONTRACT",
 					auditpkg.BuildSubmitAuditFields(c.ContractID, term.ID(), c.Kind, c.Poster, c.Acceptor))
@@ -79,6 +82,38 @@ func handleInstantSubmitContract(w *World, a *Agent, inst protocol.InstantReq, n
 	)
 }
 
+func handleInstantBidAuction(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	contractinstantspkg.HandleBidAuction(
+		newContractInstantsEnv(w),
+		actionResult,
+		contractinstantspkg.AuctionBidHooks{
+			OnBid: func(out contractinstantspkg.AuctionBidOutcome) {
+				c := out.Contract
+				term := out.Terminal
+				w.auditEvent(nowTick, a.ID, "AUCTION_BID", term.Pos, "BID_AUCTION",
+					auditpkg.BuildBidAuditFields(c.ContractID, term.ID(), c.AuctionType, c.PriceItem, out.Bid.Amount))
+				for _, b := range out.Released {
+					if b.Bidder == a.ID {
+						continue
+					}
+					if outbid := w.agents[b.Bidder]; outbid != nil {
+						outbid.AddEvent(protocol.Event{
+							"t":           nowTick,
+							"type":        "AUCTION_OUTBID",
+							"contract_id": c.ContractID,
+							"terminal_id": term.ID(),
+							"refund":      inventorypkg.EncodeItemPairs(map[string]int{c.PriceItem: b.Amount}),
+						})
+					}
+				}
+			},
+		},
+		a,
+		inst,
+		nowTick,
+	)
+}
+
 func handleInstantToggleSwitch(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
 	conveyorinstantspkg.HandleToggleSwitch(
 		newConveyorInstantsEnv(w),
@@ -10,17 +10,26 @@ var instantDispatch = map[string]instantHandler{
 	InstantTypeEat:            handleInstantEat,
 	InstantTypeSaveMemory:     handleInstantSaveMemory,
 	InstantTypeLoadMemory:     handleInstantLoadMemory,
+	InstantTypeSetView:        handleInstantSetView,
+	InstantTypeSetTrigger:     handleInstantSetTrigger,
+	InstantTypeRemoveTrigger:  handleInstantRemoveTrigger,
 	InstantTypeOfferTrade:     handleInstantOfferTrade,
 	InstantTypeAcceptTrade:    handleInstantAcceptTrade,
 	InstantTypeDeclineTrade:   handleInstantDeclineTrade,
+	InstantTypePostOrder:      handleInstantPostOrder,
+	InstantTypeCancelOrder:    handleInstantCancelOrder,
+	InstantTypeSetShopPrice:   handleInstantSetShopPrice,
 	InstantTypePostBoard:      handleInstantPostBoard,
 	InstantTypeSearchBoard:    handleInstantSearchBoard,
 	InstantTypeSetSign:        handleInstantSetSign,
 	InstantTypeToggleSwitch:   handleInstantToggleSwitch,
+	InstantTypeSetSensor:      handleInstantSetSensor,
+	InstantTypeSetRecipe:      handleInstantSetRecipe,
 	InstantTypeClaimOwed:      handleInstantClaimOwed,
 	InstantTypePostContract:   handleInstantPostContract,
 	InstantTypeAcceptContract: handleInstantAcceptContract,
 	InstantTypeSubmitContract: handleInstantSubmitContract,
+	InstantTypeBidAuction:     handleInstantBidAuction,
 	InstantTypeSetPermissions: handleInstantSetPermissions,
 	InstantTypeUpgradeClaim:   handleInstantUpgradeClaim,
 	InstantTypeAddMember:      handleInstantAddMember,
@@ -33,4 +42,5 @@ var instantDispatch = map[string]instantHandler{
 	InstantTypeDeedLand:       handleInstantDeedLand,
 	InstantTypeProposeLaw:     handleInstantProposeLaw,
 	InstantTypeVote:           handleInstantVote,
+	InstantTypeEquip:          handleInstantEquip,
 }
@@ -40,6 +40,7 @@ func handleInstantAcceptTrade(w *World, a *Agent, inst protocol.InstantReq, nowT
 					"land_id":        out.Tax.LandID,
 					"tax_to":         out.Tax.TaxTo,
 				})
+				w.observePrices(out.Trade.From, out.Trade.To, out.Trade.Offer, out.Trade.Request, nowTick)
 				w.bumpRepTrade(out.From.ID, 2)
 				w.bumpRepTrade(out.To.ID, 2)
 				if out.MutualOK {
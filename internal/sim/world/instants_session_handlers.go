@@ -3,6 +3,7 @@ package world
 import (
 	"voxelcraft.ai/internal/protocol"
 	sessioninstantspkg "voxelcraft.ai/internal/sim/world/feature/session/instants"
+	viewpkg "voxelcraft.ai/internal/sim/world/feature/session/view"
 )
 
 func handleInstantSay(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
@@ -47,3 +48,11 @@ func handleInstantSaveMemory(_ *World, a *Agent, inst protocol.InstantReq, nowTi
 func handleInstantLoadMemory(_ *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
 	sessioninstantspkg.HandleLoadMemory(actionResult, a, inst, nowTick)
 }
+
+func handleInstantSetView(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	sessioninstantspkg.HandleSetView(actionResult, a, inst, nowTick, w.viewLimits())
+}
+
+func (w *World) viewLimits() viewpkg.Limits {
+	return viewpkg.Limits{MinRadius: w.cfg.ObsRadiusMin, MaxRadius: w.cfg.ObsRadiusMax}
+}
@@ -16,6 +16,8 @@ type Agent struct {
 
 	CurrentWorldID               string
 	WorldSwitchCooldownUntilTick uint64
+	// World permits held: permit id -> ticks left inside the worlds it gates (0 = never expires).
+	Permits map[string]uint64
 
 	// ResumeToken is a transport-level token used for reconnects.
 	// It is intentionally NOT included in snapshots/digests.
@@ -40,6 +42,12 @@ type Agent struct {
 
 	MoveTask *tasks.MovementTask
 	WorkTask *tasks.WorkTask
+	// Ordered plan of tasks started one at a time as slots free up (ENQUEUE).
+	TaskQueue []QueuedTask
+	// Reactive rules (SET_TRIGGER), sorted by ID.
+	Triggers []Trigger
+	// Events up to this cursor were already matched against Triggers (not persisted).
+	TriggerCursor uint64
 
 	Events []protocol.Event
 	// Monotonic count of events delivered to this agent via OBS.
@@ -60,6 +68,9 @@ type Agent struct {
 	Memory map[string]MemoryEntry
 	// If set in this tick, OBS will include it.
 	PendingMemory []protocol.MemoryKV
+
+	// OBS view configuration (SET_VIEW).
+	View View
 }
 
 type Equipment struct {
@@ -174,6 +185,7 @@ func (a *Agent) AddEvent(e protocol.Event) {
 	if len(a.EventLog) > 4096 {
 		a.EventLog = append([]eventLogEntry(nil), a.EventLog[len(a.EventLog)-4096:]...)
 	}
+	a.recordQueuedEnd(e)
 }
 
 func (a *Agent) TakeEvents() []protocol.Event {
@@ -521,4 +533,3 @@ func minInt(a, b int) int {
 	}
 	return b
 }
-
@@ -1,5 +1,7 @@
 package model
 
+import "voxelcraft.ai/internal/sim/world/policy/rules"
+
 type ClaimFlags struct {
 	AllowBuild  bool
 	AllowBreak  bool
@@ -22,21 +24,8 @@ type LandClaim struct {
 	Flags     ClaimFlags
 	Members   map[string]bool // agent ids
 
-	// MVP configurable parameters (via laws or direct settings).
-	MarketTax     float64 // 0..1
-	CurfewEnabled bool
-	CurfewStart   float64 // 0..1 time_of_day
-	CurfewEnd     float64 // 0..1 time_of_day
-
-	// Law: fine for illegal break attempts.
-	FineBreakEnabled  bool
-	FineBreakItem     string
-	FineBreakPerBlock int
-
-	// Law: access pass for the claim's "core" area.
-	AccessPassEnabled bool
-	AccessTicketItem  string
-	AccessTicketCost  int
+	// Active law policies, one per template (see policy/rules).
+	Policies []rules.Policy
 
 	// Maintenance: stage 0=ok, 1=late (no expansion), 2=unprotected.
 	MaintenanceDueTick uint64
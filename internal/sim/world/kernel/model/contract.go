@@ -25,8 +25,39 @@ type Contract struct {
 	Anchor      Vec3i
 	Rotation    int
 
+	// LOAN contracts: Reward is the principal, Requirements the total repayment
+	// (principal plus InterestPct), Deposit the collateral items. A loan posted without
+	// collateral items is secured by the land the borrower pledges on acceptance
+	// (CollateralLand). While the loan is OPEN, installment due ticks are offsets from
+	// acceptance.
+	InterestPct    int
+	CollateralLand string
+	Installments   []LoanInstallment
+
+	// AUCTION contracts: Reward is the lot. Bids of PriceItem at or above ReservePrice
+	// are escrowed at the terminal; the highest wins at DeadlineTick and becomes the
+	// Acceptor. An ENGLISH auction holds only the leading bid, a SEALED one a bid per
+	// bidder.
+	AuctionType  string
+	PriceItem    string
+	ReservePrice int
+	Bids         []AuctionBid
+
 	CreatedTick  uint64
 	DeadlineTick uint64
 	State        ContractState
 }
 
+// LoanInstallment is one scheduled repayment of a LOAN contract.
+type LoanInstallment struct {
+	DueTick uint64
+	Items   map[string]int
+	Paid    bool
+}
+
+// AuctionBid is one escrowed bid on an AUCTION contract.
+type AuctionBid struct {
+	Bidder string
+	Amount int
+	Tick   uint64
+}
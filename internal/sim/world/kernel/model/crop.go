@@ -0,0 +1,9 @@
+package model
+
+// Crop is the growth state of a planted crop block (Type is the block name).
+type Crop struct {
+	Type        string
+	Stage       int // 0 = planted
+	Growth      int // points toward the next stage
+	PlantedTick uint64
+}
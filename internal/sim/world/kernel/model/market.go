@@ -0,0 +1,32 @@
+package model
+
+type OrderSide string
+
+const (
+	OrderBuy  OrderSide = "BUY"
+	OrderSell OrderSide = "SELL"
+)
+
+// MarketOrder is a resting limit order at a MARKET_TERMINAL. Price is the number of
+// PriceItem paid per unit of Item and Count is what is left to fill. The terminal
+// container holds the escrow as Reserved items: Count Item for a sell, Count*Price
+// PriceItem for a buy.
+type MarketOrder struct {
+	OrderID     string
+	TerminalPos Vec3i
+	Owner       string
+	Side        OrderSide
+	Item        string
+	PriceItem   string
+	Price       int
+	Count       int
+	CreatedTick uint64
+}
+
+// Escrow is the item and amount the terminal holds for the unfilled part of the order.
+func (o *MarketOrder) Escrow() (item string, n int) {
+	if o.Side == OrderBuy {
+		return o.PriceItem, o.Count * o.Price
+	}
+	return o.Item, o.Count
+}
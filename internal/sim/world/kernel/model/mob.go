@@ -0,0 +1,21 @@
+package model
+
+// Mob is a director-spawned NPC (hostile or neutral) with a deterministic behaviour state.
+// It is part of the authoritative sim state and must be snapshot/digest'd.
+type Mob struct {
+	EntityID string
+	Kind     string
+	Pos      Vec3i
+	Home     Vec3i // guard/wander anchor
+	HP       int
+
+	State      string
+	TargetID   string // agent being chased or fled from
+	StateTick  uint64 // tick the current state was entered
+	AttackTick uint64 // last strike tick
+
+	CreatedTick uint64
+	DespawnTick uint64 // 0 = never
+}
+
+func (m *Mob) ID() string { return m.EntityID }
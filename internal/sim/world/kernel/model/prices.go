@@ -0,0 +1,15 @@
+package model
+
+// PriceSample is one observed unit price of an item, in milli reference units, weighted
+// by the number of units that changed hands.
+type PriceSample struct {
+	Tick   uint64
+	Price  int64
+	Volume int
+}
+
+// PriceSeries is the rolling window of observed prices for one item, oldest first.
+type PriceSeries struct {
+	Item    string
+	Samples []PriceSample
+}
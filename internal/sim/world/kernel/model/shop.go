@@ -0,0 +1,14 @@
+package model
+
+// Shop is the price list of a SHOP block. Stock and takings live in the SHOP
+// container; Prices maps an item to what one unit of it costs.
+type Shop struct {
+	Owner  string
+	Prices map[string]ShopPrice
+}
+
+// ShopPrice is the cost of one unit: Price of PriceItem.
+type ShopPrice struct {
+	PriceItem string
+	Price     int
+}
@@ -16,3 +16,25 @@ type ConveyorMeta struct {
 	DX int8 // -1,0,1
 	DZ int8 // -1,0,1
 }
+
+// SignalNode is the persistent state of a signal block (WIRE, SENSOR, BATTERY or a
+// gate): its output strength plus the fields its kind uses.
+type SignalNode struct {
+	Level  int  // output strength, 0..15
+	DX     int8 // output side of gates and batteries
+	DZ     int8
+	Charge int    // BATTERY
+	Mode   string // SENSOR mode ("" = ITEMS)
+	Arg    int    // SENSOR radius (AGENT) or threshold (FILL)
+}
+
+// MachineMeta is the runtime state of an automated machine (FURNACE, AUTO_CRAFTER
+// or HOPPER). Idle furnaces and unbound crafters have no entry.
+type MachineMeta struct {
+	DX       int8   // HOPPER output side
+	DZ       int8   // HOPPER output side
+	Owner    string // HOPPER placer; container pulls need its withdraw permission
+	RecipeID string // AUTO_CRAFTER binding; FURNACE: the recipe in progress
+	Progress int    // ticks worked on the current recipe
+	Fuel     int    // powered ticks left from burnt fuel
+}
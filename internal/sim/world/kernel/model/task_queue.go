@@ -0,0 +1,28 @@
+package model
+
+import "voxelcraft.ai/internal/protocol"
+
+// QueuedTask is an entry of Agent.TaskQueue; the head may be running.
+type QueuedTask struct {
+	Req       protocol.TaskReq
+	Attempts  int    // failed attempts so far (on_fail=RETRY)
+	TaskID    string // set while the head runs
+	NotBefore uint64 // retry waits until this tick
+	// How the running head ended (TASK_DONE/TASK_FAIL and its code), until the queue settles it.
+	EndType string
+	EndCode string
+}
+
+// recordQueuedEnd keeps the final status of the running queue head when its
+// TASK_DONE/TASK_FAIL event is emitted.
+func (a *Agent) recordQueuedEnd(e protocol.Event) {
+	if len(a.TaskQueue) == 0 || a.TaskQueue[0].TaskID == "" || e["task_id"] != a.TaskQueue[0].TaskID {
+		return
+	}
+	switch t, _ := e["type"].(string); t {
+	case "TASK_DONE", "TASK_FAIL":
+		head := &a.TaskQueue[0]
+		head.EndType = t
+		head.EndCode, _ = e["code"].(string)
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import "voxelcraft.ai/internal/protocol"
+
+// Trigger is a normalized SET_TRIGGER rule.
+type Trigger struct {
+	ID            string
+	On            string
+	Where         []TriggerCond
+	Instant       *protocol.InstantReq
+	Task          *protocol.TaskReq
+	CooldownTicks int
+	MaxFires      int
+
+	Fires        int
+	LastFireTick uint64 // valid when Fires > 0
+}
+
+// TriggerCond compares Field with Str (IsStr) or Num.
+type TriggerCond struct {
+	Field string
+	Op    string
+	Str   string
+	Num   float64
+	IsStr bool
+}
+
+// CloneTriggers copies rules for transfer; actions are never mutated after SET_TRIGGER,
+// so they are copied one level deep.
+func CloneTriggers(ts []Trigger) []Trigger {
+	if len(ts) == 0 {
+		return nil
+	}
+	out := make([]Trigger, len(ts))
+	for i, t := range ts {
+		t.Where = append([]TriggerCond(nil), t.Where...)
+		if t.Instant != nil {
+			inst := *t.Instant
+			t.Instant = &inst
+		}
+		if t.Task != nil {
+			tr := *t.Task
+			t.Task = &tr
+		}
+		out[i] = t
+	}
+	return out
+}
+
+// EventsSince returns logged events with cursor > cursor (oldest first).
+func (a *Agent) EventsSince(cursor uint64) []protocol.Event {
+	i := len(a.EventLog)
+	for i > 0 && a.EventLog[i-1].Cursor > cursor {
+		i--
+	}
+	out := make([]protocol.Event, 0, len(a.EventLog)-i)
+	for _, e := range a.EventLog[i:] {
+		out = append(out, e.Event)
+	}
+	return out
+}
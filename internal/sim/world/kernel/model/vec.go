@@ -1,5 +1,7 @@
 package model
 
+import "sort"
+
 type Vec3i struct {
 	X int
 	Y int
@@ -23,3 +25,24 @@ func Manhattan(a, b Vec3i) int {
 	}
 	return dx + dy + dz
 }
+
+// PosLess orders positions by X, then Y, then Z.
+func PosLess(a, b Vec3i) bool {
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	if a.Y != b.Y {
+		return a.Y < b.Y
+	}
+	return a.Z < b.Z
+}
+
+// SortedPositions returns the keys of m in PosLess order, for deterministic iteration.
+func SortedPositions[V any](m map[Vec3i]V) []Vec3i {
+	out := make([]Vec3i, 0, len(m))
+	for p := range m {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return PosLess(out[i], out[j]) })
+	return out
+}
@@ -0,0 +1,70 @@
+package model
+
+import "voxelcraft.ai/internal/protocol"
+
+// OBS sections an agent can hide via SET_VIEW.
+const (
+	ViewSectionVoxels       = "voxels"
+	ViewSectionEntities     = "entities"
+	ViewSectionPublicBoards = "public_boards"
+	ViewSectionMarkets      = "markets"
+	ViewSectionPrices       = "prices"
+	ViewSectionMemory       = "memory"
+	ViewSectionFunScore     = "fun_score"
+)
+
+// View is an agent's OBS configuration; the zero value is the server default.
+type View struct {
+	Hidden      []string // sorted hidden sections
+	ObsRadius   int      // 0 = world default
+	EntityTypes []string // sorted; empty = all
+	MaxEvents   int      // 0 = unlimited
+}
+
+func (v View) IsZero() bool {
+	return len(v.Hidden) == 0 && v.ObsRadius == 0 && len(v.EntityTypes) == 0 && v.MaxEvents == 0
+}
+
+func (v View) Shows(section string) bool {
+	for _, s := range v.Hidden {
+		if s == section {
+			return false
+		}
+	}
+	return true
+}
+
+func (v View) ShowsEntity(typ string) bool {
+	if len(v.EntityTypes) == 0 {
+		return true
+	}
+	for _, t := range v.EntityTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Clone copies the slices so the view can cross world/snapshot boundaries.
+func (v View) Clone() View {
+	v.Hidden = append([]string(nil), v.Hidden...)
+	v.EntityTypes = append([]string(nil), v.EntityTypes...)
+	if len(v.Hidden) == 0 {
+		v.Hidden = nil
+	}
+	if len(v.EntityTypes) == 0 {
+		v.EntityTypes = nil
+	}
+	return v
+}
+
+// TakeEventsN takes up to max pending events (max <= 0 takes all); the rest stay queued.
+func (a *Agent) TakeEventsN(max int) []protocol.Event {
+	if max <= 0 || len(a.Events) <= max {
+		return a.TakeEvents()
+	}
+	ev := append([]protocol.Event(nil), a.Events[:max]...)
+	a.Events = append(a.Events[:0], a.Events[max:]...)
+	return ev
+}
@@ -0,0 +1,24 @@
+package gravity
+
+type Pos struct {
+	X int
+	Y int
+	Z int
+}
+
+// Landing returns where a block at p comes to rest if it falls: the lowest cell
+// of the run of empty cells directly below it. ok=false means p is supported
+// (by a block or by the world floor at y=0).
+func Landing(p Pos, empty func(Pos) bool) (Pos, bool) {
+	to := p
+	for to.Y > 0 && empty(Pos{X: to.X, Y: to.Y - 1, Z: to.Z}) {
+		to.Y--
+	}
+	return to, to != p
+}
+
+// Candidates lists the cells whose support may have changed after a block was
+// set at p: the cell itself and the one above it, in that order.
+func Candidates(p Pos) [2]Pos {
+	return [2]Pos{p, {X: p.X, Y: p.Y + 1, Z: p.Z}}
+}
@@ -0,0 +1,21 @@
+package gravity
+
+import "testing"
+
+func TestLanding(t *testing.T) {
+	// Column x=0,z=0: solid at y=0 and y=1, empty at y=2..4.
+	empty := func(p Pos) bool { return p.Y >= 2 }
+
+	if to, ok := Landing(Pos{Y: 5}, empty); !ok || to != (Pos{Y: 2}) {
+		t.Fatalf("Landing(y=5) = %+v,%v, want y=2,true", to, ok)
+	}
+	if _, ok := Landing(Pos{Y: 2}, empty); ok {
+		t.Fatalf("block resting on y=1 should be supported")
+	}
+	if _, ok := Landing(Pos{Y: 0}, func(Pos) bool { return true }); ok {
+		t.Fatalf("world floor should support y=0")
+	}
+	if to, ok := Landing(Pos{Y: 3}, func(Pos) bool { return true }); !ok || to.Y != 0 {
+		t.Fatalf("fall through empty column = %+v,%v, want y=0", to, ok)
+	}
+}
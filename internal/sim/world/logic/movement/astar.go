@@ -0,0 +1,189 @@
+package movement
+
+import "container/heap"
+
+// FindPath2D runs a bounded A* search over the y=0 tilemap from start towards
+// target. A cell is considered a goal when its XZ manhattan distance to target
+// is <= tolerance.
+//
+// The search expands at most maxNodes cells. If no goal is reached within the
+// budget, the returned path leads to the expanded cell closest to the target
+// (ties broken by lower cost, then discovery order), so repeated calls always
+// make strict progress. stepCost returns the extra cost of stepping into a
+// cell (0 for plain ground) and may be nil.
+//
+// The returned path excludes start. reached reports whether the last node is a
+// goal. An empty path with reached=false means no cell closer to target is
+// reachable within the budget.
+func FindPath2D(start, target Pos, tolerance int, maxNodes int, inBounds func(Pos) bool, isSolid func(Pos) bool, stepCost func(Pos) int) (path []Pos, reached bool) {
+	var step func(from, to Pos) (Pos, bool)
+	if isSolid != nil {
+		step = func(_, to Pos) (Pos, bool) { return to, !isSolid(to) }
+	}
+	start.Y = 0
+	return FindPathSurface(start, target, tolerance, maxNodes, inBounds, step, stepCost)
+}
+
+// FindPathSurface is FindPath2D over standing cells instead of a flat tilemap:
+// step resolves a move from a cell into the neighbouring column (to carries the
+// from height) to the cell actually stood on, or rejects it (for example a
+// climb limit). Cells are keyed by their height too, so a column can be walked
+// at different floors. step may be nil (flat moves); the distance to target
+// ignores height.
+func FindPathSurface(start, target Pos, tolerance int, maxNodes int, inBounds func(Pos) bool, step func(from, to Pos) (Pos, bool), stepCost func(Pos) int) (path []Pos, reached bool) {
+	target.Y = 0
+	if tolerance < 0 {
+		tolerance = 0
+	}
+	h := func(p Pos) int {
+		d := distXZ(p, target) - tolerance
+		if d < 0 {
+			return 0
+		}
+		return d
+	}
+	if h(start) == 0 {
+		return nil, true
+	}
+	if maxNodes <= 0 {
+		return nil, false
+	}
+
+	// Neighbor order is fixed per search for determinism: the primary axis towards
+	// target first (same preference as the greedy step), then the secondary axis.
+	dirs := searchDirs(target.X-start.X, target.Z-start.Z)
+
+	type nodeInfo struct {
+		parent Pos
+		g      int
+		closed bool
+	}
+	nodes := make(map[Pos]*nodeInfo, 256)
+	nodes[start] = &nodeInfo{parent: start}
+
+	open := &astarHeap{}
+	seq := 0
+	heap.Push(open, astarItem{p: start, f: h(start), h: h(start), seq: seq})
+
+	best := start
+	bestH := h(start)
+	bestG := 0
+	expanded := 0
+
+	for open.Len() > 0 && expanded < maxNodes {
+		it := heap.Pop(open).(astarItem)
+		n := nodes[it.p]
+		if n == nil || n.closed || it.f-it.h != n.g {
+			// Stale heap entry (a cheaper route was found later).
+			continue
+		}
+		n.closed = true
+		expanded++
+
+		if it.h < bestH || (it.h == bestH && n.g < bestG) {
+			best = it.p
+			bestH = it.h
+			bestG = n.g
+		}
+		if it.h == 0 {
+			break
+		}
+
+		for _, d := range dirs {
+			np := Pos{X: it.p.X + d.X, Y: it.p.Y, Z: it.p.Z + d.Z}
+			if inBounds != nil && !inBounds(np) {
+				continue
+			}
+			if step != nil {
+				var ok bool
+				if np, ok = step(it.p, np); !ok {
+					continue
+				}
+			}
+			g := n.g + 1
+			if stepCost != nil {
+				if c := stepCost(np); c > 0 {
+					g += c
+				}
+			}
+			nn := nodes[np]
+			if nn != nil && (nn.closed || nn.g <= g) {
+				continue
+			}
+			if nn == nil {
+				nn = &nodeInfo{}
+				nodes[np] = nn
+			}
+			nn.parent = it.p
+			nn.g = g
+			seq++
+			nh := h(np)
+			heap.Push(open, astarItem{p: np, f: g + nh, h: nh, seq: seq})
+		}
+	}
+
+	if best == start {
+		return nil, false
+	}
+	for p := best; p != start; p = nodes[p].parent {
+		path = append(path, p)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, bestH == 0
+}
+
+func searchDirs(dx, dz int) []Pos {
+	sx, sz := 1, 1
+	if dx < 0 {
+		sx = -1
+	}
+	if dz < 0 {
+		sz = -1
+	}
+	adx, adz := dx, dz
+	if adx < 0 {
+		adx = -adx
+	}
+	if adz < 0 {
+		adz = -adz
+	}
+	if adx >= adz {
+		return []Pos{{X: sx}, {Z: sz}, {Z: -sz}, {X: -sx}}
+	}
+	return []Pos{{Z: sz}, {X: sx}, {X: -sx}, {Z: -sz}}
+}
+
+type astarItem struct {
+	p   Pos
+	f   int
+	h   int
+	seq int
+}
+
+type astarHeap []astarItem
+
+func (q astarHeap) Len() int { return len(q) }
+
+func (q astarHeap) Less(i, j int) bool {
+	if q[i].f != q[j].f {
+		return q[i].f < q[j].f
+	}
+	if q[i].h != q[j].h {
+		return q[i].h < q[j].h
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q astarHeap) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *astarHeap) Push(x any) { *q = append(*q, x.(astarItem)) }
+
+func (q *astarHeap) Pop() any {
+	old := *q
+	n := len(old)
+	it := old[n-1]
+	*q = old[:n-1]
+	return it
+}
@@ -0,0 +1,75 @@
+package movement
+
+import "testing"
+
+func TestFindPath2DRoutesAroundWall(t *testing.T) {
+	// Wall at x=1 from z=-5..5; the only way to x=3 is around either end.
+	solid := func(p Pos) bool { return p.X == 1 && p.Z >= -5 && p.Z <= 5 }
+	path, reached := FindPath2D(Pos{}, Pos{X: 3}, 0, 1024, nil, solid, nil)
+	if !reached {
+		t.Fatalf("expected goal reached")
+	}
+	if got, want := len(path), 3+2*6; got != want {
+		t.Fatalf("path len=%d want %d: %v", got, want, path)
+	}
+	if last := path[len(path)-1]; last != (Pos{X: 3}) {
+		t.Fatalf("last=%+v", last)
+	}
+	prev := Pos{}
+	for _, p := range path {
+		if distXZ(prev, p) != 1 || solid(p) {
+			t.Fatalf("invalid step %+v -> %+v", prev, p)
+		}
+		prev = p
+	}
+
+	again, _ := FindPath2D(Pos{}, Pos{X: 3}, 0, 1024, nil, solid, nil)
+	if len(again) != len(path) {
+		t.Fatalf("non-deterministic path length")
+	}
+	for i := range path {
+		if again[i] != path[i] {
+			t.Fatalf("non-deterministic path at %d: %+v vs %+v", i, again[i], path[i])
+		}
+	}
+}
+
+func TestFindPath2DNoPathWhenEnclosed(t *testing.T) {
+	solid := func(p Pos) bool { return p != (Pos{}) && distXZ(p, Pos{}) == 1 }
+	path, reached := FindPath2D(Pos{}, Pos{X: 10}, 1, 1024, nil, solid, nil)
+	if reached || len(path) != 0 {
+		t.Fatalf("expected no path, got reached=%v path=%v", reached, path)
+	}
+}
+
+func TestFindPath2DPartialPathWithinBudget(t *testing.T) {
+	path, reached := FindPath2D(Pos{}, Pos{X: 500}, 0, 64, nil, nil, nil)
+	if reached {
+		t.Fatalf("expected budget to stop search before goal")
+	}
+	if len(path) == 0 {
+		t.Fatalf("expected partial path")
+	}
+	if end := path[len(path)-1]; distXZ(end, Pos{X: 500}) >= 500 {
+		t.Fatalf("partial path must make progress: end=%+v", end)
+	}
+}
+
+func TestFindPath2DStepCostAvoidsPenalizedCells(t *testing.T) {
+	// Straight line is penalized; a one-cell detour is cheaper.
+	cost := func(p Pos) int {
+		if p.Z == 0 && p.X >= 1 && p.X <= 3 {
+			return 10
+		}
+		return 0
+	}
+	path, reached := FindPath2D(Pos{}, Pos{X: 4}, 0, 1024, nil, nil, cost)
+	if !reached {
+		t.Fatalf("expected goal reached")
+	}
+	for _, p := range path {
+		if cost(p) > 0 {
+			t.Fatalf("path should avoid penalized cell %+v: %v", p, path)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package movement
+
+type Pos struct {
+	X int
+	Y int
+	Z int
+}
+
+func distXZ(a, b Pos) int {
+	dx := a.X - b.X
+	if dx < 0 {
+		dx = -dx
+	}
+	dz := a.Z - b.Z
+	if dz < 0 {
+		dz = -dz
+	}
+	return dx + dz
+}
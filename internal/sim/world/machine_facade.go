@@ -0,0 +1,100 @@
+package world
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	machinepkg "voxelcraft.ai/internal/sim/world/feature/automation/machine"
+	signalpkg "voxelcraft.ai/internal/sim/world/feature/automation/signal"
+	conveyruntimepkg "voxelcraft.ai/internal/sim/world/feature/conveyor/runtime"
+)
+
+// systemMachines runs furnaces, auto-crafters and hoppers after conveyors have moved.
+func (w *World) systemMachines(nowTick uint64) {
+	hooks := w.signalHooks(nowTick)
+	machinepkg.Step(
+		machinepkg.StepInput{
+			Machines:   w.machines,
+			Containers: w.containers,
+			Recipes:    w.catalogs.Recipes.ByID,
+		},
+		machinepkg.StepHooks{
+			BlockNameAt: hooks.BlockNameAt,
+			Powered: func(pos Vec3i) bool {
+				return signalpkg.Powered(w.signalNodes, hooks, pos)
+			},
+			HopperEnabled: w.conveyorEnabled,
+			CanWithdraw:   w.canWithdrawFromContainer,
+			TakeDropped: func(pos Vec3i) string {
+				return w.takeDroppedItem(nowTick, pos)
+			},
+			BeltFree: func(pos Vec3i) bool {
+				return w.blockName(w.chunks.GetBlock(pos)) == "CONVEYOR" && !w.hasLiveItemAt(pos)
+			},
+			Drop: func(pos Vec3i, item string) {
+				_ = w.spawnItemEntity(nowTick, "WORLD", pos, item, 1, "HOPPER_DROP")
+			},
+			Audit: func(action string, pos Vec3i, details map[string]any) {
+				w.auditEvent(nowTick, "WORLD", action, pos, "MACHINE", details)
+			},
+		},
+	)
+}
+
+// takeDroppedItem removes one unit from the first live item entity at pos.
+func (w *World) takeDroppedItem(nowTick uint64, pos Vec3i) string {
+	for _, id := range w.itemsAt[pos] {
+		e := w.items[id]
+		if e == nil || e.Item == "" || e.Count <= 0 {
+			continue
+		}
+		item := e.Item
+		if e.Count > 1 {
+			e.Count--
+		} else {
+			w.removeItemEntity(nowTick, "WORLD", id, "HOPPER_PULL")
+		}
+		return item
+	}
+	return ""
+}
+
+func (w *World) hasLiveItemAt(pos Vec3i) bool {
+	for _, id := range w.itemsAt[pos] {
+		if e := w.items[id]; e != nil && e.Item != "" && e.Count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// resetMachine clears the state of a freshly placed machine; a hopper starts facing +X.
+func (w *World) resetMachine(pos Vec3i, blockName string) {
+	delete(w.machines, pos)
+	if machinepkg.Faced(blockName) {
+		w.machines[pos] = MachineMeta{DX: 1}
+	}
+}
+
+// setMachinePlacer points a placed hopper at the placer's yaw and records the placer as
+// its owner.
+func (w *World) setMachinePlacer(pos Vec3i, agentID string, yaw int) {
+	dx, dz := conveyruntimepkg.YawToDir(yaw)
+	m := w.machines[pos]
+	m.DX, m.DZ = int8(dx), int8(dz)
+	m.Owner = agentID
+	w.machines[pos] = m
+}
+
+// setMachineRecipe binds an auto-crafter; work on the previous recipe is lost.
+func (w *World) setMachineRecipe(pos Vec3i, recipeID string) {
+	m := w.machines[pos]
+	m.RecipeID, m.Progress = recipeID, 0
+	if m == (MachineMeta{}) {
+		delete(w.machines, pos)
+		return
+	}
+	w.machines[pos] = m
+}
+
+func handleInstantSetRecipe(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	machinepkg.HandleSetRecipe(newConveyorInstantsEnv(w), actionResult, a, inst, nowTick)
+}
@@ -0,0 +1,148 @@
+package world
+
+import (
+	"slices"
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestMachine_PoweredCrafterFeedsChestThroughHopper(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "machine", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "smith", Resp: resp})
+	a := w.agents[(<-resp).Welcome.AgentID]
+
+	c := Vec3i{X: a.Pos.X + 10, Z: a.Pos.Z}
+	for dx := -2; dx <= 6; dx++ {
+		for dz := -3; dz <= 3; dz++ {
+			setAir(w, Vec3i{X: c.X + dx, Z: c.Z + dz})
+		}
+	}
+	place := func(name string, pos Vec3i) {
+		t.Helper()
+		b, ok := w.catalogs.Blocks.Index[name]
+		if !ok {
+			t.Fatalf("unknown block %s", name)
+		}
+		w.chunks.SetBlock(pos, b)
+		w.auditSetBlock(w.CurrentTick(), "TEST", pos, w.chunks.gen.Air, b, "TEST")
+		w.ensureContainerForPlacedBlock(pos, name)
+	}
+	crafter := Vec3i{X: c.X + 1, Z: c.Z}
+	hopper := Vec3i{X: c.X + 2, Z: c.Z}
+	chest := Vec3i{X: c.X + 3, Z: c.Z}
+	place("SWITCH", c)
+	place("AUTO_CRAFTER", crafter)
+	place("HOPPER", hopper)
+	place("CHEST", chest)
+	w.containers[crafter].Inventory["LOG"] = 2
+
+	a.Pos = Vec3i{X: c.X + 1, Z: c.Z + 2}
+	w.step(nil, nil, []ActionEnvelope{{AgentID: a.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: a.ID, Instants: []protocol.InstantReq{
+		{ID: "I_bad", Type: InstantTypeSetRecipe, TargetID: containerID("AUTO_CRAFTER", crafter), RecipeID: "iron_ingot"},
+		{ID: "I_recipe", Type: InstantTypeSetRecipe, TargetID: containerID("AUTO_CRAFTER", crafter), RecipeID: "plank_from_log"},
+		{ID: "I_switch", Type: InstantTypeToggleSwitch, TargetID: switchIDAt(c)},
+	}}}})
+	if m := w.machines[crafter]; m.RecipeID != "plank_from_log" {
+		t.Fatalf("crafter not bound: %+v", m)
+	}
+	if i := slices.IndexFunc(a.Events, func(ev protocol.Event) bool { return ev["ref"] == "I_bad" }); i < 0 || a.Events[i]["ok"] != false {
+		t.Fatalf("furnace recipe must be rejected by the crafter: %v", a.Events)
+	}
+
+	for i := 0; i < 6; i++ {
+		w.step(nil, nil, nil)
+	}
+	ents := w.buildObsEntities(a, nil)
+	if i := slices.IndexFunc(ents, func(e protocol.EntityObs) bool { return e.Type == "AUTO_CRAFTER" }); i < 0 || !slices.Contains(ents[i].Tags, "recipe:plank_from_log") {
+		t.Fatalf("crafter entity: %+v", ents)
+	}
+	if i := slices.IndexFunc(ents, func(e protocol.EntityObs) bool { return e.Type == "HOPPER" }); i < 0 || !slices.Contains(ents[i].Tags, "dir:+X") {
+		t.Fatalf("hopper entity: %+v", ents)
+	}
+
+	// Resume from a snapshot mid-production; both worlds must end up identical.
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	tick := w.CurrentTick() - 1
+	if err := w2.ImportSnapshot(w.ExportSnapshot(tick)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	for _, ww := range []*World{w, w2} {
+		for i := 0; i < 20; i++ {
+			ww.step(nil, nil, nil)
+		}
+		if got := ww.containers[chest].Inventory["PLANK"]; got != 8 {
+			t.Fatalf("chest planks = %d, want 8 (crafter %v)", got, ww.containers[crafter].Inventory)
+		}
+		if ww.containers[crafter].Inventory["LOG"] != 0 {
+			t.Fatalf("crafter logs left: %v", ww.containers[crafter].Inventory)
+		}
+	}
+	tick = w.CurrentTick() - 1
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after resumed machines: %s vs %s", d1, d2)
+	}
+}
+
+func TestMachine_HopperPullsOnlyWithOwnerWithdrawPermission(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	w, err := New(WorldConfig{ID: "machine", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000}, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	join := func(name string) *Agent {
+		resp := make(chan JoinResponse, 1)
+		w.handleJoin(JoinRequest{Name: name, Resp: resp})
+		return w.agents[(<-resp).Welcome.AgentID]
+	}
+	owner, rook := join("owner"), join("rook")
+
+	c := Vec3i{X: owner.Pos.X + 10, Z: owner.Pos.Z}
+	for dx := 0; dx <= 2; dx++ {
+		setAir(w, Vec3i{X: c.X + dx, Z: c.Z})
+	}
+	for _, p := range []struct {
+		name string
+		pos  Vec3i
+	}{{"CHEST", c}, {"HOPPER", Vec3i{X: c.X + 1, Z: c.Z}}, {"CHEST", Vec3i{X: c.X + 2, Z: c.Z}}} {
+		b := w.catalogs.Blocks.Index[p.name]
+		w.chunks.SetBlock(p.pos, b)
+		w.ensureContainerForPlacedBlock(p.pos, p.name)
+	}
+	hopper, dst := Vec3i{X: c.X + 1, Z: c.Z}, Vec3i{X: c.X + 2, Z: c.Z}
+	w.containers[c].Inventory["COAL"] = 3
+	w.claims["LAND_1"] = &LandClaim{LandID: "LAND_1", Owner: owner.ID, Anchor: c, Radius: 8}
+
+	// A hopper placed by a non-member cannot drain the owner's chest.
+	w.setMachinePlacer(hopper, rook.ID, 90)
+	if m := w.machines[hopper]; m.Owner != rook.ID || m.DX != 1 {
+		t.Fatalf("placed hopper: %+v", m)
+	}
+	w.step(nil, nil, nil)
+	w.step(nil, nil, nil)
+	if got := w.containers[dst].Inventory["COAL"]; got != 0 {
+		t.Fatalf("non-member hopper pulled %d COAL from a claimed chest", got)
+	}
+
+	w.machines[hopper] = MachineMeta{DX: 1, Owner: owner.ID}
+	w.step(nil, nil, nil)
+	if got := w.containers[dst].Inventory["COAL"]; got != 1 {
+		t.Fatalf("owner hopper moved %d COAL, want 1", got)
+	}
+}
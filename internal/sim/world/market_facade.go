@@ -0,0 +1,197 @@
+package world
+
+import (
+	"fmt"
+
+	"voxelcraft.ai/internal/protocol"
+	inventorypkg "voxelcraft.ai/internal/sim/world/feature/economy/inventory"
+	marketpkg "voxelcraft.ai/internal/sim/world/feature/economy/market"
+	taxpkg "voxelcraft.ai/internal/sim/world/feature/economy/tax"
+	marketinstctxpkg "voxelcraft.ai/internal/sim/world/featurectx/instants/market"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	rulespkg "voxelcraft.ai/internal/sim/world/policy/rules"
+)
+
+func (w *World) newOrderID() string {
+	n := w.nextOrderNum.Add(1)
+	return fmt.Sprintf("ORD%06d", n)
+}
+
+func newMarketInstantsEnv(w *World) marketinstctxpkg.Env {
+	if w == nil {
+		return marketinstctxpkg.Env{}
+	}
+	return marketinstctxpkg.Env{
+		GetContainerByIDFn: w.getContainerByID,
+		DistanceFn:         Manhattan,
+		CanTradeAtFn: func(agentID string, pos modelpkg.Vec3i, items []string, nowTick uint64) bool {
+			return w.lawDecision(agentID, pos, rulespkg.ActionTrade, items, nowTick).Allowed
+		},
+		ItemExistsFn: func(itemID string) bool {
+			_, ok := w.catalogs.Items.Defs[itemID]
+			return ok
+		},
+		OpenOrdersFn: func(agentID string) int {
+			return marketpkg.OpenOrders(w.orders, agentID)
+		},
+		NewOrderIDFn: w.newOrderID,
+		PutOrderFn: func(o *modelpkg.MarketOrder) {
+			if o != nil {
+				w.orders[o.OrderID] = o
+			}
+		},
+		GetOrderFn: func(orderID string) *modelpkg.MarketOrder {
+			return w.orders[orderID]
+		},
+		DeleteOrderFn: func(orderID string) {
+			delete(w.orders, orderID)
+		},
+	}
+}
+
+func handleInstantPostOrder(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	marketpkg.HandlePostOrder(newMarketInstantsEnv(w), actionResult, marketpkg.OrderHooks{
+		OnPosted: func(o *MarketOrder) {
+			w.auditEvent(nowTick, a.ID, "ORDER_POST", o.TerminalPos, "POST_ORDER", marketOrderAudit(o))
+		},
+	}, a, inst, nowTick, w.cfg.AllowTrade)
+}
+
+func handleInstantCancelOrder(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	marketpkg.HandleCancelOrder(newMarketInstantsEnv(w), actionResult, marketpkg.OrderHooks{
+		OnCanceled: func(o *MarketOrder, refund map[string]int) {
+			details := marketOrderAudit(o)
+			details["refund"] = inventorypkg.EncodeItemPairs(refund)
+			w.auditEvent(nowTick, a.ID, "ORDER_CANCEL", o.TerminalPos, "CANCEL_ORDER", details)
+		},
+	}, a, inst, nowTick)
+}
+
+func marketOrderAudit(o *MarketOrder) map[string]any {
+	return map[string]any{
+		"order_id":   o.OrderID,
+		"side":       string(o.Side),
+		"item":       o.Item,
+		"price_item": o.PriceItem,
+		"price":      o.Price,
+		"count":      o.Count,
+	}
+}
+
+// tickMarket crosses the order books after contracts settle. Orders whose terminal is
+// gone are dropped; filled orders are removed.
+func (w *World) tickMarket(nowTick uint64) {
+	if len(w.orders) == 0 {
+		return
+	}
+	for id, o := range w.orders {
+		if c := w.containers[o.TerminalPos]; c == nil || c.Type != marketpkg.TerminalBlock {
+			delete(w.orders, id)
+		}
+	}
+	for _, f := range marketpkg.Match(w.orders) {
+		w.settleMarketFill(nowTick, f)
+	}
+	for id, o := range w.orders {
+		if o.Count <= 0 {
+			delete(w.orders, id)
+		}
+	}
+}
+
+// settleMarketFill pays both sides of a fill. Laws were checked when the orders were
+// posted; the land's market tax is charged now, at each owner's own rate.
+func (w *World) settleMarketFill(nowTick uint64, f marketpkg.Fill) {
+	term := w.containers[f.Buy.TerminalPos]
+	items := []string{f.Sell.Item, f.Buy.PriceItem}
+	buyerTax := w.marketTaxRate(f.Buy.Owner, term.Pos, items, nowTick)
+	sellerTax := w.marketTaxRate(f.Sell.Owner, term.Pos, items, nowTick)
+	s := marketpkg.Settle(term, f, buyerTax, sellerTax)
+
+	w.marketPayout(term, f.Buy.Owner, s.ToBuyer)
+	w.marketPayout(term, f.Sell.Owner, s.ToSeller)
+	land := w.landAt(term.Pos)
+	sink, taxTo := w.landTaxSink(land)
+	if sink != nil {
+		for _, tax := range []map[string]int{s.TaxBuyer, s.TaxSeller} {
+			for item, n := range tax {
+				sink[item] += n
+			}
+		}
+	}
+
+	landID := ""
+	if land != nil {
+		landID = land.LandID
+	}
+	w.auditEvent(nowTick, "WORLD", "MARKET_FILL", term.Pos, "MATCH", map[string]any{
+		"buy_order":     f.Buy.OrderID,
+		"sell_order":    f.Sell.OrderID,
+		"buyer":         f.Buy.Owner,
+		"seller":        f.Sell.Owner,
+		"item":          f.Sell.Item,
+		"price_item":    f.Buy.PriceItem,
+		"price":         f.Price,
+		"count":         f.Count,
+		"tax_paid_buy":  inventorypkg.EncodeItemPairs(s.TaxBuyer),
+		"tax_paid_sell": inventorypkg.EncodeItemPairs(s.TaxSeller),
+		"land_id":       landID,
+		"tax_to":        taxTo,
+	})
+	w.observePrices(f.Sell.Owner, f.Buy.Owner, map[string]int{f.Sell.Item: f.Count}, map[string]int{f.Buy.PriceItem: f.Count * f.Price}, nowTick)
+	if w.stats != nil {
+		w.stats.RecordTrade(nowTick)
+	}
+	left := map[*MarketOrder]int{f.Buy: f.BuyLeft, f.Sell: f.SellLeft}
+	for _, o := range []*MarketOrder{f.Buy, f.Sell} {
+		if a := w.agents[o.Owner]; a != nil {
+			a.AddEvent(protocol.Event{
+				"t":          nowTick,
+				"type":       "ORDER_FILLED",
+				"order_id":   o.OrderID,
+				"side":       string(o.Side),
+				"item":       o.Item,
+				"price_item": o.PriceItem,
+				"price":      f.Price,
+				"count":      f.Count,
+				"remaining":  left[o],
+			})
+		}
+	}
+}
+
+// marketPayout credits an online owner directly; otherwise the items wait at the
+// terminal for CLAIM_OWED.
+func (w *World) marketPayout(term *Container, agentID string, items map[string]int) {
+	a := w.agents[agentID]
+	for _, item := range inventorypkg.SortedItemKeys(items) {
+		n := items[item]
+		if n <= 0 {
+			continue
+		}
+		if a != nil {
+			a.Inventory[item] += n
+		} else {
+			term.AddOwed(agentID, item, n)
+		}
+	}
+}
+
+// marketTaxRate is the land's trade tax for agentID at a terminal (both sides of a fill
+// stand on the terminal's land).
+func (w *World) marketTaxRate(agentID string, pos Vec3i, items []string, nowTick uint64) float64 {
+	if w.landAt(pos) == nil {
+		return 0
+	}
+	d := w.lawDecision(agentID, pos, rulespkg.ActionTrade, items, nowTick)
+	return taxpkg.EffectiveMarketTax(d.TaxRate, true, w.activeEventID, nowTick, w.activeEventEnds)
+}
+
+// marketBooks lists the order books of terminals within 32 blocks of pos.
+func (w *World) marketBooks(pos Vec3i) []protocol.MarketObs {
+	return marketpkg.Books(w.orders, func(p Vec3i) bool { return Manhattan(pos, p) <= 32 })
+}
+
+func (w *World) marketOrdersForTerminal(pos Vec3i, agentID string) []map[string]interface{} {
+	return marketpkg.OwnOrders(w.orders, pos, agentID)
+}
@@ -0,0 +1,107 @@
+package world
+
+import (
+	"slices"
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestMarket_OrdersMatchAtTerminal(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "market", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000, AllowTrade: true}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	join := func(name string) *Agent {
+		resp := make(chan JoinResponse, 1)
+		w.handleJoin(JoinRequest{Name: name, Resp: resp})
+		return w.agents[(<-resp).Welcome.AgentID]
+	}
+	seller, buyer := join("seller"), join("buyer")
+
+	term := Vec3i{X: seller.Pos.X + 10, Z: seller.Pos.Z}
+	setAir(w, term)
+	b := w.catalogs.Blocks.Index["MARKET_TERMINAL"]
+	w.chunks.SetBlock(term, b)
+	w.ensureContainerForPlacedBlock(term, "MARKET_TERMINAL")
+	termID := containerID("MARKET_TERMINAL", term)
+	seller.Pos = Vec3i{X: term.X + 1, Z: term.Z}
+	buyer.Pos = Vec3i{X: term.X - 1, Z: term.Z}
+	seller.Inventory = map[string]int{"IRON_INGOT": 4}
+	buyer.Inventory = map[string]int{"COAL": 20}
+
+	act := func(a *Agent, insts ...protocol.InstantReq) ActionEnvelope {
+		return ActionEnvelope{AgentID: a.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: a.ID, Instants: insts}}
+	}
+	w.step(nil, nil, []ActionEnvelope{
+		act(seller, protocol.InstantReq{ID: "I_sell", Type: InstantTypePostOrder, TerminalID: termID, Side: "SELL", ItemID: "IRON_INGOT", PriceItem: "COAL", Price: 3, Count: 4}),
+		act(buyer,
+			protocol.InstantReq{ID: "I_poor", Type: InstantTypePostOrder, TerminalID: termID, Side: "BUY", ItemID: "IRON_INGOT", PriceItem: "COAL", Price: 5, Count: 9},
+			protocol.InstantReq{ID: "I_buy", Type: InstantTypePostOrder, TerminalID: termID, Side: "BUY", ItemID: "IRON_INGOT", PriceItem: "COAL", Price: 5, Count: 3},
+		),
+	})
+	if i := slices.IndexFunc(buyer.Events, func(ev protocol.Event) bool { return ev["ref"] == "I_poor" }); i < 0 || buyer.Events[i]["code"] != "E_NO_RESOURCE" {
+		t.Fatalf("unfunded bid must be rejected: %v", buyer.Events)
+	}
+
+	// The sell order rests first, so 3 ingots fill at its price of 3 and the buyer gets
+	// back the 2 COAL per unit it escrowed above that.
+	if buyer.Inventory["IRON_INGOT"] != 3 || buyer.Inventory["COAL"] != 11 {
+		t.Fatalf("buyer inventory: %v", buyer.Inventory)
+	}
+	if seller.Inventory["COAL"] != 9 || seller.Inventory["IRON_INGOT"] != 0 {
+		t.Fatalf("seller inventory: %v", seller.Inventory)
+	}
+	if i := slices.IndexFunc(seller.Events, func(ev protocol.Event) bool { return ev["type"] == "ORDER_FILLED" }); i < 0 || seller.Events[i]["remaining"] != 1 {
+		t.Fatalf("seller fill event: %v", seller.Events)
+	}
+	c := w.containers[term]
+	if c.Inventory["IRON_INGOT"] != 1 || c.ReservedCount("IRON_INGOT") != 1 || c.Inventory["COAL"] != 0 {
+		t.Fatalf("terminal escrow: inv=%v reserved=%v", c.Inventory, c.Reserved)
+	}
+	if len(w.orders) != 1 {
+		t.Fatalf("orders left: %v", w.orders)
+	}
+
+	obs := w.buildObs(buyer, &clientState{}, w.CurrentTick())
+	if len(obs.Markets) != 1 || obs.Markets[0].TerminalID != termID || len(obs.Markets[0].Books) != 1 {
+		t.Fatalf("markets obs: %+v", obs.Markets)
+	}
+	if asks := obs.Markets[0].Books[0].Asks; len(asks) != 1 || asks[0].Price != 3 || asks[0].Count != 1 {
+		t.Fatalf("asks: %+v", asks)
+	}
+
+	// Resting orders survive a snapshot round trip.
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	tick := w.CurrentTick() - 1
+	if err := w2.ImportSnapshot(w.ExportSnapshot(tick)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+
+	var orderID string
+	for id := range w.orders {
+		orderID = id
+	}
+	w.step(nil, nil, []ActionEnvelope{
+		act(buyer, protocol.InstantReq{ID: "I_steal", Type: InstantTypeCancelOrder, OrderID: orderID}),
+		act(seller, protocol.InstantReq{ID: "I_cancel", Type: InstantTypeCancelOrder, OrderID: orderID}),
+	})
+	if i := slices.IndexFunc(buyer.Events, func(ev protocol.Event) bool { return ev["ref"] == "I_steal" }); i < 0 || buyer.Events[i]["code"] != "E_NO_PERMISSION" {
+		t.Fatalf("cancel by non-owner must be denied: %v", buyer.Events)
+	}
+	if seller.Inventory["IRON_INGOT"] != 1 || len(w.orders) != 0 || len(c.Reserved) != 0 {
+		t.Fatalf("cancel: seller=%v orders=%v reserved=%v", seller.Inventory, w.orders, c.Reserved)
+	}
+}
@@ -7,10 +7,11 @@ import statspkg "voxelcraft.ai/internal/sim/world/feature/director/stats"
 type WorldMetrics struct {
 	Tick uint64 `json:"tick"`
 
-	Agents       int    `json:"agents"`
-	Clients      int    `json:"clients"`
-	LoadedChunks int    `json:"loaded_chunks"`
-	ResetTotal   uint64 `json:"reset_total"`
+	Agents        int    `json:"agents"`
+	Clients       int    `json:"clients"`
+	LoadedChunks  int    `json:"loaded_chunks"`
+	EvictedChunks int    `json:"evicted_chunks"`
+	ResetTotal    uint64 `json:"reset_total"`
 
 	QueueDepths QueueDepths `json:"queue_depths"`
 
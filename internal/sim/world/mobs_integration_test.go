@@ -0,0 +1,191 @@
+package world
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func newMobTestWorld(t *testing.T) (*World, *Agent) {
+	t.Helper()
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	w, err := New(WorldConfig{
+		ID:         "test",
+		TickRateHz: 5,
+		DayTicks:   6000,
+		ObsRadius:  7,
+		Height:     1,
+		Seed:       42,
+		BoundaryR:  4000,
+	}, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "hunter", DeltaVoxels: false, Out: nil, Resp: resp})
+	a := w.agents[(<-resp).Welcome.AgentID]
+	if a == nil {
+		t.Fatalf("missing agent")
+	}
+	return w, a
+}
+
+func TestBanditCamp_SpawnsHostileMobsInObs(t *testing.T) {
+	w, a := newMobTestWorld(t)
+	w.startEvent(0, "BANDIT_CAMP")
+	if len(w.mobs) != 2 {
+		t.Fatalf("mobs=%d want 2", len(w.mobs))
+	}
+	for _, m := range w.mobs {
+		if m.Kind != "BANDIT" || m.State != "GUARD" || m.DespawnTick != w.activeEventEnds {
+			t.Fatalf("unexpected mob: %+v", m)
+		}
+	}
+
+	a.Pos = w.activeEventCenter
+	obs := w.buildObs(a, &clientState{}, 1)
+	seen := 0
+	for _, e := range obs.Entities {
+		if e.Type != "MOB" {
+			continue
+		}
+		seen++
+		hostile := false
+		for _, tag := range e.Tags {
+			if tag == "hostile" {
+				hostile = true
+			}
+		}
+		if !hostile {
+			t.Fatalf("bandit missing hostile tag: %+v", e)
+		}
+	}
+	if seen != 2 {
+		t.Fatalf("MOB entities=%d want 2", seen)
+	}
+}
+
+func TestAttack_KillMobSpawnsDrops(t *testing.T) {
+	w, a := newMobTestWorld(t)
+	air := w.catalogs.Blocks.Index["AIR"]
+	a.Pos = Vec3i{X: 20, Y: 0, Z: 20}
+	mobPos := Vec3i{X: 21, Y: 0, Z: 20}
+	setSolid(w, a.Pos, air)
+	setSolid(w, mobPos, air)
+	mobID := w.spawnMob(0, "RUIN_GUARDIAN", mobPos, 0, "TEST")
+	if mobID == "" {
+		t.Fatalf("spawn failed")
+	}
+	a.Inventory["IRON_SWORD"] = 1
+
+	act := protocol.ActMsg{
+		Type:            protocol.TypeAct,
+		ProtocolVersion: protocol.Version,
+		AgentID:         a.ID,
+		Tasks:           []protocol.TaskReq{{ID: "K_atk", Type: "ATTACK", TargetID: mobID}},
+	}
+	a.Events = nil
+	w.step(nil, nil, []ActionEnvelope{{AgentID: a.ID, Act: act}})
+	done := false
+	for i := 0; i < 40 && !done; i++ {
+		for _, e := range a.Events {
+			if e["type"] == "TASK_FAIL" {
+				t.Fatalf("unexpected TASK_FAIL: %v", e)
+			}
+			if e["type"] == "TASK_DONE" {
+				done = true
+			}
+		}
+		a.Events = nil
+		w.step(nil, nil, nil)
+	}
+	if !done {
+		t.Fatalf("expected ATTACK to kill the mob")
+	}
+	if w.mobs[mobID] != nil {
+		t.Fatalf("mob still present")
+	}
+	drops := map[string]int{}
+	for _, e := range w.items {
+		drops[e.Item] += e.Count
+	}
+	if drops["CRYSTAL_SHARD"] != 1 || drops["COPPER_INGOT"] != 2 {
+		t.Fatalf("drops=%v", drops)
+	}
+	if a.HP >= 20 {
+		t.Fatalf("expected the guardian to fight back, hp=%d", a.HP)
+	}
+}
+
+func TestMobs_SnapshotRoundTripKeepsDigest(t *testing.T) {
+	w1, _ := newMobTestWorld(t)
+	w1.startEvent(0, "BANDIT_CAMP")
+	for i := 0; i < 4; i++ {
+		w1.step(nil, nil, nil)
+	}
+	snapTick := w1.CurrentTick() - 1
+	snap := w1.ExportSnapshot(snapTick)
+	if len(snap.Mobs) != 2 || snap.Counters.NextMob != 2 {
+		t.Fatalf("snapshot mobs=%d next=%d", len(snap.Mobs), snap.Counters.NextMob)
+	}
+
+	w2, err := New(w1.cfg, w1.catalogs)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	if err := w2.ImportSnapshot(snap); err != nil {
+		t.Fatalf("import snapshot: %v", err)
+	}
+	if d1, d2 := w1.stateDigest(snapTick), w2.stateDigest(snapTick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+	if id := w2.newMobID(); id != "MB000003" {
+		t.Fatalf("next mob id=%s", id)
+	}
+}
+
+func TestMobs_ProtectedLandSheltersAgents(t *testing.T) {
+	w, a := newMobTestWorld(t)
+	air := w.catalogs.Blocks.Index["AIR"]
+	a.Pos = Vec3i{X: 20, Y: 0, Z: 20}
+	mobPos := Vec3i{X: 22, Y: 0, Z: 20}
+	for x := 19; x <= 23; x++ {
+		setSolid(w, Vec3i{X: x, Y: 0, Z: 20}, air)
+	}
+	w.claims["LAND_SAFE"] = &LandClaim{
+		LandID: "LAND_SAFE",
+		Owner:  "A9",
+		Anchor: a.Pos,
+		Radius: 1,
+		Flags:  ClaimFlags{AllowDamage: false},
+	}
+	mobID := w.spawnMob(0, "BANDIT", mobPos, 0, "TEST")
+	if mobID == "" {
+		t.Fatalf("spawn failed")
+	}
+	m := w.mobs[mobID]
+	// Already chasing, e.g. from before the agent stepped onto the claim.
+	m.State, m.TargetID = "CHASE", a.ID
+
+	for i := 0; i < 20; i++ {
+		w.step(nil, nil, nil)
+	}
+	if a.HP != 20 {
+		t.Fatalf("bandit hurt an agent on no-damage land: hp=%d", a.HP)
+	}
+	if m.State != "GUARD" || m.TargetID != "" {
+		t.Fatalf("bandit kept chasing: %+v", m)
+	}
+
+	w.claims["LAND_SAFE"].Flags.AllowDamage = true
+	for i := 0; i < 20 && a.HP == 20; i++ {
+		w.step(nil, nil, nil)
+	}
+	if a.HP == 20 {
+		t.Fatalf("bandit never attacked once damage was allowed")
+	}
+}
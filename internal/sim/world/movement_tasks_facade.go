@@ -28,3 +28,15 @@ func handleTaskMoveTo(w *World, a *Agent, tr protocol.TaskReq, nowTick uint64) {
 func handleTaskFollow(w *World, a *Agent, tr protocol.TaskReq, nowTick uint64) {
 	runtimepkg.HandleTaskFollow(newMovementTaskEnv(w), actionResult, a, tr, nowTick)
 }
+
+// invalidateMovePaths drops cached movement paths that cross a changed block.
+func (w *World) invalidateMovePaths(pos Vec3i) {
+	for _, a := range w.agents {
+		if a == nil || a.MoveTask == nil {
+			continue
+		}
+		if runtimepkg.PathCrosses(a.MoveTask, pos) {
+			a.MoveTask.Path = nil
+		}
+	}
+}
@@ -3,45 +3,66 @@ package world
 import (
 	"voxelcraft.ai/internal/protocol"
 	"voxelcraft.ai/internal/sim/tasks"
-	claimspkg "voxelcraft.ai/internal/sim/world/feature/governance/claims"
 	metapkg "voxelcraft.ai/internal/sim/world/feature/observer/meta"
 	observerruntimepkg "voxelcraft.ai/internal/sim/world/feature/observer/runtime"
 	streamspkg "voxelcraft.ai/internal/sim/world/feature/observer/stream"
+	viewpkg "voxelcraft.ai/internal/sim/world/feature/session/view"
 	progresspkg "voxelcraft.ai/internal/sim/world/feature/work/progress"
+	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	rulespkg "voxelcraft.ai/internal/sim/world/policy/rules"
 	genpkg "voxelcraft.ai/internal/sim/world/terrain/gen"
 )
 
 func (w *World) buildObs(a *Agent, cl *clientState, nowTick uint64) protocol.ObsMsg {
 	center := a.Pos
-	vox, sensorsNear := w.buildObsVoxels(center, cl)
+	view := a.View
+	vox, sensorsNear := w.buildObsVoxels(center, cl, view)
 
 	land, perms := w.permissionsFor(a.ID, a.Pos)
-	if land != nil && land.CurfewEnabled {
-		t := w.timeOfDay(nowTick)
-		if claimspkg.InWindow(t, land.CurfewStart, land.CurfewEnd) {
-			perms["can_build"] = false
-			perms["can_break"] = false
+	marketTax := 0.0
+	if land != nil && len(land.Policies) > 0 {
+		// Overlay law policies (e.g. curfews) on the claim permissions.
+		for action, key := range lawActionPerm {
+			d := w.lawDecision(a.ID, a.Pos, action, nil, nowTick)
+			perms[key] = d.Allowed
+			if action == rulespkg.ActionTrade {
+				marketTax = d.TaxRate
+			}
 		}
 	}
 
 	tasksObs := w.buildObsTasks(a, nowTick)
-	ents := w.buildObsEntities(a, sensorsNear)
+	ents := []protocol.EntityObs{}
+	if view.Shows(modelpkg.ViewSectionEntities) {
+		ents = viewpkg.FilterEntities(view, w.buildObsEntities(a, sensorsNear))
+	}
 
-	publicBoards := observerruntimepkg.BuildPublicBoardsFromWorld(observerruntimepkg.PublicBoardsFromWorldInput{
-		Boards: w.boards,
-		Self:   a.Pos,
-		ParseContainerID: func(id string) (string, Vec3i, bool) {
-			return parseContainerID(id)
-		},
-		Distance:      Manhattan,
-		MaxDistance:   32,
-		MaxPosts:      5,
-		MaxSummaryLen: 120,
-	})
+	var publicBoards []protocol.BoardObs
+	if view.Shows(modelpkg.ViewSectionPublicBoards) {
+		publicBoards = observerruntimepkg.BuildPublicBoardsFromWorld(observerruntimepkg.PublicBoardsFromWorldInput{
+			Boards: w.boards,
+			Self:   a.Pos,
+			ParseContainerID: func(id string) (string, Vec3i, bool) {
+				return parseContainerID(id)
+			},
+			Distance:      Manhattan,
+			MaxDistance:   32,
+			MaxPosts:      5,
+			MaxSummaryLen: 120,
+		})
+	}
+
+	var markets []protocol.MarketObs
+	if view.Shows(modelpkg.ViewSectionMarkets) {
+		markets = w.marketBooks(a.Pos)
+	}
+	var prices []protocol.PriceObs
+	if view.Shows(modelpkg.ViewSectionPrices) {
+		prices = w.priceIndexEntries()
+	}
 
 	landID := ""
 	owner := ""
-	marketTax := 0.0
 	maintenanceDue := uint64(0)
 	maintenanceStage := 0
 	isOwner := false
@@ -49,7 +70,6 @@ func (w *World) buildObs(a *Agent, cl *clientState, nowTick uint64) protocol.Obs
 	if land != nil {
 		landID = land.LandID
 		owner = land.Owner
-		marketTax = land.MarketTax
 		maintenanceDue = land.MaintenanceDueTick
 		maintenanceStage = land.MaintenanceStage
 		isOwner = land.Owner == a.ID
@@ -105,8 +125,10 @@ func (w *World) buildObs(a *Agent, cl *clientState, nowTick uint64) protocol.Obs
 		Entities:     ents,
 		Tasks:        tasksObs,
 		PublicBoards: publicBoards,
+		Markets:      markets,
+		Prices:       prices,
 	})
-	metapkg.AttachObsEventsAndMeta(a, &obs, nowTick)
+	metapkg.AttachObsEventsAndMetaWith(a, &obs, nowTick, view)
 	return obs
 }
 
@@ -129,13 +151,18 @@ func (w *World) buildObsEntities(a *Agent, sensorsNear []Vec3i) []protocol.Entit
 		Signs:                       w.signs,
 		Conveyors:                   w.conveyors,
 		Switches:                    w.switches,
+		Machines:                    w.machines,
+		Crops:                       w.crops,
+		Signals:                     w.signalNodes,
 		Items:                       w.items,
+		Mobs:                        w.mobs,
 		SensorsNear:                 sensorsNear,
 		ParseContainerID:            parseContainerID,
 		SortedSignPositionsNear:     w.sortedSignPositionsNear,
 		SortedConveyorPositionsNear: w.sortedConveyorPositionsNear,
 		SortedSwitchPositionsNear:   w.sortedSwitchPositionsNear,
 		SensorOn:                    w.sensorOn,
+		BlockNameAt:                 func(p Vec3i) string { return w.blockName(w.chunks.GetBlock(p)) },
 		ContainerID:                 containerID,
 		SignIDAt:                    signIDAt,
 		ConveyorIDAt:                conveyorIDAt,
@@ -144,18 +171,24 @@ func (w *World) buildObsEntities(a *Agent, sensorsNear []Vec3i) []protocol.Entit
 	})
 }
 
-func (w *World) buildObsVoxels(center Vec3i, cl *clientState) (protocol.VoxelsObs, []Vec3i) {
-	r := w.cfg.ObsRadius
+// buildObsVoxels skips the scan when the view hides voxels (sensors then go unseen too).
+func (w *World) buildObsVoxels(center Vec3i, cl *clientState, view modelpkg.View) (protocol.VoxelsObs, []Vec3i) {
+	if !view.Shows(modelpkg.ViewSectionVoxels) {
+		cl.LastVoxels = nil
+		return protocol.VoxelsObs{Center: center.ToArray(), Encoding: "NONE"}, nil
+	}
+	r := viewpkg.Radius(view, w.cfg.ObsRadius, w.viewLimits())
 	sensorBlock, hasSensor := w.catalogs.Blocks.Index["SENSOR"]
-	out := streamspkg.BuildObsVoxels2D(streamspkg.VoxelsBuildInput{
+	out := streamspkg.BuildObsVoxels(streamspkg.VoxelsBuildInput{
 		Center: streamspkg.VoxelPos{X: center.X, Y: center.Y, Z: center.Z},
 		Radius: r,
+		Height: w.cfg.Height,
 
 		AirBlock:    w.chunks.gen.Air,
 		HasSensor:   hasSensor,
 		SensorBlock: sensorBlock,
 
-		DeltaEnabled: cl.DeltaVoxels,
+		DeltaEnabled: cl.DeltaVoxels && cl.ObsDelta == nil, // delta OBS diffs against the acked base instead
 		LastVoxels:   cl.LastVoxels,
 	}, func(pos streamspkg.VoxelPos) uint16 {
 		return w.chunks.GetBlock(Vec3i{X: pos.X, Y: pos.Y, Z: pos.Z})
@@ -178,6 +211,8 @@ func (w *World) workProgressForAgent(a *Agent, wt *tasks.WorkTask) float64 {
 		pos := v3FromTask(wt.BlockPos)
 		blockName := w.blockName(w.chunks.GetBlock(pos))
 		return progresspkg.MineProgress(wt.WorkTicks, blockName, a.Inventory)
+	case tasks.KindTill:
+		return progresspkg.TillProgress(wt.WorkTicks, a.Inventory)
 	case tasks.KindCraft:
 		rec, ok := w.catalogs.Recipes.ByID[wt.RecipeID]
 		if !ok {
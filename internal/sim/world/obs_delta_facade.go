@@ -0,0 +1,43 @@
+package world
+
+import (
+	"context"
+	"errors"
+
+	obsdeltapkg "voxelcraft.ai/internal/sim/world/feature/observer/obsdelta"
+)
+
+type obsAckReq struct {
+	AgentID string
+	ObsID   string
+	Resync  bool
+}
+
+// RequestObsAck forwards OBS_ACK (or OBS_RESYNC when resync is set) for an attached
+// client. The first ack switches the client to delta OBS.
+func (w *World) RequestObsAck(ctx context.Context, agentID, obsID string, resync bool) error {
+	if w == nil || w.obsAck == nil {
+		return errors.New("obs ack not available")
+	}
+	select {
+	case w.obsAck <- obsAckReq{AgentID: agentID, ObsID: obsID, Resync: resync}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *World) handleObsAck(req obsAckReq) {
+	cl := w.clients[req.AgentID]
+	if cl == nil {
+		return
+	}
+	if cl.ObsDelta == nil {
+		cl.ObsDelta = obsdeltapkg.NewTracker()
+	}
+	if req.Resync {
+		cl.ObsDelta.Resync()
+		return
+	}
+	cl.ObsDelta.Ack(req.ObsID)
+}
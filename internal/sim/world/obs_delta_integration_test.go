@@ -0,0 +1,66 @@
+package world
+
+import (
+	"encoding/json"
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestObsDelta_AckSwitchesToDeltaAndIdleIsSmall(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	w, err := New(WorldConfig{ID: "obs-delta", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 7, BoundaryR: 4000}, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	out := make(chan []byte, 1)
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "idle", Out: out, Resp: resp})
+	id := (<-resp).Welcome.AgentID
+
+	type header struct {
+		ObsID     string `json:"obs_id"`
+		ObsMode   string `json:"obs_mode"`
+		BaseObsID string `json:"base_obs_id"`
+	}
+	next := func() (header, int) {
+		t.Helper()
+		w.step(nil, nil, nil)
+		b := <-out
+		var h header
+		if err := json.Unmarshal(b, &h); err != nil {
+			t.Fatalf("decode obs: %v", err)
+		}
+		return h, len(b)
+	}
+
+	legacy, fullSize := next()
+	if legacy.ObsMode != "" {
+		t.Fatalf("expected legacy OBS before any ack, got %+v", legacy)
+	}
+
+	// First ack enables delta mode; the unknown id yields a FULL keyframe to ack.
+	w.handleObsAck(obsAckReq{AgentID: id, ObsID: legacy.ObsID})
+	key, _ := next()
+	if key.ObsMode != protocol.ObsModeFull {
+		t.Fatalf("expected FULL keyframe, got %+v", key)
+	}
+	w.handleObsAck(obsAckReq{AgentID: id, ObsID: key.ObsID})
+
+	d, deltaSize := next()
+	if d.ObsMode != protocol.ObsModeDelta || d.BaseObsID != key.ObsID {
+		t.Fatalf("expected DELTA on %s, got %+v", key.ObsID, d)
+	}
+	if deltaSize*5 > fullSize {
+		t.Fatalf("idle delta too large: %d bytes vs full %d", deltaSize, fullSize)
+	}
+
+	w.handleObsAck(obsAckReq{AgentID: id, Resync: true})
+	if h, _ := next(); h.ObsMode != protocol.ObsModeFull {
+		t.Fatalf("expected FULL after resync, got %+v", h)
+	}
+}
@@ -0,0 +1,70 @@
+package world
+
+import (
+	"encoding/json"
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestSetView_ShapesObs(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	w, err := New(WorldConfig{ID: "obs-view", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, ObsRadiusMin: 2, ObsRadiusMax: 12, Height: 1, Seed: 7, BoundaryR: 4000}, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	out := make(chan []byte, 1)
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "viewer", Out: out, Resp: resp})
+	a := w.agents[(<-resp).Welcome.AgentID]
+	next := func() protocol.ObsMsg {
+		t.Helper()
+		w.step(nil, nil, nil)
+		var obs protocol.ObsMsg
+		if err := json.Unmarshal(<-out, &obs); err != nil {
+			t.Fatalf("decode obs: %v", err)
+		}
+		return obs
+	}
+
+	w.applyInstant(a, protocol.InstantReq{ID: "V0", Type: InstantTypeSetView, View: &protocol.ViewSpec{ObsRadius: 13}}, 0)
+	if ev := a.Events[len(a.Events)-1]; ev["ok"] != false || ev["code"] != protocol.ErrBadRequest {
+		t.Fatalf("expected out-of-range obs_radius rejected, got %v", ev)
+	}
+
+	w.applyInstant(a, protocol.InstantReq{ID: "V1", Type: InstantTypeSetView, View: &protocol.ViewSpec{
+		Hide:      []string{"entities", "public_boards", "fun_score"},
+		ObsRadius: 3,
+		MaxEvents: 1,
+	}}, 0)
+	obs := next()
+	if obs.Voxels.Radius != 3 || len(obs.Entities) != 0 || obs.PublicBoards != nil || obs.FunScore != nil {
+		t.Fatalf("view not applied: radius=%d entities=%d", obs.Voxels.Radius, len(obs.Entities))
+	}
+	queued := len(a.Events)
+	if len(obs.Events) != 1 || queued == 0 || obs.EventsCursor != a.EventCursor-uint64(queued) {
+		t.Fatalf("expected 1 event delivered and the rest queued, got %d/%d cursor=%d", len(obs.Events), queued, obs.EventsCursor)
+	}
+	for i := 0; i < queued; i++ {
+		if obs = next(); len(obs.Events) != 1 {
+			t.Fatalf("expected queued events one per OBS, got %d", len(obs.Events))
+		}
+	}
+	if len(a.Events) != 0 || obs.EventsCursor != a.EventCursor {
+		t.Fatalf("expected queue drained, got %d cursor=%d/%d", len(a.Events), obs.EventsCursor, a.EventCursor)
+	}
+
+	w.applyInstant(a, protocol.InstantReq{ID: "V2", Type: InstantTypeSetView, View: &protocol.ViewSpec{Hide: []string{"voxels"}}}, 0)
+	if obs = next(); obs.Voxels.Encoding != "NONE" || obs.Voxels.Data != "" {
+		t.Fatalf("expected voxels hidden, got %+v", obs.Voxels)
+	}
+
+	w.applyInstant(a, protocol.InstantReq{ID: "V3", Type: InstantTypeSetView}, 0)
+	if obs = next(); !a.View.IsZero() || obs.Voxels.Radius != 7 {
+		t.Fatalf("expected SET_VIEW without view to reset, got %+v radius=%d", a.View, obs.Voxels.Radius)
+	}
+}
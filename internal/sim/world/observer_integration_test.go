@@ -42,19 +42,19 @@ func TestComputeSurfaceCellAt_surfaceUpdateCases(t *testing.T) {
 	}
 
 	// Place a block: surface becomes STONE@0.
-	ch.Set(lx, lz, stone)
+	ch.Set(lx, 0, lz, stone)
 	if got := w.computeSurfaceCellAt(wx, wz); got != (surfaceCell{B: stone, Y: 0}) {
 		t.Fatalf("place surface = %+v, want stone@0", got)
 	}
 
 	// Replace the surface: surface becomes WOOD@0.
-	ch.Set(lx, lz, wood)
+	ch.Set(lx, 0, lz, wood)
 	if got := w.computeSurfaceCellAt(wx, wz); got != (surfaceCell{B: wood, Y: 0}) {
 		t.Fatalf("replace surface = %+v, want wood@0", got)
 	}
 
 	// Mine the surface: remove block => AIR@0.
-	ch.Set(lx, lz, air)
+	ch.Set(lx, 0, lz, air)
 	if got := w.computeSurfaceCellAt(wx, wz); got != (surfaceCell{B: air, Y: 0}) {
 		t.Fatalf("mine surface = %+v, want air@0", got)
 	}
@@ -84,7 +84,7 @@ func TestStepObserverChunksForClient_emitsChunkPatch(t *testing.T) {
 	)
 
 	wx, wz := 3, 9
-	ch.Set(wx, wz, stone)
+	ch.Set(wx, 0, wz, stone)
 
 	dataOut := make(chan []byte, 8)
 	c := &observerClient{
@@ -109,7 +109,7 @@ func TestStepObserverChunksForClient_emitsChunkPatch(t *testing.T) {
 	c.Chunks[key] = st
 
 	// Apply the world change first (audit is recorded after mutation).
-	ch.Set(wx, wz, wood)
+	ch.Set(wx, 0, wz, wood)
 	audits := []AuditEntry{
 		{
 			Tick:   100,
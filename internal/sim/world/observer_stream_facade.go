@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"voxelcraft.ai/internal/observerproto"
+	marketpkg "voxelcraft.ai/internal/sim/world/feature/economy/market"
 	boardspkg "voxelcraft.ai/internal/sim/world/feature/observer/boards"
 	chunkspkg "voxelcraft.ai/internal/sim/world/feature/observer/chunks"
 	observerruntimepkg "voxelcraft.ai/internal/sim/world/feature/observer/runtime"
@@ -99,8 +100,11 @@ func (w *World) chunkForSurface(cx, cz int) *Chunk {
 	if ch, ok := w.chunks.chunks[k]; ok && ch != nil {
 		return ch
 	}
-	// Generate an ephemeral chunk without mutating the world's loaded chunk set. This ensures
-	// observer clients cannot affect simulation state/digests by "viewing" far-away terrain.
+	// Peek (spill area or ephemeral generation) without mutating the world's resident chunk set.
+	// This ensures observer clients cannot affect simulation state/digests by "viewing" far-away terrain.
+	if ch := w.chunks.peekChunk(cx, cz); ch != nil {
+		return ch
+	}
 	tmp := &Chunk{
 		CX:     cx,
 		CZ:     cz,
@@ -279,6 +283,7 @@ func (w *World) stepObservers(nowTick uint64, joins []RecordedJoin, leaves []str
 		Leaves:              leaves,
 		Actions:             actionsOut,
 		Audits:              auditsOut,
+		Markets:             marketpkg.Books(w.orders, nil),
 	})
 	if err != nil {
 		return
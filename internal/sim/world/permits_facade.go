@@ -0,0 +1,34 @@
+package world
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	permitpkg "voxelcraft.ai/internal/sim/world/feature/transfer/permit"
+)
+
+// systemPermits runs down this world's permits for the agents inside it. When the last one an
+// agent holds expires, its tasks stop and it may only leave (SWITCH_WORLD is routed by the
+// multiworld manager, not by this world).
+func (w *World) systemPermits(nowTick uint64) {
+	if len(w.cfg.PermitIDs) == 0 {
+		return
+	}
+	for _, a := range w.agents {
+		if a == nil {
+			continue
+		}
+		expired := permitpkg.Tick(a, w.cfg.PermitIDs)
+		for _, id := range expired {
+			a.AddEvent(protocol.Event{"t": nowTick, "type": "PERMIT_EXPIRED", "permit_id": id, "world_id": w.cfg.ID})
+		}
+		if len(expired) > 0 && w.permitBarred(a) {
+			a.MoveTask = nil
+			a.WorkTask = nil
+			a.TaskQueue = nil
+		}
+	}
+}
+
+// permitBarred reports whether the agent is in a permit-gated world without a permit for it.
+func (w *World) permitBarred(a *Agent) bool {
+	return len(w.cfg.PermitIDs) > 0 && !permitpkg.Holds(a, w.cfg.PermitIDs)
+}
@@ -0,0 +1,120 @@
+package world
+
+import (
+	"slices"
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	transferruntimepkg "voxelcraft.ai/internal/sim/world/feature/transfer/runtime"
+)
+
+func TestPermits_AdmissionChargesOnlyOnEntryAndRunsDownInside(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "DEEP", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000, PermitIDs: []string{"DEEP_PASS"}}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	rules := []PermitRule{{ID: "DEEP_PASS", DurationTicks: 3, Price: map[string]int{"IRON_INGOT": 5}}}
+	admit := func(tr AgentTransfer) transferruntimepkg.TransferInResp {
+		resp := make(chan transferruntimepkg.TransferInResp, 1)
+		w.handleTransferIn(transferruntimepkg.TransferInReq{Transfer: tr, RequirePermit: true, Permits: rules, Resp: resp})
+		return <-resp
+	}
+
+	poor := AgentTransfer{ID: "A7", Name: "poor", FromWorldID: "OVER", Inventory: map[string]int{"IRON_INGOT": 4}}
+	if r := admit(poor); r.Code != protocol.ErrWorldPermitRequired || w.agents["A7"] != nil {
+		t.Fatalf("admitted without a permit: %+v", r)
+	}
+	tr := AgentTransfer{ID: "A8", Name: "rich", FromWorldID: "OVER", Inventory: map[string]int{"IRON_INGOT": 6}}
+	if r := admit(tr); r.Err != "" || r.Permit.Via != "PURCHASE" || r.Permit.RemainingTicks != 3 {
+		t.Fatalf("paid admission: %+v", r)
+	}
+	if tr.Inventory["IRON_INGOT"] != 6 {
+		t.Fatalf("admission charged the transfer itself: %v", tr.Inventory)
+	}
+	a := w.agents["A8"]
+	if a == nil || a.Inventory["IRON_INGOT"] != 1 || a.Permits["DEEP_PASS"] != 3 {
+		t.Fatalf("admitted agent: %+v", a)
+	}
+
+	act := func(ref string) {
+		w.step(nil, nil, []ActionEnvelope{{AgentID: a.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: a.ID,
+			Instants: []protocol.InstantReq{{ID: ref, Type: "SAY", Channel: "LOCAL", Text: "hi"}}}}})
+	}
+	barred := func(ref string) bool {
+		i := slices.IndexFunc(a.Events, func(ev protocol.Event) bool { return ev["ref"] == ref })
+		return i >= 0 && a.Events[i]["code"] == protocol.ErrWorldPermitExpired
+	}
+	act("S1")
+	act("S2")
+	if barred("S1") || barred("S2") || a.Permits["DEEP_PASS"] != 1 {
+		t.Fatalf("permit ran out early: %v events %v", a.Permits, a.Events)
+	}
+	act("S3")
+	if !barred("S3") || len(a.Permits) != 0 {
+		t.Fatalf("expired permit still lets the agent act: %v events %v", a.Permits, a.Events)
+	}
+	if !slices.ContainsFunc(a.Events, func(ev protocol.Event) bool { return ev["type"] == "PERMIT_EXPIRED" && ev["permit_id"] == "DEEP_PASS" }) {
+		t.Fatalf("no PERMIT_EXPIRED event: %v", a.Events)
+	}
+}
+
+func TestPermits_ExpiredPermitSuspendsTriggers(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "DEEP", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000, PermitIDs: []string{"DEEP_PASS"}}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	resp := make(chan transferruntimepkg.TransferInResp, 1)
+	w.handleTransferIn(transferruntimepkg.TransferInReq{
+		Transfer:      AgentTransfer{ID: "A8", Name: "rich", FromWorldID: "OVER", Inventory: map[string]int{"IRON_INGOT": 5}},
+		RequirePermit: true,
+		Permits:       []PermitRule{{ID: "DEEP_PASS", DurationTicks: 2, Price: map[string]int{"IRON_INGOT": 5}}},
+		Resp:          resp,
+	})
+	if r := <-resp; r.Err != "" {
+		t.Fatalf("admission: %+v", r)
+	}
+	a := w.agents["A8"]
+
+	set := protocol.InstantReq{ID: "t1", Type: InstantTypeSetTrigger, Trigger: &protocol.TriggerSpec{
+		ID: "chatter", On: "STATS", Instant: &protocol.InstantReq{Type: "SAY", Channel: "LOCAL", Text: "hi"},
+	}}
+	w.step(nil, nil, []ActionEnvelope{{AgentID: a.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: a.ID, Instants: []protocol.InstantReq{set}}}})
+	fired := func() int {
+		n := 0
+		for _, ev := range a.Events {
+			if ev["type"] == "TRIGGER_FIRED" {
+				n++
+			}
+		}
+		return n
+	}
+	if fired() == 0 {
+		t.Fatalf("trigger did not fire while the permit was held: %v", a.Events)
+	}
+
+	w.step(nil, nil, nil)
+	if len(a.Permits) != 0 {
+		t.Fatalf("permit still held: %v", a.Permits)
+	}
+	n := fired()
+	for i := 0; i < 3; i++ {
+		w.step(nil, nil, nil)
+	}
+	if fired() != n {
+		t.Fatalf("trigger kept firing after the permit expired: %v", a.Events)
+	}
+	if len(a.Triggers) != 1 {
+		t.Fatalf("suspended trigger was dropped: %+v", a.Triggers)
+	}
+}
@@ -36,13 +36,3 @@ func InWindow(t, start, end float64) bool {
 	// Wrap-around window.
 	return t >= start || t <= end
 }
-
-func CanActionWithCurfew(baseAllowed, curfewEnabled bool, now, start, end float64) bool {
-	if !baseAllowed {
-		return false
-	}
-	if !curfewEnabled {
-		return true
-	}
-	return !InWindow(now, start, end)
-}
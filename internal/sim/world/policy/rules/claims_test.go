@@ -22,19 +22,3 @@ func TestInWindow(t *testing.T) {
 		t.Fatalf("expected outside wrap-around window")
 	}
 }
-
-func TestCanActionWithCurfew(t *testing.T) {
-	if CanActionWithCurfew(false, false, 0.0, 0.0, 0.1) {
-		t.Fatalf("base denied should deny")
-	}
-	if !CanActionWithCurfew(true, false, 0.0, 0.0, 0.1) {
-		t.Fatalf("curfew disabled should allow")
-	}
-	// Curfew enabled: deny inside, allow outside.
-	if CanActionWithCurfew(true, true, 0.05, 0.0, 0.1) {
-		t.Fatalf("expected denied inside curfew window")
-	}
-	if !CanActionWithCurfew(true, true, 0.2, 0.0, 0.1) {
-		t.Fatalf("expected allowed outside curfew window")
-	}
-}
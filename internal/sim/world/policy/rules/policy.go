@@ -0,0 +1,212 @@
+package rules
+
+import "strings"
+
+// Actions a law rule can match.
+const (
+	ActionBreak  = "BREAK"  // MINE inside the land
+	ActionBuild  = "BUILD"  // PLACE / BUILD_BLUEPRINT / claim and board placement
+	ActionDamage = "DAMAGE" // attacking inside the land
+	ActionTrade  = "TRADE"  // trades settled inside the land
+	ActionEnter  = "ENTER"  // stepping into the land core from outside
+)
+
+// Actor roles. A rule without an actor matches everyone.
+const (
+	RoleAny     = "ANY"
+	RoleMember  = "MEMBER"
+	RoleVisitor = "VISITOR"
+	RoleWanted  = "WANTED" // law reputation below the wanted threshold
+)
+
+// Regions. A rule without a region matches the whole land.
+const (
+	RegionLand = "LAND"
+	RegionCore = "CORE"
+)
+
+// Outcomes for FINE/REP rules. A rule without an outcome applies either way.
+const (
+	OutcomeAllowed = "ALLOWED"
+	OutcomeDenied  = "DENIED"
+)
+
+// Effects.
+const (
+	EffectDeny   = "DENY"   // forbid the action
+	EffectFine   = "FINE"   // take up to Amount of Item from the actor (whatever they have)
+	EffectTax    = "TAX"    // trade tax rate (rates of matching rules add up, capped at 1)
+	EffectTicket = "TICKET" // the action costs exactly Amount of Item, or is refused
+	EffectRep    = "REP"    // change the actor's law reputation by Amount
+)
+
+// WantedRepLaw is the law reputation below which an actor counts as WANTED.
+const WantedRepLaw = 200
+
+// Wanted reports whether a law reputation marks its holder as WANTED (0 means unset).
+func Wanted(repLaw int) bool { return repLaw > 0 && repLaw < WantedRepLaw }
+
+// Rule is a compiled law rule: a condition and an effect with concrete values.
+type Rule struct {
+	Actions []string `json:"actions,omitempty"`
+	Actor   string   `json:"actor,omitempty"`
+	Region  string   `json:"region,omitempty"`
+	Items   []string `json:"items,omitempty"`
+	Outcome string   `json:"outcome,omitempty"`
+	// Time window in time-of-day [0,1]; wraps around midnight when From > To.
+	TimeWindow bool    `json:"time_window,omitempty"`
+	TimeFrom   float64 `json:"time_from,omitempty"`
+	TimeTo     float64 `json:"time_to,omitempty"`
+
+	Effect string  `json:"effect"`
+	Item   string  `json:"item,omitempty"`
+	Amount int     `json:"amount,omitempty"`
+	Rate   float64 `json:"rate,omitempty"`
+}
+
+// Policy is the set of rules one active law contributes to a land.
+type Policy struct {
+	LawID      string `json:"law_id"`
+	TemplateID string `json:"template_id"`
+	Rules      []Rule `json:"rules"`
+}
+
+// Context describes one attempted action.
+type Context struct {
+	Action    string
+	Member    bool
+	RepLaw    int
+	InCore    bool
+	TimeOfDay float64
+	Items     []string
+	// BaseAllowed is the outcome before laws (claim flags, membership, maintenance).
+	BaseAllowed bool
+}
+
+// Charge is an item payment required by a FINE or TICKET rule.
+type Charge struct {
+	LawID  string
+	Item   string
+	Amount int
+}
+
+// Decision is the combined effect of every matching rule.
+type Decision struct {
+	Allowed  bool
+	DeniedBy string // law id of the first DENY rule that matched
+	TaxRate  float64
+	Tickets  []Charge
+	Fines    []Charge
+	RepDelta int
+}
+
+// Evaluate applies policies in order. DENY rules decide Allowed; FINE and REP rules are then
+// filtered by their outcome against that result.
+func Evaluate(policies []Policy, ctx Context) Decision {
+	d := Decision{Allowed: ctx.BaseAllowed}
+	for _, p := range policies {
+		for _, r := range p.Rules {
+			if r.Effect == EffectDeny && r.matches(ctx) && d.Allowed {
+				d.Allowed = false
+				d.DeniedBy = p.LawID
+			}
+		}
+	}
+	for _, p := range policies {
+		for _, r := range p.Rules {
+			if r.Effect == EffectDeny || !r.matches(ctx) {
+				continue
+			}
+			if (r.Outcome == OutcomeAllowed && !d.Allowed) || (r.Outcome == OutcomeDenied && d.Allowed) {
+				continue
+			}
+			switch r.Effect {
+			case EffectFine:
+				d.Fines = append(d.Fines, Charge{LawID: p.LawID, Item: r.Item, Amount: r.Amount})
+			case EffectTicket:
+				d.Tickets = append(d.Tickets, Charge{LawID: p.LawID, Item: r.Item, Amount: r.Amount})
+			case EffectTax:
+				d.TaxRate += r.Rate
+			case EffectRep:
+				d.RepDelta += r.Amount
+			}
+		}
+	}
+	if d.TaxRate > 1 {
+		d.TaxRate = 1
+	}
+	return d
+}
+
+func (r Rule) matches(ctx Context) bool {
+	if len(r.Actions) > 0 && !contains(r.Actions, ctx.Action) {
+		return false
+	}
+	switch r.Actor {
+	case RoleMember:
+		if !ctx.Member {
+			return false
+		}
+	case RoleVisitor:
+		if ctx.Member {
+			return false
+		}
+	case RoleWanted:
+		if !Wanted(ctx.RepLaw) {
+			return false
+		}
+	}
+	if r.Region == RegionCore && !ctx.InCore {
+		return false
+	}
+	if len(r.Items) > 0 {
+		hit := false
+		for _, it := range ctx.Items {
+			if contains(r.Items, it) {
+				hit = true
+				break
+			}
+		}
+		if !hit {
+			return false
+		}
+	}
+	if r.TimeWindow && !InWindow(ctx.TimeOfDay, r.TimeFrom, r.TimeTo) {
+		return false
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Upsert replaces the policy of the same template (a newer law of a template supersedes the
+// older one) or appends p. A policy without rules removes the template's policy.
+func Upsert(policies []Policy, p Policy) []Policy {
+	out := make([]Policy, 0, len(policies)+1)
+	for _, q := range policies {
+		if q.TemplateID != p.TemplateID {
+			out = append(out, q)
+		}
+	}
+	if len(p.Rules) > 0 {
+		out = append(out, p)
+	}
+	return out
+}
+
+// Find returns the active policy for templateID.
+func Find(policies []Policy, templateID string) (Policy, bool) {
+	for _, p := range policies {
+		if p.TemplateID == templateID {
+			return p, true
+		}
+	}
+	return Policy{}, false
+}
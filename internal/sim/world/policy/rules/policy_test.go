@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEvaluateDenyFineTicket(t *testing.T) {
+	policies := []Policy{
+		{LawID: "L1", TemplateID: "CURFEW", Rules: []Rule{{
+			Actions: []string{ActionBuild, ActionBreak}, TimeWindow: true, TimeFrom: 0.9, TimeTo: 0.1, Effect: EffectDeny,
+		}}},
+		{LawID: "L2", TemplateID: "FINE", Rules: []Rule{{
+			Actions: []string{ActionBreak}, Actor: RoleVisitor, Outcome: OutcomeDenied, Effect: EffectFine, Item: "IRON_INGOT", Amount: 3,
+		}}},
+		{LawID: "L3", TemplateID: "PASS", Rules: []Rule{{
+			Actions: []string{ActionEnter}, Actor: RoleVisitor, Region: RegionCore, Effect: EffectTicket, Item: "GOLD_INGOT", Amount: 1,
+		}}},
+	}
+
+	// Curfew (wrapping midnight) denies members too; visitors are fined.
+	d := Evaluate(policies, Context{Action: ActionBreak, Member: true, TimeOfDay: 0.95, BaseAllowed: true})
+	if d.Allowed || d.DeniedBy != "L1" || len(d.Fines) != 0 {
+		t.Fatalf("member in curfew: %+v", d)
+	}
+	d = Evaluate(policies, Context{Action: ActionBreak, TimeOfDay: 0.5, BaseAllowed: false})
+	if d.Allowed || d.DeniedBy != "" || len(d.Fines) != 1 || d.Fines[0].Amount != 3 {
+		t.Fatalf("visitor denied by claim: %+v", d)
+	}
+	d = Evaluate(policies, Context{Action: ActionBreak, TimeOfDay: 0.5, BaseAllowed: true})
+	if !d.Allowed || len(d.Fines) != 0 {
+		t.Fatalf("allowed break should not be fined: %+v", d)
+	}
+
+	// Tickets only apply to visitors entering the core.
+	if d := Evaluate(policies, Context{Action: ActionEnter, InCore: true, BaseAllowed: true}); len(d.Tickets) != 1 {
+		t.Fatalf("expected ticket: %+v", d)
+	}
+	if d := Evaluate(policies, Context{Action: ActionEnter, InCore: false, BaseAllowed: true}); len(d.Tickets) != 0 {
+		t.Fatalf("ticket outside core: %+v", d)
+	}
+}
+
+func TestEvaluateItemsWantedTaxRep(t *testing.T) {
+	policies := []Policy{{LawID: "L1", Rules: []Rule{
+		{Actions: []string{ActionTrade}, Items: []string{"TNT"}, Effect: EffectDeny},
+		{Actions: []string{ActionTrade}, Effect: EffectTax, Rate: 0.7},
+		{Actions: []string{ActionTrade}, Effect: EffectTax, Rate: 0.6},
+		{Actor: RoleWanted, Effect: EffectRep, Amount: -5},
+	}}}
+	d := Evaluate(policies, Context{Action: ActionTrade, Items: []string{"PLANK"}, RepLaw: 500, BaseAllowed: true})
+	if !d.Allowed || d.TaxRate != 1 || d.RepDelta != 0 {
+		t.Fatalf("plank trade: %+v", d)
+	}
+	d = Evaluate(policies, Context{Action: ActionTrade, Items: []string{"PLANK", "TNT"}, RepLaw: 100, BaseAllowed: true})
+	if d.Allowed || d.RepDelta != -5 {
+		t.Fatalf("tnt trade by wanted: %+v", d)
+	}
+}
+
+func TestCompileTemplate(t *testing.T) {
+	raw := `[
+		{"when":{"actions":["ENTER"],"actor":"VISITOR","region":"CORE"},"then":{"effect":"TICKET","item":"$ticket_item","amount":"$ticket_cost"}},
+		{"when":{"actions":["ENTER"],"outcome":"DENIED"},"then":{"effect":"REP","amount":"-$penalty"}},
+		{"when":{"actions":["TRADE"]},"then":{"effect":"TAX","rate":0.05}}
+	]`
+	var specs []RuleSpec
+	if err := json.Unmarshal([]byte(raw), &specs); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	types := map[string]string{"ticket_item": TypeItemID, "ticket_cost": TypeInt, "penalty": TypeInt}
+	if err := ValidateSpecs(types, specs); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if err := ValidateSpecs(map[string]string{"ticket_item": TypeItemID}, specs); err == nil {
+		t.Fatalf("expected undeclared param error")
+	}
+
+	params, err := NormalizeParams(types, map[string][2]float64{"ticket_cost": {0, 64}}, map[string]interface{}{
+		"ticket_item": "GOLD_INGOT", "ticket_cost": 99, "penalty": 2,
+	}, nil)
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	rs, err := Compile(specs, params)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	if len(rs) != 3 || rs[0].Amount != 64 || rs[0].Actor != RoleVisitor || rs[1].Amount != -2 || rs[2].Rate != 0.05 {
+		t.Fatalf("compiled rules mismatch: %+v", rs)
+	}
+
+	// Zero cost drops the ticket rule.
+	params["ticket_cost"] = "0"
+	if rs, _ := Compile(specs, params); len(rs) != 2 {
+		t.Fatalf("expected ticket rule dropped: %+v", rs)
+	}
+}
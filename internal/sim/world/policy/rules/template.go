@@ -0,0 +1,305 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Law template parameter types (configs/law_templates.json "params").
+const (
+	TypeItemID  = "ITEM_ID"
+	TypeInt     = "INT"
+	TypeFloat   = "FLOAT"
+	TypeFloat01 = "FLOAT_0_1"
+)
+
+// Arg is a rule argument in a template: a literal ("IRON_INGOT", 3, 0.5), a
+// parameter reference ("$fine_item") or a negated numeric reference ("-$penalty").
+type Arg string
+
+func (a *Arg) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = Arg(strings.TrimSpace(s))
+		return nil
+	}
+	var f float64
+	if err := json.Unmarshal(b, &f); err != nil {
+		return fmt.Errorf("arg must be string or number")
+	}
+	*a = Arg(FloatToCanonString(f))
+	return nil
+}
+
+// Param returns the referenced parameter name.
+func (a Arg) Param() (string, bool) {
+	s := strings.TrimPrefix(string(a), "-")
+	if strings.HasPrefix(s, "$") {
+		return s[1:], true
+	}
+	return "", false
+}
+
+func (a Arg) negated() bool {
+	return strings.HasPrefix(string(a), "-$")
+}
+
+// When is the condition part of a rule spec. Empty fields match everything.
+type When struct {
+	Actions []string `json:"actions,omitempty"`
+	Actor   string   `json:"actor,omitempty"`
+	Region  string   `json:"region,omitempty"`
+	Items   []Arg    `json:"items,omitempty"`
+	Time    []Arg    `json:"time,omitempty"` // [from, to] time-of-day
+	Outcome string   `json:"outcome,omitempty"`
+}
+
+// Then is the effect part of a rule spec.
+type Then struct {
+	Effect string `json:"effect"`
+	Item   Arg    `json:"item,omitempty"`
+	Amount Arg    `json:"amount,omitempty"`
+	Rate   Arg    `json:"rate,omitempty"`
+}
+
+// RuleSpec is one rule as written in a law template.
+type RuleSpec struct {
+	When When `json:"when"`
+	Then Then `json:"then"`
+}
+
+// ValidateSpecs checks enums, arities and that every "$param" is declared in params.
+func ValidateSpecs(params map[string]string, specs []RuleSpec) error {
+	if len(specs) == 0 {
+		return fmt.Errorf("no rules")
+	}
+	ref := func(a Arg, field string) error {
+		if a == "" {
+			return fmt.Errorf("missing %s", field)
+		}
+		if name, ok := a.Param(); ok {
+			if _, declared := params[name]; !declared {
+				return fmt.Errorf("%s references undeclared param %s", field, name)
+			}
+		}
+		return nil
+	}
+	for i, s := range specs {
+		wrap := func(err error) error { return fmt.Errorf("rule %d: %w", i, err) }
+		for _, act := range s.When.Actions {
+			switch act {
+			case ActionBreak, ActionBuild, ActionDamage, ActionTrade, ActionEnter:
+			default:
+				return wrap(fmt.Errorf("bad action %q", act))
+			}
+		}
+		switch s.When.Actor {
+		case "", RoleAny, RoleMember, RoleVisitor, RoleWanted:
+		default:
+			return wrap(fmt.Errorf("bad actor %q", s.When.Actor))
+		}
+		switch s.When.Region {
+		case "", RegionLand, RegionCore:
+		default:
+			return wrap(fmt.Errorf("bad region %q", s.When.Region))
+		}
+		switch s.When.Outcome {
+		case "", OutcomeAllowed, OutcomeDenied:
+		default:
+			return wrap(fmt.Errorf("bad outcome %q", s.When.Outcome))
+		}
+		for _, it := range s.When.Items {
+			if err := ref(it, "items"); err != nil {
+				return wrap(err)
+			}
+		}
+		if len(s.When.Time) != 0 {
+			if len(s.When.Time) != 2 {
+				return wrap(fmt.Errorf("time must be [from, to]"))
+			}
+			for _, t := range s.When.Time {
+				if err := ref(t, "time"); err != nil {
+					return wrap(err)
+				}
+			}
+		}
+		var err error
+		switch s.Then.Effect {
+		case EffectDeny:
+			if s.When.Outcome != "" {
+				err = fmt.Errorf("DENY cannot have an outcome")
+			}
+		case EffectFine, EffectTicket:
+			if err = ref(s.Then.Item, "item"); err == nil {
+				err = ref(s.Then.Amount, "amount")
+			}
+		case EffectTax:
+			err = ref(s.Then.Rate, "rate")
+		case EffectRep:
+			err = ref(s.Then.Amount, "amount")
+		default:
+			err = fmt.Errorf("bad effect %q", s.Then.Effect)
+		}
+		if err != nil {
+			return wrap(err)
+		}
+	}
+	return nil
+}
+
+// Compile resolves parameter references against normalized law params. Rules that
+// cannot have any effect (zero amount/rate, empty time window) are dropped, so a law
+// with e.g. ticket_cost=0 switches its effect off.
+func Compile(specs []RuleSpec, params map[string]string) ([]Rule, error) {
+	resolve := func(a Arg) (string, error) {
+		name, ok := a.Param()
+		if !ok {
+			return string(a), nil
+		}
+		v, ok := params[name]
+		if !ok || strings.TrimSpace(v) == "" {
+			return "", fmt.Errorf("missing %s", name)
+		}
+		return strings.TrimSpace(v), nil
+	}
+	num := func(a Arg) (float64, error) {
+		s, err := resolve(a)
+		if err != nil {
+			return 0, err
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("bad number %q", s)
+		}
+		if a.negated() {
+			f = -f
+		}
+		return f, nil
+	}
+
+	out := make([]Rule, 0, len(specs))
+	for _, s := range specs {
+		r := Rule{
+			Actions: append([]string(nil), s.When.Actions...),
+			Actor:   s.When.Actor,
+			Region:  s.When.Region,
+			Outcome: s.When.Outcome,
+			Effect:  s.Then.Effect,
+		}
+		if r.Actor == RoleAny {
+			r.Actor = ""
+		}
+		if r.Region == RegionLand {
+			r.Region = ""
+		}
+		for _, it := range s.When.Items {
+			v, err := resolve(it)
+			if err != nil {
+				return nil, err
+			}
+			r.Items = append(r.Items, v)
+		}
+		if len(s.When.Time) == 2 {
+			from, err := num(s.When.Time[0])
+			if err != nil {
+				return nil, err
+			}
+			to, err := num(s.When.Time[1])
+			if err != nil {
+				return nil, err
+			}
+			if from == to {
+				continue
+			}
+			r.TimeWindow, r.TimeFrom, r.TimeTo = true, from, to
+		}
+		switch r.Effect {
+		case EffectFine, EffectTicket, EffectRep:
+			if r.Effect != EffectRep {
+				item, err := resolve(s.Then.Item)
+				if err != nil {
+					return nil, err
+				}
+				if item == "" {
+					continue
+				}
+				r.Item = item
+			}
+			f, err := num(s.Then.Amount)
+			if err != nil {
+				return nil, err
+			}
+			r.Amount = int(f)
+			if r.Amount == 0 || (r.Amount < 0 && r.Effect != EffectRep) {
+				continue
+			}
+		case EffectTax:
+			f, err := num(s.Then.Rate)
+			if err != nil {
+				return nil, err
+			}
+			if f <= 0 {
+				continue
+			}
+			r.Rate = f
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// NormalizeParams validates raw proposal params against the template's declared
+// types, clamps them to limits and returns canonical strings.
+func NormalizeParams(types map[string]string, limits map[string][2]float64, raw map[string]interface{}, itemExists func(string) bool) (map[string]string, error) {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := make(map[string]string, len(types))
+	for _, name := range names {
+		typ := types[name]
+		switch typ {
+		case TypeItemID:
+			item, err := ParamString(raw, name)
+			if err != nil {
+				return nil, err
+			}
+			if itemExists != nil && !itemExists(item) {
+				return nil, fmt.Errorf("unknown %s", name)
+			}
+			out[name] = item
+		case TypeInt, TypeFloat, TypeFloat01:
+			f, err := ParamFloat(raw, name)
+			if err != nil {
+				return nil, err
+			}
+			if typ == TypeInt {
+				f = float64(int(f))
+			}
+			lim, ok := limits[name]
+			if typ == TypeFloat01 && !ok {
+				lim, ok = [2]float64{0, 1}, true
+			}
+			if ok {
+				if f < lim[0] {
+					f = lim[0]
+				}
+				if f > lim[1] {
+					f = lim[1]
+				}
+			}
+			if typ == TypeInt {
+				out[name] = strconv.Itoa(int(f))
+			} else {
+				out[name] = FloatToCanonString(f)
+			}
+		default:
+			return nil, fmt.Errorf("bad param type %s for %s", typ, name)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,50 @@
+package world
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	auctionpkg "voxelcraft.ai/internal/sim/world/feature/contracts/auction"
+	pricespkg "voxelcraft.ai/internal/sim/world/feature/economy/prices"
+)
+
+// itemValue is the price index value of one unit of item in whole reference units.
+func (w *World) itemValue(item string) int64 {
+	return pricespkg.Value(w.prices, item)
+}
+
+// itemUnitValue is the price index value of one unit of item in reference units.
+func (w *World) itemUnitValue(item string) float64 {
+	return pricespkg.UnitValue(w.prices, item)
+}
+
+// observePrices feeds one completed exchange (fromID handed over a to toID for b) into
+// the index. An agent dealing with itself is no market evidence and is skipped.
+func (w *World) observePrices(fromID, toID string, a, b map[string]int, nowTick uint64) {
+	if fromID == toID {
+		return
+	}
+	if w.prices == nil {
+		w.prices = map[string]*PriceSeries{}
+	}
+	pricespkg.Observe(w.prices, a, b, nowTick)
+}
+
+// observeContractPrices records a settled GATHER/DELIVER contract (the acceptor's
+// delivered requirements against the poster's reward) or a sold AUCTION lot against its
+// winning bid. BUILD rewards pay for labour and say nothing about item prices.
+func (w *World) observeContractPrices(c *Contract, nowTick uint64) {
+	if c == nil {
+		return
+	}
+	switch c.Kind {
+	case "GATHER", "DELIVER":
+		w.observePrices(c.Acceptor, c.Poster, c.Requirements, c.Reward, nowTick)
+	case "AUCTION":
+		if win, ok := auctionpkg.Winner(c); ok {
+			w.observePrices(c.Poster, win.Bidder, c.Reward, map[string]int{c.PriceItem: win.Amount}, nowTick)
+		}
+	}
+}
+
+func (w *World) priceIndexEntries() []protocol.PriceObs {
+	return pricespkg.Traded(w.prices)
+}
@@ -0,0 +1,125 @@
+package world
+
+import (
+	"slices"
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	marketpkg "voxelcraft.ai/internal/sim/world/feature/economy/market"
+	pricespkg "voxelcraft.ai/internal/sim/world/feature/economy/prices"
+)
+
+func TestPrices_TradesMoveIndex(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "prices", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000, AllowTrade: true}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	join := func(name string) *Agent {
+		resp := make(chan JoinResponse, 1)
+		w.handleJoin(JoinRequest{Name: name, Resp: resp})
+		return w.agents[(<-resp).Welcome.AgentID]
+	}
+	a, b := join("a"), join("b")
+	a.Inventory = map[string]int{"IRON_INGOT": 10}
+	b.Inventory = map[string]int{"COAL": 40}
+	act := func(ag *Agent, inst protocol.InstantReq) ActionEnvelope {
+		return ActionEnvelope{AgentID: ag.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: ag.ID, Instants: []protocol.InstantReq{inst}}}
+	}
+
+	// Iron trades at 4 COAL (ref 2 each), well above its reference value of 5.
+	for i := 0; i < pricespkg.MinSamples; i++ {
+		w.step(nil, nil, []ActionEnvelope{act(a, protocol.InstantReq{ID: "I_offer", Type: InstantTypeOfferTrade, To: b.ID, Offer: [][]interface{}{{"IRON_INGOT", 1}}, Request: [][]interface{}{{"COAL", 4}}})})
+		ev := lastTradeOffer(b)
+		if ev == nil {
+			t.Fatalf("no trade offer: %v", b.Events)
+		}
+		tradeID := ev["trade_id"].(string)
+		w.step(nil, nil, []ActionEnvelope{act(b, protocol.InstantReq{ID: "I_accept", Type: InstantTypeAcceptTrade, TradeID: tradeID})})
+	}
+	if a.Inventory["COAL"] != 12 || b.Inventory["IRON_INGOT"] != 3 {
+		t.Fatalf("trades did not settle: a=%v b=%v", a.Inventory, b.Inventory)
+	}
+	if got := w.itemValue("IRON_INGOT"); got != 8 {
+		t.Fatalf("iron index value: got %d want 8", got)
+	}
+
+	w.step(nil, nil, []ActionEnvelope{act(b, protocol.InstantReq{ID: "I_offer", Type: InstantTypeOfferTrade, To: a.ID, Offer: [][]interface{}{{"COAL", 1}}, Request: [][]interface{}{{"IRON_INGOT", 1}}})})
+	if ev := lastTradeOffer(a); ev == nil || ev["request_value"] != int64(8) {
+		t.Fatalf("request_value should follow the index: %v", a.Events)
+	}
+
+	obs := w.buildObs(a, &clientState{}, w.CurrentTick())
+	j := slices.IndexFunc(obs.Prices, func(p protocol.PriceObs) bool { return p.Item == "IRON_INGOT" })
+	if j < 0 || obs.Prices[j].Price != 8 || obs.Prices[j].Base != 5 || obs.Prices[j].Samples != pricespkg.MinSamples {
+		t.Fatalf("prices obs: %+v", obs.Prices)
+	}
+
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	tick := w.CurrentTick() - 1
+	if err := w2.ImportSnapshot(w.ExportSnapshot(tick)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+	if got := w2.itemValue("IRON_INGOT"); got != 8 {
+		t.Fatalf("iron index after import: got %d want 8", got)
+	}
+}
+
+func TestPrices_SelfMatchedFillsAreNotSampled(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "prices", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000, AllowTrade: true}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "washer", Resp: resp})
+	a := w.agents[(<-resp).Welcome.AgentID]
+
+	term := Vec3i{X: a.Pos.X + 10, Z: a.Pos.Z}
+	setAir(w, term)
+	w.chunks.SetBlock(term, w.catalogs.Blocks.Index["MARKET_TERMINAL"])
+	w.ensureContainerForPlacedBlock(term, "MARKET_TERMINAL")
+	c := w.containers[term]
+	base := w.itemValue("IRON_INGOT")
+
+	// Fills between one agent's own bid and ask, as if the book had crossed them.
+	for i := 0; i < 2*pricespkg.MinSamples; i++ {
+		c.Inventory["IRON_INGOT"]++
+		c.Inventory["COAL"] += 40
+		c.Reserve("IRON_INGOT", 1)
+		c.Reserve("COAL", 40)
+		buy := &MarketOrder{OrderID: "B", TerminalPos: term, Owner: a.ID, Item: "IRON_INGOT", PriceItem: "COAL", Price: 40}
+		sell := &MarketOrder{OrderID: "S", TerminalPos: term, Owner: a.ID, Item: "IRON_INGOT", PriceItem: "COAL", Price: 40}
+		w.settleMarketFill(w.CurrentTick(), marketpkg.Fill{Buy: buy, Sell: sell, Count: 1, Price: 40})
+	}
+	if got := w.itemValue("IRON_INGOT"); got != base {
+		t.Fatalf("self-matched fills moved the iron index: %d -> %d", base, got)
+	}
+	if len(w.priceIndexEntries()) != 0 {
+		t.Fatalf("self-matched fills were sampled: %+v", w.priceIndexEntries())
+	}
+}
+
+func lastTradeOffer(a *Agent) protocol.Event {
+	for i := len(a.Events) - 1; i >= 0; i-- {
+		if a.Events[i]["type"] == "TRADE_OFFER" {
+			return a.Events[i]
+		}
+	}
+	return nil
+}
@@ -40,6 +40,8 @@ func (w *World) BlockPalette() []string {
 //
 // This must be called only when the world is stopped or from the world loop goroutine.
 func (w *World) ImportSnapshot(s snapshot.SnapshotV1) error {
+	// The next logged tick records every section digest again.
+	w.loggedSections = nil
 	return w.importSnapshotV1(s)
 }
 
@@ -56,3 +58,4 @@ func (w *World) CurrentTick() uint64 { return w.tick.Load() }
 
 func (w *World) systemMovement(nowTick uint64) { w.systemMovementImpl(nowTick) }
 func (w *World) systemWork(nowTick uint64)     { w.systemWorkImpl(nowTick) }
+func (w *World) systemMobs(nowTick uint64)     { w.systemMobsImpl(nowTick) }
@@ -14,11 +14,21 @@ func (w *World) RequestTransferOut(ctx context.Context, agentID string) (AgentTr
 	return transferruntimepkg.RequestTransferOut(ctx, w.transferOut, agentID)
 }
 
-func (w *World) RequestTransferIn(ctx context.Context, t AgentTransfer, out chan []byte, delta bool) error {
+func (w *World) RequestTransferIn(ctx context.Context, t AgentTransfer, out chan []byte, delta bool, encoding string) error {
 	if w == nil {
 		return errors.New("transfer in not available")
 	}
-	return transferruntimepkg.RequestTransferIn(ctx, w.transferIn, t, out, delta)
+	return transferruntimepkg.RequestTransferIn(ctx, w.transferIn, t, out, delta, encoding)
+}
+
+// RequestPermitTransferIn is RequestTransferIn gated on this world's permits, checked and
+// charged against the incoming agent. A refusal is a *TransferDeniedError carrying the
+// action-result code; the agent is not admitted and t is left untouched.
+func (w *World) RequestPermitTransferIn(ctx context.Context, t AgentTransfer, permits []PermitRule, out chan []byte, delta bool, encoding string) (PermitGrant, error) {
+	if w == nil {
+		return PermitGrant{}, errors.New("transfer in not available")
+	}
+	return transferruntimepkg.RequestPermitTransferIn(ctx, w.transferIn, t, permits, out, delta, encoding)
 }
 
 func (w *World) handleTransferIn(req transferruntimepkg.TransferInReq) {
@@ -42,9 +52,10 @@ func (w *World) handleTransferIn(req transferruntimepkg.TransferInReq) {
 		CurrentNextOrg: w.nextOrgNum.Load(),
 	})
 	if out.Err != "" {
-		resp.Err = out.Err
+		resp.Err, resp.Code = out.Err, out.Code
 		return
 	}
+	resp.Permit = out.Permit
 	if out.NextOrg > w.nextOrgNum.Load() {
 		w.nextOrgNum.Store(out.NextOrg)
 	}
@@ -52,8 +63,11 @@ func (w *World) handleTransferIn(req transferruntimepkg.TransferInReq) {
 		resp.Err = "transfer in did not produce agent"
 		return
 	}
+	if a := w.agents[out.JoinedAgentID]; a != nil {
+		a.Pos.Y = w.spawnY(a.Pos.X, a.Pos.Z)
+	}
 	if req.Out != nil {
-		w.clients[out.JoinedAgentID] = &clientState{Out: req.Out, DeltaVoxels: req.DeltaVoxels}
+		w.clients[out.JoinedAgentID] = &clientState{Out: req.Out, DeltaVoxels: req.DeltaVoxels, Encoding: req.Encoding}
 	}
 }
 
@@ -52,6 +52,8 @@ func (w *World) Run(ctx context.Context) error {
 			w.handleEventsReq(req)
 		case req := <-w.actDedupeReq:
 			w.handleActDedupeReq(req)
+		case req := <-w.obsAck:
+			w.handleObsAck(req)
 		case req := <-w.orgMetaReq:
 			w.handleOrgMetaReq(req)
 		case req := <-w.orgMetaUpsert:
@@ -66,6 +68,9 @@ func (w *World) Run(ctx context.Context) error {
 			pendingActions = append(pendingActions, env)
 		case <-ticker.C:
 			w.stepInternal(pendingJoins, pendingLeaves, pendingActions, pendingTransferOut, pendingTransferIn, pendingInjectEvents)
+			if err := w.chunks.err(); err != nil {
+				return fmt.Errorf("tick %d: chunk store: %w", w.CurrentTick(), err)
+			}
 			w.handleAdminSnapshotRequests(pendingAdmin)
 			w.handleAdminResetRequests(pendingAdminReset)
 			pendingJoins = pendingJoins[:0]
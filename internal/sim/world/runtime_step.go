@@ -1,10 +1,10 @@
 package world
 
 import (
-	"encoding/json"
 	"errors"
 	"time"
 
+	"voxelcraft.ai/internal/protocol"
 	resourcespkg "voxelcraft.ai/internal/sim/world/feature/director/resources"
 	transferruntimepkg "voxelcraft.ai/internal/sim/world/feature/transfer/runtime"
 )
@@ -20,6 +20,12 @@ func (w *World) stepInternal(joins []JoinRequest, leaves []string, actions []Act
 	w.maybeWorldResetNotice(nowTick)
 	w.maybeSeasonRollover(nowTick)
 
+	// Chunk residency changes only at tick boundaries (terrain content never depends on it).
+	w.maintainChunks(nowTick)
+	if w.chunks.err() != nil {
+		return
+	}
+
 	// Apply leaves and joins deterministically at tick boundary.
 	recordedLeaves := make([]string, 0, len(leaves))
 	for _, id := range leaves {
@@ -30,10 +36,13 @@ func (w *World) stepInternal(joins []JoinRequest, leaves []string, actions []Act
 	}
 	recordedJoins := make([]RecordedJoin, 0, len(joins))
 	for _, req := range joins {
-		resp := w.joinAgent(req.Name, req.DeltaVoxels, req.Out)
+		resp := w.joinAgentAs(req.AgentID, req.Reserved, req.Name, req.DeltaVoxels, req.Encoding, req.Out)
 		if req.Resp != nil {
 			req.Resp <- resp
 		}
+		if resp.Welcome.AgentID == "" {
+			continue
+		}
 		recordedJoins = append(recordedJoins, RecordedJoin{AgentID: resp.Welcome.AgentID, Name: req.Name})
 	}
 
@@ -66,6 +75,7 @@ func (w *World) stepInternal(joins []JoinRequest, leaves []string, actions []Act
 
 	// Maintenance runs at tick boundary before any actions so permissions reflect the current stage.
 	w.tickClaimsMaintenance(nowTick)
+	w.systemPermits(nowTick)
 
 	// Apply actions in server_receive_order (the inbox order).
 	recorded := make([]RecordedAction, 0, len(actions))
@@ -79,15 +89,23 @@ func (w *World) stepInternal(joins []JoinRequest, leaves []string, actions []Act
 		w.applyAct(a, env.Act, nowTick)
 	}
 
-	// Systems: movement -> work -> environment (minimal) -> others (stub)
+	// Systems: movement -> work -> mobs -> environment (minimal) -> others (stub)
 	w.systemMovement(nowTick)
 	w.systemWork(nowTick)
+	w.systemTaskQueues(nowTick)
+	w.systemMobs(nowTick)
+	w.systemSignals(nowTick)
 	w.systemConveyors(nowTick)
+	w.systemMachines(nowTick)
 	w.systemEnvironment(nowTick)
+	w.systemWater(nowTick)
 	w.tickLaws(nowTick)
 	w.systemDirector(nowTick)
 	w.tickContracts(nowTick)
+	w.tickMarket(nowTick)
 	w.systemFun(nowTick)
+	w.systemGravity(nowTick)
+	w.systemTriggers(nowTick)
 	if w.stats != nil {
 		for _, a := range w.agents {
 			if a == nil {
@@ -104,7 +122,11 @@ func (w *World) stepInternal(joins []JoinRequest, leaves []string, actions []Act
 			continue
 		}
 		obs := w.buildObs(a, cl, nowTick)
-		b, err := json.Marshal(obs)
+		var msg any = obs
+		if cl.ObsDelta != nil {
+			msg = cl.ObsDelta.Next(obs, cl.LastVoxels, nowTick)
+		}
+		b, err := protocol.Marshal(cl.Encoding, msg)
 		if err != nil {
 			continue
 		}
@@ -114,9 +136,19 @@ func (w *World) stepInternal(joins []JoinRequest, leaves []string, actions []Act
 	// Observer stream (admin-only, read-only).
 	w.stepObservers(nowTick, recordedJoins, recordedLeaves, recorded)
 
-	digest := w.stateDigest(nowTick)
+	// Terrain that could not be read back fails the tick: it is neither logged nor snapshotted,
+	// and Run stops the world.
+	if w.chunks.err() != nil {
+		return
+	}
+
+	var digest string
 	if w.tickLogger != nil {
-		_ = w.tickLogger.WriteTick(TickLogEntry{Tick: nowTick, Joins: recordedJoins, Leaves: recordedLeaves, Actions: recorded, Digest: digest})
+		var sections map[string]string
+		digest, sections = w.stateDigestWithSections(nowTick)
+		_ = w.tickLogger.WriteTick(TickLogEntry{Tick: nowTick, Joins: recordedJoins, Leaves: recordedLeaves, Actions: recorded, Digest: digest, Sections: w.sectionsToLog(nowTick, sections)})
+	} else {
+		digest = w.stateDigest(nowTick)
 	}
 
 	// Snapshot every N ticks (default 3000), starting after tick 0.
@@ -153,11 +185,12 @@ func (w *World) stepInternal(joins []JoinRequest, leaves []string, actions []Act
 
 	dm := w.computeDirectorMetrics(nowTick)
 	w.metrics.Store(WorldMetrics{
-		Tick:         nextTick,
-		Agents:       len(w.agents),
-		Clients:      len(w.clients),
-		LoadedChunks: len(w.chunks.chunks),
-		ResetTotal:   w.resetTotal,
+		Tick:          nextTick,
+		Agents:        len(w.agents),
+		Clients:       len(w.clients),
+		LoadedChunks:  len(w.chunks.chunks),
+		EvictedChunks: w.chunks.evictedCount(),
+		ResetTotal:    w.resetTotal,
 		QueueDepths: QueueDepths{
 			Inbox:  len(w.inbox),
 			Join:   len(w.join),
@@ -6,6 +6,7 @@ import (
 	"voxelcraft.ai/internal/protocol"
 	runtimepkg "voxelcraft.ai/internal/sim/world/feature/director/runtime"
 	respawnpkg "voxelcraft.ai/internal/sim/world/feature/survival/respawn"
+	storepkg "voxelcraft.ai/internal/sim/world/terrain/store"
 )
 
 func (w *World) maybeSeasonRollover(nowTick uint64) {
@@ -62,7 +63,11 @@ func (w *World) resetWorldForNewSeason(nowTick uint64, newSeason int, archiveTic
 	// Reset terrain/chunks with the new seed.
 	gen := w.chunks.gen
 	gen.Seed = w.cfg.Seed
-	w.chunks = NewChunkStore(gen)
+	if err := w.resetChunks(storepkg.NewChunkStore(gen)); err != nil {
+		// Keep the reset going in memory only; evicted chunks simply stay resident.
+		w.chunkRegions = nil
+		w.chunks = NewChunkStore(gen)
+	}
 
 	// Reset world-scoped mutable state.
 	w.weather = "CLEAR"
@@ -77,12 +82,23 @@ func (w *World) resetWorldForNewSeason(nowTick uint64, newSeason int, archiveTic
 	w.containers = map[Vec3i]*Container{}
 	w.items = map[string]*ItemEntity{}
 	w.itemsAt = map[Vec3i][]string{}
+	w.mobs = map[string]*Mob{}
 	w.trades = map[string]*Trade{}
 	w.boards = map[string]*Board{}
 	w.signs = map[Vec3i]*Sign{}
 	w.conveyors = map[Vec3i]ConveyorMeta{}
+	w.machines = map[Vec3i]MachineMeta{}
+	w.shops = map[Vec3i]*Shop{}
 	w.switches = map[Vec3i]bool{}
+	w.crops = map[Vec3i]*Crop{}
+	w.waterLevels = map[Vec3i]int{}
+	w.waterActive = map[Vec3i]bool{}
+	w.signalNodes = map[Vec3i]*SignalNode{}
+	w.signalActive = map[Vec3i]bool{}
+	w.signalPolled = map[Vec3i]bool{}
 	w.contracts = map[string]*Contract{}
+	w.orders = map[string]*MarketOrder{}
+	w.prices = map[string]*PriceSeries{}
 	w.laws = map[string]*Law{}
 	w.structures = map[string]*Structure{}
 	w.stats = NewWorldStats(300, 72000)
@@ -139,6 +155,7 @@ func (w *World) resetWorldForNewSeason(nowTick uint64, newSeason int, archiveTic
 
 func (w *World) resetAgentForNewSeason(nowTick uint64, a *Agent) {
 	respawnpkg.ResetForSeason(a, w.findSpawnAir)
+	a.Pos.Y = w.spawnY(a.Pos.X, a.Pos.Z)
 	// Award novelty for the first biome arrival in the season.
 	w.funOnBiome(a, nowTick)
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"voxelcraft.ai/internal/protocol"
+	pricespkg "voxelcraft.ai/internal/sim/world/feature/economy/prices"
 	catalogspkg "voxelcraft.ai/internal/sim/world/feature/session/catalogs"
 	lifecyclepkg "voxelcraft.ai/internal/sim/world/feature/session/lifecycle"
 	welcomepkg "voxelcraft.ai/internal/sim/world/feature/session/welcome"
@@ -21,6 +22,7 @@ func (w *World) buildWelcome(agentID, resumeToken string) protocol.WelcomeMsg {
 		ResetNoticeTicks:   w.cfg.ResetNoticeTicks,
 		TickRateHz:         w.cfg.TickRateHz,
 		ObsRadius:          w.cfg.ObsRadius,
+		Height:             w.cfg.Height,
 		DayTicks:           w.cfg.DayTicks,
 		Seed:               w.cfg.Seed,
 		BlockPaletteDigest: w.catalogs.Blocks.PaletteDigest,
@@ -48,6 +50,7 @@ func (w *World) buildCatalogMsgs() ([]protocol.CatalogMsg, string) {
 		LawTemplates:       w.catalogs.Laws.Templates,
 		EventsDigest:       w.catalogs.Events.Digest,
 		EventsByID:         w.catalogs.Events.ByID,
+		PriceIndex:         pricespkg.Catalog(w.prices, w.catalogs.Items.Palette),
 		Tuning: catalogspkg.TuningInput{
 			SnapshotEveryTicks: w.cfg.SnapshotEveryTicks,
 			DirectorEveryTicks: w.cfg.DirectorEveryTicks,
@@ -78,10 +81,27 @@ func (w *World) buildCatalogMsgs() ([]protocol.CatalogMsg, string) {
 }
 
 func (w *World) joinAgent(name string, delta bool, out chan []byte) JoinResponse {
+	return w.joinAgentAs("", nil, name, delta, "", out)
+}
+
+// joinAgentAs joins a new agent. A requested id (A<n>) is honoured when it is free so bound
+// identities keep their agent id; otherwise the next sequential id not reserved for another
+// identity is used. Binding the identity to the id is up to the caller (off the world goroutine).
+func (w *World) joinAgentAs(requestedID string, reserved func(agentID string) bool, name string, delta bool, encoding string, out chan []byte) JoinResponse {
 	name = catalogspkg.NormalizeAgentName(name)
 	nowTick := w.tick.Load()
 
-	idNum := w.nextAgentNum.Add(1)
+	idNum, ok := lifecyclepkg.ParseAgentID(requestedID)
+	if ok && w.agents[requestedID] == nil {
+		if idNum > w.nextAgentNum.Load() {
+			w.nextAgentNum.Store(idNum)
+		}
+	} else {
+		idNum = w.nextAgentNum.Add(1)
+		for reserved != nil && reserved(lifecyclepkg.NewAgentID(idNum)) {
+			idNum = w.nextAgentNum.Add(1)
+		}
+	}
 	agentID := lifecyclepkg.NewAgentID(idNum)
 
 	// Spawn near origin on surface.
@@ -92,7 +112,7 @@ func (w *World) joinAgent(name string, delta bool, out chan []byte) JoinResponse
 		AgentID:      agentID,
 		Name:         name,
 		WorldID:      w.cfg.ID,
-		Spawn:        Vec3i{X: spawnX, Y: 0, Z: spawnZ},
+		Spawn:        Vec3i{X: spawnX, Y: w.spawnY(spawnX, spawnZ), Z: spawnZ},
 		StarterItems: w.cfg.StarterItems,
 	})
 
@@ -104,7 +124,7 @@ func (w *World) joinAgent(name string, delta bool, out chan []byte) JoinResponse
 
 	w.agents[agentID] = a
 	if out != nil {
-		w.clients[agentID] = &clientState{Out: out, DeltaVoxels: delta}
+		w.clients[agentID] = &clientState{Out: out, DeltaVoxels: delta, Encoding: encoding}
 	}
 
 	token := lifecyclepkg.NewResumeToken(w.cfg.ID, time.Now().UnixNano())
@@ -118,7 +138,7 @@ func (w *World) joinAgent(name string, delta bool, out chan []byte) JoinResponse
 }
 
 func (w *World) handleJoin(req JoinRequest) {
-	resp := w.joinAgent(req.Name, req.DeltaVoxels, req.Out)
+	resp := w.joinAgentAs(req.AgentID, req.Reserved, req.Name, req.DeltaVoxels, req.Encoding, req.Out)
 	if req.Resp != nil {
 		req.Resp <- resp
 	}
@@ -126,14 +146,20 @@ func (w *World) handleJoin(req JoinRequest) {
 
 func (w *World) handleAttach(req AttachRequest) {
 	token := strings.TrimSpace(req.ResumeToken)
-	if token == "" || req.Out == nil {
+	agentID := strings.TrimSpace(req.AgentID)
+	if (token == "" && agentID == "") || req.Out == nil {
 		if req.Resp != nil {
 			req.Resp <- JoinResponse{}
 		}
 		return
 	}
 
-	attached := lifecyclepkg.AttachByToken(token, w.agents, w.cfg.ID, time.Now().UnixNano())
+	var attached lifecyclepkg.AttachResult
+	if agentID != "" {
+		attached = lifecyclepkg.AttachByID(agentID, w.agents, w.cfg.ID, time.Now().UnixNano())
+	} else {
+		attached = lifecyclepkg.AttachByToken(token, w.agents, w.cfg.ID, time.Now().UnixNano())
+	}
 	if !attached.OK || attached.Agent == nil {
 		if req.Resp != nil {
 			req.Resp <- JoinResponse{}
@@ -143,7 +169,7 @@ func (w *World) handleAttach(req AttachRequest) {
 	a := attached.Agent
 
 	// Attach client (does not affect simulation determinism).
-	w.clients[a.ID] = &clientState{Out: req.Out, DeltaVoxels: req.DeltaVoxels}
+	w.clients[a.ID] = &clientState{Out: req.Out, DeltaVoxels: req.DeltaVoxels, Encoding: req.Encoding}
 
 	// If a world event is active, inform the resuming agent.
 	w.enqueueActiveEventForAgent(w.tick.Load(), a)
@@ -0,0 +1,129 @@
+package world
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/tasks"
+	inventorypkg "voxelcraft.ai/internal/sim/world/feature/economy/inventory"
+	shoppkg "voxelcraft.ai/internal/sim/world/feature/economy/shop"
+	workruntimepkg "voxelcraft.ai/internal/sim/world/feature/work/runtime"
+	shopinstctxpkg "voxelcraft.ai/internal/sim/world/featurectx/instants/shop"
+	rulespkg "voxelcraft.ai/internal/sim/world/policy/rules"
+)
+
+// openShop gives a freshly placed SHOP to its placer with an empty price list.
+func (w *World) openShop(pos Vec3i, owner string) {
+	w.shops[pos] = &Shop{Owner: owner}
+}
+
+func newShopInstantsEnv(w *World) shopinstctxpkg.Env {
+	if w == nil {
+		return shopinstctxpkg.Env{}
+	}
+	return shopinstctxpkg.Env{
+		GetContainerByIDFn: w.getContainerByID,
+		DistanceFn:         Manhattan,
+		ItemExistsFn: func(itemID string) bool {
+			_, ok := w.catalogs.Items.Defs[itemID]
+			return ok
+		},
+		GetShopFn: func(pos Vec3i) *Shop {
+			return w.shops[pos]
+		},
+	}
+}
+
+func handleInstantSetShopPrice(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	shoppkg.HandleSetShopPrice(newShopInstantsEnv(w), actionResult, shoppkg.PriceHooks{
+		OnPriced: func(pos Vec3i, in shoppkg.PriceInput) {
+			w.auditEvent(nowTick, a.ID, "SHOP_PRICE", pos, "SET_SHOP_PRICE", map[string]any{
+				"shop_id":    containerID(shoppkg.Block, pos),
+				"item":       in.Item,
+				"price_item": in.PriceItem,
+				"price":      in.Price,
+			})
+		},
+	}, a, inst, nowTick)
+}
+
+func handleTaskBuy(w *World, a *Agent, tr protocol.TaskReq, nowTick uint64) {
+	workruntimepkg.HandleTaskBuy(newWorkTaskReqEnv(w), actionResult, a, tr, nowTick, w.cfg.AllowTrade)
+}
+
+// tickBuy completes a BUY in one tick: the goods leave the shop's stock and the payment,
+// less the land's trade tax at the owner's rate, goes into the shop.
+func (w *World) tickBuy(a *Agent, wt *tasks.WorkTask, nowTick uint64) {
+	fail := func(code, msg string) {
+		a.WorkTask = nil
+		a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_FAIL", "task_id": wt.TaskID, "code": code, "message": msg})
+	}
+	term := w.getContainerByID(wt.TargetID)
+	var s *Shop
+	if term != nil && term.Type == shoppkg.Block {
+		s = w.shops[term.Pos]
+	}
+	in := shoppkg.BuyInput{HasShop: s != nil, Buyer: a.ID, Count: wt.Count}
+	var price ShopPrice
+	if s != nil {
+		price, in.Listed = s.Prices[wt.ItemID]
+		in.Owner = s.Owner
+		in.Distance = Manhattan(a.Pos, term.Pos)
+		in.Stock = term.AvailableCount(wt.ItemID)
+		in.Cost = wt.Count * price.Price
+		in.Funds = a.Inventory[price.PriceItem]
+	}
+	if ok, code, msg := shoppkg.ValidateBuy(in); !ok {
+		fail(code, msg)
+		return
+	}
+	items := []string{wt.ItemID, price.PriceItem}
+	if !w.lawDecision(a.ID, term.Pos, rulespkg.ActionTrade, items, nowTick).Allowed {
+		fail("E_NO_PERMISSION", "trade not allowed here")
+		return
+	}
+
+	taxRate := w.marketTaxRate(s.Owner, term.Pos, items, nowTick)
+	sale := shoppkg.Sell(term, a, wt.ItemID, wt.Count, price, taxRate)
+	land := w.landAt(term.Pos)
+	sink, taxTo := w.landTaxSink(land)
+	if sink != nil {
+		for item, n := range sale.Tax {
+			sink[item] += n
+		}
+	}
+	landID := ""
+	if land != nil {
+		landID = land.LandID
+	}
+	shopID := term.ID()
+	w.auditEvent(nowTick, a.ID, "SHOP_SALE", term.Pos, "BUY", map[string]any{
+		"shop_id":    shopID,
+		"owner":      s.Owner,
+		"buyer":      a.ID,
+		"item":       sale.Item,
+		"count":      sale.Count,
+		"price_item": sale.PriceItem,
+		"paid":       sale.Paid,
+		"tax_paid":   inventorypkg.EncodeItemPairs(sale.Tax),
+		"land_id":    landID,
+		"tax_to":     taxTo,
+	})
+	w.observePrices(s.Owner, a.ID, map[string]int{sale.Item: sale.Count}, map[string]int{sale.PriceItem: sale.Paid}, nowTick)
+	if w.stats != nil {
+		w.stats.RecordTrade(nowTick)
+	}
+	if owner := w.agents[s.Owner]; owner != nil {
+		owner.AddEvent(protocol.Event{
+			"t":          nowTick,
+			"type":       "SHOP_SALE",
+			"shop_id":    shopID,
+			"buyer":      a.ID,
+			"item":       sale.Item,
+			"count":      sale.Count,
+			"price_item": sale.PriceItem,
+			"paid":       sale.Paid,
+		})
+	}
+
+	a.WorkTask = nil
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "TASK_DONE", "task_id": wt.TaskID, "kind": string(wt.Kind)})
+}
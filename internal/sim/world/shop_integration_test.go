@@ -0,0 +1,106 @@
+package world
+
+import (
+	"slices"
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestShop_FixedPriceBuy(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "shop", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000, AllowTrade: true}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	join := func(name string) *Agent {
+		resp := make(chan JoinResponse, 1)
+		w.handleJoin(JoinRequest{Name: name, Resp: resp})
+		return w.agents[(<-resp).Welcome.AgentID]
+	}
+	owner, buyer := join("owner"), join("buyer")
+
+	pos := Vec3i{X: owner.Pos.X + 10, Z: owner.Pos.Z}
+	setAir(w, pos)
+	w.chunks.SetBlock(pos, w.catalogs.Blocks.Index["SHOP"])
+	w.ensureContainerForPlacedBlock(pos, "SHOP")
+	w.openShop(pos, owner.ID)
+	shopID := containerID("SHOP", pos)
+	owner.Pos = Vec3i{X: pos.X + 1, Z: pos.Z}
+	buyer.Pos = Vec3i{X: pos.X - 1, Z: pos.Z}
+	w.containers[pos].Inventory = map[string]int{"BREAD": 5}
+	buyer.Inventory = map[string]int{"COAL": 10}
+
+	act := func(a *Agent, insts []protocol.InstantReq, tasks ...protocol.TaskReq) ActionEnvelope {
+		return ActionEnvelope{AgentID: a.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: a.ID, Instants: insts, Tasks: tasks}}
+	}
+	taskFailCode := func(a *Agent, ref string) any {
+		i := slices.IndexFunc(a.Events, func(ev protocol.Event) bool { return ev["ref"] == ref })
+		if i < 0 {
+			return nil
+		}
+		j := slices.IndexFunc(a.Events, func(ev protocol.Event) bool {
+			return ev["type"] == "TASK_FAIL" && ev["task_id"] == a.Events[i]["task_id"]
+		})
+		if j < 0 {
+			return nil
+		}
+		return a.Events[j]["code"]
+	}
+	w.step(nil, nil, []ActionEnvelope{
+		act(owner, []protocol.InstantReq{{ID: "I_price", Type: InstantTypeSetShopPrice, TargetID: shopID, ItemID: "BREAD", PriceItem: "COAL", Price: 2}}),
+		act(buyer, []protocol.InstantReq{{ID: "I_hijack", Type: InstantTypeSetShopPrice, TargetID: shopID, ItemID: "BREAD", PriceItem: "COAL", Price: 1}}),
+	})
+	if i := slices.IndexFunc(buyer.Events, func(ev protocol.Event) bool { return ev["ref"] == "I_hijack" }); i < 0 || buyer.Events[i]["code"] != "E_NO_PERMISSION" {
+		t.Fatalf("pricing by non-owner must be denied: %v", buyer.Events)
+	}
+	if p := w.shops[pos].Prices["BREAD"]; p.PriceItem != "COAL" || p.Price != 2 {
+		t.Fatalf("price: %+v", p)
+	}
+
+	w.step(nil, nil, []ActionEnvelope{
+		act(buyer, nil, protocol.TaskReq{ID: "K_buy", Type: "BUY", TargetID: shopID, ItemID: "BREAD", Count: 3}),
+	})
+	if buyer.Inventory["BREAD"] != 3 || buyer.Inventory["COAL"] != 4 {
+		t.Fatalf("buyer inventory: %v", buyer.Inventory)
+	}
+	c := w.containers[pos]
+	if c.Inventory["BREAD"] != 2 || c.Inventory["COAL"] != 6 {
+		t.Fatalf("shop inventory: %v", c.Inventory)
+	}
+	if i := slices.IndexFunc(owner.Events, func(ev protocol.Event) bool { return ev["type"] == "SHOP_SALE" }); i < 0 || owner.Events[i]["paid"] != 6 {
+		t.Fatalf("owner sale event: %v", owner.Events)
+	}
+
+	// Stock is only for sale: a non-owner can neither buy past it nor withdraw it.
+	w.step(nil, nil, []ActionEnvelope{
+		act(buyer, nil, protocol.TaskReq{ID: "K_more", Type: "BUY", TargetID: shopID, ItemID: "BREAD", Count: 3}),
+	})
+	if code := taskFailCode(buyer, "K_more"); code != "E_NO_RESOURCE" {
+		t.Fatalf("buy past stock must fail: %v", buyer.Events)
+	}
+	w.step(nil, nil, []ActionEnvelope{
+		act(buyer, nil, protocol.TaskReq{ID: "K_steal", Type: "TRANSFER", Src: shopID, Dst: "SELF", ItemID: "COAL", Count: 6}),
+	})
+	if code := taskFailCode(buyer, "K_steal"); code != "E_NO_PERMISSION" {
+		t.Fatalf("withdraw by non-owner must be denied: %v", buyer.Events)
+	}
+
+	// Shop state survives a snapshot round trip.
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	tick := w.CurrentTick() - 1
+	if err := w2.ImportSnapshot(w.ExportSnapshot(tick)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+}
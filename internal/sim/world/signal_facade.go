@@ -0,0 +1,145 @@
+package world
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	signalpkg "voxelcraft.ai/internal/sim/world/feature/automation/signal"
+	conveyruntimepkg "voxelcraft.ai/internal/sim/world/feature/conveyor/runtime"
+	survivalruntimepkg "voxelcraft.ai/internal/sim/world/feature/survival/runtime"
+)
+
+// systemSignals polls sensors and batteries and propagates signal changes, at most
+// signal_updates_per_tick cells. It runs before conveyors so a toggle moves belts in
+// the same tick.
+func (w *World) systemSignals(nowTick uint64) {
+	if len(w.signalPolled) == 0 && len(w.signalActive) == 0 {
+		return
+	}
+	signalpkg.Step(
+		signalpkg.StepInput{
+			Nodes:  w.signalNodes,
+			Active: w.signalActive,
+			Budget: w.cfg.SignalUpdatesPerTick,
+			Polled: w.signalPolled,
+		},
+		w.signalHooks(nowTick),
+	)
+}
+
+func (w *World) signalHooks(nowTick uint64) signalpkg.StepHooks {
+	return signalpkg.StepHooks{
+		BlockNameAt: func(pos Vec3i) string { return w.blockName(w.chunks.GetBlock(pos)) },
+		SwitchOn:    func(pos Vec3i) bool { return w.switches[pos] },
+		SensorActive: func(pos Vec3i, n SignalNode) bool {
+			return w.sensorActive(nowTick, pos, n)
+		},
+		SetDoor: func(pos Vec3i, open bool) bool {
+			name := signalpkg.Door
+			if open {
+				name = signalpkg.DoorOpen
+			} else if w.agentAt(pos) {
+				return false
+			}
+			to, ok := w.catalogs.Blocks.Index[name]
+			if !ok {
+				return true
+			}
+			from := w.chunks.GetBlock(pos)
+			w.chunks.SetBlock(pos, to)
+			w.auditSetBlock(nowTick, "WORLD", pos, from, to, "SIGNAL")
+			return true
+		},
+	}
+}
+
+func (w *World) sensorActive(nowTick uint64, pos Vec3i, n SignalNode) bool {
+	switch n.Mode {
+	case signalpkg.SensorAgent:
+		for _, a := range w.agents {
+			if a != nil && Manhattan(a.Pos, pos) <= n.Arg {
+				return true
+			}
+		}
+		return false
+	case signalpkg.SensorDay:
+		return !survivalruntimepkg.IsNight(w.timeOfDay(nowTick))
+	case signalpkg.SensorNight:
+		return survivalruntimepkg.IsNight(w.timeOfDay(nowTick))
+	case signalpkg.SensorFill:
+		total := 0
+		for _, d := range conveyruntimepkg.SensorNeighborOffsets() {
+			c := w.containers[Vec3i{X: pos.X + d.X, Y: pos.Y + d.Y, Z: pos.Z + d.Z}]
+			if c == nil {
+				continue
+			}
+			for item := range c.Inventory {
+				total += c.AvailableCount(item)
+			}
+		}
+		return total >= n.Arg
+	default:
+		return w.sensorItemsActive(pos)
+	}
+}
+
+func (w *World) agentAt(pos Vec3i) bool {
+	for _, a := range w.agents {
+		if a != nil && a.Pos == pos {
+			return true
+		}
+	}
+	return false
+}
+
+// wakeSignal queues a changed cell and its neighbours for the next signal update.
+func (w *World) wakeSignal(pos Vec3i) {
+	if w.signalActive == nil {
+		return
+	}
+	if signalpkg.IsPolled(w.blockName(w.chunks.GetBlock(pos))) {
+		w.signalPolled[pos] = true
+	}
+	w.signalActive[pos] = true
+	for _, n := range signalpkg.Neighbours(pos) {
+		w.signalActive[n] = true
+	}
+}
+
+// setSignalFacing points a placed gate or battery at the placer's yaw.
+func (w *World) setSignalFacing(pos Vec3i, yaw int) {
+	dx, dz := conveyruntimepkg.YawToDir(yaw)
+	n := w.signalNodes[pos]
+	if n == nil {
+		n = &SignalNode{}
+		w.signalNodes[pos] = n
+	}
+	n.DX, n.DZ = int8(dx), int8(dz)
+	w.wakeSignal(pos)
+}
+
+// setSensor configures a sensor; its output is re-evaluated on the next poll.
+func (w *World) setSensor(pos Vec3i, mode string, arg int) {
+	n := w.signalNodes[pos]
+	if n == nil {
+		n = &SignalNode{DX: 1}
+		w.signalNodes[pos] = n
+	}
+	n.Mode, n.Arg = mode, arg
+	w.signalPolled[pos] = true
+}
+
+func handleInstantSetSensor(w *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	signalpkg.HandleSetSensor(newConveyorInstantsEnv(w), actionResult, a, inst, nowTick)
+}
+
+// conveyorEnabled: a conveyor next to a wire, switch or sensor (or a gate/battery
+// facing it) runs only while powered; one without controls always runs.
+func (w *World) conveyorEnabled(pos Vec3i) bool {
+	hooks := w.signalHooks(w.CurrentTick())
+	return !signalpkg.Controlled(w.signalNodes, hooks, pos) || signalpkg.Powered(w.signalNodes, hooks, pos)
+}
+
+// sensorOn is the sensor's last evaluated output (as shown in OBS).
+func (w *World) sensorOn(pos Vec3i) bool {
+	n := w.signalNodes[pos]
+	return n != nil && n.Level > 0
+}
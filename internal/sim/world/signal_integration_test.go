@@ -0,0 +1,96 @@
+package world
+
+import (
+	"slices"
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestSignal_SensorOpensDoorAndSurvivesSnapshot(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "signal", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "guard", Resp: resp})
+	a := w.agents[(<-resp).Welcome.AgentID]
+
+	c := Vec3i{X: a.Pos.X + 10, Z: a.Pos.Z}
+	for dx := -2; dx <= 6; dx++ {
+		for dz := -4; dz <= 4; dz++ {
+			setAir(w, Vec3i{X: c.X + dx, Z: c.Z + dz})
+		}
+	}
+	place := func(name string, pos Vec3i) {
+		t.Helper()
+		b, ok := w.catalogs.Blocks.Index[name]
+		if !ok {
+			t.Fatalf("unknown block %s", name)
+		}
+		w.chunks.SetBlock(pos, b)
+		w.auditSetBlock(w.CurrentTick(), "TEST", pos, w.chunks.gen.Air, b, "TEST")
+	}
+	door := Vec3i{X: c.X + 3, Z: c.Z}
+	place("SENSOR", c)
+	place("WIRE", Vec3i{X: c.X + 1, Z: c.Z})
+	place("WIRE", Vec3i{X: c.X + 2, Z: c.Z})
+	place("DOOR", door)
+
+	a.Pos = Vec3i{X: c.X, Z: c.Z + 3}
+	w.step(nil, nil, []ActionEnvelope{{AgentID: a.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: a.ID, Instants: []protocol.InstantReq{{
+		ID: "I_sensor", Type: InstantTypeSetSensor, TargetID: containerID("SENSOR", c), Mode: "agent", Radius: 2,
+	}}}}})
+	if n := w.signalNodes[c]; n == nil || n.Mode != "AGENT" || n.Arg != 2 {
+		t.Fatalf("sensor not configured: %+v", n)
+	}
+	w.step(nil, nil, nil)
+	if got := w.blockName(w.chunks.GetBlock(door)); got != "DOOR" {
+		t.Fatalf("door = %s with nobody in range", got)
+	}
+
+	a.Pos = Vec3i{X: c.X, Z: c.Z + 1}
+	w.step(nil, nil, nil)
+	w.step(nil, nil, nil)
+	if got := w.blockName(w.chunks.GetBlock(door)); got != "DOOR_OPEN" {
+		t.Fatalf("door = %s with an agent next to the sensor", got)
+	}
+	ents := w.buildObsEntities(a, []Vec3i{c})
+	i := slices.IndexFunc(ents, func(e protocol.EntityObs) bool { return e.Type == "SENSOR" })
+	if i < 0 || !slices.Contains(ents[i].Tags, "state:on") || !slices.Contains(ents[i].Tags, "mode:AGENT") {
+		t.Fatalf("sensor entity: %+v", ents)
+	}
+
+	// Snapshot with the door open; both worlds must close it identically.
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	tick := w.CurrentTick() - 1
+	if err := w2.ImportSnapshot(w.ExportSnapshot(tick)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+	for _, ww := range []*World{w, w2} {
+		for _, ag := range ww.agents {
+			ag.Pos = Vec3i{X: c.X, Z: c.Z + 4}
+		}
+		ww.step(nil, nil, nil)
+		ww.step(nil, nil, nil)
+		if got := ww.blockName(ww.chunks.GetBlock(door)); got != "DOOR" {
+			t.Fatalf("door = %s after the agent left", got)
+		}
+	}
+	tick = w.CurrentTick() - 1
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after resumed signals: %s vs %s", d1, d2)
+	}
+}
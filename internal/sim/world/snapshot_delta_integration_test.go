@@ -0,0 +1,56 @@
+package world
+
+import (
+	"path/filepath"
+	"testing"
+
+	"voxelcraft.ai/internal/persistence/snapshot"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestSnapshotDelta_ChainImportKeepsDigest(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "delta", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000}
+	w1, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world1: %v", err)
+	}
+	w1.StepOnce([]JoinRequest{{Name: "alpha"}, {Name: "beta"}}, nil, nil)
+
+	dir := t.TempDir()
+	cw := snapshot.NewChainWriter(dir, 10)
+	baseTick := w1.CurrentTick() - 1
+	if _, delta, err := cw.Write(w1.ExportSnapshot(baseTick), false); err != nil || delta {
+		t.Fatalf("base write delta=%v err=%v", delta, err)
+	}
+
+	a := w1.agents["A1"]
+	a.Inventory["PLANK"] += 3
+	setSolid(w1, Vec3i{X: a.Pos.X + 2, Y: 0, Z: a.Pos.Z}, w1.catalogs.Blocks.Index["STONE"])
+	for i := 0; i < 3; i++ {
+		w1.step(nil, nil, nil)
+	}
+	snapTick := w1.CurrentTick() - 1
+	path, delta, err := cw.Write(w1.ExportSnapshot(snapTick), false)
+	if err != nil || !delta || filepath.Base(path) != snapshot.FileName(snapTick, true) {
+		t.Fatalf("delta write path=%s delta=%v err=%v", path, delta, err)
+	}
+
+	snap, err := snapshot.ReadSnapshot(path)
+	if err != nil {
+		t.Fatalf("read delta: %v", err)
+	}
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	if err := w2.ImportSnapshot(snap); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w1.stateDigest(snapTick), w2.stateDigest(snapTick); d1 != d2 {
+		t.Fatalf("digest mismatch after base+delta import: %s vs %s", d1, d2)
+	}
+}
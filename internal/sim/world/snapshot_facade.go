@@ -4,14 +4,14 @@ import (
 	"fmt"
 
 	"voxelcraft.ai/internal/persistence/snapshot"
+	signalpkg "voxelcraft.ai/internal/sim/world/feature/automation/signal"
 	snapshotfeaturepkg "voxelcraft.ai/internal/sim/world/feature/persistence/snapshot"
 	"voxelcraft.ai/internal/sim/world/io/snapshotcodec"
 	storepkg "voxelcraft.ai/internal/sim/world/terrain/store"
 )
 
 func (w *World) exportChunkSnapshots() []snapshot.ChunkV1 {
-	keys := w.chunks.LoadedChunkKeys()
-	return storepkg.ExportLoadedChunks(w.chunks.chunks, keys)
+	return w.chunks.exportModifiedChunks()
 }
 
 func (w *World) exportSnapshot(nowTick uint64) snapshot.SnapshotV1 {
@@ -20,7 +20,7 @@ func (w *World) exportSnapshot(nowTick uint64) snapshot.SnapshotV1 {
 
 	return snapshot.SnapshotV1{
 		Header: snapshot.Header{
-			Version: 1,
+			Version: snapshot.CurrentVersion,
 			WorldID: w.cfg.ID,
 			Tick:    nowTick,
 		},
@@ -74,12 +74,20 @@ func (w *World) exportSnapshot(nowTick uint64) snapshot.SnapshotV1 {
 		Claims:                 snapshotfeaturepkg.ExportClaims(w.claims),
 		Containers:             snapshotfeaturepkg.ExportContainers(w.containers),
 		Items:                  snapshotfeaturepkg.ExportItems(nowTick, w.items),
+		Mobs:                   snapshotfeaturepkg.ExportMobs(nowTick, w.mobs),
 		Signs:                  snapshotfeaturepkg.ExportSigns(w.signs),
 		Conveyors:              snapshotfeaturepkg.ExportConveyors(w.conveyors),
 		Switches:               snapshotfeaturepkg.ExportSwitches(w.switches),
+		Crops:                  snapshotfeaturepkg.ExportCrops(w.crops),
+		Water:                  snapshotfeaturepkg.ExportWater(w.waterLevels, w.waterActive),
+		Signals:                snapshotfeaturepkg.ExportSignals(w.signalNodes, w.signalActive),
+		Machines:               snapshotfeaturepkg.ExportMachines(w.machines),
+		Shops:                  snapshotfeaturepkg.ExportShops(w.shops),
 		Trades:                 snapshotfeaturepkg.ExportTrades(w.trades),
 		Boards:                 snapshotfeaturepkg.ExportBoards(w.boards),
 		Contracts:              snapshotfeaturepkg.ExportContracts(w.contracts),
+		Orders:                 snapshotfeaturepkg.ExportOrders(w.orders),
+		Prices:                 snapshotfeaturepkg.ExportPrices(w.prices),
 		Laws:                   snapshotfeaturepkg.ExportLaws(w.laws),
 		Orgs:                   snapshotfeaturepkg.ExportOrgs(w.orgs),
 		Structures:             snapshotfeaturepkg.ExportStructures(w.structures),
@@ -94,6 +102,8 @@ func (w *World) exportSnapshot(nowTick uint64) snapshot.SnapshotV1 {
 			NextLaw:      w.nextLawNum.Load(),
 			NextOrg:      w.nextOrgNum.Load(),
 			NextItem:     w.nextItemNum.Load(),
+			NextMob:      w.nextMobNum.Load(),
+			NextOrder:    w.nextOrderNum.Load(),
 		},
 	}
 }
@@ -138,12 +148,22 @@ func (w *World) importSnapshotV1(s snapshot.SnapshotV1) error {
 	w.itemsAt = itemsAt
 	w.nextItemNum.Store(snapshotfeaturepkg.MaxU64(maxItem, s.Counters.NextItem))
 
+	mobs, maxMob := snapshotfeaturepkg.ImportMobs(s)
+	w.mobs = mobs
+	w.nextMobNum.Store(snapshotfeaturepkg.MaxU64(maxMob, s.Counters.NextMob))
+
 	blockNameAt := func(pos Vec3i) string {
 		return w.blockName(w.chunks.GetBlock(pos))
 	}
 	w.signs = snapshotfeaturepkg.ImportSigns(s, blockNameAt)
 	w.conveyors = snapshotfeaturepkg.ImportConveyors(s, blockNameAt)
 	w.switches = snapshotfeaturepkg.ImportSwitches(s, blockNameAt)
+	w.crops = snapshotfeaturepkg.ImportCrops(s, blockNameAt)
+	w.waterLevels, w.waterActive = snapshotfeaturepkg.ImportWater(s, blockNameAt)
+	w.signalNodes, w.signalActive = snapshotfeaturepkg.ImportSignals(s, blockNameAt)
+	w.signalPolled = signalpkg.PolledPositions(w.signalNodes, blockNameAt)
+	w.machines = snapshotfeaturepkg.ImportMachines(s, blockNameAt)
+	w.shops = snapshotfeaturepkg.ImportShops(s, blockNameAt)
 
 	trades, maxTrade := snapshotfeaturepkg.ImportTrades(s)
 	w.trades = trades
@@ -157,6 +177,11 @@ func (w *World) importSnapshotV1(s snapshot.SnapshotV1) error {
 	w.contracts = contracts
 	w.nextContractNum.Store(snapshotfeaturepkg.MaxU64(maxContract, s.Counters.NextContract))
 
+	orders, maxOrder := snapshotfeaturepkg.ImportOrders(s)
+	w.orders = orders
+	w.nextOrderNum.Store(snapshotfeaturepkg.MaxU64(maxOrder, s.Counters.NextOrder))
+	w.prices = snapshotfeaturepkg.ImportPrices(s)
+
 	laws, maxLaw := snapshotfeaturepkg.ImportLaws(s)
 	w.laws = laws
 	w.nextLawNum.Store(snapshotfeaturepkg.MaxU64(maxLaw, s.Counters.NextLaw))
@@ -178,16 +203,11 @@ func (w *World) importChunkSnapshots(gen WorldGen, chunks []snapshot.ChunkV1) er
 	if err != nil {
 		return err
 	}
-	w.chunks = &ChunkStore{
-		inner:  inner,
-		gen:    inner.Gen,
-		chunks: inner.Chunks,
-	}
-	return nil
+	return w.resetChunks(inner)
 }
 
 func (w *World) validateSnapshotImport(s snapshot.SnapshotV1) error {
-	if s.Header.Version != 1 {
+	if !snapshot.SupportedVersion(s.Header.Version) {
 		return fmt.Errorf("unsupported snapshot version: %d", s.Header.Version)
 	}
 	if w.cfg.Seed != s.Seed {
@@ -196,8 +216,8 @@ func (w *World) validateSnapshotImport(s snapshot.SnapshotV1) error {
 	if w.cfg.Height != s.Height {
 		return fmt.Errorf("snapshot height mismatch: cfg=%d snap=%d", w.cfg.Height, s.Height)
 	}
-	if s.Height != 1 {
-		return fmt.Errorf("unsupported snapshot height for 2D world: height=%d", s.Height)
+	if s.Height < 1 || s.Height > MaxWorldHeight {
+		return fmt.Errorf("unsupported snapshot height: height=%d", s.Height)
 	}
 	if w.cfg.DayTicks != s.DayTicks {
 		return fmt.Errorf("snapshot day_ticks mismatch: cfg=%d snap=%d", w.cfg.DayTicks, s.DayTicks)
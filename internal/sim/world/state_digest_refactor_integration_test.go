@@ -99,3 +99,57 @@ func TestStateDigestRefactorParity_SnapshotAndTicks(t *testing.T) {
 		}
 	}
 }
+
+type tickLogRecorder struct{ entries []TickLogEntry }
+
+func (r *tickLogRecorder) WriteTick(e TickLogEntry) error {
+	r.entries = append(r.entries, e)
+	return nil
+}
+
+func TestTickLogSectionsOnlyChangedBetweenSnapshots(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	w, err := New(WorldConfig{
+		ID:                 "digest-sections",
+		TickRateHz:         5,
+		DayTicks:           6000,
+		ObsRadius:          7,
+		Height:             1,
+		Seed:               42,
+		BoundaryR:          4000,
+		SnapshotEveryTicks: 3,
+	}, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	log := &tickLogRecorder{}
+	w.SetTickLogger(log)
+
+	out := make(chan []byte, 1)
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "alpha", Out: out, Resp: resp})
+	a := w.agents[(<-resp).Welcome.AgentID]
+
+	w.step(nil, nil, nil) // tick 0: first logged tick
+	w.step(nil, nil, nil) // tick 1: agents unchanged
+	a.Inventory["COAL"]++
+	w.step(nil, nil, nil) // tick 2: agents changed
+	w.step(nil, nil, nil) // tick 3: snapshot tick
+
+	all := len(w.StateDigestSections(3))
+	if n := len(log.entries[0].Sections); n != all {
+		t.Fatalf("tick 0 sections=%d want all %d", n, all)
+	}
+	if s := log.entries[1].Sections; len(s) >= all || s["header"] == "" || s["agents"] != "" || s["claims"] != "" {
+		t.Fatalf("tick 1 sections=%v", s)
+	}
+	if s := log.entries[2].Sections; len(s) >= all || s["agents"] == "" || s["claims"] != "" {
+		t.Fatalf("tick 2 sections=%v", s)
+	}
+	if n := len(log.entries[3].Sections); n != all {
+		t.Fatalf("tick 3 sections=%d want all %d", n, all)
+	}
+}
@@ -26,12 +26,14 @@ func (w *World) systemEnvironment(nowTick uint64) {
 			CleanupExpired: w.cleanupExpiredItemEntities,
 		},
 	)
+	w.tickCrops(nowTick)
 }
 
 func (w *World) respawnAgent(nowTick uint64, a *Agent, reason string) {
 	respawnpkg.Apply(nowTick, a, reason, respawnpkg.Hooks{
 		SpawnItem:    w.spawnItemEntity,
 		FindSpawnAir: w.findSpawnAir,
+		SurfaceY:     w.spawnY,
 	})
 }
 
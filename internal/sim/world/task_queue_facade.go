@@ -0,0 +1,34 @@
+package world
+
+import (
+	"voxelcraft.ai/internal/protocol"
+	taskqueuepkg "voxelcraft.ai/internal/sim/world/feature/work/queue"
+)
+
+func (w *World) taskQueueEnv() taskqueuepkg.Env {
+	return taskqueuepkg.Env{
+		Start: func(a *Agent, req protocol.TaskReq, nowTick uint64) {
+			if h := taskReqDispatch[req.Type]; h != nil {
+				h(w, a, req, nowTick)
+			}
+		},
+		Enqueueable: func(typ string) bool {
+			return typ != TaskTypeStop && typ != TaskTypeClearQueue && taskReqDispatch[typ] != nil
+		},
+	}
+}
+
+// systemTaskQueues starts the next queued task for agents whose queued task ended.
+func (w *World) systemTaskQueues(nowTick uint64) {
+	env := w.taskQueueEnv()
+	for _, a := range w.sortedAgents() {
+		if len(a.TaskQueue) > 0 {
+			taskqueuepkg.Pump(env, a, nowTick)
+		}
+	}
+}
+
+func handleTaskClearQueue(_ *World, a *Agent, tr protocol.TaskReq, nowTick uint64) {
+	n := taskqueuepkg.Clear(a)
+	a.AddEvent(protocol.Event{"t": nowTick, "type": "ACTION_RESULT", "ref": tr.ID, "ok": true, "cleared": n})
+}
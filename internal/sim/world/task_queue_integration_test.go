@@ -0,0 +1,62 @@
+package world
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestTaskQueue_RunsPlanAndSurvivesSnapshot(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "queue", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "planner", Resp: resp})
+	a := w.agents[(<-resp).Welcome.AgentID]
+	a.Inventory["PLANK"] = 6
+	a.Inventory["STICK"] = 0
+
+	craft := func(id string) protocol.TaskReq {
+		return protocol.TaskReq{ID: id, Type: "CRAFT", RecipeID: "stick_from_plank", Count: 1, Queue: "ENQUEUE"}
+	}
+	act := protocol.ActMsg{Type: protocol.TypeAct, ProtocolVersion: protocol.Version, Tick: w.CurrentTick(), AgentID: a.ID,
+		Tasks: []protocol.TaskReq{craft("c1"), craft("c2"), craft("c3")}}
+	w.step(nil, nil, []ActionEnvelope{{AgentID: a.ID, Act: act}})
+	if len(a.TaskQueue) != 3 || a.WorkTask == nil || a.TaskQueue[0].TaskID != a.WorkTask.TaskID {
+		t.Fatalf("expected c1 running with c2,c3 queued, got %+v", a.TaskQueue)
+	}
+	tasksObs := w.buildObsTasks(a, w.CurrentTick())
+	if len(tasksObs) != 3 || tasksObs[0].Ref != "c1" || tasksObs[1].Status != "QUEUED" || tasksObs[2].TaskID != "c3" {
+		t.Fatalf("unexpected OBS tasks: %+v", tasksObs)
+	}
+
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	tick := w.CurrentTick() - 1
+	if err := w2.ImportSnapshot(w.ExportSnapshot(tick)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+	b := w2.agents[a.ID]
+	if len(b.TaskQueue) != 3 || b.TaskQueue[1].Req.RecipeID != "stick_from_plank" || b.TaskQueue[0].TaskID != a.TaskQueue[0].TaskID {
+		t.Fatalf("queue not restored: %+v", b.TaskQueue)
+	}
+
+	for i := 0; i < 30 && len(b.TaskQueue) > 0; i++ {
+		w2.step(nil, nil, nil)
+	}
+	if len(b.TaskQueue) != 0 || b.Inventory["STICK"] != 12 {
+		t.Fatalf("expected plan done with 12 sticks, queue=%+v sticks=%d", b.TaskQueue, b.Inventory["STICK"])
+	}
+}
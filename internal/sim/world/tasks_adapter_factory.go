@@ -4,6 +4,7 @@ import (
 	"voxelcraft.ai/internal/sim/catalogs"
 	conveyruntimepkg "voxelcraft.ai/internal/sim/world/feature/conveyor/runtime"
 	claimrequestctxpkg "voxelcraft.ai/internal/sim/world/featurectx/claimrequest"
+	combatctxpkg "voxelcraft.ai/internal/sim/world/featurectx/combat"
 	movementctxpkg "voxelcraft.ai/internal/sim/world/featurectx/movement"
 	workexecctxpkg "voxelcraft.ai/internal/sim/world/featurectx/workexec"
 	workrequestctxpkg "voxelcraft.ai/internal/sim/world/featurectx/workrequest"
@@ -28,6 +29,7 @@ func newWorkTaskReqEnv(w *World) workrequestctxpkg.Env {
 			_, ok := w.catalogs.Blueprints.ByID[blueprintID]
 			return ok
 		},
+		WorldHeightFn: func() int { return w.cfg.Height },
 	}
 }
 
@@ -43,6 +45,8 @@ func newWorkTaskExecEnv(w *World) workexecctxpkg.Env {
 		GetSignFn:                      func(pos Vec3i) *Sign { return w.signs[pos] },
 		SignIDAtFn:                     signIDAt,
 		ContractSummariesForTerminalFn: w.contractSummariesForTerminal,
+		MarketOrdersForTerminalFn:      w.marketOrdersForTerminal,
+		ShopAtFn:                       func(pos Vec3i) *Shop { return w.shops[pos] },
 		OnContainerOpenedDuringEventFn: w.onContainerOpenedDuringEvent,
 		CanWithdrawFromContainerFn:     w.canWithdrawFromContainer,
 		AuditTransferFn: func(nowTick uint64, actorID string, at Vec3i, srcID string, dstID string, item string, count int) {
@@ -92,27 +96,22 @@ func newWorkTaskExecEnv(w *World) workexecctxpkg.Env {
 			dx, dz := conveyruntimepkg.YawToDir(yaw)
 			w.ensureConveyor(pos, dx, dz)
 		},
-		CanBreakAtFn:     w.canBreakAt,
-		PermissionsForFn: w.permissionsFor,
-		IsLandMemberFn:   w.isLandMember,
-		TransferToLandOwnerFn: func(ownerID string, item string, count int) {
-			if ownerID == "" || item == "" || count <= 0 {
-				return
-			}
-			if owner := w.agents[ownerID]; owner != nil {
-				owner.Inventory[item] += count
-				return
-			}
-			if org := w.orgByID(ownerID); org != nil {
-				w.orgTreasury(org)[item] += count
-			}
+		SetSignalFacingFromYawFn: func(pos Vec3i, yaw int) {
+			w.setSignalFacing(pos, yaw)
 		},
+		SetMachinePlacerFn: func(pos Vec3i, agentID string, yaw int) {
+			w.setMachinePlacer(pos, agentID, yaw)
+		},
+		SetShopOwnerFn:  w.openShop,
+		CanBreakAtFn:    w.canBreakAt,
+		EnforceLawFn:    w.enforceLaw,
 		BlockNameFn:     w.blockName,
 		BlockIDToItemFn: w.blockIDToItem,
 		SpawnItemEntityFn: func(nowTick uint64, actor string, pos Vec3i, item string, count int, reason string) string {
 			return w.spawnItemEntity(nowTick, actor, pos, item, count, reason)
 		},
 		GetContainerAtFn: func(pos Vec3i) *Container { return w.containers[pos] },
+		ContainerInUseFn: w.terminalHasActiveLoan,
 		RemoveContainerFn: func(pos Vec3i) {
 			_ = w.removeContainer(pos)
 		},
@@ -120,10 +119,13 @@ func newWorkTaskExecEnv(w *World) workexecctxpkg.Env {
 		RemoveSignFn:          w.removeSign,
 		RemoveConveyorFn:      w.removeConveyor,
 		RemoveSwitchFn:        w.removeSwitch,
+		ClaimPledgedAtFn:      w.claimPledgedAt,
 		RemoveClaimByAnchorFn: w.removeClaimByAnchor,
 		OnMinedBlockDuringEventFn: func(a *Agent, pos Vec3i, blockName string, nowTick uint64) {
 			w.onMinedBlockDuringEvent(a, pos, blockName, nowTick)
 		},
+		PlantCropFn: w.plantCrop,
+		TakeCropFn:  w.takeCrop,
 	}
 }
 
@@ -163,26 +165,16 @@ func newMovementTaskEnv(w *World) movementctxpkg.Env {
 		InBoundsFn:        w.chunks.inBounds,
 		FollowTargetPosFn: w.followTargetPos,
 		SortedAgentsFn:    w.sortedAgents,
-		SurfaceYFn:        w.surfaceY,
 		BlockSolidAtFn: func(pos modelpkg.Vec3i) bool {
 			return w.blockSolid(w.chunks.GetBlock(pos))
 		},
-		LandAtFn:           w.landAt,
-		LandCoreContainsFn: w.landCoreContains,
-		IsLandMemberFn:     w.isLandMember,
-		OrgByIDFn:          w.orgByID,
-		TransferAccessTicketFn: func(ownerID string, item string, count int) {
-			if ownerID == "" || item == "" || count <= 0 {
-				return
-			}
-			if owner := w.agents[ownerID]; owner != nil {
-				owner.Inventory[item] += count
-				return
-			}
-			if org := w.orgByID(ownerID); org != nil {
-				w.orgTreasury(org)[item] += count
-			}
-		},
+		LandAtFn:               w.landAt,
+		LandCoreContainsFn:     w.landCoreContains,
+		IsLandMemberFn:         w.isLandMember,
+		OrgByIDFn:              w.orgByID,
+		TransferAccessTicketFn: w.transferToLandOwner,
+		LawDecisionFn:          w.lawDecision,
+		EnforceLawFn:           w.enforceLaw,
 		RecordDeniedFn: func(nowTick uint64) {
 			if w.stats != nil {
 				w.stats.RecordDenied(nowTick)
@@ -193,6 +185,36 @@ func newMovementTaskEnv(w *World) movementctxpkg.Env {
 	}
 }
 
+func newCombatTaskEnv(w *World) combatctxpkg.Env {
+	if w == nil {
+		return combatctxpkg.Env{}
+	}
+	return combatctxpkg.Env{
+		NewTaskIDFn: w.newTaskID,
+		TargetExistsFn: func(targetID string) bool {
+			return w.agents[targetID] != nil || w.mobs[targetID] != nil
+		},
+		GetAgentFn: func(agentID string) *Agent {
+			return w.agents[agentID]
+		},
+		CanDamageAtFn: w.canDamageAt,
+		EnforceLawFn:  w.enforceLaw,
+		BumpRepLawFn:  w.bumpRepLaw,
+		RecordDeniedFn: func(nowTick uint64) {
+			if w.stats != nil {
+				w.stats.RecordDenied(nowTick)
+			}
+		},
+		RespawnFn:    w.respawnAgent,
+		AuditEventFn: w.auditEvent,
+		GetMobFn: func(mobID string) *Mob {
+			return w.mobs[mobID]
+		},
+		MobHurtFn: w.mobHurt,
+		KillMobFn: w.killMob,
+	}
+}
+
 func claimTotemBlockID(index map[string]uint16) func() (uint16, bool) {
 	return func() (uint16, bool) {
 		id, ok := index["CLAIM_TOTEM"]
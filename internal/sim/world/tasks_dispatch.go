@@ -20,4 +20,8 @@ var taskReqDispatch = map[string]taskReqHandler{
 	string(tasks.KindSmelt):          handleTaskSmelt,
 	TaskTypeClaimLand:                handleTaskClaimLand,
 	string(tasks.KindBuildBlueprint): handleTaskBuildBlueprint,
+	string(tasks.KindAttack):         handleTaskAttack,
+	string(tasks.KindTill):           handleTaskTill,
+	string(tasks.KindBuy):            handleTaskBuy,
+	TaskTypeClearQueue:               handleTaskClearQueue,
 }
@@ -0,0 +1,63 @@
+package gen
+
+// Integer value noise for 3D worlds. Everything stays in integer math so terrain is identical
+// across platforms (no float rounding in the sim).
+
+// ValueNoise2 returns smooth noise in [0,1000] with lattice spacing grid.
+func ValueNoise2(seed int64, x, z, grid int) int {
+	if grid <= 1 {
+		return int(Hash2(seed, x, z) % 1001)
+	}
+	gx, gz := FloorDiv(x, grid), FloorDiv(z, grid)
+	fx, fz := Mod(x, grid), Mod(z, grid)
+	v := func(dx, dz int) int { return int(Hash2(seed, gx+dx, gz+dz) % 1001) }
+	top := lerp(v(0, 0), v(1, 0), fx, grid)
+	bot := lerp(v(0, 1), v(1, 1), fx, grid)
+	return lerp(top, bot, fz, grid)
+}
+
+// ValueNoise3 is the trilinear counterpart of ValueNoise2.
+func ValueNoise3(seed int64, x, y, z, grid int) int {
+	if grid <= 1 {
+		return int(Hash3(seed, x, y, z) % 1001)
+	}
+	gx, gy, gz := FloorDiv(x, grid), FloorDiv(y, grid), FloorDiv(z, grid)
+	fx, fy, fz := Mod(x, grid), Mod(y, grid), Mod(z, grid)
+	v := func(dx, dy, dz int) int { return int(Hash3(seed, gx+dx, gy+dy, gz+dz) % 1001) }
+	layer := func(dy int) int {
+		top := lerp(v(0, dy, 0), v(1, dy, 0), fx, grid)
+		bot := lerp(v(0, dy, 1), v(1, dy, 1), fx, grid)
+		return lerp(top, bot, fz, grid)
+	}
+	return lerp(layer(0), layer(1), fy, grid)
+}
+
+func lerp(a, b, t, n int) int {
+	return a + (b-a)*t/n
+}
+
+// Elevation is the number of ground blocks in column (x,z) of a world with the given height:
+// y in [0,e) is ground and agents stand at y=e. It stays within [1, height-1].
+func Elevation(seed int64, x, z, height int) int {
+	if height <= 1 {
+		return 0
+	}
+	lo := height / 4
+	if lo < 1 {
+		lo = 1
+	}
+	hi := height * 3 / 4
+	if hi > height-1 {
+		hi = height - 1
+	}
+	if hi < lo {
+		hi = lo
+	}
+	n := (2*ValueNoise2(seed+11, x, z, 48) + ValueNoise2(seed+12, x, z, 12)) / 3
+	return lo + n*(hi-lo)/1000
+}
+
+// IsCave reports whether (x,y,z) is carved out underground.
+func IsCave(seed int64, x, y, z int) bool {
+	return ValueNoise3(seed+21, x, y, z, 8) > 720
+}
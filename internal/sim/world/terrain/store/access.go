@@ -7,7 +7,7 @@ import (
 )
 
 func (s *ChunkStore) InBounds(x, y, z int) bool {
-	if y != 0 {
+	if y < 0 || y >= s.Gen.WorldHeight() {
 		return false
 	}
 	if s.Gen.BoundaryR > 0 {
@@ -23,17 +23,45 @@ func (s *ChunkStore) LoadedChunkKeys() []ChunkKey {
 	for k := range s.Chunks {
 		keys = append(keys, k)
 	}
+	sortKeys(keys)
+	return keys
+}
+
+// ModifiedChunkKeys returns resident and evicted modified chunks, sorted. This set (not residency)
+// defines terrain state for digests and snapshots.
+func (s *ChunkStore) ModifiedChunkKeys() []ChunkKey {
+	keys := make([]ChunkKey, 0, len(s.Evicted))
+	for k, ch := range s.Chunks {
+		if ch != nil && ch.modified {
+			keys = append(keys, k)
+		}
+	}
+	for k := range s.Evicted {
+		keys = append(keys, k)
+	}
+	sortKeys(keys)
+	return keys
+}
+
+// ChunkDigest returns the digest of a resident or evicted chunk without loading it.
+func (s *ChunkStore) ChunkDigest(k ChunkKey) [32]byte {
+	if ch := s.Chunks[k]; ch != nil {
+		return ch.Digest()
+	}
+	return s.Evicted[k]
+}
+
+func sortKeys(keys []ChunkKey) {
 	sort.Slice(keys, func(i, j int) bool {
 		if keys[i].CX != keys[j].CX {
 			return keys[i].CX < keys[j].CX
 		}
 		return keys[i].CZ < keys[j].CZ
 	})
-	return keys
 }
 
 func (s *ChunkStore) GetBlock(x, y, z int) uint16 {
-	if y != 0 || !s.InBounds(x, y, z) {
+	if !s.InBounds(x, y, z) {
 		return s.Gen.Air
 	}
 
@@ -42,11 +70,11 @@ func (s *ChunkStore) GetBlock(x, y, z int) uint16 {
 	lx := genpkg.Mod(x, 16)
 	lz := genpkg.Mod(z, 16)
 	ch := s.GetOrGenChunk(cx, cz)
-	return ch.Get(lx, lz)
+	return ch.Get(lx, y, lz)
 }
 
 func (s *ChunkStore) SetBlock(x, y, z int, b uint16) {
-	if y != 0 || !s.InBounds(x, y, z) {
+	if !s.InBounds(x, y, z) {
 		return
 	}
 
@@ -55,22 +83,55 @@ func (s *ChunkStore) SetBlock(x, y, z int, b uint16) {
 	lx := genpkg.Mod(x, 16)
 	lz := genpkg.Mod(z, 16)
 	ch := s.GetOrGenChunk(cx, cz)
-	ch.Set(lx, lz, b)
+	ch.Set(lx, y, lz, b)
 }
 
 func (s *ChunkStore) GetOrGenChunk(cx, cz int) *Chunk {
 	k := ChunkKey{CX: cx, CZ: cz}
 	if ch, ok := s.Chunks[k]; ok {
+		ch.touched = s.now
 		return ch
 	}
+	if _, ok := s.Evicted[k]; ok {
+		s.loadEvicted([]ChunkKey{k})
+		if ch, ok := s.Chunks[k]; ok {
+			ch.touched = s.now
+			return ch
+		}
+		// Unreadable: the store has failed (Err), so hand out a throwaway generated chunk
+		// without making it resident; the tick is not committed.
+		tmp := &Chunk{CX: cx, CZ: cz, Blocks: make([]uint16, s.Gen.ChunkLen())}
+		s.GenerateChunk(tmp)
+		return tmp
+	}
 	ch := &Chunk{
 		CX:     cx,
 		CZ:     cz,
-		Blocks: make([]uint16, 16*16),
+		Blocks: make([]uint16, s.Gen.ChunkLen()),
 	}
 	s.GenerateChunk(ch)
 	ch.dirty = true
+	ch.touched = s.now
 	_ = ch.Digest()
 	s.Chunks[k] = ch
 	return ch
 }
+
+// SurfaceY returns where an agent stands in column (x,z): the lowest non-solid cell above the
+// topmost solid block (0 for an empty column, and always 0 in 2D worlds). ok=false means the
+// column is solid up to the top, so it has no surface.
+func (s *ChunkStore) SurfaceY(x, z int, solid func(uint16) bool) (y int, ok bool) {
+	h := s.Gen.WorldHeight()
+	if h <= 1 {
+		return 0, true
+	}
+	for y := h - 1; y >= 0; y-- {
+		if solid(s.GetBlock(x, y, z)) {
+			if y+1 >= h {
+				return 0, false
+			}
+			return y + 1, true
+		}
+	}
+	return 0, true
+}
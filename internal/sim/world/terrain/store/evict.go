@@ -0,0 +1,170 @@
+package store
+
+import (
+	"fmt"
+
+	snapv1 "voxelcraft.ai/internal/persistence/snapshot"
+)
+
+// Maintain runs at a tick boundary: it brings evicted chunks in hot back into memory, marks hot
+// chunks as touched, then evicts resident chunks idle for at least idleTicks (0 disables eviction).
+//
+// Unmodified chunks are dropped and regenerated on demand. Modified chunks are evicted only when
+// a region store is attached; without one they stay resident. Residency never feeds the digest,
+// so this does not affect determinism.
+func (s *ChunkStore) Maintain(nowTick, idleTicks uint64, hot []ChunkKey) {
+	s.now = nowTick
+
+	var want []ChunkKey
+	for _, k := range hot {
+		if _, ok := s.Evicted[k]; ok {
+			want = append(want, k)
+		}
+	}
+	s.loadEvicted(want)
+	isHot := make(map[ChunkKey]bool, len(hot))
+	for _, k := range hot {
+		isHot[k] = true
+		if ch := s.Chunks[k]; ch != nil {
+			ch.touched = nowTick
+		}
+	}
+
+	if idleTicks == 0 {
+		return
+	}
+	out := map[ChunkKey][]uint16{}
+	for _, k := range s.LoadedChunkKeys() {
+		ch := s.Chunks[k]
+		if isHot[k] || ch == nil || nowTick < ch.touched+idleTicks {
+			continue
+		}
+		if !ch.modified {
+			delete(s.Chunks, k)
+			s.Stats.Evictions++
+			continue
+		}
+		if s.Regions != nil {
+			out[k] = ch.Blocks
+		}
+	}
+	if len(out) == 0 {
+		return
+	}
+	if err := s.Regions.Save(out); err != nil {
+		// Keep them resident; eviction is retried on the next pass.
+		s.noteError(err)
+		return
+	}
+	for k := range out {
+		s.Evicted[k] = s.Chunks[k].Digest()
+		delete(s.Chunks, k)
+		s.Stats.Evictions++
+	}
+}
+
+// loadEvicted makes evicted chunks resident again. If their blocks cannot be read, the chunks stay
+// evicted and the store fails (see Err): regenerating them would silently replace modified terrain.
+func (s *ChunkStore) loadEvicted(keys []ChunkKey) {
+	if len(keys) == 0 {
+		return
+	}
+	blocks, err := s.evictedBlocks(keys)
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	for _, k := range keys {
+		b, ok := blocks[k]
+		if !ok || len(b) != s.Gen.ChunkLen() {
+			s.fail(fmt.Errorf("evicted chunk %d,%d not readable from region store", k.CX, k.CZ))
+			continue
+		}
+		ch := &Chunk{CX: k.CX, CZ: k.CZ, Blocks: b, modified: true, touched: s.now}
+		ch.dirty = true
+		_ = ch.Digest()
+		s.Chunks[k] = ch
+		delete(s.Evicted, k)
+		s.Stats.Loads++
+	}
+}
+
+func (s *ChunkStore) evictedBlocks(keys []ChunkKey) (map[ChunkKey][]uint16, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	if s.Regions == nil {
+		return nil, fmt.Errorf("%d evicted chunks but no region store", len(keys))
+	}
+	return s.Regions.Load(keys)
+}
+
+func (s *ChunkStore) noteError(err error) {
+	s.Stats.RegionError++
+	s.Stats.LastError = err.Error()
+}
+
+// fail records an unrecoverable read of evicted terrain. The first error sticks.
+func (s *ChunkStore) fail(err error) {
+	s.noteError(err)
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// Err reports the first evicted chunk that could not be read back. Once set, terrain is
+// incomplete and the world must stop instead of committing further ticks.
+func (s *ChunkStore) Err() error { return s.err }
+
+// AttachRegions uses r (which should be empty) for evicted modified chunks from now on.
+func (s *ChunkStore) AttachRegions(r *RegionStore) { s.Regions = r }
+
+// PeekChunk returns a chunk for read-only viewers without touching or loading it into the resident
+// set. Evicted chunks are read from their spill area; everything else is generated ephemerally.
+func (s *ChunkStore) PeekChunk(cx, cz int) *Chunk {
+	k := ChunkKey{CX: cx, CZ: cz}
+	if ch := s.Chunks[k]; ch != nil {
+		return ch
+	}
+	tmp := &Chunk{CX: cx, CZ: cz}
+	if _, ok := s.Evicted[k]; ok {
+		if blocks, err := s.evictedBlocks([]ChunkKey{k}); err == nil && len(blocks[k]) == s.Gen.ChunkLen() {
+			tmp.Blocks = blocks[k]
+			return tmp
+		}
+	}
+	tmp.Blocks = make([]uint16, s.Gen.ChunkLen())
+	s.GenerateChunk(tmp)
+	return tmp
+}
+
+// ExportModifiedChunks returns every modified chunk (resident or evicted) as snapshot chunks.
+// If the spill area cannot be read, the store fails and the unreadable chunks are left out, so
+// callers must check Err before using the result.
+func (s *ChunkStore) ExportModifiedChunks() []snapv1.ChunkV1 {
+	keys := s.ModifiedChunkKeys()
+	var evicted []ChunkKey
+	for _, k := range keys {
+		if s.Chunks[k] == nil {
+			evicted = append(evicted, k)
+		}
+	}
+	blocks, err := s.evictedBlocks(evicted)
+	if err != nil {
+		s.fail(err)
+	}
+	out := make([]snapv1.ChunkV1, 0, len(keys))
+	for _, k := range keys {
+		var src []uint16
+		if ch := s.Chunks[k]; ch != nil {
+			src = ch.Blocks
+		} else if src = blocks[k]; len(src) != s.Gen.ChunkLen() {
+			s.fail(fmt.Errorf("evicted chunk %d,%d not readable from region store", k.CX, k.CZ))
+			continue
+		}
+		b := make([]uint16, len(src))
+		copy(b, src)
+		out = append(out, snapv1.ChunkV1{CX: k.CX, CZ: k.CZ, Height: s.Gen.WorldHeight(), Blocks: b})
+	}
+	return out
+}
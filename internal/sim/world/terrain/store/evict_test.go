@@ -0,0 +1,111 @@
+package store
+
+import "testing"
+
+func TestMaintainEvictsAndReloadsModifiedChunks(t *testing.T) {
+	r, err := OpenRegionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("open regions: %v", err)
+	}
+	s := NewChunkStore(WorldGen{Seed: 3, Air: 0, Stone: 1})
+	s.Regions = r
+
+	s.SetBlock(40, 0, -70, 9) // chunk (2,-5), modified
+	_ = s.GetBlock(500, 0, 500)
+	want := s.ChunkDigest(ChunkKey{CX: 2, CZ: -5})
+
+	s.Maintain(100, 50, nil)
+	if len(s.Chunks) != 0 {
+		t.Fatalf("expected no resident chunks, got %d", len(s.Chunks))
+	}
+	if keys := s.ModifiedChunkKeys(); len(keys) != 1 || keys[0] != (ChunkKey{CX: 2, CZ: -5}) {
+		t.Fatalf("modified keys after eviction: %v", keys)
+	}
+	if got := s.ChunkDigest(ChunkKey{CX: 2, CZ: -5}); got != want {
+		t.Fatalf("evicted digest changed")
+	}
+	if got := s.PeekChunk(2, -5).Get(8, 0, 10); got != 9 {
+		t.Fatalf("peek evicted block: got %d", got)
+	}
+	if len(s.Chunks) != 0 {
+		t.Fatalf("peek must not load chunks")
+	}
+
+	if got := s.GetBlock(40, 0, -70); got != 9 {
+		t.Fatalf("reloaded block: got %d want 9", got)
+	}
+	if len(s.Evicted) != 0 || s.Stats.Loads != 1 || s.Stats.RegionError != 0 {
+		t.Fatalf("unexpected state after reload: evicted=%d stats=%+v", len(s.Evicted), s.Stats)
+	}
+	if got := s.ChunkDigest(ChunkKey{CX: 2, CZ: -5}); got != want {
+		t.Fatalf("reloaded digest changed")
+	}
+}
+
+func TestMaintainKeepsHotChunks(t *testing.T) {
+	s := NewChunkStore(WorldGen{Seed: 3})
+	s.SetBlock(1, 0, 1, 4)
+	_ = s.GetBlock(500, 0, 500)
+	s.Maintain(1000, 10, []ChunkKey{{CX: 0, CZ: 0}})
+	if s.Chunks[ChunkKey{}] == nil {
+		t.Fatalf("hot chunk evicted")
+	}
+	// Without a region store, idle modified chunks stay resident; unmodified ones are dropped.
+	s.Maintain(2000, 10, nil)
+	if s.Chunks[ChunkKey{}] == nil || len(s.Chunks) != 1 || len(s.Evicted) != 0 {
+		t.Fatalf("resident=%d evicted=%d", len(s.Chunks), len(s.Evicted))
+	}
+	out := s.ExportModifiedChunks()
+	if len(out) != 1 || out[0].Blocks[17] != 4 {
+		t.Fatalf("export of idle chunk: %+v", out)
+	}
+}
+
+func TestUnreadableEvictedChunkFailsStore(t *testing.T) {
+	dir := t.TempDir()
+	r, err := OpenRegionStore(dir)
+	if err != nil {
+		t.Fatalf("open regions: %v", err)
+	}
+	s := NewChunkStore(WorldGen{Seed: 3, Air: 0, Stone: 1})
+	s.Regions = r
+	s.SetBlock(40, 0, -70, 9)
+	s.Maintain(100, 50, nil)
+	if len(s.Evicted) != 1 {
+		t.Fatalf("chunk not evicted")
+	}
+	if err := r.Reset(); err != nil {
+		t.Fatalf("reset regions: %v", err)
+	}
+
+	_ = s.GetBlock(40, 0, -70)
+	if s.Err() == nil || s.Stats.RegionError == 0 {
+		t.Fatalf("read failure not surfaced: %+v", s.Stats)
+	}
+	if len(s.Chunks) != 0 || len(s.Evicted) != 1 {
+		t.Fatalf("unreadable chunk regenerated: resident=%d evicted=%d", len(s.Chunks), len(s.Evicted))
+	}
+	if out := s.ExportModifiedChunks(); len(out) != 0 {
+		t.Fatalf("exported unreadable chunk: %+v", out)
+	}
+}
+
+func TestImportChunksSkipsGeneratedChunks(t *testing.T) {
+	gen := WorldGen{Seed: 5, Air: 0, Stone: 1}
+	s := NewChunkStore(gen)
+	pristine := s.GetOrGenChunk(0, 0)
+	changed := s.GetOrGenChunk(1, 0)
+	changed.Set(0, 0, 0, changed.Get(0, 0, 0)+1)
+
+	in := ExportLoadedChunks(s.Chunks, s.LoadedChunkKeys())
+	imported, err := ImportChunks(gen, in)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if imported.Chunks[ChunkKey{CX: pristine.CX}] != nil {
+		t.Fatalf("generated chunk should be regenerated, not imported")
+	}
+	if keys := imported.ModifiedChunkKeys(); len(keys) != 1 || keys[0] != (ChunkKey{CX: 1}) {
+		t.Fatalf("modified keys: %v", keys)
+	}
+}
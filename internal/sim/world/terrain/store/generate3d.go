@@ -0,0 +1,62 @@
+package store
+
+import genpkg "voxelcraft.ai/internal/sim/world/terrain/gen"
+
+// generateChunk3D builds columns of stone under a soil crust whose top follows Elevation.
+// Caves are carved below the crust, ores get richer with depth, and the 2D tile generator
+// still decides where trees stand on the surface.
+func (s *ChunkStore) generateChunk3D(ch *Chunk, height int) {
+	g := s.Gen
+	for z := 0; z < 16; z++ {
+		for x := 0; x < 16; x++ {
+			wx := ch.CX*16 + x
+			wz := ch.CZ*16 + z
+			e := genpkg.Elevation(g.Seed, wx, wz, height)
+			clear := genpkg.WithinSpawnClear(wx, wz, g.SpawnClearRadius)
+			biome := genpkg.BiomeAt(g.Seed, wx, wz, g.BiomeRegionSize)
+			top, soil := g.Grass, g.Dirt
+			if biome == "DESERT" {
+				top, soil = g.Sand, g.Sand
+			}
+			for y := 0; y < height; y++ {
+				var b uint16
+				switch {
+				case y >= e:
+					b = g.Air
+					if y == e && !clear && s.tileAt(wx, wz) == g.Log {
+						b = g.Log
+					}
+				case y == e-1:
+					b = top
+				case y >= e-3:
+					b = soil
+				case y > 0 && !clear && genpkg.IsCave(g.Seed, wx, y, wz):
+					b = g.Air
+				default:
+					b = s.stoneAt(wx, y, wz, e-1-y, height)
+				}
+				ch.Blocks[x+z*16+y*256] = b
+			}
+		}
+	}
+}
+
+// stoneAt picks stone or ore for an underground block depth blocks below the surface.
+// Veins are 2D clusters stacked over 3-block bands so they have some thickness.
+func (s *ChunkStore) stoneAt(wx, y, wz, depth, height int) uint16 {
+	g := s.Gen
+	band := int64(y / 3)
+	switch {
+	case y < height/4 && genpkg.InCluster(g.Seed+101+band*7, wx, wz, 48, 2, genpkg.ScalePermille(200, g.OreClusterProbScalePermille)):
+		return g.CrystalOre
+	case depth >= 5 && genpkg.InCluster(g.Seed+102+band*7, wx, wz, 32, 2, genpkg.ScalePermille(350, g.OreClusterProbScalePermille)):
+		return g.IronOre
+	case depth >= 5 && genpkg.InCluster(g.Seed+103+band*7, wx, wz, 32, 2, genpkg.ScalePermille(350, g.OreClusterProbScalePermille)):
+		return g.CopperOre
+	case depth >= 2 && genpkg.InCluster(g.Seed+104+band*7, wx, wz, 24, 2, genpkg.ScalePermille(450, g.OreClusterProbScalePermille)):
+		return g.CoalOre
+	case genpkg.Hash3(g.Seed+105, wx, y, wz)%1000 < 40:
+		return g.Gravel
+	}
+	return g.Stone
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"testing"
+
+	genpkg "voxelcraft.ai/internal/sim/world/terrain/gen"
+)
+
+func testGen3D(height int) WorldGen {
+	return WorldGen{
+		Seed: 7, Height: height, BiomeRegionSize: 64, SpawnClearRadius: 6,
+		Air: 0, Dirt: 1, Grass: 2, Sand: 3, Stone: 4, Gravel: 5, Log: 6,
+		CoalOre: 7, IronOre: 8, CopperOre: 9, CrystalOre: 10,
+	}
+}
+
+func TestGenerateChunk3DFollowsElevation(t *testing.T) {
+	const height = 16
+	s := NewChunkStore(testGen3D(height))
+	solid := func(b uint16) bool { return b != 0 }
+	ch := s.GetOrGenChunk(1, -2)
+	if len(ch.Blocks) != 16*16*height {
+		t.Fatalf("chunk len = %d", len(ch.Blocks))
+	}
+	for z := 0; z < 16; z++ {
+		for x := 0; x < 16; x++ {
+			wx, wz := 16+x, -32+z
+			e := genpkg.Elevation(7, wx, wz, height)
+			if e < height/4 || e > height*3/4 {
+				t.Fatalf("elevation %d out of range at %d,%d", e, wx, wz)
+			}
+			if b := ch.Get(x, 0, z); b == 0 {
+				t.Fatalf("bedrock layer must be solid at %d,%d", wx, wz)
+			}
+			if b := ch.Get(x, e-1, z); b != 2 && b != 3 {
+				t.Fatalf("top block at %d,%d,%d = %d, want grass or sand", wx, e-1, wz, b)
+			}
+			sy, ok := s.SurfaceY(wx, wz, solid)
+			if !ok || sy != e && !(sy == e+1 && ch.Get(x, e, z) == 6) {
+				t.Fatalf("surface y at %d,%d = %d, elevation %d", wx, wz, sy, e)
+			}
+		}
+	}
+}
+
+func TestGenerateChunk2DUnchanged(t *testing.T) {
+	s := NewChunkStore(testGen3D(1))
+	ch := s.GetOrGenChunk(0, 0)
+	if len(ch.Blocks) != 256 {
+		t.Fatalf("2D chunk len = %d", len(ch.Blocks))
+	}
+	for z := 0; z < 16; z++ {
+		for x := 0; x < 16; x++ {
+			if got, want := ch.Get(x, 0, z), s.tileAt(x, z); got != want {
+				t.Fatalf("tile %d,%d = %d want %d", x, z, got, want)
+			}
+		}
+	}
+	if y, ok := s.SurfaceY(3, 3, func(uint16) bool { return true }); !ok || y != 0 {
+		t.Fatalf("2D surface y = %d", y)
+	}
+}
+
+func TestSurfaceYSolidColumnHasNoSurface(t *testing.T) {
+	s := NewChunkStore(testGen3D(8))
+	if _, ok := s.SurfaceY(3, 3, func(uint16) bool { return true }); ok {
+		t.Fatalf("fully solid column reported a surface")
+	}
+	if y, ok := s.SurfaceY(3, 3, func(uint16) bool { return false }); !ok || y != 0 {
+		t.Fatalf("empty column surface = %d,%v", y, ok)
+	}
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	genpkg "voxelcraft.ai/internal/sim/world/terrain/gen"
+)
+
+// RegionSize is the edge length, in chunks, of one region file.
+const RegionSize = 32
+
+type regionKey struct {
+	RX int
+	RZ int
+}
+
+func regionOf(k ChunkKey) regionKey {
+	return regionKey{RX: genpkg.FloorDiv(k.CX, RegionSize), RZ: genpkg.FloorDiv(k.CZ, RegionSize)}
+}
+
+type regionFile struct {
+	Chunks map[ChunkKey][]uint16
+}
+
+// RegionStore keeps evicted modified chunks in zstd-compressed region files (r.<rx>.<rz>.region.zst).
+// Region files are a spill area, not a source of truth: the snapshot is authoritative and Reset
+// discards whatever a previous process left behind.
+type RegionStore struct {
+	Dir string
+}
+
+func OpenRegionStore(dir string) (*RegionStore, error) {
+	r := &RegionStore{Dir: dir}
+	if err := r.Reset(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reset removes all region files.
+func (r *RegionStore) Reset() error {
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return err
+	}
+	ents, err := os.ReadDir(r.Dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range ents {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), "r.") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(r.Dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RegionStore) path(rk regionKey) string {
+	return filepath.Join(r.Dir, fmt.Sprintf("r.%d.%d.region.zst", rk.RX, rk.RZ))
+}
+
+// Load reads the requested chunks, opening each region file once.
+func (r *RegionStore) Load(keys []ChunkKey) (map[ChunkKey][]uint16, error) {
+	out := make(map[ChunkKey][]uint16, len(keys))
+	for rk, ks := range groupByRegion(keys) {
+		f, err := r.read(rk)
+		if err != nil {
+			return out, err
+		}
+		for _, k := range ks {
+			b, ok := f.Chunks[k]
+			if !ok {
+				return out, fmt.Errorf("region %d,%d: missing chunk %d,%d", rk.RX, rk.RZ, k.CX, k.CZ)
+			}
+			out[k] = b
+		}
+	}
+	return out, nil
+}
+
+// Save merges chunks into their region files. Each file is replaced atomically.
+func (r *RegionStore) Save(chunks map[ChunkKey][]uint16) error {
+	keys := make([]ChunkKey, 0, len(chunks))
+	for k := range chunks {
+		keys = append(keys, k)
+	}
+	groups := groupByRegion(keys)
+	rks := make([]regionKey, 0, len(groups))
+	for rk := range groups {
+		rks = append(rks, rk)
+	}
+	sort.Slice(rks, func(i, j int) bool {
+		if rks[i].RX != rks[j].RX {
+			return rks[i].RX < rks[j].RX
+		}
+		return rks[i].RZ < rks[j].RZ
+	})
+	for _, rk := range rks {
+		f, err := r.read(rk)
+		if err != nil {
+			return err
+		}
+		for _, k := range groups[rk] {
+			f.Chunks[k] = chunks[k]
+		}
+		if err := r.write(rk, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RegionStore) read(rk regionKey) (regionFile, error) {
+	f := regionFile{Chunks: map[ChunkKey][]uint16{}}
+	raw, err := os.ReadFile(r.path(rk))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return f, nil
+		}
+		return f, err
+	}
+	dec, err := zstd.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return f, err
+	}
+	defer dec.Close()
+	if err := gob.NewDecoder(dec).Decode(&f); err != nil {
+		return f, fmt.Errorf("decode region %d,%d: %w", rk.RX, rk.RZ, err)
+	}
+	if f.Chunks == nil {
+		f.Chunks = map[ChunkKey][]uint16{}
+	}
+	return f, nil
+}
+
+func (r *RegionStore) write(rk regionKey, f regionFile) error {
+	var buf bytes.Buffer
+	enc, err := zstd.NewWriter(&buf)
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(enc).Encode(f); err != nil {
+		_ = enc.Close()
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	path := r.path(rk)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func groupByRegion(keys []ChunkKey) map[regionKey][]ChunkKey {
+	out := map[regionKey][]ChunkKey{}
+	for _, k := range keys {
+		rk := regionOf(k)
+		out[rk] = append(out[rk], k)
+	}
+	return out
+}
@@ -19,33 +19,49 @@ func ExportLoadedChunks(chunks map[ChunkKey]*Chunk, keys []ChunkKey) []snapv1.Ch
 		out = append(out, snapv1.ChunkV1{
 			CX:     k.CX,
 			CZ:     k.CZ,
-			Height: 1,
+			Height: len(blocks) / 256,
 			Blocks: blocks,
 		})
 	}
 	return out
 }
 
-// ImportChunks rebuilds a chunk store from snapshot chunks.
+// ImportChunks rebuilds a chunk store from snapshot chunks. Chunks identical to a fresh WorldGen
+// chunk (older snapshots exported every loaded chunk) are skipped; the rest are marked modified.
 func ImportChunks(gen WorldGen, chunks []snapv1.ChunkV1) (*ChunkStore, error) {
 	store := NewChunkStore(gen)
 	for _, ch := range chunks {
-		if ch.Height != 1 {
-			return nil, fmt.Errorf("snapshot chunk height mismatch: got %d want 1", ch.Height)
+		if ch.Height != gen.WorldHeight() {
+			return nil, fmt.Errorf("snapshot chunk height mismatch: got %d want %d", ch.Height, gen.WorldHeight())
 		}
-		if len(ch.Blocks) != 16*16 {
-			return nil, fmt.Errorf("snapshot chunk blocks length mismatch: got %d want %d", len(ch.Blocks), 16*16)
+		if len(ch.Blocks) != gen.ChunkLen() {
+			return nil, fmt.Errorf("snapshot chunk blocks length mismatch: got %d want %d", len(ch.Blocks), gen.ChunkLen())
 		}
 		k := ChunkKey{CX: ch.CX, CZ: ch.CZ}
 		blocks := make([]uint16, len(ch.Blocks))
 		copy(blocks, ch.Blocks)
 		c := &Chunk{
-			CX:     ch.CX,
-			CZ:     ch.CZ,
-			Blocks: blocks,
+			CX:       ch.CX,
+			CZ:       ch.CZ,
+			Blocks:   blocks,
+			modified: true,
+		}
+		if store.isGenerated(c) {
+			continue
 		}
 		_ = c.Digest()
 		store.Chunks[k] = c
 	}
 	return store, nil
 }
+
+func (s *ChunkStore) isGenerated(c *Chunk) bool {
+	g := &Chunk{CX: c.CX, CZ: c.CZ, Blocks: make([]uint16, s.Gen.ChunkLen())}
+	s.GenerateChunk(g)
+	for i, b := range g.Blocks {
+		if c.Blocks[i] != b {
+			return false
+		}
+	}
+	return true
+}
@@ -12,29 +12,35 @@ type ChunkKey struct {
 
 type Chunk struct {
 	CX, CZ int
-	Blocks []uint16 // len = 16*16 (pure 2D world)
+	Blocks []uint16 // len = 16*16*height, index x + z*16 + y*256 (YZX)
 
-	dirty bool
-	hash  [32]byte
+	dirty    bool
+	hash     [32]byte
+	modified bool   // 被模拟写过（与 WorldGen 结果可能不同）
+	touched  uint64 // 最近一次被模拟访问的 tick
 }
 
-func (c *Chunk) index(x, z int) int {
-	return x + z*16
+func (c *Chunk) index(x, y, z int) int {
+	return x + z*16 + y*256
 }
 
-func (c *Chunk) Get(x, z int) uint16 {
-	return c.Blocks[c.index(x, z)]
+func (c *Chunk) Get(x, y, z int) uint16 {
+	return c.Blocks[c.index(x, y, z)]
 }
 
-func (c *Chunk) Set(x, z int, b uint16) {
-	i := c.index(x, z)
+func (c *Chunk) Set(x, y, z int, b uint16) {
+	i := c.index(x, y, z)
 	if c.Blocks[i] == b {
 		return
 	}
 	c.Blocks[i] = b
 	c.dirty = true
+	c.modified = true
 }
 
+// Modified reports whether the chunk was written since generation; unmodified chunks are never persisted.
+func (c *Chunk) Modified() bool { return c.modified }
+
 func (c *Chunk) Digest() [32]byte {
 	if c.dirty || c.hash == ([32]byte{}) {
 		h := sha256.New()
@@ -52,6 +58,7 @@ func (c *Chunk) Digest() [32]byte {
 type WorldGen struct {
 	Seed      int64
 	BoundaryR int // blocks
+	Height    int // blocks per column; 0 or 1 = 2D tilemap
 
 	BiomeRegionSize                 int
 	SpawnClearRadius                int
@@ -74,14 +81,44 @@ type WorldGen struct {
 	CrystalOre uint16
 }
 
+// WorldHeight is the number of layers per column (at least 1).
+func (g WorldGen) WorldHeight() int {
+	if g.Height < 1 {
+		return 1
+	}
+	return g.Height
+}
+
+// ChunkLen is the number of blocks in one chunk.
+func (g WorldGen) ChunkLen() int {
+	return 16 * 16 * g.WorldHeight()
+}
+
+// ChunkStore holds resident chunks. Modified chunks that were evicted keep only their digest
+// in Evicted; their blocks live in Regions (only evicted when a region store is attached).
 type ChunkStore struct {
 	Gen    WorldGen
 	Chunks map[ChunkKey]*Chunk
+
+	Evicted map[ChunkKey][32]byte
+	Regions *RegionStore
+	Stats   StoreStats
+
+	err error // first evicted chunk that could not be read back
+	now uint64
+}
+
+type StoreStats struct {
+	Evictions   uint64
+	Loads       uint64 // evicted chunks brought back (prefetch or on-demand)
+	RegionError uint64
+	LastError   string
 }
 
 func NewChunkStore(gen WorldGen) *ChunkStore {
 	return &ChunkStore{
-		Gen:    gen,
-		Chunks: map[ChunkKey]*Chunk{},
+		Gen:     gen,
+		Chunks:  map[ChunkKey]*Chunk{},
+		Evicted: map[ChunkKey][32]byte{},
 	}
 }
@@ -4,11 +4,22 @@ import (
 	"voxelcraft.ai/internal/sim/world/logic/mathx"
 )
 
-func (w *World) surfaceY(x, z int) int {
-	// Pure 2D world: the only valid y coordinate is 0.
-	_ = x
-	_ = z
-	return 0
+// surfaceY is the y an agent stands at in column (x,z); always 0 in 2D worlds. ok=false means
+// the column is solid up to the top.
+func (w *World) surfaceY(x, z int) (int, bool) {
+	if w == nil || w.chunks == nil || w.chunks.inner == nil {
+		return 0, true
+	}
+	return w.chunks.inner.SurfaceY(x, z, w.blockSolid)
+}
+
+// spawnY is the y an agent or entity is placed at in column (x,z): its surface, or the top cell
+// when the column has none (findSpawnAir skips such columns, so this is a last resort).
+func (w *World) spawnY(x, z int) int {
+	if y, ok := w.surfaceY(x, z); ok {
+		return y
+	}
+	return w.cfg.Height - 1
 }
 
 func (w *World) findSpawnAir(x, z int, maxR int) (int, int) {
@@ -28,8 +39,9 @@ func (w *World) findSpawnAir(x, z int, maxR int) (int, int) {
 				}
 				px := x + dx
 				pz := z + dz
-				p := Vec3i{X: px, Y: 0, Z: pz}
-				if !w.chunks.inBounds(p) {
+				y, ok := w.surfaceY(px, pz)
+				p := Vec3i{X: px, Y: y, Z: pz}
+				if !ok || !w.chunks.inBounds(p) {
 					continue
 				}
 				if w.chunks.GetBlock(p) == air {
@@ -78,6 +90,10 @@ func (w *World) blockIDToItem(b uint16) string {
 		return "COPPER_ORE"
 	case "CRYSTAL_ORE":
 		return "CRYSTAL_SHARD"
+	case "FARMLAND":
+		return "DIRT"
+	case "DOOR_OPEN":
+		return "DOOR"
 	}
 	return ""
 }
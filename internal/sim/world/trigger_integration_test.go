@@ -0,0 +1,77 @@
+package world
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestTriggers_AutoAcceptTradeAndSurviveSnapshot(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "triggers", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	join := func(name string) *Agent {
+		resp := make(chan JoinResponse, 1)
+		w.handleJoin(JoinRequest{Name: name, Resp: resp})
+		return w.agents[(<-resp).Welcome.AgentID]
+	}
+	seller := join("seller")
+	buyer := join("buyer")
+	buyer.Pos = seller.Pos
+
+	set := protocol.InstantReq{ID: "t1", Type: InstantTypeSetTrigger, Trigger: &protocol.TriggerSpec{
+		ID: "accept", On: "TRADE_OFFER", MaxFires: 1,
+		Where: []protocol.TriggerCond{
+			{Field: "from", Op: "EQ", Value: seller.ID},
+			{Field: "offer_value", Op: "GE", Value: 1},
+		},
+		Instant: &protocol.InstantReq{Type: InstantTypeAcceptTrade, TradeID: "$trade_id"},
+	}}
+	w.step(nil, nil, []ActionEnvelope{{AgentID: buyer.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: buyer.ID, Instants: []protocol.InstantReq{set}}}})
+	if len(buyer.Triggers) != 1 {
+		t.Fatalf("trigger not stored")
+	}
+
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	tick := w.CurrentTick() - 1
+	if err := w2.ImportSnapshot(w.ExportSnapshot(tick)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+	seller, buyer = w2.agents[seller.ID], w2.agents[buyer.ID]
+	if len(buyer.Triggers) != 1 || buyer.Triggers[0].Instant.TradeID != "$trade_id" {
+		t.Fatalf("trigger not restored: %+v", buyer.Triggers)
+	}
+
+	planks, stone := buyer.Inventory["PLANK"], seller.Inventory["STONE"]
+	offer := protocol.InstantReq{ID: "o1", Type: InstantTypeOfferTrade, To: buyer.ID,
+		Offer: [][]interface{}{{"PLANK", 2}}, Request: [][]interface{}{{"STONE", 1}}}
+	w2.step(nil, nil, []ActionEnvelope{{AgentID: seller.ID, Act: protocol.ActMsg{Tick: w2.CurrentTick(), AgentID: seller.ID, Instants: []protocol.InstantReq{offer}}}})
+	if buyer.Inventory["PLANK"] != planks+2 || seller.Inventory["STONE"] != stone+1 {
+		t.Fatalf("trade not auto-accepted: buyer PLANK=%d seller STONE=%d", buyer.Inventory["PLANK"], seller.Inventory["STONE"])
+	}
+	if buyer.Triggers != nil {
+		t.Fatalf("expected max_fires=1 trigger removed, got %+v", buyer.Triggers)
+	}
+	fired := false
+	for _, e := range buyer.Events {
+		if e["type"] == "TRIGGER_FIRED" && e["trigger_id"] == "accept" {
+			fired = true
+		}
+	}
+	if !fired {
+		t.Fatalf("missing TRIGGER_FIRED event")
+	}
+}
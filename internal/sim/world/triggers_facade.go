@@ -0,0 +1,39 @@
+package world
+
+import (
+	"slices"
+
+	"voxelcraft.ai/internal/protocol"
+	triggerspkg "voxelcraft.ai/internal/sim/world/feature/session/triggers"
+)
+
+// triggerRules gates the action types a trigger may fire. It reads the type lists
+// rather than the dispatch maps, which would make the SET_TRIGGER handler refer to
+// its own map.
+func triggerRules() triggerspkg.Env {
+	return triggerspkg.Env{
+		InstantAllowed: func(typ string) bool {
+			return typ != InstantTypeSetTrigger && typ != InstantTypeRemoveTrigger && slices.Contains(supportedInstantTypes, typ)
+		},
+		TaskAllowed: func(typ string) bool {
+			return slices.Contains(supportedTaskReqTypes, typ)
+		},
+	}
+}
+
+// systemTriggers runs after the other systems so rules see this tick's events.
+func (w *World) systemTriggers(nowTick uint64) {
+	env := triggerRules()
+	env.ApplyInstant = w.applyInstant
+	env.ApplyTask = w.applyTaskReq
+	env.Suspended = w.permitBarred
+	triggerspkg.EvaluateAll(env, w.sortedAgents(), nowTick, w.cfg.TriggerBudgetPerTick)
+}
+
+func handleInstantSetTrigger(_ *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	triggerspkg.HandleSet(triggerRules(), actionResult, a, inst, nowTick)
+}
+
+func handleInstantRemoveTrigger(_ *World, a *Agent, inst protocol.InstantReq, nowTick uint64) {
+	triggerspkg.HandleRemove(actionResult, a, inst, nowTick)
+}
@@ -4,10 +4,17 @@ import "voxelcraft.ai/internal/protocol"
 
 import modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
 import transferruntimepkg "voxelcraft.ai/internal/sim/world/feature/transfer/runtime"
+import digestfeaturepkg "voxelcraft.ai/internal/sim/world/feature/persistence/digest"
+import permitpkg "voxelcraft.ai/internal/sim/world/feature/transfer/permit"
 
 type Vec3i = modelpkg.Vec3i
 type Sign = modelpkg.Sign
 type ConveyorMeta = modelpkg.ConveyorMeta
+type Crop = modelpkg.Crop
+type SignalNode = modelpkg.SignalNode
+type MachineMeta = modelpkg.MachineMeta
+type Shop = modelpkg.Shop
+type ShopPrice = modelpkg.ShopPrice
 type FunScore = modelpkg.FunScore
 type Equipment = modelpkg.Equipment
 type Agent = modelpkg.Agent
@@ -19,13 +26,21 @@ type OrgRole = modelpkg.OrgRole
 type Organization = modelpkg.Organization
 type ContractState = modelpkg.ContractState
 type Contract = modelpkg.Contract
+type AuctionBid = modelpkg.AuctionBid
+type MarketOrder = modelpkg.MarketOrder
+type PriceSeries = modelpkg.PriceSeries
 type ItemEntity = modelpkg.ItemEntity
+type Mob = modelpkg.Mob
 type Structure = modelpkg.Structure
 type MemoryEntry = modelpkg.MemoryEntry
 type RateWindowSnapshot = modelpkg.RateWindowSnapshot
 type FunDecaySnapshot = modelpkg.FunDecaySnapshot
 type AgentTransfer = transferruntimepkg.AgentTransfer
 type OrgTransfer = transferruntimepkg.OrgTransfer
+type TransferDeniedError = transferruntimepkg.DeniedError
+type PermitRule = permitpkg.Rule
+type PermitGrant = permitpkg.Grant
+type DigestSection = digestfeaturepkg.Section
 
 const (
 	ClaimTypeDefault   = modelpkg.ClaimTypeDefault
@@ -56,15 +71,23 @@ func Manhattan(a, b Vec3i) int {
 }
 
 type JoinRequest struct {
-	Name        string
+	Name string
+	// AgentID optionally requests a specific id (authenticated identity binding, replay).
+	AgentID string
+	// Reserved reports ids bound to other identities; sequential allocation skips them.
+	Reserved    func(agentID string) bool
 	DeltaVoxels bool
+	Encoding    string
 	Out         chan []byte
 	Resp        chan JoinResponse
 }
 
 type AttachRequest struct {
 	ResumeToken string
+	// AgentID attaches an already-authenticated identity to its bound agent (no token needed).
+	AgentID     string
 	DeltaVoxels bool
+	Encoding    string
 	Out         chan []byte
 	Resp        chan JoinResponse
 }
@@ -130,6 +153,11 @@ type TickLogEntry struct {
 	Leaves  []string         `json:"leaves,omitempty"`
 	Actions []RecordedAction `json:"actions,omitempty"`
 	Digest  string           `json:"digest"`
+	// Sections holds the per-section digests of the same state (name -> hash) so replay can
+	// tell which sections diverged at the first mismatching tick. Only sections that changed
+	// since the previous entry are written, except on snapshot ticks and the first tick logged
+	// by a process, which carry all of them.
+	Sections map[string]string `json:"sections,omitempty"`
 }
 
 type RecordedAction struct {
@@ -5,9 +5,11 @@ import (
 
 	"voxelcraft.ai/internal/persistence/snapshot"
 	"voxelcraft.ai/internal/sim/catalogs"
+	obsdeltapkg "voxelcraft.ai/internal/sim/world/feature/observer/obsdelta"
 	transfereventspkg "voxelcraft.ai/internal/sim/world/feature/transfer/events"
 	transferruntimepkg "voxelcraft.ai/internal/sim/world/feature/transfer/runtime"
 	modelpkg "voxelcraft.ai/internal/sim/world/kernel/model"
+	storepkg "voxelcraft.ai/internal/sim/world/terrain/store"
 )
 
 type Trade = modelpkg.Trade
@@ -24,6 +26,8 @@ type World struct {
 	metrics atomic.Value
 
 	chunks *ChunkStore
+	// Optional spill area for evicted modified chunks; survives season resets and snapshot imports.
+	chunkRegions *storepkg.RegionStore
 
 	// Derived from catalogs at startup; does not affect determinism/digests directly.
 	smeltByInput map[string]catalogs.RecipeDef
@@ -35,15 +39,34 @@ type World struct {
 	containers map[Vec3i]*Container
 	items      map[string]*ItemEntity
 	itemsAt    map[Vec3i][]string // pos -> item entity ids (in insertion order)
+	mobs       map[string]*Mob
 	conveyors  map[Vec3i]ConveyorMeta
 	switches   map[Vec3i]bool
+	machines   map[Vec3i]MachineMeta
+	shops      map[Vec3i]*Shop
+	crops      map[Vec3i]*Crop
 	trades     map[string]*Trade
 	boards     map[string]*Board
 	signs      map[Vec3i]*Sign
 	contracts  map[string]*Contract
+	orders     map[string]*MarketOrder
 	laws       map[string]*Law
 	orgs       map[string]*Organization
 
+	// Price index: rolling price samples per item, fed by every completed exchange.
+	prices map[string]*PriceSeries
+
+	// Flowing water: FLOWING_WATER levels and cells to re-evaluate next tick.
+	waterLevels map[Vec3i]int
+	waterActive map[Vec3i]bool
+
+	// Signal network: node state of wires/sensors/batteries/gates and cells to
+	// re-evaluate next tick. signalPolled indexes the sensors and batteries polled
+	// every tick (derived from the nodes; not persisted).
+	signalNodes  map[Vec3i]*SignalNode
+	signalActive map[Vec3i]bool
+	signalPolled map[Vec3i]bool
+
 	inbox         chan ActionEnvelope
 	join          chan JoinRequest
 	attach        chan AttachRequest
@@ -59,6 +82,7 @@ type World struct {
 	transferOut   chan transferruntimepkg.TransferOutReq
 	transferIn    chan transferruntimepkg.TransferInReq
 	injectEvent   chan injectEventReq
+	obsAck        chan obsAckReq
 
 	// Observer (admin-only, read-only)
 	observerJoin  chan ObserverJoinRequest
@@ -71,13 +95,17 @@ type World struct {
 	nextTradeNum    atomic.Uint64
 	nextPostNum     atomic.Uint64
 	nextContractNum atomic.Uint64
+	nextOrderNum    atomic.Uint64
 	nextLawNum      atomic.Uint64
 	nextOrgNum      atomic.Uint64
 	nextItemNum     atomic.Uint64
+	nextMobNum      atomic.Uint64
 
 	// Optional loggers (may be nil). Implemented in internal/persistence/*.
 	tickLogger  TickLogger
 	auditLogger AuditLogger
+	// Section digests as of the last logged tick; the tick log records only changes against it.
+	loggedSections map[string]string
 
 	// Optional snapshot sink (may be nil). Snapshot writing should be off-thread.
 	snapshotSink chan<- snapshot.SnapshotV1
@@ -100,6 +128,8 @@ type World struct {
 	observers map[string]*observerClient
 	// Per-tick audit events for observers (captured via auditSetBlock).
 	obsAuditsThisTick []AuditEntry
+	// Cells touched this tick whose support must be re-checked (3D worlds only).
+	gravityQueue []Vec3i
 
 	resetTotal uint64
 
@@ -112,5 +142,7 @@ type World struct {
 type clientState struct {
 	Out         chan []byte
 	DeltaVoxels bool
+	Encoding    string // protocol wire encoding for OBS ("" = JSON)
 	LastVoxels  []uint16
+	ObsDelta    *obsdeltapkg.Tracker // nil until the client sends OBS_ACK
 }
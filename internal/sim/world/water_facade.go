@@ -0,0 +1,121 @@
+package world
+
+import (
+	farmingpkg "voxelcraft.ai/internal/sim/world/feature/survival/farming"
+	waterpkg "voxelcraft.ai/internal/sim/world/feature/survival/water"
+)
+
+// systemWater re-evaluates the water cells woken since the last tick (block changes
+// next to water, flood sources), at most water_updates_per_tick of them.
+func (w *World) systemWater(nowTick uint64) {
+	if len(w.waterActive) == 0 {
+		return
+	}
+	src, flow, ok := w.waterBlocks()
+	if !ok {
+		return
+	}
+	air := w.chunks.gen.Air
+	waterpkg.Step(
+		waterpkg.StepInput{
+			Levels:   w.waterLevels,
+			Active:   w.waterActive,
+			Budget:   w.cfg.WaterUpdatesPerTick,
+			Vertical: w.cfg.Height > 1,
+		},
+		waterpkg.StepHooks{
+			CellAt: func(pos Vec3i) waterpkg.Cell {
+				return w.waterCellAt(nowTick, pos, src, flow)
+			},
+			InBounds: w.chunks.inBounds,
+			SetFlow: func(pos Vec3i, from waterpkg.Cell) {
+				reason := "WATER_FLOW"
+				if from == waterpkg.CellFloodable {
+					reason = "FLOOD"
+					delete(w.crops, pos)
+				}
+				b := w.chunks.GetBlock(pos)
+				w.chunks.SetBlock(pos, flow)
+				w.auditSetBlock(nowTick, "WORLD", pos, b, flow, reason)
+			},
+			Dry: func(pos Vec3i) {
+				w.chunks.SetBlock(pos, air)
+				w.auditSetBlock(nowTick, "WORLD", pos, flow, air, "WATER_DRY")
+			},
+		},
+	)
+}
+
+func (w *World) waterBlocks() (src, flow uint16, ok bool) {
+	src, ok1 := w.catalogs.Blocks.Index[waterpkg.SourceBlock]
+	flow, ok2 := w.catalogs.Blocks.Index[waterpkg.FlowBlock]
+	return src, flow, ok1 && ok2
+}
+
+// waterCellAt classifies pos for the flow rules. Inside an active FLOOD_WARNING zone,
+// crops and structure blocks on unclaimed land are washed away.
+func (w *World) waterCellAt(nowTick uint64, pos Vec3i, src, flow uint16) waterpkg.Cell {
+	switch b := w.chunks.GetBlock(pos); b {
+	case w.chunks.gen.Air:
+		return waterpkg.CellAir
+	case src:
+		return waterpkg.CellSource
+	case flow:
+		return waterpkg.CellFlow
+	default:
+		if !w.inFloodZone(nowTick, pos) || w.landAt(pos) != nil {
+			return waterpkg.CellSolid
+		}
+		name := w.blockName(b)
+		if farmingpkg.IsCropBlock(name) || (waterpkg.Erodable(name) && w.inStructure(pos)) {
+			return waterpkg.CellFloodable
+		}
+		return waterpkg.CellSolid
+	}
+}
+
+func (w *World) inFloodZone(nowTick uint64, pos Vec3i) bool {
+	return w.activeEventID == "FLOOD_WARNING" && nowTick < w.activeEventEnds &&
+		w.activeEventRadius > 0 && distXZ(pos, w.activeEventCenter) <= w.activeEventRadius
+}
+
+func (w *World) inStructure(pos Vec3i) bool {
+	for _, s := range w.structures {
+		if s != nil &&
+			pos.X >= s.Min.X && pos.X <= s.Max.X &&
+			pos.Y >= s.Min.Y && pos.Y <= s.Max.Y &&
+			pos.Z >= s.Min.Z && pos.Z <= s.Max.Z {
+			return true
+		}
+	}
+	return false
+}
+
+// wakeWater runs for every audited block change: pos and the flowing water around it
+// are re-evaluated next tick if any water touches pos.
+func (w *World) wakeWater(pos Vec3i) {
+	src, flow, ok := w.waterBlocks()
+	if !ok || w.waterActive == nil {
+		return
+	}
+	b := w.chunks.GetBlock(pos)
+	if b != flow {
+		delete(w.waterLevels, pos)
+	}
+	near := b == src || b == flow
+	for _, n := range waterpkg.Neighbours(pos, w.cfg.Height > 1) {
+		if !w.chunks.inBounds(n) {
+			continue
+		}
+		switch w.chunks.GetBlock(n) {
+		case flow:
+			w.waterActive[n] = true
+			near = true
+		case src:
+			near = true
+		}
+	}
+	if near {
+		w.waterActive[pos] = true
+	}
+}
@@ -0,0 +1,123 @@
+package world
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func TestWater_FloodWashesUnprotectedCropsAndSurvivesSnapshot(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "water", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000, WaterUpdatesPerTick: 64}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	center := Vec3i{X: 100, Y: 0, Z: 100}
+	for dx := -12; dx <= 12; dx++ {
+		for dz := -12; dz <= 12; dz++ {
+			setAir(w, Vec3i{X: center.X + dx, Z: center.Z + dz})
+		}
+	}
+	wild := Vec3i{X: center.X + 5, Z: center.Z}
+	claimed := Vec3i{X: center.X - 5, Z: center.Z}
+	for _, p := range []Vec3i{wild, claimed} {
+		setSolid(w, p, w.catalogs.Blocks.Index["WHEAT_CROP"])
+		w.plantCrop(p, "WHEAT_CROP", 0)
+	}
+	w.claims["LAND_1"] = &LandClaim{LandID: "LAND_1", Owner: "A1", Anchor: claimed, Radius: 1}
+
+	now := w.CurrentTick()
+	w.activeEventID = "FLOOD_WARNING"
+	w.activeEventCenter = center
+	w.activeEventRadius = 12
+	w.activeEventEnds = now + 10_000
+	w.spawnFloodWarning(now, center)
+	if len(w.waterActive) == 0 {
+		t.Fatalf("flood sources did not wake the water system")
+	}
+
+	w.step(nil, nil, nil)
+	w.step(nil, nil, nil)
+
+	// Snapshot mid-flow: both worlds must settle identically.
+	w2, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world2: %v", err)
+	}
+	tick := w.CurrentTick() - 1
+	if err := w2.ImportSnapshot(w.ExportSnapshot(tick)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(w2.waterActive) == 0 || len(w2.waterLevels) == 0 {
+		t.Fatalf("water state not restored")
+	}
+	for i := 0; i < 40; i++ {
+		w.step(nil, nil, nil)
+		w2.step(nil, nil, nil)
+	}
+	if len(w.waterActive) != 0 {
+		t.Fatalf("water did not settle: %d active", len(w.waterActive))
+	}
+	tick = w.CurrentTick() - 1
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after resumed flow: %s vs %s", d1, d2)
+	}
+
+	if got := w.blockName(w.chunks.GetBlock(wild)); got != "FLOWING_WATER" || w.crops[wild] != nil {
+		t.Fatalf("expected unprotected crop washed away, got %s", got)
+	}
+	if got := w.blockName(w.chunks.GetBlock(claimed)); got != "WHEAT_CROP" || w.crops[claimed] == nil {
+		t.Fatalf("expected claimed crop kept, got %s", got)
+	}
+	if got := w.waterLevels[Vec3i{X: center.X + 3, Z: center.Z}]; got != 6 {
+		t.Fatalf("level next to the flood sources = %d, want 6", got)
+	}
+	if _, ok := w.waterLevels[Vec3i{X: center.X + 9, Z: center.Z}]; ok {
+		t.Fatalf("water spread too far")
+	}
+}
+
+func TestWater_BucketFillAndPlace(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	cfg := WorldConfig{ID: "bucket", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 42, BoundaryR: 4000}
+	w, err := New(cfg, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	resp := make(chan JoinResponse, 1)
+	w.handleJoin(JoinRequest{Name: "carrier", Resp: resp})
+	a := w.agents[(<-resp).Welcome.AgentID]
+	a.Inventory["BUCKET"] = 1
+
+	from := Vec3i{X: a.Pos.X + 1, Z: a.Pos.Z}
+	to := Vec3i{X: a.Pos.X - 1, Z: a.Pos.Z}
+	setSolid(w, from, w.catalogs.Blocks.Index["WATER"])
+	setAir(w, to)
+	run := func(tr protocol.TaskReq) {
+		t.Helper()
+		w.step(nil, nil, []ActionEnvelope{{AgentID: a.ID, Act: protocol.ActMsg{Tick: w.CurrentTick(), AgentID: a.ID, Tasks: []protocol.TaskReq{tr}}}})
+		for i := 0; i < 50 && a.WorkTask != nil; i++ {
+			w.step(nil, nil, nil)
+		}
+	}
+
+	run(protocol.TaskReq{ID: "fill", Type: "MINE", BlockPos: from.ToArray()})
+	if a.Inventory["WATER_BUCKET"] != 1 || a.Inventory["BUCKET"] != 0 {
+		t.Fatalf("bucket not filled: %v", a.Inventory)
+	}
+	run(protocol.TaskReq{ID: "pour", Type: "PLACE", ItemID: "WATER_BUCKET", BlockPos: to.ToArray()})
+	if got := w.blockName(w.chunks.GetBlock(to)); got != "WATER" {
+		t.Fatalf("expected a water source, got %s", got)
+	}
+	if a.Inventory["WATER_BUCKET"] != 0 || a.Inventory["BUCKET"] != 1 {
+		t.Fatalf("bucket not returned: %v", a.Inventory)
+	}
+}
@@ -60,6 +60,12 @@ func (w *World) systemWorkImpl(nowTick uint64) {
 			w.tickSmelt(a, wt, nowTick)
 		case tasks.KindBuildBlueprint:
 			w.tickBuildBlueprint(a, wt, nowTick)
+		case tasks.KindAttack:
+			w.tickAttack(a, wt, nowTick)
+		case tasks.KindTill:
+			workruntimepkg.TickTill(newWorkTaskExecEnv(w), a, wt, nowTick)
+		case tasks.KindBuy:
+			w.tickBuy(a, wt, nowTick)
 		}
 	}
 }
@@ -11,8 +11,8 @@ import (
 
 func New(cfg WorldConfig, cats *catalogs.Catalogs) (*World, error) {
 	cfg.applyDefaults()
-	if cfg.Height != 1 {
-		return nil, fmt.Errorf("2D world requires height=1 (got %d)", cfg.Height)
+	if cfg.Height < 1 || cfg.Height > MaxWorldHeight {
+		return nil, fmt.Errorf("world height must be in 1..%d (got %d)", MaxWorldHeight, cfg.Height)
 	}
 	if err := validateActionDispatchMaps(); err != nil {
 		return nil, err
@@ -46,6 +46,7 @@ func New(cfg WorldConfig, cats *catalogs.Catalogs) (*World, error) {
 	gen := WorldGen{
 		Seed:      cfg.Seed,
 		BoundaryR: cfg.BoundaryR,
+		Height:    cfg.Height,
 		// Worldgen tuning.
 		BiomeRegionSize:                 cfg.BiomeRegionSize,
 		SpawnClearRadius:                cfg.SpawnClearRadius,
@@ -78,12 +79,23 @@ func New(cfg WorldConfig, cats *catalogs.Catalogs) (*World, error) {
 		containers:    map[Vec3i]*Container{},
 		items:         map[string]*ItemEntity{},
 		itemsAt:       map[Vec3i][]string{},
+		mobs:          map[string]*Mob{},
 		conveyors:     map[Vec3i]ConveyorMeta{},
+		machines:      map[Vec3i]MachineMeta{},
+		shops:         map[Vec3i]*Shop{},
 		switches:      map[Vec3i]bool{},
+		crops:         map[Vec3i]*Crop{},
+		waterLevels:   map[Vec3i]int{},
+		waterActive:   map[Vec3i]bool{},
+		signalNodes:   map[Vec3i]*SignalNode{},
+		signalActive:  map[Vec3i]bool{},
+		signalPolled:  map[Vec3i]bool{},
 		trades:        map[string]*Trade{},
 		boards:        map[string]*Board{},
 		signs:         map[Vec3i]*Sign{},
 		contracts:     map[string]*Contract{},
+		orders:        map[string]*MarketOrder{},
+		prices:        map[string]*PriceSeries{},
 		laws:          map[string]*Law{},
 		orgs:          map[string]*Organization{},
 		inbox:         make(chan ActionEnvelope, 1024),
@@ -101,6 +113,7 @@ func New(cfg WorldConfig, cats *catalogs.Catalogs) (*World, error) {
 		transferOut:   make(chan transferruntimepkg.TransferOutReq, 64),
 		transferIn:    make(chan transferruntimepkg.TransferInReq, 64),
 		injectEvent:   make(chan injectEventReq, 256),
+		obsAck:        make(chan obsAckReq, 256),
 		observerJoin:  make(chan ObserverJoinRequest, 16),
 		observerSub:   make(chan ObserverSubscribeRequest, 64),
 		observerLeave: make(chan string, 16),
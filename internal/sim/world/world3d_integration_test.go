@@ -0,0 +1,190 @@
+package world
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+)
+
+func newWorld3D(t *testing.T, height int) *World {
+	t.Helper()
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	w, err := New(WorldConfig{ID: "deep", TickRateHz: 5, DayTicks: 6000, ObsRadius: 7, Height: height, Seed: 42, BoundaryR: 4000}, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	return w
+}
+
+func TestWorld3D_AgentsStandOnSurface(t *testing.T) {
+	w := newWorld3D(t, 16)
+	w.StepOnce([]JoinRequest{{Name: "alpha"}}, nil, nil)
+	var a *Agent
+	for _, v := range w.agents {
+		a = v
+	}
+	if a == nil {
+		t.Fatalf("agent not joined")
+	}
+	if a.Pos.Y <= 0 || a.Pos.Y != w.spawnY(a.Pos.X, a.Pos.Z) {
+		t.Fatalf("agent y=%d, surface=%d", a.Pos.Y, w.spawnY(a.Pos.X, a.Pos.Z))
+	}
+	if !w.blockSolid(w.chunks.GetBlock(Vec3i{X: a.Pos.X, Y: a.Pos.Y - 1, Z: a.Pos.Z})) {
+		t.Fatalf("agent is not standing on a solid block")
+	}
+
+	// Digging out the ground under the agent drops it with the surface.
+	ground := Vec3i{X: a.Pos.X, Y: a.Pos.Y - 1, Z: a.Pos.Z}
+	air := w.chunks.gen.Air
+	from := w.chunks.GetBlock(ground)
+	w.chunks.SetBlock(ground, air)
+	w.auditSetBlock(w.CurrentTick(), "TEST", ground, from, air, "TEST")
+	w.step(nil, nil, nil)
+	if a.Pos.Y != w.spawnY(a.Pos.X, a.Pos.Z) || a.Pos.Y > ground.Y {
+		t.Fatalf("agent did not settle: y=%d ground=%d", a.Pos.Y, ground.Y)
+	}
+}
+
+func TestWorld3D_AgentsStayInCaves(t *testing.T) {
+	w := newWorld3D(t, 16)
+	w.StepOnce([]JoinRequest{{Name: "alpha"}}, nil, nil)
+	var a *Agent
+	for _, v := range w.agents {
+		a = v
+	}
+	stone := w.catalogs.Blocks.Index["STONE"]
+	air := w.chunks.gen.Air
+
+	// A pocket two cells under the surface, roofed and floored with stone.
+	x, z := a.Pos.X, a.Pos.Z
+	sy := w.spawnY(x, z)
+	if sy < 4 {
+		t.Fatalf("surface too low for test: %d", sy)
+	}
+	cave := Vec3i{X: x, Y: sy - 3, Z: z}
+	w.chunks.SetBlock(Vec3i{X: x, Y: cave.Y - 1, Z: z}, stone)
+	w.chunks.SetBlock(cave, air)
+	w.chunks.SetBlock(Vec3i{X: x, Y: cave.Y + 1, Z: z}, stone)
+	a.Pos = cave
+	w.step(nil, nil, nil)
+	if a.Pos != cave {
+		t.Fatalf("agent in cave moved to %+v", a.Pos)
+	}
+
+	// Without the floor it falls to the next solid block below.
+	w.chunks.SetBlock(Vec3i{X: x, Y: cave.Y - 1, Z: z}, air)
+	w.chunks.SetBlock(Vec3i{X: x, Y: cave.Y - 2, Z: z}, air)
+	w.step(nil, nil, nil)
+	if a.Pos.Y > cave.Y-2 {
+		t.Fatalf("unsupported agent did not fall: y=%d cave=%d", a.Pos.Y, cave.Y)
+	}
+	if a.Pos.Y > 0 && !w.blockSolid(w.chunks.GetBlock(Vec3i{X: x, Y: a.Pos.Y - 1, Z: z})) {
+		t.Fatalf("agent stopped mid-air at y=%d", a.Pos.Y)
+	}
+}
+
+func TestWorld3D_AgentsWalkThroughTunnels(t *testing.T) {
+	w := newWorld3D(t, 16)
+	w.StepOnce([]JoinRequest{{Name: "alpha"}}, nil, nil)
+	var a *Agent
+	for _, v := range w.agents {
+		a = v
+	}
+	stone := w.catalogs.Blocks.Index["STONE"]
+	air := w.chunks.gen.Air
+
+	// A straight tunnel three cells under the surface, roofed and floored with stone.
+	x, z := a.Pos.X, a.Pos.Z
+	sy := w.spawnY(x, z)
+	if sy < 4 {
+		t.Fatalf("surface too low for test: %d", sy)
+	}
+	ty := sy - 3
+	for i := 0; i <= 5; i++ {
+		w.chunks.SetBlock(Vec3i{X: x + i, Y: ty - 1, Z: z}, stone)
+		w.chunks.SetBlock(Vec3i{X: x + i, Y: ty, Z: z}, air)
+		w.chunks.SetBlock(Vec3i{X: x + i, Y: ty + 1, Z: z}, stone)
+	}
+	a.Pos = Vec3i{X: x, Y: ty, Z: z}
+
+	target := Vec3i{X: x + 5, Y: ty, Z: z}
+	act := protocol.ActMsg{
+		Type:            protocol.TypeAct,
+		ProtocolVersion: protocol.Version,
+		AgentID:         a.ID,
+		Tasks:           []protocol.TaskReq{{ID: "K1", Type: "MOVE_TO", Target: target.ToArray()}},
+	}
+	w.step(nil, nil, []ActionEnvelope{{AgentID: a.ID, Act: act}})
+	for i := 0; i < 6 && a.MoveTask != nil; i++ {
+		w.step(nil, nil, nil)
+	}
+	if a.MoveTask != nil || a.Pos != (Vec3i{X: x + 4, Y: ty, Z: z}) {
+		t.Fatalf("agent did not walk the tunnel: pos=%+v task=%+v", a.Pos, a.MoveTask)
+	}
+}
+
+func TestWorld3D_GravityDropsSandColumn(t *testing.T) {
+	w := newWorld3D(t, 16)
+	w.StepOnce(nil, nil, nil)
+	sand := w.catalogs.Blocks.Index["SAND"]
+	stone := w.catalogs.Blocks.Index["STONE"]
+	air := w.chunks.gen.Air
+
+	x, z := 200, -150
+	sy := w.spawnY(x, z)
+	if sy+4 >= 16 {
+		t.Fatalf("surface too high for test: %d", sy)
+	}
+	// STONE pillar of 2 with two SAND on top.
+	for i, b := range []uint16{stone, stone, sand, sand} {
+		p := Vec3i{X: x, Y: sy + i, Z: z}
+		w.chunks.SetBlock(p, b)
+	}
+	w.step(nil, nil, nil)
+	if got := w.chunks.GetBlock(Vec3i{X: x, Y: sy + 3, Z: z}); got != sand {
+		t.Fatalf("untouched sand should stay: got %d", got)
+	}
+
+	// Remove the stone pillar: both sand blocks fall onto the original surface.
+	tick := w.CurrentTick()
+	for i := 0; i < 2; i++ {
+		p := Vec3i{X: x, Y: sy + i, Z: z}
+		w.chunks.SetBlock(p, air)
+		w.auditSetBlock(tick, "TEST", p, stone, air, "TEST")
+	}
+	w.step(nil, nil, nil)
+	for i, want := range []uint16{sand, sand, air, air} {
+		if got := w.chunks.GetBlock(Vec3i{X: x, Y: sy + i, Z: z}); got != want {
+			t.Fatalf("y=%d: got %d want %d", sy+i, got, want)
+		}
+	}
+}
+
+func TestWorld3D_SnapshotRoundTrip(t *testing.T) {
+	w := newWorld3D(t, 24)
+	w.StepOnce([]JoinRequest{{Name: "alpha"}}, nil, nil)
+	p := Vec3i{X: 5, Y: 20, Z: 5}
+	w.chunks.SetBlock(p, w.catalogs.Blocks.Index["STONE"])
+	tick := w.CurrentTick()
+	snap := w.ExportSnapshot(tick)
+	if snap.Height != 24 || len(snap.Chunks) == 0 || len(snap.Chunks[0].Blocks) != 16*16*24 {
+		t.Fatalf("unexpected snapshot layout: height=%d chunks=%d", snap.Height, len(snap.Chunks))
+	}
+
+	w2 := newWorld3D(t, 24)
+	if err := w2.ImportSnapshot(snap); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d1, d2 := w.stateDigest(tick), w2.stateDigest(tick); d1 != d2 {
+		t.Fatalf("digest mismatch after import: %s vs %s", d1, d2)
+	}
+
+	flat := newWorld3D(t, 1)
+	if err := flat.ImportSnapshot(snap); err == nil {
+		t.Fatalf("expected height mismatch error")
+	}
+}
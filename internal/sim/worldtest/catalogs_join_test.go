@@ -29,7 +29,7 @@ func TestJoin_CatalogsIncludeFullSet(t *testing.T) {
 	}}, nil, nil)
 	jr := <-resp
 
-	if got, want := len(jr.Catalogs), 7; got != want {
+	if got, want := len(jr.Catalogs), 8; got != want {
 		t.Fatalf("catalog count: got %d want %d", got, want)
 	}
 
@@ -52,7 +52,7 @@ func TestJoin_CatalogsIncludeFullSet(t *testing.T) {
 			t.Fatalf("catalog %q missing data", c.Name)
 		}
 	}
-	wantNames := []string{"block_palette", "item_palette", "tuning", "recipes", "blueprints", "law_templates", "events"}
+	wantNames := []string{"block_palette", "item_palette", "tuning", "recipes", "blueprints", "law_templates", "events", "price_index"}
 	if !reflect.DeepEqual(names, wantNames) {
 		t.Fatalf("catalog names/order: got %v want %v", names, wantNames)
 	}
@@ -123,4 +123,9 @@ func TestJoin_CatalogsIncludeFullSet(t *testing.T) {
 			}
 		}
 	}
+	if entries, ok := jr.Catalogs[7].Data.([]protocol.PriceObs); !ok || len(entries) != len(cats.Items.Palette) {
+		t.Fatalf("price_index data type/len unexpected: %T", jr.Catalogs[7].Data)
+	} else if entries[0].Item != cats.Items.Palette[0] || entries[0].Price != entries[0].Base {
+		t.Fatalf("price_index entry unexpected: %+v", entries[0])
+	}
 }
@@ -0,0 +1,174 @@
+package worldtest
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	world "voxelcraft.ai/internal/sim/world"
+)
+
+// newArena claims land for the default agent with allow_damage enabled and puts
+// a second agent next to the anchor. It returns the attacker and victim ids.
+func newArena(t *testing.T) (*Harness, string, string, world.Vec3i) {
+	t.Helper()
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	h := NewHarness(t, world.WorldConfig{
+		ID:         "test",
+		WorldType:  "OVERWORLD",
+		TickRateHz: 5,
+		DayTicks:   6000,
+		ObsRadius:  7,
+		Height:     1,
+		Seed:       1,
+		BoundaryR:  4000,
+		StarterItems: map[string]int{
+			"BATTERY":       5,
+			"CRYSTAL_SHARD": 5,
+			"PLANK":         20,
+		},
+	}, cats, "attacker")
+	attacker := h.DefaultAgentID
+	victim := h.Join("victim")
+
+	anchorArr := h.LastObsFor(attacker).Self.Pos
+	anchor := world.Vec3i{X: anchorArr[0], Y: 0, Z: anchorArr[2]}
+	h.SetBlock(anchor, "AIR")
+	h.StepFor(attacker, nil, []protocol.TaskReq{{ID: "K_claim", Type: "CLAIM_LAND", Anchor: anchorArr, Radius: 32}}, nil)
+	landID := actionResultFieldString(h.LastObsFor(attacker), "K_claim", "land_id")
+	if landID == "" {
+		t.Fatalf("expected land_id in claim ACTION_RESULT")
+	}
+	h.StepFor(attacker, []protocol.InstantReq{{ID: "I_perm", Type: "SET_PERMISSIONS", LandID: landID, Policy: map[string]bool{"allow_damage": true}}}, nil, nil)
+	if got := actionResultCode(h.LastObsFor(attacker), "I_perm"); got != "" {
+		t.Fatalf("set permissions code=%q", got)
+	}
+
+	victimPos := world.Vec3i{X: anchor.X + 1, Y: 0, Z: anchor.Z}
+	h.SetBlock(victimPos, "AIR")
+	h.SetAgentPosFor(attacker, anchor)
+	h.SetAgentPosFor(victim, victimPos)
+	return h, attacker, victim, anchor
+}
+
+func findEvent(obs protocol.ObsMsg, typ string) protocol.Event {
+	for _, e := range obs.Events {
+		if e["type"] == typ {
+			return e
+		}
+	}
+	return nil
+}
+
+func TestAttack_DeniedInWild(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	h := NewHarness(t, world.WorldConfig{ID: "test", Seed: 42}, cats, "attacker")
+	victim := h.Join("victim")
+	h.SetAgentPos(world.Vec3i{X: 10, Y: 0, Z: 10})
+	h.SetAgentPosFor(victim, world.Vec3i{X: 11, Y: 0, Z: 10})
+
+	before := h.LastObs().Self.Reputation.Law
+	obs := h.Step(nil, []protocol.TaskReq{{ID: "K_atk", Type: "ATTACK", TargetID: victim}}, nil)
+	if !hasTaskFail(obs, "E_NO_PERMISSION") {
+		t.Fatalf("expected TASK_FAIL E_NO_PERMISSION; events=%v", obs.Events)
+	}
+	if after := obs.Self.Reputation.Law; after >= before {
+		t.Fatalf("expected law reputation penalty: before=%v after=%v", before, after)
+	}
+	if hp := h.LastObsFor(victim).Self.HP; hp != 20 {
+		t.Fatalf("victim hp=%d want 20", hp)
+	}
+}
+
+func TestAttack_KillRespawnsVictimWithKilledReason(t *testing.T) {
+	h, attacker, victim, _ := newArena(t)
+	h.AddInventoryFor(attacker, "IRON_SWORD", 1)
+
+	h.StepFor(attacker, nil, []protocol.TaskReq{{ID: "K_atk", Type: "ATTACK", TargetID: victim}}, nil)
+	if got := actionResultCode(h.LastObsFor(attacker), "K_atk"); got != "" {
+		t.Fatalf("attack code=%q", got)
+	}
+	var respawn protocol.Event
+	done := false
+	for i := 0; i < 40 && !done; i++ {
+		h.StepNoop()
+		if ev := findEvent(h.LastObsFor(victim), "RESPAWN"); ev != nil {
+			respawn = ev
+		}
+		aobs := h.LastObsFor(attacker)
+		if hasTaskFail(aobs, "") {
+			t.Fatalf("unexpected TASK_FAIL: %v", aobs.Events)
+		}
+		done = findEvent(aobs, "TASK_DONE") != nil
+	}
+	if !done {
+		t.Fatalf("expected ATTACK to finish with a kill")
+	}
+	if respawn == nil || respawn["reason"] != "KILLED" {
+		t.Fatalf("expected victim RESPAWN reason KILLED, got %v", respawn)
+	}
+	if hp := h.LastObsFor(victim).Self.HP; hp != 20 {
+		t.Fatalf("victim hp after respawn=%d want 20", hp)
+	}
+}
+
+func TestAttack_ArmorReducesDamage(t *testing.T) {
+	h, attacker, victim, _ := newArena(t)
+	h.AddInventoryFor(attacker, "IRON_SWORD", 1)
+	for _, item := range []string{"IRON_HELMET", "IRON_CHESTPLATE", "IRON_LEGGINGS", "IRON_BOOTS"} {
+		h.AddInventoryFor(victim, item, 1)
+		h.StepFor(victim, []protocol.InstantReq{{ID: "I_" + item, Type: "EQUIP", ItemID: item}}, nil, nil)
+		if got := actionResultCode(h.LastObsFor(victim), "I_"+item); got != "" {
+			t.Fatalf("equip %s code=%q", item, got)
+		}
+	}
+	if armor := h.LastObsFor(victim).Equipment.Armor; armor[1] != "IRON_CHESTPLATE" {
+		t.Fatalf("armor=%v", armor)
+	}
+
+	h.StepFor(attacker, nil, []protocol.TaskReq{{ID: "K_atk", Type: "ATTACK", TargetID: victim}}, nil)
+	var hit protocol.Event
+	for i := 0; i < 10 && hit == nil; i++ {
+		h.StepNoop()
+		hit = findEvent(h.LastObsFor(attacker), "ATTACK_HIT")
+	}
+	if hit == nil {
+		t.Fatalf("expected an ATTACK_HIT event")
+	}
+	// Iron sword (5) against full iron armor (80%) deals the minimum of 1.
+	if dmg, _ := hit["damage"].(float64); dmg != 1 {
+		t.Fatalf("damage=%v want 1", hit["damage"])
+	}
+}
+
+func TestAttack_WeaponTierFollowsInventory(t *testing.T) {
+	h, attacker, victim, _ := newArena(t)
+	h.AddInventoryFor(attacker, "IRON_SWORD", 1)
+	nextHit := func() protocol.Event {
+		t.Helper()
+		for i := 0; i < 10; i++ {
+			h.StepNoop()
+			if hit := findEvent(h.LastObsFor(attacker), "ATTACK_HIT"); hit != nil {
+				return hit
+			}
+		}
+		t.Fatalf("expected an ATTACK_HIT event")
+		return nil
+	}
+
+	h.StepFor(attacker, nil, []protocol.TaskReq{{ID: "K_atk", Type: "ATTACK", TargetID: victim}}, nil)
+	if dmg, _ := nextHit()["damage"].(float64); dmg != 5 {
+		t.Fatalf("iron sword damage=%v want 5", dmg)
+	}
+	// Once the sword leaves the inventory the next swing is bare-handed.
+	h.AddInventoryFor(attacker, "IRON_SWORD", -1)
+	if dmg, _ := nextHit()["damage"].(float64); dmg != 1 {
+		t.Fatalf("bare hands damage=%v want 1", dmg)
+	}
+}
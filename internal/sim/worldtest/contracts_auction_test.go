@@ -0,0 +1,183 @@
+package worldtest
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	world "voxelcraft.ai/internal/sim/world"
+	"voxelcraft.ai/internal/sim/world/logic/ids"
+)
+
+type auctionSetup struct {
+	h       *Harness
+	seller  string
+	bidders [2]string
+	termPos world.Vec3i
+	termID  string
+}
+
+// newAuctionSetup places a contract terminal next to the seller, with both bidders in
+// range and the seller's lot posted as an auction of mode with a reserve of 3 IRON_INGOT.
+func newAuctionSetup(t *testing.T, mode string) (auctionSetup, string) {
+	t.Helper()
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	h := NewHarness(t, world.WorldConfig{
+		ID:         "test",
+		WorldType:  "OVERWORLD",
+		TickRateHz: 5,
+		DayTicks:   6000,
+		ObsRadius:  7,
+		Height:     1,
+		Seed:       1,
+		BoundaryR:  4000,
+	}, cats, "seller")
+	s := auctionSetup{h: h, seller: h.DefaultAgentID}
+	s.bidders = [2]string{h.Join("bidder_a"), h.Join("bidder_b")}
+
+	anchorArr := h.LastObsFor(s.seller).Self.Pos
+	anchor := world.Vec3i{X: anchorArr[0], Y: 0, Z: anchorArr[2]}
+	s.termPos = world.Vec3i{X: anchor.X + 1, Y: 0, Z: anchor.Z}
+	h.SetBlock(s.termPos, "AIR")
+	h.AddInventoryFor(s.seller, "CONTRACT_TERMINAL", 1)
+	obs := h.StepFor(s.seller, nil, []protocol.TaskReq{{
+		ID:       "K_place_term",
+		Type:     "PLACE",
+		ItemID:   "CONTRACT_TERMINAL",
+		BlockPos: s.termPos.ToArray(),
+	}}, nil)
+	if hasTaskFail(obs, "") {
+		t.Fatalf("place terminal failed: events=%v", obs.Events)
+	}
+	s.termID = ids.ContainerID("CONTRACT_TERMINAL", s.termPos.X, s.termPos.Y, s.termPos.Z)
+	for _, b := range s.bidders {
+		h.SetAgentPosFor(b, anchor)
+		h.AddInventoryFor(b, "IRON_INGOT", 10)
+	}
+	h.StepNoop()
+
+	h.AddInventoryFor(s.seller, "CRYSTAL_SHARD", 1)
+	h.ClearAgentEventsFor(s.seller)
+	obs = h.StepFor(s.seller, []protocol.InstantReq{{
+		ID:            "I_post",
+		Type:          "POST_CONTRACT",
+		TerminalID:    s.termID,
+		ContractKind:  "AUCTION",
+		Mode:          mode,
+		Reward:        []protocol.ItemStack{{Item: "CRYSTAL_SHARD", Count: 1}},
+		PriceItem:     "IRON_INGOT",
+		Price:         3,
+		DurationTicks: 30,
+	}}, nil, nil)
+	if got := actionResultCode(obs, "I_post"); got != "" {
+		t.Fatalf("POST_CONTRACT expected ok, got code=%q events=%v", got, obs.Events)
+	}
+	contractID := actionResultFieldString(obs, "I_post", "contract_id")
+	if contractID == "" {
+		t.Fatalf("missing contract_id; events=%v", obs.Events)
+	}
+	return s, contractID
+}
+
+func (s auctionSetup) bid(t *testing.T, bidder string, contractID string, amount int) protocol.ObsMsg {
+	t.Helper()
+	s.h.ClearAgentEventsFor(bidder)
+	return s.h.StepFor(bidder, []protocol.InstantReq{{
+		ID:         "I_bid",
+		Type:       "BID_AUCTION",
+		TerminalID: s.termID,
+		ContractID: contractID,
+		Price:      amount,
+	}}, nil, nil)
+}
+
+func TestAuction_EnglishOutbidIsOwedAndWinnerSettles(t *testing.T) {
+	s, contractID := newAuctionSetup(t, "ENGLISH")
+	h := s.h
+	a, b := s.bidders[0], s.bidders[1]
+	baseSellerIron := invCount(h.LastObsFor(s.seller).Inventory, "IRON_INGOT")
+
+	if got := actionResultCode(s.bid(t, a, contractID, 2), "I_bid"); got != "E_BAD_REQUEST" {
+		t.Fatalf("bid below reserve expected E_BAD_REQUEST, got %q", got)
+	}
+	if got := actionResultCode(s.bid(t, a, contractID, 4), "I_bid"); got != "" {
+		t.Fatalf("first bid expected ok, got %q", got)
+	}
+	if got := actionResultCode(s.bid(t, b, contractID, 4), "I_bid"); got != "E_CONFLICT" {
+		t.Fatalf("matching bid expected E_CONFLICT, got %q", got)
+	}
+	h.ClearAgentEventsFor(a)
+	if got := actionResultCode(s.bid(t, b, contractID, 6), "I_bid"); got != "" {
+		t.Fatalf("higher bid expected ok, got %q", got)
+	}
+	outbid := false
+	for _, e := range h.LastObsFor(a).Events {
+		if e["type"] == "AUCTION_OUTBID" && e["contract_id"] == contractID {
+			outbid = true
+		}
+	}
+	if !outbid {
+		t.Fatalf("expected AUCTION_OUTBID for the previous leader, events=%v", h.LastObsFor(a).Events)
+	}
+	_, snap := h.Snapshot()
+	term := findContainerAt(snap, "CONTRACT_TERMINAL", s.termPos.ToArray())
+	if term == nil || term.Owed[a]["IRON_INGOT"] != 4 || term.Reserved["IRON_INGOT"] != 6 {
+		t.Fatalf("terminal after outbid: %+v", term)
+	}
+
+	c := findContract(snap, contractID)
+	stepUntilTick(t, h, c.DeadlineTick+1)
+	_, snap = h.Snapshot()
+	c = findContract(snap, contractID)
+	if c.State != "COMPLETED" || c.Acceptor != b {
+		t.Fatalf("expected COMPLETED with winner %s, got state=%q acceptor=%q", b, c.State, c.Acceptor)
+	}
+	if got := invCount(h.LastObsFor(b).Inventory, "CRYSTAL_SHARD"); got != 1 {
+		t.Fatalf("winner should receive the lot, got %d", got)
+	}
+	if got := invCount(h.LastObsFor(s.seller).Inventory, "IRON_INGOT"); got != baseSellerIron+6 {
+		t.Fatalf("seller should receive the winning bid, got %d->%d", baseSellerIron, got)
+	}
+
+	h.ClearAgentEventsFor(a)
+	obs := h.StepFor(a, []protocol.InstantReq{{ID: "I_claim", Type: "CLAIM_OWED", TerminalID: s.termID}}, nil, nil)
+	if got := actionResultCode(obs, "I_claim"); got != "" {
+		t.Fatalf("CLAIM_OWED expected ok, got %q", got)
+	}
+	if got := invCount(h.LastObsFor(a).Inventory, "IRON_INGOT"); got != 10 {
+		t.Fatalf("outbid bidder should be made whole, got %d IRON_INGOT", got)
+	}
+}
+
+func TestAuction_SealedHighestBidWinsAndLosersAreOwed(t *testing.T) {
+	s, contractID := newAuctionSetup(t, "SEALED")
+	h := s.h
+	a, b := s.bidders[0], s.bidders[1]
+
+	if got := actionResultCode(s.bid(t, a, contractID, 7), "I_bid"); got != "" {
+		t.Fatalf("bid a expected ok, got %q", got)
+	}
+	// Sealed bids need not beat each other.
+	if got := actionResultCode(s.bid(t, b, contractID, 5), "I_bid"); got != "" {
+		t.Fatalf("bid b expected ok, got %q", got)
+	}
+	_, snap := h.Snapshot()
+	c := findContract(snap, contractID)
+	if len(c.Bids) != 2 {
+		t.Fatalf("sealed auction should hold both bids: %+v", c.Bids)
+	}
+
+	stepUntilTick(t, h, c.DeadlineTick+1)
+	_, snap = h.Snapshot()
+	c = findContract(snap, contractID)
+	if c.State != "COMPLETED" || c.Acceptor != a {
+		t.Fatalf("expected COMPLETED with winner %s, got state=%q acceptor=%q", a, c.State, c.Acceptor)
+	}
+	term := findContainerAt(snap, "CONTRACT_TERMINAL", s.termPos.ToArray())
+	if term == nil || term.Owed[b]["IRON_INGOT"] != 5 || len(term.Reserved) != 0 {
+		t.Fatalf("losing bid should be owed back: %+v", term)
+	}
+}
@@ -0,0 +1,323 @@
+package worldtest
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/persistence/snapshot"
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	world "voxelcraft.ai/internal/sim/world"
+	"voxelcraft.ai/internal/sim/world/logic/ids"
+)
+
+type loanFixture struct {
+	h        *Harness
+	lender   string
+	borrower string
+	anchor   world.Vec3i
+	termPos  world.Vec3i
+	termID   string
+}
+
+func newLoanFixture(t *testing.T) loanFixture {
+	t.Helper()
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	h := NewHarness(t, world.WorldConfig{
+		ID:         "test",
+		WorldType:  "OVERWORLD",
+		TickRateHz: 5,
+		DayTicks:   6000,
+		ObsRadius:  7,
+		Height:     1,
+		Seed:       1,
+		BoundaryR:  4000,
+	}, cats, "lender")
+	f := loanFixture{h: h, lender: h.DefaultAgentID}
+	f.borrower = h.Join("borrower")
+	// Deposit multiplier 1, so collateral is exactly what the lender asked for.
+	h.SetAgentReputationFor(f.borrower, 500, 500, 500, 500)
+
+	anchorArr := h.LastObsFor(f.lender).Self.Pos
+	f.anchor = world.Vec3i{X: anchorArr[0], Y: 0, Z: anchorArr[2]}
+	f.termPos = world.Vec3i{X: f.anchor.X + 1, Y: 0, Z: f.anchor.Z}
+	h.SetBlock(f.termPos, "AIR")
+	h.AddInventoryFor(f.lender, "CONTRACT_TERMINAL", 1)
+	obs := h.StepFor(f.lender, nil, []protocol.TaskReq{{
+		ID:       "K_place_term",
+		Type:     "PLACE",
+		ItemID:   "CONTRACT_TERMINAL",
+		BlockPos: f.termPos.ToArray(),
+	}}, nil)
+	if hasTaskFail(obs, "") {
+		t.Fatalf("place terminal failed: events=%v", obs.Events)
+	}
+	f.termID = ids.ContainerID("CONTRACT_TERMINAL", f.termPos.X, f.termPos.Y, f.termPos.Z)
+	h.SetAgentPosFor(f.borrower, f.anchor)
+	h.StepNoop()
+	return f
+}
+
+// postLoan lends 10 IRON_INGOT at 20% over two installments due 20 and 40 ticks after
+// acceptance.
+func (f loanFixture) postLoan(t *testing.T, deposit []protocol.ItemStack) string {
+	t.Helper()
+	f.h.AddInventoryFor(f.lender, "IRON_INGOT", 10)
+	f.h.ClearAgentEventsFor(f.lender)
+	obs := f.h.StepFor(f.lender, []protocol.InstantReq{{
+		ID:            "I_post",
+		Type:          "POST_CONTRACT",
+		TerminalID:    f.termID,
+		ContractKind:  "LOAN",
+		Reward:        []protocol.ItemStack{{Item: "IRON_INGOT", Count: 10}},
+		Deposit:       deposit,
+		InterestPct:   20,
+		Installments:  2,
+		DurationTicks: 40,
+	}}, nil, nil)
+	if got := actionResultCode(obs, "I_post"); got != "" {
+		t.Fatalf("POST_CONTRACT expected ok, got code=%q events=%v", got, obs.Events)
+	}
+	contractID := actionResultFieldString(obs, "I_post", "contract_id")
+	if contractID == "" {
+		t.Fatalf("missing contract_id; events=%v", obs.Events)
+	}
+	return contractID
+}
+
+func (f loanFixture) accept(t *testing.T, contractID string, landID string) protocol.ObsMsg {
+	t.Helper()
+	f.h.ClearAgentEventsFor(f.borrower)
+	return f.h.StepFor(f.borrower, []protocol.InstantReq{{
+		ID:         "I_accept",
+		Type:       "ACCEPT_CONTRACT",
+		TerminalID: f.termID,
+		ContractID: contractID,
+		LandID:     landID,
+	}}, nil, nil)
+}
+
+func (f loanFixture) repayToTerminal(t *testing.T, count int) {
+	t.Helper()
+	f.h.ClearAgentEventsFor(f.borrower)
+	obs := f.h.StepFor(f.borrower, nil, []protocol.TaskReq{{
+		ID:     "K_repay",
+		Type:   "TRANSFER",
+		Src:    "SELF",
+		Dst:    f.termID,
+		ItemID: "IRON_INGOT",
+		Count:  count,
+	}}, nil)
+	if hasTaskFail(obs, "") {
+		t.Fatalf("TRANSFER failed: events=%v", obs.Events)
+	}
+}
+
+// claimLand has the borrower claim land away from the terminal, then walks it back.
+func (f loanFixture) claimLand(t *testing.T) (string, world.Vec3i) {
+	t.Helper()
+	h := f.h
+	landAnchor := world.Vec3i{X: f.anchor.X + 60, Y: 0, Z: f.anchor.Z}
+	clearArea(t, h, landAnchor, 2)
+	h.SetAgentPosFor(f.borrower, landAnchor)
+	h.AddInventoryFor(f.borrower, "BATTERY", 1)
+	h.AddInventoryFor(f.borrower, "CRYSTAL_SHARD", 1)
+	obs := h.StepFor(f.borrower, nil, []protocol.TaskReq{{
+		ID:     "K_claim",
+		Type:   "CLAIM_LAND",
+		Anchor: landAnchor.ToArray(),
+		Radius: 8,
+	}}, nil)
+	landID := actionResultFieldString(obs, "K_claim", "land_id")
+	if landID == "" {
+		t.Fatalf("CLAIM_LAND failed: events=%v", obs.Events)
+	}
+	h.SetAgentPosFor(f.borrower, f.anchor)
+	h.StepNoop()
+	return landID, landAnchor
+}
+
+func (f loanFixture) contract(t *testing.T, contractID string) (snapshot.SnapshotV1, *snapshot.ContractV1) {
+	t.Helper()
+	_, snap := f.h.Snapshot()
+	c := findContract(snap, contractID)
+	if c == nil {
+		t.Fatalf("missing contract %s in snapshot", contractID)
+	}
+	return snap, c
+}
+
+func TestLoan_InstallmentThenDefaultSeizesCollateral(t *testing.T) {
+	f := newLoanFixture(t)
+	h := f.h
+	contractID := f.postLoan(t, []protocol.ItemStack{{Item: "COAL", Count: 5}})
+
+	_, c := f.contract(t, contractID)
+	if c.Requirements["IRON_INGOT"] != 12 || len(c.Installments) != 2 {
+		t.Fatalf("loan terms: requirements=%v installments=%+v", c.Requirements, c.Installments)
+	}
+
+	h.AddInventoryFor(f.borrower, "COAL", 5)
+	if obs := f.accept(t, contractID, ""); actionResultCode(obs, "I_accept") != "" {
+		t.Fatalf("ACCEPT_CONTRACT expected ok, events=%v", obs.Events)
+	}
+	if got := invCount(h.LastObsFor(f.borrower).Inventory, "IRON_INGOT"); got != 10 {
+		t.Fatalf("borrower should receive the principal on accept, got %d", got)
+	}
+	snap, c := f.contract(t, contractID)
+	if c.State != "ACCEPTED" || c.Installments[0].Items["IRON_INGOT"] != 6 || c.DeadlineTick != c.Installments[1].DueTick {
+		t.Fatalf("accepted loan: %+v", c)
+	}
+	baseRepTrade := findAgent(snap, f.borrower).RepTrade
+	baseLenderCoal := invCount(h.LastObsFor(f.lender).Inventory, "COAL")
+
+	// First installment is stocked at the terminal in time and goes to the lender.
+	f.repayToTerminal(t, 6)
+	stepUntilTick(t, h, c.Installments[0].DueTick+1)
+	_, c1 := f.contract(t, contractID)
+	if !c1.Installments[0].Paid || c1.State != "ACCEPTED" {
+		t.Fatalf("first installment not paid: %+v", c1)
+	}
+	if got := invCount(h.LastObsFor(f.lender).Inventory, "IRON_INGOT"); got != 6 {
+		t.Fatalf("lender after first installment: got %d want 6", got)
+	}
+
+	// The second installment is missed: the collateral goes to the lender.
+	stepUntilTick(t, h, c.Installments[1].DueTick+1)
+	snap2, c2 := f.contract(t, contractID)
+	if c2.State != "FAILED" {
+		t.Fatalf("expected FAILED after missed installment, got %q", c2.State)
+	}
+	if got := invCount(h.LastObsFor(f.lender).Inventory, "COAL"); got != baseLenderCoal+5 {
+		t.Fatalf("lender should receive the collateral, got %d->%d COAL", baseLenderCoal, got)
+	}
+	term := findContainerAt(snap2, "CONTRACT_TERMINAL", f.termPos.ToArray())
+	if term == nil || term.Inventory["COAL"] != 0 || term.Reserved["COAL"] != 0 {
+		t.Fatalf("terminal after default: %+v", term)
+	}
+	if got := findAgent(snap2, f.borrower).RepTrade; got >= baseRepTrade {
+		t.Fatalf("expected RepTrade penalty on default, got %d->%d", baseRepTrade, got)
+	}
+}
+
+func TestLoan_EarlyRepaymentReturnsCollateral(t *testing.T) {
+	f := newLoanFixture(t)
+	h := f.h
+	contractID := f.postLoan(t, []protocol.ItemStack{{Item: "COAL", Count: 5}})
+	baseCoal := invCount(h.LastObsFor(f.borrower).Inventory, "COAL")
+	h.AddInventoryFor(f.borrower, "COAL", 5)
+	if obs := f.accept(t, contractID, ""); actionResultCode(obs, "I_accept") != "" {
+		t.Fatalf("ACCEPT_CONTRACT expected ok, events=%v", obs.Events)
+	}
+
+	h.AddInventoryFor(f.borrower, "IRON_INGOT", 2)
+	f.repayToTerminal(t, 12)
+	h.ClearAgentEventsFor(f.borrower)
+	obs := h.StepFor(f.borrower, []protocol.InstantReq{{
+		ID:         "I_submit",
+		Type:       "SUBMIT_CONTRACT",
+		TerminalID: f.termID,
+		ContractID: contractID,
+	}}, nil, nil)
+	if got := actionResultCode(obs, "I_submit"); got != "" {
+		t.Fatalf("SUBMIT_CONTRACT expected ok, got code=%q events=%v", got, obs.Events)
+	}
+	snap, c := f.contract(t, contractID)
+	if c.State != "COMPLETED" {
+		t.Fatalf("expected COMPLETED after early repayment, got %q", c.State)
+	}
+	if got := invCount(h.LastObsFor(f.borrower).Inventory, "COAL"); got != baseCoal+5 {
+		t.Fatalf("collateral should return to the borrower, got %d->%d COAL", baseCoal, got)
+	}
+	term := findContainerAt(snap, "CONTRACT_TERMINAL", f.termPos.ToArray())
+	if term == nil || term.Owed[f.lender]["IRON_INGOT"] != 12 {
+		t.Fatalf("lender should be owed the repayment: %+v", term)
+	}
+}
+
+func TestLoan_LandCollateralIsPledgedAndForfeited(t *testing.T) {
+	f := newLoanFixture(t)
+	h := f.h
+	landID, _ := f.claimLand(t)
+
+	contractID := f.postLoan(t, nil)
+	if obs := f.accept(t, contractID, ""); actionResultCode(obs, "I_accept") != "E_BAD_REQUEST" {
+		t.Fatalf("accept without collateral should fail, events=%v", obs.Events)
+	}
+	if obs := f.accept(t, contractID, landID); actionResultCode(obs, "I_accept") != "" {
+		t.Fatalf("ACCEPT_CONTRACT with land expected ok, events=%v", obs.Events)
+	}
+	_, c := f.contract(t, contractID)
+	if c.CollateralLand != landID {
+		t.Fatalf("collateral land: got %q want %q", c.CollateralLand, landID)
+	}
+
+	// Pledged land cannot be deeded away.
+	h.ClearAgentEventsFor(f.borrower)
+	obs := h.StepFor(f.borrower, []protocol.InstantReq{{
+		ID:       "I_deed",
+		Type:     "DEED_LAND",
+		LandID:   landID,
+		NewOwner: f.borrower,
+	}}, nil, nil)
+	if got := actionResultCode(obs, "I_deed"); got != "E_CONFLICT" {
+		t.Fatalf("DEED_LAND of pledged land expected E_CONFLICT, got %q events=%v", got, obs.Events)
+	}
+
+	stepUntilTick(t, h, c.Installments[0].DueTick+1)
+	snap, c := f.contract(t, contractID)
+	if c.State != "FAILED" {
+		t.Fatalf("expected FAILED after missed installment, got %q", c.State)
+	}
+	for _, land := range snap.Claims {
+		if land.LandID == landID && land.Owner != f.lender {
+			t.Fatalf("land should be deeded to the lender, owner=%q", land.Owner)
+		}
+	}
+}
+
+func TestLoan_PledgedClaimTotemCannotBeMined(t *testing.T) {
+	f := newLoanFixture(t)
+	h := f.h
+	landID, landAnchor := f.claimLand(t)
+
+	contractID := f.postLoan(t, nil)
+	if obs := f.accept(t, contractID, landID); actionResultCode(obs, "I_accept") != "" {
+		t.Fatalf("ACCEPT_CONTRACT with land expected ok, events=%v", obs.Events)
+	}
+
+	h.SetAgentPosFor(f.borrower, world.Vec3i{X: landAnchor.X + 1, Y: 0, Z: landAnchor.Z})
+	h.ClearAgentEventsFor(f.borrower)
+	obs := h.StepFor(f.borrower, nil, []protocol.TaskReq{{
+		ID:       "K_mine",
+		Type:     "MINE",
+		BlockPos: landAnchor.ToArray(),
+	}}, nil)
+	for i := 0; i < 100 && !hasTaskFail(obs, ""); i++ {
+		obs = h.StepFor(f.borrower, nil, nil, nil)
+	}
+	if !hasTaskFail(obs, "E_CONFLICT") {
+		t.Fatalf("mining a pledged claim totem expected E_CONFLICT, events=%v", obs.Events)
+	}
+
+	_, c := f.contract(t, contractID)
+	stepUntilTick(t, h, c.Installments[0].DueTick+1)
+	snap, c := f.contract(t, contractID)
+	if c.State != "FAILED" {
+		t.Fatalf("expected FAILED after missed installment, got %q", c.State)
+	}
+	found := false
+	for _, land := range snap.Claims {
+		if land.LandID == landID {
+			found = true
+			if land.Owner != f.lender {
+				t.Fatalf("land should be deeded to the lender, owner=%q", land.Owner)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("pledged claim %s was removed", landID)
+	}
+}
@@ -0,0 +1,103 @@
+package worldtest
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	world "voxelcraft.ai/internal/sim/world"
+)
+
+func TestMoveTo_PathAroundLongWall(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	h := NewHarness(t, world.WorldConfig{ID: "test", Seed: 42}, cats, "bot")
+
+	start := world.Vec3i{X: 10, Y: 0, Z: 10}
+	target := world.Vec3i{X: 16, Y: 0, Z: 10}
+	clearArea(t, h, world.Vec3i{X: 13, Y: 0, Z: 10}, 14)
+	// A wall 25 tiles long, far beyond the reach of a greedy step + short detour.
+	for z := start.Z - 12; z <= start.Z+12; z++ {
+		h.SetBlock(world.Vec3i{X: start.X + 3, Y: 0, Z: z}, "STONE")
+	}
+	h.SetAgentPos(start)
+
+	h.Step(nil, []protocol.TaskReq{{ID: "K1", Type: "MOVE_TO", Target: target.ToArray(), Tolerance: 1}}, nil)
+	for i := 0; i < 80; i++ {
+		obs := h.StepNoop()
+		if hasTaskFail(obs, "") {
+			t.Fatalf("unexpected TASK_FAIL: events=%v", obs.Events)
+		}
+		if !hasMoveTask(obs) {
+			break
+		}
+	}
+	obs := h.LastObs()
+	if hasMoveTask(obs) {
+		t.Fatalf("expected MOVE_TO to complete; pos=%v", obs.Self.Pos)
+	}
+	pos := world.Vec3i{X: obs.Self.Pos[0], Y: 0, Z: obs.Self.Pos[2]}
+	if world.Manhattan(pos, target) > 1 {
+		t.Fatalf("pos=%v want within 1 of %v", obs.Self.Pos, target)
+	}
+}
+
+func TestMoveTo_NoPathFailsCleanly(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	h := NewHarness(t, world.WorldConfig{ID: "test", Seed: 42}, cats, "bot")
+
+	start := world.Vec3i{X: 10, Y: 0, Z: 10}
+	h.SetAgentPos(start)
+	h.SetBlock(start, "AIR")
+	for _, d := range []world.Vec3i{{X: 1}, {X: -1}, {Z: 1}, {Z: -1}} {
+		h.SetBlock(world.Vec3i{X: start.X + d.X, Y: 0, Z: start.Z + d.Z}, "STONE")
+	}
+
+	h.ClearAgentEvents()
+	obs := h.Step(nil, []protocol.TaskReq{{ID: "K1", Type: "MOVE_TO", Target: [3]int{start.X + 8, 0, start.Z}, Tolerance: 1}}, nil)
+	if !hasTaskFail(obs, "E_NO_PATH") {
+		t.Fatalf("expected TASK_FAIL E_NO_PATH; events=%v", obs.Events)
+	}
+	if hasMoveTask(obs) {
+		t.Fatalf("expected move task cleared after E_NO_PATH")
+	}
+	if got := obs.Self.Pos; got != start.ToArray() {
+		t.Fatalf("pos=%v want unchanged %v", got, start.ToArray())
+	}
+}
+
+func TestMoveTo_ReplansWhenBlockPlacedOnPath(t *testing.T) {
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	h := NewHarness(t, world.WorldConfig{ID: "test", Seed: 42}, cats, "bot")
+
+	start := world.Vec3i{X: 10, Y: 0, Z: 10}
+	clearArea(t, h, world.Vec3i{X: 14, Y: 0, Z: 10}, 6)
+	h.SetAgentPos(start)
+
+	h.Step(nil, []protocol.TaskReq{{ID: "K1", Type: "MOVE_TO", Target: [3]int{start.X + 8, 0, start.Z}, Tolerance: 1}}, nil)
+	// Drop a wall across the straight route after the path was planned.
+	for z := start.Z - 2; z <= start.Z+2; z++ {
+		h.SetBlock(world.Vec3i{X: start.X + 4, Y: 0, Z: z}, "STONE")
+	}
+	for i := 0; i < 40 && hasMoveTask(h.LastObs()); i++ {
+		obs := h.StepNoop()
+		if hasTaskFail(obs, "") {
+			t.Fatalf("unexpected TASK_FAIL: events=%v", obs.Events)
+		}
+	}
+	obs := h.LastObs()
+	if hasMoveTask(obs) {
+		t.Fatalf("expected MOVE_TO to complete; pos=%v", obs.Self.Pos)
+	}
+	if got := obs.Self.Pos[0]; got < start.X+7 {
+		t.Fatalf("expected agent past the wall; pos=%v", obs.Self.Pos)
+	}
+}
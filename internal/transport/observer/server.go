@@ -58,8 +58,8 @@ func (s *Server) BootstrapHandler() http.HandlerFunc {
 			Tick:            s.world.CurrentTick(),
 			WorldParams: observerproto.WorldParams{
 				TickRateHz: cfg.TickRateHz,
-				ChunkSize:  [3]int{16, 16, 1},
-				Height:     1,
+				ChunkSize:  [3]int{16, 16, cfg.Height},
+				Height:     cfg.Height,
 				Seed:       cfg.Seed,
 				BoundaryR:  cfg.BoundaryR,
 			},
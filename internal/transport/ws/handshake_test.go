@@ -0,0 +1,121 @@
+package ws
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/sim/catalogs"
+	"voxelcraft.ai/internal/sim/world"
+	"voxelcraft.ai/internal/transport/wsauth"
+)
+
+var testSecret = []byte("handshake-secret")
+
+func startAuthedServer(t *testing.T, bindings *wsauth.Bindings) (string, *world.World) {
+	t.Helper()
+	cats, err := catalogs.Load("../../../configs")
+	if err != nil {
+		t.Fatalf("load catalogs: %v", err)
+	}
+	w, err := world.New(world.WorldConfig{ID: "ws", TickRateHz: 20, DayTicks: 6000, ObsRadius: 7, Height: 1, Seed: 7, BoundaryR: 4000}, cats)
+	if err != nil {
+		t.Fatalf("world: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = w.Run(ctx) }()
+
+	s := NewServer(w, nil)
+	s.SetAuth(wsauth.HMACTokens{Secret: testSecret}, bindings)
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return "ws" + strings.TrimPrefix(ts.URL, "http"), w
+}
+
+// hello dials, sends a HELLO with a token for sub and returns the WELCOME (or the close error).
+func hello(t *testing.T, url, sub string) (protocol.WelcomeMsg, error) {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	now := time.Now()
+	tok, err := wsauth.SignToken(testSecret, wsauth.TokenClaims{Sub: sub, Iat: now.Unix(), Exp: now.Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.WriteJSON(protocol.HelloMsg{
+		Type:            protocol.TypeHello,
+		ProtocolVersion: protocol.Version,
+		AgentName:       sub,
+		Auth:            &protocol.HelloAuth{Bearer: tok},
+	}); err != nil {
+		t.Fatalf("write hello: %v", err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var welcome protocol.WelcomeMsg
+	err = conn.ReadJSON(&welcome)
+	return welcome, err
+}
+
+func TestHandshake_FreshJoinSkipsIDBoundToAnotherToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bindings.json")
+	if err := os.WriteFile(path, []byte(`{"bindings":{"tok:alice":"A1"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bindings, err := wsauth.OpenBindings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url, _ := startAuthedServer(t, bindings)
+
+	// bob has no binding; the next sequential id (A1) belongs to alice.
+	bob, err := hello(t, url, "bob")
+	if err != nil {
+		t.Fatalf("bob: %v", err)
+	}
+	if bob.AgentID == "A1" || bob.AgentID == "" {
+		t.Fatalf("bob got %q, alice's bound id", bob.AgentID)
+	}
+	alice, err := hello(t, url, "alice")
+	if err != nil {
+		t.Fatalf("alice: %v", err)
+	}
+	if alice.AgentID != "A1" {
+		t.Fatalf("alice got %q, want A1", alice.AgentID)
+	}
+	if bindings.AgentID("tok:bob") != bob.AgentID {
+		t.Fatalf("bob bound to %q, want %q", bindings.AgentID("tok:bob"), bob.AgentID)
+	}
+}
+
+func TestHandshake_BindFailureRejectsHello(t *testing.T) {
+	// Once opened, the bindings directory is replaced by a regular file, so persisting
+	// the fresh binding fails.
+	dir := filepath.Join(t.TempDir(), "state")
+	bindings, err := wsauth.OpenBindings(filepath.Join(dir, "bindings.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dir, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	url, _ := startAuthedServer(t, bindings)
+
+	_, err = hello(t, url, "alice")
+	if !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+		t.Fatalf("want policy-violation close, got %v", err)
+	}
+	if id := bindings.AgentID("tok:alice"); id != "" {
+		t.Fatalf("failed bind left alice bound to %s", id)
+	}
+}
@@ -0,0 +1,26 @@
+package ws
+
+import (
+	"testing"
+
+	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/protocol/cbor"
+)
+
+func TestAdaptOutboundCBOR_CurrentVersionPassesThrough(t *testing.T) {
+	raw, err := cbor.Marshal(map[string]any{"type": "OBS", "protocol_version": "1.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := adaptOutboundProtocolVersion(raw, protocol.Version, protocol.EncodingCBOR); &got[0] != &raw[0] {
+		t.Fatalf("current-version frame was re-encoded")
+	}
+	got := adaptOutboundProtocolVersion(raw, "1.2", protocol.EncodingCBOR)
+	v, err := cbor.Unmarshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := v.(map[string]any); m["protocol_version"] != "1.2" {
+		t.Fatalf("version not rewritten: %v", v)
+	}
+}
@@ -20,8 +20,10 @@ import (
 	"github.com/gorilla/websocket"
 
 	"voxelcraft.ai/internal/protocol"
+	"voxelcraft.ai/internal/protocol/cbor"
 	"voxelcraft.ai/internal/sim/multiworld"
 	"voxelcraft.ai/internal/sim/world"
+	"voxelcraft.ai/internal/transport/wsauth"
 )
 
 type Server struct {
@@ -29,6 +31,11 @@ type Server struct {
 	manager *multiworld.Manager
 	log     *log.Logger
 
+	// Optional HELLO authentication. When set, every HELLO must authenticate and the
+	// identity is bound to a stable agent id (resume tokens alone are not trusted).
+	auth     wsauth.Authenticator
+	bindings *wsauth.Bindings
+
 	upgrader websocket.Upgrader
 }
 
@@ -58,11 +65,19 @@ func NewManagedServer(m *multiworld.Manager, logger *log.Logger) *Server {
 	return s
 }
 
+// SetAuth enables HELLO authentication. bindings may be nil (no cross-restart identity binding).
+func (s *Server) SetAuth(a wsauth.Authenticator, bindings *wsauth.Bindings) {
+	s.auth = a
+	s.bindings = bindings
+}
+
 type connSession struct {
 	AgentID         string
 	WorldID         string
 	Out             chan []byte
 	Delta           bool
+	Encoding        string
+	ObsDelta        bool
 	ProtocolVersion string
 	SessionID       string
 	mu              sync.Mutex
@@ -95,9 +110,9 @@ func (s *Server) Handler() http.HandlerFunc {
 					if !ok {
 						return
 					}
-					b = adaptOutboundProtocolVersion(b, sess.ProtocolVersion)
+					b = adaptOutboundProtocolVersion(b, sess.ProtocolVersion, sess.Encoding)
 					_ = conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
-					if err := conn.WriteMessage(websocket.TextMessage, b); err != nil {
+					if err := conn.WriteMessage(frameType(sess.Encoding), b); err != nil {
 						cancel()
 						return
 					}
@@ -108,11 +123,17 @@ func (s *Server) Handler() http.HandlerFunc {
 		// Reader loop.
 		for {
 			_ = conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-			_, msg, err := conn.ReadMessage()
+			mt, msg, err := conn.ReadMessage()
 			if err != nil {
 				cancel()
 				break
 			}
+			if mt == websocket.BinaryMessage {
+				// Binary frames carry CBOR; normalize so the JSON decoding paths below apply.
+				if msg, err = protocol.ToJSON(protocol.EncodingCBOR, msg); err != nil {
+					continue
+				}
+			}
 			base, err := protocol.DecodeBase(msg)
 			if err != nil {
 				continue
@@ -128,13 +149,24 @@ func (s *Server) Handler() http.HandlerFunc {
 				s.handleEventBatchReq(ctx, &sess, req)
 				continue
 			}
+			if base.Type == protocol.TypeObsAck || base.Type == protocol.TypeObsResync {
+				if !sess.ObsDelta {
+					continue
+				}
+				var ack protocol.ObsAckMsg
+				if err := json.Unmarshal(msg, &ack); err != nil {
+					continue
+				}
+				s.forwardObsAck(ctx, &sess, ack.ObsID, base.Type == protocol.TypeObsResync)
+				continue
+			}
 			if base.Type != protocol.TypeAct {
 				continue
 			}
 			var act protocol.ActMsg
 			if err := json.Unmarshal(msg, &act); err != nil {
 				if sess.ProtocolVersion == "1.1" {
-					sendAckToOut(sess.Out, protocol.AckMsg{
+					sendAckToOut(sess.Out, sess.Encoding, protocol.AckMsg{
 						Type:            protocol.TypeAck,
 						ProtocolVersion: sess.ProtocolVersion,
 						AckFor:          act.ActID,
@@ -149,7 +181,7 @@ func (s *Server) Handler() http.HandlerFunc {
 			}
 			if !protocol.IsSupportedVersion(act.ProtocolVersion) {
 				if sess.ProtocolVersion == "1.1" {
-					sendAckToOut(sess.Out, protocol.AckMsg{
+					sendAckToOut(sess.Out, sess.Encoding, protocol.AckMsg{
 						Type:            protocol.TypeAck,
 						ProtocolVersion: sess.ProtocolVersion,
 						AckFor:          act.ActID,
@@ -164,7 +196,7 @@ func (s *Server) Handler() http.HandlerFunc {
 			}
 			if sess.ProtocolVersion == "1.1" {
 				if strings.TrimSpace(act.ActID) == "" {
-					sendAckToOut(sess.Out, protocol.AckMsg{
+					sendAckToOut(sess.Out, sess.Encoding, protocol.AckMsg{
 						Type:            protocol.TypeAck,
 						ProtocolVersion: sess.ProtocolVersion,
 						AckFor:          "",
@@ -177,7 +209,7 @@ func (s *Server) Handler() http.HandlerFunc {
 					continue
 				}
 				if strings.TrimSpace(act.BasedOnObsID) == "" {
-					sendAckToOut(sess.Out, protocol.AckMsg{
+					sendAckToOut(sess.Out, sess.Encoding, protocol.AckMsg{
 						Type:            protocol.TypeAck,
 						ProtocolVersion: sess.ProtocolVersion,
 						AckFor:          act.ActID,
@@ -190,7 +222,7 @@ func (s *Server) Handler() http.HandlerFunc {
 					continue
 				}
 				if isMutatingAct(act) && strings.TrimSpace(act.ExpectedWorldID) == "" {
-					sendAckToOut(sess.Out, protocol.AckMsg{
+					sendAckToOut(sess.Out, sess.Encoding, protocol.AckMsg{
 						Type:            protocol.TypeAck,
 						ProtocolVersion: sess.ProtocolVersion,
 						AckFor:          act.ActID,
@@ -203,7 +235,7 @@ func (s *Server) Handler() http.HandlerFunc {
 					continue
 				}
 				if cached, ok := sess.lookupAck(act.ActID); ok {
-					sendAckToOut(sess.Out, cached)
+					sendAckToOut(sess.Out, sess.Encoding, cached)
 					continue
 				}
 				dedupeWorldID := strings.TrimSpace(act.ExpectedWorldID)
@@ -221,19 +253,20 @@ func (s *Server) Handler() http.HandlerFunc {
 				if remembered, duplicate, err := s.checkOrRememberWorldActAck(ctx, dedupeWorldID, sess.AgentID, act.ActID, ack); err == nil {
 					if duplicate {
 						sess.rememberAck(act.ActID, remembered)
-						sendAckToOut(sess.Out, remembered)
+						sendAckToOut(sess.Out, sess.Encoding, remembered)
 						continue
 					}
 					ack = remembered
 				}
 				sess.rememberAck(act.ActID, ack)
-				sendAckToOut(sess.Out, ack)
+				sendAckToOut(sess.Out, sess.Encoding, ack)
 			}
 			if s.manager != nil {
 				nextWorld, _ := s.manager.RouteAct(ctx, &multiworld.Session{
 					AgentID:      sess.AgentID,
 					CurrentWorld: sess.WorldID,
 					DeltaVoxels:  sess.Delta,
+					Encoding:     sess.Encoding,
 					Out:          sess.Out,
 				}, act)
 				if nextWorld != "" {
@@ -250,6 +283,7 @@ func (s *Server) Handler() http.HandlerFunc {
 				AgentID:      sess.AgentID,
 				CurrentWorld: sess.WorldID,
 				DeltaVoxels:  sess.Delta,
+				Encoding:     sess.Encoding,
 				Out:          sess.Out,
 			})
 		} else {
@@ -280,9 +314,39 @@ func (s *Server) handshake(conn *websocket.Conn) (connSession, bool) {
 		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "bad protocol_version"), time.Now().Add(time.Second))
 		return connSession{}, false
 	}
+
+	// Authenticate before touching any world state.
+	var (
+		ident   wsauth.Identity
+		authed  bool
+		boundID string
+	)
+	if s.auth != nil {
+		id, err := s.auth.Authenticate(hello.Auth, time.Now())
+		if err != nil {
+			if s.log != nil {
+				s.log.Printf("ws: HELLO rejected (%s): %v", hello.AgentName, err)
+			}
+			_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, protocol.ErrNoPermission+": "+err.Error()), time.Now().Add(time.Second))
+			return connSession{}, false
+		}
+		ident = id
+		authed = true
+		if id.Name != "" {
+			hello.AgentName = id.Name
+		}
+		if s.bindings != nil {
+			boundID = s.bindings.AgentID(id.Subject)
+		}
+	}
 	if hello.AgentName == "" {
 		hello.AgentName = "agent"
 	}
+	// Binary encodings are a 1.1 capability; older sessions stay on JSON.
+	encoding := protocol.EncodingJSON
+	if selectedVersion == "1.1" {
+		encoding = protocol.SelectEncoding(hello.ClientCapabilities.Encodings)
+	}
 
 	maxQ := hello.Capabilities.MaxQueue
 	if maxQ <= 0 {
@@ -295,38 +359,55 @@ func (s *Server) handshake(conn *websocket.Conn) (connSession, bool) {
 
 	// Optional: resume an existing agent (reconnect).
 	resumeToken := ""
-	if hello.Auth != nil {
+	if hello.Auth != nil && !authed {
 		resumeToken = strings.TrimSpace(hello.Auth.Token)
 	}
 
+	// A fresh join only reserves the agent id in the world; the binding is persisted here, off
+	// the world goroutine, and a failed write detaches the new agent again.
+	bindFresh := authed && s.bindings != nil
+
 	if s.manager != nil {
 		var (
 			resp world.JoinResponse
 			sess multiworld.Session
 		)
-		if resumeToken != "" {
-			ss, rr, err := s.manager.Attach(resumeToken, hello.Capabilities.DeltaVoxels, out)
+		if boundID != "" {
+			ss, rr, err := s.manager.AttachAgent(boundID, hello.Capabilities.DeltaVoxels, encoding, out)
+			if err == nil {
+				sess = ss
+				resp = rr
+			}
+		} else if resumeToken != "" {
+			ss, rr, err := s.manager.Attach(resumeToken, hello.Capabilities.DeltaVoxels, encoding, out)
 			if err == nil {
 				sess = ss
 				resp = rr
 			}
 		}
 		if resp.Welcome.AgentID == "" {
-			ss, rr, err := s.manager.Join(hello.AgentName, hello.Capabilities.DeltaVoxels, out, hello.WorldPreference)
+			ss, rr, err := s.manager.JoinAs(boundID, s.reservedIDs(ident), hello.AgentName, hello.Capabilities.DeltaVoxels, encoding, out, hello.WorldPreference)
 			if err != nil {
 				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "join failed"), time.Now().Add(time.Second))
 				return connSession{}, false
 			}
+			if bindFresh {
+				if err := s.bindIdentity(ident, ss.AgentID); err != nil {
+					s.manager.Leave(ss)
+					s.rejectBind(conn, ident, err)
+					return connSession{}, false
+				}
+			}
 			sess = ss
 			resp = rr
 		}
-		applyWelcomeVersion(&resp.Welcome, selectedVersion)
+		applyWelcomeVersion(&resp.Welcome, selectedVersion, encoding)
 		if err := writeJSON(conn, resp.Welcome); err != nil {
 			return connSession{}, false
 		}
 		for _, c := range resp.Catalogs {
 			c.ProtocolVersion = selectedVersion
-			if err := writeJSON(conn, c); err != nil {
+			if err := writeMessage(conn, encoding, c); err != nil {
 				return connSession{}, false
 			}
 		}
@@ -335,6 +416,8 @@ func (s *Server) handshake(conn *websocket.Conn) (connSession, bool) {
 			WorldID:         sess.CurrentWorld,
 			Out:             out,
 			Delta:           hello.Capabilities.DeltaVoxels,
+			Encoding:        encoding,
+			ObsDelta:        selectedVersion == "1.1" && hello.ClientCapabilities.ObsDelta,
 			ProtocolVersion: selectedVersion,
 			SessionID:       resp.Welcome.SessionID,
 			acksByActID:     map[string]protocol.AckMsg{},
@@ -342,11 +425,13 @@ func (s *Server) handshake(conn *websocket.Conn) (connSession, bool) {
 	}
 
 	var resp world.JoinResponse
-	if resumeToken != "" {
+	if boundID != "" || resumeToken != "" {
 		respCh := make(chan world.JoinResponse, 1)
 		s.world.Attach() <- world.AttachRequest{
 			ResumeToken: resumeToken,
+			AgentID:     boundID,
 			DeltaVoxels: hello.Capabilities.DeltaVoxels,
+			Encoding:    encoding,
 			Out:         out,
 			Resp:        respCh,
 		}
@@ -357,21 +442,31 @@ func (s *Server) handshake(conn *websocket.Conn) (connSession, bool) {
 		respCh := make(chan world.JoinResponse, 1)
 		s.world.Join() <- world.JoinRequest{
 			Name:        hello.AgentName,
+			AgentID:     boundID,
+			Reserved:    s.reservedIDs(ident),
 			DeltaVoxels: hello.Capabilities.DeltaVoxels,
+			Encoding:    encoding,
 			Out:         out,
 			Resp:        respCh,
 		}
 		resp = <-respCh
+		if bindFresh && resp.Welcome.AgentID != "" {
+			if err := s.bindIdentity(ident, resp.Welcome.AgentID); err != nil {
+				s.world.Leave() <- resp.Welcome.AgentID
+				s.rejectBind(conn, ident, err)
+				return connSession{}, false
+			}
+		}
 	}
 
 	// Send welcome + catalogs immediately.
-	applyWelcomeVersion(&resp.Welcome, selectedVersion)
+	applyWelcomeVersion(&resp.Welcome, selectedVersion, encoding)
 	if err := writeJSON(conn, resp.Welcome); err != nil {
 		return connSession{}, false
 	}
 	for _, c := range resp.Catalogs {
 		c.ProtocolVersion = selectedVersion
-		if err := writeJSON(conn, c); err != nil {
+		if err := writeMessage(conn, encoding, c); err != nil {
 			return connSession{}, false
 		}
 	}
@@ -381,12 +476,37 @@ func (s *Server) handshake(conn *websocket.Conn) (connSession, bool) {
 		WorldID:         resp.Welcome.CurrentWorldID,
 		Out:             out,
 		Delta:           hello.Capabilities.DeltaVoxels,
+		Encoding:        encoding,
+		ObsDelta:        selectedVersion == "1.1" && hello.ClientCapabilities.ObsDelta,
 		ProtocolVersion: selectedVersion,
 		SessionID:       resp.Welcome.SessionID,
 		acksByActID:     map[string]protocol.AckMsg{},
 	}, true
 }
 
+func (s *Server) bindIdentity(ident wsauth.Identity, agentID string) error {
+	if s.bindings == nil || agentID == "" {
+		return nil
+	}
+	return s.bindings.Bind(ident.Subject, agentID)
+}
+
+// reservedIDs keeps fresh joins off agent ids bound to other identities.
+func (s *Server) reservedIDs(ident wsauth.Identity) func(agentID string) bool {
+	if s.bindings == nil {
+		return nil
+	}
+	b := s.bindings
+	return func(agentID string) bool { return b.BoundElsewhere(agentID, ident.Subject) }
+}
+
+func (s *Server) rejectBind(conn *websocket.Conn, ident wsauth.Identity, err error) {
+	if s.log != nil {
+		s.log.Printf("ws: HELLO rejected, bind %s: %v", ident.Subject, err)
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, protocol.ErrConflict+": "+err.Error()), time.Now().Add(time.Second))
+}
+
 func (s *Server) currentTick(worldID string) uint64 {
 	if s.manager != nil {
 		if rt := s.manager.Runtime(worldID); rt != nil && rt.World != nil {
@@ -404,14 +524,14 @@ func isMutatingAct(act protocol.ActMsg) bool {
 	return len(act.Instants) > 0 || len(act.Tasks) > 0 || len(act.Cancel) > 0
 }
 
-func sendAckToOut(out chan []byte, ack protocol.AckMsg) {
+func sendAckToOut(out chan []byte, encoding string, ack protocol.AckMsg) {
 	if !protocol.IsKnownCode(ack.Code) {
 		ack.Code = protocol.ErrInternal
 		if ack.Message == "" {
 			ack.Message = "unknown error code"
 		}
 	}
-	b, err := json.Marshal(ack)
+	b, err := protocol.Marshal(encoding, ack)
 	if err != nil {
 		return
 	}
@@ -494,20 +614,41 @@ func (s *Server) handleEventBatchReq(ctx context.Context, sess *connSession, req
 		}
 		res.NextCursor = next
 	}
-	b, mErr := json.Marshal(res)
+	b, mErr := protocol.Marshal(sess.Encoding, res)
 	if mErr != nil {
 		return
 	}
 	sendLatestBytes(sess.Out, b)
 }
 
-func applyWelcomeVersion(w *protocol.WelcomeMsg, selectedVersion string) {
+func (s *Server) forwardObsAck(ctx context.Context, sess *connSession, obsID string, resync bool) {
+	reqCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if s.manager != nil {
+		if rt := s.manager.Runtime(sess.WorldID); rt != nil && rt.World != nil {
+			_ = rt.World.RequestObsAck(reqCtx, sess.AgentID, obsID, resync)
+		}
+		return
+	}
+	if s.world != nil {
+		_ = s.world.RequestObsAck(reqCtx, sess.AgentID, obsID, resync)
+	}
+}
+
+func applyWelcomeVersion(w *protocol.WelcomeMsg, selectedVersion, encoding string) {
 	if w == nil {
 		return
 	}
 	w.ProtocolVersion = selectedVersion
 	w.SelectedVersion = selectedVersion
-	w.ServerCapabilities = protocol.ServerCapabilities{Ack: true, EventBatch: true, Idempotency: true}
+	w.ServerCapabilities = protocol.ServerCapabilities{
+		Ack:         true,
+		EventBatch:  true,
+		Idempotency: true,
+		Encodings:   protocol.ServerEncodings,
+		Encoding:    encoding,
+		ObsDelta:    true,
+	}
 	if w.SessionID == "" {
 		w.SessionID = newSessionID()
 	}
@@ -521,10 +662,13 @@ func newSessionID() string {
 	return "sess_" + hex.EncodeToString(b[:])
 }
 
-func adaptOutboundProtocolVersion(raw []byte, selectedVersion string) []byte {
+func adaptOutboundProtocolVersion(raw []byte, selectedVersion, encoding string) []byte {
 	if selectedVersion == "" || len(raw) == 0 {
 		return raw
 	}
+	if encoding == protocol.EncodingCBOR {
+		return adaptOutboundCBOR(raw, selectedVersion)
+	}
 	base, err := protocol.DecodeBase(raw)
 	if err != nil {
 		return raw
@@ -544,6 +688,29 @@ func adaptOutboundProtocolVersion(raw []byte, selectedVersion string) []byte {
 	return b
 }
 
+// adaptOutboundCBOR is the CBOR counterpart of adaptOutboundProtocolVersion. CBOR is only
+// negotiated by current-version sessions, whose frames the world already stamps with
+// protocol.Version, so those pass through without a decode/re-encode.
+func adaptOutboundCBOR(raw []byte, selectedVersion string) []byte {
+	if selectedVersion == protocol.Version {
+		return raw
+	}
+	v, err := cbor.Unmarshal(raw)
+	if err != nil {
+		return raw
+	}
+	m, ok := v.(map[string]any)
+	if !ok || m["protocol_version"] == selectedVersion {
+		return raw
+	}
+	m["protocol_version"] = selectedVersion
+	b, err := cbor.Marshal(m)
+	if err != nil {
+		return raw
+	}
+	return b
+}
+
 func (s *connSession) lookupAck(actID string) (protocol.AckMsg, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -583,6 +750,27 @@ func sendLatestBytes(ch chan []byte, b []byte) {
 	}
 }
 
+func frameType(encoding string) int {
+	if protocol.IsBinaryEncoding(encoding) {
+		return websocket.BinaryMessage
+	}
+	return websocket.TextMessage
+}
+
+// writeMessage writes v in the session encoding (WELCOME always goes through writeJSON
+// so clients can read the negotiated encoding before switching).
+func writeMessage(conn *websocket.Conn, encoding string, v any) error {
+	if !protocol.IsBinaryEncoding(encoding) {
+		return writeJSON(conn, v)
+	}
+	b, err := protocol.Marshal(encoding, v)
+	if err != nil {
+		return err
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	return conn.WriteMessage(websocket.BinaryMessage, b)
+}
+
 func writeJSON(conn *websocket.Conn, v any) error {
 	b, err := json.Marshal(v)
 	if err != nil {
@@ -0,0 +1,131 @@
+package wsauth
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"voxelcraft.ai/internal/protocol"
+)
+
+const accountHashIter = 100_000
+
+type accountEntry struct {
+	Account string `json:"account"`
+	Name    string `json:"name,omitempty"`
+	Salt    string `json:"salt"`
+	Hash    string `json:"hash"`
+	Iter    int    `json:"iter"`
+}
+
+type accountFile struct {
+	Accounts []accountEntry `json:"accounts"`
+}
+
+// Accounts is a local file-backed account store (PBKDF2-SHA256 password hashes).
+type Accounts struct {
+	path string
+
+	mu       sync.RWMutex
+	accounts map[string]accountEntry
+}
+
+// OpenAccounts loads the store; a missing file is an empty store.
+func OpenAccounts(path string) (*Accounts, error) {
+	s := &Accounts{path: path, accounts: map[string]accountEntry{}}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	var f accountFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("parse account file: %w", err)
+	}
+	for _, e := range f.Accounts {
+		if strings.TrimSpace(e.Account) == "" {
+			continue
+		}
+		s.accounts[e.Account] = e
+	}
+	return s, nil
+}
+
+// SetPassword creates or updates an account and persists the store. An empty name
+// keeps the account's current display name.
+func (s *Accounts) SetPassword(account, name, password string) error {
+	account = strings.TrimSpace(account)
+	if account == "" || password == "" {
+		return fmt.Errorf("missing account/password")
+	}
+	var salt [16]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return err
+	}
+	hash, err := pbkdf2.Key(sha256.New, password, salt[:], accountHashIter, sha256.Size)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = s.accounts[account].Name
+	}
+	s.accounts[account] = accountEntry{
+		Account: account,
+		Name:    name,
+		Salt:    hex.EncodeToString(salt[:]),
+		Hash:    hex.EncodeToString(hash),
+		Iter:    accountHashIter,
+	}
+	return s.saveLocked()
+}
+
+func (s *Accounts) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	f := accountFile{Accounts: make([]accountEntry, 0, len(s.accounts))}
+	for _, id := range sortedKeys(s.accounts) {
+		f.Accounts = append(f.Accounts, s.accounts[id])
+	}
+	return writeJSONAtomic(s.path, f)
+}
+
+func (s *Accounts) Authenticate(auth *protocol.HelloAuth, _ time.Time) (Identity, error) {
+	if auth == nil || strings.TrimSpace(auth.Account) == "" {
+		return Identity{}, ErrNoCredentials
+	}
+	account := strings.TrimSpace(auth.Account)
+	s.mu.RLock()
+	e, ok := s.accounts[account]
+	s.mu.RUnlock()
+	if !ok || auth.Password == "" {
+		return Identity{}, ErrBadCredentials
+	}
+	salt, err1 := hex.DecodeString(e.Salt)
+	want, err2 := hex.DecodeString(e.Hash)
+	if err1 != nil || err2 != nil || e.Iter <= 0 {
+		return Identity{}, ErrBadCredentials
+	}
+	got, err := pbkdf2.Key(sha256.New, auth.Password, salt, e.Iter, len(want))
+	if err != nil || subtle.ConstantTimeCompare(got, want) != 1 {
+		return Identity{}, ErrBadCredentials
+	}
+	name := e.Name
+	if name == "" {
+		name = e.Account
+	}
+	return Identity{Subject: "acct:" + e.Account, Name: name}, nil
+}
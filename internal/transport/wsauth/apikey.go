@@ -0,0 +1,65 @@
+package wsauth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"voxelcraft.ai/internal/protocol"
+)
+
+type apiKeyEntry struct {
+	Subject   string `json:"subject"`
+	Name      string `json:"name,omitempty"`
+	KeySHA256 string `json:"key_sha256"`
+}
+
+type apiKeyFile struct {
+	Keys []apiKeyEntry `json:"keys"`
+}
+
+// APIKeys is a static key file. Only SHA-256 digests of keys are stored on disk.
+type APIKeys struct {
+	byDigest map[string]apiKeyEntry
+}
+
+func LoadAPIKeys(path string) (*APIKeys, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f apiKeyFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("parse api key file: %w", err)
+	}
+	k := &APIKeys{byDigest: map[string]apiKeyEntry{}}
+	for _, e := range f.Keys {
+		e.Subject = strings.TrimSpace(e.Subject)
+		digest := strings.ToLower(strings.TrimSpace(e.KeySHA256))
+		if e.Subject == "" || len(digest) != sha256.Size*2 {
+			return nil, fmt.Errorf("api key file: bad entry for subject %q", e.Subject)
+		}
+		k.byDigest[digest] = e
+	}
+	return k, nil
+}
+
+func KeyDigest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *APIKeys) Authenticate(auth *protocol.HelloAuth, _ time.Time) (Identity, error) {
+	if auth == nil || strings.TrimSpace(auth.APIKey) == "" {
+		return Identity{}, ErrNoCredentials
+	}
+	e, ok := k.byDigest[KeyDigest(strings.TrimSpace(auth.APIKey))]
+	if !ok {
+		return Identity{}, ErrBadCredentials
+	}
+	return Identity{Subject: "key:" + e.Subject, Name: e.Name}, nil
+}
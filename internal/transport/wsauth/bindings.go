@@ -0,0 +1,102 @@
+package wsauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type bindingsFile struct {
+	Bindings map[string]string `json:"bindings"`
+}
+
+// Bindings persists identity subject -> agent id so an identity keeps its agent across restarts.
+type Bindings struct {
+	path string
+
+	mu sync.RWMutex
+	m  map[string]string
+}
+
+// OpenBindings loads the binding file; a missing file is an empty set.
+func OpenBindings(path string) (*Bindings, error) {
+	b := &Bindings{path: path, m: map[string]string{}}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return b, nil
+		}
+		return nil, err
+	}
+	var f bindingsFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, fmt.Errorf("parse bindings file: %w", err)
+	}
+	for subject, agentID := range f.Bindings {
+		if strings.TrimSpace(subject) != "" && strings.TrimSpace(agentID) != "" {
+			b.m[subject] = agentID
+		}
+	}
+	return b, nil
+}
+
+func (b *Bindings) AgentID(subject string) string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.m[subject]
+}
+
+// BoundElsewhere reports whether agentID is bound to a subject other than subject.
+func (b *Bindings) BoundElsewhere(agentID, subject string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s, id := range b.m {
+		if id == agentID && s != subject {
+			return true
+		}
+	}
+	return false
+}
+
+// Bind records subject -> agentID and persists it. An agent id may only belong to one subject.
+func (b *Bindings) Bind(subject, agentID string) error {
+	if strings.TrimSpace(subject) == "" || strings.TrimSpace(agentID) == "" {
+		return fmt.Errorf("missing subject/agent_id")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.m[subject] == agentID {
+		return nil
+	}
+	for s, id := range b.m {
+		if id == agentID && s != subject {
+			return fmt.Errorf("agent %s already bound to %s", agentID, s)
+		}
+	}
+	prev, had := b.m[subject]
+	b.m[subject] = agentID
+	if b.path == "" {
+		return nil
+	}
+	if err := writeJSONAtomic(b.path, bindingsFile{Bindings: b.m}); err != nil {
+		if had {
+			b.m[subject] = prev
+		} else {
+			delete(b.m, subject)
+		}
+		return err
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,74 @@
+package wsauth
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"voxelcraft.ai/internal/hmacsig"
+	"voxelcraft.ai/internal/protocol"
+)
+
+// MaxClockSkew matches the MCP sidecar's x-ts window.
+const MaxClockSkew = 5 * time.Minute
+
+const tokenHeader = `{"alg":"HS256","typ":"VCT"}`
+
+type TokenClaims struct {
+	Sub  string `json:"sub"`
+	Name string `json:"name,omitempty"`
+	Iat  int64  `json:"iat"` // unix seconds
+	Exp  int64  `json:"exp"` // unix seconds
+}
+
+// SignToken builds a JWT-style token: b64url(header).b64url(claims).hex(HMAC-SHA256).
+// The signature is the same hex HMAC-SHA256 the MCP sidecar uses for request signing.
+func SignToken(secret []byte, c TokenClaims) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	signing := base64.RawURLEncoding.EncodeToString([]byte(tokenHeader)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signing + "." + hmacsig.Sign(secret, signing), nil
+}
+
+type HMACTokens struct {
+	Secret []byte
+}
+
+func (t HMACTokens) Authenticate(auth *protocol.HelloAuth, now time.Time) (Identity, error) {
+	if auth == nil || strings.TrimSpace(auth.Bearer) == "" {
+		return Identity{}, ErrNoCredentials
+	}
+	if len(t.Secret) == 0 {
+		return Identity{}, ErrBadCredentials
+	}
+	parts := strings.Split(strings.TrimSpace(auth.Bearer), ".")
+	if len(parts) != 3 {
+		return Identity{}, ErrBadCredentials
+	}
+	want := hmacsig.Sign(t.Secret, parts[0]+"."+parts[1])
+	if !hmac.Equal([]byte(strings.ToLower(parts[2])), []byte(want)) {
+		return Identity{}, ErrBadCredentials
+	}
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || string(header) != tokenHeader {
+		return Identity{}, ErrBadCredentials
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Identity{}, ErrBadCredentials
+	}
+	var c TokenClaims
+	if err := json.Unmarshal(payload, &c); err != nil || strings.TrimSpace(c.Sub) == "" || c.Exp == 0 {
+		return Identity{}, ErrBadCredentials
+	}
+	skew := int64(MaxClockSkew / time.Second)
+	nowS := now.Unix()
+	if c.Iat > nowS+skew || nowS > c.Exp+skew {
+		return Identity{}, ErrExpired
+	}
+	return Identity{Subject: "tok:" + strings.TrimSpace(c.Sub), Name: c.Name}, nil
+}
@@ -0,0 +1,63 @@
+// Package wsauth authenticates agent WebSocket HELLOs and binds identities to stable agent ids.
+package wsauth
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"voxelcraft.ai/internal/protocol"
+)
+
+var (
+	// ErrNoCredentials means the HELLO carried no credential for this backend.
+	ErrNoCredentials  = errors.New("missing credentials")
+	ErrBadCredentials = errors.New("bad credentials")
+	ErrExpired        = errors.New("credentials expired")
+)
+
+// Identity is an authenticated principal. Subject is stable across restarts and is
+// prefixed by backend ("key:", "tok:", "acct:") so backends cannot collide.
+type Identity struct {
+	Subject string
+	Name    string
+}
+
+type Authenticator interface {
+	Authenticate(auth *protocol.HelloAuth, now time.Time) (Identity, error)
+}
+
+// Chain tries each backend in order. A backend answering ErrNoCredentials is skipped;
+// any other error rejects the HELLO.
+type Chain []Authenticator
+
+func (c Chain) Authenticate(auth *protocol.HelloAuth, now time.Time) (Identity, error) {
+	for _, a := range c {
+		if a == nil {
+			continue
+		}
+		id, err := a.Authenticate(auth, now)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return id, err
+	}
+	return Identity{}, ErrNoCredentials
+}
+
+func writeJSONAtomic(path string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,116 @@
+package wsauth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"voxelcraft.ai/internal/protocol"
+)
+
+func TestAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	body := `{"keys":[{"subject":"bot1","name":"Bot One","key_sha256":"` + KeyDigest("secret-1") + `"}]}`
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	k, err := LoadAPIKeys(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	id, err := k.Authenticate(&protocol.HelloAuth{APIKey: "secret-1"}, time.Now())
+	if err != nil || id.Subject != "key:bot1" || id.Name != "Bot One" {
+		t.Fatalf("id=%+v err=%v", id, err)
+	}
+	if _, err := k.Authenticate(&protocol.HelloAuth{APIKey: "nope"}, time.Now()); !errors.Is(err, ErrBadCredentials) {
+		t.Fatalf("want bad credentials, got %v", err)
+	}
+	if _, err := k.Authenticate(&protocol.HelloAuth{}, time.Now()); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("want no credentials, got %v", err)
+	}
+}
+
+func TestHMACTokens(t *testing.T) {
+	secret := []byte("s3cret")
+	now := time.Unix(1_700_000_000, 0)
+	tok, err := SignToken(secret, TokenClaims{Sub: "u1", Name: "U", Iat: now.Unix(), Exp: now.Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := HMACTokens{Secret: secret}
+	id, err := a.Authenticate(&protocol.HelloAuth{Bearer: tok}, now)
+	if err != nil || id.Subject != "tok:u1" || id.Name != "U" {
+		t.Fatalf("id=%+v err=%v", id, err)
+	}
+	if _, err := a.Authenticate(&protocol.HelloAuth{Bearer: tok}, now.Add(2*time.Hour)); !errors.Is(err, ErrExpired) {
+		t.Fatalf("want expired, got %v", err)
+	}
+	if _, err := (HMACTokens{Secret: []byte("other")}).Authenticate(&protocol.HelloAuth{Bearer: tok}, now); !errors.Is(err, ErrBadCredentials) {
+		t.Fatalf("want bad credentials for wrong secret, got %v", err)
+	}
+	tampered := tok[:len(tok)-1] + "0"
+	if tampered == tok {
+		tampered = tok[:len(tok)-1] + "1"
+	}
+	if _, err := a.Authenticate(&protocol.HelloAuth{Bearer: tampered}, now); !errors.Is(err, ErrBadCredentials) {
+		t.Fatalf("want bad credentials for tampered token, got %v", err)
+	}
+}
+
+func TestAccountsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	s, err := OpenAccounts(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetPassword("alice", "Alice", "pw"); err != nil {
+		t.Fatal(err)
+	}
+	s2, err := OpenAccounts(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := s2.Authenticate(&protocol.HelloAuth{Account: "alice", Password: "pw"}, time.Now())
+	if err != nil || id.Subject != "acct:alice" || id.Name != "Alice" {
+		t.Fatalf("id=%+v err=%v", id, err)
+	}
+	if _, err := s2.Authenticate(&protocol.HelloAuth{Account: "alice", Password: "bad"}, time.Now()); !errors.Is(err, ErrBadCredentials) {
+		t.Fatalf("want bad credentials, got %v", err)
+	}
+}
+
+func TestChainSkipsMissingBackends(t *testing.T) {
+	s, _ := OpenAccounts("")
+	_ = s.SetPassword("bob", "", "pw")
+	c := Chain{HMACTokens{Secret: []byte("x")}, s}
+	id, err := c.Authenticate(&protocol.HelloAuth{Account: "bob", Password: "pw"}, time.Now())
+	if err != nil || id.Subject != "acct:bob" || id.Name != "bob" {
+		t.Fatalf("id=%+v err=%v", id, err)
+	}
+	if _, err := c.Authenticate(&protocol.HelloAuth{}, time.Now()); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("want no credentials, got %v", err)
+	}
+}
+
+func TestBindingsPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ws_identities.json")
+	b, err := OpenBindings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Bind("key:bot1", "A7"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Bind("key:bot2", "A7"); err == nil {
+		t.Fatalf("expected conflict binding A7 to a second subject")
+	}
+	b2, err := OpenBindings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b2.AgentID("key:bot1"); got != "A7" {
+		t.Fatalf("AgentID=%q want A7", got)
+	}
+}